@@ -20,6 +20,7 @@ limitations under the License.
 #include <linux/bpf.h>
 
 #define DNS_CAPTURE_PORT_FLAG (1 << 1)
+#define DNS_CAPTURE_PORT6_FLAG (1 << 2)
 
 // get_current_cgroup_info return 1 if succeed, 0 for error
 static inline int get_current_cgroup_info(void *ctx,
@@ -35,6 +36,7 @@ static inline int get_current_cgroup_info(void *ctx,
                 .id = cgroup_id,
                 .is_in_mesh = 0,
                 .cgroup_ip = {0, 0, 0, 0},
+                .cgroup_ip6 = {0, 0, 0, 0},
                 .flags = 0,
                 .detected_flags = 0,
         };
@@ -54,12 +56,13 @@ static inline int get_current_cgroup_info(void *ctx,
             if (s) {
                 __u32 curr_ip_mark = s->mark;
                 bpf_sk_release(s);
-                __u32 *ip = (__u32 *) bpf_map_lookup_elem(&osm_mark_fib,
-                                                          &curr_ip_mark);
-                if (!ip) {
+                struct netns_ips *ips = (struct netns_ips *) bpf_map_lookup_elem(
+                        &osm_mark_fib, &curr_ip_mark);
+                if (!ips) {
                     debugf("get ip for mark 0x%x error", curr_ip_mark);
                 } else {
-                    set_ipv6(_default.cgroup_ip, ip); // network order
+                    set_ipv6(_default.cgroup_ip, ips->ip);   // network order
+                    set_ipv6(_default.cgroup_ip6, ips->ip6); // network order
                 }
             }
         }
@@ -99,9 +102,45 @@ static inline int is_port_listen_in_cgroup(void *ctx, __u16 is_tcp, __u32 ip,
     } else {
         listen = is_port_listen_udp_current_ns(ctx, ip, port);
     }
-    cg_info.detected_flags |= port_flag;
-    if (listen)
+    // only a listening port is cached, the sidecar may not be ready yet when
+    // the app starts.
+    if (listen) {
+        cg_info.detected_flags |= port_flag;
         cg_info.flags |= port_flag;
-    bpf_map_update_elem(&osm_cgr_fib, &cg_info.id, &cg_info, BPF_ANY);
+        bpf_map_update_elem(&osm_cgr_fib, &cg_info.id, &cg_info, BPF_ANY);
+    }
+    return listen;
+}
+
+// is_port_listen_in_cgroup6 is the ipv6 version of is_port_listen_in_cgroup.
+static inline int is_port_listen_in_cgroup6(void *ctx, __u16 is_tcp, __u32 *ip,
+                                            __u16 port, __u16 port_flag) {
+    struct cgroup_info cg_info;
+    if (!get_current_cgroup_info(ctx, &cg_info)) {
+        return 0;
+    }
+    if (!cg_info.is_in_mesh) {
+        return 0;
+    }
+    if (cg_info.detected_flags & port_flag) {
+        if (cg_info.flags & port_flag) {
+            return 1;
+        }
+        return 0;
+    }
+    // need detect
+    int listen;
+    if (is_tcp) {
+        listen = is_port_listen_current_ns6(ctx, ip, port);
+    } else {
+        listen = is_port_listen_udp_current_ns6(ctx, ip, port);
+    }
+    // only a listening port is cached, the sidecar may not be ready yet when
+    // the app starts.
+    if (listen) {
+        cg_info.detected_flags |= port_flag;
+        cg_info.flags |= port_flag;
+        bpf_map_update_elem(&osm_cgr_fib, &cg_info.id, &cg_info, BPF_ANY);
+    }
     return listen;
 }
@@ -0,0 +1,189 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+#pragma once
+
+#include "cgroup.h"
+#include "helpers.h"
+#include "maps.h"
+#include "mesh.h"
+#include "trace.h"
+#include <linux/bpf.h>
+
+#define DNS_PORT 53
+
+// The DNS queries of the apps are redirected to the local DNS proxy of the
+// sidecar, like the DNAT rule of the iptables mode does. The queries are
+// redirected only if the proxy is listening in the pod, and the original
+// destination is kept in osm_cki_fib so that recvmsg can restore the source
+// of the replies.
+//
+// recvmsg only sees the address of the proxy, so the source of the replies can
+// only be restored for one DNS server per socket: the queries a socket sends to
+// another DNS server than the first one redirected are not redirected.
+//
+// Only the outbound exclusion lists of the pod are respected: the inclusion
+// lists select the traffic the sidecar proxies, which is TCP only, so an
+// inclusion list must not disable the DNS proxy.
+
+// record_dns_origin records the DNS server ip and port, network order, as the
+// original destination of the queries of the socket of the given cookie. It
+// returns 0 if the socket already queried another DNS server.
+static inline int record_dns_origin(__u64 cookie, __u32 *ip, __u16 port) {
+    struct origin_info *recorded = bpf_map_lookup_elem(&osm_cki_fib, &cookie);
+    if (!recorded) {
+        struct origin_info origin;
+        memset(&origin, 0, sizeof(origin));
+        set_ipv6(origin.ip, ip);
+        origin.port = port;
+        if (!bpf_map_update_elem(&osm_cki_fib, &cookie, &origin, BPF_NOEXIST)) {
+            return 1;
+        }
+        // another query of the socket recorded its destination concurrently.
+        recorded = bpf_map_lookup_elem(&osm_cki_fib, &cookie);
+        if (!recorded) {
+            printk("update cookie origin failed");
+            return 0;
+        }
+    }
+    return ipv6_equal(recorded->ip, ip) && recorded->port == port;
+}
+
+// redirect_dns4 redirects a DNS query of an app to 127.0.0.153:5300, it is
+// called by connect4 for connected sockets and by sendmsg4 for the others.
+static inline void redirect_dns4(struct bpf_sock_addr *ctx) {
+    if (bpf_htons(ctx->user_port) != DNS_PORT) {
+        return;
+    }
+    struct cgroup_info cg_info;
+    if (!get_current_cgroup_info(ctx, &cg_info)) {
+        return;
+    }
+    if (!cg_info.is_in_mesh) {
+        return;
+    }
+    __u64 uid = bpf_get_current_uid_gid() & 0xffffffff;
+    if (uid == SIDECAR_USER_ID) {
+        // the upstream queries of the DNS proxy.
+        return;
+    }
+    if (!is_port_listen_in_cgroup(ctx, 0, dns_capture_ip, DNS_CAPTURE_PORT,
+                                  DNS_CAPTURE_PORT_FLAG)) {
+        // the DNS proxy of the sidecar is not enabled.
+        return;
+    }
+    __u32 dst_ip[4];
+    set_ipv4(dst_ip, ctx->user_ip4);
+    __u16 dst_port = ctx->user_port;
+    struct pod_config *pod = bpf_map_lookup_elem(&osm_pod_fib, cg_info.cgroup_ip);
+    if (pod) {
+        int exclude = 0;
+        IS_EXCLUDE_PORT(pod->exclude_out_ports, ctx->user_port, &exclude);
+        if (exclude) {
+            debugf("redirect_dns4: ignored by exclude_out_ports");
+            trace_decision(ctx, cg_info.cgroup_ip, dst_ip, dst_port,
+                           TRACE_BYPASS, TRACE_REASON_EXCLUDE_OUT_PORT);
+            return;
+        }
+        IS_EXCLUDE_IPRANGES(pod->exclude_out_ranges, dst_ip, &exclude);
+        if (exclude) {
+            debugf("redirect_dns4: ignored by exclude_out_ranges, ip: %pI4",
+                   &dst_ip[3]);
+            trace_decision(ctx, cg_info.cgroup_ip, dst_ip, dst_port,
+                           TRACE_BYPASS, TRACE_REASON_EXCLUDE_OUT_RANGE);
+            return;
+        }
+    }
+    __u64 cookie = bpf_get_socket_cookie_addr(ctx);
+    if (!record_dns_origin(cookie, dst_ip, dst_port)) {
+        debugf("redirect_dns4: socket queries another DNS server, ip: %pI4",
+               &dst_ip[3]);
+        trace_decision(ctx, cg_info.cgroup_ip, dst_ip, dst_port,
+                       TRACE_BYPASS, TRACE_REASON_DNS_MULTI_SERVER);
+        return;
+    }
+    ctx->user_ip4 = dns_capture_ip;
+    ctx->user_port = bpf_htons(DNS_CAPTURE_PORT);
+    debugf("redirect_dns4 [App->DNS Proxy]: dst ip: %pI4", &dst_ip[3]);
+    trace_decision(ctx, cg_info.cgroup_ip, dst_ip, dst_port,
+                   TRACE_APP_TO_DNS_PROXY, TRACE_REASON_NONE);
+}
+
+// redirect_dns6 redirects a DNS query of an app to [::1]:5300. The kernel does
+// not allow to rewrite the destination of an ipv6 socket to an ipv4-mapped
+// address, so the query is only redirected if the DNS proxy also listens on
+// ::1.
+static inline void redirect_dns6(struct bpf_sock_addr *ctx) {
+    if (bpf_htons(ctx->user_port) != DNS_PORT) {
+        return;
+    }
+    struct cgroup_info cg_info;
+    if (!get_current_cgroup_info(ctx, &cg_info)) {
+        return;
+    }
+    if (!cg_info.is_in_mesh) {
+        return;
+    }
+    __u64 uid = bpf_get_current_uid_gid() & 0xffffffff;
+    if (uid == SIDECAR_USER_ID) {
+        // the upstream queries of the DNS proxy.
+        return;
+    }
+    if (!is_port_listen_in_cgroup6(ctx, 0, (__u32 *) localhost6,
+                                   DNS_CAPTURE_PORT, DNS_CAPTURE_PORT6_FLAG)) {
+        // the DNS proxy of the sidecar is not enabled.
+        return;
+    }
+    // user_ip6 can only be read with 4 bytes loads.
+    __u32 dst_ip[4];
+    dst_ip[0] = ctx->user_ip6[0];
+    dst_ip[1] = ctx->user_ip6[1];
+    dst_ip[2] = ctx->user_ip6[2];
+    dst_ip[3] = ctx->user_ip6[3];
+    __u16 dst_port = ctx->user_port;
+    struct pod_config *pod = bpf_map_lookup_elem(&osm_pod_fib, cg_info.cgroup_ip6);
+    if (pod) {
+        int exclude = 0;
+        IS_EXCLUDE_PORT(pod->exclude_out_ports, ctx->user_port, &exclude);
+        if (exclude) {
+            debugf("redirect_dns6: ignored by exclude_out_ports");
+            trace_decision(ctx, cg_info.cgroup_ip6, dst_ip, dst_port,
+                           TRACE_BYPASS, TRACE_REASON_EXCLUDE_OUT_PORT);
+            return;
+        }
+        IS_EXCLUDE_IPRANGES(pod->exclude_out_ranges, dst_ip, &exclude);
+        if (exclude) {
+            debugf("redirect_dns6: ignored by exclude_out_ranges, ip: %pI6c",
+                   dst_ip);
+            trace_decision(ctx, cg_info.cgroup_ip6, dst_ip, dst_port,
+                           TRACE_BYPASS, TRACE_REASON_EXCLUDE_OUT_RANGE);
+            return;
+        }
+    }
+    __u64 cookie = bpf_get_socket_cookie_addr(ctx);
+    if (!record_dns_origin(cookie, dst_ip, dst_port)) {
+        debugf("redirect_dns6: socket queries another DNS server, ip: %pI6c",
+               dst_ip);
+        trace_decision(ctx, cg_info.cgroup_ip6, dst_ip, dst_port,
+                       TRACE_BYPASS, TRACE_REASON_DNS_MULTI_SERVER);
+        return;
+    }
+    ctx->user_ip6[0] = localhost6[0];
+    ctx->user_ip6[1] = localhost6[1];
+    ctx->user_ip6[2] = localhost6[2];
+    ctx->user_ip6[3] = localhost6[3];
+    ctx->user_port = bpf_htons(DNS_CAPTURE_PORT);
+    debugf("redirect_dns6 [App->DNS Proxy]: dst ip: %pI6c", dst_ip);
+    trace_decision(ctx, cg_info.cgroup_ip6, dst_ip, dst_port,
+                   TRACE_APP_TO_DNS_PROXY, TRACE_REASON_NONE);
+}
@@ -105,6 +105,13 @@ static long (*bpf_msg_redirect_hash)(
 static long (*bpf_bind)(struct bpf_sock_addr *ctx, struct sockaddr_in *addr,
                         int addr_len) = (void *) BPF_FUNC_bind;
 
+static long (*bpf_bind6)(struct bpf_sock_addr *ctx, struct sockaddr_in6 *addr,
+                         int addr_len) = (void *) BPF_FUNC_bind;
+
+static long (*bpf_perf_event_output)(void *ctx, struct bpf_elf_map *map,
+                                     __u64 flags, void *data, __u64 size) =
+        (void *) BPF_FUNC_perf_event_output;
+
 static long (*bpf_l4_csum_replace)(struct __sk_buff *skb, __u32 offset,
                                    __u64 from, __u64 to, __u64 flags) = (void *)
         BPF_FUNC_l4_csum_replace;
@@ -247,6 +254,9 @@ struct cgroup_info {
     __u64 id;
     __u32 is_in_mesh;
     __u32 cgroup_ip[4];
+    // cgroup_ip6 is the ipv6 address of the pod, all zero if the pod has no
+    // ipv6 address.
+    __u32 cgroup_ip6[4];
     // We can't specify which ports are listened to here, so we open up a flags,
     // user-defined. E.g, for those who wish to determine if port 15001 is
     // listened to, we can customize a flag, `IS_LISTEN_15001 = 1 << 2`, which
@@ -260,17 +270,43 @@ struct cgroup_info {
     __u16 detected_flags;
 };
 
+// netns_ips stores the ip addresses of a pod network namespace, which can be
+// set by controller with the mark of the socket listening on SOCK_IP_MARK_PORT.
+struct netns_ips {
+    __u32 ip[4];  // ipv4, all zero if the pod has no ipv4 address
+    __u32 ip6[4]; // ipv6, all zero if the pod has no ipv6 address
+};
+
 #define MAX_ITEM_LEN 10
 
+// cidr stores ipv4 networks like ips, in the last 4 bytes of net, with a
+// mask offset by 96 bits, so that ipv4 and ipv6 networks can be matched in the
+// same way.
 struct cidr {
-    __u32 net; // network order
+    __u32 net[4]; // network order
     __u8 mask;
     __u8 __pad[3];
 };
 
-static inline int is_in_cidr(struct cidr *c, __u32 ip) {
-    return (bpf_htonl(c->net) >> (32 - c->mask)) ==
-           bpf_htonl(ip) >> (32 - c->mask);
+static inline int is_cidr_set(struct cidr *c) {
+    return c->net[0] != 0 || c->net[1] != 0 || c->net[2] != 0 ||
+           c->net[3] != 0;
+}
+
+static inline int is_in_cidr(struct cidr *c, __u32 *ip) {
+    __u32 mask = c->mask;
+#pragma unroll
+    for (int i = 0; i < 4; i++) {
+        if (mask == 0) {
+            break;
+        }
+        __u32 bits = mask >= 32 ? 0xffffffff : ~(0xffffffff >> mask);
+        if ((bpf_htonl(c->net[i]) & bits) != (bpf_htonl(ip[i]) & bits)) {
+            return 0;
+        }
+        mask = mask >= 32 ? mask - 32 : 0;
+    }
+    return 1;
 }
 
 struct pod_config {
@@ -298,7 +334,7 @@ struct pod_config {
 #define IS_EXCLUDE_IPRANGES(ITEM, IP, RET)                                     \
     do {                                                                       \
         *RET = 0;                                                              \
-        for (int i = 0; i < MAX_ITEM_LEN && ITEM[i].net != 0; i++) {           \
+        for (int i = 0; i < MAX_ITEM_LEN && is_cidr_set(&ITEM[i]); i++) {      \
             if (is_in_cidr(&ITEM[i], IP)) {                                    \
                 *RET = 1;                                                      \
                 break;                                                         \
@@ -324,8 +360,8 @@ struct pod_config {
 #define IS_INCLUDE_IPRANGES(ITEM, IP, RET)                                     \
     do {                                                                       \
         *RET = 0;                                                              \
-        if (ITEM[0].net != 0) {                                                \
-            for (int i = 0; i < MAX_ITEM_LEN && ITEM[i].net != 0; i++) {       \
+        if (is_cidr_set(&ITEM[0])) {                                           \
+            for (int i = 0; i < MAX_ITEM_LEN && is_cidr_set(&ITEM[i]); i++) {  \
                 if (is_in_cidr(&ITEM[i], IP)) {                                \
                     *RET = 1;                                                  \
                     break;                                                     \
@@ -39,7 +39,7 @@ struct bpf_elf_map __section("maps") osm_pod_fib = {
 struct bpf_elf_map __section("maps") osm_proc_fib = {
         .type = BPF_MAP_TYPE_LRU_HASH,
         .size_key = sizeof(__u32),
-        .size_value = sizeof(__u32),
+        .size_value = sizeof(__u32) * 4,
         .max_elem = 1024,
 };
 
@@ -67,9 +67,22 @@ struct bpf_elf_map __section("maps") osm_sock_fib = {
         .max_elem = 65535,
 };
 
+// osm_mark_fib stores the ip addresses of pods, keyed by the mark of the
+// socket listening on SOCK_IP_MARK_PORT in the pod.
 struct bpf_elf_map __section("maps") osm_mark_fib = {
         .type = BPF_MAP_TYPE_HASH,
         .size_key = sizeof(__u32),
-        .size_value = sizeof(__u32) * 4,
+        .size_value = sizeof(struct netns_ips),
         .max_elem = 65535,
 };
+
+// osm_trace_events streams the redirect decisions of the connect programs to
+// the interceptor, see struct trace_event. max_elem must not be less than the
+// number of cpus, it is set to the number of cpus when created by Makefile.
+struct bpf_elf_map __section("maps") osm_trace_events = {
+        .type = BPF_MAP_TYPE_PERF_EVENT_ARRAY,
+        .size_key = sizeof(__u32),
+        .size_value = sizeof(__u32),
+        .max_elem = 128,
+        .pinning = PIN_GLOBAL_NS,
+};
@@ -26,11 +26,15 @@ limitations under the License.
 #define SIDECAR_USER_ID 1500
 #endif
 
+// DNS_CAPTURE_PORT is the port of the local DNS proxy of the sidecar
 #ifndef DNS_CAPTURE_PORT
-#define DNS_CAPTURE_PORT 15053
+#define DNS_CAPTURE_PORT 5300
 #endif
 
 // 127.0.0.6 (network order)
 static const __u32 sidecar_ip = 127 + (6 << 24);
 // ::6 (network order)
 static const __u32 sidecar_ip6[4] = {0, 0, 0, 6 << 24};
+// 127.0.0.153 (network order), the address of the local DNS proxy of the
+// sidecar, which is the nameserver of the pods when it is enabled.
+static const __u32 dns_capture_ip = 127 + (153 << 24);
@@ -0,0 +1,72 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+#pragma once
+
+#include "helpers.h"
+#include "maps.h"
+#include <linux/bpf.h>
+
+// redirect decisions, keep in sync with pkg/cni/controller/trace.go
+#define TRACE_BYPASS 0
+#define TRACE_APP_TO_SIDECAR 1
+#define TRACE_SIDECAR_TO_APP 2
+#define TRACE_SIDECAR_TO_SIDECAR 3
+#define TRACE_APP_TO_DNS_PROXY 4
+
+// reasons of the bypass decisions
+#define TRACE_REASON_NONE 0
+#define TRACE_REASON_LOCALHOST 1
+#define TRACE_REASON_EXCLUDE_OUT_PORT 2
+#define TRACE_REASON_EXCLUDE_OUT_RANGE 3
+#define TRACE_REASON_NOT_INCLUDE_OUT_PORT 4
+#define TRACE_REASON_NOT_INCLUDE_OUT_RANGE 5
+#define TRACE_REASON_DST_NOT_IN_NODE 6
+#define TRACE_REASON_EXCLUDE_IN_PORT 7
+#define TRACE_REASON_NOT_INCLUDE_IN_PORT 8
+#define TRACE_REASON_DNS_MULTI_SERVER 9
+
+// trace_event is a redirect decision of the connect programs, ipv4 addresses
+// are stored in the last 4 bytes like the maps do.
+struct trace_event {
+    __u32 src_ip[4];      // ip of the current pod, all zero if unknown
+    __u32 dst_ip[4];      // original destination ip
+    __u16 dst_port;       // original destination port, network order
+    __u16 redirect_port;  // redirected destination port, network order, 0 if bypassed
+    __u32 pid;
+    __u8 decision;
+    __u8 reason;
+    __u8 family;
+    __u8 __pad;
+};
+
+// trace_decision emits a redirect decision to osm_trace_events, events are
+// dropped by the kernel when the interceptor is not reading them.
+static inline void trace_decision(struct bpf_sock_addr *ctx, __u32 *src_ip,
+                                  __u32 *dst_ip, __u16 dst_port, __u8 decision,
+                                  __u8 reason) {
+    struct trace_event ev;
+    memset(&ev, 0, sizeof(ev));
+    set_ipv6(ev.src_ip, src_ip);
+    set_ipv6(ev.dst_ip, dst_ip);
+    ev.dst_port = dst_port;
+    if (decision != TRACE_BYPASS) {
+        ev.redirect_port = ctx->user_port;
+    }
+    ev.pid = bpf_get_current_pid_tgid() >> 32;
+    ev.decision = decision;
+    ev.reason = reason;
+    ev.family = ctx->user_family;
+    bpf_perf_event_output(ctx, &osm_trace_events, BPF_F_CURRENT_CPU, &ev,
+                          sizeof(ev));
+}
@@ -12,9 +12,11 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 #include "headers/cgroup.h"
+#include "headers/dns.h"
 #include "headers/helpers.h"
 #include "headers/maps.h"
 #include "headers/mesh.h"
+#include "headers/trace.h"
 #include <linux/bpf.h>
 #include <linux/in.h>
 
@@ -40,11 +42,16 @@ static inline int tcp_connect4(struct bpf_sock_addr *ctx) {
     }
     __u64 uid = bpf_get_current_uid_gid() & 0xffffffff;
     __u32 dst_ip = ctx->user_ip4;
+    __u32 _dst_ip[4];
+    set_ipv4(_dst_ip, dst_ip);
+    __u16 dst_port = ctx->user_port;
     debugf("tcp_connect4 uid: %d pod ip: %pI4 dst ip: %pI4", uid, &curr_pod_ip, &dst_ip);
     if (uid != SIDECAR_USER_ID) {
         if ((dst_ip & 0xff) == 0x7f) {
             debugf("tcp_connect4 [App->Local]: bypass");
             // app call local, bypass.
+            trace_decision(ctx, _curr_pod_ip, _dst_ip, dst_port, TRACE_BYPASS,
+                           TRACE_REASON_LOCALHOST);
             return 1;
         }
         __u64 cookie = bpf_get_socket_cookie_addr(ctx);
@@ -72,15 +79,19 @@ static inline int tcp_connect4(struct bpf_sock_addr *ctx) {
                     debugf("ignored dest port by exclude_out_ports, ip: "
                            "%pI4, port: %d",
                            &curr_pod_ip, bpf_htons(ctx->user_port));
+                    trace_decision(ctx, _curr_pod_ip, _dst_ip, dst_port,
+                                   TRACE_BYPASS, TRACE_REASON_EXCLUDE_OUT_PORT);
                     return 1;
                 }
-                IS_EXCLUDE_IPRANGES(pod->exclude_out_ranges, dst_ip, &exclude);
+                IS_EXCLUDE_IPRANGES(pod->exclude_out_ranges, _dst_ip, &exclude);
                 debugf("exclude ipranges: %x, exclude: %d",
-                       pod->exclude_out_ranges[0].net, exclude);
+                       pod->exclude_out_ranges[0].net[3], exclude);
                 if (exclude) {
                     debugf(
                             "ignored dest ranges by exclude_out_ranges, ip: %pI4",
                             &dst_ip);
+                    trace_decision(ctx, _curr_pod_ip, _dst_ip, dst_port,
+                                   TRACE_BYPASS, TRACE_REASON_EXCLUDE_OUT_RANGE);
                     return 1;
                 }
                 int include = 0;
@@ -90,14 +101,20 @@ static inline int tcp_connect4(struct bpf_sock_addr *ctx) {
                     debugf("dest port %d not in pod(%pI4)'s include_out_ports, "
                            "ignored.",
                            bpf_htons(ctx->user_port), &curr_pod_ip);
+                    trace_decision(ctx, _curr_pod_ip, _dst_ip, dst_port,
+                                   TRACE_BYPASS,
+                                   TRACE_REASON_NOT_INCLUDE_OUT_PORT);
                     return 1;
                 }
 
-                IS_INCLUDE_IPRANGES(pod->include_out_ranges, dst_ip, &include);
+                IS_INCLUDE_IPRANGES(pod->include_out_ranges, _dst_ip, &include);
                 if (!include) {
                     debugf("dest %pI4 not in pod(%pI4)'s include_out_ranges, "
                            "ignored.",
                            &dst_ip, &curr_pod_ip);
+                    trace_decision(ctx, _curr_pod_ip, _dst_ip, dst_port,
+                                   TRACE_BYPASS,
+                                   TRACE_REASON_NOT_INCLUDE_OUT_RANGE);
                     return 1;
                 }
             } else {
@@ -154,10 +171,10 @@ static inline int tcp_connect4(struct bpf_sock_addr *ctx) {
         __u32 rewrite_dst_ip = ctx->user_ip4;
         debugf("tcp_connect4 [App->Sidecar]: rewrite dst ip: %pI4, redirect dst port: %d",
                &rewrite_dst_ip, bpf_htons(ctx->user_port));
+        trace_decision(ctx, _curr_pod_ip, _dst_ip, dst_port,
+                       TRACE_APP_TO_SIDECAR, TRACE_REASON_NONE);
     } else {
         // from sidecar to others
-        __u32 _dst_ip[4];
-        set_ipv4(_dst_ip, dst_ip);
         struct pod_config *pod = bpf_map_lookup_elem(&osm_pod_fib, _dst_ip);
         if (!pod) {
             debugf("tcp_connect4 [Sidecar->Sidecar]: uid: %d", uid);
@@ -165,6 +182,8 @@ static inline int tcp_connect4(struct bpf_sock_addr *ctx) {
             debugf("tcp_connect4 [Sidecar->Sidecar]: dst pod ip: %pI4 dst port: %d", &dst_ip, bpf_htons(ctx->user_port));
             // dst ip is not in this node, bypass
             debugf("tcp_connect4 dest ip: %pI4 not in this node, bypass", &dst_ip);
+            trace_decision(ctx, _curr_pod_ip, _dst_ip, dst_port, TRACE_BYPASS,
+                           TRACE_REASON_DST_NOT_IN_NODE);
             return 1;
         }
 
@@ -174,6 +193,7 @@ static inline int tcp_connect4(struct bpf_sock_addr *ctx) {
         memset(&origin, 0, sizeof(origin));
         set_ipv4(origin.ip, dst_ip);
         origin.port = ctx->user_port;
+        __u8 decision = TRACE_SIDECAR_TO_APP;
 
         debugf("tcp_connect4 [Sidecar->Sidecar]: uid: %d", uid);
         debugf("tcp_connect4 [Sidecar->Sidecar]: cur pod ip: %pI4", &curr_pod_ip);
@@ -189,6 +209,8 @@ static inline int tcp_connect4(struct bpf_sock_addr *ctx) {
                     debugf("ignored dest port by exclude_in_ports, ip: %pI4, "
                            "port: %d",
                            &dst_ip, bpf_htons(ctx->user_port));
+                    trace_decision(ctx, _curr_pod_ip, _dst_ip, dst_port,
+                                   TRACE_BYPASS, TRACE_REASON_EXCLUDE_IN_PORT);
                     return 1;
                 }
                 int include = 0;
@@ -198,9 +220,13 @@ static inline int tcp_connect4(struct bpf_sock_addr *ctx) {
                     debugf("ignored dest port by include_in_ports, ip: %pI4, "
                            "port: %d",
                            &dst_ip, bpf_htons(ctx->user_port));
+                    trace_decision(ctx, _curr_pod_ip, _dst_ip, dst_port,
+                                   TRACE_BYPASS,
+                                   TRACE_REASON_NOT_INCLUDE_IN_PORT);
                     return 1;
                 }
                 ctx->user_port = bpf_htons(IN_REDIRECT_PORT);
+                decision = TRACE_SIDECAR_TO_SIDECAR;
             }
             origin.flags |= 1;
         } else {
@@ -218,10 +244,11 @@ static inline int tcp_connect4(struct bpf_sock_addr *ctx) {
                    bpf_htons(ctx->user_port));
             if (curr_ip) {
                 // sidecar to other sidecar
-                if (*(__u32 * )curr_ip != dst_ip) {
+                if (get_ipv4((__u32 *) curr_ip) != dst_ip) {
                     debugf("tcp_connect4 sidecar to other, rewrite dst port from %d to %d",
                            bpf_htons(ctx->user_port), IN_REDIRECT_PORT);
                     ctx->user_port = bpf_htons(IN_REDIRECT_PORT);
+                    decision = TRACE_SIDECAR_TO_SIDECAR;
                 }
                 //origin.flags |= 1;
                 origin.flags = 0;
@@ -241,6 +268,7 @@ static inline int tcp_connect4(struct bpf_sock_addr *ctx) {
                 debugf("tcp_connect4 [Sidecar->Others{Sidecar}]: sidecar to sidecar, rewrite dst port from %d to %d",
                        bpf_htons(ctx->user_port), IN_REDIRECT_PORT);
                 ctx->user_port = bpf_htons(IN_REDIRECT_PORT);
+                decision = TRACE_SIDECAR_TO_SIDECAR;
                 //debugf("tcp_connect4 [Sidecar->Others{Sidecar}]: sidecar to sidecar, dst port: %d", bpf_htons(ctx->user_port));
             }
         }
@@ -251,6 +279,229 @@ static inline int tcp_connect4(struct bpf_sock_addr *ctx) {
             printk("update cookie origin failed");
             return 0;
         }
+        trace_decision(ctx, _curr_pod_ip, _dst_ip, dst_port, decision,
+                       TRACE_REASON_NONE);
+    }
+
+    return 1;
+}
+
+static inline int tcp_connect6(struct bpf_sock_addr *ctx) {
+    struct cgroup_info cg_info;
+    if (!get_current_cgroup_info(ctx, &cg_info)) {
+        return 1;
+    }
+    if (!cg_info.is_in_mesh) {
+        // bypass normal traffic. we only deal pod's
+        // traffic managed by mesh.
+        return 1;
+    }
+    __u32 curr_pod_ip[4];
+    set_ipv6(curr_pod_ip, cg_info.cgroup_ip6);
+    int has_curr_pod_ip = !ipv6_equal(curr_pod_ip, (__u32 *) ip_zero6);
+
+    if (!has_curr_pod_ip) {
+        debugf("get current pod ip6 error");
+    }
+    __u64 uid = bpf_get_current_uid_gid() & 0xffffffff;
+    // user_ip6 can only be read with 4 bytes loads.
+    __u32 dst_ip[4];
+    dst_ip[0] = ctx->user_ip6[0];
+    dst_ip[1] = ctx->user_ip6[1];
+    dst_ip[2] = ctx->user_ip6[2];
+    dst_ip[3] = ctx->user_ip6[3];
+    __u16 dst_port = ctx->user_port;
+    debugf("tcp_connect6 uid: %d pod ip: %pI6c dst ip: %pI6c", uid, curr_pod_ip, dst_ip);
+    if (uid != SIDECAR_USER_ID) {
+        if (ipv6_equal(dst_ip, (__u32 *) localhost6)) {
+            debugf("tcp_connect6 [App->Local]: bypass");
+            // app call local, bypass.
+            trace_decision(ctx, curr_pod_ip, dst_ip, dst_port, TRACE_BYPASS,
+                           TRACE_REASON_LOCALHOST);
+            return 1;
+        }
+        __u64 cookie = bpf_get_socket_cookie_addr(ctx);
+        // app call other app
+        debugf("tcp_connect6 [App->App]: dst ip: %pI6c dst port: %d", dst_ip,
+               bpf_htons(ctx->user_port));
+
+        // we need redirect it to sidecar.
+        struct origin_info origin;
+        memset(&origin, 0, sizeof(origin));
+        set_ipv6(origin.ip, dst_ip);
+        origin.port = ctx->user_port;
+        origin.flags = 1;
+        if (bpf_map_update_elem(&osm_cki_fib, &cookie, &origin, BPF_ANY)) {
+            debugf("write osm_cki_fib failed");
+            return 0;
+        }
+        if (has_curr_pod_ip) {
+            struct pod_config *pod = bpf_map_lookup_elem(&osm_pod_fib, curr_pod_ip);
+            if (pod) {
+                int exclude = 0;
+                IS_EXCLUDE_PORT(pod->exclude_out_ports, ctx->user_port,
+                                &exclude);
+                if (exclude) {
+                    debugf("ignored dest port by exclude_out_ports, ip: "
+                           "%pI6c, port: %d",
+                           curr_pod_ip, bpf_htons(ctx->user_port));
+                    trace_decision(ctx, curr_pod_ip, dst_ip, dst_port,
+                                   TRACE_BYPASS, TRACE_REASON_EXCLUDE_OUT_PORT);
+                    return 1;
+                }
+                IS_EXCLUDE_IPRANGES(pod->exclude_out_ranges, dst_ip, &exclude);
+                if (exclude) {
+                    debugf(
+                            "ignored dest ranges by exclude_out_ranges, ip: %pI6c",
+                            dst_ip);
+                    trace_decision(ctx, curr_pod_ip, dst_ip, dst_port,
+                                   TRACE_BYPASS, TRACE_REASON_EXCLUDE_OUT_RANGE);
+                    return 1;
+                }
+                int include = 0;
+                IS_INCLUDE_PORT(pod->include_out_ports, ctx->user_port,
+                                &include);
+                if (!include) {
+                    debugf("dest port %d not in pod(%pI6c)'s include_out_ports, "
+                           "ignored.",
+                           bpf_htons(ctx->user_port), curr_pod_ip);
+                    trace_decision(ctx, curr_pod_ip, dst_ip, dst_port,
+                                   TRACE_BYPASS,
+                                   TRACE_REASON_NOT_INCLUDE_OUT_PORT);
+                    return 1;
+                }
+
+                IS_INCLUDE_IPRANGES(pod->include_out_ranges, dst_ip, &include);
+                if (!include) {
+                    debugf("dest %pI6c not in pod(%pI6c)'s include_out_ranges, "
+                           "ignored.",
+                           dst_ip, curr_pod_ip);
+                    trace_decision(ctx, curr_pod_ip, dst_ip, dst_port,
+                                   TRACE_BYPASS,
+                                   TRACE_REASON_NOT_INCLUDE_OUT_RANGE);
+                    return 1;
+                }
+            } else {
+                debugf("current pod ip found(%pI6c), but can not find pod_info "
+                       "from osm_pod_fib",
+                       curr_pod_ip);
+            }
+            // bind the pod's ip as the source ip to avoid quaternions conflict
+            // of different pods, see tcp_connect4.
+            struct sockaddr_in6 addr = {
+                    .sin6_family = 10,
+                    .sin6_port = 0,
+                    .sin6_flowinfo = 0,
+                    .sin6_addr.in6_u.u6_addr32 = {curr_pod_ip[0], curr_pod_ip[1],
+                                                  curr_pod_ip[2], curr_pod_ip[3]},
+                    .sin6_scope_id = 0,
+            };
+            if (bpf_bind6(ctx, &addr, sizeof(struct sockaddr_in6))) {
+                debugf("bind %pI6c error", curr_pod_ip);
+            }
+        } else {
+            debugf("curr_pod_ip6 false");
+            // unlike ipv4, there is no loopback segment in ipv6, we can only
+            // rewrite the dest address to ::1.
+        }
+        ctx->user_ip6[0] = localhost6[0];
+        ctx->user_ip6[1] = localhost6[1];
+        ctx->user_ip6[2] = localhost6[2];
+        ctx->user_ip6[3] = localhost6[3];
+        ctx->user_port = bpf_htons(OUT_REDIRECT_PORT);
+        debugf("tcp_connect6 [App->Sidecar]: rewrite dst ip: %pI6c, redirect dst port: %d",
+               localhost6, bpf_htons(ctx->user_port));
+        trace_decision(ctx, curr_pod_ip, dst_ip, dst_port, TRACE_APP_TO_SIDECAR,
+                       TRACE_REASON_NONE);
+    } else {
+        // from sidecar to others
+        struct pod_config *pod = bpf_map_lookup_elem(&osm_pod_fib, dst_ip);
+        if (!pod) {
+            // dst ip is not in this node, bypass
+            debugf("tcp_connect6 dest ip: %pI6c not in this node, bypass", dst_ip);
+            trace_decision(ctx, curr_pod_ip, dst_ip, dst_port, TRACE_BYPASS,
+                           TRACE_REASON_DST_NOT_IN_NODE);
+            return 1;
+        }
+
+        // dst ip is in this node, but not the current pod,
+        // it is sidecar to sidecar connecting.
+        struct origin_info origin;
+        memset(&origin, 0, sizeof(origin));
+        set_ipv6(origin.ip, dst_ip);
+        origin.port = ctx->user_port;
+        __u8 decision = TRACE_SIDECAR_TO_APP;
+
+        debugf("tcp_connect6 [Sidecar->Sidecar]: uid: %d", uid);
+        debugf("tcp_connect6 [Sidecar->Sidecar]: cur pod ip: %pI6c", curr_pod_ip);
+        debugf("tcp_connect6 [Sidecar->Sidecar]: dst pod ip: %pI6c dst port: %d", dst_ip, bpf_htons(ctx->user_port));
+
+        if (has_curr_pod_ip) {
+            if (!ipv6_equal(curr_pod_ip, dst_ip)) {
+                // call other pod, need redirect port.
+                int exclude = 0;
+                IS_EXCLUDE_PORT(pod->exclude_in_ports, ctx->user_port,
+                                &exclude);
+                if (exclude) {
+                    debugf("ignored dest port by exclude_in_ports, ip: %pI6c, "
+                           "port: %d",
+                           dst_ip, bpf_htons(ctx->user_port));
+                    trace_decision(ctx, curr_pod_ip, dst_ip, dst_port,
+                                   TRACE_BYPASS, TRACE_REASON_EXCLUDE_IN_PORT);
+                    return 1;
+                }
+                int include = 0;
+                IS_INCLUDE_PORT(pod->include_in_ports, ctx->user_port,
+                                &include);
+                if (!include) {
+                    debugf("ignored dest port by include_in_ports, ip: %pI6c, "
+                           "port: %d",
+                           dst_ip, bpf_htons(ctx->user_port));
+                    trace_decision(ctx, curr_pod_ip, dst_ip, dst_port,
+                                   TRACE_BYPASS,
+                                   TRACE_REASON_NOT_INCLUDE_IN_PORT);
+                    return 1;
+                }
+                ctx->user_port = bpf_htons(IN_REDIRECT_PORT);
+                decision = TRACE_SIDECAR_TO_SIDECAR;
+            }
+            origin.flags |= 1;
+        } else {
+            // can not get current pod ip, we use the legacy mode,
+            // see tcp_connect4.
+            __u32 pid = bpf_get_current_pid_tgid() >> 32; // tgid
+            __u32 *curr_ip = bpf_map_lookup_elem(&osm_proc_fib, &pid);
+            debugf("tcp_connect6 [Sidecar->Others]: pid: %d, dst ip: %pI6c dst port:%d", pid, dst_ip,
+                   bpf_htons(ctx->user_port));
+            if (curr_ip) {
+                // sidecar to other sidecar
+                if (!ipv6_equal(curr_ip, dst_ip)) {
+                    debugf("tcp_connect6 sidecar to other, rewrite dst port from %d to %d",
+                           bpf_htons(ctx->user_port), IN_REDIRECT_PORT);
+                    ctx->user_port = bpf_htons(IN_REDIRECT_PORT);
+                    decision = TRACE_SIDECAR_TO_SIDECAR;
+                }
+                origin.flags = 0;
+                origin.pid = pid;
+            } else {
+                origin.flags = 0;
+                origin.pid = pid;
+                // sidecar to sidecar, try redirect to 15003
+                debugf("tcp_connect6 [Sidecar->Others{Sidecar}]: sidecar to sidecar, rewrite dst port from %d to %d",
+                       bpf_htons(ctx->user_port), IN_REDIRECT_PORT);
+                ctx->user_port = bpf_htons(IN_REDIRECT_PORT);
+                decision = TRACE_SIDECAR_TO_SIDECAR;
+            }
+        }
+        __u64 cookie = bpf_get_socket_cookie_addr(ctx);
+        debugf("tcp_connect6 [Sidecar->Others]: call from sidecar container: cookie: %d, dst ip: %pI6c, dst port: %d",
+               cookie, dst_ip, bpf_htons(ctx->user_port));
+        if (bpf_map_update_elem(&osm_cki_fib, &cookie, &origin, BPF_NOEXIST)) {
+            printk("update cookie origin failed");
+            return 0;
+        }
+        trace_decision(ctx, curr_pod_ip, dst_ip, dst_port, decision,
+                       TRACE_REASON_NONE);
     }
 
     return 1;
@@ -260,6 +511,23 @@ __section("cgroup/connect4") int osm_cni_group_connect4(struct bpf_sock_addr *ct
     switch (ctx->protocol) {
         case IPPROTO_TCP:
             return tcp_connect4(ctx);
+        case IPPROTO_UDP:
+            // the DNS queries of connected sockets, e.g. the queries of glibc.
+            redirect_dns4(ctx);
+            return 1;
+        default:
+            return 1;
+    }
+}
+
+__section("cgroup/connect6") int osm_cni_group_connect6(struct bpf_sock_addr *ctx) {
+    switch (ctx->protocol) {
+        case IPPROTO_TCP:
+            return tcp_connect6(ctx);
+        case IPPROTO_UDP:
+            // the DNS queries of connected sockets, e.g. the queries of glibc.
+            redirect_dns6(ctx);
+            return 1;
         default:
             return 1;
     }
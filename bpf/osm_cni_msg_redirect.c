@@ -27,6 +27,17 @@ __section("sk_msg") int osm_cni_msg_redirect(struct sk_msg_md *msg) {
             set_ipv4(p.dip, msg->local_ip4);
             set_ipv4(p.sip, msg->remote_ip4);
             break;
+        case 10:
+            // ipv6, can only be read with 4 bytes loads.
+            p.dip[0] = msg->local_ip6[0];
+            p.dip[1] = msg->local_ip6[1];
+            p.dip[2] = msg->local_ip6[2];
+            p.dip[3] = msg->local_ip6[3];
+            p.sip[0] = msg->remote_ip6[0];
+            p.sip[1] = msg->remote_ip6[1];
+            p.sip[2] = msg->remote_ip6[2];
+            p.sip[3] = msg->remote_ip6[3];
+            break;
     }
 
     __u32 local_ip4 = get_ipv4(p.dip);
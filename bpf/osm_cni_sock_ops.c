@@ -41,18 +41,16 @@ static inline int sockops_ipv4(struct bpf_sock_ops *skops) {
             if (skops->local_ip4 == sidecar_ip ||
                 skops->local_ip4 == skops->remote_ip4) {
                 // sidecar to local
-                __u32 ip = skops->remote_ip4;
-                debugf("sockops_ipv4 [Sidecar->Local] detected process %d's ip is %pI4", pid, &ip);
-                bpf_map_update_elem(&osm_proc_fib, &pid, &ip, BPF_ANY);
+                debugf("sockops_ipv4 [Sidecar->Local] detected process %d's ip is %pI4", pid, &p.dip[3]);
+                bpf_map_update_elem(&osm_proc_fib, &pid, p.dip, BPF_ANY);
                 if (skops->remote_port >> 16 == bpf_htons(IN_REDIRECT_PORT)) {
                     printk("incorrect connection: cookie=%d", cookie);
                     return 1;
                 }
             } else {
                 // sidecar to sidecar
-                __u32 ip = skops->local_ip4;
-                bpf_map_update_elem(&osm_proc_fib, &pid, &ip, BPF_ANY);
-                debugf("sockops_ipv4 [Sidecar->Sidecar] detected process %d's ip is %pI4", pid, &ip);
+                bpf_map_update_elem(&osm_proc_fib, &pid, p.sip, BPF_ANY);
+                debugf("sockops_ipv4 [Sidecar->Sidecar] detected process %d's ip is %pI4", pid, &p.sip[3]);
             }
         }
         // get_sockopts can read pid and cookie,
@@ -67,6 +65,58 @@ static inline int sockops_ipv4(struct bpf_sock_ops *skops) {
     return 0;
 }
 
+static inline int sockops_ipv6(struct bpf_sock_ops *skops) {
+    __u64 cookie = bpf_get_socket_cookie_ops(skops);
+
+    // local_ip6 and remote_ip6 can only be read with 4 bytes loads.
+    struct pair p;
+    memset(&p, 0, sizeof(p));
+    p.sip[0] = skops->local_ip6[0];
+    p.sip[1] = skops->local_ip6[1];
+    p.sip[2] = skops->local_ip6[2];
+    p.sip[3] = skops->local_ip6[3];
+    p.sport = bpf_htons(skops->local_port);
+    p.dip[0] = skops->remote_ip6[0];
+    p.dip[1] = skops->remote_ip6[1];
+    p.dip[2] = skops->remote_ip6[2];
+    p.dip[3] = skops->remote_ip6[3];
+    p.dport = skops->remote_port >> 16;
+
+    struct origin_info *dst = bpf_map_lookup_elem(&osm_cki_fib, &cookie);
+    if (dst) {
+        struct origin_info dd = *dst;
+        if (!(dd.flags & 1)) {
+            debugf("sockops_ipv6 remote_port:%d -> local_port: %d (%d)", bpf_htons(p.dport), p.sport,
+                   skops->local_port);
+            debugf("sockops_ipv6 remote_ip6: %pI6c -> local_ip6: %pI6c", p.dip, p.sip);
+
+            __u32 pid = dd.pid;
+            // process ip not detected
+            if (ipv6_equal(p.sip, (__u32 *) sidecar_ip6) ||
+                ipv6_equal(p.sip, p.dip)) {
+                // sidecar to local
+                debugf("sockops_ipv6 [Sidecar->Local] detected process %d's ip is %pI6c", pid, p.dip);
+                bpf_map_update_elem(&osm_proc_fib, &pid, p.dip, BPF_ANY);
+                if (skops->remote_port >> 16 == bpf_htons(IN_REDIRECT_PORT)) {
+                    printk("incorrect connection: cookie=%d", cookie);
+                    return 1;
+                }
+            } else {
+                // sidecar to sidecar
+                bpf_map_update_elem(&osm_proc_fib, &pid, p.sip, BPF_ANY);
+                debugf("sockops_ipv6 [Sidecar->Sidecar] detected process %d's ip is %pI6c", pid, p.sip);
+            }
+        }
+        bpf_map_update_elem(&osm_nat_fib, &p, &dd, BPF_ANY);
+        bpf_sock_hash_update(skops, &osm_sock_fib, &p, BPF_NOEXIST);
+    } else if (skops->local_port == OUT_REDIRECT_PORT ||
+               skops->local_port == IN_REDIRECT_PORT ||
+               ipv6_equal(p.dip, (__u32 *) sidecar_ip6)) {
+        bpf_sock_hash_update(skops, &osm_sock_fib, &p, BPF_NOEXIST);
+    }
+    return 0;
+}
+
 __section("sockops") int osm_cni_sock_ops(struct bpf_sock_ops *skops) {
     switch (skops->op) {
         case BPF_SOCK_OPS_PASSIVE_ESTABLISHED_CB:
@@ -76,6 +126,9 @@ __section("sockops") int osm_cni_sock_ops(struct bpf_sock_ops *skops) {
                     // AF_INET, we don't include socket.h, because it may
                     // cause an import error.
                     return sockops_ipv4(skops);
+                case 10:
+                    // AF_INET6
+                    return sockops_ipv6(skops);
             }
             return 0;
     }
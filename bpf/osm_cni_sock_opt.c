@@ -84,6 +84,44 @@ __section("cgroup/getsockopt") int osm_cni_sock_opt(struct bpf_sockopt *ctx) {
                 //debugf("osm_cni_sock_opt rewite_origin_ip4:127.0.0.1  rewite_origin_port: %d", 5000);
             }
             break;
+        case 10: // ipv6
+            p.dip[0] = ctx->sk->src_ip6[0];
+            p.dip[1] = ctx->sk->src_ip6[1];
+            p.dip[2] = ctx->sk->src_ip6[2];
+            p.dip[3] = ctx->sk->src_ip6[3];
+            p.sip[0] = ctx->sk->dst_ip6[0];
+            p.sip[1] = ctx->sk->dst_ip6[1];
+            p.sip[2] = ctx->sk->dst_ip6[2];
+            p.sip[3] = ctx->sk->dst_ip6[3];
+            debugf("osm_cni_sock_opt src ip6: %pI6c src port: %d", p.sip, p.sport);
+            debugf("osm_cni_sock_opt dst ip6: %pI6c dst port: %d", p.dip, bpf_htons(p.dport));
+            origin = bpf_map_lookup_elem(&osm_nat_fib, &p);
+            if (origin) {
+                // rewrite original_dst, IP6T_SO_ORIGINAL_DST has the same
+                // optname as SO_ORIGINAL_DST.
+                ctx->optlen = (__s32)
+                sizeof(struct sockaddr_in6);
+                if ((void *) ((struct sockaddr_in6 *) ctx->optval + 1) >
+                    ctx->optval_end) {
+                    printk("optname: %d: invalid getsockopt optval", ctx->optname);
+                    return 1;
+                }
+                ctx->retval = 0;
+                struct sockaddr_in6 sa = {
+                        .sin6_family = ctx->sk->family,
+                        .sin6_port = origin->port,
+                        .sin6_flowinfo = 0,
+                        .sin6_addr.in6_u.u6_addr32 = {origin->ip[0], origin->ip[1],
+                                                      origin->ip[2], origin->ip[3]},
+                        .sin6_scope_id = 0,
+                };
+                *(struct sockaddr_in6 *) ctx->optval = sa;
+                debugf("osm_cni_sock_opt origin dst ip6:%pI6c origin dst port: %d", origin->ip,
+                       bpf_htons(origin->port));
+            } else {
+                debugf("osm_cni_sock_opt osm_nat_fib:NOT FOUND");
+            }
+            break;
     }
     return 1;
 }
@@ -18,6 +18,7 @@ limitations under the License.
 #include <linux/if_ether.h>
 #include <linux/in.h>
 #include <linux/ip.h>
+#include <linux/ipv6.h>
 #include <linux/pkt_cls.h>
 #include <linux/tcp.h>
 #include <stddef.h>
@@ -58,6 +59,22 @@ __section("classifier_ingress") int osm_cni_tc_dnat(struct __sk_buff *skb) {
             dport_off = ETH_HLEN + sizeof(struct iphdr) + offsetof(struct tcphdr, dest);
             break;
         }
+        case ETH_P_IPV6: {
+            struct ipv6hdr *ip6h = (struct ipv6hdr *) (eth + 1);
+            if ((void *) (ip6h + 1) > data_end) {
+                return TC_ACT_SHOT;
+            }
+            // ipv6 extension headers are not supported.
+            if (ip6h->nexthdr != IPPROTO_TCP) {
+                return TC_ACT_OK;
+            }
+            set_ipv6(src_ip, ip6h->saddr.in6_u.u6_addr32);
+            set_ipv6(dst_ip, ip6h->daddr.in6_u.u6_addr32);
+            tcph = (struct tcphdr *) (ip6h + 1);
+            csum_off = ETH_HLEN + sizeof(struct ipv6hdr) + offsetof(struct tcphdr, check);
+            dport_off = ETH_HLEN + sizeof(struct ipv6hdr) + offsetof(struct tcphdr, dest);
+            break;
+        }
         default:
             return TC_ACT_OK;
     }
@@ -191,6 +208,22 @@ __section("classifier_egress") int osm_cni_tc_snat(struct __sk_buff *skb) {
             sport_off = ETH_HLEN + sizeof(struct iphdr) + offsetof(struct tcphdr, source);
             break;
         }
+        case ETH_P_IPV6: {
+            struct ipv6hdr *ip6h = (struct ipv6hdr *) (eth + 1);
+            if ((void *) (ip6h + 1) > data_end) {
+                return TC_ACT_SHOT;
+            }
+            // ipv6 extension headers are not supported.
+            if (ip6h->nexthdr != IPPROTO_TCP) {
+                return TC_ACT_OK;
+            }
+            set_ipv6(src_ip, ip6h->saddr.in6_u.u6_addr32);
+            set_ipv6(dst_ip, ip6h->daddr.in6_u.u6_addr32);
+            tcph = (struct tcphdr *) (ip6h + 1);
+            csum_off = ETH_HLEN + sizeof(struct ipv6hdr) + offsetof(struct tcphdr, check);
+            sport_off = ETH_HLEN + sizeof(struct ipv6hdr) + offsetof(struct tcphdr, source);
+            break;
+        }
         default:
             return TC_ACT_OK;
     }
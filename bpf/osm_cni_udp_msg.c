@@ -0,0 +1,78 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+#include "headers/dns.h"
+#include "headers/helpers.h"
+#include "headers/maps.h"
+#include "headers/mesh.h"
+#include <linux/bpf.h>
+#include <linux/in.h>
+
+// sendmsg redirects the DNS queries of unconnected udp sockets to the DNS
+// proxy of the sidecar, the queries of connected sockets are redirected by
+// connect.
+__section("cgroup/sendmsg4") int osm_cni_sendmsg4(struct bpf_sock_addr *ctx) {
+    redirect_dns4(ctx);
+    return 1;
+}
+
+__section("cgroup/sendmsg6") int osm_cni_sendmsg6(struct bpf_sock_addr *ctx) {
+    redirect_dns6(ctx);
+    return 1;
+}
+
+// recvmsg restores the source of the DNS replies of the proxy to the original
+// destination of the queries, otherwise the resolvers drop the replies.
+__section("cgroup/recvmsg4") int osm_cni_recvmsg4(struct bpf_sock_addr *ctx) {
+    if (ctx->user_ip4 != dns_capture_ip ||
+        bpf_htons(ctx->user_port) != DNS_CAPTURE_PORT) {
+        return 1;
+    }
+    __u64 cookie = bpf_get_socket_cookie_addr(ctx);
+    struct origin_info *origin = bpf_map_lookup_elem(&osm_cki_fib, &cookie);
+    if (!origin) {
+        debugf("osm_cni_recvmsg4: origin of cookie %d not found", cookie);
+        return 1;
+    }
+    ctx->user_ip4 = get_ipv4(origin->ip);
+    ctx->user_port = origin->port;
+    return 1;
+}
+
+__section("cgroup/recvmsg6") int osm_cni_recvmsg6(struct bpf_sock_addr *ctx) {
+    // user_ip6 can only be read with 4 bytes loads.
+    __u32 src_ip[4];
+    src_ip[0] = ctx->user_ip6[0];
+    src_ip[1] = ctx->user_ip6[1];
+    src_ip[2] = ctx->user_ip6[2];
+    src_ip[3] = ctx->user_ip6[3];
+    if (!ipv6_equal(src_ip, (__u32 *) localhost6) ||
+        bpf_htons(ctx->user_port) != DNS_CAPTURE_PORT) {
+        return 1;
+    }
+    __u64 cookie = bpf_get_socket_cookie_addr(ctx);
+    struct origin_info *origin = bpf_map_lookup_elem(&osm_cki_fib, &cookie);
+    if (!origin) {
+        debugf("osm_cni_recvmsg6: origin of cookie %d not found", cookie);
+        return 1;
+    }
+    ctx->user_ip6[0] = origin->ip[0];
+    ctx->user_ip6[1] = origin->ip[1];
+    ctx->user_ip6[2] = origin->ip[2];
+    ctx->user_ip6[3] = origin->ip[3];
+    ctx->user_port = origin->port;
+    return 1;
+}
+
+char ____license[] __section("license") = "GPL";
+int _version __section("version") = 1;
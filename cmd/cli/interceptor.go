@@ -0,0 +1,27 @@
+package main
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+	"helm.sh/helm/v3/pkg/action"
+)
+
+const interceptorCmdDescription = `
+This command consists of subcommands related to the operations
+of osm-interceptor, which intercepts the traffic of the meshed
+pods with eBPF programs on each node.
+`
+
+func newInterceptorCmd(config *action.Configuration, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "interceptor",
+		Short: "osm-interceptor operations",
+		Long:  interceptorCmdDescription,
+		Args:  cobra.NoArgs,
+	}
+	cmd.AddCommand(newInterceptorMapsCmd(config, out))
+	cmd.AddCommand(newInterceptorTraceCmd(config, out))
+
+	return cmd
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/spf13/cobra"
+	"helm.sh/helm/v3/pkg/action"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/openservicemesh/osm/pkg/cli"
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+var interceptorMapsCmdDescription = fmt.Sprintf(`
+This command lists the entries of an eBPF map of osm-interceptor, per node.
+
+The following maps are supported:
+  pod_fib:  the interception policy of the meshed pods on the node, by pod IP
+  nat_fib:  the original destination of the redirected connections
+  cgr_fib:  the mesh membership and IP addresses cached for each cgroup
+  mark_fib: the IP addresses of the pod network namespaces, by socket mark
+
+The entries are read from the debug server of osm-interceptor, which listens on
+port %d unless disabled with the --debug-server-port flag of osm-interceptor.
+`, constants.InterceptorDebugPort)
+
+const interceptorMapsCmdExample = `
+# List the interception policies of the meshed pods on every node
+osm interceptor maps pod_fib
+
+# List the original destinations of the redirected connections on the node 'node-1' as YAML
+osm interceptor maps nat_fib --node node-1 -o yaml
+`
+
+type interceptorMapsCmd struct {
+	out          io.Writer
+	config       *rest.Config
+	clientSet    kubernetes.Interface
+	osmNamespace string
+	mapName      string
+	node         string
+	localPort    uint16
+	outputFormat string
+}
+
+func newInterceptorMapsCmd(config *action.Configuration, out io.Writer) *cobra.Command {
+	mapsCmd := &interceptorMapsCmd{
+		out: out,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "maps MAP",
+		Short: "list the entries of an eBPF map per node",
+		Long:  interceptorMapsCmdDescription,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			mapsCmd.mapName = args[0]
+			conf, err := config.RESTClientGetter.ToRESTConfig()
+			if err != nil {
+				return fmt.Errorf("Error fetching kubeconfig: %w", err)
+			}
+			mapsCmd.config = conf
+
+			clientset, err := kubernetes.NewForConfig(conf)
+			if err != nil {
+				return fmt.Errorf("Could not access Kubernetes cluster, check kubeconfig: %w", err)
+			}
+			mapsCmd.clientSet = clientset
+			mapsCmd.osmNamespace = settings.Namespace()
+			return mapsCmd.run()
+		},
+		Example: interceptorMapsCmdExample,
+	}
+
+	f := cmd.Flags()
+	f.StringVar(&mapsCmd.node, "node", "", "Node to list the map entries of, all the nodes by default")
+	f.Uint16VarP(&mapsCmd.localPort, "local-port", "p", constants.InterceptorDebugPort, "Local port to use for port forwarding")
+	f.StringVarP(&mapsCmd.outputFormat, "output", "o", outputFormatJSON, "Output format, one of json or yaml")
+
+	return cmd
+}
+
+func (cmd *interceptorMapsCmd) run() error {
+	if cmd.outputFormat != outputFormatJSON && cmd.outputFormat != outputFormatYAML {
+		return fmt.Errorf("Invalid output format %s, must be one of %s or %s", cmd.outputFormat, outputFormatJSON, outputFormatYAML)
+	}
+	if !cli.IsInterceptorMap(cmd.mapName) {
+		return fmt.Errorf("Invalid map %s, must be one of %s", cmd.mapName, strings.Join(cli.InterceptorMaps, ", "))
+	}
+
+	pods, err := cli.GetInterceptorPods(cmd.clientSet, cmd.osmNamespace, cmd.node)
+	if err != nil {
+		return err
+	}
+
+	// entries by node
+	entries := make(map[string][]interface{})
+	for _, pod := range pods {
+		nodeEntries, err := cli.GetInterceptorMap(cmd.clientSet, cmd.config, cmd.osmNamespace, pod.Name, cmd.localPort, cmd.mapName)
+		if err != nil {
+			return err
+		}
+		entries[pod.Spec.NodeName] = nodeEntries
+	}
+
+	var output []byte
+	if cmd.outputFormat == outputFormatYAML {
+		output, err = yaml.Marshal(entries)
+	} else {
+		output, err = json.MarshalIndent(entries, "", "  ")
+		output = append(output, '\n')
+	}
+	if err != nil {
+		return err
+	}
+	_, err = cmd.out.Write(output)
+	return err
+}
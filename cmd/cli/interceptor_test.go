@@ -0,0 +1,209 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openservicemesh/osm/pkg/cli"
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+func TestInterceptorMapsCmdValidation(t *testing.T) {
+	testCases := []struct {
+		name          string
+		mapName       string
+		outputFormat  string
+		expectedError string
+	}{
+		{
+			name:          "invalid output format",
+			mapName:       "pod_fib",
+			outputFormat:  "xml",
+			expectedError: "Invalid output format xml, must be one of json or yaml",
+		},
+		{
+			name:          "invalid map",
+			mapName:       "sock_fib",
+			outputFormat:  outputFormatJSON,
+			expectedError: "Invalid map sock_fib, must be one of pod_fib, nat_fib, cgr_fib, mark_fib",
+		},
+		{
+			name:          "no interceptor",
+			mapName:       "pod_fib",
+			outputFormat:  outputFormatJSON,
+			expectedError: "No running osm-interceptor pod found in namespace osm-system",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			cmd := &interceptorMapsCmd{
+				out:          new(bytes.Buffer),
+				clientSet:    fake.NewSimpleClientset(),
+				osmNamespace: "osm-system",
+				mapName:      tc.mapName,
+				outputFormat: tc.outputFormat,
+			}
+			assert.EqualError(cmd.run(), tc.expectedError)
+		})
+	}
+}
+
+func TestInterceptorTraceCmdGetNode(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "bookbuyer", Namespace: "bookbuyer"},
+		Spec:       corev1.PodSpec{NodeName: "node-1"},
+	}
+
+	testCases := []struct {
+		name          string
+		node          string
+		pod           string
+		expectedNode  string
+		expectedError string
+	}{
+		{
+			name:          "no node or pod",
+			expectedError: "One of --node or --pod must be specified",
+		},
+		{
+			name:         "node",
+			node:         "node-2",
+			expectedNode: "node-2",
+		},
+		{
+			name:         "node of the pod",
+			pod:          "bookbuyer",
+			expectedNode: "node-1",
+		},
+		{
+			name:          "pod on another node",
+			node:          "node-2",
+			pod:           "bookbuyer",
+			expectedError: "Pod bookbuyer in namespace bookbuyer is not on node node-2",
+		},
+		{
+			name:          "pod not found",
+			pod:           "bookstore",
+			expectedError: "Could not find pod bookstore in namespace bookbuyer",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			clientSet := fake.NewSimpleClientset()
+			_, err := clientSet.CoreV1().Pods(pod.Namespace).Create(context.TODO(), pod, metav1.CreateOptions{})
+			assert.NoError(err)
+
+			cmd := &interceptorTraceCmd{
+				out:       new(bytes.Buffer),
+				clientSet: clientSet,
+				node:      tc.node,
+				namespace: "bookbuyer",
+				pod:       tc.pod,
+			}
+			node, err := cmd.getNode()
+			if tc.expectedError != "" {
+				assert.EqualError(err, tc.expectedError)
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(tc.expectedNode, node)
+		})
+	}
+}
+
+func TestInterceptorTraceCmdWrite(t *testing.T) {
+	testCases := []struct {
+		name         string
+		outputFormat string
+		event        cli.InterceptorTraceEvent
+		expected     string
+	}{
+		{
+			name:         "app to sidecar",
+			outputFormat: outputFormatText,
+			event: cli.InterceptorTraceEvent{
+				SrcIP: "10.244.1.12", DstIP: "10.96.0.20", DstPort: 14001, RedirectPort: 15001, PID: 42, Decision: "app-to-sidecar",
+			},
+			expected: "[node-1] app-to-sidecar     pid=42 10.244.1.12 -> 10.96.0.20:14001 redirected to port 15001\n",
+		},
+		{
+			name:         "bypass",
+			outputFormat: outputFormatText,
+			event: cli.InterceptorTraceEvent{
+				SrcIP: "fd00::12", DstIP: "fd00::20", DstPort: 6379, PID: 42, Decision: "bypass", Reason: "outbound port excluded",
+			},
+			expected: "[node-1] bypass             pid=42 fd00::12 -> [fd00::20]:6379 (outbound port excluded)\n",
+		},
+		{
+			name:         "lost",
+			outputFormat: outputFormatText,
+			event:        cli.InterceptorTraceEvent{Lost: 3},
+			expected:     "[node-1] lost 3 redirect decisions\n",
+		},
+		{
+			name:         "json",
+			outputFormat: outputFormatJSON,
+			event: cli.InterceptorTraceEvent{
+				DstIP: "10.244.1.13", DstPort: 14001, RedirectPort: 14001, PID: 7, Decision: "sidecar-to-app",
+			},
+			expected: `{"dstIP":"10.244.1.13","dstPort":14001,"redirectPort":14001,"pid":7,"decision":"sidecar-to-app"}` + "\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			out := new(bytes.Buffer)
+			cmd := &interceptorTraceCmd{
+				out:          out,
+				outputFormat: tc.outputFormat,
+			}
+			assert.NoError(cmd.write("node-1", tc.event))
+			assert.Equal(tc.expected, out.String())
+		})
+	}
+}
+
+func TestGetInterceptorPods(t *testing.T) {
+	assert := tassert.New(t)
+
+	clientSet := fake.NewSimpleClientset()
+	for _, node := range []string{"node-2", "node-1"} {
+		_, err := clientSet.CoreV1().Pods("osm-system").Create(context.TODO(), &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "osm-interceptor-" + node,
+				Namespace: "osm-system",
+				Labels:    map[string]string{constants.AppLabel: constants.OSMInterceptorName},
+			},
+			Spec:   corev1.PodSpec{NodeName: node},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		}, metav1.CreateOptions{})
+		assert.NoError(err)
+	}
+
+	pods, err := cli.GetInterceptorPods(clientSet, "osm-system", "")
+	assert.NoError(err)
+	assert.Len(pods, 2)
+	assert.Equal("node-1", pods[0].Spec.NodeName)
+
+	pods, err = cli.GetInterceptorPods(clientSet, "osm-system", "node-2")
+	assert.NoError(err)
+	assert.Len(pods, 1)
+	assert.Equal("osm-interceptor-node-2", pods[0].Name)
+
+	_, err = cli.GetInterceptorPods(clientSet, "osm-system", "node-3")
+	assert.EqualError(err, "No running osm-interceptor pod found on node node-3 in namespace osm-system")
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"helm.sh/helm/v3/pkg/action"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/openservicemesh/osm/pkg/cli"
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+const interceptorTraceCmdDescription = `
+This command streams the redirect decisions the eBPF programs of osm-interceptor
+take on a node when the processes of the meshed pods connect or send DNS queries,
+until interrupted:
+  bypass:             the connection is not redirected, the reason is displayed
+  app-to-sidecar:     the connection of an app is redirected to its sidecar
+  sidecar-to-app:     the connection of a sidecar to its app is not redirected
+  sidecar-to-sidecar: the connection of a sidecar is redirected to the inbound
+                      port of the sidecar of another pod on the node
+  app-to-dns-proxy:   the DNS query of an app is redirected to the local DNS
+                      proxy of its sidecar
+
+The node is the node of the pod given with --pod, which also restricts the
+decisions to the connections from or to the pod. Decisions the kernel drops
+because they are not read in time are reported as lost.
+`
+
+const interceptorTraceCmdExample = `
+# Stream the redirect decisions on the node 'node-1'
+osm interceptor trace --node node-1
+
+# Stream the redirect decisions of the pod 'bookbuyer-5ccf77f46d-rc5mg' in the 'bookbuyer' namespace
+osm interceptor trace --pod bookbuyer-5ccf77f46d-rc5mg -n bookbuyer
+
+# Stream the redirect decisions to or from the IP '10.244.1.12' on the node 'node-1' as JSON lines
+osm interceptor trace --node node-1 --ip 10.244.1.12 -o json
+`
+
+type interceptorTraceCmd struct {
+	out          io.Writer
+	config       *rest.Config
+	clientSet    kubernetes.Interface
+	osmNamespace string
+	node         string
+	namespace    string
+	pod          string
+	ip           string
+	localPort    uint16
+	outputFormat string
+}
+
+func newInterceptorTraceCmd(config *action.Configuration, out io.Writer) *cobra.Command {
+	traceCmd := &interceptorTraceCmd{
+		out: out,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "trace",
+		Short: "stream the redirect decisions on a node",
+		Long:  interceptorTraceCmdDescription,
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			conf, err := config.RESTClientGetter.ToRESTConfig()
+			if err != nil {
+				return fmt.Errorf("Error fetching kubeconfig: %w", err)
+			}
+			traceCmd.config = conf
+
+			clientset, err := kubernetes.NewForConfig(conf)
+			if err != nil {
+				return fmt.Errorf("Could not access Kubernetes cluster, check kubeconfig: %w", err)
+			}
+			traceCmd.clientSet = clientset
+			traceCmd.osmNamespace = settings.Namespace()
+			return traceCmd.run()
+		},
+		Example: interceptorTraceCmdExample,
+	}
+
+	f := cmd.Flags()
+	f.StringVar(&traceCmd.node, "node", "", "Node to stream the redirect decisions of")
+	f.StringVar(&traceCmd.pod, "pod", "", "Pod to stream the redirect decisions of")
+	f.StringVarP(&traceCmd.namespace, "namespace", "n", metav1.NamespaceDefault, "Namespace of the pod")
+	f.StringVar(&traceCmd.ip, "ip", "", "IP address to stream the redirect decisions of")
+	f.Uint16VarP(&traceCmd.localPort, "local-port", "p", constants.InterceptorDebugPort, "Local port to use for port forwarding")
+	f.StringVarP(&traceCmd.outputFormat, "output", "o", outputFormatText, "Output format, one of text or json")
+
+	return cmd
+}
+
+func (cmd *interceptorTraceCmd) run() error {
+	if cmd.outputFormat != outputFormatText && cmd.outputFormat != outputFormatJSON {
+		return fmt.Errorf("Invalid output format %s, must be one of %s or %s", cmd.outputFormat, outputFormatText, outputFormatJSON)
+	}
+
+	node, err := cmd.getNode()
+	if err != nil {
+		return err
+	}
+	pods, err := cli.GetInterceptorPods(cmd.clientSet, cmd.osmNamespace, node)
+	if err != nil {
+		return err
+	}
+
+	pod := ""
+	if cmd.pod != "" {
+		pod = cmd.namespace + "/" + cmd.pod
+	}
+	return cli.StreamInterceptorTrace(cmd.clientSet, cmd.config, cmd.osmNamespace, pods[0].Name, cmd.localPort, pod, cmd.ip,
+		func(ev cli.InterceptorTraceEvent) error {
+			return cmd.write(node, ev)
+		})
+}
+
+// getNode returns the node of the pod given with --pod, or the node given with --node
+func (cmd *interceptorTraceCmd) getNode() (string, error) {
+	if cmd.pod == "" {
+		if cmd.node == "" {
+			return "", fmt.Errorf("One of --node or --pod must be specified")
+		}
+		return cmd.node, nil
+	}
+
+	pod, err := cmd.clientSet.CoreV1().Pods(cmd.namespace).Get(context.TODO(), cmd.pod, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("Could not find pod %s in namespace %s", cmd.pod, cmd.namespace)
+	}
+	if cmd.node != "" && cmd.node != pod.Spec.NodeName {
+		return "", fmt.Errorf("Pod %s in namespace %s is not on node %s", cmd.pod, cmd.namespace, cmd.node)
+	}
+	if pod.Spec.NodeName == "" {
+		return "", fmt.Errorf("Pod %s in namespace %s is not scheduled", cmd.pod, cmd.namespace)
+	}
+	return pod.Spec.NodeName, nil
+}
+
+func (cmd *interceptorTraceCmd) write(node string, ev cli.InterceptorTraceEvent) error {
+	var line string
+	if cmd.outputFormat == outputFormatJSON {
+		b, err := json.Marshal(ev)
+		if err != nil {
+			return err
+		}
+		line = string(b)
+	} else {
+		line = cli.FormatInterceptorTraceEvent(node, ev)
+	}
+	_, err := fmt.Fprintln(cmd.out, strings.TrimSpace(line))
+	return err
+}
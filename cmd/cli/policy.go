@@ -20,6 +20,8 @@ func newPolicyCmd(stdout io.Writer, _ io.Writer) *cobra.Command {
 	}
 	cmd.AddCommand(newPolicyCheckPods(stdout))
 	cmd.AddCommand(newPolicyCheckConflicts(stdout))
+	cmd.AddCommand(newPolicyExplain(stdout))
+	cmd.AddCommand(newPolicyPreview(stdout))
 
 	return cmd
 }
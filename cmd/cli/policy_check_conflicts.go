@@ -19,11 +19,27 @@ import (
 
 const policyCheckConflictsDesc = `
 This command checks whether API resources of the same kind conflict.
+
+The following resource kinds are supported:
+- IngressBackend: backends specified by multiple resources in the same namespace
+- Retry: destinations specified by multiple resources for the same source
+- UpstreamTrafficSetting: hosts specified by multiple resources
+- EgressGateway: global egress gateways specified by multiple resources
+
+When resources conflict, only one of them is applied. IngressBackend conflicts
+are checked in a single namespace, other conflicts are checked across the given
+namespaces, or across all namespaces when none are given.
 `
 
 const policyCheckConflictsExample = `
 # To check if IngressBackend API resources conflict in the 'test' namespace
 osm policy check-conflicts IngressBackend -n test
+
+# To check if Retry API resources conflict in any namespace
+osm policy check-conflicts Retry
+
+# To check if UpstreamTrafficSetting API resources conflict in the 'test' and 'prod' namespaces
+osm policy check-conflicts UpstreamTrafficSetting -n test,prod
 `
 
 type policyCheckConflictsCmd struct {
@@ -75,6 +91,15 @@ func (cmd *policyCheckConflictsCmd) run() error {
 	case "ingressbackend":
 		err = cmd.checkIngressBackendConflict()
 
+	case "retry":
+		err = cmd.checkRetryConflict()
+
+	case "upstreamtrafficsetting":
+		err = cmd.checkUpstreamTrafficSettingConflict()
+
+	case "egressgateway":
+		err = cmd.checkEgressGatewayConflict()
+
 	default:
 		return fmt.Errorf("Invalid resource kind %s", cmd.resourceKind)
 	}
@@ -128,3 +153,118 @@ func (cmd *policyCheckConflictsCmd) checkIngressBackendConflict() error {
 
 	return nil
 }
+
+func (cmd *policyCheckConflictsCmd) checkRetryConflict() error {
+	var retries []policyv1alpha1.Retry
+	for _, ns := range cmd.conflictCheckNamespaces() {
+		retryList, err := cmd.policyClient.PolicyV1alpha1().Retries(ns).List(context.Background(), metav1.ListOptions{})
+		if err != nil {
+			return fmt.Errorf("Error listing Retry resources in %s: %w", describeConflictCheckNamespace(ns), err)
+		}
+		retries = append(retries, retryList.Items...)
+	}
+
+	conflictsExist := false
+	for i, x := range retries {
+		for _, y := range retries[i+1:] {
+			if cmd.printConflicts("Retry", &x.ObjectMeta, &y.ObjectMeta, policy.DetectRetryConflicts(x, y)) {
+				conflictsExist = true
+			}
+		}
+	}
+
+	if !conflictsExist {
+		fmt.Fprintf(cmd.stdout, "No conflicts among Retry resources in %s\n", cmd.describeConflictCheckNamespaces())
+	}
+
+	return nil
+}
+
+func (cmd *policyCheckConflictsCmd) checkUpstreamTrafficSettingConflict() error {
+	var upstreamTrafficSettings []policyv1alpha1.UpstreamTrafficSetting
+	for _, ns := range cmd.conflictCheckNamespaces() {
+		upstreamTrafficSettingList, err := cmd.policyClient.PolicyV1alpha1().UpstreamTrafficSettings(ns).List(context.Background(), metav1.ListOptions{})
+		if err != nil {
+			return fmt.Errorf("Error listing UpstreamTrafficSetting resources in %s: %w", describeConflictCheckNamespace(ns), err)
+		}
+		upstreamTrafficSettings = append(upstreamTrafficSettings, upstreamTrafficSettingList.Items...)
+	}
+
+	conflictsExist := false
+	for i, x := range upstreamTrafficSettings {
+		for _, y := range upstreamTrafficSettings[i+1:] {
+			if cmd.printConflicts("UpstreamTrafficSetting", &x.ObjectMeta, &y.ObjectMeta, policy.DetectUpstreamTrafficSettingConflicts(x, y)) {
+				conflictsExist = true
+			}
+		}
+	}
+
+	if !conflictsExist {
+		fmt.Fprintf(cmd.stdout, "No conflicts among UpstreamTrafficSetting resources in %s\n", cmd.describeConflictCheckNamespaces())
+	}
+
+	return nil
+}
+
+func (cmd *policyCheckConflictsCmd) checkEgressGatewayConflict() error {
+	var egressGateways []policyv1alpha1.EgressGateway
+	for _, ns := range cmd.conflictCheckNamespaces() {
+		egressGatewayList, err := cmd.policyClient.PolicyV1alpha1().EgressGateways(ns).List(context.Background(), metav1.ListOptions{})
+		if err != nil {
+			return fmt.Errorf("Error listing EgressGateway resources in %s: %w", describeConflictCheckNamespace(ns), err)
+		}
+		egressGateways = append(egressGateways, egressGatewayList.Items...)
+	}
+
+	conflictsExist := false
+	for i, x := range egressGateways {
+		for _, y := range egressGateways[i+1:] {
+			if cmd.printConflicts("EgressGateway", &x.ObjectMeta, &y.ObjectMeta, policy.DetectEgressGatewayConflicts(x, y)) {
+				conflictsExist = true
+			}
+		}
+	}
+
+	if !conflictsExist {
+		fmt.Fprintf(cmd.stdout, "No conflicts among EgressGateway resources in %s\n", cmd.describeConflictCheckNamespaces())
+	}
+
+	return nil
+}
+
+// printConflicts prints the given conflicts between the resources x and y of the given kind, and returns whether
+// there are any
+func (cmd *policyCheckConflictsCmd) printConflicts(kind string, x, y *metav1.ObjectMeta, conflicts []error) bool {
+	if len(conflicts) == 0 {
+		return false
+	}
+
+	fmt.Fprintf(cmd.stdout, "[+] %s %s/%s conflicts with %s/%s:\n", kind, x.Namespace, x.Name, y.Namespace, y.Name)
+	for _, err := range conflicts {
+		fmt.Fprintf(cmd.stdout, "%s\n", err)
+	}
+	fmt.Fprintf(cmd.stdout, "\n")
+	return true
+}
+
+// conflictCheckNamespaces returns the namespaces to check for conflicts, all namespaces when none were given
+func (cmd *policyCheckConflictsCmd) conflictCheckNamespaces() []string {
+	if len(cmd.namespaces) == 0 {
+		return []string{metav1.NamespaceAll}
+	}
+	return cmd.namespaces
+}
+
+func (cmd *policyCheckConflictsCmd) describeConflictCheckNamespaces() string {
+	if len(cmd.namespaces) == 0 {
+		return describeConflictCheckNamespace(metav1.NamespaceAll)
+	}
+	return fmt.Sprintf("namespaces %s", strings.Join(cmd.namespaces, ", "))
+}
+
+func describeConflictCheckNamespace(ns string) string {
+	if ns == metav1.NamespaceAll {
+		return "all namespaces"
+	}
+	return fmt.Sprintf("namespace %s", ns)
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -200,6 +201,152 @@ func TestPolicyCheckConflictRun(t *testing.T) {
 			},
 			expectErr: true,
 		},
+		{
+			name:         "Conflicts among Retry resources across namespaces",
+			resourceKind: "Retry",
+			existingResources: []runtime.Object{
+				&policyv1alpha1.Retry{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "retry-1",
+						Namespace: testNs,
+					},
+					Spec: policyv1alpha1.RetrySpec{
+						Source: policyv1alpha1.RetrySrcDstSpec{Kind: "ServiceAccount", Name: "client", Namespace: "foo"},
+						Destinations: []policyv1alpha1.RetrySrcDstSpec{
+							{Kind: "Service", Name: "server", Namespace: testNs},
+						},
+					},
+				},
+				&policyv1alpha1.Retry{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "retry-2",
+						Namespace: "foo",
+					},
+					Spec: policyv1alpha1.RetrySpec{
+						Source: policyv1alpha1.RetrySrcDstSpec{Kind: "ServiceAccount", Name: "client", Namespace: "foo"},
+						Destinations: []policyv1alpha1.RetrySrcDstSpec{
+							{Kind: "Service", Name: "server", Namespace: testNs},
+						},
+					},
+				},
+			},
+			expectErr:             false,
+			expectedRegexMatchOut: "Retry .*/retry-. conflicts with .*/retry-.:\nDestination Service test/server for source ServiceAccount foo/client",
+		},
+		{
+			name:         "No conflicts among Retry resources in the given namespace",
+			resourceKind: "Retry",
+			namespaces:   []string{testNs},
+			existingResources: []runtime.Object{
+				&policyv1alpha1.Retry{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "retry-1",
+						Namespace: testNs,
+					},
+					Spec: policyv1alpha1.RetrySpec{
+						Source: policyv1alpha1.RetrySrcDstSpec{Kind: "ServiceAccount", Name: "client", Namespace: "foo"},
+						Destinations: []policyv1alpha1.RetrySrcDstSpec{
+							{Kind: "Service", Name: "server", Namespace: testNs},
+						},
+					},
+				},
+				&policyv1alpha1.Retry{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "retry-2",
+						Namespace: "foo",
+					},
+					Spec: policyv1alpha1.RetrySpec{
+						Source: policyv1alpha1.RetrySrcDstSpec{Kind: "ServiceAccount", Name: "client", Namespace: "foo"},
+						Destinations: []policyv1alpha1.RetrySrcDstSpec{
+							{Kind: "Service", Name: "server", Namespace: testNs},
+						},
+					},
+				},
+			},
+			expectErr:             false,
+			expectedRegexMatchOut: "No conflicts among Retry resources in namespaces test",
+		},
+		{
+			name:         "Conflicts among UpstreamTrafficSetting resources",
+			resourceKind: "UpstreamTrafficSetting",
+			existingResources: []runtime.Object{
+				&policyv1alpha1.UpstreamTrafficSetting{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "uts-1",
+						Namespace: testNs,
+					},
+					Spec: policyv1alpha1.UpstreamTrafficSettingSpec{
+						Host: "httpbin.test.svc.cluster.local",
+					},
+				},
+				&policyv1alpha1.UpstreamTrafficSetting{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "uts-2",
+						Namespace: testNs,
+					},
+					Spec: policyv1alpha1.UpstreamTrafficSettingSpec{
+						Host: "httpbin.test.svc.cluster.local",
+					},
+				},
+			},
+			expectErr:             false,
+			expectedRegexMatchOut: "UpstreamTrafficSetting test/uts-. conflicts with test/uts-.:\nHost httpbin.test.svc.cluster.local specified in",
+		},
+		{
+			name:         "No conflicts among EgressGateway resources",
+			resourceKind: "EgressGateway",
+			existingResources: []runtime.Object{
+				&policyv1alpha1.EgressGateway{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "egress-gateway-1",
+						Namespace: testNs,
+					},
+					Spec: policyv1alpha1.EgressGatewaySpec{
+						GlobalEgressGateways: []policyv1alpha1.GatewayBindingSubject{
+							{Service: "fsm-egress-gateway", Namespace: "osm-system"},
+						},
+					},
+				},
+				&policyv1alpha1.EgressGateway{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "egress-gateway-2",
+						Namespace: testNs,
+					},
+				},
+			},
+			expectErr:             false,
+			expectedRegexMatchOut: "No conflicts among EgressGateway resources in all namespaces",
+		},
+		{
+			name:         "Conflicts among EgressGateway resources",
+			resourceKind: "EgressGateway",
+			existingResources: []runtime.Object{
+				&policyv1alpha1.EgressGateway{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "egress-gateway-1",
+						Namespace: testNs,
+					},
+					Spec: policyv1alpha1.EgressGatewaySpec{
+						GlobalEgressGateways: []policyv1alpha1.GatewayBindingSubject{
+							{Service: "fsm-egress-gateway", Namespace: "osm-system"},
+						},
+					},
+				},
+				&policyv1alpha1.EgressGateway{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "egress-gateway-2",
+						Namespace: "foo",
+					},
+					Spec: policyv1alpha1.EgressGatewaySpec{
+						GlobalEgressGateways: []policyv1alpha1.GatewayBindingSubject{
+							{Service: "fsm-egress-gateway", Namespace: "osm-system"},
+						},
+					},
+				},
+			},
+			expectErr:             false,
+			expectedRegexMatchOut: "EgressGateway .*/egress-gateway-. conflicts with .*/egress-gateway-.:\nGlobal egress gateways specified in",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -214,8 +361,17 @@ func TestPolicyCheckConflictRun(t *testing.T) {
 			}
 
 			switch tc.resourceKind {
-			case "IngressBackend":
+			case "IngressBackend", "Retry", "UpstreamTrafficSetting":
 				cmd.policyClient = fakePolicyClientset.NewSimpleClientset(tc.existingResources...)
+			case "EgressGateway":
+				// The object tracker guesses the wrong resource for EgressGateway objects, so they are created through the client
+				policyClient := fakePolicyClientset.NewSimpleClientset()
+				for _, resource := range tc.existingResources {
+					egressGateway := resource.(*policyv1alpha1.EgressGateway)
+					_, err := policyClient.PolicyV1alpha1().EgressGateways(egressGateway.Namespace).Create(context.Background(), egressGateway, metav1.CreateOptions{})
+					a.NoError(err)
+				}
+				cmd.policyClient = policyClient
 			}
 
 			err := cmd.run()
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/openservicemesh/osm/pkg/cli"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+const policyExplainDescription = `
+This command explains whether a source pod is allowed to send traffic to a
+destination pod on a given port, optionally for a given HTTP method and path.
+
+It walks every mechanism of the mesh: the permissive traffic policy mode, SMI
+TrafficTargets along with their HTTPRouteGroups and TCPRoutes, AccessControl,
+IngressBackend and Egress policies, and the plugin chains run by the sidecars.
+For each mechanism it reports the objects which matched the traffic and the
+rule which allowed or denied it, followed by the retry, rate limiting and
+circuit breaking settings applied to the traffic.
+
+When the HTTP method or path are not given, the routes of the policies are
+matched regardless of them.
+
+The explanation is computed by the debug server of the osm-controller, which
+must be enabled with the 'observability.enableDebugServer' MeshConfig field.
+`
+
+const policyExplainExample = `
+# Explain whether pod 'bookbuyer-client' in the 'bookbuyer' namespace can send traffic to pod 'bookstore-server' in the 'bookstore' namespace on port 14001
+osm policy explain bookbuyer/bookbuyer-client bookstore/bookstore-server --port 14001
+
+# Explain whether the same pods can exchange a GET request on the /books-bought path
+osm policy explain bookbuyer/bookbuyer-client bookstore/bookstore-server --port 14001 --method GET --path /books-bought
+
+# Output the explanation as JSON
+osm policy explain bookbuyer/bookbuyer-client bookstore/bookstore-server --port 14001 -o json
+`
+
+type policyExplainCmd struct {
+	out                 io.Writer
+	config              *rest.Config
+	clientSet           kubernetes.Interface
+	osmNamespace        string
+	sourcePod           string
+	destinationPod      string
+	port                uint16
+	method              string
+	path                string
+	controllerLocalPort uint16
+	outputFormat        string
+}
+
+func newPolicyExplain(out io.Writer) *cobra.Command {
+	explainCmd := &policyExplainCmd{
+		out: out,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "explain SOURCE_POD DESTINATION_POD",
+		Short: "explain which policies allow or deny traffic between two pods",
+		Long:  policyExplainDescription,
+		Args:  cobra.ExactArgs(2),
+		RunE: func(_ *cobra.Command, args []string) error {
+			explainCmd.sourcePod = args[0]
+			explainCmd.destinationPod = args[1]
+
+			config, err := settings.RESTClientGetter().ToRESTConfig()
+			if err != nil {
+				return fmt.Errorf("Error fetching kubeconfig: %w", err)
+			}
+			explainCmd.config = config
+
+			clientset, err := kubernetes.NewForConfig(config)
+			if err != nil {
+				return fmt.Errorf("Could not access Kubernetes cluster, check kubeconfig: %w", err)
+			}
+			explainCmd.clientSet = clientset
+			explainCmd.osmNamespace = settings.Namespace()
+			return explainCmd.run()
+		},
+		Example: policyExplainExample,
+	}
+
+	f := cmd.Flags()
+	f.Uint16Var(&explainCmd.port, "port", 0, "Port of the destination pod the traffic is sent to")
+	f.StringVar(&explainCmd.method, "method", "", "HTTP method of the request")
+	f.StringVar(&explainCmd.path, "path", "", "HTTP path of the request")
+	f.Uint16Var(&explainCmd.controllerLocalPort, "controller-local-port", defaultDebugLocalPort, "Local port to use for port forwarding to the controller")
+	f.StringVarP(&explainCmd.outputFormat, "output", "o", outputFormatText, "Output format, one of text, json or yaml")
+	//nolint: errcheck
+	//#nosec G104: Errors unhandled
+	cmd.MarkFlagRequired("port")
+
+	return cmd
+}
+
+func (cmd *policyExplainCmd) run() error {
+	if cmd.outputFormat != outputFormatText && cmd.outputFormat != outputFormatJSON && cmd.outputFormat != outputFormatYAML {
+		return fmt.Errorf("Invalid output format %s, must be one of %s, %s or %s", cmd.outputFormat, outputFormatText, outputFormatJSON, outputFormatYAML)
+	}
+	if cmd.port == 0 {
+		return fmt.Errorf("The port must be specified")
+	}
+
+	srcNs, srcPodName, err := unmarshalNamespacedPod(cmd.sourcePod)
+	if err != nil {
+		return fmt.Errorf("Invalid argument specified for the source pod [%s/%s]: %w", srcNs, srcPodName, err)
+	}
+	dstNs, dstPodName, err := unmarshalNamespacedPod(cmd.destinationPod)
+	if err != nil {
+		return fmt.Errorf("Invalid argument specified for the destination pod [%s/%s]: %w", dstNs, dstPodName, err)
+	}
+
+	explanation, err := cli.GetTrafficExplanation(cmd.clientSet, cmd.config, cmd.osmNamespace,
+		srcNs+namespaceSeparator+srcPodName, dstNs+namespaceSeparator+dstPodName, cmd.port, cmd.method, cmd.path, cmd.controllerLocalPort)
+	if err != nil {
+		return err
+	}
+
+	return cmd.print(explanation)
+}
+
+// print writes the explanation in the requested output format
+func (cmd *policyExplainCmd) print(explanation *trafficpolicy.TrafficExplanation) error {
+	switch cmd.outputFormat {
+	case outputFormatJSON:
+		enc := json.NewEncoder(cmd.out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(explanation)
+	case outputFormatYAML:
+		output, err := yaml.Marshal(explanation)
+		if err != nil {
+			return err
+		}
+		_, err = cmd.out.Write(output)
+		return err
+	}
+
+	request := fmt.Sprintf("port %d", explanation.Port)
+	if explanation.Method != "" || explanation.Path != "" {
+		request = fmt.Sprintf("%s %s on %s", orAny(explanation.Method, "request"), orAny(explanation.Path, "any path"), request)
+	}
+	fmt.Fprintf(cmd.out, "Traffic from %s to %s on %s\n", describeExplainedPod(explanation.Source), describeExplainedPod(explanation.Destination), request)
+	if len(explanation.DestinationServices) > 0 {
+		fmt.Fprintf(cmd.out, "Destination services: %s\n", strings.Join(explanation.DestinationServices, ", "))
+	}
+	verdict := "DENIED"
+	if explanation.Allowed {
+		verdict = "ALLOWED"
+	}
+	fmt.Fprintf(cmd.out, "Verdict: %s, %s\n", verdict, explanation.Reason)
+
+	fmt.Fprintf(cmd.out, "\nMechanisms:\n")
+	for _, mechanism := range explanation.Mechanisms {
+		fmt.Fprintf(cmd.out, "  %s: %s\n", mechanism.Mechanism, mechanism.Verdict)
+		if len(mechanism.MatchedObjects) > 0 {
+			fmt.Fprintf(cmd.out, "    matched: %s\n", strings.Join(mechanism.MatchedObjects, ", "))
+		}
+		if mechanism.Rule != "" {
+			fmt.Fprintf(cmd.out, "    rule: %s\n", mechanism.Rule)
+		}
+	}
+
+	settings := explanation.Settings
+	if settings == nil {
+		return nil
+	}
+	fmt.Fprintf(cmd.out, "\nSettings:\n")
+	if settings.UpstreamTrafficSetting != "" {
+		fmt.Fprintf(cmd.out, "  upstreamTrafficSetting: %s\n", settings.UpstreamTrafficSetting)
+	}
+	for _, setting := range []struct {
+		name  string
+		value interface{}
+		set   bool
+	}{
+		{name: "retry", value: settings.Retry, set: settings.Retry != nil},
+		{name: "rateLimit", value: settings.RateLimit, set: settings.RateLimit != nil},
+		{name: "routeRateLimit", value: settings.RouteRateLimit, set: settings.RouteRateLimit != nil},
+		{name: "connectionSettings", value: settings.ConnectionSettings, set: settings.ConnectionSettings != nil},
+		{name: "outlierDetection", value: settings.OutlierDetection, set: settings.OutlierDetection != nil},
+	} {
+		if setting.set {
+			fmt.Fprintf(cmd.out, "  %s: %s\n", setting.name, formatDiffValue(setting.value))
+		}
+	}
+	return nil
+}
+
+func describeExplainedPod(pod trafficpolicy.ExplainedPod) string {
+	meshed := "meshed"
+	if !pod.Meshed {
+		meshed = "not meshed"
+	}
+	return fmt.Sprintf("%s/%s (%s, %s)", pod.Namespace, pod.Name, pod.Identity, meshed)
+}
+
+func orAny(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
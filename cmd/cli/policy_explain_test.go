@@ -0,0 +1,149 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	tassert "github.com/stretchr/testify/assert"
+
+	policyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+func TestPolicyExplainCmdPrint(t *testing.T) {
+	explanation := &trafficpolicy.TrafficExplanation{
+		Source:              trafficpolicy.ExplainedPod{Namespace: "bookbuyer", Name: "bookbuyer", Identity: "bookbuyer.bookbuyer", Meshed: true},
+		Destination:         trafficpolicy.ExplainedPod{Namespace: "bookstore", Name: "bookstore", Identity: "bookstore.bookstore", Meshed: true},
+		Port:                14001,
+		Method:              "GET",
+		Path:                "/books-bought",
+		DestinationServices: []string{"bookstore/bookstore:14001"},
+		Allowed:             true,
+		Reason:              "TrafficTarget bookstore/bookstore allows bookbuyer.bookbuyer on HTTPRouteGroup bookstore/bookstore-service-routes match books-bought (methods GET, path /books-bought)",
+		Mechanisms: []trafficpolicy.MechanismExplanation{
+			{
+				Mechanism: trafficpolicy.ExplainMechanismPermissive,
+				Verdict:   trafficpolicy.ExplainVerdictNotApplicable,
+				Rule:      "Permissive traffic policy mode is disabled",
+			},
+			{
+				Mechanism:      trafficpolicy.ExplainMechanismSMI,
+				Verdict:        trafficpolicy.ExplainVerdictAllow,
+				MatchedObjects: []string{"TrafficTarget bookstore/bookstore", "HTTPRouteGroup bookstore/bookstore-service-routes"},
+				Rule:           "TrafficTarget bookstore/bookstore allows bookbuyer.bookbuyer on HTTPRouteGroup bookstore/bookstore-service-routes match books-bought (methods GET, path /books-bought)",
+			},
+		},
+		Settings: &trafficpolicy.TrafficSettingsExplanation{
+			UpstreamTrafficSetting: "bookstore/bookstore",
+			RateLimit:              &policyv1alpha1.RateLimitSpec{Local: &policyv1alpha1.LocalRateLimitSpec{TCP: &policyv1alpha1.TCPLocalRateLimitSpec{Connections: 10, Unit: "minute"}}},
+		},
+	}
+
+	testCases := []struct {
+		name         string
+		outputFormat string
+		explanation  *trafficpolicy.TrafficExplanation
+		expected     string
+	}{
+		{
+			name:         "text",
+			outputFormat: outputFormatText,
+			explanation:  explanation,
+			expected: `Traffic from bookbuyer/bookbuyer (bookbuyer.bookbuyer, meshed) to bookstore/bookstore (bookstore.bookstore, meshed) on GET /books-bought on port 14001
+Destination services: bookstore/bookstore:14001
+Verdict: ALLOWED, TrafficTarget bookstore/bookstore allows bookbuyer.bookbuyer on HTTPRouteGroup bookstore/bookstore-service-routes match books-bought (methods GET, path /books-bought)
+
+Mechanisms:
+  permissive: notApplicable
+    rule: Permissive traffic policy mode is disabled
+  smi: allow
+    matched: TrafficTarget bookstore/bookstore, HTTPRouteGroup bookstore/bookstore-service-routes
+    rule: TrafficTarget bookstore/bookstore allows bookbuyer.bookbuyer on HTTPRouteGroup bookstore/bookstore-service-routes match books-bought (methods GET, path /books-bought)
+
+Settings:
+  upstreamTrafficSetting: bookstore/bookstore
+  rateLimit: {"local":{"tcp":{"connections":10,"unit":"minute"}}}
+`,
+		},
+		{
+			name:         "text for denied traffic without a request",
+			outputFormat: outputFormatText,
+			explanation: &trafficpolicy.TrafficExplanation{
+				Source:      trafficpolicy.ExplainedPod{Namespace: "bookbuyer", Name: "bookbuyer", Identity: "bookbuyer.bookbuyer", Meshed: false},
+				Destination: trafficpolicy.ExplainedPod{Namespace: "bookstore", Name: "bookstore", Identity: "bookstore.bookstore", Meshed: true},
+				Port:        14001,
+				Reason:      "No service of the destination pod exposes port 14001",
+			},
+			expected: `Traffic from bookbuyer/bookbuyer (bookbuyer.bookbuyer, not meshed) to bookstore/bookstore (bookstore.bookstore, meshed) on port 14001
+Verdict: DENIED, No service of the destination pod exposes port 14001
+
+Mechanisms:
+`,
+		},
+		{
+			name:         "yaml",
+			outputFormat: outputFormatYAML,
+			explanation: &trafficpolicy.TrafficExplanation{
+				Source:      trafficpolicy.ExplainedPod{Namespace: "bookbuyer", Name: "bookbuyer", Identity: "bookbuyer.bookbuyer"},
+				Destination: trafficpolicy.ExplainedPod{Namespace: "bookstore", Name: "bookstore", Identity: "bookstore.bookstore"},
+				Port:        14001,
+				Reason:      "No service of the destination pod exposes port 14001",
+			},
+			expected: `allowed: false
+destination:
+  identity: bookstore.bookstore
+  meshed: false
+  name: bookstore
+  namespace: bookstore
+mechanisms: null
+port: 14001
+reason: No service of the destination pod exposes port 14001
+source:
+  identity: bookbuyer.bookbuyer
+  meshed: false
+  name: bookbuyer
+  namespace: bookbuyer
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			out := new(bytes.Buffer)
+			cmd := &policyExplainCmd{out: out, outputFormat: tc.outputFormat}
+			tassert.NoError(t, cmd.print(tc.explanation))
+			tassert.Equal(t, tc.expected, out.String())
+		})
+	}
+}
+
+func TestPolicyExplainCmdValidation(t *testing.T) {
+	testCases := []struct {
+		name         string
+		cmd          *policyExplainCmd
+		errorMessage string
+	}{
+		{
+			name:         "invalid output format",
+			cmd:          &policyExplainCmd{port: 14001, outputFormat: "xml", sourcePod: "bookbuyer/bookbuyer", destinationPod: "bookstore/bookstore"},
+			errorMessage: "Invalid output format xml, must be one of text, json or yaml",
+		},
+		{
+			name:         "missing port",
+			cmd:          &policyExplainCmd{outputFormat: outputFormatText, sourcePod: "bookbuyer/bookbuyer", destinationPod: "bookstore/bookstore"},
+			errorMessage: "The port must be specified",
+		},
+		{
+			name:         "invalid destination pod",
+			cmd:          &policyExplainCmd{port: 14001, outputFormat: outputFormatText, sourcePod: "bookbuyer/bookbuyer", destinationPod: "bookstore/bookstore/bookstore"},
+			errorMessage: "Invalid argument specified for the destination pod [/]: Pod name should be of the form <namespace/pod>, or <pod> for default namespace, got: bookstore/bookstore/bookstore",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.cmd.run()
+			tassert.EqualError(t, err, tc.errorMessage)
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ghodss/yaml"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/openservicemesh/osm/pkg/cli"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+const policyPreviewDescription = `
+This command previews the impact of applying a policy on the config of the
+sidecars of the mesh, without applying it.
+
+The policy is read from a YAML or JSON file and must be one of the AccessControl,
+Egress, EgressGateway, FaultInjection, IngressBackend, RequestAuthentication,
+Retry or UpstreamTrafficSetting kinds. It replaces the policy of the same kind,
+namespace and name if any, and is added to the policies of the mesh otherwise.
+
+For each sidecar whose config would change, the command reports the changed
+fields of its inbound, outbound, ingress, access control, egress and egress
+gateway traffic policies, along with their values before and after applying
+the policy.
+
+The preview is computed by the debug server of the osm-controller, which
+must be enabled with the 'observability.enableDebugServer' MeshConfig field.
+`
+
+const policyPreviewExample = `
+# Preview the impact of applying the policy in the 'retry.yaml' file
+osm policy preview -f retry.yaml
+
+# Output the preview as JSON
+osm policy preview -f retry.yaml -o json
+`
+
+type policyPreviewCmd struct {
+	out                 io.Writer
+	config              *rest.Config
+	clientSet           kubernetes.Interface
+	osmNamespace        string
+	filename            string
+	controllerLocalPort uint16
+	outputFormat        string
+}
+
+func newPolicyPreview(out io.Writer) *cobra.Command {
+	previewCmd := &policyPreviewCmd{
+		out: out,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "preview -f FILENAME",
+		Short: "preview which sidecars' config would change when applying a policy",
+		Long:  policyPreviewDescription,
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			config, err := settings.RESTClientGetter().ToRESTConfig()
+			if err != nil {
+				return fmt.Errorf("Error fetching kubeconfig: %w", err)
+			}
+			previewCmd.config = config
+
+			clientset, err := kubernetes.NewForConfig(config)
+			if err != nil {
+				return fmt.Errorf("Could not access Kubernetes cluster, check kubeconfig: %w", err)
+			}
+			previewCmd.clientSet = clientset
+			previewCmd.osmNamespace = settings.Namespace()
+			return previewCmd.run()
+		},
+		Example: policyPreviewExample,
+	}
+
+	f := cmd.Flags()
+	f.StringVarP(&previewCmd.filename, "filename", "f", "", "File holding the policy to preview, in YAML or JSON")
+	f.Uint16Var(&previewCmd.controllerLocalPort, "controller-local-port", defaultDebugLocalPort, "Local port to use for port forwarding to the controller")
+	f.StringVarP(&previewCmd.outputFormat, "output", "o", outputFormatText, "Output format, one of text, json or yaml")
+	//nolint: errcheck
+	//#nosec G104: Errors unhandled
+	cmd.MarkFlagRequired("filename")
+
+	return cmd
+}
+
+func (cmd *policyPreviewCmd) run() error {
+	if cmd.outputFormat != outputFormatText && cmd.outputFormat != outputFormatJSON && cmd.outputFormat != outputFormatYAML {
+		return fmt.Errorf("Invalid output format %s, must be one of %s, %s or %s", cmd.outputFormat, outputFormatText, outputFormatJSON, outputFormatYAML)
+	}
+
+	policyJSON, err := readPolicyFile(cmd.filename)
+	if err != nil {
+		return err
+	}
+
+	preview, err := cli.GetPolicyPreview(cmd.clientSet, cmd.config, cmd.osmNamespace, policyJSON, cmd.controllerLocalPort)
+	if err != nil {
+		return err
+	}
+
+	return cmd.print(preview)
+}
+
+// readPolicyFile returns the JSON encoding of the policy in the given YAML or JSON file
+func readPolicyFile(filename string) ([]byte, error) {
+	if filename == "" {
+		return nil, fmt.Errorf("The file holding the policy must be specified")
+	}
+	// #nosec G304: file inclusion via variable
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading policy file %s: %w", filename, err)
+	}
+	policyJSON, err := yaml.YAMLToJSON(content)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing policy file %s: %w", filename, err)
+	}
+	return policyJSON, nil
+}
+
+// print writes the preview in the requested output format
+func (cmd *policyPreviewCmd) print(preview *trafficpolicy.PolicyPreview) error {
+	switch cmd.outputFormat {
+	case outputFormatJSON:
+		enc := json.NewEncoder(cmd.out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(preview)
+	case outputFormatYAML:
+		output, err := yaml.Marshal(preview)
+		if err != nil {
+			return err
+		}
+		_, err = cmd.out.Write(output)
+		return err
+	}
+
+	fmt.Fprintf(cmd.out, "Applying %s %s/%s would change the config of %d sidecar(s), %d sidecar(s) unchanged\n",
+		preview.Kind, preview.Namespace, preview.Name, len(preview.Proxies), preview.UnchangedProxies)
+	for _, proxy := range preview.Proxies {
+		fmt.Fprintf(cmd.out, "\n%s/%s (%s):\n", proxy.Namespace, proxy.Name, proxy.Identity)
+		for _, change := range proxy.Changes {
+			path := change.Section
+			if change.Path != "" {
+				path += "." + change.Path
+			}
+			fmt.Fprintf(cmd.out, "  %s: before %s, after %s\n", path, formatDiffValue(change.Before), formatDiffValue(change.After))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	tassert "github.com/stretchr/testify/assert"
+
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+func TestPolicyPreviewCmdPrint(t *testing.T) {
+	preview := &trafficpolicy.PolicyPreview{
+		Kind:      "IngressBackend",
+		Namespace: "bookstore",
+		Name:      "ingress",
+		Proxies: []trafficpolicy.ProxyConfigPreview{
+			{
+				Namespace: "bookstore",
+				Name:      "bookstore",
+				Identity:  "bookstore.bookstore",
+				Changes: []trafficpolicy.ConfigChange{
+					{Section: trafficpolicy.PreviewSectionIngress, Path: "bookstore/bookstore:14001", Before: nil, After: map[string]interface{}{"trafficMatches": []interface{}{"ingress"}}},
+					{Section: trafficpolicy.PreviewSectionEgress, Path: "", Before: "a", After: "b"},
+				},
+			},
+		},
+		UnchangedProxies: 2,
+	}
+
+	testCases := []struct {
+		name         string
+		outputFormat string
+		preview      *trafficpolicy.PolicyPreview
+		expected     string
+	}{
+		{
+			name:         "text",
+			outputFormat: outputFormatText,
+			preview:      preview,
+			expected: `Applying IngressBackend bookstore/ingress would change the config of 1 sidecar(s), 2 sidecar(s) unchanged
+
+bookstore/bookstore (bookstore.bookstore):
+  ingress.bookstore/bookstore:14001: before <none>, after {"trafficMatches":["ingress"]}
+  egress: before "a", after "b"
+`,
+		},
+		{
+			name:         "text without changes",
+			outputFormat: outputFormatText,
+			preview:      &trafficpolicy.PolicyPreview{Kind: "Retry", Namespace: "bookbuyer", Name: "retry", UnchangedProxies: 3},
+			expected: `Applying Retry bookbuyer/retry would change the config of 0 sidecar(s), 3 sidecar(s) unchanged
+`,
+		},
+		{
+			name:         "yaml",
+			outputFormat: outputFormatYAML,
+			preview:      &trafficpolicy.PolicyPreview{Kind: "Retry", Namespace: "bookbuyer", Name: "retry", UnchangedProxies: 3},
+			expected: `kind: Retry
+name: retry
+namespace: bookbuyer
+unchangedProxies: 3
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			out := new(bytes.Buffer)
+			cmd := &policyPreviewCmd{out: out, outputFormat: tc.outputFormat}
+			tassert.NoError(t, cmd.print(tc.preview))
+			tassert.Equal(t, tc.expected, out.String())
+		})
+	}
+}
+
+func TestReadPolicyFile(t *testing.T) {
+	assert := tassert.New(t)
+
+	filename := filepath.Join(t.TempDir(), "retry.yaml")
+	assert.NoError(os.WriteFile(filename, []byte("apiVersion: policy.openservicemesh.io/v1alpha1\nkind: Retry\nmetadata:\n  name: retry\n"), 0600))
+
+	policyJSON, err := readPolicyFile(filename)
+	assert.NoError(err)
+	assert.JSONEq(`{"apiVersion":"policy.openservicemesh.io/v1alpha1","kind":"Retry","metadata":{"name":"retry"}}`, string(policyJSON))
+
+	_, err = readPolicyFile("")
+	assert.EqualError(err, "The file holding the policy must be specified")
+
+	_, err = readPolicyFile(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.Error(err)
+}
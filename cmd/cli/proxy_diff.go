@@ -0,0 +1,170 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/ghodss/yaml"
+	"github.com/spf13/cobra"
+	"helm.sh/helm/v3/pkg/action"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/openservicemesh/osm/pkg/cli"
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+const diffCmdDescription = `
+This command compares the config the osm-controller currently computes for the
+sidecar of the given pod with the config the sidecar actually runs, and prints
+the resources which differ: listeners, clusters, routes and endpoints for Envoy
+sidecars, traffic matches, clusters and plugin chains for Pipy sidecars.
+
+A resource is reported as missing when the controller computes it but the
+sidecar does not run it, as unexpected when the sidecar runs it but the
+controller does not compute it, and as changed along with the fields which
+differ otherwise.
+
+The config computed by the controller is fetched from its debug server, which
+must be enabled with the 'observability.enableDebugServer' MeshConfig field.
+`
+
+const diffCmdExample = `
+# Compare the config of the sidecar of the pod 'bookbuyer-5ccf77f46d-rc5mg' in the 'bookbuyer' namespace with the config computed by the controller
+osm proxy diff bookbuyer-5ccf77f46d-rc5mg -n bookbuyer
+
+# Output the differences as YAML
+osm proxy diff bookbuyer-5ccf77f46d-rc5mg -n bookbuyer -o yaml
+`
+
+const (
+	outputFormatText = "text"
+
+	// defaultDebugLocalPort is the default local port used to port forward to the debug server of the controller
+	defaultDebugLocalPort = constants.DebugPort
+)
+
+type proxyDiffCmd struct {
+	out                 io.Writer
+	config              *rest.Config
+	clientSet           kubernetes.Interface
+	namespace           string
+	osmNamespace        string
+	pod                 string
+	localPort           uint16
+	controllerLocalPort uint16
+	outputFormat        string
+}
+
+func newProxyDiffCmd(config *action.Configuration, out io.Writer) *cobra.Command {
+	diffCmd := &proxyDiffCmd{
+		out: out,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "diff POD",
+		Short: "compare the config of a proxy with the config computed by the controller",
+		Long:  diffCmdDescription,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			diffCmd.pod = args[0]
+			conf, err := config.RESTClientGetter.ToRESTConfig()
+			if err != nil {
+				return fmt.Errorf("Error fetching kubeconfig: %w", err)
+			}
+			diffCmd.config = conf
+
+			clientset, err := kubernetes.NewForConfig(conf)
+			if err != nil {
+				return fmt.Errorf("Could not access Kubernetes cluster, check kubeconfig: %w", err)
+			}
+			diffCmd.clientSet = clientset
+			diffCmd.osmNamespace = settings.Namespace()
+			return diffCmd.run()
+		},
+		Example: diffCmdExample,
+	}
+
+	f := cmd.Flags()
+	f.StringVarP(&diffCmd.namespace, "namespace", "n", metav1.NamespaceDefault, "Namespace of pod")
+	f.Uint16VarP(&diffCmd.localPort, "local-port", "p", constants.SidecarAdminPort, "Local port to use for port forwarding to the sidecar")
+	f.Uint16Var(&diffCmd.controllerLocalPort, "controller-local-port", defaultDebugLocalPort, "Local port to use for port forwarding to the controller")
+	f.StringVarP(&diffCmd.outputFormat, "output", "o", outputFormatText, "Output format, one of text, json or yaml")
+
+	return cmd
+}
+
+func (cmd *proxyDiffCmd) run() error {
+	if cmd.outputFormat != outputFormatText && cmd.outputFormat != outputFormatJSON && cmd.outputFormat != outputFormatYAML {
+		return fmt.Errorf("Invalid output format %s, must be one of %s, %s or %s", cmd.outputFormat, outputFormatText, outputFormatJSON, outputFormatYAML)
+	}
+
+	diffs, err := cli.GetProxyConfigDiff(cmd.clientSet, cmd.config, cmd.osmNamespace, cmd.namespace, cmd.pod, cmd.localPort, cmd.controllerLocalPort)
+	if err != nil {
+		return err
+	}
+
+	return cmd.print(diffs)
+}
+
+// print writes the differences in the requested output format
+func (cmd *proxyDiffCmd) print(diffs []cli.ResourceDiff) error {
+	if diffs == nil {
+		diffs = []cli.ResourceDiff{}
+	}
+
+	switch cmd.outputFormat {
+	case outputFormatJSON:
+		enc := json.NewEncoder(cmd.out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(diffs)
+	case outputFormatYAML:
+		output, err := yaml.Marshal(diffs)
+		if err != nil {
+			return err
+		}
+		_, err = cmd.out.Write(output)
+		return err
+	}
+
+	if len(diffs) == 0 {
+		fmt.Fprintf(cmd.out, "The config of the sidecar of pod %s in namespace %s matches the config computed by the controller\n", cmd.pod, cmd.namespace)
+		return nil
+	}
+	for _, diff := range diffs {
+		name := diff.Name
+		if name != "" {
+			name = " " + name
+		}
+		switch diff.Status {
+		case cli.ResourceMissing:
+			fmt.Fprintf(cmd.out, "%s%s: missing from the sidecar\n", diff.Kind, name)
+		case cli.ResourceUnexpected:
+			fmt.Fprintf(cmd.out, "%s%s: not computed by the controller\n", diff.Kind, name)
+		default:
+			fmt.Fprintf(cmd.out, "%s%s: changed\n", diff.Kind, name)
+		}
+		for _, field := range diff.Fields {
+			path := field.Path
+			if path == "" {
+				path = "."
+			}
+			fmt.Fprintf(cmd.out, "  %s: sidecar %s, controller %s\n", path, formatDiffValue(field.Sidecar), formatDiffValue(field.Controller))
+		}
+	}
+	return nil
+}
+
+// formatDiffValue returns the compact JSON representation of a value of a config, or <none> if the value is missing
+func formatDiffValue(value interface{}) string {
+	if value == nil {
+		return "<none>"
+	}
+	output, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(output)
+}
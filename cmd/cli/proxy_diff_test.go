@@ -0,0 +1,87 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	tassert "github.com/stretchr/testify/assert"
+
+	"github.com/openservicemesh/osm/pkg/cli"
+)
+
+func TestProxyDiffCmdPrint(t *testing.T) {
+	diffs := []cli.ResourceDiff{
+		{
+			Kind:   "cluster",
+			Name:   "bookstore/bookstore|14001",
+			Status: cli.ResourceChanged,
+			Fields: []cli.ConfigDifference{{Path: "connect_timeout", Sidecar: "5s", Controller: "1s"}},
+		},
+		{
+			Kind:   "listener",
+			Name:   "inbound-listener",
+			Status: cli.ResourceMissing,
+		},
+		{
+			Kind:   "config",
+			Status: cli.ResourceChanged,
+			Fields: []cli.ConfigDifference{{Path: "Spec.Probes", Controller: map[string]interface{}{"LivenessProbes": nil}}},
+		},
+	}
+
+	testCases := []struct {
+		name         string
+		outputFormat string
+		diffs        []cli.ResourceDiff
+		expected     string
+	}{
+		{
+			name:         "text",
+			outputFormat: outputFormatText,
+			diffs:        diffs,
+			expected: `cluster bookstore/bookstore|14001: changed
+  connect_timeout: sidecar "5s", controller "1s"
+listener inbound-listener: missing from the sidecar
+config: changed
+  Spec.Probes: sidecar <none>, controller {"LivenessProbes":null}
+`,
+		},
+		{
+			name:         "text without differences",
+			outputFormat: outputFormatText,
+			expected:     "The config of the sidecar of pod bookbuyer in namespace bookbuyer matches the config computed by the controller\n",
+		},
+		{
+			name:         "yaml",
+			outputFormat: outputFormatYAML,
+			diffs:        diffs[1:2],
+			expected:     "- kind: listener\n  name: inbound-listener\n  status: missing\n",
+		},
+		{
+			name:         "json without differences",
+			outputFormat: outputFormatJSON,
+			expected:     "[]\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			out := new(bytes.Buffer)
+			cmd := &proxyDiffCmd{
+				out:          out,
+				namespace:    "bookbuyer",
+				pod:          "bookbuyer",
+				outputFormat: tc.outputFormat,
+			}
+			assert.NoError(cmd.print(tc.diffs))
+			assert.Equal(tc.expected, out.String())
+		})
+	}
+}
+
+func TestProxyDiffCmdInvalidOutputFormat(t *testing.T) {
+	cmd := &proxyDiffCmd{outputFormat: "xml"}
+	tassert.EqualError(t, cmd.run(), "Invalid output format xml, must be one of text, json or yaml")
+}
@@ -1,10 +1,12 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 
+	"github.com/ghodss/yaml"
 	"github.com/spf13/cobra"
 	"helm.sh/helm/v3/pkg/action"
 	corev1 "k8s.io/api/core/v1"
@@ -18,9 +20,24 @@ import (
 
 const getCmdDescription = `
 This command will get the proxy configuration for the given query and pod.
-The query is forwarded as is to the proxy sidecar.
+The command is aware of the class of the sidecar running in the pod.
+
+For Envoy sidecars, the query is forwarded as is to the proxy sidecar.
 Refer to https://www.envoyproxy.io/docs/envoy/latest/operations/admin for the
 list of supported GET queries.
+
+For Pipy sidecars, the following queries are supported and output as JSON or YAML:
+  config_dump: the PipyConf (config.json) loaded by the sidecar, private key redacted
+  plugins:     the plugins loaded by the sidecar, by mount point
+  metrics:     the metrics of the sidecar
+  version:     the version of the codebase loaded by the sidecar
+  all:         all of the above
+Other queries, such as certs, listeners, clusters or stats, are forwarded as is
+to the proxy sidecar.
+
+The --diff flag compares the PipyConf loaded by a Pipy sidecar with the one
+currently computed by the osm-controller for the sidecar, and outputs the
+fields which differ.
 `
 
 const getCmdExample = `
@@ -29,18 +46,37 @@ osm proxy get config_dump bookbuyer-5ccf77f46d-rc5mg -n bookbuyer
 
 # Get the cluster config for the given pod 'bookbuyer-5ccf77f46d-rc5mg' in the 'bookbuyer' namespace and output to file 'clusters.txt'
 osm proxy get clusters bookbuyer-5ccf77f46d-rc5mg -n bookbuyer -f clusters.txt
+
+# Get the plugins loaded by the Pipy sidecar of the given pod 'bookbuyer-5ccf77f46d-rc5mg' in the 'bookbuyer' namespace as YAML
+osm proxy get plugins bookbuyer-5ccf77f46d-rc5mg -n bookbuyer -o yaml
+
+# Compare the config loaded by the Pipy sidecar of the given pod 'bookbuyer-5ccf77f46d-rc5mg' in the 'bookbuyer' namespace
+# with the config computed by the controller
+osm proxy get config_dump bookbuyer-5ccf77f46d-rc5mg -n bookbuyer --diff
 `
 
+const (
+	outputFormatJSON = "json"
+	outputFormatYAML = "yaml"
+
+	// defaultControllerLocalPort is the default local port used to port forward to the repo server of the controller
+	defaultControllerLocalPort = 16060
+)
+
 type proxyGetCmd struct {
-	out        io.Writer
-	config     *rest.Config
-	clientSet  kubernetes.Interface
-	query      string
-	namespace  string
-	pod        string
-	localPort  uint16
-	outFile    string
-	sigintChan chan os.Signal
+	out                 io.Writer
+	config              *rest.Config
+	clientSet           kubernetes.Interface
+	query               string
+	namespace           string
+	osmNamespace        string
+	pod                 string
+	localPort           uint16
+	controllerLocalPort uint16
+	outFile             string
+	outputFormat        string
+	diff                bool
+	sigintChan          chan os.Signal
 }
 
 func newProxyGetCmd(config *action.Configuration, out io.Writer) *cobra.Command {
@@ -68,6 +104,7 @@ func newProxyGetCmd(config *action.Configuration, out io.Writer) *cobra.Command
 				return fmt.Errorf("Could not access Kubernetes cluster, check kubeconfig: %w", err)
 			}
 			getCmd.clientSet = clientset
+			getCmd.osmNamespace = settings.Namespace()
 			return getCmd.run()
 		},
 		Example: getCmdExample,
@@ -78,16 +115,94 @@ func newProxyGetCmd(config *action.Configuration, out io.Writer) *cobra.Command
 	f.StringVarP(&getCmd.namespace, "namespace", "n", metav1.NamespaceDefault, "Namespace of pod")
 	f.StringVarP(&getCmd.outFile, "file", "f", "", "File to write output to")
 	f.Uint16VarP(&getCmd.localPort, "local-port", "p", constants.SidecarAdminPort, "Local port to use for port forwarding")
+	f.Uint16Var(&getCmd.controllerLocalPort, "controller-local-port", defaultControllerLocalPort, "Local port to use for port forwarding to the controller with --diff")
+	f.StringVarP(&getCmd.outputFormat, "output", "o", outputFormatJSON, "Output format of Pipy sidecar queries, one of json or yaml")
+	f.BoolVar(&getCmd.diff, "diff", false, "Output the differences between the config of a Pipy sidecar and the config computed by the controller")
 
 	return cmd
 }
 
 func (cmd *proxyGetCmd) run() error {
-	sidecarProxyConfig, err := cli.GetSidecarProxyConfig(cmd.clientSet, cmd.config, cmd.namespace, cmd.pod, cmd.localPort, cmd.query)
+	if cmd.outputFormat != outputFormatJSON && cmd.outputFormat != outputFormatYAML {
+		return fmt.Errorf("Invalid output format %s, must be one of %s or %s", cmd.outputFormat, outputFormatJSON, outputFormatYAML)
+	}
+
+	sidecarClass, err := cli.GetMeshedPodSidecarClass(cmd.clientSet, cmd.namespace, cmd.pod)
 	if err != nil {
 		return err
 	}
+	if cmd.diff && (sidecarClass != constants.SidecarClassPipy || cmd.query != cli.PipyQueryConfig) {
+		return fmt.Errorf("--diff is only supported with the %s query for pods with a %s sidecar", cli.PipyQueryConfig, constants.SidecarClassPipy)
+	}
+
+	var output []byte
+	switch {
+	case cmd.diff:
+		output, err = cmd.getPipyConfigDiff()
+	case sidecarClass == constants.SidecarClassPipy && cli.IsPipyQuery(cmd.query):
+		output, err = cmd.getPipySidecarState()
+	default:
+		output, err = cli.GetSidecarProxyConfig(cmd.clientSet, cmd.config, cmd.namespace, cmd.pod, cmd.localPort, cmd.query)
+	}
+	if err != nil {
+		return err
+	}
+
+	return cmd.write(output)
+}
+
+// getPipySidecarState returns the requested state of a Pipy sidecar in the requested output format
+func (cmd *proxyGetCmd) getPipySidecarState() ([]byte, error) {
+	state, err := cli.GetPipySidecarState(cmd.clientSet, cmd.config, cmd.namespace, cmd.pod, cmd.localPort, cmd.query)
+	if err != nil {
+		return nil, err
+	}
+
+	switch cmd.query {
+	case cli.PipyQueryConfig:
+		return cmd.format(state.Config)
+	case cli.PipyQueryPlugins:
+		return cmd.format(state.Plugins)
+	case cli.PipyQueryMetrics:
+		return cmd.format(state.Metrics)
+	default:
+		return cmd.format(state)
+	}
+}
+
+// getPipyConfigDiff returns the differences between the config loaded by a Pipy sidecar and the config computed
+// by the controller in the requested output format
+func (cmd *proxyGetCmd) getPipyConfigDiff() ([]byte, error) {
+	state, err := cli.GetPipySidecarState(cmd.clientSet, cmd.config, cmd.namespace, cmd.pod, cmd.localPort, cli.PipyQueryConfig)
+	if err != nil {
+		return nil, err
+	}
+	controllerConfig, err := cli.GetPipyControllerConfig(cmd.clientSet, cmd.config, cmd.osmNamespace, cmd.namespace, cmd.pod, cmd.controllerLocalPort)
+	if err != nil {
+		return nil, err
+	}
+
+	diffs := cli.DiffPipyConfig(state.Config, controllerConfig)
+	if diffs == nil {
+		diffs = []cli.ConfigDifference{}
+	}
+	return cmd.format(diffs)
+}
+
+// format renders the given value in the requested output format
+func (cmd *proxyGetCmd) format(v interface{}) ([]byte, error) {
+	if cmd.outputFormat == outputFormatYAML {
+		return yaml.Marshal(v)
+	}
+	output, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(output, '\n'), nil
+}
 
+// write writes the output to the requested file, or to stdout by default
+func (cmd *proxyGetCmd) write(output []byte) error {
 	out := cmd.out // By default, output is written to stdout
 	if cmd.outFile != "" {
 		fd, err := os.Create(cmd.outFile)
@@ -100,7 +215,7 @@ func (cmd *proxyGetCmd) run() error {
 		out = fd // write output to file
 	}
 
-	_, err = out.Write(sidecarProxyConfig)
+	_, err := out.Write(output)
 	return err
 }
 
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+func TestProxyGetCmdValidation(t *testing.T) {
+	envoyPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "bookbuyer",
+			Namespace: "bookbuyer",
+			Labels:    map[string]string{constants.SidecarUniqueIDLabelName: "1f0b0a4e-5c1e-4f0e-9b5e-3c8f7c6e2a10"},
+		},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{
+			{Name: constants.SidecarContainerName, Command: []string{"envoy"}},
+		}},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		outputFormat  string
+		diff          bool
+		expectedError string
+	}{
+		{
+			name:          "invalid output format",
+			query:         "config_dump",
+			outputFormat:  "xml",
+			expectedError: "Invalid output format xml, must be one of json or yaml",
+		},
+		{
+			name:          "diff of an envoy sidecar",
+			query:         "config_dump",
+			outputFormat:  outputFormatJSON,
+			diff:          true,
+			expectedError: "--diff is only supported with the config_dump query for pods with a pipy sidecar",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			clientSet := fake.NewSimpleClientset()
+			_, err := clientSet.CoreV1().Pods(envoyPod.Namespace).Create(context.TODO(), envoyPod, metav1.CreateOptions{})
+			assert.NoError(err)
+
+			cmd := &proxyGetCmd{
+				out:          new(bytes.Buffer),
+				clientSet:    clientSet,
+				query:        tc.query,
+				namespace:    envoyPod.Namespace,
+				pod:          envoyPod.Name,
+				outputFormat: tc.outputFormat,
+				diff:         tc.diff,
+			}
+			assert.EqualError(cmd.run(), tc.expectedError)
+		})
+	}
+}
+
+func TestProxyGetCmdFormat(t *testing.T) {
+	assert := tassert.New(t)
+
+	plugins := map[string][]string{"inbound-http": {"modules/inbound-tls-termination.js"}}
+
+	cmd := &proxyGetCmd{outputFormat: outputFormatJSON}
+	output, err := cmd.format(plugins)
+	assert.NoError(err)
+	assert.Equal("{\n  \"inbound-http\": [\n    \"modules/inbound-tls-termination.js\"\n  ]\n}\n", string(output))
+
+	cmd.outputFormat = outputFormatYAML
+	output, err = cmd.format(plugins)
+	assert.NoError(err)
+	assert.Equal("inbound-http:\n- modules/inbound-tls-termination.js\n", string(output))
+}
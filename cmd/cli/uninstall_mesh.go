@@ -294,6 +294,8 @@ func (d *uninstallMeshCmd) uninstallCustomResourceDefinitions() error {
 		"meshRootCertificate.config.openservicemesh.io",
 		"upstreamtrafficsettings.policy.openservicemesh.io",
 		"retries.policy.openservicemesh.io",
+		"faultinjections.policy.openservicemesh.io",
+		"requestauthentications.policy.openservicemesh.io",
 		"httproutegroups.specs.smi-spec.io",
 		"tcproutes.specs.smi-spec.io",
 		"trafficsplits.split.smi-spec.io",
@@ -109,6 +109,14 @@ func init() {
 	flags.IntVar(&vaultOptions.VaultPort, "vault-port", 8200, "Port of the Hashi Vault")
 	flags.StringVar(&vaultOptions.VaultTokenSecretName, "vault-token-secret-name", "", "Name of the secret storing the Vault token used in OSM")
 	flags.StringVar(&vaultOptions.VaultTokenSecretKey, "vault-token-secret-key", "", "Key for the vault token used in OSM")
+	flags.StringVar(&vaultOptions.VaultNamespace, "vault-namespace", "", "Vault Enterprise namespace of the PKI secrets engine and the auth method")
+	flags.StringVar(&vaultOptions.VaultAuthMethod, "vault-auth-method", providers.VaultTokenAuthMethod, fmt.Sprintf("Vault auth method, one of [%s, %s, %s]", providers.VaultTokenAuthMethod, providers.VaultKubernetesAuthMethod, providers.VaultAppRoleAuthMethod))
+	flags.StringVar(&vaultOptions.VaultKubernetesAuthRole, "vault-kubernetes-auth-role", "", "Name of the Vault role bound to the OSM ServiceAccount for the kubernetes auth method")
+	flags.StringVar(&vaultOptions.VaultKubernetesAuthMountPath, "vault-kubernetes-auth-mount-path", "", "Path the Vault kubernetes auth method is mounted at")
+	flags.StringVar(&vaultOptions.VaultAppRoleID, "vault-approle-role-id", "", "Role ID for the Vault approle auth method")
+	flags.StringVar(&vaultOptions.VaultAppRoleMountPath, "vault-approle-mount-path", "", "Path the Vault approle auth method is mounted at")
+	flags.StringVar(&vaultOptions.VaultAppRoleSecretIDSecretName, "vault-approle-secret-id-secret-name", "", "Name of the secret storing the secret ID for the Vault approle auth method")
+	flags.StringVar(&vaultOptions.VaultAppRoleSecretIDSecretKey, "vault-approle-secret-id-secret-key", "", "Key for the secret ID for the Vault approle auth method")
 
 	// Cert-manager certificate manager/provider options
 	flags.StringVar(&certManagerOptions.IssuerName, "cert-manager-issuer-name", "osm-ca", "cert-manager issuer name")
@@ -26,6 +26,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/clientcmd"
+	gatewayAPIClientset "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned"
 
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
 
@@ -46,6 +47,7 @@ import (
 	"github.com/openservicemesh/osm/pkg/debugger"
 	"github.com/openservicemesh/osm/pkg/endpoint"
 	"github.com/openservicemesh/osm/pkg/errcode"
+	"github.com/openservicemesh/osm/pkg/gatewayapi"
 	"github.com/openservicemesh/osm/pkg/health"
 	"github.com/openservicemesh/osm/pkg/httpserver"
 	"github.com/openservicemesh/osm/pkg/ingress"
@@ -87,6 +89,8 @@ var (
 
 	certProviderKind          string
 	enableMeshRootCertificate bool
+	enableCertAuditEvents     bool
+	enableGatewayAPI          bool
 
 	tresorOptions      providers.TresorOptions
 	vaultOptions       providers.VaultOptions
@@ -115,6 +119,8 @@ func init() {
 	// Generic certificate manager/provider options
 	flags.StringVar(&certProviderKind, "certificate-manager", providers.TresorKind.String(), fmt.Sprintf("Certificate manager, one of [%v]", providers.ValidCertificateProviders))
 	flags.BoolVar(&enableMeshRootCertificate, "enable-mesh-root-certificate", false, "Enable unsupported MeshRootCertificate to create the OSM Certificate Manager")
+	flags.BoolVar(&enableCertAuditEvents, "enable-certificate-audit-events", false, "Record the issuance, rotation and release of certificates as Kubernetes events")
+	flags.BoolVar(&enableGatewayAPI, "enable-gateway-api", false, "Enable Gateway API HTTPRoute, GRPCRoute and TCPRoute resources attached to Services as a source of traffic policies")
 	flags.StringVar(&caBundleSecretName, "ca-bundle-secret-name", "", "Name of the Kubernetes Secret for the OSM CA bundle")
 
 	// TODO (#4502): Remove when we add full MRC support
@@ -128,6 +134,14 @@ func init() {
 	flags.IntVar(&vaultOptions.VaultPort, "vault-port", 8200, "Port of the Hashi Vault")
 	flags.StringVar(&vaultOptions.VaultTokenSecretName, "vault-token-secret-name", "", "Name of the secret storing the Vault token used in OSM")
 	flags.StringVar(&vaultOptions.VaultTokenSecretKey, "vault-token-secret-key", "", "Key for the vault token used in OSM")
+	flags.StringVar(&vaultOptions.VaultNamespace, "vault-namespace", "", "Vault Enterprise namespace of the PKI secrets engine and the auth method")
+	flags.StringVar(&vaultOptions.VaultAuthMethod, "vault-auth-method", providers.VaultTokenAuthMethod, fmt.Sprintf("Vault auth method, one of [%s, %s, %s]", providers.VaultTokenAuthMethod, providers.VaultKubernetesAuthMethod, providers.VaultAppRoleAuthMethod))
+	flags.StringVar(&vaultOptions.VaultKubernetesAuthRole, "vault-kubernetes-auth-role", "", "Name of the Vault role bound to the OSM ServiceAccount for the kubernetes auth method")
+	flags.StringVar(&vaultOptions.VaultKubernetesAuthMountPath, "vault-kubernetes-auth-mount-path", "", "Path the Vault kubernetes auth method is mounted at")
+	flags.StringVar(&vaultOptions.VaultAppRoleID, "vault-approle-role-id", "", "Role ID for the Vault approle auth method")
+	flags.StringVar(&vaultOptions.VaultAppRoleMountPath, "vault-approle-mount-path", "", "Path the Vault approle auth method is mounted at")
+	flags.StringVar(&vaultOptions.VaultAppRoleSecretIDSecretName, "vault-approle-secret-id-secret-name", "", "Name of the secret storing the secret ID for the Vault approle auth method")
+	flags.StringVar(&vaultOptions.VaultAppRoleSecretIDSecretKey, "vault-approle-secret-id-secret-key", "", "Key for the secret ID for the Vault approle auth method")
 
 	// Cert-manager certificate manager/provider options
 	flags.StringVar(&certManagerOptions.IssuerName, "cert-manager-issuer-name", "osm-ca", "cert-manager issuer name")
@@ -215,14 +229,24 @@ func main() {
 	smiTrafficSpecClientSet := smiTrafficSpecClient.NewForConfigOrDie(kubeConfig)
 	smiTrafficTargetClientSet := smiAccessClient.NewForConfigOrDie(kubeConfig)
 
+	// The Gateway API CRDs are not installed by OSM, so their informers are only started when enabled
+	var gatewayAPIClient gatewayAPIClientset.Interface
+	var gatewayAPIInformerOption informers.InformerCollectionOption
+	if enableGatewayAPI {
+		gatewayAPIClient = gatewayAPIClientset.NewForConfigOrDie(kubeConfig)
+		gatewayAPIInformerOption = informers.WithGatewayAPIClient(gatewayAPIClient)
+	}
+
 	informerCollection, err := informers.NewInformerCollection(meshName, stop,
 		informers.WithKubeClient(kubeClient),
+		informers.WithSecretClient(kubeClient),
 		informers.WithSMIClients(smiTrafficSplitClientSet, smiTrafficSpecClientSet, smiTrafficTargetClientSet),
 		informers.WithConfigClient(configClient, osmMeshConfigName, osmNamespace),
 		informers.WithPolicyClient(policyClient),
 		informers.WithPluginClient(pluginClient),
 		informers.WithMultiClusterClient(multiclusterClient),
 		informers.WithNetworkingClient(networkingClient),
+		gatewayAPIInformerOption,
 	)
 	if err != nil {
 		events.GenericEventRecorder().FatalEvent(err, events.InitializationError, "Error creating informer collection")
@@ -235,7 +259,7 @@ func main() {
 		events.GenericEventRecorder().FatalEvent(err, events.InitializationError, "Error creating sidecar driver")
 	}
 
-	k8sClient := k8s.NewKubernetesController(informerCollection, policyClient, pluginClient, msgBroker)
+	k8sClient := k8s.NewKubernetesController(informerCollection, policyClient, pluginClient, gatewayAPIClient, msgBroker)
 
 	meshSpec := smi.NewSMIClient(informerCollection, osmNamespace, k8sClient, msgBroker)
 
@@ -247,7 +271,7 @@ func main() {
 	// Intitialize certificate manager/provider
 	var certManager *certificate.Manager
 	if enableMeshRootCertificate {
-		certManager, err = providers.NewCertificateManagerFromMRC(ctx, kubeClient, kubeConfig, cfg, osmNamespace,
+		certManager, err = providers.NewCertificateManagerFromMRC(ctx, kubeClient, kubeConfig, configClient, cfg, osmNamespace,
 			certOpts, msgBroker, informerCollection, 5*time.Second)
 		if err != nil {
 			events.GenericEventRecorder().FatalEvent(err, events.InvalidCertificateManager,
@@ -261,11 +285,20 @@ func main() {
 				"Error fetching certificate manager of kind %s", certProviderKind)
 		}
 	}
+	if enableCertAuditEvents {
+		certManager.AddAuditSink(certificate.KubernetesEventAuditSink{Recorder: events.GenericEventRecorder()})
+	}
+	metricsstore.DefaultMetricsStore.Start(certManager.ExpirationMetricsCollector())
 
 	policyController := policy.NewPolicyController(informerCollection, kubeClient, k8sClient, msgBroker)
 	pluginController := plugin.NewPluginController(informerCollection, kubeClient, k8sClient, msgBroker)
 	multiclusterController := multicluster.NewMultiClusterController(informerCollection, kubeClient, k8sClient, msgBroker)
 
+	var gatewayAPIController gatewayapi.Controller
+	if enableGatewayAPI {
+		gatewayAPIController = gatewayapi.NewGatewayAPIController(informerCollection, k8sClient, msgBroker)
+	}
+
 	kubeProvider := kube.NewClient(k8sClient, cfg)
 	multiclusterProvider := fsm.NewClient(multiclusterController, cfg)
 
@@ -283,6 +316,7 @@ func main() {
 		policyController,
 		pluginController,
 		multiclusterController,
+		gatewayAPIController,
 		stop,
 		cfg,
 		serviceProviders,
@@ -346,6 +380,10 @@ func main() {
 	go k8s.WatchAndUpdateProxyBootstrapSecret(kubeClient, msgBroker, stop)
 	// Start the global log level watcher that updates the log level dynamically
 	go k8s.WatchAndUpdateLogLevel(msgBroker, stop)
+	// Start the policy status reconciler that writes back the conditions of the policies
+	go meshCatalog.ReconcilePolicyStatuses(msgBroker, stop)
+	// Start the Gateway API route status reconciler that writes back the conditions of the routes attached to services
+	go meshCatalog.ReconcileGatewayAPIRouteStatuses(msgBroker, stop)
 
 	if enableReconciler {
 		log.Info().Msgf("OSM reconciler enabled for validating webhook")
@@ -381,6 +419,7 @@ func startMetricsStore() {
 		metricsstore.DefaultMetricsStore.AdmissionWebhookResponseTotal,
 		metricsstore.DefaultMetricsStore.EventsQueued,
 		metricsstore.DefaultMetricsStore.ReconciliationTotal,
+		metricsstore.DefaultMetricsStore.CertRotationTotal,
 	)
 }
 
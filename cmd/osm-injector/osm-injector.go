@@ -65,6 +65,7 @@ var (
 
 	certProviderKind          string
 	enableMeshRootCertificate bool
+	enableCertAuditEvents     bool
 
 	enableReconciler bool
 
@@ -95,6 +96,7 @@ func init() {
 	// Generic certificate manager/provider options
 	flags.StringVar(&certProviderKind, "certificate-manager", providers.TresorKind.String(), fmt.Sprintf("Certificate manager, one of [%v]", providers.ValidCertificateProviders))
 	flags.BoolVar(&enableMeshRootCertificate, "enable-mesh-root-certificate", false, "Enable unsupported MeshRootCertificate to create the OSM Certificate Manager")
+	flags.BoolVar(&enableCertAuditEvents, "enable-certificate-audit-events", false, "Record the issuance, rotation and release of certificates as Kubernetes events")
 	flags.StringVar(&caBundleSecretName, "ca-bundle-secret-name", "", "Name of the Kubernetes Secret for the OSM CA bundle")
 
 	// TODO (#4502): Remove when we add full MRC support
@@ -108,6 +110,14 @@ func init() {
 	flags.IntVar(&vaultOptions.VaultPort, "vault-port", 8200, "Port of the Hashi Vault")
 	flags.StringVar(&vaultOptions.VaultTokenSecretName, "vault-token-secret-name", "", "Name of the secret storing the Vault token used in OSM")
 	flags.StringVar(&vaultOptions.VaultTokenSecretKey, "vault-token-secret-key", "", "Key for the vault token used in OSM")
+	flags.StringVar(&vaultOptions.VaultNamespace, "vault-namespace", "", "Vault Enterprise namespace of the PKI secrets engine and the auth method")
+	flags.StringVar(&vaultOptions.VaultAuthMethod, "vault-auth-method", providers.VaultTokenAuthMethod, fmt.Sprintf("Vault auth method, one of [%s, %s, %s]", providers.VaultTokenAuthMethod, providers.VaultKubernetesAuthMethod, providers.VaultAppRoleAuthMethod))
+	flags.StringVar(&vaultOptions.VaultKubernetesAuthRole, "vault-kubernetes-auth-role", "", "Name of the Vault role bound to the OSM ServiceAccount for the kubernetes auth method")
+	flags.StringVar(&vaultOptions.VaultKubernetesAuthMountPath, "vault-kubernetes-auth-mount-path", "", "Path the Vault kubernetes auth method is mounted at")
+	flags.StringVar(&vaultOptions.VaultAppRoleID, "vault-approle-role-id", "", "Role ID for the Vault approle auth method")
+	flags.StringVar(&vaultOptions.VaultAppRoleMountPath, "vault-approle-mount-path", "", "Path the Vault approle auth method is mounted at")
+	flags.StringVar(&vaultOptions.VaultAppRoleSecretIDSecretName, "vault-approle-secret-id-secret-name", "", "Name of the secret storing the secret ID for the Vault approle auth method")
+	flags.StringVar(&vaultOptions.VaultAppRoleSecretIDSecretKey, "vault-approle-secret-id-secret-key", "", "Key for the secret ID for the Vault approle auth method")
 
 	// Cert-manager certificate manager/provider options
 	flags.StringVar(&certManagerOptions.IssuerName, "cert-manager-issuer-name", "osm-ca", "cert-manager issuer name")
@@ -182,6 +192,7 @@ func main() {
 	metricsstore.DefaultMetricsStore.Start(
 		metricsstore.DefaultMetricsStore.CertIssuedCount,
 		metricsstore.DefaultMetricsStore.CertIssuedTime,
+		metricsstore.DefaultMetricsStore.CertRotationTotal,
 		metricsstore.DefaultMetricsStore.ErrCodeCounter,
 		metricsstore.DefaultMetricsStore.HTTPResponseTotal,
 		metricsstore.DefaultMetricsStore.HTTPResponseDuration,
@@ -197,6 +208,7 @@ func main() {
 
 	informerCollection, err := informers.NewInformerCollection(meshName, stop,
 		informers.WithKubeClient(kubeClient),
+		informers.WithSecretClient(kubeClient),
 		informers.WithSMIClients(smiTrafficSplitClientSet, smiTrafficSpecClientSet, smiTrafficTargetClientSet),
 		informers.WithConfigClient(configClient, osmMeshConfigName, osmNamespace),
 		informers.WithPolicyClient(policyClient),
@@ -214,7 +226,7 @@ func main() {
 	}
 
 	// Initialize kubernetes.Controller to watch kubernetes resources
-	kubeController := k8s.NewKubernetesController(informerCollection, policyClient, pluginClient, msgBroker, k8s.Namespaces)
+	kubeController := k8s.NewKubernetesController(informerCollection, policyClient, pluginClient, nil, msgBroker, k8s.Namespaces)
 
 	certOpts, err := getCertOptions()
 	if err != nil {
@@ -223,7 +235,7 @@ func main() {
 	// Intitialize certificate manager/provider
 	var certManager *certificate.Manager
 	if enableMeshRootCertificate {
-		certManager, err = providers.NewCertificateManagerFromMRC(ctx, kubeClient, kubeConfig, cfg, osmNamespace,
+		certManager, err = providers.NewCertificateManagerFromMRC(ctx, kubeClient, kubeConfig, configClient, cfg, osmNamespace,
 			certOpts, msgBroker, informerCollection, 5*time.Second)
 		if err != nil {
 			events.GenericEventRecorder().FatalEvent(err, events.InvalidCertificateManager,
@@ -237,6 +249,10 @@ func main() {
 				"Error initializing certificate manager of kind %s", certProviderKind)
 		}
 	}
+	if enableCertAuditEvents {
+		certManager.AddAuditSink(certificate.KubernetesEventAuditSink{Recorder: events.GenericEventRecorder()})
+	}
+	metricsstore.DefaultMetricsStore.Start(certManager.ExpirationMetricsCollector())
 
 	// Initialize the sidecar injector webhook
 	if err := injector.NewMutatingWebhook(ctx, kubeClient, certManager, kubeController, meshName, osmNamespace, webhookConfigName, osmVersion, webhookTimeout, enableReconciler, cfg, corev1.PullPolicy(osmContainerPullPolicy)); err != nil {
@@ -104,4 +104,7 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&config.KubeConfig, "kubeconfig", "", "Kubernetes configuration file")
 	rootCmd.PersistentFlags().StringVar(&config.Context, "kubecontext", "", "The name of the kube config context to use")
 	rootCmd.PersistentFlags().BoolVar(&config.EnableHotRestart, "enable-hot-restart", false, "enable hot restart")
+	rootCmd.PersistentFlags().StringVar(&config.OsmNamespace, "osm-namespace", "osm-system", "Namespace to which OSM belongs to.")
+	rootCmd.PersistentFlags().StringVar(&config.MeshConfigName, "osm-config-name", "osm-mesh-config", "Name of the OSM MeshConfig")
+	rootCmd.PersistentFlags().IntVar(&config.DebugServerPort, "debug-server-port", config.DebugServerDefaultPort, "Port of the debug server on localhost, 0 to disable")
 }
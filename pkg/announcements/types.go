@@ -35,6 +35,17 @@ const (
 
 	// ---
 
+	// EndpointSliceAdded is the type of announcement emitted when we observe an addition of a Kubernetes EndpointSlice
+	EndpointSliceAdded Kind = "endpointslice-added"
+
+	// EndpointSliceDeleted the type of announcement emitted when we observe the deletion of a Kubernetes EndpointSlice
+	EndpointSliceDeleted Kind = "endpointslice-deleted"
+
+	// EndpointSliceUpdated is the type of announcement emitted when we observe an update to a Kubernetes EndpointSlice
+	EndpointSliceUpdated Kind = "endpointslice-updated"
+
+	// ---
+
 	// NamespaceAdded is the type of announcement emitted when we observe an addition of a Kubernetes Namespace
 	NamespaceAdded Kind = "namespace-added"
 
@@ -101,6 +112,39 @@ const (
 
 	// ---
 
+	// GatewayAPIHTTPRouteAdded is the type of announcement emitted when we observe an addition of a Gateway API HTTPRoute
+	GatewayAPIHTTPRouteAdded Kind = "gatewayapi-httproute-added"
+
+	// GatewayAPIHTTPRouteDeleted the type of announcement emitted when we observe the deletion of a Gateway API HTTPRoute
+	GatewayAPIHTTPRouteDeleted Kind = "gatewayapi-httproute-deleted"
+
+	// GatewayAPIHTTPRouteUpdated is the type of announcement emitted when we observe an update to a Gateway API HTTPRoute
+	GatewayAPIHTTPRouteUpdated Kind = "gatewayapi-httproute-updated"
+
+	// ---
+
+	// GatewayAPIGRPCRouteAdded is the type of announcement emitted when we observe an addition of a Gateway API GRPCRoute
+	GatewayAPIGRPCRouteAdded Kind = "gatewayapi-grpcroute-added"
+
+	// GatewayAPIGRPCRouteDeleted the type of announcement emitted when we observe the deletion of a Gateway API GRPCRoute
+	GatewayAPIGRPCRouteDeleted Kind = "gatewayapi-grpcroute-deleted"
+
+	// GatewayAPIGRPCRouteUpdated is the type of announcement emitted when we observe an update to a Gateway API GRPCRoute
+	GatewayAPIGRPCRouteUpdated Kind = "gatewayapi-grpcroute-updated"
+
+	// ---
+
+	// GatewayAPITCPRouteAdded is the type of announcement emitted when we observe an addition of a Gateway API TCPRoute
+	GatewayAPITCPRouteAdded Kind = "gatewayapi-tcproute-added"
+
+	// GatewayAPITCPRouteDeleted the type of announcement emitted when we observe the deletion of a Gateway API TCPRoute
+	GatewayAPITCPRouteDeleted Kind = "gatewayapi-tcproute-deleted"
+
+	// GatewayAPITCPRouteUpdated is the type of announcement emitted when we observe an update to a Gateway API TCPRoute
+	GatewayAPITCPRouteUpdated Kind = "gatewayapi-tcproute-updated"
+
+	// ---
+
 	// TrafficTargetAdded is the type of announcement emitted when we observe an addition of a Kubernetes TrafficTarget
 	TrafficTargetAdded Kind = "traffictarget-added"
 
@@ -240,6 +284,33 @@ const (
 	// RetryPolicyUpdated is the type of announcement emitted when we observe an update to retries.policy.openservicemesh.io
 	RetryPolicyUpdated Kind = "retry-updated"
 
+	// FaultInjectionPolicyAdded is the type of announcement emitted when we observe an addition of faultinjections.policy.openservicemesh.io
+	FaultInjectionPolicyAdded Kind = "faultinjection-added"
+
+	// FaultInjectionPolicyDeleted the type of announcement emitted when we observe a deletion of faultinjections.policy.openservicemesh.io
+	FaultInjectionPolicyDeleted Kind = "faultinjection-deleted"
+
+	// FaultInjectionPolicyUpdated is the type of announcement emitted when we observe an update to faultinjections.policy.openservicemesh.io
+	FaultInjectionPolicyUpdated Kind = "faultinjection-updated"
+
+	// RequestAuthenticationAdded is the type of announcement emitted when we observe an addition of requestauthentications.policy.openservicemesh.io
+	RequestAuthenticationAdded Kind = "requestauthentication-added"
+
+	// RequestAuthenticationDeleted the type of announcement emitted when we observe a deletion of requestauthentications.policy.openservicemesh.io
+	RequestAuthenticationDeleted Kind = "requestauthentication-deleted"
+
+	// RequestAuthenticationUpdated is the type of announcement emitted when we observe an update to requestauthentications.policy.openservicemesh.io
+	RequestAuthenticationUpdated Kind = "requestauthentication-updated"
+
+	// RequestAuthenticationSecretAdded is the type of announcement emitted when we observe an addition of a Secret referenced by a requestauthentications.policy.openservicemesh.io
+	RequestAuthenticationSecretAdded Kind = "requestauthentication-secret-added"
+
+	// RequestAuthenticationSecretDeleted the type of announcement emitted when we observe a deletion of a Secret referenced by a requestauthentications.policy.openservicemesh.io
+	RequestAuthenticationSecretDeleted Kind = "requestauthentication-secret-deleted"
+
+	// RequestAuthenticationSecretUpdated is the type of announcement emitted when we observe an update to a Secret referenced by a requestauthentications.policy.openservicemesh.io
+	RequestAuthenticationSecretUpdated Kind = "requestauthentication-secret-updated"
+
 	// UpstreamTrafficSettingAdded is the type of announcement emitted when we observe an addition of upstreamtrafficsettings.policy.openservicemesh.io
 	UpstreamTrafficSettingAdded Kind = "upstreamtrafficsetting-added"
 
@@ -59,6 +59,16 @@ const (
 	LocalProxyModePodIP LocalProxyMode = "PodIP"
 )
 
+// PolicyConflictMode is a type alias representing how policies conflicting with existing policies are admitted
+type PolicyConflictMode string
+
+const (
+	// PolicyConflictModeReject indicates that policies conflicting with existing policies are rejected
+	PolicyConflictModeReject PolicyConflictMode = "Reject"
+	// PolicyConflictModeWarn indicates that policies conflicting with existing policies are admitted with a warning
+	PolicyConflictModeWarn PolicyConflictMode = "Warn"
+)
+
 // LocalDNSProxy is the type to represent OSM's local DNS proxy configuration.
 type LocalDNSProxy struct {
 	// Enable defines a boolean indicating if the sidecars are enabled for local DNS Proxy.
@@ -150,10 +160,18 @@ type TrafficSpec struct {
 	// for all inbound and ingress traffic in the mesh.
 	InboundExternalAuthorization ExternalAuthzSpec `json:"inboundExternalAuthorization,omitempty"`
 
+	// GlobalRateLimitService defines a remote rate limit service that, if enabled, is used to enforce
+	// the global rate limits configured for the upstream hosts in the mesh.
+	GlobalRateLimitService GlobalRateLimitServiceSpec `json:"globalRateLimitService,omitempty"`
+
 	// NetworkInterfaceExclusionList defines a global list of network interface
 	// names to exclude from inbound and outbound traffic interception by the
 	// sidecar proxy.
 	NetworkInterfaceExclusionList []string `json:"networkInterfaceExclusionList"`
+
+	// PolicyConflictMode defines how the validating webhook handles a policy conflicting with existing policies.
+	// Acceptable values are [`Reject`, `Warn`]. The default is `Reject`.
+	PolicyConflictMode PolicyConflictMode `json:"policyConflictMode,omitempty"`
 }
 
 // ObservabilitySpec is the type to represent OSM's observability configurations.
@@ -233,6 +251,29 @@ type ExternalAuthzSpec struct {
 	FailureModeAllow bool `json:"failureModeAllow"`
 }
 
+// GlobalRateLimitServiceSpec is a type to represent the remote rate limit service, speaking the
+// Envoy rate limit service (RLS) gRPC protocol, used to enforce global rate limits.
+type GlobalRateLimitServiceSpec struct {
+	// Enable defines a boolean indicating if global rate limiting is to be enabled.
+	Enable bool `json:"enable"`
+
+	// Address defines the remote address of the rate limit service.
+	Address string `json:"address,omitempty"`
+
+	// Port defines the destination port of the remote rate limit service.
+	Port uint16 `json:"port,omitempty"`
+
+	// Domain defines the domain the rate limit service applies its rate limit configuration for.
+	Domain string `json:"domain,omitempty"`
+
+	// Timeout defines the timeout in which a response from the rate limit service is expected.
+	Timeout string `json:"timeout,omitempty"`
+
+	// FailureModeAllow defines a boolean indicating if traffic should be allowed on a failure to get a
+	// response from the rate limit service.
+	FailureModeAllow bool `json:"failureModeAllow"`
+}
+
 // CertificateSpec is the type to reperesent OSM's certificate management configuration.
 type CertificateSpec struct {
 	// ServiceCertValidityDuration defines the service certificate validity duration.
@@ -244,6 +285,27 @@ type CertificateSpec struct {
 	// IngressGateway defines the certificate specification for an ingress gateway.
 	// +optional
 	IngressGateway *IngressGatewayCertSpec `json:"ingressGateway,omitempty"`
+
+	// SpiffeEnabled defines if service certificates carry the SPIFFE ID of the workload as a URI SAN.
+	// It applies to the certificate provider configured without a MeshRootCertificate.
+	// +optional
+	SpiffeEnabled bool `json:"spiffeEnabled,omitempty"`
+
+	// FederatedTrustDomains defines the trust bundles of other SPIFFE trust domains whose workloads
+	// can be authorized by the mesh. Each trust bundle only validates the SPIFFE IDs of its own trust domain,
+	// and peers are then authorized by their SPIFFE ID only, so federation requires SpiffeEnabled.
+	// Federation is only supported by Envoy sidecars.
+	// +optional
+	FederatedTrustDomains []FederatedTrustDomainSpec `json:"federatedTrustDomains,omitempty"`
+}
+
+// FederatedTrustDomainSpec is the type to represent a SPIFFE trust domain federated with the mesh.
+type FederatedTrustDomainSpec struct {
+	// TrustDomain defines the name of the federated trust domain, e.g. "example.com".
+	TrustDomain string `json:"trustDomain"`
+
+	// TrustBundle defines the PEM encoded root certificates of the federated trust domain.
+	TrustBundle string `json:"trustBundle"`
 }
 
 // IngressGatewayCertSpec is the type to represent the certificate specification for an ingress gateway.
@@ -299,6 +361,13 @@ type FeatureFlags struct {
 	// EnableRetryPolicy defines if retry policy is enabled.
 	EnableRetryPolicy bool `json:"enableRetryPolicy"`
 
+	// EnableFaultInjectionPolicy defines if fault injection policy is enabled.
+	EnableFaultInjectionPolicy bool `json:"enableFaultInjectionPolicy"`
+
+	// EnableRequestAuthenticationPolicy defines if OSM will use the RequestAuthentication API
+	// to require JWT authentication for inbound traffic to mesh backends.
+	EnableRequestAuthenticationPolicy bool `json:"enableRequestAuthenticationPolicy"`
+
 	// EnablePluginPolicy defines if plugin policy is enabled.
 	EnablePluginPolicy bool `json:"enablePluginPolicy"`
 }
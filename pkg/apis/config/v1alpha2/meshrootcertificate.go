@@ -33,6 +33,10 @@ type MeshRootCertificateSpec struct {
 
 	// TrustDomain is the trust domain to use as a suffix in Common Names for new certificates.
 	TrustDomain string `json:"trustDomain"`
+
+	// SpiffeEnabled defines if service certificates carry the SPIFFE ID of the workload as a URI SAN.
+	// +optional
+	SpiffeEnabled bool `json:"spiffeEnabled,omitempty"`
 }
 
 // ProviderSpec defines the certificate provider used by the mesh control plane
@@ -48,6 +52,10 @@ type ProviderSpec struct {
 	// Tresor specifies the Tresor provider configuration
 	// +optional
 	Tresor *TresorProviderSpec `json:"tresor,omitempty"`
+
+	// ExternalCA specifies the configuration of a provider signing certificates with an operator supplied intermediate CA
+	// +optional
+	ExternalCA *ExternalCAProviderSpec `json:"externalCA,omitempty"`
 }
 
 // CertManagerProviderSpec defines the configuration of the cert-manager provider
@@ -77,8 +85,60 @@ type VaultProviderSpec struct {
 	Protocol string `json:"protocol"`
 
 	// Token specifies the configuration of the token to be used by mesh control plane
-	// to connect to Vault
-	Token VaultTokenSpec `json:"token"`
+	// to connect to Vault. It is ignored when Auth is specified.
+	// +optional
+	Token VaultTokenSpec `json:"token,omitempty"`
+
+	// Auth specifies the Vault auth method used by mesh control plane to obtain
+	// its Vault token, instead of a static token
+	// +optional
+	Auth *VaultAuthSpec `json:"auth,omitempty"`
+
+	// Namespace specifies the Vault Enterprise namespace in which the PKI secrets engine
+	// and the auth method are mounted
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// VaultAuthSpec defines the Vault auth method used by mesh control plane.
+// Exactly one auth method must be specified.
+type VaultAuthSpec struct {
+	// Kubernetes specifies the configuration of the Kubernetes auth method,
+	// authenticating with the ServiceAccount token of mesh control plane
+	// +optional
+	Kubernetes *VaultKubernetesAuthSpec `json:"kubernetes,omitempty"`
+
+	// AppRole specifies the configuration of the AppRole auth method
+	// +optional
+	AppRole *VaultAppRoleAuthSpec `json:"appRole,omitempty"`
+}
+
+// VaultKubernetesAuthSpec defines the configuration of the Vault Kubernetes auth method
+type VaultKubernetesAuthSpec struct {
+	// Role specifies the name of the Vault role bound to the ServiceAccount of mesh control plane
+	Role string `json:"role"`
+
+	// MountPath specifies the path the Kubernetes auth method is mounted at, defaults to kubernetes
+	// +optional
+	MountPath string `json:"mountPath,omitempty"`
+
+	// ServiceAccountTokenPath specifies the path of the ServiceAccount token file,
+	// defaults to /var/run/secrets/kubernetes.io/serviceaccount/token
+	// +optional
+	ServiceAccountTokenPath string `json:"serviceAccountTokenPath,omitempty"`
+}
+
+// VaultAppRoleAuthSpec defines the configuration of the Vault AppRole auth method
+type VaultAppRoleAuthSpec struct {
+	// RoleID specifies the role ID of the AppRole
+	RoleID string `json:"roleID"`
+
+	// SecretIDRef specifies the secret in which the secret ID of the AppRole is stored
+	SecretIDRef SecretKeyReferenceSpec `json:"secretIDRef"`
+
+	// MountPath specifies the path the AppRole auth method is mounted at, defaults to approle
+	// +optional
+	MountPath string `json:"mountPath,omitempty"`
 }
 
 // VaultTokenSpec defines the configuration of the Vault token
@@ -111,11 +171,24 @@ type TresorCASpec struct {
 	SecretRef corev1.SecretReference `json:"secretRef"`
 }
 
+// ExternalCAProviderSpec defines the configuration of the external CA provider
+type ExternalCAProviderSpec struct {
+	// SecretRef specifies the secret in which the intermediate CA is stored.
+	// The secret holds the intermediate certificate, followed by any further intermediates, in tls.crt,
+	// its private key in tls.key and the root certificates the chain leads up to in ca.crt.
+	SecretRef corev1.SecretReference `json:"secretRef"`
+}
+
 // MeshRootCertificateStatus defines the status of the MeshRootCertificate resource
 type MeshRootCertificateStatus struct {
 	// State specifies the state of the certificate provider
 	// All states are specified in constants.go
 	State string `json:"state"`
+
+	// Conditions specifies the conditions of the root certificate through the stages of a root certificate rotation
+	// All condition types are specified in constants.go
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // MeshRootCertificateList defines the list of MeshRootCertificate objects
@@ -19,6 +19,7 @@ limitations under the License.
 package v1alpha2
 
 import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -46,6 +47,11 @@ func (in *CertificateSpec) DeepCopyInto(out *CertificateSpec) {
 		*out = new(IngressGatewayCertSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.FederatedTrustDomains != nil {
+		in, out := &in.FederatedTrustDomains, &out.FederatedTrustDomains
+		*out = make([]FederatedTrustDomainSpec, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -112,6 +118,23 @@ func (in *ExternalAuthzSpec) DeepCopy() *ExternalAuthzSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalCAProviderSpec) DeepCopyInto(out *ExternalCAProviderSpec) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalCAProviderSpec.
+func (in *ExternalCAProviderSpec) DeepCopy() *ExternalCAProviderSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalCAProviderSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FeatureFlags) DeepCopyInto(out *FeatureFlags) {
 	*out = *in
@@ -128,6 +151,38 @@ func (in *FeatureFlags) DeepCopy() *FeatureFlags {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FederatedTrustDomainSpec) DeepCopyInto(out *FederatedTrustDomainSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FederatedTrustDomainSpec.
+func (in *FederatedTrustDomainSpec) DeepCopy() *FederatedTrustDomainSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FederatedTrustDomainSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GlobalRateLimitServiceSpec) DeepCopyInto(out *GlobalRateLimitServiceSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalRateLimitServiceSpec.
+func (in *GlobalRateLimitServiceSpec) DeepCopy() *GlobalRateLimitServiceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GlobalRateLimitServiceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IngressGatewayCertSpec) DeepCopyInto(out *IngressGatewayCertSpec) {
 	*out = *in
@@ -256,7 +311,7 @@ func (in *MeshRootCertificate) DeepCopyInto(out *MeshRootCertificate) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -331,6 +386,13 @@ func (in *MeshRootCertificateSpec) DeepCopy() *MeshRootCertificateSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MeshRootCertificateStatus) DeepCopyInto(out *MeshRootCertificateStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -449,13 +511,18 @@ func (in *ProviderSpec) DeepCopyInto(out *ProviderSpec) {
 	if in.Vault != nil {
 		in, out := &in.Vault, &out.Vault
 		*out = new(VaultProviderSpec)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Tresor != nil {
 		in, out := &in.Tresor, &out.Tresor
 		*out = new(TresorProviderSpec)
 		**out = **in
 	}
+	if in.ExternalCA != nil {
+		in, out := &in.ExternalCA, &out.ExternalCA
+		*out = new(ExternalCAProviderSpec)
+		**out = **in
+	}
 	return
 }
 
@@ -616,6 +683,7 @@ func (in *TrafficSpec) DeepCopyInto(out *TrafficSpec) {
 		copy(*out, *in)
 	}
 	out.InboundExternalAuthorization = in.InboundExternalAuthorization
+	out.GlobalRateLimitService = in.GlobalRateLimitService
 	if in.NetworkInterfaceExclusionList != nil {
 		in, out := &in.NetworkInterfaceExclusionList, &out.NetworkInterfaceExclusionList
 		*out = make([]string, len(*in))
@@ -668,10 +736,74 @@ func (in *TresorProviderSpec) DeepCopy() *TresorProviderSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultAppRoleAuthSpec) DeepCopyInto(out *VaultAppRoleAuthSpec) {
+	*out = *in
+	out.SecretIDRef = in.SecretIDRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultAppRoleAuthSpec.
+func (in *VaultAppRoleAuthSpec) DeepCopy() *VaultAppRoleAuthSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultAppRoleAuthSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultAuthSpec) DeepCopyInto(out *VaultAuthSpec) {
+	*out = *in
+	if in.Kubernetes != nil {
+		in, out := &in.Kubernetes, &out.Kubernetes
+		*out = new(VaultKubernetesAuthSpec)
+		**out = **in
+	}
+	if in.AppRole != nil {
+		in, out := &in.AppRole, &out.AppRole
+		*out = new(VaultAppRoleAuthSpec)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultAuthSpec.
+func (in *VaultAuthSpec) DeepCopy() *VaultAuthSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultAuthSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultKubernetesAuthSpec) DeepCopyInto(out *VaultKubernetesAuthSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultKubernetesAuthSpec.
+func (in *VaultKubernetesAuthSpec) DeepCopy() *VaultKubernetesAuthSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultKubernetesAuthSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VaultProviderSpec) DeepCopyInto(out *VaultProviderSpec) {
 	*out = *in
 	out.Token = in.Token
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(VaultAuthSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
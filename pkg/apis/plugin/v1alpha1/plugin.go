@@ -51,4 +51,14 @@ type PluginStatus struct {
 	// Reason defines the reason for the current status of a Plugin resource.
 	// +optional
 	Reason string `json:"reason,omitempty"`
+
+	// ObservedGeneration defines the generation of a Plugin resource the conditions were computed for.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions defines the Accepted, ResolvedRefs and Attached conditions of a Plugin resource.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
@@ -69,4 +69,14 @@ type PluginChainStatus struct {
 	// Reason defines the reason for the current status of a PluginChain resource.
 	// +optional
 	Reason string `json:"reason,omitempty"`
+
+	// ObservedGeneration defines the generation of a PluginChain resource the conditions were computed for.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions defines the Accepted, ResolvedRefs and Attached conditions of a PluginChain resource.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
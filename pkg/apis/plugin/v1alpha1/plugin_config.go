@@ -56,4 +56,14 @@ type PluginConfigStatus struct {
 	// Reason defines the reason for the current status of a PluginConfig resource.
 	// +optional
 	Reason string `json:"reason,omitempty"`
+
+	// ObservedGeneration defines the generation of a PluginConfig resource the conditions were computed for.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions defines the Accepted, ResolvedRefs and Attached conditions of a PluginConfig resource.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
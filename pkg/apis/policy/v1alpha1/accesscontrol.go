@@ -86,4 +86,14 @@ type AccessControlStatus struct {
 	// Reason defines the reason for the current status of an AccessControl resource.
 	// +optional
 	Reason string `json:"reason,omitempty"`
+
+	// ObservedGeneration defines the generation of an AccessControl resource the conditions were computed for.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions defines the Accepted, ResolvedRefs and Attached conditions of an AccessControl resource.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
@@ -0,0 +1,49 @@
+package v1alpha1
+
+// PolicyConditionType is the type of a condition of the status of a policy resource.
+type PolicyConditionType string
+
+const (
+	// PolicyConditionAccepted indicates whether the policy is accepted by the controller.
+	// It is False when the API of the policy is disabled or when the policy is invalid.
+	PolicyConditionAccepted PolicyConditionType = "Accepted"
+
+	// PolicyConditionResolvedRefs indicates whether all the resources referenced by the policy exist.
+	PolicyConditionResolvedRefs PolicyConditionType = "ResolvedRefs"
+
+	// PolicyConditionAttached indicates whether the policy is part of the config generated for at least one sidecar.
+	// It does not indicate whether the sidecars applied the config.
+	PolicyConditionAttached PolicyConditionType = "Attached"
+)
+
+// PolicyConditionReason is the reason of a condition of the status of a policy resource.
+type PolicyConditionReason string
+
+const (
+	// PolicyReasonAccepted is the reason of a True Accepted condition.
+	PolicyReasonAccepted PolicyConditionReason = "Accepted"
+
+	// PolicyReasonDisabled is the reason of a False Accepted condition when the API of the policy is disabled
+	// by the feature flags of the MeshConfig.
+	PolicyReasonDisabled PolicyConditionReason = "Disabled"
+
+	// PolicyReasonInvalid is the reason of a False Accepted condition when the policy cannot be processed.
+	PolicyReasonInvalid PolicyConditionReason = "Invalid"
+
+	// PolicyReasonResolvedRefs is the reason of a True ResolvedRefs condition.
+	PolicyReasonResolvedRefs PolicyConditionReason = "ResolvedRefs"
+
+	// PolicyReasonRefNotFound is the reason of a False ResolvedRefs condition when a referenced resource
+	// does not exist.
+	PolicyReasonRefNotFound PolicyConditionReason = "RefNotFound"
+
+	// PolicyReasonAttached is the reason of a True Attached condition.
+	PolicyReasonAttached PolicyConditionReason = "Attached"
+
+	// PolicyReasonNoProxies is the reason of a False Attached condition when the policy applies to no sidecar.
+	PolicyReasonNoProxies PolicyConditionReason = "NoProxies"
+
+	// PolicyReasonPending is the reason of a False Attached condition when the policy is not accepted or
+	// references resources which do not exist.
+	PolicyReasonPending PolicyConditionReason = "Pending"
+)
@@ -10,7 +10,6 @@ import (
 // external to the service mesh or cluster based on the specified
 // rules in the policy.
 // +genclient
-// +genclient:noStatus
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 type Egress struct {
 	// Object's type metadata
@@ -23,6 +22,10 @@ type Egress struct {
 	// Spec is the Egress policy specification
 	// +optional
 	Spec EgressSpec `json:"spec,omitempty"`
+
+	// Status is the status of the Egress policy.
+	// +optional
+	Status EgressStatus `json:"status,omitempty"`
 }
 
 // EgressSpec is the type used to represent the Egress policy specification.
@@ -104,6 +107,19 @@ type PortSpec struct {
 	Protocol string `json:"protocol"`
 }
 
+// EgressStatus is the type used to represent the status of an Egress resource.
+type EgressStatus struct {
+	// ObservedGeneration defines the generation of an Egress resource the conditions were computed for.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions defines the Accepted, ResolvedRefs and Attached conditions of an Egress resource.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
 // EgressList defines the list of Egress objects.
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 type EgressList struct {
@@ -7,7 +7,6 @@ import (
 
 // EgressGateway is the type used to represent an Egress Gateway policy.
 // +genclient
-// +genclient:noStatus
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 type EgressGateway struct {
 	// Object's type metadata
@@ -20,6 +19,10 @@ type EgressGateway struct {
 	// Spec is the EgressGateway policy specification
 	// +optional
 	Spec EgressGatewaySpec `json:"spec,omitempty"`
+
+	// Status is the status of the EgressGateway policy.
+	// +optional
+	Status EgressGatewayStatus `json:"status,omitempty"`
 }
 
 // EgressGatewaySpec is the type used to represent the Egress Gateway specification.
@@ -54,6 +57,19 @@ type GatewayBindingSubject struct {
 	Weight    *int   `json:"weight,omitempty"`
 }
 
+// EgressGatewayStatus is the type used to represent the status of an EgressGateway resource.
+type EgressGatewayStatus struct {
+	// ObservedGeneration defines the generation of an EgressGateway resource the conditions were computed for.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions defines the Accepted, ResolvedRefs and Attached conditions of an EgressGateway resource.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
 // EgressGatewayList defines the list of EgressGateway objects.
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 type EgressGatewayList struct {
@@ -0,0 +1,108 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// FaultInjection is the type used to represent a FaultInjection policy.
+// A FaultInjection policy injects delays and aborts into the outbound HTTP
+// traffic from one service source to one or more destination services.
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type FaultInjection struct {
+	// Object's type metadata
+	metav1.TypeMeta `json:",inline"`
+
+	// Object's metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec is the FaultInjection policy specification
+	// +optional
+	Spec FaultInjectionSpec `json:"spec,omitempty"`
+
+	// Status is the status of the FaultInjection policy.
+	// +optional
+	Status FaultInjectionStatus `json:"status,omitempty"`
+}
+
+// FaultInjectionSpec is the type used to represent the FaultInjection policy specification.
+type FaultInjectionSpec struct {
+	// Source defines the source the FaultInjection policy applies to.
+	Source FaultInjectionSrcDstSpec `json:"source"`
+
+	// Destinations defines the list of destinations the FaultInjection policy applies to.
+	Destinations []FaultInjectionSrcDstSpec `json:"destinations"`
+
+	// HTTPRoutes defines the list of HTTP routes faults are injected for.
+	HTTPRoutes []HTTPFaultInjectionRouteSpec `json:"httpRoutes"`
+}
+
+// FaultInjectionSrcDstSpec is the type used to represent the Destination in the list of Destinations and the Source
+// specified in the FaultInjection policy specification.
+type FaultInjectionSrcDstSpec struct {
+	// Kind defines the kind for the Src/Dst in the FaultInjection policy.
+	Kind string `json:"kind"`
+
+	// Name defines the name of the Src/Dst for the given Kind.
+	Name string `json:"name"`
+
+	// Namespace defines the namespace for the given Src/Dst.
+	Namespace string `json:"namespace"`
+}
+
+// HTTPFaultInjectionRouteSpec defines the faults injected for an HTTP route.
+type HTTPFaultInjectionRouteSpec struct {
+	// Path defines the HTTP path as an RE2 regex value.
+	// Faults are injected for all requests if not specified.
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// Delay defines the delay injected before requests are forwarded upstream.
+	// +optional
+	Delay *HTTPFaultDelaySpec `json:"delay,omitempty"`
+
+	// Abort defines the error response injected in place of the upstream response.
+	// +optional
+	Abort *HTTPFaultAbortSpec `json:"abort,omitempty"`
+}
+
+// HTTPFaultDelaySpec defines the delay injected for an HTTP route.
+type HTTPFaultDelaySpec struct {
+	// Percentage defines the percentage of requests to delay, in the range 0-100.
+	Percentage uint32 `json:"percentage"`
+
+	// FixedDelay defines the duration requests are delayed for.
+	FixedDelay metav1.Duration `json:"fixedDelay"`
+}
+
+// HTTPFaultAbortSpec defines the abort injected for an HTTP route.
+type HTTPFaultAbortSpec struct {
+	// Percentage defines the percentage of requests to abort, in the range 0-100.
+	Percentage uint32 `json:"percentage"`
+
+	// HTTPStatus defines the HTTP status code returned for aborted requests.
+	HTTPStatus uint32 `json:"httpStatus"`
+}
+
+// FaultInjectionStatus is the type used to represent the status of a FaultInjection resource.
+type FaultInjectionStatus struct {
+	// ObservedGeneration defines the generation of a FaultInjection resource the conditions were computed for.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions defines the Accepted, ResolvedRefs and Attached conditions of a FaultInjection resource.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// FaultInjectionList defines the list of FaultInjection objects.
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type FaultInjectionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []FaultInjection `json:"items"`
+}
@@ -108,4 +108,14 @@ type IngressBackendStatus struct {
 	// Reason defines the reason for the current status of an IngressBackend resource.
 	// +optional
 	Reason string `json:"reason,omitempty"`
+
+	// ObservedGeneration defines the generation of an IngressBackend resource the conditions were computed for.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions defines the Accepted, ResolvedRefs and Attached conditions of an IngressBackend resource.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
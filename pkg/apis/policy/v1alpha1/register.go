@@ -47,6 +47,10 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&AccessControlList{},
 		&AccessCert{},
 		&AccessCertList{},
+		&FaultInjection{},
+		&FaultInjectionList{},
+		&RequestAuthentication{},
+		&RequestAuthenticationList{},
 		&Retry{},
 		&RetryList{},
 		&UpstreamTrafficSetting{},
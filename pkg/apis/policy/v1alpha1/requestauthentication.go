@@ -0,0 +1,149 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RequestAuthentication is the type used to represent a RequestAuthentication policy.
+// A RequestAuthentication policy requires requests to one or more backends to carry
+// a valid JSON Web Token (JWT), and optionally authorizes them based on its claims.
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type RequestAuthentication struct {
+	// Object's type metadata
+	metav1.TypeMeta `json:",inline"`
+
+	// Object's metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec is the RequestAuthentication policy specification
+	// +optional
+	Spec RequestAuthenticationSpec `json:"spec,omitempty"`
+
+	// Status is the status of the RequestAuthentication configuration.
+	// +optional
+	Status RequestAuthenticationStatus `json:"status,omitempty"`
+}
+
+// RequestAuthenticationSpec is the type used to represent the RequestAuthentication policy specification.
+type RequestAuthenticationSpec struct {
+	// Backends defines the list of backends the RequestAuthentication policy applies to.
+	Backends []RequestAuthenticationBackendSpec `json:"backends"`
+
+	// JWTRules defines the list of JWT issuers trusted by the backends.
+	// A request must carry a JWT that is valid for one of the issuers.
+	JWTRules []JWTRuleSpec `json:"jwtRules"`
+
+	// Rules defines the list of authorization rules matched against the claims of a valid JWT.
+	// A request is allowed if it matches any of the rules. If no rules are specified,
+	// any request carrying a valid JWT is allowed.
+	// +optional
+	Rules []JWTAuthorizationRuleSpec `json:"rules,omitempty"`
+}
+
+// RequestAuthenticationBackendSpec is the type used to represent a Backend specified in the RequestAuthentication policy specification.
+type RequestAuthenticationBackendSpec struct {
+	// Name defines the name of the backend.
+	Name string `json:"name"`
+
+	// Port defines the specification for the backend's port.
+	Port PortSpec `json:"port"`
+}
+
+// JWTRuleSpec is the type used to represent a JWT issuer trusted by the backends.
+type JWTRuleSpec struct {
+	// Issuer defines the issuer of the JWT, matched against its 'iss' claim.
+	Issuer string `json:"issuer"`
+
+	// Audiences defines the list of audiences allowed to access the backends,
+	// matched against the 'aud' claim of the JWT. If not specified, the audience is not checked.
+	// +optional
+	Audiences []string `json:"audiences,omitempty"`
+
+	// JWKS defines the inline JSON Web Key Set used to verify the signature of the JWT.
+	// Exactly one of JWKS or JWKSSecretRef must be specified.
+	// +optional
+	JWKS string `json:"jwks,omitempty"`
+
+	// JWKSSecretRef defines the Secret in the namespace of the RequestAuthentication
+	// policy holding the JSON Web Key Set used to verify the signature of the JWT.
+	// Exactly one of JWKS or JWKSSecretRef must be specified.
+	// +optional
+	JWKSSecretRef *JWKSSecretReferenceSpec `json:"jwksSecretRef,omitempty"`
+
+	// ForwardOriginalToken defines whether the JWT is forwarded to the backend.
+	// Defaults to false, in which case the JWT is removed from the request.
+	// +optional
+	ForwardOriginalToken bool `json:"forwardOriginalToken,omitempty"`
+
+	// ClaimToHeaders defines the list of claims of the JWT copied to request headers.
+	// +optional
+	ClaimToHeaders []ClaimToHeaderSpec `json:"claimToHeaders,omitempty"`
+}
+
+// JWKSSecretReferenceSpec is the type used to represent a reference to the Secret holding a JSON Web Key Set.
+type JWKSSecretReferenceSpec struct {
+	// Name defines the name of the Secret.
+	Name string `json:"name"`
+
+	// Key defines the key of the Secret data holding the JSON Web Key Set.
+	// Defaults to 'jwks'.
+	// +optional
+	Key string `json:"key,omitempty"`
+}
+
+// ClaimToHeaderSpec is the type used to represent a claim of the JWT copied to a request header.
+type ClaimToHeaderSpec struct {
+	// Claim defines the name of the claim. Nested claims are separated by '.'.
+	Claim string `json:"claim"`
+
+	// Header defines the name of the request header the claim is copied to.
+	Header string `json:"header"`
+}
+
+// JWTAuthorizationRuleSpec is the type used to represent an authorization rule matched against the claims of a JWT.
+type JWTAuthorizationRuleSpec struct {
+	// Claims defines the list of claims the JWT must match, all of which must match.
+	Claims []JWTClaimMatchSpec `json:"claims"`
+}
+
+// JWTClaimMatchSpec is the type used to represent a match on a claim of a JWT.
+type JWTClaimMatchSpec struct {
+	// Name defines the name of the claim. Nested claims are separated by '.'.
+	Name string `json:"name"`
+
+	// Values defines the list of allowed values of the claim. A claim whose value is
+	// a list matches if any of its elements is one of the allowed values.
+	Values []string `json:"values"`
+}
+
+// RequestAuthenticationList defines the list of RequestAuthentication objects.
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type RequestAuthenticationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []RequestAuthentication `json:"items"`
+}
+
+// RequestAuthenticationStatus is the type used to represent the status of a RequestAuthentication resource.
+type RequestAuthenticationStatus struct {
+	// CurrentStatus defines the current status of a RequestAuthentication resource.
+	// +optional
+	CurrentStatus string `json:"currentStatus,omitempty"`
+
+	// Reason defines the reason for the current status of a RequestAuthentication resource.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// ObservedGeneration defines the generation of a RequestAuthentication resource the conditions were computed for.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions defines the Accepted, ResolvedRefs and Attached conditions of a RequestAuthentication resource.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
@@ -20,6 +20,10 @@ type Retry struct {
 	// Spec is the Retry policy specification
 	// +optional
 	Spec RetrySpec `json:"spec,omitempty"`
+
+	// Status is the status of the Retry policy.
+	// +optional
+	Status RetryStatus `json:"status,omitempty"`
 }
 
 // RetrySpec is the type used to represent the Retry policy specification.
@@ -65,6 +69,19 @@ type RetryPolicySpec struct {
 	RetryBackoffBaseInterval *metav1.Duration `json:"retryBackoffBaseInterval"`
 }
 
+// RetryStatus is the type used to represent the status of a Retry resource.
+type RetryStatus struct {
+	// ObservedGeneration defines the generation of a Retry resource the conditions were computed for.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions defines the Accepted, ResolvedRefs and Attached conditions of a Retry resource.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
 // RetryList defines the list of Retry objects.
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 type RetryList struct {
@@ -39,6 +39,16 @@ type UpstreamTrafficSettingSpec struct {
 	// +optional
 	ConnectionSettings *ConnectionSettingsSpec `json:"connectionSettings,omitempty"`
 
+	// OutlierDetection specifies the settings to passively detect and
+	// eject unhealthy endpoints of the upstream host.
+	// +optional
+	OutlierDetection *OutlierDetectionSpec `json:"outlierDetection,omitempty"`
+
+	// LoadBalancer specifies the load balancing settings for traffic
+	// directed to the upstream host.
+	// +optional
+	LoadBalancer *LoadBalancerSpec `json:"loadBalancer,omitempty"`
+
 	// RateLimit specifies the rate limit settings for the traffic
 	// directed to the upstream host.
 	// If HTTP rate limiting is specified, the rate limiting is applied
@@ -82,6 +92,105 @@ type TCPConnectionSettings struct {
 	ConnectTimeout *metav1.Duration `json:"connectTimeout,omitempty"`
 }
 
+// OutlierDetectionSpec defines the outlier detection settings for an
+// upstream host. An endpoint of the upstream host is ejected from the
+// load balancing pool after returning a number of consecutive errors,
+// and is returned to the pool once its ejection time has elapsed.
+type OutlierDetectionSpec struct {
+	// ConsecutiveErrors specifies the number of consecutive 5xx responses,
+	// including gateway errors and connection failures, after which an
+	// endpoint is ejected.
+	// Defaults to 5 if not specified.
+	// +optional
+	ConsecutiveErrors *uint32 `json:"consecutiveErrors,omitempty"`
+
+	// Interval specifies the time interval between ejection sweep analysis.
+	// Defaults to 10s if not specified.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// BaseEjectionTime specifies the base time an endpoint is ejected for.
+	// The actual ejection time is the base ejection time multiplied by the
+	// number of times the endpoint has been ejected.
+	// Defaults to 30s if not specified.
+	// +optional
+	BaseEjectionTime *metav1.Duration `json:"baseEjectionTime,omitempty"`
+
+	// MaxEjectionPercent specifies the maximum percentage of endpoints
+	// of the upstream host that can be ejected at the same time, between
+	// 0 and 100.
+	// Defaults to 10 if not specified.
+	// +optional
+	MaxEjectionPercent *uint32 `json:"maxEjectionPercent,omitempty"`
+}
+
+// LoadBalancerType defines the load balancing algorithm used to distribute
+// traffic across the endpoints of an upstream host.
+type LoadBalancerType string
+
+const (
+	// LoadBalancerRoundRobin selects endpoints in a weighted round robin order.
+	LoadBalancerRoundRobin LoadBalancerType = "RoundRobin"
+
+	// LoadBalancerLeastRequest selects the endpoint with the fewest active requests.
+	LoadBalancerLeastRequest LoadBalancerType = "LeastRequest"
+
+	// LoadBalancerRandom selects a random endpoint.
+	LoadBalancerRandom LoadBalancerType = "Random"
+
+	// LoadBalancerRingHash selects endpoints using consistent hashing on a hash ring.
+	LoadBalancerRingHash LoadBalancerType = "RingHash"
+
+	// LoadBalancerMaglev selects endpoints using Maglev consistent hashing.
+	LoadBalancerMaglev LoadBalancerType = "Maglev"
+)
+
+// LoadBalancerSpec defines the load balancing settings for an upstream host.
+type LoadBalancerSpec struct {
+	// Type defines the load balancing algorithm.
+	// Defaults to RoundRobin if not specified.
+	// +optional
+	Type LoadBalancerType `json:"type,omitempty"`
+
+	// HashKeys defines the keys hashed to select an endpoint when the
+	// RingHash or Maglev algorithm is used. Keys are evaluated in order,
+	// and the first key present on a request is used. Requests without
+	// any of the keys are distributed randomly.
+	// +optional
+	HashKeys []LoadBalancerHashKeySpec `json:"hashKeys,omitempty"`
+}
+
+// LoadBalancerHashKeySpec defines a key hashed by the consistent hashing
+// load balancing algorithms. Exactly one of the fields must be specified.
+type LoadBalancerHashKeySpec struct {
+	// Header defines the name of the HTTP header whose value is hashed.
+	// +optional
+	Header string `json:"header,omitempty"`
+
+	// Cookie defines the HTTP cookie whose value is hashed.
+	// +optional
+	Cookie *LoadBalancerCookieSpec `json:"cookie,omitempty"`
+
+	// SourceIP defines whether the source IP address of the downstream
+	// client is hashed.
+	// +optional
+	SourceIP bool `json:"sourceIP,omitempty"`
+}
+
+// LoadBalancerCookieSpec defines the HTTP cookie hashed by the consistent
+// hashing load balancing algorithms.
+type LoadBalancerCookieSpec struct {
+	// Name defines the name of the cookie.
+	Name string `json:"name"`
+
+	// TTL defines the lifetime of the cookie generated by the sidecar when
+	// a request does not carry the cookie, so that subsequent requests from
+	// the same client stick to the same endpoint.
+	// No cookie is generated if not specified.
+	// +optional
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+}
+
 // HTTPCircuitBreaking defines the HTTP Circuit Breaking settings for an
 // upstream host.
 type HTTPCircuitBreaking struct {
@@ -160,6 +269,59 @@ type RateLimitSpec struct {
 	// This is applied as a token bucket rate limiter.
 	// +optional
 	Local *LocalRateLimitSpec `json:"local,omitempty"`
+
+	// Global specifies the global rate limiting specification
+	// for the upstream host.
+	// Global rate limiting is enforced by the remote rate limit
+	// service configured in the MeshConfig, which shares the rate
+	// limits across all the downstream clients of the upstream host.
+	// The rate limits are configured in the rate limit service for
+	// the descriptors sent by the downstream clients.
+	// +optional
+	Global *GlobalRateLimitSpec `json:"global,omitempty"`
+}
+
+// GlobalRateLimitSpec defines the global rate limiting specification
+// for the upstream host.
+type GlobalRateLimitSpec struct {
+	// Descriptors defines the list of rate limit descriptors sent to the
+	// rate limit service for each HTTP request to the upstream host.
+	// Each descriptor starts with a "destination" entry whose value is
+	// the upstream host. A request is rate limited if any of its
+	// descriptors is over the limit.
+	Descriptors []RateLimitDescriptorSpec `json:"descriptors"`
+}
+
+// RateLimitDescriptorSpec defines a rate limit descriptor built from
+// the attributes of an HTTP request. A descriptor is only sent if all of
+// its entries can be built from the request.
+type RateLimitDescriptorSpec struct {
+	// Entries defines the list of entries of the descriptor.
+	Entries []RateLimitDescriptorEntrySpec `json:"entries"`
+}
+
+// RateLimitDescriptorEntrySpec defines an entry of a rate limit descriptor.
+// Exactly one of Header, SourceIdentity or Path must be specified.
+type RateLimitDescriptorEntrySpec struct {
+	// Key defines the key of the descriptor entry.
+	// Defaults to the header name for header entries, "source_identity"
+	// for source identity entries and "path" for path entries.
+	// +optional
+	Key string `json:"key,omitempty"`
+
+	// Header defines the name of the HTTP header whose value is used
+	// as the entry value.
+	// +optional
+	Header string `json:"header,omitempty"`
+
+	// SourceIdentity defines whether the service identity of the
+	// downstream client is used as the entry value.
+	// +optional
+	SourceIdentity bool `json:"sourceIdentity,omitempty"`
+
+	// Path defines whether the HTTP request path is used as the entry value.
+	// +optional
+	Path bool `json:"path,omitempty"`
 }
 
 // LocalRateLimitSpec defines the local rate limiting specification
@@ -247,6 +409,46 @@ type HTTPRouteSpec struct {
 	// RateLimit defines the HTTP rate limiting specification for
 	// the specified HTTP route.
 	RateLimit *HTTPPerRouteRateLimitSpec `json:"rateLimit,omitempty"`
+
+	// Timeout defines the timeout settings for the specified HTTP route.
+	// +optional
+	Timeout *HTTPRouteTimeoutSpec `json:"timeout,omitempty"`
+
+	// Mirror defines the traffic mirroring settings for the specified HTTP route.
+	// +optional
+	Mirror *HTTPRouteMirrorSpec `json:"mirror,omitempty"`
+}
+
+// HTTPRouteTimeoutSpec defines the timeout settings per HTTP route.
+type HTTPRouteTimeoutSpec struct {
+	// Request defines the timeout for the entire request, measured from
+	// when the request is received until the response has been completely
+	// processed. A value of 0s disables the timeout.
+	// Defaults to 0s (disabled) if not specified.
+	// +optional
+	Request *metav1.Duration `json:"request,omitempty"`
+
+	// Idle defines the amount of time the stream for a request may remain
+	// idle, with no upstream or downstream activity, before it is reset.
+	// A value of 0s disables the timeout.
+	// Defaults to the sidecar's stream idle timeout if not specified.
+	// +optional
+	Idle *metav1.Duration `json:"idle,omitempty"`
+}
+
+// HTTPRouteMirrorSpec defines the settings to mirror (shadow) the requests
+// on an HTTP route to a secondary backend. Mirrored requests are sent in a
+// fire-and-forget manner: responses from the mirror backend are discarded.
+type HTTPRouteMirrorSpec struct {
+	// Service defines the name of the service requests are mirrored to.
+	// The service must belong to the same namespace as the upstream host,
+	// and must be reachable by the downstream clients.
+	Service string `json:"service"`
+
+	// Percentage defines the percentage of requests to mirror, in the range 0-100.
+	// Defaults to 100 if not specified.
+	// +optional
+	Percentage *uint32 `json:"percentage,omitempty"`
 }
 
 // HTTPPerRouteRateLimitSpec defines the rate limiting specification
@@ -266,6 +468,16 @@ type UpstreamTrafficSettingStatus struct {
 	// Reason defines the reason for the current status of an UpstreamTrafficSetting resource.
 	// +optional
 	Reason string `json:"reason,omitempty"`
+
+	// ObservedGeneration defines the generation of an UpstreamTrafficSetting resource the conditions were computed for.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions defines the Accepted, ResolvedRefs and Attached conditions of an UpstreamTrafficSetting resource.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // UpstreamTrafficSettingList defines the list of UpstreamTrafficSetting objects.
@@ -30,10 +30,6 @@ func (mc *MeshCatalog) GetAccessControlTrafficPolicy(svc service.MeshService) (*
 		return nil, nil
 	}
 
-	// The status field will be updated after the policy is processed.
-	// Note: The original pointer returned by cache.Store must not be modified for thread safety.
-	aclWithStatus := *aclPolicy
-
 	var trafficRoutingRules []*trafficpolicy.Rule
 	sourcePrincipals := mapset.NewSet()
 	var trafficMatches []*trafficpolicy.AccessControlTrafficMatch
@@ -67,13 +63,6 @@ func (mc *MeshCatalog) GetAccessControlTrafficPolicy(svc service.MeshService) (*
 				}
 				endpoints := mc.listEndpointsForService(sourceMeshSvc)
 				if len(endpoints) == 0 {
-					aclWithStatus.Status = policyV1alpha1.AccessControlStatus{
-						CurrentStatus: "error",
-						Reason:        fmt.Sprintf("endpoints not found for service %s/%s", source.Namespace, source.Name),
-					}
-					if _, err := mc.kubeController.UpdateStatus(&aclWithStatus); err != nil {
-						log.Error().Err(err).Msg("Error updating status for AccessControl")
-					}
 					return nil, fmt.Errorf("Could not list endpoints of the source service %s/%s specified in the AccessControl %s/%s",
 						source.Namespace, source.Name, aclPolicy.Namespace, aclPolicy.Name)
 				}
@@ -142,14 +131,6 @@ func (mc *MeshCatalog) GetAccessControlTrafficPolicy(svc service.MeshService) (*
 		return nil, nil
 	}
 
-	aclWithStatus.Status = policyV1alpha1.AccessControlStatus{
-		CurrentStatus: "committed",
-		Reason:        "successfully committed by the system",
-	}
-	if _, err := mc.kubeController.UpdateStatus(&aclWithStatus); err != nil {
-		log.Error().Err(err).Msg("Error updating status for AccessControl")
-	}
-
 	// Create an inbound traffic policy from the routing rules
 	// TODO(#3779): Implement HTTP route matching from AccessControl.Spec.Matches
 	var httpRoutePolicy *trafficpolicy.InboundTrafficPolicy
@@ -0,0 +1,625 @@
+package catalog
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+
+	access "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/access/v1alpha3"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	policyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/k8s"
+	"github.com/openservicemesh/osm/pkg/policy"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/smi"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+const (
+	// inboundChainPrefix is the prefix of the names of the plugin chains run on inbound traffic
+	inboundChainPrefix = "inbound-"
+
+	// outboundChainPrefix is the prefix of the names of the plugin chains run on outbound traffic
+	outboundChainPrefix = "outbound-"
+)
+
+// trafficExplainRequest is the traffic explained by ExplainTraffic
+type trafficExplainRequest struct {
+	source              *corev1.Pod
+	destination         *corev1.Pod
+	sourceMeshed        bool
+	destinationMeshed   bool
+	sourceIdentity      identity.ServiceIdentity
+	destinationIdentity identity.ServiceIdentity
+	port                uint16
+	method              string
+	path                string
+
+	// destinationServices are the services of the destination pod exposing the port
+	destinationServices []service.MeshService
+}
+
+// ExplainTraffic explains how the policies of the mesh apply to traffic from the source pod to the destination pod on
+// the given port: the verdict of each mechanism, the objects it matched and the rule which decided it, along with the
+// retry, rate limiting and circuit breaking settings applied to the traffic.
+// The routes of the policies are matched regardless of the HTTP method or path when they are empty.
+func (mc *MeshCatalog) ExplainTraffic(source, destination *corev1.Pod, port uint16, method, path string) *trafficpolicy.TrafficExplanation {
+	req := trafficExplainRequest{
+		source:              source,
+		destination:         destination,
+		sourceMeshed:        isMeshedPod(source),
+		destinationMeshed:   isMeshedPod(destination),
+		sourceIdentity:      podServiceIdentity(source),
+		destinationIdentity: podServiceIdentity(destination),
+		port:                port,
+		method:              method,
+		path:                path,
+		destinationServices: mc.listServicesForPodPort(destination, port),
+	}
+
+	explanation := &trafficpolicy.TrafficExplanation{
+		Source:      explainedPod(source, req.sourceMeshed),
+		Destination: explainedPod(destination, req.destinationMeshed),
+		Port:        port,
+		Method:      method,
+		Path:        path,
+		Mechanisms: []trafficpolicy.MechanismExplanation{
+			mc.explainPermissive(req),
+			mc.explainSMI(req),
+			mc.explainAccessControl(req),
+			mc.explainIngressBackend(req),
+			mc.explainEgress(req),
+			mc.explainPlugins(req),
+		},
+		Settings: mc.explainSettings(req),
+	}
+	for _, svc := range req.destinationServices {
+		explanation.DestinationServices = append(explanation.DestinationServices, fmt.Sprintf("%s/%s:%d", svc.Namespace, svc.Name, svc.Port))
+	}
+
+	for _, mechanism := range explanation.Mechanisms {
+		if mechanism.Verdict == trafficpolicy.ExplainVerdictAllow {
+			explanation.Allowed = true
+			explanation.Reason = mechanism.Rule
+			return explanation
+		}
+	}
+
+	switch {
+	case !req.sourceMeshed && !req.destinationMeshed:
+		explanation.Allowed = true
+		explanation.Reason = "Neither pod is part of the mesh, the traffic is not subject to the policies of the mesh"
+	case !req.destinationMeshed:
+		explanation.Reason = "No Egress policy allows the traffic to the destination outside the mesh and egress is disabled"
+	case len(req.destinationServices) == 0:
+		explanation.Reason = fmt.Sprintf("No service of the destination pod exposes port %d", port)
+	case !req.sourceMeshed:
+		explanation.Reason = "The source is outside the mesh and no AccessControl or IngressBackend policy allows its traffic"
+	default:
+		explanation.Reason = "No TrafficTarget, AccessControl or IngressBackend policy allows the traffic"
+	}
+	return explanation
+}
+
+// explainPermissive explains the verdict of the permissive traffic policy mode
+func (mc *MeshCatalog) explainPermissive(req trafficExplainRequest) trafficpolicy.MechanismExplanation {
+	mechanism := trafficpolicy.MechanismExplanation{
+		Mechanism: trafficpolicy.ExplainMechanismPermissive,
+		Verdict:   trafficpolicy.ExplainVerdictNotApplicable,
+	}
+
+	switch {
+	case !mc.configurator.IsPermissiveTrafficPolicyMode():
+		mechanism.Rule = "Permissive traffic policy mode is disabled"
+	case !req.sourceMeshed || !req.destinationMeshed || len(req.destinationServices) == 0:
+		mechanism.Rule = "Permissive traffic policy mode only applies to traffic between services of meshed pods"
+	default:
+		mechanism.Verdict = trafficpolicy.ExplainVerdictAllow
+		mechanism.Rule = "Permissive traffic policy mode allows traffic between all the services of meshed pods"
+	}
+	return mechanism
+}
+
+// explainSMI explains the verdict of the SMI TrafficTargets and the routes they reference
+func (mc *MeshCatalog) explainSMI(req trafficExplainRequest) trafficpolicy.MechanismExplanation {
+	mechanism := trafficpolicy.MechanismExplanation{
+		Mechanism: trafficpolicy.ExplainMechanismSMI,
+		Verdict:   trafficpolicy.ExplainVerdictNotApplicable,
+	}
+
+	if mc.configurator.IsPermissiveTrafficPolicyMode() {
+		mechanism.Rule = "SMI policies are ignored in permissive traffic policy mode"
+		return mechanism
+	}
+	if !req.sourceMeshed || !req.destinationMeshed || len(req.destinationServices) == 0 {
+		mechanism.Rule = "SMI policies only apply to traffic between services of meshed pods"
+		return mechanism
+	}
+
+	mechanism.Verdict = trafficpolicy.ExplainVerdictDeny
+	trafficTargetsWithRoutes, err := mc.ListInboundTrafficTargetsWithRoutes(req.destinationIdentity)
+	if err != nil {
+		mechanism.Rule = fmt.Sprintf("Error listing the TrafficTargets of %s: %s", req.destinationIdentity, err)
+		return mechanism
+	}
+	httpRoutes, err := mc.getHTTPPathsPerRoute()
+	if err != nil {
+		mechanism.Rule = fmt.Sprintf("Error listing the HTTPRouteGroups: %s", err)
+		return mechanism
+	}
+	trafficTargets := make(map[string]*access.TrafficTarget)
+	for _, trafficTarget := range mc.meshSpec.ListTrafficTargets(smi.WithTrafficTargetDestination(req.destinationIdentity.ToK8sServiceAccount())) {
+		trafficTargets[fmt.Sprintf("%s/%s", trafficTarget.Namespace, trafficTarget.Name)] = trafficTarget
+	}
+
+	for _, trafficTargetWithRoutes := range trafficTargetsWithRoutes {
+		if !containsServiceIdentity(trafficTargetWithRoutes.Sources, req.sourceIdentity) {
+			continue
+		}
+		mechanism.MatchedObjects = append(mechanism.MatchedObjects, "TrafficTarget "+trafficTargetWithRoutes.Name)
+		if mechanism.Verdict == trafficpolicy.ExplainVerdictAllow {
+			continue
+		}
+
+		for _, svc := range req.destinationServices {
+			if mechanism.Verdict == trafficpolicy.ExplainVerdictAllow {
+				break
+			}
+			if isTCPProtocol(svc.Protocol) {
+				for _, tcpRouteMatch := range trafficTargetWithRoutes.TCPRouteMatches {
+					if len(tcpRouteMatch.Ports) == 0 || containsPort(tcpRouteMatch.Ports, svc.TargetPort) {
+						mechanism.Verdict = trafficpolicy.ExplainVerdictAllow
+						mechanism.Rule = fmt.Sprintf("TrafficTarget %s allows %s to access port %d over TCP",
+							trafficTargetWithRoutes.Name, req.sourceIdentity, svc.TargetPort)
+						break
+					}
+				}
+				continue
+			}
+
+			trafficTarget, ok := trafficTargets[trafficTargetWithRoutes.Name]
+			if !ok {
+				continue
+			}
+			if rule, routeGroup := explainHTTPRouteGroupRules(trafficTarget, httpRoutes, req); rule != "" {
+				mechanism.Verdict = trafficpolicy.ExplainVerdictAllow
+				mechanism.Rule = fmt.Sprintf("TrafficTarget %s allows %s on %s", trafficTargetWithRoutes.Name, req.sourceIdentity, rule)
+				mechanism.MatchedObjects = append(mechanism.MatchedObjects, "HTTPRouteGroup "+routeGroup)
+				break
+			}
+		}
+	}
+
+	if mechanism.Verdict == trafficpolicy.ExplainVerdictDeny {
+		if len(mechanism.MatchedObjects) == 0 {
+			mechanism.Rule = fmt.Sprintf("No TrafficTarget allows %s to access %s", req.sourceIdentity, req.destinationIdentity)
+		} else {
+			mechanism.Rule = fmt.Sprintf("No route of the TrafficTargets allowing %s to access %s matches the traffic", req.sourceIdentity, req.destinationIdentity)
+		}
+	}
+	return mechanism
+}
+
+// explainHTTPRouteGroupRules returns the HTTPRouteGroup match of the given TrafficTarget matching the request along with
+// the namespaced name of the HTTPRouteGroup, or empty strings if there is none
+func explainHTTPRouteGroupRules(trafficTarget *access.TrafficTarget,
+	httpRoutes map[trafficpolicy.TrafficSpecName]map[trafficpolicy.TrafficSpecMatchName]trafficpolicy.HTTPRouteMatch,
+	req trafficExplainRequest) (string, string) {
+	for _, rule := range trafficTarget.Spec.Rules {
+		if rule.Kind != smi.HTTPRouteGroupKind {
+			continue
+		}
+		routeGroup := fmt.Sprintf("%s/%s", trafficTarget.Namespace, rule.Name)
+		for _, match := range rule.Matches {
+			route, ok := httpRoutes[getTrafficSpecName(smi.HTTPRouteGroupKind, trafficTarget.Namespace, rule.Name)][trafficpolicy.TrafficSpecMatchName(match)]
+			if ok && routeMatchesRequest(route, req.method, req.path) {
+				return fmt.Sprintf("HTTPRouteGroup %s match %s (methods %s, path %s)", routeGroup, match, strings.Join(route.Methods, ","), route.Path), routeGroup
+			}
+		}
+	}
+	return "", ""
+}
+
+// explainAccessControl explains the verdict of the AccessControl policies of the services of the destination
+func (mc *MeshCatalog) explainAccessControl(req trafficExplainRequest) trafficpolicy.MechanismExplanation {
+	mechanism := trafficpolicy.MechanismExplanation{
+		Mechanism: trafficpolicy.ExplainMechanismAccessControl,
+		Verdict:   trafficpolicy.ExplainVerdictNotApplicable,
+	}
+
+	if !mc.configurator.GetFeatureFlags().EnableAccessControlPolicy {
+		mechanism.Rule = "AccessControl policies are disabled"
+		return mechanism
+	}
+	if !req.destinationMeshed {
+		mechanism.Rule = "AccessControl policies only apply to traffic to meshed pods"
+		return mechanism
+	}
+
+	for _, svc := range req.destinationServices {
+		trafficPolicy, err := mc.GetAccessControlTrafficPolicy(svc)
+		if err != nil {
+			mechanism.Rule = fmt.Sprintf("Error computing the AccessControl policy of service %s: %s", svc, err)
+			continue
+		}
+		aclPolicy := mc.policyController.GetAccessControlPolicy(svc)
+		if trafficPolicy == nil || aclPolicy == nil {
+			continue
+		}
+
+		name := fmt.Sprintf("%s/%s", aclPolicy.Namespace, aclPolicy.Name)
+		mechanism.MatchedObjects = append(mechanism.MatchedObjects, "AccessControl "+name)
+		if mechanism.Verdict == trafficpolicy.ExplainVerdictAllow {
+			continue
+		}
+		mechanism.Verdict = trafficpolicy.ExplainVerdictDeny
+
+		var principals []string
+		for _, source := range aclPolicy.Spec.Sources {
+			if source.Kind == policyv1alpha1.KindAuthenticatedPrincipal {
+				principals = append(principals, source.Name)
+			}
+		}
+		for _, trafficMatch := range trafficPolicy.TrafficMatches {
+			if rule := mc.explainSourceMatch("AccessControl "+name, uint16(trafficMatch.Port), trafficMatch.SourceIPRanges, principals, req); rule != "" {
+				mechanism.Verdict = trafficpolicy.ExplainVerdictAllow
+				mechanism.Rule = rule
+				break
+			}
+		}
+	}
+
+	if mechanism.Verdict == trafficpolicy.ExplainVerdictDeny {
+		mechanism.Rule = "No AccessControl policy lists the source"
+	}
+	return mechanism
+}
+
+// explainIngressBackend explains the verdict of the IngressBackend policies of the services of the destination
+func (mc *MeshCatalog) explainIngressBackend(req trafficExplainRequest) trafficpolicy.MechanismExplanation {
+	mechanism := trafficpolicy.MechanismExplanation{
+		Mechanism: trafficpolicy.ExplainMechanismIngressBackend,
+		Verdict:   trafficpolicy.ExplainVerdictNotApplicable,
+	}
+
+	if !mc.configurator.GetFeatureFlags().EnableIngressBackendPolicy {
+		mechanism.Rule = "IngressBackend policies are disabled"
+		return mechanism
+	}
+	if !req.destinationMeshed {
+		mechanism.Rule = "IngressBackend policies only apply to traffic to meshed pods"
+		return mechanism
+	}
+
+	for _, svc := range req.destinationServices {
+		trafficPolicy, err := mc.GetIngressTrafficPolicy(svc)
+		if err != nil {
+			mechanism.Rule = fmt.Sprintf("Error computing the IngressBackend policy of service %s: %s", svc, err)
+			continue
+		}
+		ingressBackend := mc.policyController.GetIngressBackendPolicy(svc)
+		if trafficPolicy == nil || ingressBackend == nil {
+			continue
+		}
+
+		name := fmt.Sprintf("%s/%s", ingressBackend.Namespace, ingressBackend.Name)
+		mechanism.MatchedObjects = append(mechanism.MatchedObjects, "IngressBackend "+name)
+		if mechanism.Verdict == trafficpolicy.ExplainVerdictAllow {
+			continue
+		}
+		mechanism.Verdict = trafficpolicy.ExplainVerdictDeny
+
+		var principals []string
+		for _, source := range ingressBackend.Spec.Sources {
+			if source.Kind == policyv1alpha1.KindAuthenticatedPrincipal {
+				principals = append(principals, source.Name)
+			}
+		}
+		for _, trafficMatch := range trafficPolicy.TrafficMatches {
+			if rule := mc.explainSourceMatch("IngressBackend "+name, uint16(trafficMatch.Port), trafficMatch.SourceIPRanges, principals, req); rule != "" {
+				mechanism.Verdict = trafficpolicy.ExplainVerdictAllow
+				mechanism.Rule = rule
+				break
+			}
+		}
+	}
+
+	if mechanism.Verdict == trafficpolicy.ExplainVerdictDeny {
+		mechanism.Rule = "No IngressBackend policy lists the source"
+	}
+	return mechanism
+}
+
+// explainSourceMatch returns the rule of the given policy allowing the source on the given port of the destination by
+// its IP address or its principal, or an empty string if the policy does not allow the source
+func (mc *MeshCatalog) explainSourceMatch(policyName string, port uint16, sourceIPRanges []string, principals []string, req trafficExplainRequest) string {
+	matchesPort := false
+	for _, svc := range req.destinationServices {
+		if svc.TargetPort == port {
+			matchesPort = true
+			break
+		}
+	}
+	if !matchesPort {
+		return ""
+	}
+
+	if ipRange := ipRangeContaining(req.source.Status.PodIP, sourceIPRanges); ipRange != "" {
+		return fmt.Sprintf("%s allows the source IP range %s on port %d", policyName, ipRange, port)
+	}
+	if !req.sourceMeshed {
+		return ""
+	}
+	sourcePrincipal := req.sourceIdentity.AsPrincipal(mc.GetTrustDomain())
+	for _, principal := range principals {
+		if principal == sourcePrincipal {
+			return fmt.Sprintf("%s allows the principal %s on port %d", policyName, principal, port)
+		}
+	}
+	return ""
+}
+
+// explainEgress explains the verdict of the Egress policies of the source and of the global egress setting
+func (mc *MeshCatalog) explainEgress(req trafficExplainRequest) trafficpolicy.MechanismExplanation {
+	mechanism := trafficpolicy.MechanismExplanation{
+		Mechanism: trafficpolicy.ExplainMechanismEgress,
+		Verdict:   trafficpolicy.ExplainVerdictNotApplicable,
+	}
+
+	if !req.sourceMeshed || req.destinationMeshed {
+		mechanism.Rule = "Egress only applies to traffic from meshed pods to destinations outside the mesh"
+		return mechanism
+	}
+
+	mechanism.Verdict = trafficpolicy.ExplainVerdictDeny
+	if mc.configurator.GetFeatureFlags().EnableEgressPolicy {
+		for _, egress := range mc.policyController.ListEgressPoliciesForSourceIdentity(req.sourceIdentity.ToK8sServiceAccount()) {
+			name := fmt.Sprintf("%s/%s", egress.Namespace, egress.Name)
+			for _, portSpec := range egress.Spec.Ports {
+				if portSpec.Number != int(req.port) {
+					continue
+				}
+				mechanism.MatchedObjects = append(mechanism.MatchedObjects, "Egress "+name)
+				if mechanism.Verdict == trafficpolicy.ExplainVerdictAllow {
+					break
+				}
+
+				protocol := strings.ToLower(portSpec.Protocol)
+				if ipRange := ipRangeContaining(req.destination.Status.PodIP, egress.Spec.IPAddresses); ipRange != "" && protocol != constants.ProtocolHTTP {
+					mechanism.Verdict = trafficpolicy.ExplainVerdictAllow
+					mechanism.Rule = fmt.Sprintf("Egress %s allows %s traffic to the IP range %s on port %d", name, protocol, ipRange, req.port)
+				} else if len(egress.Spec.Hosts) > 0 && (protocol == constants.ProtocolHTTP || protocol == constants.ProtocolHTTPS) {
+					mechanism.Verdict = trafficpolicy.ExplainVerdictAllow
+					mechanism.Rule = fmt.Sprintf("Egress %s allows %s traffic to the hosts %s on port %d", name, protocol, strings.Join(egress.Spec.Hosts, ","), req.port)
+				}
+				break
+			}
+		}
+	}
+
+	if mechanism.Verdict == trafficpolicy.ExplainVerdictDeny && mc.configurator.IsEgressEnabled() {
+		mechanism.Verdict = trafficpolicy.ExplainVerdictAllow
+		mechanism.Rule = "Egress to destinations outside the mesh is enabled in the MeshConfig"
+	} else if mechanism.Verdict == trafficpolicy.ExplainVerdictDeny {
+		mechanism.Rule = "No Egress policy allows the traffic and egress is disabled in the MeshConfig"
+	}
+	return mechanism
+}
+
+// explainPlugins explains which plugin chains the sidecars run on the traffic: the outbound chains of the source and
+// the inbound chains of the destination
+func (mc *MeshCatalog) explainPlugins(req trafficExplainRequest) trafficpolicy.MechanismExplanation {
+	mechanism := trafficpolicy.MechanismExplanation{
+		Mechanism: trafficpolicy.ExplainMechanismPlugins,
+		Verdict:   trafficpolicy.ExplainVerdictNotApplicable,
+	}
+
+	var rules []string
+	for _, pluginChain := range mc.GetPluginChains() {
+		var chains []string
+		for _, chain := range pluginChain.Chains {
+			if (req.sourceMeshed && strings.HasPrefix(chain.Name, outboundChainPrefix) && mc.pluginChainSelectsPod(pluginChain, req.source)) ||
+				(req.destinationMeshed && strings.HasPrefix(chain.Name, inboundChainPrefix) && mc.pluginChainSelectsPod(pluginChain, req.destination)) {
+				chains = append(chains, fmt.Sprintf("%s on the %s chain", strings.Join(chain.Plugins, ","), chain.Name))
+			}
+		}
+		if len(chains) == 0 {
+			continue
+		}
+		name := fmt.Sprintf("%s/%s", pluginChain.Namespace, pluginChain.Name)
+		mechanism.MatchedObjects = append(mechanism.MatchedObjects, "PluginChain "+name)
+		rules = append(rules, fmt.Sprintf("PluginChain %s runs %s", name, strings.Join(chains, ", ")))
+	}
+
+	if len(rules) == 0 {
+		mechanism.Rule = "No plugin chain applies to the traffic"
+		return mechanism
+	}
+	mechanism.Verdict = trafficpolicy.ExplainVerdictUnknown
+	mechanism.Rule = strings.Join(rules, "; ")
+	return mechanism
+}
+
+// pluginChainSelectsPod returns whether the selectors of the plugin chain select the given pod
+func (mc *MeshCatalog) pluginChainSelectsPod(pluginChain *trafficpolicy.PluginChain, pod *corev1.Pod) bool {
+	var namespaceLabels map[string]string
+	if ns := mc.kubeController.GetNamespace(pod.Namespace); ns != nil {
+		namespaceLabels = ns.Labels
+	}
+	return labelSelectorMatches(pluginChain.Selectors.NamespaceSelector, namespaceLabels) &&
+		labelSelectorMatches(pluginChain.Selectors.PodSelector, pod.Labels)
+}
+
+// explainSettings returns the retry, rate limiting and circuit breaking settings applied to the traffic, or nil if
+// there are none
+func (mc *MeshCatalog) explainSettings(req trafficExplainRequest) *trafficpolicy.TrafficSettingsExplanation {
+	if !req.sourceMeshed || !req.destinationMeshed || len(req.destinationServices) == 0 {
+		return nil
+	}
+
+	svc := req.destinationServices[0]
+	settings := &trafficpolicy.TrafficSettingsExplanation{
+		Retry: mc.GetRetryPolicy(req.sourceIdentity, svc),
+	}
+	if upstreamTrafficSetting := mc.policyController.GetUpstreamTrafficSetting(policy.UpstreamTrafficSettingGetOpt{MeshService: &svc}); upstreamTrafficSetting != nil {
+		settings.UpstreamTrafficSetting = fmt.Sprintf("%s/%s", upstreamTrafficSetting.Namespace, upstreamTrafficSetting.Name)
+		settings.RateLimit = upstreamTrafficSetting.Spec.RateLimit
+		settings.ConnectionSettings = upstreamTrafficSetting.Spec.ConnectionSettings
+		settings.OutlierDetection = upstreamTrafficSetting.Spec.OutlierDetection
+		if req.path != "" {
+			for _, route := range upstreamTrafficSetting.Spec.HTTPRoutes {
+				if regexMatchesFully(route.Path, req.path) {
+					settings.RouteRateLimit = route.RateLimit
+					break
+				}
+			}
+		}
+	}
+
+	if settings.Retry == nil && settings.UpstreamTrafficSetting == "" {
+		return nil
+	}
+	return settings
+}
+
+// listServicesForPodPort returns the mesh services of the services selecting the given pod whose port or target port
+// is the given port
+func (mc *MeshCatalog) listServicesForPodPort(pod *corev1.Pod, port uint16) []service.MeshService {
+	var meshServices []service.MeshService
+	for _, meshSvc := range mc.listServicesForPod(pod) {
+		if meshSvc.Port == port || meshSvc.TargetPort == port {
+			meshServices = append(meshServices, meshSvc)
+		}
+	}
+	return meshServices
+}
+
+// listServicesForPod returns the mesh services of the services selecting the given pod
+func (mc *MeshCatalog) listServicesForPod(pod *corev1.Pod) []service.MeshService {
+	var meshServices []service.MeshService
+	for _, svc := range mc.kubeController.ListServices() {
+		if svc.Namespace != pod.Namespace || len(svc.Spec.Selector) == 0 {
+			continue
+		}
+		if !labels.Set(svc.Spec.Selector).AsSelector().Matches(labels.Set(pod.Labels)) {
+			continue
+		}
+		for _, meshSvc := range k8s.ServiceToMeshServices(mc.kubeController, *svc) {
+			if meshSvc.Name == svc.Name {
+				meshServices = append(meshServices, meshSvc)
+			}
+		}
+	}
+	return meshServices
+}
+
+func isMeshedPod(pod *corev1.Pod) bool {
+	_, ok := pod.Labels[constants.SidecarUniqueIDLabelName]
+	return ok
+}
+
+func podServiceIdentity(pod *corev1.Pod) identity.ServiceIdentity {
+	return identity.K8sServiceAccount{Name: pod.Spec.ServiceAccountName, Namespace: pod.Namespace}.ToServiceIdentity()
+}
+
+func explainedPod(pod *corev1.Pod, meshed bool) trafficpolicy.ExplainedPod {
+	return trafficpolicy.ExplainedPod{
+		Namespace: pod.Namespace,
+		Name:      pod.Name,
+		IP:        pod.Status.PodIP,
+		Identity:  podServiceIdentity(pod).String(),
+		Meshed:    meshed,
+	}
+}
+
+func isTCPProtocol(protocol string) bool {
+	return protocol == constants.ProtocolTCP || protocol == constants.ProtocolTCPServerFirst
+}
+
+func containsServiceIdentity(identities []identity.ServiceIdentity, si identity.ServiceIdentity) bool {
+	for _, i := range identities {
+		if i == si {
+			return true
+		}
+	}
+	return false
+}
+
+func containsPort(ports []uint16, port uint16) bool {
+	for _, p := range ports {
+		if p == port {
+			return true
+		}
+	}
+	return false
+}
+
+// routeMatchesRequest returns whether the HTTP route matches the given method and path, which match any route when empty
+func routeMatchesRequest(route trafficpolicy.HTTPRouteMatch, method, path string) bool {
+	if method != "" {
+		allowed := false
+		for _, m := range route.Methods {
+			if m == constants.WildcardHTTPMethod || strings.EqualFold(m, method) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return false
+		}
+	}
+	if path == "" {
+		return true
+	}
+
+	switch route.PathMatchType {
+	case trafficpolicy.PathMatchExact:
+		return route.Path == path
+	case trafficpolicy.PathMatchPrefix:
+		return strings.HasPrefix(path, route.Path)
+	default:
+		return regexMatchesFully(route.Path, path)
+	}
+}
+
+// regexMatchesFully returns whether the regular expression matches the whole value, as the sidecars match paths
+func regexMatchesFully(pattern, value string) bool {
+	re, err := regexp.Compile("^(?:" + pattern + ")$")
+	if err != nil {
+		return false
+	}
+	return re.MatchString(value)
+}
+
+// ipRangeContaining returns the first of the IP ranges containing the IP address, or an empty string if there is none
+func ipRangeContaining(ip string, ipRanges []string) string {
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return ""
+	}
+	for _, ipRange := range ipRanges {
+		if _, ipNet, err := net.ParseCIDR(ipRange); err == nil && ipNet.Contains(addr) {
+			return ipRange
+		}
+	}
+	return ""
+}
+
+// labelSelectorMatches returns whether the label selector matches the labels, a nil selector matching all labels
+func labelSelectorMatches(labelSelector *metav1.LabelSelector, set map[string]string) bool {
+	if labelSelector == nil {
+		return true
+	}
+	selector, err := metav1.LabelSelectorAsSelector(labelSelector)
+	if err != nil {
+		return false
+	}
+	return selector.Matches(labels.Set(set))
+}
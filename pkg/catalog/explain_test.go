@@ -0,0 +1,338 @@
+package catalog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	access "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/access/v1alpha3"
+	spec "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/specs/v1alpha4"
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	configv1alpha2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	pluginv1alpha1 "github.com/openservicemesh/osm/pkg/apis/plugin/v1alpha1"
+	policyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+
+	tresorFake "github.com/openservicemesh/osm/pkg/certificate/providers/tresor/fake"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/k8s"
+	"github.com/openservicemesh/osm/pkg/plugin"
+	"github.com/openservicemesh/osm/pkg/policy"
+	"github.com/openservicemesh/osm/pkg/smi"
+	"github.com/openservicemesh/osm/pkg/tests"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+func TestExplainTraffic(t *testing.T) {
+	bookbuyer := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: tests.Namespace,
+			Name:      "bookbuyer",
+			Labels:    map[string]string{"app": "bookbuyer", constants.SidecarUniqueIDLabelName: "bookbuyer-uuid"},
+		},
+		Spec:   corev1.PodSpec{ServiceAccountName: tests.BookbuyerServiceAccountName},
+		Status: corev1.PodStatus{PodIP: "10.0.0.1"},
+	}
+	bookstore := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: tests.Namespace,
+			Name:      "bookstore",
+			Labels:    map[string]string{"app": "bookstore", constants.SidecarUniqueIDLabelName: "bookstore-uuid"},
+		},
+		Spec:   corev1.PodSpec{ServiceAccountName: tests.BookstoreServiceAccountName},
+		Status: corev1.PodStatus{PodIP: "10.0.0.2"},
+	}
+	unmeshed := func(pod *corev1.Pod) *corev1.Pod {
+		pod = pod.DeepCopy()
+		delete(pod.Labels, constants.SidecarUniqueIDLabelName)
+		return pod
+	}
+	bookstoreSvc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: tests.Namespace, Name: "bookstore"},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"app": "bookstore"},
+			Ports:    []corev1.ServicePort{{Name: "http", Port: 14001}},
+		},
+	}
+	bookstoreEndpoints := &corev1.Endpoints{
+		Subsets: []corev1.EndpointSubset{{Ports: []corev1.EndpointPort{{Name: "http", Port: 14001}}}},
+	}
+
+	testCases := []struct {
+		name                string
+		source              *corev1.Pod
+		destination         *corev1.Pod
+		method              string
+		path                string
+		permissive          bool
+		egressEnabled       bool
+		ingressBackend      *policyv1alpha1.IngressBackend
+		egress              *policyv1alpha1.Egress
+		upstreamSetting     *policyv1alpha1.UpstreamTrafficSetting
+		pluginChain         *pluginv1alpha1.PluginChain
+		expectedAllowed     bool
+		expectedReason      string
+		expectedMechanism   trafficpolicy.MechanismExplanation
+		expectedSettings    *trafficpolicy.TrafficSettingsExplanation
+		expectedDestination []string
+	}{
+		{
+			name:            "permissive mode allows traffic between meshed pods",
+			source:          bookbuyer,
+			destination:     bookstore,
+			permissive:      true,
+			expectedAllowed: true,
+			expectedReason:  "Permissive traffic policy mode allows traffic between all the services of meshed pods",
+			expectedMechanism: trafficpolicy.MechanismExplanation{
+				Mechanism: trafficpolicy.ExplainMechanismPermissive,
+				Verdict:   trafficpolicy.ExplainVerdictAllow,
+				Rule:      "Permissive traffic policy mode allows traffic between all the services of meshed pods",
+			},
+			expectedDestination: []string{"default/bookstore:14001"},
+		},
+		{
+			name:            "TrafficTarget route allows the request",
+			source:          bookbuyer,
+			destination:     bookstore,
+			method:          "GET",
+			path:            "/buy",
+			expectedAllowed: true,
+			expectedReason:  "TrafficTarget default/bookbuyer-access-bookstore allows bookbuyer.default on HTTPRouteGroup default/bookstore-service-routes match buy-books (methods GET, path /buy)",
+			expectedMechanism: trafficpolicy.MechanismExplanation{
+				Mechanism:      trafficpolicy.ExplainMechanismSMI,
+				Verdict:        trafficpolicy.ExplainVerdictAllow,
+				MatchedObjects: []string{"TrafficTarget default/bookbuyer-access-bookstore", "HTTPRouteGroup default/bookstore-service-routes"},
+				Rule:           "TrafficTarget default/bookbuyer-access-bookstore allows bookbuyer.default on HTTPRouteGroup default/bookstore-service-routes match buy-books (methods GET, path /buy)",
+			},
+			expectedDestination: []string{"default/bookstore:14001"},
+		},
+		{
+			name:            "no TrafficTarget route matches the request",
+			source:          bookbuyer,
+			destination:     bookstore,
+			method:          "POST",
+			path:            "/buy",
+			expectedAllowed: false,
+			expectedReason:  "No TrafficTarget, AccessControl or IngressBackend policy allows the traffic",
+			expectedMechanism: trafficpolicy.MechanismExplanation{
+				Mechanism:      trafficpolicy.ExplainMechanismSMI,
+				Verdict:        trafficpolicy.ExplainVerdictDeny,
+				MatchedObjects: []string{"TrafficTarget default/bookbuyer-access-bookstore"},
+				Rule:           "No route of the TrafficTargets allowing bookbuyer.default to access bookstore.default matches the traffic",
+			},
+			expectedDestination: []string{"default/bookstore:14001"},
+		},
+		{
+			name:            "IngressBackend allows the source outside the mesh",
+			source:          unmeshed(bookbuyer),
+			destination:     bookstore,
+			expectedAllowed: true,
+			ingressBackend: &policyv1alpha1.IngressBackend{
+				ObjectMeta: metav1.ObjectMeta{Namespace: tests.Namespace, Name: "ingress"},
+				Spec: policyv1alpha1.IngressBackendSpec{
+					Backends: []policyv1alpha1.BackendSpec{{Name: "bookstore", Port: policyv1alpha1.PortSpec{Number: 14001, Protocol: "http"}}},
+					Sources:  []policyv1alpha1.IngressSourceSpec{{Kind: policyv1alpha1.KindIPRange, Name: "10.0.0.0/24"}},
+				},
+			},
+			expectedReason: "IngressBackend default/ingress allows the source IP range 10.0.0.0/24 on port 14001",
+			expectedMechanism: trafficpolicy.MechanismExplanation{
+				Mechanism:      trafficpolicy.ExplainMechanismIngressBackend,
+				Verdict:        trafficpolicy.ExplainVerdictAllow,
+				MatchedObjects: []string{"IngressBackend default/ingress"},
+				Rule:           "IngressBackend default/ingress allows the source IP range 10.0.0.0/24 on port 14001",
+			},
+			expectedDestination: []string{"default/bookstore:14001"},
+		},
+		{
+			name:          "Egress allows traffic to a destination outside the mesh",
+			source:        bookbuyer,
+			destination:   unmeshed(bookstore),
+			egressEnabled: false,
+			egress: &policyv1alpha1.Egress{
+				ObjectMeta: metav1.ObjectMeta{Namespace: tests.Namespace, Name: "egress"},
+				Spec: policyv1alpha1.EgressSpec{
+					IPAddresses: []string{"10.0.0.2/32"},
+					Ports:       []policyv1alpha1.PortSpec{{Number: 14001, Protocol: "tcp"}},
+				},
+			},
+			expectedAllowed: true,
+			expectedReason:  "Egress default/egress allows tcp traffic to the IP range 10.0.0.2/32 on port 14001",
+			expectedMechanism: trafficpolicy.MechanismExplanation{
+				Mechanism:      trafficpolicy.ExplainMechanismEgress,
+				Verdict:        trafficpolicy.ExplainVerdictAllow,
+				MatchedObjects: []string{"Egress default/egress"},
+				Rule:           "Egress default/egress allows tcp traffic to the IP range 10.0.0.2/32 on port 14001",
+			},
+			expectedDestination: []string{"default/bookstore:14001"},
+		},
+		{
+			name:            "neither pod is meshed",
+			source:          unmeshed(bookbuyer),
+			destination:     unmeshed(bookstore),
+			expectedAllowed: true,
+			expectedReason:  "Neither pod is part of the mesh, the traffic is not subject to the policies of the mesh",
+			expectedMechanism: trafficpolicy.MechanismExplanation{
+				Mechanism: trafficpolicy.ExplainMechanismEgress,
+				Verdict:   trafficpolicy.ExplainVerdictNotApplicable,
+				Rule:      "Egress only applies to traffic from meshed pods to destinations outside the mesh",
+			},
+			expectedDestination: []string{"default/bookstore:14001"},
+		},
+		{
+			name:        "settings and plugins apply to the traffic",
+			source:      bookbuyer,
+			destination: bookstore,
+			path:        "/buy",
+			permissive:  true,
+			upstreamSetting: &policyv1alpha1.UpstreamTrafficSetting{
+				ObjectMeta: metav1.ObjectMeta{Namespace: tests.Namespace, Name: "bookstore"},
+				Spec: policyv1alpha1.UpstreamTrafficSettingSpec{
+					ConnectionSettings: &policyv1alpha1.ConnectionSettingsSpec{TCP: &policyv1alpha1.TCPConnectionSettings{MaxConnections: pointer.Uint32(10)}},
+					HTTPRoutes: []policyv1alpha1.HTTPRouteSpec{
+						{Path: "/sell", RateLimit: &policyv1alpha1.HTTPPerRouteRateLimitSpec{Local: &policyv1alpha1.HTTPLocalRateLimitSpec{Requests: 1}}},
+						{Path: "/b.*", RateLimit: &policyv1alpha1.HTTPPerRouteRateLimitSpec{Local: &policyv1alpha1.HTTPLocalRateLimitSpec{Requests: 10}}},
+					},
+				},
+			},
+			pluginChain: &pluginv1alpha1.PluginChain{
+				ObjectMeta: metav1.ObjectMeta{Namespace: tests.Namespace, Name: "token-verifier"},
+				Spec: pluginv1alpha1.PluginChainSpec{
+					Chains: []pluginv1alpha1.ChainPluginSpec{
+						{Name: "inbound-http", Plugins: []string{"token-verifier"}},
+						{Name: "outbound-http", Plugins: []string{"token-injector"}},
+					},
+					Selectors: pluginv1alpha1.ChainSelectorSpec{
+						PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "bookstore"}},
+					},
+				},
+			},
+			expectedAllowed: true,
+			expectedReason:  "Permissive traffic policy mode allows traffic between all the services of meshed pods",
+			expectedMechanism: trafficpolicy.MechanismExplanation{
+				Mechanism:      trafficpolicy.ExplainMechanismPlugins,
+				Verdict:        trafficpolicy.ExplainVerdictUnknown,
+				MatchedObjects: []string{"PluginChain default/token-verifier"},
+				Rule:           "PluginChain default/token-verifier runs token-verifier on the inbound-http chain",
+			},
+			expectedSettings: &trafficpolicy.TrafficSettingsExplanation{
+				UpstreamTrafficSetting: "default/bookstore",
+				ConnectionSettings:     &policyv1alpha1.ConnectionSettingsSpec{TCP: &policyv1alpha1.TCPConnectionSettings{MaxConnections: pointer.Uint32(10)}},
+				RouteRateLimit:         &policyv1alpha1.HTTPPerRouteRateLimitSpec{Local: &policyv1alpha1.HTTPLocalRateLimitSpec{Requests: 10}},
+			},
+			expectedDestination: []string{"default/bookstore:14001"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockKubeController := k8s.NewMockController(mockCtrl)
+			mockPolicyController := policy.NewMockController(mockCtrl)
+			mockPluginController := plugin.NewMockController(mockCtrl)
+			mockCfg := configurator.NewMockConfigurator(mockCtrl)
+			mockMeshSpec := smi.NewMockMeshSpec(mockCtrl)
+			mc := &MeshCatalog{
+				kubeController:   mockKubeController,
+				policyController: mockPolicyController,
+				pluginController: mockPluginController,
+				certManager:      tresorFake.NewFake(nil, 1*time.Hour),
+				configurator:     mockCfg,
+				meshSpec:         mockMeshSpec,
+			}
+
+			mockCfg.EXPECT().IsPermissiveTrafficPolicyMode().Return(tc.permissive).AnyTimes()
+			mockCfg.EXPECT().IsEgressEnabled().Return(tc.egressEnabled).AnyTimes()
+			mockCfg.EXPECT().GetFeatureFlags().Return(configv1alpha2.FeatureFlags{
+				EnableIngressBackendPolicy: true,
+				EnableEgressPolicy:         true,
+				EnablePluginPolicy:         true,
+			}).AnyTimes()
+
+			mockKubeController.EXPECT().ListServices().Return([]*corev1.Service{bookstoreSvc}).AnyTimes()
+			mockKubeController.EXPECT().GetEndpoints(gomock.Any()).Return(bookstoreEndpoints, nil).AnyTimes()
+			mockKubeController.EXPECT().GetNamespace(tests.Namespace).Return(&corev1.Namespace{}).AnyTimes()
+
+			mockMeshSpec.EXPECT().ListTrafficTargets().Return([]*access.TrafficTarget{&tests.TrafficTarget}).AnyTimes()
+			mockMeshSpec.EXPECT().ListTrafficTargets(gomock.Any()).Return([]*access.TrafficTarget{&tests.TrafficTarget}).AnyTimes()
+			mockMeshSpec.EXPECT().ListHTTPTrafficSpecs().Return([]*spec.HTTPRouteGroup{&tests.HTTPRouteGroup}).AnyTimes()
+
+			mockPolicyController.EXPECT().GetIngressBackendPolicy(gomock.Any()).Return(tc.ingressBackend).AnyTimes()
+			mockPolicyController.EXPECT().GetUpstreamTrafficSetting(gomock.Any()).Return(tc.upstreamSetting).AnyTimes()
+			mockPolicyController.EXPECT().ListRetryPolicies(gomock.Any()).Return(nil).AnyTimes()
+			var egresses []*policyv1alpha1.Egress
+			if tc.egress != nil {
+				egresses = append(egresses, tc.egress)
+			}
+			mockPolicyController.EXPECT().ListEgressPoliciesForSourceIdentity(gomock.Any()).Return(egresses).AnyTimes()
+			var pluginChains []*pluginv1alpha1.PluginChain
+			if tc.pluginChain != nil {
+				pluginChains = append(pluginChains, tc.pluginChain)
+			}
+			mockPluginController.EXPECT().GetPluginChains().Return(pluginChains).AnyTimes()
+
+			explanation := mc.ExplainTraffic(tc.source, tc.destination, 14001, tc.method, tc.path)
+			assert.Equal(tc.expectedAllowed, explanation.Allowed)
+			assert.Equal(tc.expectedReason, explanation.Reason)
+			assert.Contains(explanation.Mechanisms, tc.expectedMechanism)
+			assert.Equal(tc.expectedSettings, explanation.Settings)
+			assert.Equal(tc.expectedDestination, explanation.DestinationServices)
+			assert.Len(explanation.Mechanisms, 6)
+		})
+	}
+}
+
+func TestRouteMatchesRequest(t *testing.T) {
+	testCases := []struct {
+		name     string
+		route    trafficpolicy.HTTPRouteMatch
+		method   string
+		path     string
+		expected bool
+	}{
+		{
+			name:     "wildcard route",
+			route:    trafficpolicy.WildCardRouteMatch,
+			method:   "DELETE",
+			path:     "/books/1",
+			expected: true,
+		},
+		{
+			name:     "regex route matching the whole path",
+			route:    trafficpolicy.HTTPRouteMatch{Path: "/books/[0-9]+", PathMatchType: trafficpolicy.PathMatchRegex, Methods: []string{"GET"}},
+			method:   "get",
+			path:     "/books/1",
+			expected: true,
+		},
+		{
+			name:     "regex route matching part of the path",
+			route:    trafficpolicy.HTTPRouteMatch{Path: "/books", PathMatchType: trafficpolicy.PathMatchRegex, Methods: []string{"GET"}},
+			path:     "/books/1",
+			expected: false,
+		},
+		{
+			name:     "method not allowed",
+			route:    trafficpolicy.HTTPRouteMatch{Path: "/books", PathMatchType: trafficpolicy.PathMatchExact, Methods: []string{"GET"}},
+			method:   "POST",
+			path:     "/books",
+			expected: false,
+		},
+		{
+			name:     "prefix route without a path to match",
+			route:    trafficpolicy.HTTPRouteMatch{Path: "/books", PathMatchType: trafficpolicy.PathMatchPrefix, Methods: []string{"GET"}},
+			expected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tassert.Equal(t, tc.expected, routeMatchesRequest(tc.route, tc.method, tc.path))
+		})
+	}
+}
@@ -132,5 +132,5 @@ func NewFakeMeshCatalog(kubeClient kubernetes.Interface, meshConfigClient config
 		}).AnyTimes()
 
 	return catalog.NewMeshCatalog(mockKubeController, meshSpec, certManager,
-		mockPolicyController, mockPluginController, mockMultiClusterController, stop, cfg, serviceProviders, endpointProviders, messaging.NewBroker(stop))
+		mockPolicyController, mockPluginController, mockMultiClusterController, nil, stop, cfg, serviceProviders, endpointProviders, messaging.NewBroker(stop))
 }
@@ -0,0 +1,40 @@
+package catalog
+
+import (
+	"github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+// GetFaultInjectionPolicy returns the HTTP route faults to inject for the given downstream identity and upstream service
+func (mc *MeshCatalog) GetFaultInjectionPolicy(downstreamIdentity identity.ServiceIdentity, upstreamSvc service.MeshService) []v1alpha1.HTTPFaultInjectionRouteSpec {
+	if !mc.configurator.GetFeatureFlags().EnableFaultInjectionPolicy {
+		log.Trace().Msgf("Fault injection policy flag not enabled")
+		return nil
+	}
+	src := downstreamIdentity.ToK8sServiceAccount()
+
+	// List the fault injection policies for the source
+	faultInjectionPolicies := mc.policyController.ListFaultInjectionPolicies(src)
+	if faultInjectionPolicies == nil {
+		log.Trace().Msgf("Did not find fault injection policy for downstream service %s", src)
+		return nil
+	}
+
+	for _, faultInjectionCRD := range faultInjectionPolicies {
+		for _, dest := range faultInjectionCRD.Spec.Destinations {
+			if dest.Kind != "Service" {
+				log.Error().Msgf("Fault injection policy destinations must be a service: %s is a %s", dest, dest.Kind)
+				continue
+			}
+			destMeshSvc := service.MeshService{Name: dest.Name, Namespace: dest.Namespace}
+			// All statefulset replicas have the same fault injection policy regardless of how they're accessed
+			if upstreamSvc.SiblingTo(destMeshSvc) {
+				return faultInjectionCRD.Spec.HTTPRoutes
+			}
+		}
+	}
+
+	log.Trace().Msgf("Could not find fault injection policy for source %s and destination %s", src, upstreamSvc)
+	return nil
+}
@@ -0,0 +1,128 @@
+package catalog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/policy"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+func TestGetFaultInjectionPolicy(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockCfg := configurator.NewMockConfigurator(mockCtrl)
+	mockPolicyController := policy.NewMockController(mockCtrl)
+	mc := &MeshCatalog{
+		configurator:     mockCfg,
+		policyController: mockPolicyController,
+	}
+	faultSrc := identity.ServiceIdentity("sa1.ns")
+
+	routes := []policyV1alpha1.HTTPFaultInjectionRouteSpec{
+		{
+			Path: "/api",
+			Delay: &policyV1alpha1.HTTPFaultDelaySpec{
+				Percentage: 50,
+				FixedDelay: metav1.Duration{Duration: 2 * time.Second},
+			},
+			Abort: &policyV1alpha1.HTTPFaultAbortSpec{
+				Percentage: 10,
+				HTTPStatus: 503,
+			},
+		},
+	}
+
+	faultCRD := func(destKind string) *policyV1alpha1.FaultInjection {
+		return &policyV1alpha1.FaultInjection{
+			Spec: policyV1alpha1.FaultInjectionSpec{
+				Source: policyV1alpha1.FaultInjectionSrcDstSpec{
+					Kind:      "ServiceAccount",
+					Name:      "sa1",
+					Namespace: "ns",
+				},
+				Destinations: []policyV1alpha1.FaultInjectionSrcDstSpec{
+					{
+						Kind:      destKind,
+						Name:      "s1",
+						Namespace: "b",
+					},
+				},
+				HTTPRoutes: routes,
+			},
+		}
+	}
+
+	testcases := []struct {
+		name                string
+		faultPolicyFlag     bool
+		faultCRDs           []*policyV1alpha1.FaultInjection
+		destSvc             service.MeshService
+		expectedFaultRoutes []policyV1alpha1.HTTPFaultInjectionRouteSpec
+	}{
+		{
+			name:                "No fault injection policies",
+			faultPolicyFlag:     true,
+			faultCRDs:           nil,
+			expectedFaultRoutes: nil,
+		},
+		{
+			name:                "Fault injection policy for service",
+			faultPolicyFlag:     true,
+			faultCRDs:           []*policyV1alpha1.FaultInjection{faultCRD("Service")},
+			destSvc:             service.MeshService{Name: "s1", Namespace: "b"},
+			expectedFaultRoutes: routes,
+		},
+		{
+			name:                "Fault injection policy for statefulset replica",
+			faultPolicyFlag:     true,
+			faultCRDs:           []*policyV1alpha1.FaultInjection{faultCRD("Service")},
+			destSvc:             service.MeshService{Name: "s1-0.s1", Namespace: "b"},
+			expectedFaultRoutes: routes,
+		},
+		{
+			name:                "Fault injection policy for a different service",
+			faultPolicyFlag:     true,
+			faultCRDs:           []*policyV1alpha1.FaultInjection{faultCRD("Service")},
+			destSvc:             service.MeshService{Name: "s2", Namespace: "b"},
+			expectedFaultRoutes: nil,
+		},
+		{
+			name:                "Fault injection policy with invalid destination kind",
+			faultPolicyFlag:     true,
+			faultCRDs:           []*policyV1alpha1.FaultInjection{faultCRD("ServiceAccount")},
+			destSvc:             service.MeshService{Name: "s1", Namespace: "b"},
+			expectedFaultRoutes: nil,
+		},
+		{
+			name:                "Fault injection policy flag disabled",
+			faultPolicyFlag:     false,
+			faultCRDs:           []*policyV1alpha1.FaultInjection{faultCRD("Service")},
+			destSvc:             service.MeshService{Name: "s1", Namespace: "b"},
+			expectedFaultRoutes: nil,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			mockCfg.EXPECT().GetFeatureFlags().Return(v1alpha2.FeatureFlags{EnableFaultInjectionPolicy: tc.faultPolicyFlag}).Times(1)
+			if tc.faultPolicyFlag {
+				mockPolicyController.EXPECT().ListFaultInjectionPolicies(gomock.Any()).Return(tc.faultCRDs).Times(1)
+			}
+
+			res := mc.GetFaultInjectionPolicy(faultSrc, tc.destSvc)
+			assert.Equal(tc.expectedFaultRoutes, res)
+		})
+	}
+}
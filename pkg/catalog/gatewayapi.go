@@ -0,0 +1,459 @@
+package catalog
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	gatewayAPIv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayAPIv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/gatewayapi"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+const (
+	// grpcPathSegmentRegex matches a single segment of a gRPC request path, ie: /<service>/<method>
+	grpcPathSegmentRegex = "[^/]+"
+)
+
+// gatewayAPIRouteStatus is the ResolvedRefs condition computed for a Gateway API route
+type gatewayAPIRouteStatus struct {
+	reason  gatewayAPIv1beta1.RouteConditionReason
+	message string
+}
+
+// set records the reason a backendRef of the route cannot be resolved, it is a no-op on a nil route status
+func (s *gatewayAPIRouteStatus) set(reason gatewayAPIv1beta1.RouteConditionReason, message string) {
+	if s == nil {
+		return
+	}
+	s.reason = reason
+	s.message = message
+}
+
+// getGatewayAPIRouteMatches returns the route matches and their upstream clusters derived from the Gateway API
+// routes whose parentRefs reference the given upstream MeshService (GAMMA mesh mode), for a downstream in the given
+// namespace. HTTPRoute and GRPCRoute resources apply to HTTP based services while TCPRoute resources apply to TCP
+// based services.
+//
+// Routes in the downstream's namespace that reference a Service in a different namespace (consumer routes) take
+// precedence over the routes in the Service's namespace (producer routes). A nil slice is returned if no route
+// applies, in which case the default routing to the MeshService is expected to be used.
+//
+// The route matches are programmed as is by both sidecar drivers: Envoy routes preserve them (PreserveRouteMatches),
+// and Pipy always programs the path, header and method matches of the routes in order.
+func (mc *MeshCatalog) getGatewayAPIRouteMatches(downstreamNamespace string, meshSvc service.MeshService) []*trafficpolicy.HTTPRouteMatchWithWeightedClusters {
+	if mc.gatewayAPIController == nil {
+		return nil
+	}
+
+	if meshSvc.Protocol == constants.ProtocolTCP || meshSvc.Protocol == constants.ProtocolTCPServerFirst {
+		return mc.getGatewayAPITCPRouteMatches(downstreamNamespace, meshSvc)
+	}
+
+	httpRoutes := mc.gatewayAPIController.ListHTTPRoutes(meshSvc)
+	grpcRoutes := mc.gatewayAPIController.ListGRPCRoutes(meshSvc)
+	if len(httpRoutes) == 0 && len(grpcRoutes) == 0 {
+		return nil
+	}
+
+	var routeNamespaces []string
+	for _, route := range httpRoutes {
+		routeNamespaces = append(routeNamespaces, route.Namespace)
+	}
+	for _, route := range grpcRoutes {
+		routeNamespaces = append(routeNamespaces, route.Namespace)
+	}
+	routeNamespace := getGatewayAPIRouteNamespace(downstreamNamespace, meshSvc, routeNamespaces)
+
+	var routeMatches []*trafficpolicy.HTTPRouteMatchWithWeightedClusters
+	var catchAll *trafficpolicy.HTTPRouteMatchWithWeightedClusters
+
+	for _, route := range httpRoutes {
+		if route.Namespace != routeNamespace {
+			continue
+		}
+		for _, rule := range route.Spec.Rules {
+			var backendRefs []gatewayAPIv1beta1.BackendRef
+			for _, backendRef := range rule.BackendRefs {
+				backendRefs = append(backendRefs, backendRef.BackendRef)
+			}
+			upstreamClusters := mc.getGatewayAPIUpstreamClusters(route.Namespace, meshSvc, backendRefs, nil)
+			if len(upstreamClusters) == 0 {
+				continue
+			}
+
+			matches := rule.Matches
+			if len(matches) == 0 {
+				// A rule without matches matches all requests
+				matches = []gatewayAPIv1beta1.HTTPRouteMatch{{}}
+			}
+			for _, match := range matches {
+				if len(match.QueryParams) > 0 {
+					log.Warn().Msgf("Query parameter matches are not supported, ignoring them in HTTPRoute %s/%s", route.Namespace, route.Name)
+				}
+				routeMatch := httpRouteMatchFromGatewayAPI(match)
+				if isCatchAllRouteMatch(routeMatch) {
+					if catchAll == nil {
+						catchAll = &trafficpolicy.HTTPRouteMatchWithWeightedClusters{UpstreamClusters: upstreamClusters}
+					}
+					continue
+				}
+				routeMatches = append(routeMatches, &trafficpolicy.HTTPRouteMatchWithWeightedClusters{
+					UpstreamClusters:     upstreamClusters,
+					RouteMatches:         []trafficpolicy.HTTPRouteMatch{routeMatch},
+					HasSplitMatches:      true,
+					PreserveRouteMatches: true,
+				})
+			}
+		}
+
+	}
+
+	for _, route := range grpcRoutes {
+		if route.Namespace != routeNamespace {
+			continue
+		}
+		for _, rule := range route.Spec.Rules {
+			var backendRefs []gatewayAPIv1beta1.BackendRef
+			for _, backendRef := range rule.BackendRefs {
+				backendRefs = append(backendRefs, backendRef.BackendRef)
+			}
+			upstreamClusters := mc.getGatewayAPIUpstreamClusters(route.Namespace, meshSvc, backendRefs, nil)
+			if len(upstreamClusters) == 0 {
+				continue
+			}
+
+			matches := rule.Matches
+			if len(matches) == 0 {
+				// A rule without matches matches all requests
+				matches = []gatewayAPIv1alpha2.GRPCRouteMatch{{}}
+			}
+			for _, match := range matches {
+				routeMatch := grpcRouteMatchFromGatewayAPI(match)
+				if isCatchAllRouteMatch(routeMatch) {
+					if catchAll == nil {
+						catchAll = &trafficpolicy.HTTPRouteMatchWithWeightedClusters{UpstreamClusters: upstreamClusters}
+					}
+					continue
+				}
+				routeMatches = append(routeMatches, &trafficpolicy.HTTPRouteMatchWithWeightedClusters{
+					UpstreamClusters:     upstreamClusters,
+					RouteMatches:         []trafficpolicy.HTTPRouteMatch{routeMatch},
+					HasSplitMatches:      true,
+					PreserveRouteMatches: true,
+				})
+			}
+		}
+
+	}
+
+	if len(routeMatches) == 0 && catchAll == nil {
+		return nil
+	}
+
+	// Envoy and Pipy evaluate routes in order, so the most specific matches must come first
+	sort.SliceStable(routeMatches, func(i, j int) bool {
+		return httpRouteMatchHasPrecedence(routeMatches[i].RouteMatches[0], routeMatches[j].RouteMatches[0])
+	})
+
+	// Requests that do not match any rule are routed to the MeshService itself
+	if catchAll == nil {
+		catchAll = new(trafficpolicy.HTTPRouteMatchWithWeightedClusters)
+		catchAll.UpstreamClusters = mc.mergeUpstreamClusters(meshSvc, catchAll.UpstreamClusters)
+	}
+	return append(routeMatches, catchAll)
+}
+
+// getGatewayAPITCPRouteMatches returns the upstream clusters for the given TCP based upstream MeshService derived
+// from the first TCPRoute rule referencing it. TCPRoute resources do not support matches, so at most a single
+// route match is returned.
+func (mc *MeshCatalog) getGatewayAPITCPRouteMatches(downstreamNamespace string, meshSvc service.MeshService) []*trafficpolicy.HTTPRouteMatchWithWeightedClusters {
+	tcpRoutes := mc.gatewayAPIController.ListTCPRoutes(meshSvc)
+	if len(tcpRoutes) == 0 {
+		return nil
+	}
+
+	var routeNamespaces []string
+	for _, route := range tcpRoutes {
+		routeNamespaces = append(routeNamespaces, route.Namespace)
+	}
+	routeNamespace := getGatewayAPIRouteNamespace(downstreamNamespace, meshSvc, routeNamespaces)
+
+	var routeMatch *trafficpolicy.HTTPRouteMatchWithWeightedClusters
+	for _, route := range tcpRoutes {
+		if route.Namespace != routeNamespace {
+			continue
+		}
+		for _, rule := range route.Spec.Rules {
+			upstreamClusters := mc.getGatewayAPIUpstreamClusters(route.Namespace, meshSvc, rule.BackendRefs, nil)
+			if len(upstreamClusters) == 0 || routeMatch != nil {
+				continue
+			}
+			routeMatch = &trafficpolicy.HTTPRouteMatchWithWeightedClusters{UpstreamClusters: upstreamClusters}
+		}
+
+	}
+
+	if routeMatch == nil {
+		return nil
+	}
+	return []*trafficpolicy.HTTPRouteMatchWithWeightedClusters{routeMatch}
+}
+
+// getGatewayAPIUpstreamClusters returns the weighted clusters corresponding to the given backendRefs of a route
+// in the given namespace. Backends that cannot be resolved are skipped and recorded in the given route status, if any.
+func (mc *MeshCatalog) getGatewayAPIUpstreamClusters(routeNamespace string, meshSvc service.MeshService,
+	backendRefs []gatewayAPIv1beta1.BackendRef, status *gatewayAPIRouteStatus) []service.WeightedCluster {
+	var upstreamClusters []service.WeightedCluster
+	for _, backendRef := range backendRefs {
+		if !gatewayapi.IsServiceRef(backendRef.Group, backendRef.Kind) {
+			status.set(gatewayAPIv1beta1.RouteReasonInvalidKind, fmt.Sprintf("backendRef %s is not a Service", backendRef.Name))
+			continue
+		}
+
+		namespace := routeNamespace
+		if backendRef.Namespace != nil {
+			namespace = string(*backendRef.Namespace)
+		}
+		if namespace != routeNamespace {
+			// ReferenceGrant resources are not supported, so cross namespace references are not permitted
+			status.set(gatewayAPIv1beta1.RouteReasonRefNotPermitted, fmt.Sprintf("backendRef %s/%s is not in the namespace of the route", namespace, backendRef.Name))
+			continue
+		}
+
+		weight := 1
+		if backendRef.Weight != nil {
+			weight = int(*backendRef.Weight)
+		}
+		if weight == 0 {
+			continue
+		}
+
+		port := meshSvc.Port
+		if backendRef.Port != nil {
+			port = uint16(*backendRef.Port)
+		}
+
+		backendMeshSvc := service.MeshService{
+			Namespace: namespace,
+			Name:      string(backendRef.Name),
+		}
+		targetPort, err := mc.kubeController.GetTargetPortForServicePort(
+			types.NamespacedName{Namespace: backendMeshSvc.Namespace, Name: backendMeshSvc.Name}, port)
+		if err != nil {
+			status.set(gatewayAPIv1beta1.RouteReasonBackendNotFound, fmt.Sprintf("backendRef %s/%s port %d not found: %s", namespace, backendRef.Name, port, err))
+			continue
+		}
+		backendMeshSvc.TargetPort = targetPort
+
+		upstreamClusters = append(upstreamClusters, service.WeightedCluster{
+			ClusterName: service.ClusterName(backendMeshSvc.SidecarClusterName()),
+			Weight:      weight,
+		})
+	}
+	return upstreamClusters
+}
+
+// getGatewayAPIRouteNamespace returns the namespace of the routes that apply to the given MeshService for a downstream
+// in the given namespace: the downstream's namespace if it has consumer routes, otherwise the MeshService's namespace.
+func getGatewayAPIRouteNamespace(downstreamNamespace string, meshSvc service.MeshService, routeNamespaces []string) string {
+	if downstreamNamespace == meshSvc.Namespace {
+		return meshSvc.Namespace
+	}
+	for _, namespace := range routeNamespaces {
+		if namespace == downstreamNamespace {
+			return downstreamNamespace
+		}
+	}
+	return meshSvc.Namespace
+}
+
+// httpRouteMatchFromGatewayAPI returns the HTTPRouteMatch corresponding to the given Gateway API HTTPRouteMatch
+func httpRouteMatchFromGatewayAPI(match gatewayAPIv1beta1.HTTPRouteMatch) trafficpolicy.HTTPRouteMatch {
+	routeMatch := trafficpolicy.HTTPRouteMatch{
+		Path:          constants.RegexMatchAll,
+		PathMatchType: trafficpolicy.PathMatchRegex,
+		Methods:       []string{constants.WildcardHTTPMethod},
+	}
+
+	if match.Path != nil && match.Path.Value != nil {
+		pathType := gatewayAPIv1beta1.PathMatchPathPrefix
+		if match.Path.Type != nil {
+			pathType = *match.Path.Type
+		}
+		switch pathType {
+		case gatewayAPIv1beta1.PathMatchExact:
+			routeMatch.Path = *match.Path.Value
+			routeMatch.PathMatchType = trafficpolicy.PathMatchExact
+		case gatewayAPIv1beta1.PathMatchRegularExpression:
+			routeMatch.Path = *match.Path.Value
+		default:
+			// A '/' prefix matches all paths, which is represented by the default regex
+			if *match.Path.Value != "/" {
+				routeMatch.Path = *match.Path.Value
+				routeMatch.PathMatchType = trafficpolicy.PathMatchPrefix
+			}
+		}
+	}
+
+	if match.Method != nil {
+		routeMatch.Methods = []string{string(*match.Method)}
+	}
+
+	for _, header := range match.Headers {
+		if routeMatch.Headers == nil {
+			routeMatch.Headers = make(map[string]string)
+		}
+		routeMatch.Headers[strings.ToLower(string(header.Name))] = headerRegex(header.Type, header.Value)
+	}
+
+	return routeMatch
+}
+
+// grpcRouteMatchFromGatewayAPI returns the HTTPRouteMatch corresponding to the given Gateway API GRPCRouteMatch.
+// gRPC methods are matched on the request path of the form /<service>/<method>.
+func grpcRouteMatchFromGatewayAPI(match gatewayAPIv1alpha2.GRPCRouteMatch) trafficpolicy.HTTPRouteMatch {
+	routeMatch := trafficpolicy.HTTPRouteMatch{
+		Path:          constants.RegexMatchAll,
+		PathMatchType: trafficpolicy.PathMatchRegex,
+		Methods:       []string{constants.WildcardHTTPMethod},
+	}
+
+	if method := match.Method; method != nil && (method.Service != nil || method.Method != nil) {
+		matchType := gatewayAPIv1alpha2.GRPCMethodMatchExact
+		if method.Type != nil {
+			matchType = *method.Type
+		}
+
+		switch {
+		case matchType == gatewayAPIv1alpha2.GRPCMethodMatchRegularExpression:
+			svc, rpc := grpcPathSegmentRegex, grpcPathSegmentRegex
+			if method.Service != nil {
+				svc = *method.Service
+			}
+			if method.Method != nil {
+				rpc = *method.Method
+			}
+			routeMatch.Path = fmt.Sprintf("/%s/%s", svc, rpc)
+
+		case method.Service != nil && method.Method != nil:
+			routeMatch.Path = fmt.Sprintf("/%s/%s", *method.Service, *method.Method)
+			routeMatch.PathMatchType = trafficpolicy.PathMatchExact
+
+		case method.Service != nil:
+			routeMatch.Path = fmt.Sprintf("/%s/", *method.Service)
+			routeMatch.PathMatchType = trafficpolicy.PathMatchPrefix
+
+		default:
+			routeMatch.Path = fmt.Sprintf("/%s/%s", grpcPathSegmentRegex, regexp.QuoteMeta(*method.Method))
+		}
+	}
+
+	for _, header := range match.Headers {
+		if routeMatch.Headers == nil {
+			routeMatch.Headers = make(map[string]string)
+		}
+		routeMatch.Headers[strings.ToLower(string(header.Name))] = headerRegex(header.Type, header.Value)
+	}
+
+	return routeMatch
+}
+
+// headerRegex returns the regex used to match a header value. Header values are matched
+// using regexes by the proxies, so exact matches are converted to an equivalent regex.
+func headerRegex(matchType *gatewayAPIv1beta1.HeaderMatchType, value string) string {
+	if matchType != nil && *matchType == gatewayAPIv1beta1.HeaderMatchRegularExpression {
+		return value
+	}
+	return regexp.QuoteMeta(value)
+}
+
+// isCatchAllRouteMatch returns true if the given HTTPRouteMatch matches all requests
+func isCatchAllRouteMatch(routeMatch trafficpolicy.HTTPRouteMatch) bool {
+	return routeMatch.Path == constants.RegexMatchAll && routeMatch.PathMatchType == trafficpolicy.PathMatchRegex &&
+		len(routeMatch.Headers) == 0 && reflect.DeepEqual(routeMatch.Methods, []string{constants.WildcardHTTPMethod})
+}
+
+// httpRouteMatchHasPrecedence returns true if the HTTPRouteMatch a must be evaluated before b, following the
+// precedence rules of the Gateway API: exact paths, then the longest prefixes, then methods, then the number of headers.
+func httpRouteMatchHasPrecedence(a, b trafficpolicy.HTTPRouteMatch) bool {
+	pathTypeOrder := map[trafficpolicy.PathMatchType]int{
+		trafficpolicy.PathMatchExact:  0,
+		trafficpolicy.PathMatchPrefix: 1,
+		trafficpolicy.PathMatchRegex:  2,
+	}
+	if pathTypeOrder[a.PathMatchType] != pathTypeOrder[b.PathMatchType] {
+		return pathTypeOrder[a.PathMatchType] < pathTypeOrder[b.PathMatchType]
+	}
+	if a.PathMatchType != trafficpolicy.PathMatchRegex && len(a.Path) != len(b.Path) {
+		return len(a.Path) > len(b.Path)
+	}
+	aHasMethod := a.Methods[0] != constants.WildcardHTTPMethod
+	bHasMethod := b.Methods[0] != constants.WildcardHTTPMethod
+	if aHasMethod != bHasMethod {
+		return aHasMethod
+	}
+	return len(a.Headers) > len(b.Headers)
+}
+
+// setGatewayAPIRouteStatus sets the Accepted and ResolvedRefs conditions on the route status for each of the
+// given parentRefs, and returns true if the status changed
+func setGatewayAPIRouteStatus(routeStatus *gatewayAPIv1beta1.RouteStatus, generation int64,
+	parentRefs []gatewayAPIv1beta1.ParentReference, status gatewayAPIRouteStatus) bool {
+	changed := false
+	for _, parentRef := range parentRefs {
+		var parentStatus *gatewayAPIv1beta1.RouteParentStatus
+		for i := range routeStatus.Parents {
+			if routeStatus.Parents[i].ControllerName == gatewayapi.ControllerName &&
+				reflect.DeepEqual(routeStatus.Parents[i].ParentRef, parentRef) {
+				parentStatus = &routeStatus.Parents[i]
+				break
+			}
+		}
+		if parentStatus == nil {
+			routeStatus.Parents = append(routeStatus.Parents, gatewayAPIv1beta1.RouteParentStatus{
+				ParentRef:      parentRef,
+				ControllerName: gatewayapi.ControllerName,
+			})
+			parentStatus = &routeStatus.Parents[len(routeStatus.Parents)-1]
+			changed = true
+		}
+
+		resolvedRefs := metav1.Condition{
+			Type:               string(gatewayAPIv1beta1.RouteConditionResolvedRefs),
+			Status:             metav1.ConditionTrue,
+			Reason:             string(status.reason),
+			Message:            status.message,
+			ObservedGeneration: generation,
+		}
+		if status.reason != gatewayAPIv1beta1.RouteReasonResolvedRefs {
+			resolvedRefs.Status = metav1.ConditionFalse
+		}
+		accepted := metav1.Condition{
+			Type:               string(gatewayAPIv1beta1.RouteConditionAccepted),
+			Status:             metav1.ConditionTrue,
+			Reason:             string(gatewayAPIv1beta1.RouteReasonAccepted),
+			ObservedGeneration: generation,
+		}
+
+		for _, condition := range []metav1.Condition{accepted, resolvedRefs} {
+			existing := meta.FindStatusCondition(parentStatus.Conditions, condition.Type)
+			if existing != nil && existing.Status == condition.Status && existing.Reason == condition.Reason &&
+				existing.Message == condition.Message && existing.ObservedGeneration == condition.ObservedGeneration {
+				continue
+			}
+			meta.SetStatusCondition(&parentStatus.Conditions, condition)
+			changed = true
+		}
+	}
+	return changed
+}
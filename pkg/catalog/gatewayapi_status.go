@@ -0,0 +1,134 @@
+package catalog
+
+import (
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	gatewayAPIv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/openservicemesh/osm/pkg/announcements"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/gatewayapi"
+	"github.com/openservicemesh/osm/pkg/messaging"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+// gatewayAPIRouteWithStatus is a copy of a Gateway API route whose status is being reconciled
+type gatewayAPIRouteWithStatus struct {
+	// route is the copy of the HTTPRoute, GRPCRoute or TCPRoute written back when its status changed
+	route   interface{}
+	status  *gatewayAPIv1beta1.RouteStatus
+	changed bool
+}
+
+// ReconcileGatewayAPIRouteStatuses writes back the Accepted and ResolvedRefs conditions to the status of the Gateway API
+// routes attached to mesh services each time the config of the sidecars is updated, until the stop channel is closed.
+// The route matches used to build the config of the sidecars are computed without side effects, so the statuses are
+// only written by this reconciler.
+func (mc *MeshCatalog) ReconcileGatewayAPIRouteStatuses(msgBroker *messaging.Broker, stop <-chan struct{}) {
+	if mc.gatewayAPIController == nil {
+		return
+	}
+
+	proxyUpdatePubSub := msgBroker.GetProxyUpdatePubSub()
+	proxyUpdateChan := proxyUpdatePubSub.Sub(announcements.ProxyUpdate.String())
+	defer msgBroker.Unsub(proxyUpdatePubSub, proxyUpdateChan)
+
+	for {
+		select {
+		case <-stop:
+			log.Info().Msg("Received stop signal, exiting Gateway API route status reconciler")
+			return
+
+		case <-proxyUpdateChan:
+			mc.updateGatewayAPIRouteStatuses(mc.listMeshServices())
+		}
+	}
+}
+
+// updateGatewayAPIRouteStatuses updates the status of the Gateway API routes attached to the given mesh services
+// whose conditions changed. A route attached to several services is updated at most once.
+func (mc *MeshCatalog) updateGatewayAPIRouteStatuses(meshServices []service.MeshService) {
+	routes := make(map[string]*gatewayAPIRouteWithStatus)
+	var routeKeys []string
+	getRoute := func(kind string, namespacedName types.NamespacedName, newRoute func() (interface{}, *gatewayAPIv1beta1.RouteStatus)) *gatewayAPIRouteWithStatus {
+		key := kind + "/" + namespacedName.String()
+		if route, ok := routes[key]; ok {
+			return route
+		}
+		// The original pointer returned by cache.Store must not be modified for thread safety.
+		route, status := newRoute()
+		routes[key] = &gatewayAPIRouteWithStatus{route: route, status: status}
+		routeKeys = append(routeKeys, key)
+		return routes[key]
+	}
+	setStatus := func(route *gatewayAPIRouteWithStatus, generation int64, parentRefs []gatewayAPIv1beta1.ParentReference, status gatewayAPIRouteStatus) {
+		if setGatewayAPIRouteStatus(route.status, generation, parentRefs, status) {
+			route.changed = true
+		}
+	}
+
+	for _, meshSvc := range meshServices {
+		if meshSvc.Protocol == constants.ProtocolTCP || meshSvc.Protocol == constants.ProtocolTCPServerFirst {
+			for _, route := range mc.gatewayAPIController.ListTCPRoutes(meshSvc) {
+				status := gatewayAPIRouteStatus{reason: gatewayAPIv1beta1.RouteReasonResolvedRefs}
+				for _, rule := range route.Spec.Rules {
+					mc.getGatewayAPIUpstreamClusters(route.Namespace, meshSvc, rule.BackendRefs, &status)
+				}
+				routeWithStatus := getRoute("TCPRoute", types.NamespacedName{Namespace: route.Namespace, Name: route.Name},
+					func() (interface{}, *gatewayAPIv1beta1.RouteStatus) {
+						routeCopy := route.DeepCopy()
+						return routeCopy, &routeCopy.Status.RouteStatus
+					})
+				setStatus(routeWithStatus, route.Generation, gatewayapi.ServiceParentRefs(route.Namespace, route.Spec.ParentRefs, meshSvc), status)
+			}
+			continue
+		}
+
+		for _, route := range mc.gatewayAPIController.ListHTTPRoutes(meshSvc) {
+			status := gatewayAPIRouteStatus{reason: gatewayAPIv1beta1.RouteReasonResolvedRefs}
+			for _, rule := range route.Spec.Rules {
+				var backendRefs []gatewayAPIv1beta1.BackendRef
+				for _, backendRef := range rule.BackendRefs {
+					backendRefs = append(backendRefs, backendRef.BackendRef)
+				}
+				mc.getGatewayAPIUpstreamClusters(route.Namespace, meshSvc, backendRefs, &status)
+			}
+			routeWithStatus := getRoute("HTTPRoute", types.NamespacedName{Namespace: route.Namespace, Name: route.Name},
+				func() (interface{}, *gatewayAPIv1beta1.RouteStatus) {
+					routeCopy := route.DeepCopy()
+					return routeCopy, &routeCopy.Status.RouteStatus
+				})
+			setStatus(routeWithStatus, route.Generation, gatewayapi.ServiceParentRefs(route.Namespace, route.Spec.ParentRefs, meshSvc), status)
+		}
+
+		for _, route := range mc.gatewayAPIController.ListGRPCRoutes(meshSvc) {
+			status := gatewayAPIRouteStatus{reason: gatewayAPIv1beta1.RouteReasonResolvedRefs}
+			for _, rule := range route.Spec.Rules {
+				var backendRefs []gatewayAPIv1beta1.BackendRef
+				for _, backendRef := range rule.BackendRefs {
+					backendRefs = append(backendRefs, backendRef.BackendRef)
+				}
+				mc.getGatewayAPIUpstreamClusters(route.Namespace, meshSvc, backendRefs, &status)
+			}
+			routeWithStatus := getRoute("GRPCRoute", types.NamespacedName{Namespace: route.Namespace, Name: route.Name},
+				func() (interface{}, *gatewayAPIv1beta1.RouteStatus) {
+					routeCopy := route.DeepCopy()
+					return routeCopy, &routeCopy.Status.RouteStatus
+				})
+			setStatus(routeWithStatus, route.Generation, gatewayapi.ServiceParentRefs(route.Namespace, route.Spec.ParentRefs, meshSvc), status)
+		}
+	}
+
+	for _, key := range routeKeys {
+		route := routes[key]
+		if !route.changed {
+			continue
+		}
+		if _, err := mc.kubeController.UpdateStatus(route.route); err != nil {
+			// Conflicts are expected when the route changed since it was listed, the next reconciliation updates it
+			if !apierrors.IsConflict(err) {
+				log.Error().Err(err).Msgf("Error updating status for %s", key)
+			}
+		}
+	}
+}
@@ -0,0 +1,328 @@
+package catalog
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	gatewayAPIv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayAPIv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/gatewayapi"
+	"github.com/openservicemesh/osm/pkg/k8s"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+func TestGetGatewayAPIRouteMatches(t *testing.T) {
+	apexSvc := service.MeshService{Name: "apex", Namespace: "ns", Port: 80, TargetPort: 8080, Protocol: constants.ProtocolHTTP}
+	tcpSvc := service.MeshService{Name: "apex", Namespace: "ns", Port: 90, TargetPort: 9090, Protocol: constants.ProtocolTCP}
+	exact := gatewayAPIv1beta1.PathMatchExact
+	prefix := gatewayAPIv1beta1.PathMatchPathPrefix
+	get := gatewayAPIv1beta1.HTTPMethodGet
+	port := gatewayAPIv1beta1.PortNumber(80)
+	otherNs := gatewayAPIv1beta1.Namespace("other")
+	parentRefs := gatewayAPIv1beta1.CommonRouteSpec{ParentRefs: []gatewayAPIv1beta1.ParentReference{{Name: "apex"}}}
+
+	backend := func(name string, weight int32) gatewayAPIv1beta1.BackendRef {
+		return gatewayAPIv1beta1.BackendRef{
+			BackendObjectReference: gatewayAPIv1beta1.BackendObjectReference{Name: gatewayAPIv1beta1.ObjectName(name), Port: &port},
+			Weight:                 pointer.Int32(weight),
+		}
+	}
+	apexCluster := service.WeightedCluster{ClusterName: "ns/apex|8080", Weight: constants.ClusterWeightAcceptAll}
+
+	testCases := []struct {
+		name                 string
+		downstreamNamespace  string
+		meshSvc              service.MeshService
+		httpRoutes           []*gatewayAPIv1beta1.HTTPRoute
+		grpcRoutes           []*gatewayAPIv1alpha2.GRPCRoute
+		tcpRoutes            []*gatewayAPIv1alpha2.TCPRoute
+		expectedRouteMatches []*trafficpolicy.HTTPRouteMatchWithWeightedClusters
+		expectedReasons      []gatewayAPIv1beta1.RouteConditionReason
+	}{
+		{
+			name:                 "no routes",
+			downstreamNamespace:  "ns",
+			meshSvc:              apexSvc,
+			expectedRouteMatches: nil,
+		},
+		{
+			name:                "HTTPRoute with matches sorted by precedence and the apex service as catch-all",
+			downstreamNamespace: "ns",
+			meshSvc:             apexSvc,
+			httpRoutes: []*gatewayAPIv1beta1.HTTPRoute{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "route", Namespace: "ns"},
+					Spec: gatewayAPIv1beta1.HTTPRouteSpec{
+						CommonRouteSpec: parentRefs,
+						Rules: []gatewayAPIv1beta1.HTTPRouteRule{
+							{
+								Matches: []gatewayAPIv1beta1.HTTPRouteMatch{
+									{Path: &gatewayAPIv1beta1.HTTPPathMatch{Type: &prefix, Value: pointer.String("/api")}},
+								},
+								BackendRefs: []gatewayAPIv1beta1.HTTPBackendRef{{BackendRef: backend("v1", 90)}, {BackendRef: backend("v2", 10)}},
+							},
+							{
+								Matches: []gatewayAPIv1beta1.HTTPRouteMatch{
+									{
+										Path:    &gatewayAPIv1beta1.HTTPPathMatch{Type: &exact, Value: pointer.String("/api/v2")},
+										Method:  &get,
+										Headers: []gatewayAPIv1beta1.HTTPHeaderMatch{{Name: "X-Version", Value: "v2.0"}},
+									},
+								},
+								BackendRefs: []gatewayAPIv1beta1.HTTPBackendRef{{BackendRef: backend("v2", 1)}},
+							},
+						},
+					},
+				},
+			},
+			expectedRouteMatches: []*trafficpolicy.HTTPRouteMatchWithWeightedClusters{
+				{
+					UpstreamClusters: []service.WeightedCluster{{ClusterName: "ns/v2|8080", Weight: 1}},
+					RouteMatches: []trafficpolicy.HTTPRouteMatch{{
+						Path:          "/api/v2",
+						PathMatchType: trafficpolicy.PathMatchExact,
+						Methods:       []string{"GET"},
+						Headers:       map[string]string{"x-version": `v2\.0`},
+					}},
+					HasSplitMatches:      true,
+					PreserveRouteMatches: true,
+				},
+				{
+					UpstreamClusters: []service.WeightedCluster{{ClusterName: "ns/v1|8080", Weight: 90}, {ClusterName: "ns/v2|8080", Weight: 10}},
+					RouteMatches: []trafficpolicy.HTTPRouteMatch{{
+						Path:          "/api",
+						PathMatchType: trafficpolicy.PathMatchPrefix,
+						Methods:       []string{constants.WildcardHTTPMethod},
+					}},
+					HasSplitMatches:      true,
+					PreserveRouteMatches: true,
+				},
+				{
+					UpstreamClusters: []service.WeightedCluster{apexCluster},
+				},
+			},
+			expectedReasons: []gatewayAPIv1beta1.RouteConditionReason{gatewayAPIv1beta1.RouteReasonResolvedRefs},
+		},
+		{
+			name:                "HTTPRoute catch-all rule with unresolvable backends",
+			downstreamNamespace: "ns",
+			meshSvc:             apexSvc,
+			httpRoutes: []*gatewayAPIv1beta1.HTTPRoute{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "route", Namespace: "ns"},
+					Spec: gatewayAPIv1beta1.HTTPRouteSpec{
+						CommonRouteSpec: parentRefs,
+						Rules: []gatewayAPIv1beta1.HTTPRouteRule{
+							{
+								BackendRefs: []gatewayAPIv1beta1.HTTPBackendRef{
+									{BackendRef: backend("v1", 1)},
+									{BackendRef: backend("missing", 1)},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedRouteMatches: []*trafficpolicy.HTTPRouteMatchWithWeightedClusters{
+				{
+					UpstreamClusters: []service.WeightedCluster{{ClusterName: "ns/v1|8080", Weight: 1}},
+				},
+			},
+			expectedReasons: []gatewayAPIv1beta1.RouteConditionReason{gatewayAPIv1beta1.RouteReasonBackendNotFound},
+		},
+		{
+			name:                "producer route is ignored when the downstream has a consumer route",
+			downstreamNamespace: "client",
+			meshSvc:             apexSvc,
+			httpRoutes: []*gatewayAPIv1beta1.HTTPRoute{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "producer", Namespace: "ns"},
+					Spec: gatewayAPIv1beta1.HTTPRouteSpec{
+						CommonRouteSpec: parentRefs,
+						Rules: []gatewayAPIv1beta1.HTTPRouteRule{
+							{BackendRefs: []gatewayAPIv1beta1.HTTPBackendRef{{BackendRef: backend("v1", 1)}}},
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "consumer", Namespace: "client"},
+					Spec: gatewayAPIv1beta1.HTTPRouteSpec{
+						CommonRouteSpec: gatewayAPIv1beta1.CommonRouteSpec{ParentRefs: []gatewayAPIv1beta1.ParentReference{
+							{Name: "apex", Namespace: (*gatewayAPIv1beta1.Namespace)(pointer.String("ns"))},
+						}},
+						Rules: []gatewayAPIv1beta1.HTTPRouteRule{
+							{
+								BackendRefs: []gatewayAPIv1beta1.HTTPBackendRef{{BackendRef: gatewayAPIv1beta1.BackendRef{
+									BackendObjectReference: gatewayAPIv1beta1.BackendObjectReference{Name: "v2", Namespace: &otherNs, Port: &port},
+								}}},
+							},
+						},
+					},
+				},
+			},
+			// The consumer route's backend is in a different namespace so it is not permitted,
+			// which results in the default routing to the apex service. The status of both routes is reconciled.
+			expectedRouteMatches: nil,
+			expectedReasons:      []gatewayAPIv1beta1.RouteConditionReason{gatewayAPIv1beta1.RouteReasonResolvedRefs, gatewayAPIv1beta1.RouteReasonRefNotPermitted},
+		},
+		{
+			name:                "GRPCRoute method matches",
+			downstreamNamespace: "ns",
+			meshSvc:             apexSvc,
+			grpcRoutes: []*gatewayAPIv1alpha2.GRPCRoute{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "route", Namespace: "ns"},
+					Spec: gatewayAPIv1alpha2.GRPCRouteSpec{
+						CommonRouteSpec: parentRefs,
+						Rules: []gatewayAPIv1alpha2.GRPCRouteRule{
+							{
+								Matches: []gatewayAPIv1alpha2.GRPCRouteMatch{
+									{Method: &gatewayAPIv1alpha2.GRPCMethodMatch{Service: pointer.String("foo.Bar")}},
+									{Method: &gatewayAPIv1alpha2.GRPCMethodMatch{Service: pointer.String("foo.Bar"), Method: pointer.String("Get")}},
+								},
+								BackendRefs: []gatewayAPIv1alpha2.GRPCBackendRef{{BackendRef: backend("v1", 1)}},
+							},
+						},
+					},
+				},
+			},
+			expectedRouteMatches: []*trafficpolicy.HTTPRouteMatchWithWeightedClusters{
+				{
+					UpstreamClusters: []service.WeightedCluster{{ClusterName: "ns/v1|8080", Weight: 1}},
+					RouteMatches: []trafficpolicy.HTTPRouteMatch{{
+						Path:          "/foo.Bar/Get",
+						PathMatchType: trafficpolicy.PathMatchExact,
+						Methods:       []string{constants.WildcardHTTPMethod},
+					}},
+					HasSplitMatches:      true,
+					PreserveRouteMatches: true,
+				},
+				{
+					UpstreamClusters: []service.WeightedCluster{{ClusterName: "ns/v1|8080", Weight: 1}},
+					RouteMatches: []trafficpolicy.HTTPRouteMatch{{
+						Path:          "/foo.Bar/",
+						PathMatchType: trafficpolicy.PathMatchPrefix,
+						Methods:       []string{constants.WildcardHTTPMethod},
+					}},
+					HasSplitMatches:      true,
+					PreserveRouteMatches: true,
+				},
+				{
+					UpstreamClusters: []service.WeightedCluster{apexCluster},
+				},
+			},
+			expectedReasons: []gatewayAPIv1beta1.RouteConditionReason{gatewayAPIv1beta1.RouteReasonResolvedRefs},
+		},
+		{
+			name:                "TCPRoute backends",
+			downstreamNamespace: "ns",
+			meshSvc:             tcpSvc,
+			tcpRoutes: []*gatewayAPIv1alpha2.TCPRoute{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "route", Namespace: "ns"},
+					Spec: gatewayAPIv1alpha2.TCPRouteSpec{
+						CommonRouteSpec: parentRefs,
+						Rules: []gatewayAPIv1alpha2.TCPRouteRule{
+							{BackendRefs: []gatewayAPIv1beta1.BackendRef{backend("v1", 50), backend("v2", 50), backend("v3", 0)}},
+						},
+					},
+				},
+			},
+			expectedRouteMatches: []*trafficpolicy.HTTPRouteMatchWithWeightedClusters{
+				{
+					UpstreamClusters: []service.WeightedCluster{{ClusterName: "ns/v1|8080", Weight: 50}, {ClusterName: "ns/v2|8080", Weight: 50}},
+				},
+			},
+			expectedReasons: []gatewayAPIv1beta1.RouteConditionReason{gatewayAPIv1beta1.RouteReasonResolvedRefs},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := tassert.New(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockKubeController := k8s.NewMockController(mockCtrl)
+			mockGatewayAPIController := gatewayapi.NewMockController(mockCtrl)
+			mc := MeshCatalog{
+				kubeController:       mockKubeController,
+				gatewayAPIController: mockGatewayAPIController,
+			}
+
+			mockGatewayAPIController.EXPECT().ListHTTPRoutes(tc.meshSvc).Return(tc.httpRoutes).AnyTimes()
+			mockGatewayAPIController.EXPECT().ListGRPCRoutes(tc.meshSvc).Return(tc.grpcRoutes).AnyTimes()
+			mockGatewayAPIController.EXPECT().ListTCPRoutes(tc.meshSvc).Return(tc.tcpRoutes).AnyTimes()
+			mockKubeController.EXPECT().GetTargetPortForServicePort(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(svc types.NamespacedName, _ uint16) (uint16, error) {
+					if svc.Name == "missing" {
+						return 0, errors.New("not found")
+					}
+					return 8080, nil
+				}).AnyTimes()
+
+			// Computing the route matches must not write the status of the routes
+			actual := mc.getGatewayAPIRouteMatches(tc.downstreamNamespace, tc.meshSvc)
+			a.Equal(tc.expectedRouteMatches, actual)
+
+			var reasons []string
+			mockKubeController.EXPECT().UpdateStatus(gomock.Any()).DoAndReturn(func(resource interface{}) (metav1.Object, error) {
+				var routeStatus gatewayAPIv1beta1.RouteStatus
+				switch route := resource.(type) {
+				case *gatewayAPIv1beta1.HTTPRoute:
+					routeStatus = route.Status.RouteStatus
+				case *gatewayAPIv1alpha2.GRPCRoute:
+					routeStatus = route.Status.RouteStatus
+				case *gatewayAPIv1alpha2.TCPRoute:
+					routeStatus = route.Status.RouteStatus
+				}
+				for _, parent := range routeStatus.Parents {
+					a.Equal(gatewayapi.ControllerName, parent.ControllerName)
+					a.True(meta.IsStatusConditionTrue(parent.Conditions, string(gatewayAPIv1beta1.RouteConditionAccepted)))
+					reasons = append(reasons, meta.FindStatusCondition(parent.Conditions, string(gatewayAPIv1beta1.RouteConditionResolvedRefs)).Reason)
+				}
+				return nil, nil
+			}).AnyTimes()
+
+			mc.updateGatewayAPIRouteStatuses([]service.MeshService{tc.meshSvc})
+			var expectedReasons []string
+			for _, reason := range tc.expectedReasons {
+				expectedReasons = append(expectedReasons, string(reason))
+			}
+			a.Equal(expectedReasons, reasons)
+		})
+	}
+}
+
+func TestSetGatewayAPIRouteStatus(t *testing.T) {
+	a := tassert.New(t)
+	parentRefs := []gatewayAPIv1beta1.ParentReference{{Name: "apex"}}
+	resolved := gatewayAPIRouteStatus{reason: gatewayAPIv1beta1.RouteReasonResolvedRefs}
+	notFound := gatewayAPIRouteStatus{reason: gatewayAPIv1beta1.RouteReasonBackendNotFound, message: "not found"}
+
+	var routeStatus gatewayAPIv1beta1.RouteStatus
+	a.True(setGatewayAPIRouteStatus(&routeStatus, 1, parentRefs, resolved))
+	a.Len(routeStatus.Parents, 1)
+	a.Len(routeStatus.Parents[0].Conditions, 2)
+
+	// Setting the same status again must not report a change to avoid needless status updates
+	a.False(setGatewayAPIRouteStatus(&routeStatus, 1, parentRefs, resolved))
+
+	a.True(setGatewayAPIRouteStatus(&routeStatus, 1, parentRefs, notFound))
+	condition := meta.FindStatusCondition(routeStatus.Parents[0].Conditions, string(gatewayAPIv1beta1.RouteConditionResolvedRefs))
+	a.Equal(metav1.ConditionFalse, condition.Status)
+	a.Equal("not found", condition.Message)
+
+	// A new generation of the route is reflected in the conditions
+	a.True(setGatewayAPIRouteStatus(&routeStatus, 2, parentRefs, notFound))
+	a.Len(routeStatus.Parents, 1)
+}
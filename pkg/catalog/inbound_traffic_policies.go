@@ -66,6 +66,7 @@ func (mc *MeshCatalog) GetInboundMeshTrafficPolicy(upstreamIdentity identity.Ser
 
 		upstreamTrafficSetting := mc.policyController.GetUpstreamTrafficSetting(
 			policy.UpstreamTrafficSettingGetOpt{MeshService: &upstreamSvc})
+		requestAuthn := mc.getRequestAuthentication(upstreamSvc)
 
 		// ---
 		// Create a TrafficMatch for this upstream servic.
@@ -91,6 +92,7 @@ func (mc *MeshCatalog) GetInboundMeshTrafficPolicy(upstreamIdentity identity.Ser
 			if upstreamTrafficSetting != nil {
 				trafficMatchForUpstreamSvc.RateLimit = upstreamTrafficSetting.Spec.RateLimit
 			}
+			trafficMatchForUpstreamSvc.RequestAuthentication = requestAuthn
 			trafficMatches = append(trafficMatches, trafficMatchForUpstreamSvc)
 		}
 
@@ -106,6 +108,7 @@ func (mc *MeshCatalog) GetInboundMeshTrafficPolicy(upstreamIdentity identity.Ser
 		// and are wildcarded in permissive mode. The downstreams that can access this upstream
 		// on the configured routes is also determined based on the traffic policy mode.
 		inboundTrafficPolicies := mc.getInboundTrafficPoliciesForUpstream(upstreamSvc, permissiveMode, trafficTargets, upstreamTrafficSetting)
+		inboundTrafficPolicies.RequestAuthentication = requestAuthn
 		routeConfigPerPort[int(upstreamSvc.TargetPort)] = append(routeConfigPerPort[int(upstreamSvc.TargetPort)], inboundTrafficPolicies)
 	}
 
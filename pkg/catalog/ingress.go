@@ -35,10 +35,6 @@ func (mc *MeshCatalog) GetIngressTrafficPolicy(svc service.MeshService) (*traffi
 		return nil, nil
 	}
 
-	// The status field will be updated after the policy is processed.
-	// Note: The original pointer returned by cache.Store must not be modified for thread safety.
-	ingressBackendWithStatus := *ingressBackendPolicy
-
 	var trafficRoutingRules []*trafficpolicy.Rule
 	// The ingress backend deals with principals (not identities). Principals have the trust domain included.
 	sourcePrincipals := mapset.NewSet()
@@ -78,13 +74,6 @@ func (mc *MeshCatalog) GetIngressTrafficPolicy(svc service.MeshService) (*traffi
 				}
 				endpoints := mc.listEndpointsForService(sourceMeshSvc)
 				if len(endpoints) == 0 {
-					ingressBackendWithStatus.Status = policyV1alpha1.IngressBackendStatus{
-						CurrentStatus: "error",
-						Reason:        fmt.Sprintf("endpoints not found for service %s/%s", source.Namespace, source.Name),
-					}
-					if _, err := mc.kubeController.UpdateStatus(&ingressBackendWithStatus); err != nil {
-						log.Error().Err(err).Msg("Error updating status for IngressBackend")
-					}
 					return nil, fmt.Errorf("Could not list endpoints of the source service %s/%s specified in the IngressBackend %s/%s",
 						source.Namespace, source.Name, ingressBackendPolicy.Namespace, ingressBackendPolicy.Name)
 				}
@@ -150,14 +139,6 @@ func (mc *MeshCatalog) GetIngressTrafficPolicy(svc service.MeshService) (*traffi
 		return nil, nil
 	}
 
-	ingressBackendWithStatus.Status = policyV1alpha1.IngressBackendStatus{
-		CurrentStatus: "committed",
-		Reason:        "successfully committed by the system",
-	}
-	if _, err := mc.kubeController.UpdateStatus(&ingressBackendWithStatus); err != nil {
-		log.Error().Err(err).Msg("Error updating status for IngressBackend")
-	}
-
 	// Create an inbound traffic policy from the routing rules
 	// TODO(#3779): Implement HTTP route matching from IngressBackend.Spec.Matches
 	httpRoutePolicy := &trafficpolicy.InboundTrafficPolicy{
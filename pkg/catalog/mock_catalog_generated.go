@@ -115,6 +115,20 @@ func (mr *MockMeshCatalogerMockRecorder) GetExportTrafficPolicy(arg0 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExportTrafficPolicy", reflect.TypeOf((*MockMeshCataloger)(nil).GetExportTrafficPolicy), arg0)
 }
 
+// GetFaultInjectionPolicy mocks base method.
+func (m *MockMeshCataloger) GetFaultInjectionPolicy(arg0 identity.ServiceIdentity, arg1 service.MeshService) []v1alpha1.HTTPFaultInjectionRouteSpec {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFaultInjectionPolicy", arg0, arg1)
+	ret0, _ := ret[0].([]v1alpha1.HTTPFaultInjectionRouteSpec)
+	return ret0
+}
+
+// GetFaultInjectionPolicy indicates an expected call of GetFaultInjectionPolicy.
+func (mr *MockMeshCatalogerMockRecorder) GetFaultInjectionPolicy(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFaultInjectionPolicy", reflect.TypeOf((*MockMeshCataloger)(nil).GetFaultInjectionPolicy), arg0, arg1)
+}
+
 // GetInboundMeshTrafficPolicy mocks base method.
 func (m *MockMeshCataloger) GetInboundMeshTrafficPolicy(arg0 identity.ServiceIdentity, arg1 []service.MeshService) *trafficpolicy.InboundMeshTrafficPolicy {
 	m.ctrl.T.Helper()
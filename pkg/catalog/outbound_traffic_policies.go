@@ -5,6 +5,7 @@ import (
 	split "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/split/v1alpha4"
 	"k8s.io/apimachinery/pkg/types"
 
+	policyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/errcode"
 	"github.com/openservicemesh/osm/pkg/identity"
@@ -15,6 +16,20 @@ import (
 	"github.com/openservicemesh/osm/pkg/trafficpolicy"
 )
 
+const (
+	// rateLimitDestinationKey is the key of the rate limit descriptor entry whose value is the upstream host
+	rateLimitDestinationKey = "destination"
+
+	// rateLimitSourceIdentityKey is the default key of the rate limit descriptor entry whose value is the downstream identity
+	rateLimitSourceIdentityKey = "source_identity"
+
+	// rateLimitPathKey is the default key of the rate limit descriptor entry whose value is the request path
+	rateLimitPathKey = "path"
+
+	// pathHeader is the HTTP/2 pseudo-header carrying the request path
+	pathHeader = ":path"
+)
+
 // GetOutboundMeshTrafficPolicy returns the outbound mesh traffic policy for the given downstream identity
 //
 // The function works as follows:
@@ -24,6 +39,7 @@ import (
 //     to every upstream service account that this downstream is authorized to access using SMI TrafficTarget
 //     policies.
 //  3. Process TraficSplit policies and update the weights for the upstream services based on the policies.
+//  4. In the absence of TrafficSplit policies, process the Gateway API routes attached to the upstream services.
 //
 // The route configurations are consolidated per port, such that upstream services using the same port are a part
 // of the same route configuration. This is required to avoid route conflicts that can occur when the same hostname
@@ -77,17 +93,20 @@ func (mc *MeshCatalog) GetOutboundMeshTrafficPolicy(downstreamIdentity identity.
 
 		// ---
 		// Create the cluster config for this upstream service
+		upstreamTrafficSetting := mc.policyController.GetUpstreamTrafficSetting(
+			policy.UpstreamTrafficSettingGetOpt{MeshService: &meshSvc})
 		clusterConfigForServicePort := &trafficpolicy.MeshClusterConfig{
 			Name:                            meshSvc.SidecarClusterName(),
 			Service:                         meshSvc,
 			EnableSidecarActiveHealthChecks: mc.configurator.GetFeatureFlags().EnableSidecarActiveHealthChecks,
-			UpstreamTrafficSetting: mc.policyController.GetUpstreamTrafficSetting(
-				policy.UpstreamTrafficSettingGetOpt{MeshService: &meshSvc}),
+			UpstreamTrafficSetting:          upstreamTrafficSetting,
 		}
 		clusterConfigs = append(clusterConfigs, clusterConfigForServicePort)
 
 		hasTrafficSplitWildCard := false
 		var routeMatches []*trafficpolicy.HTTPRouteMatchWithWeightedClusters
+		// trafficMatchRouteMatches are the route matches a TrafficMatch is built for
+		var trafficMatchRouteMatches []*trafficpolicy.HTTPRouteMatchWithWeightedClusters
 		// Check if there is a traffic split corresponding to this service.
 		// The upstream clusters are to be derived from the traffic split backends
 		// in that case.
@@ -108,6 +127,14 @@ func (mc *MeshCatalog) GetOutboundMeshTrafficPolicy(downstreamIdentity identity.
 				}
 				routeMatches = append(routeMatches, routeMatch)
 			}
+		} else if gatewayAPIRouteMatches := mc.getGatewayAPIRouteMatches(downstreamSvcAccount.Namespace, meshSvc); len(gatewayAPIRouteMatches) > 0 {
+			// Program routes to the backends specified in the Gateway API routes attached to this service
+			hasTrafficSplitWildCard = true
+			routeMatches = gatewayAPIRouteMatches
+			// The Gateway API route matches are programmed as routes in the route config of the service port,
+			// so a single TrafficMatch is built for the service port from the last route match, which is the
+			// catch-all route match.
+			trafficMatchRouteMatches = gatewayAPIRouteMatches[len(gatewayAPIRouteMatches)-1:]
 		} else {
 			hasTrafficSplitWildCard = true
 			routeMatch := new(trafficpolicy.HTTPRouteMatchWithWeightedClusters)
@@ -120,7 +147,10 @@ func (mc *MeshCatalog) GetOutboundMeshTrafficPolicy(downstreamIdentity identity.
 		// The TrafficMatch will be used by LDS to program a filter chain match
 		// for this upstream service, port, and destination IP ranges. This
 		// will be programmed on the downstream client.
-		for _, routeMatch := range routeMatches {
+		if trafficMatchRouteMatches == nil {
+			trafficMatchRouteMatches = routeMatches
+		}
+		for _, routeMatch := range trafficMatchRouteMatches {
 			trafficMatchForServicePort := &trafficpolicy.TrafficMatch{
 				Name:                meshSvc.OutboundTrafficMatchName(),
 				DestinationPort:     int(meshSvc.Port),
@@ -154,6 +184,9 @@ func (mc *MeshCatalog) GetOutboundMeshTrafficPolicy(downstreamIdentity identity.
 						Msgf("Error adding route to outbound mesh HTTP traffic policy for destination %s", meshSvc)
 					continue
 				}
+				if routeMatch.PreserveRouteMatches {
+					outboundTrafficPolicy.PreserveRouteMatch(route)
+				}
 			}
 		}
 		if !hasWildCardRoute {
@@ -164,6 +197,11 @@ func (mc *MeshCatalog) GetOutboundMeshTrafficPolicy(downstreamIdentity identity.
 				continue
 			}
 		}
+		outboundTrafficPolicy.ApplyRouteTimeouts(upstreamTrafficSetting)
+		outboundTrafficPolicy.ApplyRouteMirrors(mc.getRouteMirrorPolicies(meshSvc, upstreamTrafficSetting))
+		outboundTrafficPolicy.ApplyFaultInjection(mc.GetFaultInjectionPolicy(downstreamIdentity, meshSvc))
+		outboundTrafficPolicy.ApplyLoadBalancerHashKeys(upstreamTrafficSetting)
+		outboundTrafficPolicy.ApplyRateLimitDescriptors(getRateLimitDescriptors(downstreamIdentity, upstreamTrafficSetting))
 		routeConfigPerPort[int(meshSvc.Port)] = append(routeConfigPerPort[int(meshSvc.Port)], outboundTrafficPolicy)
 	}
 
@@ -272,6 +310,76 @@ func (mc *MeshCatalog) mergeSplitUpstreamClusters(meshSvc service.MeshService, b
 	return upstreamClusters
 }
 
+// getRouteMirrorPolicies returns the mirror policies, keyed by route path, specified in the given
+// UpstreamTrafficSetting for the given upstream service
+func (mc *MeshCatalog) getRouteMirrorPolicies(meshSvc service.MeshService, upstreamTrafficSetting *policyv1alpha1.UpstreamTrafficSetting) map[string]*trafficpolicy.MirrorPolicy {
+	if upstreamTrafficSetting == nil {
+		return nil
+	}
+
+	mirrors := make(map[string]*trafficpolicy.MirrorPolicy)
+	for _, httpRoute := range upstreamTrafficSetting.Spec.HTTPRoutes {
+		if httpRoute.Mirror == nil {
+			continue
+		}
+		mirrorMeshSvc := service.MeshService{
+			Namespace: meshSvc.Namespace, // Mirror services belong to the same namespace as the upstream service
+			Name:      httpRoute.Mirror.Service,
+		}
+		targetPort, err := mc.kubeController.GetTargetPortForServicePort(
+			types.NamespacedName{Namespace: mirrorMeshSvc.Namespace, Name: mirrorMeshSvc.Name}, meshSvc.Port)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error fetching target port for mirror service %s, ignoring mirror policy for route path %s", mirrorMeshSvc, httpRoute.Path)
+			continue
+		}
+		mirrorMeshSvc.TargetPort = targetPort
+
+		percentage := uint32(100)
+		if httpRoute.Mirror.Percentage != nil {
+			percentage = *httpRoute.Mirror.Percentage
+		}
+		mirrors[httpRoute.Path] = &trafficpolicy.MirrorPolicy{
+			ClusterName: service.ClusterName(mirrorMeshSvc.SidecarClusterName()),
+			Percentage:  percentage,
+		}
+	}
+	return mirrors
+}
+
+// getRateLimitDescriptors returns the descriptors sent to the global rate limit service for the requests
+// from the given downstream identity, as specified in the given UpstreamTrafficSetting
+func getRateLimitDescriptors(downstreamIdentity identity.ServiceIdentity, upstreamTrafficSetting *policyv1alpha1.UpstreamTrafficSetting) []trafficpolicy.RateLimitDescriptor {
+	if upstreamTrafficSetting == nil || upstreamTrafficSetting.Spec.RateLimit == nil || upstreamTrafficSetting.Spec.RateLimit.Global == nil {
+		return nil
+	}
+
+	var descriptors []trafficpolicy.RateLimitDescriptor
+	for _, descriptorSpec := range upstreamTrafficSetting.Spec.RateLimit.Global.Descriptors {
+		descriptor := trafficpolicy.RateLimitDescriptor{
+			{Key: rateLimitDestinationKey, Value: upstreamTrafficSetting.Spec.Host},
+		}
+		for _, entrySpec := range descriptorSpec.Entries {
+			var entry trafficpolicy.RateLimitDescriptorEntry
+			switch {
+			case entrySpec.Header != "":
+				entry = trafficpolicy.RateLimitDescriptorEntry{Key: entrySpec.Header, Header: entrySpec.Header}
+			case entrySpec.SourceIdentity:
+				entry = trafficpolicy.RateLimitDescriptorEntry{Key: rateLimitSourceIdentityKey, Value: downstreamIdentity.String()}
+			case entrySpec.Path:
+				entry = trafficpolicy.RateLimitDescriptorEntry{Key: rateLimitPathKey, Header: pathHeader}
+			default:
+				continue
+			}
+			if entrySpec.Key != "" {
+				entry.Key = entrySpec.Key
+			}
+			descriptor = append(descriptor, entry)
+		}
+		descriptors = append(descriptors, descriptor)
+	}
+	return descriptors
+}
+
 func (mc *MeshCatalog) enableEgressSrviceForIdentity(downstreamIdentity identity.ServiceIdentity, egressPolicyGetted bool, egressPolicy *trafficpolicy.EgressTrafficPolicy, meshSvc service.MeshService) (bool, bool, *trafficpolicy.EgressTrafficPolicy) {
 	egressEnabled := mc.configurator.IsEgressEnabled()
 	if !egressEnabled {
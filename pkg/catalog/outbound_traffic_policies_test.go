@@ -1,6 +1,7 @@
 package catalog
 
 import (
+	"errors"
 	"net"
 	"testing"
 
@@ -11,13 +12,17 @@ import (
 	tassert "github.com/stretchr/testify/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	gatewayAPIv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	configv1alpha2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
 	policyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
 	"github.com/openservicemesh/osm/pkg/policy"
 
 	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/endpoint"
+	"github.com/openservicemesh/osm/pkg/gatewayapi"
 	"github.com/openservicemesh/osm/pkg/identity"
 	"github.com/openservicemesh/osm/pkg/k8s"
 	"github.com/openservicemesh/osm/pkg/multicluster"
@@ -671,6 +676,241 @@ func TestGetOutboundMeshTrafficPolicy(t *testing.T) {
 	}
 }
 
+func TestGetOutboundMeshTrafficPolicyWithGatewayAPIRoutes(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	apexSvc := service.MeshService{Name: "apex", Namespace: "ns1", Port: 80, TargetPort: 8080, Protocol: "http"}
+	downstreamIdentity := identity.ServiceIdentity("sa-x.ns1")
+	port := gatewayAPIv1beta1.PortNumber(80)
+	prefix := gatewayAPIv1beta1.PathMatchPathPrefix
+	backendRef := func(name string) gatewayAPIv1beta1.HTTPBackendRef {
+		return gatewayAPIv1beta1.HTTPBackendRef{BackendRef: gatewayAPIv1beta1.BackendRef{
+			BackendObjectReference: gatewayAPIv1beta1.BackendObjectReference{Name: gatewayAPIv1beta1.ObjectName(name), Port: &port},
+		}}
+	}
+	httpRoute := &gatewayAPIv1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "route", Namespace: "ns1"},
+		Spec: gatewayAPIv1beta1.HTTPRouteSpec{
+			CommonRouteSpec: gatewayAPIv1beta1.CommonRouteSpec{ParentRefs: []gatewayAPIv1beta1.ParentReference{{Name: "apex"}}},
+			Rules: []gatewayAPIv1beta1.HTTPRouteRule{
+				{
+					Matches: []gatewayAPIv1beta1.HTTPRouteMatch{
+						{Path: &gatewayAPIv1beta1.HTTPPathMatch{Type: &prefix, Value: pointer.String("/v1")}},
+						{Path: &gatewayAPIv1beta1.HTTPPathMatch{Type: &prefix, Value: pointer.String("/v1beta")}},
+					},
+					BackendRefs: []gatewayAPIv1beta1.HTTPBackendRef{backendRef("apex-v1")},
+				},
+				{
+					Matches: []gatewayAPIv1beta1.HTTPRouteMatch{
+						{Path: &gatewayAPIv1beta1.HTTPPathMatch{Type: &prefix, Value: pointer.String("/v2")}},
+					},
+					BackendRefs: []gatewayAPIv1beta1.HTTPBackendRef{backendRef("apex-v2")},
+				},
+			},
+		},
+	}
+
+	mockKubeController := k8s.NewMockController(mockCtrl)
+	mockEndpointProvider := endpoint.NewMockProvider(mockCtrl)
+	mockServiceProvider := service.NewMockProvider(mockCtrl)
+	mockCfg := configurator.NewMockConfigurator(mockCtrl)
+	mockMeshSpec := smi.NewMockMeshSpec(mockCtrl)
+	mockPolicyController := policy.NewMockController(mockCtrl)
+	mockMultiClusterController := multicluster.NewMockController(mockCtrl)
+	mockGatewayAPIController := gatewayapi.NewMockController(mockCtrl)
+
+	mc := MeshCatalog{
+		kubeController:         mockKubeController,
+		endpointsProviders:     []endpoint.Provider{mockEndpointProvider},
+		serviceProviders:       []service.Provider{mockServiceProvider},
+		configurator:           mockCfg,
+		meshSpec:               mockMeshSpec,
+		policyController:       mockPolicyController,
+		multiclusterController: mockMultiClusterController,
+		gatewayAPIController:   mockGatewayAPIController,
+	}
+
+	mockCfg.EXPECT().IsPermissiveTrafficPolicyMode().Return(true).AnyTimes()
+	mockCfg.EXPECT().IsEgressEnabled().Return(false).AnyTimes()
+	mockCfg.EXPECT().GetFeatureFlags().Return(configv1alpha2.FeatureFlags{}).AnyTimes()
+	mockServiceProvider.EXPECT().ListServices().Return([]service.MeshService{apexSvc}).AnyTimes()
+	mockServiceProvider.EXPECT().GetServicesForServiceIdentity(gomock.Any()).Return([]service.MeshService{apexSvc}).AnyTimes()
+	mockServiceProvider.EXPECT().GetID().Return("test").AnyTimes()
+	mockEndpointProvider.EXPECT().GetID().Return("test").AnyTimes()
+	mockEndpointProvider.EXPECT().GetResolvableEndpointsForService(apexSvc).Return([]endpoint.Endpoint{{IP: net.ParseIP("10.0.1.1")}}).AnyTimes()
+	mockMeshSpec.EXPECT().ListTrafficSplits(gomock.Any()).Return(nil).AnyTimes()
+	mockKubeController.EXPECT().IsMonitoredNamespace(gomock.Any()).Return(true).AnyTimes()
+	mockKubeController.EXPECT().GetTargetPortForServicePort(gomock.Any(), apexSvc.Port).Return(apexSvc.TargetPort, nil).AnyTimes()
+	mockPolicyController.EXPECT().GetUpstreamTrafficSetting(gomock.Any()).Return(nil).AnyTimes()
+	mockMultiClusterController.EXPECT().GetTargetPortForServicePort(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	mockGatewayAPIController.EXPECT().ListHTTPRoutes(apexSvc).Return([]*gatewayAPIv1beta1.HTTPRoute{httpRoute}).AnyTimes()
+	mockGatewayAPIController.EXPECT().ListGRPCRoutes(apexSvc).Return(nil).AnyTimes()
+
+	actual := mc.GetOutboundMeshTrafficPolicy(downstreamIdentity)
+	assert.NotNil(actual)
+
+	// A single TrafficMatch is built for the service port regardless of the number of route matches,
+	// which are programmed as routes instead
+	assert.Equal([]*trafficpolicy.TrafficMatch{
+		{
+			Name:                apexSvc.OutboundTrafficMatchName(),
+			DestinationPort:     int(apexSvc.Port),
+			DestinationProtocol: apexSvc.Protocol,
+			DestinationIPRanges: []string{"10.0.1.1/32"},
+			WeightedClusters:    []service.WeightedCluster{{ClusterName: "ns1/apex|8080", Weight: constants.ClusterWeightAcceptAll}},
+		},
+	}, actual.TrafficMatches)
+
+	routeConfigs := actual.HTTPRouteConfigsPerPort[int(apexSvc.Port)]
+	assert.Len(routeConfigs, 1)
+	var paths []string
+	for _, route := range routeConfigs[0].Routes {
+		paths = append(paths, route.HTTPRouteMatch.Path)
+	}
+	assert.Equal([]string{"/v1beta", "/v1", "/v2", constants.RegexMatchAll}, paths)
+}
+
+func TestGetRouteMirrorPolicies(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockKubeController := k8s.NewMockController(mockCtrl)
+	mc := &MeshCatalog{
+		kubeController: mockKubeController,
+	}
+
+	meshSvc := service.MeshService{Name: "s1", Namespace: "ns1", Port: 80, TargetPort: 8080}
+	var percentage uint32 = 10
+
+	mockKubeController.EXPECT().GetTargetPortForServicePort(
+		types.NamespacedName{Namespace: "ns1", Name: "s1-v2"}, meshSvc.Port).Return(uint16(9090), nil).AnyTimes()
+	mockKubeController.EXPECT().GetTargetPortForServicePort(
+		types.NamespacedName{Namespace: "ns1", Name: "unknown"}, meshSvc.Port).Return(uint16(0), errors.New("not found")).AnyTimes()
+
+	testCases := []struct {
+		name                   string
+		upstreamTrafficSetting *policyv1alpha1.UpstreamTrafficSetting
+		expected               map[string]*trafficpolicy.MirrorPolicy
+	}{
+		{
+			name:                   "no UpstreamTrafficSetting",
+			upstreamTrafficSetting: nil,
+			expected:               nil,
+		},
+		{
+			name: "mirror policies for routes",
+			upstreamTrafficSetting: &policyv1alpha1.UpstreamTrafficSetting{
+				Spec: policyv1alpha1.UpstreamTrafficSettingSpec{
+					Host: "s1.ns1.svc.cluster.local",
+					HTTPRoutes: []policyv1alpha1.HTTPRouteSpec{
+						{
+							Path:   "/foo",
+							Mirror: &policyv1alpha1.HTTPRouteMirrorSpec{Service: "s1-v2"},
+						},
+						{
+							Path:   "/bar",
+							Mirror: &policyv1alpha1.HTTPRouteMirrorSpec{Service: "s1-v2", Percentage: &percentage},
+						},
+						{
+							Path:   "/baz",
+							Mirror: &policyv1alpha1.HTTPRouteMirrorSpec{Service: "unknown"},
+						},
+						{
+							Path: "/qux",
+						},
+					},
+				},
+			},
+			expected: map[string]*trafficpolicy.MirrorPolicy{
+				"/foo": {ClusterName: "ns1/s1-v2|9090", Percentage: 100},
+				"/bar": {ClusterName: "ns1/s1-v2|9090", Percentage: 10},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			actual := mc.getRouteMirrorPolicies(meshSvc, tc.upstreamTrafficSetting)
+			assert.Equal(tc.expected, actual)
+		})
+	}
+}
+
+func TestGetRateLimitDescriptors(t *testing.T) {
+	downstreamIdentity := identity.K8sServiceAccount{Name: "sa1", Namespace: "ns1"}.ToServiceIdentity()
+
+	testCases := []struct {
+		name                   string
+		upstreamTrafficSetting *policyv1alpha1.UpstreamTrafficSetting
+		expected               []trafficpolicy.RateLimitDescriptor
+	}{
+		{
+			name:                   "no UpstreamTrafficSetting",
+			upstreamTrafficSetting: nil,
+			expected:               nil,
+		},
+		{
+			name: "no global rate limit",
+			upstreamTrafficSetting: &policyv1alpha1.UpstreamTrafficSetting{
+				Spec: policyv1alpha1.UpstreamTrafficSettingSpec{
+					Host:      "s1.ns1.svc.cluster.local",
+					RateLimit: &policyv1alpha1.RateLimitSpec{},
+				},
+			},
+			expected: nil,
+		},
+		{
+			name: "global rate limit descriptors",
+			upstreamTrafficSetting: &policyv1alpha1.UpstreamTrafficSetting{
+				Spec: policyv1alpha1.UpstreamTrafficSettingSpec{
+					Host: "s1.ns1.svc.cluster.local",
+					RateLimit: &policyv1alpha1.RateLimitSpec{
+						Global: &policyv1alpha1.GlobalRateLimitSpec{
+							Descriptors: []policyv1alpha1.RateLimitDescriptorSpec{
+								{
+									Entries: []policyv1alpha1.RateLimitDescriptorEntrySpec{
+										{SourceIdentity: true},
+										{Header: "x-user-id"},
+									},
+								},
+								{
+									Entries: []policyv1alpha1.RateLimitDescriptorEntrySpec{
+										{Key: "route", Path: true},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expected: []trafficpolicy.RateLimitDescriptor{
+				{
+					{Key: "destination", Value: "s1.ns1.svc.cluster.local"},
+					{Key: "source_identity", Value: "sa1.ns1"},
+					{Key: "x-user-id", Header: "x-user-id"},
+				},
+				{
+					{Key: "destination", Value: "s1.ns1.svc.cluster.local"},
+					{Key: "route", Header: ":path"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			actual := getRateLimitDescriptors(downstreamIdentity, tc.upstreamTrafficSetting)
+			assert.Equal(tc.expected, actual)
+		})
+	}
+}
+
 func TestListOutboundServicesForIdentity(t *testing.T) {
 	assert := tassert.New(t)
 
@@ -0,0 +1,590 @@
+package catalog
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	mapset "github.com/deckarep/golang-set"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	configv1alpha2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	pluginv1alpha1 "github.com/openservicemesh/osm/pkg/apis/plugin/v1alpha1"
+	policyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+
+	"github.com/openservicemesh/osm/pkg/announcements"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/messaging"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+// kindServiceAccount is the ServiceAccount kind of the sources of Retry, FaultInjection and Egress policies
+const kindServiceAccount = "ServiceAccount"
+
+// policyStatusReconcileInterval is the minimum interval between two reconciliations of the statuses of the policies,
+// which coalesces the updates of the config of the sidecars received in the meantime
+var policyStatusReconcileInterval = 10 * time.Second
+
+// policyEvaluation is the outcome of the processing of a policy by the controller, from which the conditions of its
+// status are computed
+type policyEvaluation struct {
+	// disabledBy is the feature flag of the MeshConfig disabling the API of the policy, empty if the API is enabled
+	disabledBy string
+
+	// invalid is the reason the policy cannot be processed, empty if the policy is valid
+	invalid string
+
+	// missingRefs are the resources referenced by the policy which do not exist, as <kind> <namespace>/<name>
+	missingRefs []string
+
+	// selectsPod returns whether the policy is part of the config of the sidecar of the given meshed pod
+	selectsPod func(statusPod) bool
+}
+
+// statusPod is a meshed pod along with the services selecting it, as <namespace>/<name>
+type statusPod struct {
+	pod      *corev1.Pod
+	identity identity.K8sServiceAccount
+	services mapset.Set
+}
+
+// policyStatusSnapshot holds the state of the mesh the statuses of the policies are computed from
+type policyStatusSnapshot struct {
+	mc              *MeshCatalog
+	featureFlags    configv1alpha2.FeatureFlags
+	meshedPods      []statusPod
+	serviceAccounts mapset.Set
+	egresses        mapset.Set
+	plugins         mapset.Set
+	pluginChains    []*pluginv1alpha1.PluginChain
+}
+
+// ReconcilePolicyStatuses writes back the Accepted, ResolvedRefs and Attached conditions to the status of the
+// policies after the config of the sidecars is updated, at most once per policyStatusReconcileInterval, until the stop
+// channel is closed. The statuses are reconciled once the reconciler starts. It is the only writer of
+// the status of the policies, including the CurrentStatus and Reason of the AccessControl and IngressBackend policies.
+// Since the config of the sidecars is updated when policies, services, pods or service accounts change, the conditions
+// follow references breaking and the policies being attached to or detached from the sidecars.
+func (mc *MeshCatalog) ReconcilePolicyStatuses(msgBroker *messaging.Broker, stop <-chan struct{}) {
+	proxyUpdatePubSub := msgBroker.GetProxyUpdatePubSub()
+	proxyUpdateChan := proxyUpdatePubSub.Sub(announcements.ProxyUpdate.String())
+	defer msgBroker.Unsub(proxyUpdatePubSub, proxyUpdateChan)
+
+	ticker := time.NewTicker(policyStatusReconcileInterval)
+	defer ticker.Stop()
+
+	pending := true
+	for {
+		select {
+		case <-stop:
+			log.Info().Msg("Received stop signal, exiting policy status reconciler")
+			return
+
+		case <-proxyUpdateChan:
+			pending = true
+
+		case <-ticker.C:
+			if pending {
+				pending = false
+				mc.reconcilePolicyStatuses()
+			}
+		}
+	}
+}
+
+// reconcilePolicyStatuses updates the status of the policies whose conditions changed
+func (mc *MeshCatalog) reconcilePolicyStatuses() {
+	snapshot := mc.newPolicyStatusSnapshot()
+
+	for _, policy := range snapshot.listPolicies() {
+		evaluation := snapshot.evaluate(policy)
+		attached := 0
+		if evaluation.disabledBy == "" && evaluation.invalid == "" && len(evaluation.missingRefs) == 0 {
+			for _, pod := range snapshot.meshedPods {
+				if evaluation.selectsPod(pod) {
+					attached++
+				}
+			}
+		}
+
+		// The original pointer returned by cache.Store must not be modified for thread safety.
+		policyWithStatus := policy.(runtime.Object).DeepCopyObject()
+		conditions, observedGeneration := policyStatusFields(policyWithStatus)
+		changed := setPolicyConditions(conditions, observedGeneration, policy.GetGeneration(), evaluation, attached)
+		if setCurrentStatus(policyWithStatus, *conditions) {
+			changed = true
+		}
+		if !changed {
+			continue
+		}
+		if _, err := mc.kubeController.UpdateStatus(policyWithStatus); err != nil {
+			// Conflicts are expected when the policy changed since it was listed, the next reconciliation updates it
+			if !apierrors.IsConflict(err) {
+				log.Error().Err(err).Msgf("Error updating status for %T %s/%s", policy, policy.GetNamespace(), policy.GetName())
+			}
+		}
+	}
+}
+
+// newPolicyStatusSnapshot returns the state of the mesh the statuses of the policies are computed from
+func (mc *MeshCatalog) newPolicyStatusSnapshot() *policyStatusSnapshot {
+	snapshot := &policyStatusSnapshot{
+		mc:              mc,
+		featureFlags:    mc.configurator.GetFeatureFlags(),
+		serviceAccounts: mapset.NewSet(),
+		egresses:        mapset.NewSet(),
+		plugins:         mapset.NewSet(),
+		pluginChains:    mc.pluginController.GetPluginChains(),
+	}
+
+	services := mc.kubeController.ListServices()
+	for _, pod := range mc.kubeController.ListPods() {
+		if !isMeshedPod(pod) {
+			continue
+		}
+		podServices := mapset.NewSet()
+		for _, svc := range services {
+			if svc.Namespace == pod.Namespace && len(svc.Spec.Selector) > 0 &&
+				labels.Set(svc.Spec.Selector).AsSelector().Matches(labels.Set(pod.Labels)) {
+				podServices.Add(types.NamespacedName{Namespace: svc.Namespace, Name: svc.Name}.String())
+			}
+		}
+		snapshot.meshedPods = append(snapshot.meshedPods, statusPod{
+			pod:      pod,
+			identity: identity.K8sServiceAccount{Name: pod.Spec.ServiceAccountName, Namespace: pod.Namespace},
+			services: podServices,
+		})
+	}
+	for _, serviceAccount := range mc.kubeController.ListServiceAccounts() {
+		snapshot.serviceAccounts.Add(identity.K8sServiceAccount{Name: serviceAccount.Name, Namespace: serviceAccount.Namespace})
+	}
+	for _, policy := range mc.policyController.ListPolicies() {
+		if egress, ok := policy.(*policyv1alpha1.Egress); ok {
+			snapshot.egresses.Add(types.NamespacedName{Namespace: egress.Namespace, Name: egress.Name}.String())
+		}
+	}
+	for _, plugin := range mc.pluginController.GetPlugins() {
+		snapshot.plugins.Add(plugin.Name)
+	}
+
+	return snapshot
+}
+
+// listPolicies lists the policies whose status is reconciled
+func (s *policyStatusSnapshot) listPolicies() []metav1.Object {
+	policies := s.mc.policyController.ListPolicies()
+	for _, plugin := range s.mc.pluginController.GetPlugins() {
+		policies = append(policies, plugin)
+	}
+	for _, pluginChain := range s.pluginChains {
+		policies = append(policies, pluginChain)
+	}
+	for _, pluginConfig := range s.mc.pluginController.GetPluginConfigs() {
+		policies = append(policies, pluginConfig)
+	}
+	return policies
+}
+
+// evaluate returns the outcome of the processing of the given policy by the controller
+func (s *policyStatusSnapshot) evaluate(policy metav1.Object) policyEvaluation {
+	switch p := policy.(type) {
+	case *policyv1alpha1.AccessControl:
+		evaluation := policyEvaluation{}
+		if !s.featureFlags.EnableAccessControlPolicy {
+			evaluation.disabledBy = "enableAccessControlPolicy"
+		}
+		var backends []string
+		for _, backend := range p.Spec.Backends {
+			backends = append(backends, backend.Name)
+		}
+		for _, source := range p.Spec.Sources {
+			if source.Kind == policyv1alpha1.KindService {
+				s.checkSourceService(&evaluation, source.Namespace, source.Name)
+			}
+		}
+		s.selectBackends(&evaluation, p.Namespace, backends)
+		return evaluation
+
+	case *policyv1alpha1.IngressBackend:
+		evaluation := policyEvaluation{}
+		if !s.featureFlags.EnableIngressBackendPolicy {
+			evaluation.disabledBy = "enableIngressBackendPolicy"
+		}
+		var backends []string
+		for _, backend := range p.Spec.Backends {
+			backends = append(backends, backend.Name)
+		}
+		for _, source := range p.Spec.Sources {
+			if source.Kind == policyv1alpha1.KindService {
+				s.checkSourceService(&evaluation, source.Namespace, source.Name)
+			}
+		}
+		s.selectBackends(&evaluation, p.Namespace, backends)
+		return evaluation
+
+	case *policyv1alpha1.RequestAuthentication:
+		evaluation := policyEvaluation{}
+		if !s.featureFlags.EnableRequestAuthenticationPolicy {
+			evaluation.disabledBy = "enableRequestAuthenticationPolicy"
+		}
+		var backends []string
+		for _, backend := range p.Spec.Backends {
+			backends = append(backends, backend.Name)
+		}
+		s.selectBackends(&evaluation, p.Namespace, backends)
+		return evaluation
+
+	case *policyv1alpha1.Retry:
+		evaluation := policyEvaluation{}
+		if !s.featureFlags.EnableRetryPolicy {
+			evaluation.disabledBy = "enableRetryPolicy"
+		}
+		for _, destination := range p.Spec.Destinations {
+			if destination.Kind == policyv1alpha1.KindService {
+				s.checkService(&evaluation, destination.Namespace, destination.Name)
+			}
+		}
+		s.selectSources(&evaluation, sourceServiceAccounts(p.Spec.Source.Kind, p.Spec.Source.Name, p.Spec.Source.Namespace))
+		return evaluation
+
+	case *policyv1alpha1.FaultInjection:
+		evaluation := policyEvaluation{}
+		if !s.featureFlags.EnableFaultInjectionPolicy {
+			evaluation.disabledBy = "enableFaultInjectionPolicy"
+		}
+		for _, destination := range p.Spec.Destinations {
+			if destination.Kind == policyv1alpha1.KindService {
+				s.checkService(&evaluation, destination.Namespace, destination.Name)
+			}
+		}
+		s.selectSources(&evaluation, sourceServiceAccounts(p.Spec.Source.Kind, p.Spec.Source.Name, p.Spec.Source.Namespace))
+		return evaluation
+
+	case *policyv1alpha1.Egress:
+		evaluation := policyEvaluation{}
+		if !s.featureFlags.EnableEgressPolicy {
+			evaluation.disabledBy = "enableEgressPolicy"
+		}
+		var sources []identity.K8sServiceAccount
+		for _, source := range p.Spec.Sources {
+			sources = append(sources, sourceServiceAccounts(source.Kind, source.Name, source.Namespace)...)
+		}
+		s.selectSources(&evaluation, sources)
+		return evaluation
+
+	case *policyv1alpha1.EgressGateway:
+		evaluation := policyEvaluation{}
+		if !s.featureFlags.EnableEgressPolicy {
+			evaluation.disabledBy = "enableEgressPolicy"
+		}
+		for _, gateway := range p.Spec.GlobalEgressGateways {
+			s.checkService(&evaluation, gateway.Namespace, gateway.Service)
+		}
+		for _, rule := range p.Spec.EgressPolicyGatewayRules {
+			for _, egress := range rule.EgressPolicies {
+				if name := (types.NamespacedName{Namespace: egress.Namespace, Name: egress.Name}).String(); !s.egresses.Contains(name) {
+					evaluation.missingRefs = append(evaluation.missingRefs, "Egress "+name)
+				}
+			}
+			for _, gateway := range rule.EgressGateways {
+				s.checkService(&evaluation, gateway.Namespace, gateway.Service)
+			}
+		}
+		// The egress gateways are part of the config of every sidecar
+		evaluation.selectsPod = func(statusPod) bool { return true }
+		return evaluation
+
+	case *policyv1alpha1.UpstreamTrafficSetting:
+		evaluation := policyEvaluation{}
+		// The host is the FQDN of a service, of the form <name>.<namespace>.svc.<trust domain>
+		hostLabels := strings.Split(p.Spec.Host, ".")
+		if len(hostLabels) < 2 {
+			evaluation.invalid = fmt.Sprintf("The host %s is not the FQDN of a service", p.Spec.Host)
+			evaluation.selectsPod = func(statusPod) bool { return false }
+			return evaluation
+		}
+		s.selectBackends(&evaluation, hostLabels[1], []string{hostLabels[0]})
+		return evaluation
+
+	case *pluginv1alpha1.Plugin:
+		evaluation := s.pluginEvaluation()
+		evaluation.selectsPod = func(pod statusPod) bool {
+			for _, pluginChain := range s.pluginChains {
+				for _, chain := range pluginChain.Spec.Chains {
+					for _, plugin := range chain.Plugins {
+						if plugin == p.Name && s.pluginChainSelectsPod(pluginChain, pod) {
+							return true
+						}
+					}
+				}
+			}
+			return false
+		}
+		return evaluation
+
+	case *pluginv1alpha1.PluginChain:
+		evaluation := s.pluginEvaluation()
+		for _, chain := range p.Spec.Chains {
+			for _, plugin := range chain.Plugins {
+				if !s.plugins.Contains(plugin) {
+					evaluation.missingRefs = append(evaluation.missingRefs, "Plugin "+plugin)
+				}
+			}
+		}
+		evaluation.selectsPod = func(pod statusPod) bool {
+			return s.pluginChainSelectsPod(p, pod)
+		}
+		return evaluation
+
+	case *pluginv1alpha1.PluginConfig:
+		evaluation := s.pluginEvaluation()
+		if !s.plugins.Contains(p.Spec.Plugin) {
+			evaluation.missingRefs = append(evaluation.missingRefs, "Plugin "+p.Spec.Plugin)
+		}
+		var backends []string
+		var sources []identity.K8sServiceAccount
+		for _, ref := range p.Spec.DestinationRefs {
+			namespace := ref.Namespace
+			if namespace == "" {
+				namespace = p.Namespace
+			}
+			switch ref.Kind {
+			case policyv1alpha1.KindService:
+				s.checkService(&evaluation, namespace, ref.Name)
+				backends = append(backends, types.NamespacedName{Namespace: namespace, Name: ref.Name}.String())
+			case kindServiceAccount:
+				sources = append(sources, identity.K8sServiceAccount{Name: ref.Name, Namespace: namespace})
+			}
+		}
+		s.selectSources(&evaluation, sources)
+		selectsSource := evaluation.selectsPod
+		evaluation.selectsPod = func(pod statusPod) bool {
+			for _, backend := range backends {
+				if pod.services.Contains(backend) {
+					return true
+				}
+			}
+			return selectsSource(pod)
+		}
+		return evaluation
+
+	default:
+		return policyEvaluation{
+			invalid:    fmt.Sprintf("Unsupported policy type %T", policy),
+			selectsPod: func(statusPod) bool { return false },
+		}
+	}
+}
+
+// pluginEvaluation returns the evaluation of a plugin policy, which is disabled along with the plugin API
+func (s *policyStatusSnapshot) pluginEvaluation() policyEvaluation {
+	evaluation := policyEvaluation{}
+	if !s.featureFlags.EnablePluginPolicy {
+		evaluation.disabledBy = "enablePluginPolicy"
+	}
+	return evaluation
+}
+
+// checkService records the given service as a missing reference of the policy when it does not exist
+func (s *policyStatusSnapshot) checkService(evaluation *policyEvaluation, namespace, name string) {
+	if s.mc.kubeController.GetService(service.MeshService{Name: name, Namespace: namespace}) == nil {
+		evaluation.missingRefs = append(evaluation.missingRefs, fmt.Sprintf("Service %s/%s", namespace, name))
+	}
+}
+
+// checkSourceService records the given source service as a missing reference of the policy when it does not exist, or
+// its endpoints when it has none, since the source IP ranges of the policy are generated from the endpoints
+func (s *policyStatusSnapshot) checkSourceService(evaluation *policyEvaluation, namespace, name string) {
+	svc := service.MeshService{Name: name, Namespace: namespace}
+	if s.mc.kubeController.GetService(svc) == nil {
+		evaluation.missingRefs = append(evaluation.missingRefs, fmt.Sprintf("Service %s/%s", namespace, name))
+	} else if len(s.mc.listEndpointsForService(svc)) == 0 {
+		evaluation.missingRefs = append(evaluation.missingRefs, fmt.Sprintf("Endpoints %s/%s", namespace, name))
+	}
+}
+
+// selectBackends checks the given backend services of the namespace of the policy exist, and makes the policy select
+// the pods of the backend services
+func (s *policyStatusSnapshot) selectBackends(evaluation *policyEvaluation, namespace string, backends []string) {
+	selected := mapset.NewSet()
+	for _, backend := range backends {
+		name := types.NamespacedName{Namespace: namespace, Name: backend}.String()
+		// Backends of a policy may select several ports of the same service
+		if selected.Add(name) {
+			s.checkService(evaluation, namespace, backend)
+		}
+	}
+	evaluation.selectsPod = func(pod statusPod) bool {
+		return pod.services.Intersect(selected).Cardinality() > 0
+	}
+}
+
+// selectSources checks the given ServiceAccount sources of the policy exist, and makes the policy select the pods
+// running as the sources
+func (s *policyStatusSnapshot) selectSources(evaluation *policyEvaluation, sources []identity.K8sServiceAccount) {
+	selected := mapset.NewSet()
+	for _, serviceAccount := range sources {
+		if !s.serviceAccounts.Contains(serviceAccount) {
+			evaluation.missingRefs = append(evaluation.missingRefs, fmt.Sprintf("ServiceAccount %s", serviceAccount))
+		}
+		selected.Add(serviceAccount)
+	}
+	evaluation.selectsPod = func(pod statusPod) bool {
+		return selected.Contains(pod.identity)
+	}
+}
+
+// sourceServiceAccounts returns the service account of the source of a policy of the given kind, name and namespace,
+// if the source is a service account
+func sourceServiceAccounts(kind, name, namespace string) []identity.K8sServiceAccount {
+	if kind != kindServiceAccount {
+		return nil
+	}
+	return []identity.K8sServiceAccount{{Name: name, Namespace: namespace}}
+}
+
+// pluginChainSelectsPod returns whether the selectors of the plugin chain select the given pod
+func (s *policyStatusSnapshot) pluginChainSelectsPod(pluginChain *pluginv1alpha1.PluginChain, pod statusPod) bool {
+	return s.mc.pluginChainSelectsPod(&trafficpolicy.PluginChain{PluginChainSpec: pluginChain.Spec}, pod.pod)
+}
+
+// policyStatusFields returns the conditions and the observed generation of the status of the given policy
+func policyStatusFields(policy runtime.Object) (*[]metav1.Condition, *int64) {
+	switch p := policy.(type) {
+	case *policyv1alpha1.AccessControl:
+		return &p.Status.Conditions, &p.Status.ObservedGeneration
+	case *policyv1alpha1.Egress:
+		return &p.Status.Conditions, &p.Status.ObservedGeneration
+	case *policyv1alpha1.EgressGateway:
+		return &p.Status.Conditions, &p.Status.ObservedGeneration
+	case *policyv1alpha1.FaultInjection:
+		return &p.Status.Conditions, &p.Status.ObservedGeneration
+	case *policyv1alpha1.IngressBackend:
+		return &p.Status.Conditions, &p.Status.ObservedGeneration
+	case *policyv1alpha1.RequestAuthentication:
+		return &p.Status.Conditions, &p.Status.ObservedGeneration
+	case *policyv1alpha1.Retry:
+		return &p.Status.Conditions, &p.Status.ObservedGeneration
+	case *policyv1alpha1.UpstreamTrafficSetting:
+		return &p.Status.Conditions, &p.Status.ObservedGeneration
+	case *pluginv1alpha1.Plugin:
+		return &p.Status.Conditions, &p.Status.ObservedGeneration
+	case *pluginv1alpha1.PluginChain:
+		return &p.Status.Conditions, &p.Status.ObservedGeneration
+	case *pluginv1alpha1.PluginConfig:
+		return &p.Status.Conditions, &p.Status.ObservedGeneration
+	}
+	// Policies without status are never listed for reconciliation
+	return new([]metav1.Condition), new(int64)
+}
+
+// setPolicyConditions sets the Accepted, ResolvedRefs and Attached conditions and the observed generation of the
+// status of a policy from its evaluation and the number of sidecars it is attached to, and returns true if the status
+// changed
+func setPolicyConditions(conditions *[]metav1.Condition, observedGeneration *int64, generation int64,
+	evaluation policyEvaluation, attached int) bool {
+	accepted := metav1.Condition{
+		Type:    string(policyv1alpha1.PolicyConditionAccepted),
+		Status:  metav1.ConditionTrue,
+		Reason:  string(policyv1alpha1.PolicyReasonAccepted),
+		Message: "The policy is accepted by the controller",
+	}
+	if evaluation.disabledBy != "" {
+		accepted.Status = metav1.ConditionFalse
+		accepted.Reason = string(policyv1alpha1.PolicyReasonDisabled)
+		accepted.Message = fmt.Sprintf("The API of the policy is disabled by the featureFlags.%s MeshConfig field", evaluation.disabledBy)
+	} else if evaluation.invalid != "" {
+		accepted.Status = metav1.ConditionFalse
+		accepted.Reason = string(policyv1alpha1.PolicyReasonInvalid)
+		accepted.Message = evaluation.invalid
+	}
+
+	resolvedRefs := metav1.Condition{
+		Type:    string(policyv1alpha1.PolicyConditionResolvedRefs),
+		Status:  metav1.ConditionTrue,
+		Reason:  string(policyv1alpha1.PolicyReasonResolvedRefs),
+		Message: "All the resources referenced by the policy exist",
+	}
+	if len(evaluation.missingRefs) > 0 {
+		resolvedRefs.Status = metav1.ConditionFalse
+		resolvedRefs.Reason = string(policyv1alpha1.PolicyReasonRefNotFound)
+		resolvedRefs.Message = "Referenced resources not found: " + strings.Join(uniqueStrings(evaluation.missingRefs), ", ")
+	}
+
+	attachedCondition := metav1.Condition{
+		Type:    string(policyv1alpha1.PolicyConditionAttached),
+		Status:  metav1.ConditionTrue,
+		Reason:  string(policyv1alpha1.PolicyReasonAttached),
+		Message: "The policy is part of the config generated for the sidecars of the meshed pods it applies to",
+	}
+	switch {
+	case accepted.Status == metav1.ConditionFalse || resolvedRefs.Status == metav1.ConditionFalse:
+		attachedCondition.Status = metav1.ConditionFalse
+		attachedCondition.Reason = string(policyv1alpha1.PolicyReasonPending)
+		attachedCondition.Message = "The policy is not accepted or references resources which do not exist"
+	case attached == 0:
+		attachedCondition.Status = metav1.ConditionFalse
+		attachedCondition.Reason = string(policyv1alpha1.PolicyReasonNoProxies)
+		attachedCondition.Message = "The policy applies to no meshed pod"
+	}
+
+	changed := *observedGeneration != generation
+	*observedGeneration = generation
+	for _, condition := range []metav1.Condition{accepted, resolvedRefs, attachedCondition} {
+		condition.ObservedGeneration = generation
+		existing := meta.FindStatusCondition(*conditions, condition.Type)
+		if existing != nil && existing.Status == condition.Status && existing.Reason == condition.Reason &&
+			existing.Message == condition.Message && existing.ObservedGeneration == condition.ObservedGeneration {
+			continue
+		}
+		meta.SetStatusCondition(conditions, condition)
+		changed = true
+	}
+	return changed
+}
+
+// setCurrentStatus sets the CurrentStatus and Reason fields of the status of the AccessControl and IngressBackend
+// policies from their Accepted and ResolvedRefs conditions, and returns true if the status changed
+func setCurrentStatus(policy runtime.Object, conditions []metav1.Condition) bool {
+	var currentStatus, reason *string
+	switch p := policy.(type) {
+	case *policyv1alpha1.AccessControl:
+		currentStatus, reason = &p.Status.CurrentStatus, &p.Status.Reason
+	case *policyv1alpha1.IngressBackend:
+		currentStatus, reason = &p.Status.CurrentStatus, &p.Status.Reason
+	default:
+		return false
+	}
+
+	status, message := "committed", "successfully committed by the system"
+	for _, conditionType := range []policyv1alpha1.PolicyConditionType{policyv1alpha1.PolicyConditionAccepted, policyv1alpha1.PolicyConditionResolvedRefs} {
+		if condition := meta.FindStatusCondition(conditions, string(conditionType)); condition != nil && condition.Status == metav1.ConditionFalse {
+			status, message = "error", condition.Message
+			break
+		}
+	}
+
+	changed := *currentStatus != status || *reason != message
+	*currentStatus = status
+	*reason = message
+	return changed
+}
+
+// uniqueStrings returns the given strings without duplicates, in their original order
+func uniqueStrings(values []string) []string {
+	var unique []string
+	seen := mapset.NewSet()
+	for _, value := range values {
+		if seen.Add(value) {
+			unique = append(unique, value)
+		}
+	}
+	return unique
+}
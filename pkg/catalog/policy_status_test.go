@@ -0,0 +1,294 @@
+package catalog
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	configv1alpha2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	pluginv1alpha1 "github.com/openservicemesh/osm/pkg/apis/plugin/v1alpha1"
+	policyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+
+	"github.com/openservicemesh/osm/pkg/announcements"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/k8s"
+	"github.com/openservicemesh/osm/pkg/k8s/events"
+	"github.com/openservicemesh/osm/pkg/messaging"
+	"github.com/openservicemesh/osm/pkg/plugin"
+	"github.com/openservicemesh/osm/pkg/policy"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/tests"
+)
+
+func TestReconcilePolicyStatuses(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+
+	bookstore := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: tests.Namespace,
+			Name:      "bookstore",
+			Labels:    map[string]string{"app": "bookstore", constants.SidecarUniqueIDLabelName: "bookstore-uuid"},
+		},
+		Spec: corev1.PodSpec{ServiceAccountName: tests.BookstoreServiceAccountName},
+	}
+	unmeshed := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: tests.Namespace, Name: "bookbuyer", Labels: map[string]string{"app": "bookbuyer"}},
+		Spec:       corev1.PodSpec{ServiceAccountName: tests.BookbuyerServiceAccountName},
+	}
+	bookstoreSvc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: tests.Namespace, Name: "bookstore"},
+		Spec:       corev1.ServiceSpec{Selector: map[string]string{"app": "bookstore"}},
+	}
+
+	attachedACL := &policyv1alpha1.AccessControl{
+		ObjectMeta: metav1.ObjectMeta{Namespace: tests.Namespace, Name: "acl", Generation: 2},
+		Spec: policyv1alpha1.AccessControlSpec{
+			Backends: []policyv1alpha1.AccessControlBackendSpec{{Name: "bookstore", Port: policyv1alpha1.PortSpec{Number: 14001}}},
+			Sources:  []policyv1alpha1.AccessControlSourceSpec{{Kind: policyv1alpha1.KindIPRange, Name: "10.0.0.0/8"}},
+		},
+		Status: policyv1alpha1.AccessControlStatus{CurrentStatus: "committed", Reason: "successfully committed by the system"},
+	}
+	// The mesh has no endpoints provider, so the source service has no endpoints
+	sourceWithoutEndpointsACL := &policyv1alpha1.AccessControl{
+		ObjectMeta: metav1.ObjectMeta{Namespace: tests.Namespace, Name: "acl-source", Generation: 1},
+		Spec: policyv1alpha1.AccessControlSpec{
+			Backends: []policyv1alpha1.AccessControlBackendSpec{{Name: "bookstore", Port: policyv1alpha1.PortSpec{Number: 14001}}},
+			Sources:  []policyv1alpha1.AccessControlSourceSpec{{Kind: policyv1alpha1.KindService, Name: "bookstore", Namespace: tests.Namespace}},
+		},
+	}
+	brokenRetry := &policyv1alpha1.Retry{
+		ObjectMeta: metav1.ObjectMeta{Namespace: tests.Namespace, Name: "retry", Generation: 1},
+		Spec: policyv1alpha1.RetrySpec{
+			Source: policyv1alpha1.RetrySrcDstSpec{Kind: "ServiceAccount", Name: tests.BookbuyerServiceAccountName, Namespace: tests.Namespace},
+			Destinations: []policyv1alpha1.RetrySrcDstSpec{
+				{Kind: "Service", Name: "bookstore-v2", Namespace: tests.Namespace},
+				{Kind: "Service", Name: "bookstore-v2", Namespace: tests.Namespace},
+			},
+		},
+	}
+	unattachedRetry := &policyv1alpha1.Retry{
+		ObjectMeta: metav1.ObjectMeta{Namespace: tests.Namespace, Name: "retry-bookbuyer", Generation: 1},
+		Spec: policyv1alpha1.RetrySpec{
+			Source:       policyv1alpha1.RetrySrcDstSpec{Kind: "ServiceAccount", Name: tests.BookbuyerServiceAccountName, Namespace: tests.Namespace},
+			Destinations: []policyv1alpha1.RetrySrcDstSpec{{Kind: "Service", Name: "bookstore", Namespace: tests.Namespace}},
+		},
+	}
+	disabledIngressBackend := &policyv1alpha1.IngressBackend{
+		ObjectMeta: metav1.ObjectMeta{Namespace: tests.Namespace, Name: "ingress", Generation: 1},
+		Spec: policyv1alpha1.IngressBackendSpec{
+			Backends: []policyv1alpha1.BackendSpec{{Name: "bookstore", Port: policyv1alpha1.PortSpec{Number: 14001}}},
+		},
+	}
+	upToDateSetting := &policyv1alpha1.UpstreamTrafficSetting{
+		ObjectMeta: metav1.ObjectMeta{Namespace: tests.Namespace, Name: "setting", Generation: 3},
+		Spec:       policyv1alpha1.UpstreamTrafficSettingSpec{Host: "bookstore.default.svc.cluster.local"},
+	}
+	conditions, observedGeneration := policyStatusFields(upToDateSetting)
+	setPolicyConditions(conditions, observedGeneration, 3, policyEvaluation{}, 1)
+	pluginChain := &pluginv1alpha1.PluginChain{
+		ObjectMeta: metav1.ObjectMeta{Namespace: tests.Namespace, Name: "chain", Generation: 1},
+		Spec: pluginv1alpha1.PluginChainSpec{
+			Chains: []pluginv1alpha1.ChainPluginSpec{{Name: "inbound-http", Plugins: []string{"logger"}}},
+		},
+	}
+
+	mockKubeController := k8s.NewMockController(mockCtrl)
+	mockPolicyController := policy.NewMockController(mockCtrl)
+	mockPluginController := plugin.NewMockController(mockCtrl)
+	mockCfg := configurator.NewMockConfigurator(mockCtrl)
+	mc := &MeshCatalog{
+		kubeController:   mockKubeController,
+		policyController: mockPolicyController,
+		pluginController: mockPluginController,
+		configurator:     mockCfg,
+	}
+
+	mockCfg.EXPECT().GetFeatureFlags().Return(configv1alpha2.FeatureFlags{
+		EnableAccessControlPolicy: true,
+		EnableRetryPolicy:         true,
+		EnablePluginPolicy:        true,
+	}).AnyTimes()
+	mockKubeController.EXPECT().ListPods().Return([]*corev1.Pod{bookstore, unmeshed})
+	mockKubeController.EXPECT().ListServices().Return([]*corev1.Service{bookstoreSvc})
+	mockKubeController.EXPECT().ListServiceAccounts().Return([]*corev1.ServiceAccount{
+		{ObjectMeta: metav1.ObjectMeta{Namespace: tests.Namespace, Name: tests.BookstoreServiceAccountName}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: tests.Namespace, Name: tests.BookbuyerServiceAccountName}},
+	})
+	mockKubeController.EXPECT().GetService(gomock.Any()).DoAndReturn(func(svc service.MeshService) *corev1.Service {
+		if svc.Namespace == bookstoreSvc.Namespace && svc.Name == bookstoreSvc.Name {
+			return bookstoreSvc
+		}
+		return nil
+	}).AnyTimes()
+	mockPolicyController.EXPECT().ListPolicies().Return([]metav1.Object{
+		attachedACL, sourceWithoutEndpointsACL, brokenRetry, unattachedRetry, disabledIngressBackend, upToDateSetting,
+	}).AnyTimes()
+	mockPluginController.EXPECT().GetPlugins().Return(nil).AnyTimes()
+	mockPluginController.EXPECT().GetPluginChains().Return([]*pluginv1alpha1.PluginChain{pluginChain}).AnyTimes()
+	mockPluginController.EXPECT().GetPluginConfigs().Return(nil).AnyTimes()
+
+	updated := map[string][]metav1.Condition{}
+	currentStatuses := map[string][2]string{}
+	mockKubeController.EXPECT().UpdateStatus(gomock.Any()).DoAndReturn(func(resource interface{}) (metav1.Object, error) {
+		obj := resource.(metav1.Object)
+		conditions, observedGeneration := policyStatusFields(resource.(runtime.Object))
+		assert.Equal(obj.GetGeneration(), *observedGeneration)
+		updated[obj.GetName()] = *conditions
+		switch p := resource.(type) {
+		case *policyv1alpha1.AccessControl:
+			currentStatuses[obj.GetName()] = [2]string{p.Status.CurrentStatus, p.Status.Reason}
+		case *policyv1alpha1.IngressBackend:
+			currentStatuses[obj.GetName()] = [2]string{p.Status.CurrentStatus, p.Status.Reason}
+		}
+		return obj, nil
+	}).AnyTimes()
+
+	mc.reconcilePolicyStatuses()
+
+	// The status of the UpstreamTrafficSetting is up to date and is not updated
+	assert.Len(updated, 6)
+	assert.NotContains(updated, "setting")
+
+	// The CurrentStatus and Reason of the AccessControl and IngressBackend policies follow their conditions
+	assert.Equal([2]string{"committed", "successfully committed by the system"}, currentStatuses["acl"])
+	assert.Equal([2]string{"error", "Referenced resources not found: Endpoints default/bookstore"}, currentStatuses["acl-source"])
+	assert.Equal([2]string{"error", "The API of the policy is disabled by the featureFlags.enableIngressBackendPolicy MeshConfig field"},
+		currentStatuses["ingress"])
+
+	assertCondition := func(name string, conditionType policyv1alpha1.PolicyConditionType, status metav1.ConditionStatus,
+		reason policyv1alpha1.PolicyConditionReason, message string) {
+		condition := meta.FindStatusCondition(updated[name], string(conditionType))
+		if !assert.NotNil(condition, "%s has no %s condition", name, conditionType) {
+			return
+		}
+		assert.Equal(status, condition.Status, "%s %s", name, conditionType)
+		assert.Equal(string(reason), condition.Reason, "%s %s", name, conditionType)
+		if message != "" {
+			assert.Equal(message, condition.Message, "%s %s", name, conditionType)
+		}
+	}
+
+	assertCondition("acl", policyv1alpha1.PolicyConditionAccepted, metav1.ConditionTrue, policyv1alpha1.PolicyReasonAccepted, "")
+	assertCondition("acl", policyv1alpha1.PolicyConditionResolvedRefs, metav1.ConditionTrue, policyv1alpha1.PolicyReasonResolvedRefs, "")
+	assertCondition("acl", policyv1alpha1.PolicyConditionAttached, metav1.ConditionTrue, policyv1alpha1.PolicyReasonAttached, "")
+
+	assertCondition("acl-source", policyv1alpha1.PolicyConditionResolvedRefs, metav1.ConditionFalse, policyv1alpha1.PolicyReasonRefNotFound,
+		"Referenced resources not found: Endpoints default/bookstore")
+
+	assertCondition("retry", policyv1alpha1.PolicyConditionAccepted, metav1.ConditionTrue, policyv1alpha1.PolicyReasonAccepted, "")
+	assertCondition("retry", policyv1alpha1.PolicyConditionResolvedRefs, metav1.ConditionFalse, policyv1alpha1.PolicyReasonRefNotFound,
+		"Referenced resources not found: Service default/bookstore-v2")
+	assertCondition("retry", policyv1alpha1.PolicyConditionAttached, metav1.ConditionFalse, policyv1alpha1.PolicyReasonPending, "")
+
+	// The source of the Retry policy only runs in a pod without sidecar
+	assertCondition("retry-bookbuyer", policyv1alpha1.PolicyConditionResolvedRefs, metav1.ConditionTrue, policyv1alpha1.PolicyReasonResolvedRefs, "")
+	assertCondition("retry-bookbuyer", policyv1alpha1.PolicyConditionAttached, metav1.ConditionFalse, policyv1alpha1.PolicyReasonNoProxies, "")
+
+	assertCondition("ingress", policyv1alpha1.PolicyConditionAccepted, metav1.ConditionFalse, policyv1alpha1.PolicyReasonDisabled,
+		"The API of the policy is disabled by the featureFlags.enableIngressBackendPolicy MeshConfig field")
+	assertCondition("ingress", policyv1alpha1.PolicyConditionAttached, metav1.ConditionFalse, policyv1alpha1.PolicyReasonPending, "")
+
+	assertCondition("chain", policyv1alpha1.PolicyConditionResolvedRefs, metav1.ConditionFalse, policyv1alpha1.PolicyReasonRefNotFound,
+		"Referenced resources not found: Plugin logger")
+}
+
+func TestReconcilePolicyStatusesInterval(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+
+	oldInterval := policyStatusReconcileInterval
+	policyStatusReconcileInterval = 50 * time.Millisecond
+	defer func() {
+		policyStatusReconcileInterval = oldInterval
+	}()
+
+	mockKubeController := k8s.NewMockController(mockCtrl)
+	mockPolicyController := policy.NewMockController(mockCtrl)
+	mockPluginController := plugin.NewMockController(mockCtrl)
+	mockCfg := configurator.NewMockConfigurator(mockCtrl)
+	mc := &MeshCatalog{
+		kubeController:   mockKubeController,
+		policyController: mockPolicyController,
+		pluginController: mockPluginController,
+		configurator:     mockCfg,
+	}
+
+	var reconciled int32
+	mockCfg.EXPECT().GetFeatureFlags().Return(configv1alpha2.FeatureFlags{}).AnyTimes()
+	mockKubeController.EXPECT().ListPods().DoAndReturn(func() []*corev1.Pod {
+		atomic.AddInt32(&reconciled, 1)
+		return nil
+	}).AnyTimes()
+	mockKubeController.EXPECT().ListServices().Return(nil).AnyTimes()
+	mockKubeController.EXPECT().ListServiceAccounts().Return(nil).AnyTimes()
+	mockPolicyController.EXPECT().ListPolicies().Return(nil).AnyTimes()
+	mockPluginController.EXPECT().GetPlugins().Return(nil).AnyTimes()
+	mockPluginController.EXPECT().GetPluginChains().Return(nil).AnyTimes()
+	mockPluginController.EXPECT().GetPluginConfigs().Return(nil).AnyTimes()
+
+	stop := make(chan struct{})
+	msgBroker := messaging.NewBroker(stop)
+	done := make(chan struct{})
+	go func() {
+		mc.ReconcilePolicyStatuses(msgBroker, stop)
+		close(done)
+	}()
+
+	// The statuses are reconciled once the reconciler starts, and not again until the config of the sidecars is updated
+	assert.Eventually(func() bool { return atomic.LoadInt32(&reconciled) == 1 }, time.Second, 10*time.Millisecond)
+	time.Sleep(4 * policyStatusReconcileInterval)
+	assert.Equal(int32(1), atomic.LoadInt32(&reconciled))
+
+	// The updates received within an interval are coalesced
+	for i := 0; i < 5; i++ {
+		msgBroker.GetProxyUpdatePubSub().Pub(events.PubSubMessage{Kind: announcements.ProxyUpdate}, announcements.ProxyUpdate.String())
+	}
+	assert.Eventually(func() bool { return atomic.LoadInt32(&reconciled) == 2 }, time.Second, 10*time.Millisecond)
+	time.Sleep(4 * policyStatusReconcileInterval)
+	assert.Equal(int32(2), atomic.LoadInt32(&reconciled))
+
+	close(stop)
+	<-done
+}
+
+func TestSetPolicyConditions(t *testing.T) {
+	assert := tassert.New(t)
+
+	var conditions []metav1.Condition
+	var observedGeneration int64
+
+	assert.True(setPolicyConditions(&conditions, &observedGeneration, 1, policyEvaluation{}, 1))
+	assert.Equal(int64(1), observedGeneration)
+	assert.Len(conditions, 3)
+	attached := meta.FindStatusCondition(conditions, string(policyv1alpha1.PolicyConditionAttached))
+	assert.Equal(metav1.ConditionTrue, attached.Status)
+	transitionTime := attached.LastTransitionTime
+
+	// Nothing changed
+	assert.False(setPolicyConditions(&conditions, &observedGeneration, 1, policyEvaluation{}, 2))
+
+	// A new generation of the policy is observed
+	assert.True(setPolicyConditions(&conditions, &observedGeneration, 2, policyEvaluation{}, 1))
+	assert.Equal(int64(2), observedGeneration)
+	attached = meta.FindStatusCondition(conditions, string(policyv1alpha1.PolicyConditionAttached))
+	assert.Equal(int64(2), attached.ObservedGeneration)
+	assert.Equal(transitionTime, attached.LastTransitionTime)
+
+	// A reference breaks
+	assert.True(setPolicyConditions(&conditions, &observedGeneration, 2, policyEvaluation{missingRefs: []string{"Service default/bookstore"}}, 0))
+	resolvedRefs := meta.FindStatusCondition(conditions, string(policyv1alpha1.PolicyConditionResolvedRefs))
+	assert.Equal(metav1.ConditionFalse, resolvedRefs.Status)
+	assert.Equal("Referenced resources not found: Service default/bookstore", resolvedRefs.Message)
+	attached = meta.FindStatusCondition(conditions, string(policyv1alpha1.PolicyConditionAttached))
+	assert.Equal(metav1.ConditionFalse, attached.Status)
+	assert.Equal(string(policyv1alpha1.PolicyReasonPending), attached.Reason)
+}
@@ -0,0 +1,175 @@
+package catalog
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+// PreviewPolicy returns the impact of applying the given policy on the config of the proxies of the mesh, without
+// applying it: the proxies whose traffic policies would change along with the changed fields of their config.
+// The policy replaces the policy of the same kind, namespace and name if any, and is added to the policies otherwise.
+func (mc *MeshCatalog) PreviewPolicy(obj metav1.Object) (*trafficpolicy.PolicyPreview, error) {
+	previewController, err := mc.policyController.Preview(obj)
+	if err != nil {
+		return nil, err
+	}
+	previewCatalog := *mc
+	previewCatalog.policyController = previewController
+
+	preview := &trafficpolicy.PolicyPreview{
+		Kind:      reflect.Indirect(reflect.ValueOf(obj)).Type().Name(),
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+	}
+
+	pods := mc.kubeController.ListPods()
+	sort.Slice(pods, func(i, j int) bool {
+		if pods[i].Namespace != pods[j].Namespace {
+			return pods[i].Namespace < pods[j].Namespace
+		}
+		return pods[i].Name < pods[j].Name
+	})
+
+	for _, pod := range pods {
+		if !isMeshedPod(pod) {
+			continue
+		}
+
+		before, err := mc.getProxyPolicyConfig(pod)
+		if err != nil {
+			return nil, err
+		}
+		after, err := previewCatalog.getProxyPolicyConfig(pod)
+		if err != nil {
+			return nil, err
+		}
+
+		var changes []trafficpolicy.ConfigChange
+		for _, section := range previewSections {
+			var sectionChanges []trafficpolicy.ConfigChange
+			diffPreviewValues(section, "", before[section], after[section], &sectionChanges)
+			sort.Slice(sectionChanges, func(i, j int) bool {
+				return sectionChanges[i].Path < sectionChanges[j].Path
+			})
+			changes = append(changes, sectionChanges...)
+		}
+		if len(changes) == 0 {
+			preview.UnchangedProxies++
+			continue
+		}
+
+		preview.Proxies = append(preview.Proxies, trafficpolicy.ProxyConfigPreview{
+			Namespace: pod.Namespace,
+			Name:      pod.Name,
+			Identity:  podServiceIdentity(pod).String(),
+			Changes:   changes,
+		})
+	}
+
+	return preview, nil
+}
+
+// previewSections are the sections of the config of a proxy compared by PreviewPolicy, in order
+var previewSections = []string{
+	trafficpolicy.PreviewSectionInbound,
+	trafficpolicy.PreviewSectionOutbound,
+	trafficpolicy.PreviewSectionIngress,
+	trafficpolicy.PreviewSectionAccessControl,
+	trafficpolicy.PreviewSectionEgress,
+	trafficpolicy.PreviewSectionEgressGateway,
+}
+
+// getProxyPolicyConfig returns the traffic policies the catalog computes for the proxy of the given pod, decoded from
+// JSON by section of the config
+func (mc *MeshCatalog) getProxyPolicyConfig(pod *corev1.Pod) (map[string]interface{}, error) {
+	proxyIdentity := podServiceIdentity(pod)
+	proxyServices := mc.listServicesForPod(pod)
+
+	ingressPolicies := make(map[string]*trafficpolicy.IngressTrafficPolicy)
+	aclPolicies := make(map[string]*trafficpolicy.AccessControlTrafficPolicy)
+	for _, svc := range proxyServices {
+		if ingressPolicy, err := mc.GetIngressTrafficPolicy(svc); err != nil {
+			log.Error().Err(err).Msgf("Error computing the ingress traffic policy of service %s", svc)
+		} else if ingressPolicy != nil {
+			ingressPolicies[svc.String()] = ingressPolicy
+		}
+		if aclPolicy, err := mc.GetAccessControlTrafficPolicy(svc); err != nil {
+			log.Error().Err(err).Msgf("Error computing the access control traffic policy of service %s", svc)
+		} else if aclPolicy != nil {
+			aclPolicies[svc.String()] = aclPolicy
+		}
+	}
+
+	egressPolicy, err := mc.GetEgressTrafficPolicy(proxyIdentity)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error computing the egress traffic policy of identity %s", proxyIdentity)
+	}
+	egressGatewayPolicy, err := mc.GetEgressGatewayPolicy()
+	if err != nil {
+		log.Error().Err(err).Msg("Error computing the egress gateway policy")
+	}
+
+	config := map[string]interface{}{
+		trafficpolicy.PreviewSectionInbound:       mc.GetInboundMeshTrafficPolicy(proxyIdentity, proxyServices),
+		trafficpolicy.PreviewSectionOutbound:      mc.GetOutboundMeshTrafficPolicy(proxyIdentity),
+		trafficpolicy.PreviewSectionIngress:       ingressPolicies,
+		trafficpolicy.PreviewSectionAccessControl: aclPolicies,
+		trafficpolicy.PreviewSectionEgress:        egressPolicy,
+		trafficpolicy.PreviewSectionEgressGateway: egressGatewayPolicy,
+	}
+
+	// Compare the config as it is encoded, so that nil and empty values are equal when they are encoded the same way
+	configJSON, err := json.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding the traffic policies of pod %s/%s: %w", pod.Namespace, pod.Name, err)
+	}
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(configJSON, &decoded); err != nil {
+		return nil, fmt.Errorf("error decoding the traffic policies of pod %s/%s: %w", pod.Namespace, pod.Name, err)
+	}
+	return decoded, nil
+}
+
+// diffPreviewValues appends the changes between two decoded JSON values of the given section to changes, recursing
+// into objects and arrays of the same length
+func diffPreviewValues(section, path string, before, after interface{}, changes *[]trafficpolicy.ConfigChange) {
+	switch b := before.(type) {
+	case map[string]interface{}:
+		if a, ok := after.(map[string]interface{}); ok {
+			for key, value := range b {
+				diffPreviewValues(section, joinPreviewPath(path, key), value, a[key], changes)
+			}
+			for key, value := range a {
+				if _, ok := b[key]; !ok {
+					diffPreviewValues(section, joinPreviewPath(path, key), nil, value, changes)
+				}
+			}
+			return
+		}
+	case []interface{}:
+		if a, ok := after.([]interface{}); ok && len(b) == len(a) {
+			for i := range b {
+				diffPreviewValues(section, fmt.Sprintf("%s[%d]", path, i), b[i], a[i], changes)
+			}
+			return
+		}
+	}
+
+	if !reflect.DeepEqual(before, after) {
+		*changes = append(*changes, trafficpolicy.ConfigChange{Section: section, Path: path, Before: before, After: after})
+	}
+}
+
+func joinPreviewPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
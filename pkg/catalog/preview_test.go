@@ -0,0 +1,167 @@
+package catalog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	configv1alpha2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	policyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+
+	tresorFake "github.com/openservicemesh/osm/pkg/certificate/providers/tresor/fake"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/k8s"
+	"github.com/openservicemesh/osm/pkg/policy"
+	"github.com/openservicemesh/osm/pkg/smi"
+	"github.com/openservicemesh/osm/pkg/tests"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+func TestPreviewPolicy(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+
+	bookbuyer := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: tests.Namespace,
+			Name:      "bookbuyer",
+			Labels:    map[string]string{"app": "bookbuyer", constants.SidecarUniqueIDLabelName: "bookbuyer-uuid"},
+		},
+		Spec: corev1.PodSpec{ServiceAccountName: tests.BookbuyerServiceAccountName},
+	}
+	bookstore := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: tests.Namespace,
+			Name:      "bookstore",
+			Labels:    map[string]string{"app": "bookstore", constants.SidecarUniqueIDLabelName: "bookstore-uuid"},
+		},
+		Spec: corev1.PodSpec{ServiceAccountName: tests.BookstoreServiceAccountName},
+	}
+	unmeshed := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: tests.Namespace, Name: "unmeshed"}}
+	bookstoreSvc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: tests.Namespace, Name: "bookstore"},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"app": "bookstore"},
+			Ports:    []corev1.ServicePort{{Name: "http", Port: 14001}},
+		},
+	}
+	ingressBackend := &policyv1alpha1.IngressBackend{
+		ObjectMeta: metav1.ObjectMeta{Namespace: tests.Namespace, Name: "ingress"},
+		Spec: policyv1alpha1.IngressBackendSpec{
+			Backends: []policyv1alpha1.BackendSpec{{Name: "bookstore", Port: policyv1alpha1.PortSpec{Number: 14001, Protocol: "http"}}},
+			Sources:  []policyv1alpha1.IngressSourceSpec{{Kind: policyv1alpha1.KindIPRange, Name: "10.0.0.0/8"}},
+		},
+	}
+
+	mockKubeController := k8s.NewMockController(mockCtrl)
+	mockPolicyController := policy.NewMockController(mockCtrl)
+	mockPreviewController := policy.NewMockController(mockCtrl)
+	mockCfg := configurator.NewMockConfigurator(mockCtrl)
+	mockMeshSpec := smi.NewMockMeshSpec(mockCtrl)
+
+	mc := &MeshCatalog{
+		kubeController:   mockKubeController,
+		policyController: mockPolicyController,
+		certManager:      tresorFake.NewFake(nil, 1*time.Hour),
+		configurator:     mockCfg,
+		meshSpec:         mockMeshSpec,
+	}
+
+	mockCfg.EXPECT().IsPermissiveTrafficPolicyMode().Return(true).AnyTimes()
+	mockCfg.EXPECT().IsEgressEnabled().Return(false).AnyTimes()
+	mockCfg.EXPECT().GetFeatureFlags().Return(configv1alpha2.FeatureFlags{
+		EnableIngressBackendPolicy: true,
+		EnableEgressPolicy:         true,
+	}).AnyTimes()
+	mockCfg.EXPECT().GetMeshConfig().Return(configv1alpha2.MeshConfig{}).AnyTimes()
+
+	mockMeshSpec.EXPECT().ListTrafficSplits(gomock.Any()).Return(nil).AnyTimes()
+
+	mockKubeController.EXPECT().ListPods().Return([]*corev1.Pod{bookstore, unmeshed, bookbuyer})
+	mockKubeController.EXPECT().ListServices().Return([]*corev1.Service{bookstoreSvc}).AnyTimes()
+	mockKubeController.EXPECT().ListServiceIdentitiesForService(gomock.Any()).Return(nil, nil).AnyTimes()
+	mockKubeController.EXPECT().GetEndpoints(gomock.Any()).Return(&corev1.Endpoints{
+		Subsets: []corev1.EndpointSubset{{Ports: []corev1.EndpointPort{{Name: "http", Port: 14001}}}},
+	}, nil).AnyTimes()
+	mockKubeController.EXPECT().GetService(gomock.Any()).Return(bookstoreSvc).AnyTimes()
+	// Previewing a policy does not update the status of the policies
+	mockKubeController.EXPECT().UpdateStatus(gomock.Any()).Times(0)
+
+	mockPolicyController.EXPECT().Preview(ingressBackend).Return(mockPreviewController, nil)
+	for controller, policy := range map[*policy.MockController]*policyv1alpha1.IngressBackend{
+		mockPolicyController:  nil,
+		mockPreviewController: ingressBackend,
+	} {
+		controller.EXPECT().GetIngressBackendPolicy(gomock.Any()).Return(policy).AnyTimes()
+		controller.EXPECT().GetAccessControlPolicy(gomock.Any()).Return(nil).AnyTimes()
+		controller.EXPECT().GetRequestAuthenticationPolicy(gomock.Any()).Return(nil).AnyTimes()
+		controller.EXPECT().GetUpstreamTrafficSetting(gomock.Any()).Return(nil).AnyTimes()
+		controller.EXPECT().ListEgressPoliciesForSourceIdentity(gomock.Any()).Return(nil).AnyTimes()
+		controller.EXPECT().ListEgressGateways().Return(nil).AnyTimes()
+		controller.EXPECT().ListRetryPolicies(gomock.Any()).Return(nil).AnyTimes()
+		controller.EXPECT().ListFaultInjectionPolicies(gomock.Any()).Return(nil).AnyTimes()
+	}
+
+	preview, err := mc.PreviewPolicy(ingressBackend)
+	assert.Nil(err)
+	assert.Equal("IngressBackend", preview.Kind)
+	assert.Equal(tests.Namespace, preview.Namespace)
+	assert.Equal("ingress", preview.Name)
+	assert.Equal(1, preview.UnchangedProxies)
+	assert.Len(preview.Proxies, 1)
+
+	proxy := preview.Proxies[0]
+	assert.Equal("bookstore", proxy.Name)
+	assert.Equal(tests.BookstoreServiceAccountName+"."+tests.Namespace, proxy.Identity)
+	assert.NotEmpty(proxy.Changes)
+	for _, change := range proxy.Changes {
+		assert.Equal(trafficpolicy.PreviewSectionIngress, change.Section)
+	}
+}
+
+func TestDiffPreviewValues(t *testing.T) {
+	testCases := []struct {
+		name     string
+		before   interface{}
+		after    interface{}
+		expected []trafficpolicy.ConfigChange
+	}{
+		{
+			name:   "equal values",
+			before: map[string]interface{}{"a": []interface{}{1.0, "x"}},
+			after:  map[string]interface{}{"a": []interface{}{1.0, "x"}},
+		},
+		{
+			name:   "changed, added and removed fields",
+			before: map[string]interface{}{"a": 1.0, "b": map[string]interface{}{"c": true}, "d": []interface{}{"x", "y"}},
+			after:  map[string]interface{}{"a": 2.0, "e": "new", "d": []interface{}{"x", "z"}},
+			expected: []trafficpolicy.ConfigChange{
+				{Section: "s", Path: "a", Before: 1.0, After: 2.0},
+				{Section: "s", Path: "b", Before: map[string]interface{}{"c": true}, After: nil},
+				{Section: "s", Path: "d[1]", Before: "y", After: "z"},
+				{Section: "s", Path: "e", Before: nil, After: "new"},
+			},
+		},
+		{
+			name:     "arrays of different lengths",
+			before:   []interface{}{"x"},
+			after:    []interface{}{"x", "y"},
+			expected: []trafficpolicy.ConfigChange{{Section: "s", Path: "", Before: []interface{}{"x"}, After: []interface{}{"x", "y"}}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			var changes []trafficpolicy.ConfigChange
+			diffPreviewValues("s", "", tc.before, tc.after, &changes)
+			assert.ElementsMatch(tc.expected, changes)
+		})
+	}
+}
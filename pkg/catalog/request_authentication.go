@@ -0,0 +1,70 @@
+package catalog
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+const (
+	// defaultJWKSSecretKey is the key of the Secret data holding the JSON Web Key Set
+	// if not specified in the RequestAuthentication policy
+	defaultJWKSSecretKey = "jwks"
+)
+
+// getRequestAuthentication returns the JWT authentication applied to inbound traffic for the given upstream service
+func (mc *MeshCatalog) getRequestAuthentication(upstreamSvc service.MeshService) *trafficpolicy.RequestAuthentication {
+	if !mc.configurator.GetFeatureFlags().EnableRequestAuthenticationPolicy {
+		return nil
+	}
+
+	requestAuthn := mc.policyController.GetRequestAuthenticationPolicy(upstreamSvc)
+	if requestAuthn == nil {
+		log.Trace().Msgf("Did not find RequestAuthentication policy for service %s", upstreamSvc)
+		return nil
+	}
+
+	// Providers whose JSON Web Key Set cannot be resolved are skipped, such that
+	// tokens issued by them are rejected
+	authn := &trafficpolicy.RequestAuthentication{
+		Rules: requestAuthn.Spec.Rules,
+	}
+	for i, jwtRule := range requestAuthn.Spec.JWTRules {
+		jwks := jwtRule.JWKS
+		if jwtRule.JWKSSecretRef != nil {
+			secret, err := mc.policyController.GetRequestAuthenticationSecret(corev1.SecretReference{
+				Name:      jwtRule.JWKSSecretRef.Name,
+				Namespace: requestAuthn.Namespace,
+			})
+			if err != nil {
+				log.Error().Err(err).Msgf("Error fetching JWKS secret %s/%s for RequestAuthentication %s/%s, ignoring JWT rule for issuer %s",
+					requestAuthn.Namespace, jwtRule.JWKSSecretRef.Name, requestAuthn.Namespace, requestAuthn.Name, jwtRule.Issuer)
+				continue
+			}
+			key := jwtRule.JWKSSecretRef.Key
+			if key == "" {
+				key = defaultJWKSSecretKey
+			}
+			jwks = string(secret.Data[key])
+		}
+		if jwks == "" {
+			log.Error().Msgf("No JWKS found for issuer %s in RequestAuthentication %s/%s, ignoring JWT rule",
+				jwtRule.Issuer, requestAuthn.Namespace, requestAuthn.Name)
+			continue
+		}
+
+		authn.Providers = append(authn.Providers, trafficpolicy.JWTProvider{
+			Name:                 fmt.Sprintf("%s/%s/%d", requestAuthn.Namespace, requestAuthn.Name, i),
+			Issuer:               jwtRule.Issuer,
+			Audiences:            jwtRule.Audiences,
+			JWKS:                 jwks,
+			ForwardOriginalToken: jwtRule.ForwardOriginalToken,
+			ClaimToHeaders:       jwtRule.ClaimToHeaders,
+		})
+	}
+
+	return authn
+}
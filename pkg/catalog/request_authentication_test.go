@@ -0,0 +1,155 @@
+package catalog
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/policy"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+func TestGetRequestAuthentication(t *testing.T) {
+	inlineJWKS := `{"keys":[{"kty":"oct","kid":"inline","k":"c2VjcmV0"}]}`
+	secretJWKS := `{"keys":[{"kty":"oct","kid":"secret","k":"c2VjcmV0"}]}`
+	svc := service.MeshService{Name: "s1", Namespace: "ns", TargetPort: 8080}
+	rules := []policyV1alpha1.JWTAuthorizationRuleSpec{
+		{Claims: []policyV1alpha1.JWTClaimMatchSpec{{Name: "groups", Values: []string{"admin"}}}},
+	}
+
+	requestAuthn := func(jwtRules ...policyV1alpha1.JWTRuleSpec) *policyV1alpha1.RequestAuthentication {
+		return &policyV1alpha1.RequestAuthentication{
+			ObjectMeta: metav1.ObjectMeta{Name: "authn", Namespace: "ns"},
+			Spec: policyV1alpha1.RequestAuthenticationSpec{
+				Backends: []policyV1alpha1.RequestAuthenticationBackendSpec{
+					{Name: "s1", Port: policyV1alpha1.PortSpec{Number: 8080, Protocol: "http"}},
+				},
+				JWTRules: jwtRules,
+				Rules:    rules,
+			},
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		flagEnabled   bool
+		policy        *policyV1alpha1.RequestAuthentication
+		secret        *corev1.Secret
+		secretErr     error
+		expectedAuthn *trafficpolicy.RequestAuthentication
+	}{
+		{
+			name:          "feature flag disabled",
+			flagEnabled:   false,
+			expectedAuthn: nil,
+		},
+		{
+			name:          "no policy for the service",
+			flagEnabled:   true,
+			policy:        nil,
+			expectedAuthn: nil,
+		},
+		{
+			name:        "inline JWKS",
+			flagEnabled: true,
+			policy: requestAuthn(policyV1alpha1.JWTRuleSpec{
+				Issuer:         "https://issuer.example.com",
+				Audiences:      []string{"bookstore"},
+				JWKS:           inlineJWKS,
+				ClaimToHeaders: []policyV1alpha1.ClaimToHeaderSpec{{Claim: "sub", Header: "x-user"}},
+			}),
+			expectedAuthn: &trafficpolicy.RequestAuthentication{
+				Providers: []trafficpolicy.JWTProvider{
+					{
+						Name:           "ns/authn/0",
+						Issuer:         "https://issuer.example.com",
+						Audiences:      []string{"bookstore"},
+						JWKS:           inlineJWKS,
+						ClaimToHeaders: []policyV1alpha1.ClaimToHeaderSpec{{Claim: "sub", Header: "x-user"}},
+					},
+				},
+				Rules: rules,
+			},
+		},
+		{
+			name:        "JWKS from a secret with the default key",
+			flagEnabled: true,
+			policy: requestAuthn(policyV1alpha1.JWTRuleSpec{
+				Issuer:               "https://issuer.example.com",
+				JWKSSecretRef:        &policyV1alpha1.JWKSSecretReferenceSpec{Name: "jwks"},
+				ForwardOriginalToken: true,
+			}),
+			secret: &corev1.Secret{
+				Data: map[string][]byte{"jwks": []byte(secretJWKS)},
+			},
+			expectedAuthn: &trafficpolicy.RequestAuthentication{
+				Providers: []trafficpolicy.JWTProvider{
+					{
+						Name:                 "ns/authn/0",
+						Issuer:               "https://issuer.example.com",
+						JWKS:                 secretJWKS,
+						ForwardOriginalToken: true,
+					},
+				},
+				Rules: rules,
+			},
+		},
+		{
+			name:        "JWT rules whose JWKS cannot be resolved are skipped",
+			flagEnabled: true,
+			policy: requestAuthn(
+				policyV1alpha1.JWTRuleSpec{
+					Issuer:        "https://missing.example.com",
+					JWKSSecretRef: &policyV1alpha1.JWKSSecretReferenceSpec{Name: "missing"},
+				},
+				policyV1alpha1.JWTRuleSpec{
+					Issuer: "https://issuer.example.com",
+					JWKS:   inlineJWKS,
+				},
+			),
+			secretErr: errors.New("not found"),
+			expectedAuthn: &trafficpolicy.RequestAuthentication{
+				Providers: []trafficpolicy.JWTProvider{
+					{
+						Name:   "ns/authn/1",
+						Issuer: "https://issuer.example.com",
+						JWKS:   inlineJWKS,
+					},
+				},
+				Rules: rules,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockCfg := configurator.NewMockConfigurator(mockCtrl)
+			mockPolicyController := policy.NewMockController(mockCtrl)
+			mc := &MeshCatalog{
+				configurator:     mockCfg,
+				policyController: mockPolicyController,
+			}
+
+			mockCfg.EXPECT().GetFeatureFlags().Return(v1alpha2.FeatureFlags{EnableRequestAuthenticationPolicy: tc.flagEnabled}).Times(1)
+			if tc.flagEnabled {
+				mockPolicyController.EXPECT().GetRequestAuthenticationPolicy(svc).Return(tc.policy).Times(1)
+			}
+			mockPolicyController.EXPECT().GetRequestAuthenticationSecret(gomock.Any()).Return(tc.secret, tc.secretErr).AnyTimes()
+
+			actual := mc.getRequestAuthentication(svc)
+			assert.Equal(tc.expectedAuthn, actual)
+		})
+	}
+}
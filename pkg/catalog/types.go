@@ -11,6 +11,7 @@ import (
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/endpoint"
+	"github.com/openservicemesh/osm/pkg/gatewayapi"
 	"github.com/openservicemesh/osm/pkg/identity"
 	"github.com/openservicemesh/osm/pkg/k8s"
 	"github.com/openservicemesh/osm/pkg/logger"
@@ -50,6 +51,11 @@ type MeshCatalog struct {
 	// multiclusterController implements the functionality related to the resources part of the flomesh.io
 	// API group, such a serviceimport.
 	multiclusterController multicluster.Controller
+
+	// gatewayAPIController implements the functionality related to the route resources part of the
+	// gateway.networking.k8s.io API group, such as HTTPRoute, GRPCRoute and TCPRoute.
+	// It is nil when the Gateway API is not enabled.
+	gatewayAPIController gatewayapi.Controller
 }
 
 // MeshCataloger is the mechanism by which the Service Mesh controller discovers all sidecar proxies connected to the catalog.
@@ -100,6 +106,9 @@ type MeshCataloger interface {
 	// GetRetryPolicy returns the RetryPolicySpec for the given downstream identity and upstream service
 	GetRetryPolicy(downstreamIdentity identity.ServiceIdentity, upstreamSvc service.MeshService) *v1alpha1.RetryPolicySpec
 
+	// GetFaultInjectionPolicy returns the HTTP route faults to inject for the given downstream identity and upstream service
+	GetFaultInjectionPolicy(downstreamIdentity identity.ServiceIdentity, upstreamSvc service.MeshService) []v1alpha1.HTTPFaultInjectionRouteSpec
+
 	// GetExportTrafficPolicy returns the export policy for the given mesh service
 	GetExportTrafficPolicy(svc service.MeshService) (*trafficpolicy.ServiceExportTrafficPolicy, error)
 
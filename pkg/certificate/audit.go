@@ -0,0 +1,215 @@
+package certificate
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	"github.com/openservicemesh/osm/pkg/logger"
+)
+
+// AuditEventType is the type of a certificate audit event
+type AuditEventType string
+
+const (
+	// AuditEventIssued is the type of the audit event recorded when a certificate is issued
+	AuditEventIssued AuditEventType = "CertificateIssued"
+
+	// AuditEventRotated is the type of the audit event recorded when a certificate is rotated
+	AuditEventRotated AuditEventType = "CertificateRotated"
+
+	// AuditEventReleased is the type of the audit event recorded when a certificate is released
+	AuditEventReleased AuditEventType = "CertificateReleased"
+)
+
+var auditLog = logger.New("certificate-audit")
+
+// AuditEvent is the record of an operation on a certificate issued by the Manager
+type AuditEvent struct {
+	// Type is the type of operation
+	Type AuditEventType
+
+	// Time is the time of the operation
+	Time time.Time
+
+	// Identity is the identity the certificate is issued to
+	Identity string
+
+	// CommonName, SANames, URISANames, SerialNumber and Expiration describe the certificate
+	CommonName   CommonName
+	SANames      []string
+	URISANames   []string
+	SerialNumber SerialNumber
+	Expiration   time.Time
+
+	// PreviousSerialNumber is the serial number of the certificate replaced by a rotation
+	PreviousSerialNumber SerialNumber
+
+	// IssuerID is the ID of the issuer which signed the certificate, the name of its MRC
+	IssuerID string
+
+	// ProxyUUID is the UUID of the proxy which requested the certificate, if any
+	ProxyUUID string
+}
+
+// AuditSink records the audit events of the certificates issued by a Manager.
+// Sinks are called synchronously, so they should not block.
+type AuditSink interface {
+	RecordCertificateEvent(AuditEvent)
+}
+
+// AuditSinkFunc is an adapter to use a function as an AuditSink
+type AuditSinkFunc func(AuditEvent)
+
+// RecordCertificateEvent calls f(event)
+func (f AuditSinkFunc) RecordCertificateEvent(event AuditEvent) {
+	f(event)
+}
+
+// LogAuditSink is an AuditSink writing audit events as structured logs. It is always used by the Manager.
+type LogAuditSink struct{}
+
+// RecordCertificateEvent logs the given event
+func (LogAuditSink) RecordCertificateEvent(event AuditEvent) {
+	auditLog.Info().
+		Str("event", string(event.Type)).
+		Str("identity", event.Identity).
+		Str("cn", event.CommonName.String()).
+		Strs("sans", event.SANames).
+		Strs("uri_sans", event.URISANames).
+		Str("serial", event.SerialNumber.String()).
+		Str("previous_serial", event.PreviousSerialNumber.String()).
+		Str("issuer", event.IssuerID).
+		Time("expiration", event.Expiration).
+		Str("proxy_uuid", event.ProxyUUID).
+		Msgf("Certificate audit event %s for %s", event.Type, event.CommonName)
+}
+
+// KubernetesEventRecorder records Kubernetes events, it is implemented by events.EventRecorder
+type KubernetesEventRecorder interface {
+	NormalEvent(reason string, messageFmt string, args ...interface{})
+}
+
+// KubernetesEventAuditSink is an AuditSink recording audit events as Kubernetes events
+type KubernetesEventAuditSink struct {
+	Recorder KubernetesEventRecorder
+}
+
+// RecordCertificateEvent records the given event as a Normal Kubernetes event whose reason is the event type
+func (s KubernetesEventAuditSink) RecordCertificateEvent(event AuditEvent) {
+	s.Recorder.NormalEvent(string(event.Type), "CN=%s SANs=%v URISANs=%v serial=%s previousSerial=%s issuer=%s expiration=%s proxyUUID=%s",
+		event.CommonName, event.SANames, event.URISANames, event.SerialNumber, event.PreviousSerialNumber, event.IssuerID,
+		event.Expiration.Format(time.RFC3339), event.ProxyUUID)
+}
+
+// AddAuditSink adds a sink to which the audit events of the certificates issued by the Manager are sent,
+// in addition to the audit log.
+func (m *Manager) AddAuditSink(sink AuditSink) {
+	m.auditMu.Lock()
+	defer m.auditMu.Unlock()
+	m.auditSinks = append(m.auditSinks, sink)
+}
+
+// audit records an event of the given type for the given certificate with all the sinks
+func (m *Manager) audit(eventType AuditEventType, key string, cert, previous *Certificate) {
+	event := AuditEvent{
+		Type:         eventType,
+		Time:         time.Now(),
+		Identity:     certIdentity(key),
+		CommonName:   cert.CommonName,
+		SANames:      cert.SANames,
+		URISANames:   cert.URISANames,
+		SerialNumber: cert.SerialNumber,
+		Expiration:   cert.Expiration,
+		IssuerID:     cert.signingIssuerID,
+		ProxyUUID:    cert.proxyUUID,
+	}
+	if previous != nil {
+		event.PreviousSerialNumber = previous.SerialNumber
+	}
+
+	LogAuditSink{}.RecordCertificateEvent(event)
+
+	m.auditMu.Lock()
+	sinks := m.auditSinks
+	m.auditMu.Unlock()
+	for _, sink := range sinks {
+		sink.RecordCertificateEvent(event)
+	}
+}
+
+// certExpirationTimeDesc describes the metric of the soonest expiration of the certificates issued to an identity
+var certExpirationTimeDesc = prometheus.NewDesc(
+	prometheus.BuildFQName("osm", "cert", "expiration_time"),
+	"Represents the soonest expiration time, in seconds since the epoch, of the certificates issued to an identity",
+	[]string{"identity"}, nil)
+
+// expirationCollector is a prometheus.Collector computing the soonest expiration of the certificates issued to
+// each identity from the certificates cached by the Manager when the metrics are scraped.
+type expirationCollector struct {
+	m *Manager
+}
+
+// ExpirationMetricsCollector returns the collector of the metric of the soonest expiration of the certificates
+// issued to each identity by the Manager.
+func (m *Manager) ExpirationMetricsCollector() prometheus.Collector {
+	return expirationCollector{m: m}
+}
+
+// Describe implements the prometheus.Collector interface
+func (c expirationCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- certExpirationTimeDesc
+}
+
+// Collect implements the prometheus.Collector interface
+func (c expirationCollector) Collect(ch chan<- prometheus.Metric) {
+	for id, soonest := range c.m.soonestExpirations() {
+		ch <- prometheus.MustNewConstMetric(certExpirationTimeDesc, prometheus.GaugeValue, float64(soonest.Unix()), id)
+	}
+}
+
+// soonestExpirations returns the soonest expiration of the certificates issued to each identity
+func (m *Manager) soonestExpirations() map[string]time.Time {
+	soonest := make(map[string]time.Time)
+	m.cache.Range(func(keyIface interface{}, certInterface interface{}) bool {
+		cert := certInterface.(*Certificate)
+		id := certIdentity(keyIface.(string))
+		if expiration, ok := soonest[id]; !ok || cert.Expiration.Before(expiration) {
+			soonest[id] = cert.Expiration
+		}
+		return true
+	})
+	return soonest
+}
+
+// certIdentity returns the identity of a certificate from its key: the ServiceIdentity of certificates issued to
+// a proxy, whose keys are of the form <ProxyUUID>.<kind>.<name>.<namespace>, or the key itself otherwise.
+func certIdentity(key string) string {
+	parts := strings.SplitN(key, ".", 3)
+	if len(parts) == 3 && strings.Count(parts[2], ".") == 1 {
+		if _, err := uuid.Parse(parts[0]); err == nil {
+			return parts[2]
+		}
+	}
+	return key
+}
+
+// providerKind returns the kind of certificate provider configured by the given MRC, used as a metric label
+func providerKind(mrc *v1alpha2.MeshRootCertificate) string {
+	p := mrc.Spec.Provider
+	switch {
+	case p.Tresor != nil:
+		return "tresor"
+	case p.Vault != nil:
+		return "vault"
+	case p.CertManager != nil:
+		return "certManager"
+	case p.ExternalCA != nil:
+		return "externalCA"
+	default:
+		return ""
+	}
+}
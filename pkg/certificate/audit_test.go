@@ -0,0 +1,135 @@
+package certificate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	tassert "github.com/stretchr/testify/assert"
+
+	"github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	"github.com/openservicemesh/osm/pkg/messaging"
+	"github.com/openservicemesh/osm/pkg/metricsstore"
+)
+
+type fakeKubernetesEventRecorder struct {
+	reasons []string
+}
+
+func (r *fakeKubernetesEventRecorder) NormalEvent(reason string, _ string, _ ...interface{}) {
+	r.reasons = append(r.reasons, reason)
+}
+
+func TestAuditEvents(t *testing.T) {
+	assert := tassert.New(t)
+
+	stop := make(chan struct{})
+	defer close(stop)
+
+	c := &issuer{Issuer: &fakeIssuer{id: "1"}, ID: "mrc-1", TrustDomain: "cluster.local", Provider: "tresor"}
+	m := &Manager{
+		signingIssuer:               c,
+		validatingIssuer:            c,
+		serviceCertValidityDuration: func() time.Duration { return time.Hour },
+		msgBroker:                   messaging.NewBroker(stop),
+	}
+
+	var events []AuditEvent
+	m.AddAuditSink(AuditSinkFunc(func(event AuditEvent) {
+		events = append(events, event)
+	}))
+	recorder := &fakeKubernetesEventRecorder{}
+	m.AddAuditSink(KubernetesEventAuditSink{Recorder: recorder})
+
+	prefix := "1f0b0a4e-5c1e-4f0e-9b5e-3c8f7c6e2a10.sidecar.bookbuyer.default"
+	proxyUUID := "1f0b0a4e-5c1e-4f0e-9b5e-3c8f7c6e2a10"
+	rotationsBefore := testutil.ToFloat64(metricsstore.DefaultMetricsStore.CertRotationTotal.WithLabelValues("tresor"))
+
+	cert, err := m.IssueCertificate(prefix, Service, SubjectAlternativeNames("bookbuyer.default.svc"), RequestedByProxy(proxyUUID))
+	assert.NoError(err)
+	if assert.Len(events, 1) {
+		assert.Equal(AuditEventIssued, events[0].Type)
+		assert.Equal("bookbuyer.default", events[0].Identity)
+		assert.Equal(cert.CommonName, events[0].CommonName)
+		assert.Equal([]string{"bookbuyer.default.svc"}, events[0].SANames)
+		assert.Equal(cert.SerialNumber, events[0].SerialNumber)
+		assert.Equal(cert.Expiration, events[0].Expiration)
+		assert.Equal("mrc-1", events[0].IssuerID)
+		assert.Equal(proxyUUID, events[0].ProxyUUID)
+	}
+	assert.Equal(map[string]time.Time{"bookbuyer.default": cert.Expiration}, m.soonestExpirations())
+	assert.Equal(float64(cert.Expiration.Unix()), testutil.ToFloat64(m.ExpirationMetricsCollector()))
+
+	// A certificate served from the cache is not audited
+	_, err = m.IssueCertificate(prefix, Service)
+	assert.NoError(err)
+	assert.Len(events, 1)
+
+	// Rotations keep the UUID of the proxy which requested the certificate
+	rotatedIssuer := &issuer{Issuer: &fakeIssuer{id: "2"}, ID: "mrc-2", TrustDomain: "cluster.local", Provider: "tresor"}
+	m.signingIssuer, m.validatingIssuer = rotatedIssuer, rotatedIssuer
+	m.checkAndRotate()
+	rotated := m.GetCertificate(prefix)
+	if assert.Len(events, 2) {
+		assert.Equal(AuditEventRotated, events[1].Type)
+		assert.Equal(rotated.SerialNumber, events[1].SerialNumber)
+		assert.Equal(cert.SerialNumber, events[1].PreviousSerialNumber)
+		assert.Equal("mrc-2", events[1].IssuerID)
+		assert.Equal(proxyUUID, events[1].ProxyUUID)
+	}
+	assert.Equal(rotationsBefore+1, testutil.ToFloat64(metricsstore.DefaultMetricsStore.CertRotationTotal.WithLabelValues("tresor")))
+
+	m.ReleaseCertificate(prefix)
+	m.ReleaseCertificate(prefix)
+	if assert.Len(events, 3) {
+		assert.Equal(AuditEventReleased, events[2].Type)
+		assert.Equal(rotated.SerialNumber, events[2].SerialNumber)
+	}
+	// The expiration of the identity is no longer reported
+	assert.Empty(m.soonestExpirations())
+	assert.Zero(testutil.CollectAndCount(m.ExpirationMetricsCollector()))
+
+	assert.Equal([]string{string(AuditEventIssued), string(AuditEventRotated), string(AuditEventReleased)}, recorder.reasons)
+}
+
+func TestCertIdentity(t *testing.T) {
+	testCases := []struct {
+		key      string
+		expected string
+	}{
+		{
+			key:      "1f0b0a4e-5c1e-4f0e-9b5e-3c8f7c6e2a10.sidecar.bookbuyer.default",
+			expected: "bookbuyer.default",
+		},
+		{
+			key:      "bookbuyer.default",
+			expected: "bookbuyer.default",
+		},
+		{
+			key:      "osm-controller.osm-system.svc",
+			expected: "osm-controller.osm-system.svc",
+		},
+		{
+			key:      "not-a-uuid.sidecar.bookbuyer.default",
+			expected: "not-a-uuid.sidecar.bookbuyer.default",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.key, func(t *testing.T) {
+			tassert.Equal(t, tc.expected, certIdentity(tc.key))
+		})
+	}
+}
+
+func TestProviderKind(t *testing.T) {
+	assert := tassert.New(t)
+
+	assert.Equal("tresor", providerKind(&v1alpha2.MeshRootCertificate{Spec: v1alpha2.MeshRootCertificateSpec{
+		Provider: v1alpha2.ProviderSpec{Tresor: &v1alpha2.TresorProviderSpec{}},
+	}}))
+	assert.Equal("externalCA", providerKind(&v1alpha2.MeshRootCertificate{Spec: v1alpha2.MeshRootCertificateSpec{
+		Provider: v1alpha2.ProviderSpec{ExternalCA: &v1alpha2.ExternalCAProviderSpec{}},
+	}}))
+	assert.Empty(providerKind(&v1alpha2.MeshRootCertificate{}))
+}
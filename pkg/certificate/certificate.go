@@ -66,6 +66,17 @@ func (c *Certificate) GetTrustedCAs() pem.RootCertificate {
 	return c.TrustedCAs
 }
 
+// GetSigningIssuerID returns the ID of the issuer that signed the certificate
+func (c *Certificate) GetSigningIssuerID() string {
+	return c.signingIssuerID
+}
+
+// GetValidatingIssuerID returns the ID of the issuer whose root certificate is part of the trust context
+// of the certificate, which differs from the signing issuer during a root certificate rotation
+func (c *Certificate) GetValidatingIssuerID() string {
+	return c.validatingIssuerID
+}
+
 // NewFromPEM is a helper returning a *certificate.Certificate from the PEM components given.
 func NewFromPEM(pemCert pem.Certificate, pemKey pem.PrivateKey) (*Certificate, error) {
 	x509Cert, err := DecodePEMCertificate(pemCert)
@@ -3,6 +3,7 @@ package certificate
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"time"
 
 	v1 "k8s.io/api/core/v1"
@@ -24,6 +25,11 @@ func (c *fakeMRCClient) GetCertIssuerForMRC(mrc *v1alpha2.MeshRootCertificate) (
 	return &fakeIssuer{}, pem.RootCertificate("rootCA"), nil
 }
 
+// UpdateMRCStatus returns the given MRC as it has no backing store
+func (c *fakeMRCClient) UpdateMRCStatus(mrc *v1alpha2.MeshRootCertificate) (*v1alpha2.MeshRootCertificate, error) {
+	return mrc, nil
+}
+
 // List returns the single, pre-generated MRC. It is intended to implement the certificate.MRCClient interface.
 func (c *fakeMRCClient) List() ([]*v1alpha2.MeshRootCertificate, error) {
 	// return single empty object in the list.
@@ -74,13 +80,18 @@ type fakeIssuer struct {
 }
 
 // IssueCertificate is a testing helper to satisfy the certificate client interface
-func (i *fakeIssuer) IssueCertificate(cn CommonName, saNames []string, validityPeriod time.Duration) (*Certificate, error) {
+func (i *fakeIssuer) IssueCertificate(cn CommonName, saNames []string, uriSANs []*url.URL, validityPeriod time.Duration) (*Certificate, error) {
 	if i.err {
 		return nil, fmt.Errorf("%s failed", i.id)
 	}
+	var uriSANames []string
+	for _, uri := range uriSANs {
+		uriSANames = append(uriSANames, uri.String())
+	}
 	return &Certificate{
 		CommonName: cn,
 		SANames:    saNames,
+		URISANames: uriSANames,
 		Expiration: time.Now().Add(validityPeriod),
 		// simply used to distinguish the private/public key from other issuers
 		IssuingCA:  pem.RootCertificate(i.id),
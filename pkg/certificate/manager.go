@@ -4,15 +4,20 @@ import (
 	"context"
 	"errors"
 	"math/rand"
+	"net/url"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/openservicemesh/osm/pkg/announcements"
+	"github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/errcode"
+	"github.com/openservicemesh/osm/pkg/identity"
 	"github.com/openservicemesh/osm/pkg/k8s/events"
 	"github.com/openservicemesh/osm/pkg/logger"
 	"github.com/openservicemesh/osm/pkg/messaging"
+	"github.com/openservicemesh/osm/pkg/metricsstore"
 )
 
 var (
@@ -25,6 +30,7 @@ func NewManager(ctx context.Context, mrcClient MRCClient, getServiceCertValidity
 		serviceCertValidityDuration: getServiceCertValidityPeriod,
 		ingressCertValidityDuration: getIngressCertValidityDuration,
 		msgBroker:                   msgBroker,
+		mrcClient:                   mrcClient,
 	}
 
 	err := m.start(ctx, mrcClient)
@@ -40,6 +46,7 @@ func (m *Manager) startRotationTicker(ctx context.Context, checkInterval time.Du
 	ticker := time.NewTicker(checkInterval)
 	go func() {
 		m.checkAndRotate()
+		m.advanceRootRotation()
 		for {
 			select {
 			case <-ctx.Done():
@@ -47,6 +54,7 @@ func (m *Manager) startRotationTicker(ctx context.Context, checkInterval time.Du
 				return
 			case <-ticker.C:
 				m.checkAndRotate()
+				m.advanceRootRotation()
 			}
 		}
 	}()
@@ -117,44 +125,144 @@ func (m *Manager) start(ctx context.Context, mrcClient MRCClient) error {
 
 func (m *Manager) handleMRCEvent(mrcClient MRCClient, event MRCEvent) error {
 	switch event.Type {
-	case MRCEventAdded:
-		mrc := event.MRC
-		if mrc.Status.State == constants.MRCStateError {
-			log.Debug().Msgf("skipping MRC with error state %s", mrc.GetName())
-			return nil
+	case MRCEventAdded, MRCEventUpdated:
+		return m.observeMRC(mrcClient, event.MRC)
+	case MRCEventSecretUpdated:
+		log.Info().Msgf("Regenerating the issuer of MRC %s after an update to its Secret", event.MRC.GetName())
+		return m.observeMRCIssuer(mrcClient, event.MRC, true)
+	}
+
+	return nil
+}
+
+// observeMRC records the given MRC and updates the signing and validating issuers
+// from the states of all the MRCs observed.
+func (m *Manager) observeMRC(mrcClient MRCClient, mrc *v1alpha2.MeshRootCertificate) error {
+	return m.observeMRCIssuer(mrcClient, mrc, false)
+}
+
+// observeMRCIssuer records the given MRC and updates the signing and validating issuers from the states of all
+// the MRCs observed. The issuer of the MRC is generated again when regenerate is set or when its spec changed.
+func (m *Manager) observeMRCIssuer(mrcClient MRCClient, mrc *v1alpha2.MeshRootCertificate, regenerate bool) error {
+	if mrc.Status.State == constants.MRCStateError {
+		log.Debug().Msgf("skipping MRC with error state %s", mrc.GetName())
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		if observed, ok := m.mrcIssuers[mrc.Name]; ok {
+			delete(m.mrcIssuers, mrc.Name)
+			m.updateIssuers()
+			m.releaseIssuerIfUnused(observed.issuer)
 		}
+		return nil
+	}
+
+	m.mu.Lock()
+	observed, ok := m.mrcIssuers[mrc.Name]
+	m.mu.Unlock()
 
+	// The issuer is only generated again when the spec of the MRC or its Secret changes, not on status updates
+	var c *issuer
+	if ok && observed.mrc.Generation == mrc.Generation && !regenerate {
+		c = observed.issuer
+	} else {
 		client, ca, err := mrcClient.GetCertIssuerForMRC(mrc)
 		if err != nil {
 			return err
 		}
+		c = &issuer{Issuer: client, ID: mrc.Name, CertificateAuthority: ca, TrustDomain: mrc.Spec.TrustDomain, SpiffeEnabled: mrc.Spec.SpiffeEnabled, Provider: providerKind(mrc)}
+	}
 
-		c := &issuer{Issuer: client, ID: mrc.Name, CertificateAuthority: ca, TrustDomain: mrc.Spec.TrustDomain}
-		switch {
-		case mrc.Status.State == constants.MRCStateActive:
-			m.mu.Lock()
-			m.signingIssuer = c
-			m.validatingIssuer = c
-			m.mu.Unlock()
-		case mrc.Status.State == constants.MRCStateIssuingRollback || mrc.Status.State == constants.MRCStateIssuingRollout:
-			m.mu.Lock()
-			m.signingIssuer = c
-			m.mu.Unlock()
-		case mrc.Status.State == constants.MRCStateValidatingRollback || mrc.Status.State == constants.MRCStateValidatingRollout:
-			m.mu.Lock()
-			m.validatingIssuer = c
-			m.mu.Unlock()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.mrcIssuers == nil {
+		m.mrcIssuers = make(map[string]*mrcIssuer)
+	}
+	previous := m.mrcIssuers[mrc.Name]
+	m.mrcIssuers[mrc.Name] = &mrcIssuer{mrc: mrc, issuer: c}
+	m.updateIssuers()
+	if previous != nil && previous.issuer != c {
+		m.releaseIssuerIfUnused(previous.issuer)
+	}
+
+	return nil
+}
+
+// updateIssuers sets the signing and validating issuers from the states of the observed MRCs.
+// During a root certificate rotation, the MRC being rolled out is the signing or the validating
+// issuer depending on its state, while the active MRC fills the other role.
+// An MRC without a state is only used when no MRC is active.
+// The caller must hold the lock.
+func (m *Manager) updateIssuers() {
+	var active, signing, validating, unassigned *issuer
+	for _, name := range m.sortedMRCNames() {
+		observed := m.mrcIssuers[name]
+		switch observed.mrc.Status.State {
+		case constants.MRCStateActive:
+			active = observed.issuer
+		case constants.MRCStateIssuingRollout, constants.MRCStateIssuingRollback:
+			signing = observed.issuer
+		case constants.MRCStateValidatingRollout, constants.MRCStateValidatingRollback:
+			validating = observed.issuer
+		case constants.MRCStateInactive:
 		default:
-			m.mu.Lock()
-			m.signingIssuer = c
-			m.validatingIssuer = c
-			m.mu.Unlock()
+			if unassigned == nil {
+				unassigned = observed.issuer
+			}
 		}
-	case MRCEventUpdated:
-		// TODO
 	}
 
-	return nil
+	if active == nil {
+		active = unassigned
+	}
+	if signing == nil {
+		signing = active
+	}
+	if validating == nil {
+		validating = active
+	}
+	if signing == nil {
+		signing = validating
+	}
+	if validating == nil {
+		validating = signing
+	}
+	if signing == nil {
+		return
+	}
+
+	previousSigning, previousValidating := m.signingIssuer, m.validatingIssuer
+	m.signingIssuer = signing
+	m.validatingIssuer = validating
+	m.releaseIssuerIfUnused(previousSigning)
+	m.releaseIssuerIfUnused(previousValidating)
+}
+
+// releaseIssuerIfUnused releases the resources held by the given issuer if it is neither the signing nor the validating
+// issuer, nor the issuer of an observed MRC. The caller must hold the lock.
+func (m *Manager) releaseIssuerIfUnused(i *issuer) {
+	if i == nil || i == m.signingIssuer || i == m.validatingIssuer {
+		return
+	}
+	for _, observed := range m.mrcIssuers {
+		if observed.issuer == i {
+			return
+		}
+	}
+	if releasable, ok := i.Issuer.(ReleasableIssuer); ok {
+		log.Debug().Msgf("Releasing the issuer of MRC %s", i.ID)
+		releasable.Release()
+	}
+}
+
+// sortedMRCNames returns the names of the observed MRCs in order so that the issuers are
+// chosen deterministically. The caller must hold the lock.
+func (m *Manager) sortedMRCNames() []string {
+	names := make([]string, 0, len(m.mrcIssuers))
+	for name := range m.mrcIssuers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
 }
 
 // GetTrustDomain returns the trust domain from the configured signingkey issuer.
@@ -299,6 +407,9 @@ func (m *Manager) issueCertificate(prefix string, ct CertType, opts ...IssueOpti
 	}
 	if cert != nil && rotate {
 		options.saNames = uniqueSubjectAlternativeNames(cert.SANames)
+		if options.proxyUUID == "" {
+			options.proxyUUID = cert.proxyUUID
+		}
 	}
 
 	m.mu.Lock()
@@ -308,7 +419,11 @@ func (m *Manager) issueCertificate(prefix string, ct CertType, opts ...IssueOpti
 
 	start := time.Now()
 	validityDuration := m.getValidityDurationForCertType(ct)
-	newCert, err := signingIssuer.IssueCertificate(options.formatCN(prefix, signingIssuer.TrustDomain), options.subjectAlternativeNames(), options.validityPeriod(validityDuration))
+	var uriSANs []*url.URL
+	if signingIssuer.SpiffeEnabled && ct == Service && !options.fullCNProvided {
+		uriSANs = spiffeIDSubjectAlternativeNames(prefix, signingIssuer.TrustDomain)
+	}
+	newCert, err := signingIssuer.IssueCertificate(options.formatCN(prefix, signingIssuer.TrustDomain), options.subjectAlternativeNames(), uriSANs, options.validityPeriod(validityDuration))
 	if err != nil {
 		return nil, err
 	}
@@ -322,12 +437,17 @@ func (m *Manager) issueCertificate(prefix string, ct CertType, opts ...IssueOpti
 	newCert.signingIssuerID = signingIssuer.ID
 	newCert.validatingIssuerID = validatingIssuer.ID
 	newCert.certType = ct
+	newCert.proxyUUID = options.proxyUUID
 	m.cache.Store(prefix, newCert)
 
 	log.Trace().Msgf("It took %s to issue certificate with SerialNumber=%s", time.Since(start), newCert.GetSerialNumber())
 
-	if rotate {
+	if !rotate {
+		m.audit(AuditEventIssued, prefix, newCert, nil)
+	} else {
 		// Certificate was rotated
+		m.audit(AuditEventRotated, prefix, newCert, cert)
+		metricsstore.DefaultMetricsStore.CertRotationTotal.WithLabelValues(signingIssuer.Provider).Inc()
 		m.msgBroker.GetCertPubSub().Pub(events.PubSubMessage{
 			Kind:   announcements.CertificateRotated,
 			NewObj: newCert,
@@ -343,7 +463,11 @@ func (m *Manager) issueCertificate(prefix string, ct CertType, opts ...IssueOpti
 // ReleaseCertificate is called when a cert will no longer be needed and should be removed from the system.
 func (m *Manager) ReleaseCertificate(key string) {
 	log.Trace().Msgf("Releasing certificate %s", key)
-	m.cache.Delete(key)
+	certInterface, released := m.cache.LoadAndDelete(key)
+	if !released {
+		return
+	}
+	m.audit(AuditEventReleased, key, certInterface.(*Certificate), nil)
 }
 
 // ListIssuedCertificates implements CertificateDebugger interface and returns the list of issued certificates.
@@ -355,3 +479,18 @@ func (m *Manager) ListIssuedCertificates() []*Certificate {
 	})
 	return certs
 }
+
+// spiffeIDSubjectAlternativeNames returns the SPIFFE ID URI SAN for a service certificate whose prefix is
+// a ServiceIdentity of the form <ServiceAccount>.<Namespace>. Prefixes of any other form have no SPIFFE ID.
+func spiffeIDSubjectAlternativeNames(prefix, trustDomain string) []*url.URL {
+	if !identity.ServiceIdentity(prefix).IsValid() {
+		log.Warn().Msgf("Certificate prefix %s is not a service identity, the certificate has no SPIFFE ID", prefix)
+		return nil
+	}
+	spiffeID, err := url.Parse(identity.ServiceIdentity(prefix).AsSpiffeID(trustDomain))
+	if err != nil {
+		log.Error().Err(err).Msgf("Error building SPIFFE ID for certificate with prefix %s", prefix)
+		return nil
+	}
+	return []*url.URL{spiffeID}
+}
@@ -318,9 +318,27 @@ func TestIssueCertificate(t *testing.T) {
 }
 
 func TestHandleMRCEvent(t *testing.T) {
+	newMRC := func(name, state string) *v1alpha2.MeshRootCertificate {
+		return &v1alpha2.MeshRootCertificate{
+			ObjectMeta: v1.ObjectMeta{
+				Name: name,
+			},
+			Spec: v1alpha2.MeshRootCertificateSpec{
+				TrustDomain: "foo.bar.com",
+			},
+			Status: v1alpha2.MeshRootCertificateStatus{
+				State: state,
+			},
+		}
+	}
+	newIssuer := func(id string) issuer {
+		return issuer{Issuer: &fakeIssuer{}, ID: id, TrustDomain: "foo.bar.com", CertificateAuthority: pem.RootCertificate("rootCA")}
+	}
+
 	testCases := []struct {
 		name                 string
 		mrcClient            MRCClient
+		observed             []*v1alpha2.MeshRootCertificate
 		mrcEvent             MRCEvent
 		wantErr              bool
 		wantSigningIssuer    issuer
@@ -346,12 +364,47 @@ func TestHandleMRCEvent(t *testing.T) {
 			wantSigningIssuer:    issuer{Issuer: &fakeIssuer{}, ID: "my-mrc", TrustDomain: "foo.bar.com", CertificateAuthority: pem.RootCertificate("rootCA")},
 			wantValidatingIssuer: issuer{Issuer: &fakeIssuer{}, ID: "my-mrc", TrustDomain: "foo.bar.com", CertificateAuthority: pem.RootCertificate("rootCA")},
 		},
+		{
+			name:                 "new MRC without a state is not used while another MRC is active",
+			mrcClient:            &fakeMRCClient{},
+			observed:             []*v1alpha2.MeshRootCertificate{newMRC("old-mrc", constants.MRCStateActive)},
+			mrcEvent:             MRCEvent{Type: MRCEventAdded, MRC: newMRC("new-mrc", "")},
+			wantSigningIssuer:    newIssuer("old-mrc"),
+			wantValidatingIssuer: newIssuer("old-mrc"),
+		},
+		{
+			name:                 "MRC updated to validatingRollout becomes the validating issuer",
+			mrcClient:            &fakeMRCClient{},
+			observed:             []*v1alpha2.MeshRootCertificate{newMRC("old-mrc", constants.MRCStateActive), newMRC("new-mrc", "")},
+			mrcEvent:             MRCEvent{Type: MRCEventUpdated, MRC: newMRC("new-mrc", constants.MRCStateValidatingRollout)},
+			wantSigningIssuer:    newIssuer("old-mrc"),
+			wantValidatingIssuer: newIssuer("new-mrc"),
+		},
+		{
+			name:                 "MRC updated to issuingRollout becomes the signing issuer",
+			mrcClient:            &fakeMRCClient{},
+			observed:             []*v1alpha2.MeshRootCertificate{newMRC("old-mrc", constants.MRCStateActive), newMRC("new-mrc", constants.MRCStateValidatingRollout)},
+			mrcEvent:             MRCEvent{Type: MRCEventUpdated, MRC: newMRC("new-mrc", constants.MRCStateIssuingRollout)},
+			wantSigningIssuer:    newIssuer("new-mrc"),
+			wantValidatingIssuer: newIssuer("old-mrc"),
+		},
+		{
+			name:                 "inactive MRC is no longer used",
+			mrcClient:            &fakeMRCClient{},
+			observed:             []*v1alpha2.MeshRootCertificate{newMRC("old-mrc", constants.MRCStateActive), newMRC("new-mrc", constants.MRCStateIssuingRollout)},
+			mrcEvent:             MRCEvent{Type: MRCEventUpdated, MRC: newMRC("old-mrc", constants.MRCStateInactive)},
+			wantSigningIssuer:    newIssuer("new-mrc"),
+			wantValidatingIssuer: newIssuer("new-mrc"),
+		},
 	}
 
 	for _, tt := range testCases {
 		t.Run(tt.name, func(t *testing.T) {
 			assert := tassert.New(t)
 			m := &Manager{}
+			for _, mrc := range tt.observed {
+				assert.NoError(m.handleMRCEvent(tt.mrcClient, MRCEvent{Type: MRCEventAdded, MRC: mrc}))
+			}
 
 			err := m.handleMRCEvent(tt.mrcClient, tt.mrcEvent)
 			if !tt.wantErr {
@@ -365,3 +418,138 @@ func TestHandleMRCEvent(t *testing.T) {
 		})
 	}
 }
+
+type releasableFakeIssuer struct {
+	fakeIssuer
+	released bool
+}
+
+func (i *releasableFakeIssuer) Release() {
+	i.released = true
+}
+
+type releasingMRCClient struct {
+	fakeMRCClient
+	issued []*releasableFakeIssuer
+}
+
+func (c *releasingMRCClient) GetCertIssuerForMRC(mrc *v1alpha2.MeshRootCertificate) (Issuer, pem.RootCertificate, error) {
+	i := &releasableFakeIssuer{fakeIssuer: fakeIssuer{id: mrc.Name}}
+	c.issued = append(c.issued, i)
+	return i, pem.RootCertificate("rootCA"), nil
+}
+
+func TestReleaseUnusedIssuers(t *testing.T) {
+	assert := tassert.New(t)
+
+	newMRC := func(name, state string, generation int64) *v1alpha2.MeshRootCertificate {
+		return &v1alpha2.MeshRootCertificate{
+			ObjectMeta: v1.ObjectMeta{Name: name, Generation: generation},
+			Status:     v1alpha2.MeshRootCertificateStatus{State: state},
+		}
+	}
+
+	mrcClient := &releasingMRCClient{}
+	m := &Manager{}
+	assert.NoError(m.handleMRCEvent(mrcClient, MRCEvent{Type: MRCEventAdded, MRC: newMRC("old-mrc", constants.MRCStateActive, 1)}))
+	assert.Len(mrcClient.issued, 1)
+
+	// status updates do not generate the issuer again
+	assert.NoError(m.handleMRCEvent(mrcClient, MRCEvent{Type: MRCEventUpdated, MRC: newMRC("old-mrc", constants.MRCStateActive, 1)}))
+	assert.Len(mrcClient.issued, 1)
+	assert.False(mrcClient.issued[0].released)
+
+	// the issuer generated again for a spec update replaces the previous one
+	assert.NoError(m.handleMRCEvent(mrcClient, MRCEvent{Type: MRCEventUpdated, MRC: newMRC("old-mrc", constants.MRCStateActive, 2)}))
+	assert.Len(mrcClient.issued, 2)
+	assert.True(mrcClient.issued[0].released)
+	assert.False(mrcClient.issued[1].released)
+
+	// the issuer generated again for an update to the Secret of the MRC replaces the previous one
+	assert.NoError(m.handleMRCEvent(mrcClient, MRCEvent{Type: MRCEventSecretUpdated, MRC: newMRC("old-mrc", constants.MRCStateActive, 2)}))
+	assert.Len(mrcClient.issued, 3)
+	assert.True(mrcClient.issued[1].released)
+	assert.False(mrcClient.issued[2].released)
+	assert.Same(mrcClient.issued[2], m.signingIssuer.Issuer)
+
+	assert.NoError(m.handleMRCEvent(mrcClient, MRCEvent{Type: MRCEventAdded, MRC: newMRC("new-mrc", constants.MRCStateActive, 1)}))
+	assert.Len(mrcClient.issued, 4)
+
+	// the issuer of an MRC in the error state is released once it is no longer used
+	assert.NoError(m.handleMRCEvent(mrcClient, MRCEvent{Type: MRCEventUpdated, MRC: newMRC("old-mrc", constants.MRCStateError, 2)}))
+	assert.True(mrcClient.issued[2].released)
+	assert.False(mrcClient.issued[3].released)
+
+	// the last issuer is still used for signing when its MRC is in the error state
+	assert.NoError(m.handleMRCEvent(mrcClient, MRCEvent{Type: MRCEventUpdated, MRC: newMRC("new-mrc", constants.MRCStateError, 1)}))
+	assert.False(mrcClient.issued[3].released)
+	assert.Equal("new-mrc", m.signingIssuer.ID)
+}
+
+func TestIssueCertificateWithSpiffeID(t *testing.T) {
+	testCases := []struct {
+		name               string
+		spiffeEnabled      bool
+		prefix             string
+		certType           CertType
+		opts               []IssueOption
+		expectedURISANames []string
+	}{
+		{
+			name:               "service certificate for a service identity",
+			spiffeEnabled:      true,
+			prefix:             "sa-1.ns-1",
+			certType:           Service,
+			expectedURISANames: []string{"spiffe://cluster.local/ns/ns-1/sa/sa-1"},
+		},
+		{
+			name:               "service certificate for a ServiceAccount name with dots",
+			spiffeEnabled:      true,
+			prefix:             "sa-1.v1.ns-1",
+			certType:           Service,
+			expectedURISANames: []string{"spiffe://cluster.local/ns/ns-1/sa/sa-1.v1"},
+		},
+		{
+			name:          "SPIFFE is disabled for the issuer",
+			spiffeEnabled: false,
+			prefix:        "sa-1.ns-1",
+			certType:      Service,
+		},
+		{
+			name:          "internal certificate",
+			spiffeEnabled: true,
+			prefix:        "sa-1.ns-1",
+			certType:      Internal,
+		},
+		{
+			name:          "service certificate with a full CN",
+			spiffeEnabled: true,
+			prefix:        "gateway.ns-1.cluster.local",
+			certType:      Service,
+			opts:          []IssueOption{FullCNProvided()},
+		},
+		{
+			name:          "prefix is not a service identity",
+			spiffeEnabled: true,
+			prefix:        "foo",
+			certType:      Service,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			c := &issuer{Issuer: &fakeIssuer{id: "1"}, ID: "1", TrustDomain: "cluster.local", SpiffeEnabled: tc.spiffeEnabled}
+			m := &Manager{
+				signingIssuer:               c,
+				validatingIssuer:            c,
+				serviceCertValidityDuration: func() time.Duration { return time.Hour },
+			}
+
+			cert, err := m.IssueCertificate(tc.prefix, tc.certType, tc.opts...)
+			assert.NoError(err)
+			assert.Equal(tc.expectedURISANames, cert.URISANames)
+		})
+	}
+}
@@ -13,6 +13,7 @@ type issueOptions struct {
 	fullCNProvided   bool
 	validityDuration *time.Duration
 	saNames          []string
+	proxyUUID        string
 }
 
 func (o *issueOptions) formatCN(prefix, trustDomain string) CommonName {
@@ -87,3 +88,10 @@ func SubjectAlternativeNames(saNames ...string) IssueOption {
 		opts.saNames = saNames
 	}
 }
+
+// RequestedByProxy records the UUID of the proxy requesting the certificate in the audit events of the certificate.
+func RequestedByProxy(proxyUUID string) IssueOption {
+	return func(opts *issueOptions) {
+		opts.proxyUUID = proxyUUID
+	}
+}
@@ -0,0 +1,105 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+const (
+	// acknowledgementsLeaseLabel labels the leases recording the unacknowledged proxies of the control plane replicas
+	acknowledgementsLeaseLabel = "openservicemesh.io/mrc-acknowledgements"
+
+	// acknowledgementsLeasePrefix is the prefix of the name of the lease of a replica, followed by the replica name
+	acknowledgementsLeasePrefix = "osm-mrc-acknowledgements-"
+
+	signingIssuerAnnotation         = "openservicemesh.io/signing-issuer"
+	validatingIssuerAnnotation      = "openservicemesh.io/validating-issuer"
+	unacknowledgedProxiesAnnotation = "openservicemesh.io/unacknowledged-proxies"
+
+	// acknowledgementsLeaseDuration is the time after which the lease of a replica that stopped renewing it is ignored
+	acknowledgementsLeaseDuration = 30 * time.Second
+)
+
+// AggregateUnacknowledgedProxies records the number of unacknowledged proxies connected to this replica for the given
+// issuers in the lease of the replica, and returns the sum of the unacknowledged proxies recorded in the leases of the
+// live replicas. A replica that has not recorded its proxies for the given issuers yet counts as one unacknowledged
+// proxy, the leases of the replicas that stopped renewing them are deleted.
+// It implements the certificate.AcknowledgementAggregator interface.
+func (m *MRCComposer) AggregateUnacknowledgedProxies(signingIssuerID, validatingIssuerID string, pending int) (int, error) {
+	leases := m.kubeClient.CoordinationV1().Leases(m.namespace)
+	name := acknowledgementsLeasePrefix + m.replicaName
+	now := metav1.NewMicroTime(time.Now())
+	annotations := map[string]string{
+		signingIssuerAnnotation:         signingIssuerID,
+		validatingIssuerAnnotation:      validatingIssuerID,
+		unacknowledgedProxiesAnnotation: strconv.Itoa(pending),
+	}
+
+	lease, err := leases.Get(context.Background(), name, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		lease = &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   m.namespace,
+				Labels:      map[string]string{acknowledgementsLeaseLabel: "true"},
+				Annotations: annotations,
+			},
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity:       pointer.String(m.replicaName),
+				LeaseDurationSeconds: pointer.Int32(int32(acknowledgementsLeaseDuration.Seconds())),
+				RenewTime:            &now,
+			},
+		}
+		if _, err := leases.Create(context.Background(), lease, metav1.CreateOptions{}); err != nil {
+			return 0, fmt.Errorf("error creating lease %s/%s: %w", m.namespace, name, err)
+		}
+	case err != nil:
+		return 0, fmt.Errorf("error getting lease %s/%s: %w", m.namespace, name, err)
+	default:
+		lease.Annotations = annotations
+		lease.Spec.RenewTime = &now
+		if _, err := leases.Update(context.Background(), lease, metav1.UpdateOptions{}); err != nil {
+			return 0, fmt.Errorf("error updating lease %s/%s: %w", m.namespace, name, err)
+		}
+	}
+
+	list, err := leases.List(context.Background(), metav1.ListOptions{LabelSelector: acknowledgementsLeaseLabel + "=true"})
+	if err != nil {
+		return 0, fmt.Errorf("error listing the leases in namespace %s: %w", m.namespace, err)
+	}
+
+	total := pending
+	for i := range list.Items {
+		replicaLease := &list.Items[i]
+		if replicaLease.Name == name {
+			continue
+		}
+		if replicaLease.Spec.RenewTime == nil || now.Sub(replicaLease.Spec.RenewTime.Time) > acknowledgementsLeaseDuration {
+			log.Debug().Msgf("Deleting expired lease %s/%s", replicaLease.Namespace, replicaLease.Name)
+			if err := leases.Delete(context.Background(), replicaLease.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+				log.Error().Err(err).Msgf("Error deleting expired lease %s/%s", replicaLease.Namespace, replicaLease.Name)
+			}
+			continue
+		}
+		if replicaLease.Annotations[signingIssuerAnnotation] != signingIssuerID ||
+			replicaLease.Annotations[validatingIssuerAnnotation] != validatingIssuerID {
+			total++
+			continue
+		}
+		count, err := strconv.Atoi(replicaLease.Annotations[unacknowledgedProxiesAnnotation])
+		if err != nil {
+			total++
+			continue
+		}
+		total += count
+	}
+	return total, nil
+}
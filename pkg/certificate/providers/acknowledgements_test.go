@@ -0,0 +1,70 @@
+package providers
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	tassert "github.com/stretchr/testify/assert"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestAggregateUnacknowledgedProxies(t *testing.T) {
+	assert := tassert.New(t)
+
+	newLease := func(replica, signingIssuerID, validatingIssuerID string, pending int, renewed time.Time) *coordinationv1.Lease {
+		renewTime := metav1.NewMicroTime(renewed)
+		return &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      acknowledgementsLeasePrefix + replica,
+				Namespace: "osm-system",
+				Labels:    map[string]string{acknowledgementsLeaseLabel: "true"},
+				Annotations: map[string]string{
+					signingIssuerAnnotation:         signingIssuerID,
+					validatingIssuerAnnotation:      validatingIssuerID,
+					unacknowledgedProxiesAnnotation: strconv.Itoa(pending),
+				},
+			},
+			Spec: coordinationv1.LeaseSpec{RenewTime: &renewTime},
+		}
+	}
+
+	kubeClient := fake.NewSimpleClientset(
+		// a live replica on the current stage with unacknowledged proxies
+		newLease("osm-controller-2", "new-mrc", "old-mrc", 2, time.Now()),
+		// a live replica that has not observed the current stage yet
+		newLease("osm-controller-3", "old-mrc", "old-mrc", 0, time.Now()),
+		// a replica that stopped renewing its lease
+		newLease("osm-controller-4", "new-mrc", "old-mrc", 5, time.Now().Add(-time.Hour)),
+	)
+	m := &MRCComposer{
+		MRCProviderGenerator: MRCProviderGenerator{kubeClient: kubeClient},
+		namespace:            "osm-system",
+		replicaName:          "osm-controller-1",
+	}
+
+	total, err := m.AggregateUnacknowledgedProxies("new-mrc", "old-mrc", 1)
+	assert.NoError(err)
+	assert.Equal(1+2+1, total)
+
+	// the lease of this replica records its unacknowledged proxies
+	lease, err := kubeClient.CoordinationV1().Leases("osm-system").Get(context.Background(), acknowledgementsLeasePrefix+"osm-controller-1", metav1.GetOptions{})
+	assert.NoError(err)
+	assert.Equal("1", lease.Annotations[unacknowledgedProxiesAnnotation])
+	assert.Equal("osm-controller-1", *lease.Spec.HolderIdentity)
+
+	// the expired lease is deleted
+	_, err = kubeClient.CoordinationV1().Leases("osm-system").Get(context.Background(), acknowledgementsLeasePrefix+"osm-controller-4", metav1.GetOptions{})
+	assert.Error(err)
+
+	// the lease of this replica is renewed
+	total, err = m.AggregateUnacknowledgedProxies("new-mrc", "old-mrc", 0)
+	assert.NoError(err)
+	assert.Equal(0+2+1, total)
+	lease, err = kubeClient.CoordinationV1().Leases("osm-system").Get(context.Background(), acknowledgementsLeasePrefix+"osm-controller-1", metav1.GetOptions{})
+	assert.NoError(err)
+	assert.Equal("0", lease.Annotations[unacknowledgedProxiesAnnotation])
+}
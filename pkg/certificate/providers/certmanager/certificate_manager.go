@@ -8,6 +8,7 @@ import (
 	"crypto/x509/pkix"
 	"errors"
 	"fmt"
+	"net/url"
 	"time"
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
@@ -67,9 +68,15 @@ func (cm *CertManager) certificateFromCertificateRequest(cr *cmapi.CertificateRe
 		return nil, fmt.Errorf("CA not found in certificate request %s/%s", cr.Namespace, cr.Name)
 	}
 
+	var uriSANames []string
+	for _, uri := range cert.URIs {
+		uriSANames = append(uriSANames, uri.String())
+	}
+
 	return &certificate.Certificate{
 		CommonName:   certificate.CommonName(cert.Subject.CommonName),
 		SANames:      cert.DNSNames,
+		URISANames:   uriSANames,
 		SerialNumber: certificate.SerialNumber(cert.SerialNumber.String()),
 		Expiration:   cert.NotAfter,
 		CertChain:    cr.Status.Certificate,
@@ -80,7 +87,7 @@ func (cm *CertManager) certificateFromCertificateRequest(cr *cmapi.CertificateRe
 }
 
 // IssueCertificate will request a new signed certificate from the configured cert-manager issuer.
-func (cm *CertManager) IssueCertificate(cn certificate.CommonName, saNames []string, validityPeriod time.Duration) (*certificate.Certificate, error) {
+func (cm *CertManager) IssueCertificate(cn certificate.CommonName, saNames []string, uriSANs []*url.URL, validityPeriod time.Duration) (*certificate.Certificate, error) {
 	duration := &metav1.Duration{
 		Duration: validityPeriod,
 	}
@@ -109,6 +116,7 @@ func (cm *CertManager) IssueCertificate(cn certificate.CommonName, saNames []str
 			CommonName: cn.String(),
 		},
 		DNSNames: []string{cn.String()},
+		URIs:     uriSANs,
 	}
 
 	if len(saNames) > 0 {
@@ -27,3 +27,9 @@ func (c *MRCCompatClient) Watch(ctx context.Context) (<-chan certificate.MRCEven
 
 	return ch, nil
 }
+
+// UpdateMRCStatus updates the status of the MRC attached to the compat client
+func (c *MRCCompatClient) UpdateMRCStatus(mrc *v1alpha2.MeshRootCertificate) (*v1alpha2.MeshRootCertificate, error) {
+	c.mrc = mrc
+	return mrc, nil
+}
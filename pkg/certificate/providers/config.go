@@ -4,11 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"time"
 
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	cmversionedclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
@@ -18,10 +20,12 @@ import (
 	"github.com/openservicemesh/osm/pkg/certificate/castorage/k8s"
 	"github.com/openservicemesh/osm/pkg/certificate/pem"
 	"github.com/openservicemesh/osm/pkg/certificate/providers/certmanager"
+	"github.com/openservicemesh/osm/pkg/certificate/providers/externalca"
 	"github.com/openservicemesh/osm/pkg/certificate/providers/tresor"
 	"github.com/openservicemesh/osm/pkg/certificate/providers/vault"
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/constants"
+	configClientset "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned"
 	"github.com/openservicemesh/osm/pkg/k8s/informers"
 	"github.com/openservicemesh/osm/pkg/messaging"
 )
@@ -34,7 +38,7 @@ const (
 )
 
 var getCA = func(i certificate.Issuer) (pem.RootCertificate, error) {
-	cert, err := i.IssueCertificate("init-cert", nil, 1*time.Second)
+	cert, err := i.IssueCertificate("init-cert", nil, nil, 1*time.Second)
 	if err != nil {
 		return nil, err
 	}
@@ -63,8 +67,9 @@ func NewCertificateManager(ctx context.Context, kubeClient kubernetes.Interface,
 				Namespace: providerNamespace,
 			},
 			Spec: v1alpha2.MeshRootCertificateSpec{
-				Provider:    option.AsProviderSpec(),
-				TrustDomain: trustDomain,
+				Provider:      option.AsProviderSpec(),
+				TrustDomain:   trustDomain,
+				SpiffeEnabled: cfg.IsSpiffeEnabled(),
 			},
 			Status: v1alpha2.MeshRootCertificateStatus{
 				State: constants.MRCStateActive,
@@ -80,12 +85,18 @@ func NewCertificateManager(ctx context.Context, kubeClient kubernetes.Interface,
 }
 
 // NewCertificateManagerFromMRC returns a new certificate manager.
-func NewCertificateManagerFromMRC(ctx context.Context, kubeClient kubernetes.Interface, kubeConfig *rest.Config, cfg configurator.Configurator,
-	providerNamespace string, option Options, msgBroker *messaging.Broker, ic *informers.InformerCollection, checkInterval time.Duration) (*certificate.Manager, error) {
+func NewCertificateManagerFromMRC(ctx context.Context, kubeClient kubernetes.Interface, kubeConfig *rest.Config, configClient configClientset.Interface,
+	cfg configurator.Configurator, providerNamespace string, option Options, msgBroker *messaging.Broker, ic *informers.InformerCollection, checkInterval time.Duration) (*certificate.Manager, error) {
 	if err := option.Validate(); err != nil {
 		return nil, err
 	}
 
+	// The pod name of a control plane replica is its hostname
+	replicaName, err := os.Hostname()
+	if err != nil {
+		return nil, err
+	}
+
 	mrcClient := &MRCComposer{
 		MRCProviderGenerator: MRCProviderGenerator{
 			kubeClient:      kubeClient,
@@ -94,6 +105,9 @@ func NewCertificateManagerFromMRC(ctx context.Context, kubeClient kubernetes.Int
 			caExtractorFunc: getCA,
 		},
 		informerCollection: ic,
+		configClient:       configClient,
+		namespace:          providerNamespace,
+		replicaName:        replicaName,
 	}
 	// TODO(#4745): Remove after deprecating the osm.vault.token option.
 	if vaultOption, ok := option.(VaultOptions); ok {
@@ -115,6 +129,8 @@ func (c *MRCProviderGenerator) GetCertIssuerForMRC(mrc *v1alpha2.MeshRootCertifi
 		issuer, err = c.getHashiVaultOSMCertificateManager(mrc)
 	case p.CertManager != nil:
 		issuer, err = c.getCertManagerOSMCertificateManager(mrc)
+	case p.ExternalCA != nil:
+		issuer, err = c.getExternalCAOSMCertificateManager(mrc)
 	default:
 		return nil, nil, fmt.Errorf("Unknown certificate provider: %+v", p)
 	}
@@ -170,6 +186,40 @@ func (c *MRCProviderGenerator) getTresorOSMCertificateManager(mrc *v1alpha2.Mesh
 	return tresorClient, nil
 }
 
+// getExternalCAOSMCertificateManager returns a certificate manager instance signing with the intermediate CA
+// stored in the secret referenced by the MRC
+func (c *MRCProviderGenerator) getExternalCAOSMCertificateManager(mrc *v1alpha2.MeshRootCertificate) (certificate.Issuer, error) {
+	secretRef := mrc.Spec.Provider.ExternalCA.SecretRef
+	ns := secretRef.Namespace
+	if ns == "" {
+		ns = mrc.Namespace
+	}
+
+	secret, err := c.kubeClient.CoreV1().Secrets(ns).Get(context.Background(), secretRef.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error getting external CA secret %s/%s: %w", ns, secretRef.Name, err)
+	}
+
+	for _, key := range []string{corev1.TLSCertKey, corev1.TLSPrivateKeyKey, constants.KubernetesOpaqueSecretCAKey} {
+		if len(secret.Data[key]) == 0 {
+			return nil, fmt.Errorf("external CA secret %s/%s does not have required field %q: %w", ns, secretRef.Name, key, certificate.ErrInvalidCertSecret)
+		}
+	}
+
+	externalCAClient, err := externalca.New(
+		secret.Data[corev1.TLSCertKey],
+		secret.Data[corev1.TLSPrivateKeyKey],
+		secret.Data[constants.KubernetesOpaqueSecretCAKey],
+		rootCertOrganization,
+		c.KeyBitSize,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to instantiate the external CA as a Certificate Manager: %w", err)
+	}
+
+	return externalCAClient, nil
+}
+
 // getHashiVaultOSMCertificateManager returns a certificate manager instance with Hashi Vault as the certificate provider
 func (c *MRCProviderGenerator) getHashiVaultOSMCertificateManager(mrc *v1alpha2.MeshRootCertificate) (certificate.Issuer, error) {
 	provider := mrc.Spec.Provider.Vault
@@ -177,21 +227,20 @@ func (c *MRCProviderGenerator) getHashiVaultOSMCertificateManager(mrc *v1alpha2.
 	// A Vault address would have the following shape: "http://vault.default.svc.cluster.local:8200"
 	vaultAddr := fmt.Sprintf("%s://%s:%d", provider.Protocol, provider.Host, provider.Port)
 
-	// If the DefaultVaultToken is empty, query Vault token secret
-	var err error
-	vaultToken := c.DefaultVaultToken
-	if vaultToken == "" {
-		log.Debug().Msgf("Attempting to get Vault token from secret %s", provider.Token.SecretKeyRef.Name)
-		vaultToken, err = getHashiVaultOSMToken(&provider.Token.SecretKeyRef, c.kubeClient)
-		if err != nil {
-			return nil, err
-		}
+	auth, err := c.getHashiVaultAuthMethod(provider)
+	if err != nil {
+		return nil, err
 	}
 
-	vaultClient, err := vault.New(
+	// The Vault token is managed until the certificate.Manager releases the issuer, once the MRC changes or is dropped
+	vaultClient, err := vault.NewWithOptions(
+		context.Background(),
 		vaultAddr,
-		vaultToken,
 		provider.Role,
+		vault.Options{
+			Auth:      auth,
+			Namespace: provider.Namespace,
+		},
 	)
 	if err != nil {
 		return nil, fmt.Errorf("error instantiating Hashicorp Vault as a Certificate Manager: %w", err)
@@ -200,19 +249,65 @@ func (c *MRCProviderGenerator) getHashiVaultOSMCertificateManager(mrc *v1alpha2.
 	return vaultClient, nil
 }
 
+// getHashiVaultAuthMethod returns the Vault auth method specified in the provided Vault provider spec,
+// defaulting to a static token when no auth method is specified
+func (c *MRCProviderGenerator) getHashiVaultAuthMethod(provider *v1alpha2.VaultProviderSpec) (vault.AuthMethod, error) {
+	if provider.Auth != nil {
+		switch {
+		case provider.Auth.Kubernetes != nil:
+			return &vault.KubernetesAuth{
+				Role:                    provider.Auth.Kubernetes.Role,
+				MountPath:               provider.Auth.Kubernetes.MountPath,
+				ServiceAccountTokenPath: provider.Auth.Kubernetes.ServiceAccountTokenPath,
+			}, nil
+		case provider.Auth.AppRole != nil:
+			log.Debug().Msgf("Attempting to get Vault AppRole secret ID from secret %s", provider.Auth.AppRole.SecretIDRef.Name)
+			secretID, err := getSecretKeyValue(&provider.Auth.AppRole.SecretIDRef, c.kubeClient)
+			if err != nil {
+				return nil, err
+			}
+			return &vault.AppRoleAuth{
+				RoleID:    provider.Auth.AppRole.RoleID,
+				SecretID:  secretID,
+				MountPath: provider.Auth.AppRole.MountPath,
+			}, nil
+		default:
+			return nil, errors.New("Vault auth must specify one of kubernetes or appRole")
+		}
+	}
+
+	// If the DefaultVaultToken is empty, query Vault token secret
+	vaultToken := c.DefaultVaultToken
+	if vaultToken == "" {
+		log.Debug().Msgf("Attempting to get Vault token from secret %s", provider.Token.SecretKeyRef.Name)
+		var err error
+		vaultToken, err = getHashiVaultOSMToken(&provider.Token.SecretKeyRef, c.kubeClient)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &vault.TokenAuth{Token: vaultToken}, nil
+}
+
 // getHashiVaultOSMToken returns the Hashi Vault token from the secret specified in the provided secret key reference
 func getHashiVaultOSMToken(secretKeyRef *v1alpha2.SecretKeyReferenceSpec, kubeClient kubernetes.Interface) (string, error) {
-	tokenSecret, err := kubeClient.CoreV1().Secrets(secretKeyRef.Namespace).Get(context.TODO(), secretKeyRef.Name, metav1.GetOptions{})
+	return getSecretKeyValue(secretKeyRef, kubeClient)
+}
+
+// getSecretKeyValue returns the value of the key from the secret specified in the provided secret key reference
+func getSecretKeyValue(secretKeyRef *v1alpha2.SecretKeyReferenceSpec, kubeClient kubernetes.Interface) (string, error) {
+	secret, err := kubeClient.CoreV1().Secrets(secretKeyRef.Namespace).Get(context.TODO(), secretKeyRef.Name, metav1.GetOptions{})
 	if err != nil {
-		return "", fmt.Errorf("error retrieving Hashi Vault token secret %s/%s: %w", secretKeyRef.Namespace, secretKeyRef.Name, err)
+		return "", fmt.Errorf("error retrieving Hashi Vault secret %s/%s: %w", secretKeyRef.Namespace, secretKeyRef.Name, err)
 	}
 
-	token, ok := tokenSecret.Data[secretKeyRef.Key]
+	value, ok := secret.Data[secretKeyRef.Key]
 	if !ok {
-		return "", fmt.Errorf("key %s not found in Hashi Vault token secret %s/%s", secretKeyRef.Key, secretKeyRef.Namespace, secretKeyRef.Name)
+		return "", fmt.Errorf("key %s not found in Hashi Vault secret %s/%s", secretKeyRef.Key, secretKeyRef.Namespace, secretKeyRef.Name)
 	}
 
-	return string(token), nil
+	return string(value), nil
 }
 
 // getCertManagerOSMCertificateManager returns a certificate manager instance with cert-manager as the certificate provider
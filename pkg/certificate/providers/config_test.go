@@ -22,6 +22,8 @@ import (
 
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/certificate/pem"
+	"github.com/openservicemesh/osm/pkg/certificate/providers/tresor"
+	"github.com/openservicemesh/osm/pkg/certificate/providers/vault"
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/k8s/informers"
 	"github.com/openservicemesh/osm/pkg/messaging"
@@ -33,6 +35,7 @@ func TestGetCertificateManager(t *testing.T) {
 
 	mockConfigurator.EXPECT().IsDebugServerEnabled().Return(false).AnyTimes()
 	mockConfigurator.EXPECT().GetCertKeyBitSize().Return(2048).AnyTimes()
+	mockConfigurator.EXPECT().IsSpiffeEnabled().Return(false).AnyTimes()
 	mockConfigurator.EXPECT().GetServiceCertValidityPeriod().Return(1 * time.Hour).AnyTimes()
 	type testCase struct {
 		name        string
@@ -166,6 +169,7 @@ func TestGetCertificateManagerFromMRC(t *testing.T) {
 
 	mockConfigurator.EXPECT().IsDebugServerEnabled().Return(false).AnyTimes()
 	mockConfigurator.EXPECT().GetCertKeyBitSize().Return(2048).AnyTimes()
+	mockConfigurator.EXPECT().IsSpiffeEnabled().Return(false).AnyTimes()
 	mockConfigurator.EXPECT().GetServiceCertValidityPeriod().Return(1 * time.Hour).AnyTimes()
 
 	type testCase struct {
@@ -448,7 +452,7 @@ func TestGetCertificateManagerFromMRC(t *testing.T) {
 			assert.NoError(err)
 			assert.NotNil(ic)
 
-			manager, err := NewCertificateManagerFromMRC(context.Background(), tc.kubeClient, tc.restConfig, tc.cfg, tc.providerNamespace, tc.options, tc.msgBroker, ic, 1*time.Hour)
+			manager, err := NewCertificateManagerFromMRC(context.Background(), tc.kubeClient, tc.restConfig, tc.configClient, tc.cfg, tc.providerNamespace, tc.options, tc.msgBroker, ic, 1*time.Hour)
 			if tc.expectError {
 				assert.Empty(manager)
 				assert.Error(err)
@@ -539,3 +543,163 @@ func TestGetHashiVaultOSMToken(t *testing.T) {
 		})
 	}
 }
+
+func TestGetHashiVaultAuthMethod(t *testing.T) {
+	secretIDSecret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "osm-system",
+			Name:      "osm-vault-approle",
+		},
+		Data: map[string][]byte{
+			"secret-id": []byte("secret-id"),
+		},
+	}
+
+	testCases := []struct {
+		name         string
+		provider     *v1alpha2.VaultProviderSpec
+		defaultToken string
+		expectedAuth vault.AuthMethod
+		expectError  bool
+	}{
+		{
+			name:         "static token by default",
+			provider:     &v1alpha2.VaultProviderSpec{},
+			defaultToken: "token",
+			expectedAuth: &vault.TokenAuth{Token: "token"},
+		},
+		{
+			name: "kubernetes auth",
+			provider: &v1alpha2.VaultProviderSpec{
+				Auth: &v1alpha2.VaultAuthSpec{
+					Kubernetes: &v1alpha2.VaultKubernetesAuthSpec{Role: "osm", MountPath: "k8s"},
+				},
+			},
+			defaultToken: "token",
+			expectedAuth: &vault.KubernetesAuth{Role: "osm", MountPath: "k8s"},
+		},
+		{
+			name: "approle auth with the secret ID from a secret",
+			provider: &v1alpha2.VaultProviderSpec{
+				Auth: &v1alpha2.VaultAuthSpec{
+					AppRole: &v1alpha2.VaultAppRoleAuthSpec{
+						RoleID:      "role-id",
+						SecretIDRef: v1alpha2.SecretKeyReferenceSpec{Name: "osm-vault-approle", Namespace: "osm-system", Key: "secret-id"},
+					},
+				},
+			},
+			expectedAuth: &vault.AppRoleAuth{RoleID: "role-id", SecretID: "secret-id"},
+		},
+		{
+			name: "approle auth without the secret ID secret",
+			provider: &v1alpha2.VaultProviderSpec{
+				Auth: &v1alpha2.VaultAuthSpec{
+					AppRole: &v1alpha2.VaultAppRoleAuthSpec{
+						RoleID:      "role-id",
+						SecretIDRef: v1alpha2.SecretKeyReferenceSpec{Name: "missing", Namespace: "osm-system", Key: "secret-id"},
+					},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name:        "empty auth",
+			provider:    &v1alpha2.VaultProviderSpec{Auth: &v1alpha2.VaultAuthSpec{}},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			c := &MRCProviderGenerator{
+				kubeClient:        fake.NewSimpleClientset(secretIDSecret),
+				DefaultVaultToken: tc.defaultToken,
+			}
+			auth, err := c.getHashiVaultAuthMethod(tc.provider)
+			if tc.expectError {
+				assert.Error(err)
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(tc.expectedAuth, auth)
+		})
+	}
+}
+
+func TestGetCertIssuerForExternalCAMRC(t *testing.T) {
+	ca, err := tresor.NewCA("corporate-root", time.Hour, rootCertCountry, rootCertLocality, rootCertOrganization)
+	if err != nil {
+		t.Fatal(err)
+	}
+	externalCASecret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "pki",
+			Name:      "mesh-intermediate",
+		},
+		Data: map[string][]byte{
+			v1.TLSCertKey:                         ca.GetCertificateChain(),
+			v1.TLSPrivateKeyKey:                   ca.GetPrivateKey(),
+			constants.KubernetesOpaqueSecretCAKey: ca.GetCertificateChain(),
+		},
+	}
+	incompleteSecret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "osm-system",
+			Name:      "mesh-intermediate",
+		},
+		Data: map[string][]byte{
+			v1.TLSCertKey:       ca.GetCertificateChain(),
+			v1.TLSPrivateKeyKey: ca.GetPrivateKey(),
+		},
+	}
+
+	testCases := []struct {
+		name        string
+		secretRef   v1.SecretReference
+		expectError bool
+	}{
+		{
+			name:      "secret in the namespace of the reference",
+			secretRef: v1.SecretReference{Name: "mesh-intermediate", Namespace: "pki"},
+		},
+		{
+			name:        "secret without the root certificates",
+			secretRef:   v1.SecretReference{Name: "mesh-intermediate"},
+			expectError: true,
+		},
+		{
+			name:        "missing secret",
+			secretRef:   v1.SecretReference{Name: "missing", Namespace: "pki"},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			c := &MRCProviderGenerator{
+				kubeClient:      fake.NewSimpleClientset(externalCASecret, incompleteSecret),
+				KeyBitSize:      2048,
+				caExtractorFunc: getCA,
+			}
+			issuer, root, err := c.GetCertIssuerForMRC(&v1alpha2.MeshRootCertificate{
+				ObjectMeta: metav1.ObjectMeta{Name: "osm-mesh-root-certificate", Namespace: "osm-system"},
+				Spec: v1alpha2.MeshRootCertificateSpec{
+					Provider: v1alpha2.ProviderSpec{
+						ExternalCA: &v1alpha2.ExternalCAProviderSpec{SecretRef: tc.secretRef},
+					},
+				},
+			})
+			if tc.expectError {
+				assert.Error(err)
+				return
+			}
+			assert.NoError(err)
+			assert.NotNil(issuer)
+			assert.Equal(pem.RootCertificate(ca.GetCertificateChain()), root)
+		})
+	}
+}
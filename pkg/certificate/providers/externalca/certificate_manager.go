@@ -0,0 +1,166 @@
+package externalca
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	pemEnc "encoding/pem"
+	"fmt"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/certificate/pem"
+	"github.com/openservicemesh/osm/pkg/certificate/providers/tresor"
+)
+
+// New constructs a new tresor certificate client signing with the given intermediate CA.
+// certChain holds the intermediate CA certificate, optionally followed by further intermediates, key holds
+// the private key of the intermediate CA and roots holds the root certificates the chain leads up to.
+// The chain is validated, so that an intermediate which does not chain up to the roots is rejected at load time.
+func New(
+	certChain pem.Certificate,
+	key pem.PrivateKey,
+	roots pem.RootCertificate,
+	certificatesOrganization string,
+	keySize int) (*tresor.CertManager, error) {
+	if keySize == 0 {
+		return nil, fmt.Errorf("key bit size cannot be zero")
+	}
+
+	chain, err := parseCertificates(certChain)
+	if err != nil {
+		return nil, err
+	}
+	if len(chain) == 0 {
+		return nil, errNoSigningCert
+	}
+	signer := chain[0]
+	if !signer.IsCA || (signer.KeyUsage != 0 && signer.KeyUsage&x509.KeyUsageCertSign == 0) {
+		return nil, fmt.Errorf("%w: %s", errNotCA, signer.Subject)
+	}
+
+	signerKey, err := parsePrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	if publicKey, ok := signerKey.Public().(interface{ Equal(crypto.PublicKey) bool }); !ok || !publicKey.Equal(signer.PublicKey) {
+		return nil, errKeyMismatch
+	}
+
+	rootCerts, err := parseCertificates(roots)
+	if err != nil {
+		return nil, err
+	}
+	if len(rootCerts) == 0 {
+		return nil, errNoRootCert
+	}
+
+	verifiedChain, err := verifyChain(chain, rootCerts)
+	if err != nil {
+		return nil, err
+	}
+
+	// The chain served along with issued certificates is the verified path, without the root which peers already trust
+	var intermediates []byte
+	for _, cert := range verifiedChain[:len(verifiedChain)-1] {
+		intermediates = append(intermediates, encodeCertificate(cert)...)
+	}
+
+	log.Info().Msgf("Loaded intermediate CA %s chaining up to root %s, valid until %s",
+		signer.Subject, verifiedChain[len(verifiedChain)-1].Subject, signer.NotAfter)
+
+	return tresor.NewWithIntermediate(signer, signerKey, intermediates, roots, certificatesOrganization, keySize)
+}
+
+// verifyChain verifies that the first certificate of the given chain chains up to one of the roots,
+// using the rest of the chain as intermediates, and returns the verified path including the root.
+func verifyChain(chain, roots []*x509.Certificate) ([]*x509.Certificate, error) {
+	rootPool := x509.NewCertPool()
+	for _, root := range roots {
+		rootPool.AddCert(root)
+	}
+	intermediatePool := x509.NewCertPool()
+	for _, intermediate := range chain[1:] {
+		intermediatePool.AddCert(intermediate)
+	}
+
+	chains, err := chain[0].Verify(x509.VerifyOptions{
+		Roots:         rootPool,
+		Intermediates: intermediatePool,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error verifying the chain of intermediate CA %s: %w", chain[0].Subject, err)
+	}
+
+	return chains[0], nil
+}
+
+// parseCertificates parses all the certificates of the given PEM bundle
+func parseCertificates(bundle []byte) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	for len(bundle) > 0 {
+		var block *pemEnc.Block
+		block, bundle = pemEnc.Decode(bundle)
+		if block == nil {
+			break
+		}
+		if block.Type != certificate.TypeCertificate {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing certificate: %w", err)
+		}
+		certs = append(certs, cert)
+	}
+	return certs, nil
+}
+
+// parsePrivateKey parses a PKCS #8, PKCS #1 or SEC 1 encoded private key
+func parsePrivateKey(keyPEM []byte) (crypto.Signer, error) {
+	for len(keyPEM) > 0 {
+		var block *pemEnc.Block
+		block, keyPEM = pemEnc.Decode(keyPEM)
+		if block == nil {
+			break
+		}
+
+		var key interface{}
+		var err error
+		switch block.Type {
+		case certificate.TypePrivateKey:
+			key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+		case "RSA PRIVATE KEY":
+			key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+		case "EC PRIVATE KEY":
+			key, err = x509.ParseECPrivateKey(block.Bytes)
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error parsing private key: %w", err)
+		}
+
+		switch key := key.(type) {
+		case *rsa.PrivateKey:
+			return key, nil
+		case *ecdsa.PrivateKey:
+			return key, nil
+		case ed25519.PrivateKey:
+			return key, nil
+		default:
+			return nil, fmt.Errorf("%w: %T", errUnsupportedKey, key)
+		}
+	}
+
+	return nil, errNoPrivateKey
+}
+
+func encodeCertificate(cert *x509.Certificate) []byte {
+	var buf bytes.Buffer
+	_ = pemEnc.Encode(&buf, &pemEnc.Block{Type: certificate.TypeCertificate, Bytes: cert.Raw})
+	return buf.Bytes()
+}
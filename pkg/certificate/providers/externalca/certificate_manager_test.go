@@ -0,0 +1,268 @@
+package externalca
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	pemEnc "encoding/pem"
+	"math/big"
+	"net/url"
+	"testing"
+	"time"
+
+	tassert "github.com/stretchr/testify/assert"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  crypto.Signer
+}
+
+// newTestCA creates a CA certificate signed by the given parent, or a self signed root if parent is nil
+func newTestCA(t *testing.T, cn string, parent *testCA, isCA bool, notAfter time.Time) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              notAfter,
+		IsCA:                  isCA,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+	}
+	signer, signerKey := template, crypto.Signer(key)
+	if parent != nil {
+		signer, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, key.Public(), signerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testCA{cert: cert, key: key}
+}
+
+func (ca *testCA) certPEM() []byte {
+	return encodeCertificate(ca.cert)
+}
+
+func (ca *testCA) keyPEM(t *testing.T) []byte {
+	der, err := x509.MarshalECPrivateKey(ca.key.(*ecdsa.PrivateKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pemEnc.EncodeToMemory(&pemEnc.Block{Type: "EC PRIVATE KEY", Bytes: der})
+}
+
+func concat(pems ...[]byte) []byte {
+	var bundle []byte
+	for _, p := range pems {
+		bundle = append(bundle, p...)
+	}
+	return bundle
+}
+
+func TestNew(t *testing.T) {
+	notAfter := time.Now().Add(24 * time.Hour)
+	root := newTestCA(t, "corporate-root", nil, true, notAfter)
+	otherRoot := newTestCA(t, "other-root", nil, true, notAfter)
+	policyCA := newTestCA(t, "policy-ca", root, true, notAfter)
+	intermediate := newTestCA(t, "mesh-intermediate", policyCA, true, notAfter)
+	leaf := newTestCA(t, "not-a-ca", policyCA, false, notAfter)
+	expired := newTestCA(t, "expired-intermediate", root, true, time.Now().Add(-time.Minute))
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaKeyPEM, err := certificate.EncodeKeyDERtoPEM(rsaKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name                  string
+		certChain             []byte
+		key                   []byte
+		roots                 []byte
+		keySize               int
+		expectedErr           error
+		expectedIntermediates []*testCA
+	}{
+		{
+			name:                  "intermediate with the chain up to the root",
+			certChain:             concat(intermediate.certPEM(), policyCA.certPEM()),
+			key:                   intermediate.keyPEM(t),
+			roots:                 root.certPEM(),
+			keySize:               2048,
+			expectedIntermediates: []*testCA{intermediate, policyCA},
+		},
+		{
+			name:                  "root included in the chain is not served",
+			certChain:             concat(intermediate.certPEM(), policyCA.certPEM(), root.certPEM()),
+			key:                   intermediate.keyPEM(t),
+			roots:                 concat(otherRoot.certPEM(), root.certPEM()),
+			keySize:               2048,
+			expectedIntermediates: []*testCA{intermediate, policyCA},
+		},
+		{
+			name:      "intermediate missing from the chain",
+			certChain: intermediate.certPEM(),
+			key:       intermediate.keyPEM(t),
+			roots:     root.certPEM(),
+			keySize:   2048,
+		},
+		{
+			name:      "intermediate chaining up to another root",
+			certChain: concat(intermediate.certPEM(), policyCA.certPEM()),
+			key:       intermediate.keyPEM(t),
+			roots:     otherRoot.certPEM(),
+			keySize:   2048,
+		},
+		{
+			name:      "expired intermediate",
+			certChain: expired.certPEM(),
+			key:       expired.keyPEM(t),
+			roots:     root.certPEM(),
+			keySize:   2048,
+		},
+		{
+			name:        "certificate which is not a CA",
+			certChain:   concat(leaf.certPEM(), policyCA.certPEM()),
+			key:         leaf.keyPEM(t),
+			roots:       root.certPEM(),
+			keySize:     2048,
+			expectedErr: errNotCA,
+		},
+		{
+			name:        "private key of another certificate",
+			certChain:   concat(intermediate.certPEM(), policyCA.certPEM()),
+			key:         policyCA.keyPEM(t),
+			roots:       root.certPEM(),
+			keySize:     2048,
+			expectedErr: errKeyMismatch,
+		},
+		{
+			name:        "private key of another type",
+			certChain:   concat(intermediate.certPEM(), policyCA.certPEM()),
+			key:         rsaKeyPEM,
+			roots:       root.certPEM(),
+			keySize:     2048,
+			expectedErr: errKeyMismatch,
+		},
+		{
+			name:        "no private key",
+			certChain:   concat(intermediate.certPEM(), policyCA.certPEM()),
+			key:         intermediate.certPEM(),
+			roots:       root.certPEM(),
+			keySize:     2048,
+			expectedErr: errNoPrivateKey,
+		},
+		{
+			name:        "no certificate",
+			key:         intermediate.keyPEM(t),
+			roots:       root.certPEM(),
+			keySize:     2048,
+			expectedErr: errNoSigningCert,
+		},
+		{
+			name:        "no root",
+			certChain:   concat(intermediate.certPEM(), policyCA.certPEM()),
+			key:         intermediate.keyPEM(t),
+			keySize:     2048,
+			expectedErr: errNoRootCert,
+		},
+		{
+			name:      "zero key size",
+			certChain: concat(intermediate.certPEM(), policyCA.certPEM()),
+			key:       intermediate.keyPEM(t),
+			roots:     root.certPEM(),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			cm, err := New(tc.certChain, tc.key, tc.roots, "org", tc.keySize)
+			if tc.expectedIntermediates == nil {
+				assert.Nil(cm)
+				assert.Error(err)
+				if tc.expectedErr != nil {
+					assert.ErrorIs(err, tc.expectedErr)
+				}
+				return
+			}
+
+			assert.NoError(err)
+			cert, err := cm.IssueCertificate("bookbuyer.default.cluster.local", nil, nil, time.Hour)
+			assert.NoError(err)
+			chain, err := parseCertificates(cert.GetCertificateChain())
+			assert.NoError(err)
+			if assert.Len(chain, len(tc.expectedIntermediates)+1) {
+				for i, ca := range tc.expectedIntermediates {
+					assert.Equal(ca.cert.Raw, chain[i+1].Raw)
+				}
+			}
+		})
+	}
+}
+
+func TestIssueCertificate(t *testing.T) {
+	assert := tassert.New(t)
+
+	notAfter := time.Now().Add(24 * time.Hour)
+	root := newTestCA(t, "corporate-root", nil, true, notAfter)
+	policyCA := newTestCA(t, "policy-ca", root, true, notAfter)
+	intermediate := newTestCA(t, "mesh-intermediate", policyCA, true, notAfter)
+
+	cm, err := New(concat(intermediate.certPEM(), policyCA.certPEM()), intermediate.keyPEM(t), root.certPEM(), "org", 2048)
+	assert.NoError(err)
+
+	spiffeID, err := url.Parse("spiffe://cluster.local/ns/default/sa/bookbuyer")
+	assert.NoError(err)
+	cert, err := cm.IssueCertificate("bookbuyer.default.cluster.local", []string{"bookbuyer", "bookbuyer.default.cluster.local"}, []*url.URL{spiffeID}, time.Hour)
+	assert.NoError(err)
+
+	assert.Equal([]string{"bookbuyer.default.cluster.local", "bookbuyer"}, cert.SANames)
+	assert.Equal([]string{spiffeID.String()}, cert.URISANames)
+	assert.Equal(root.certPEM(), []byte(cert.GetIssuingCA()))
+	assert.Equal(root.certPEM(), []byte(cert.GetTrustedCAs()))
+
+	// The certificate chain holds the leaf followed by the intermediates, and chains up to the root
+	chain, err := parseCertificates(cert.GetCertificateChain())
+	assert.NoError(err)
+	if assert.Len(chain, 3) {
+		assert.Equal(intermediate.cert.Raw, chain[1].Raw)
+		assert.Equal(policyCA.cert.Raw, chain[2].Raw)
+
+		roots := x509.NewCertPool()
+		roots.AddCert(root.cert)
+		intermediates := x509.NewCertPool()
+		intermediates.AddCert(chain[1])
+		intermediates.AddCert(chain[2])
+		_, err = chain[0].Verify(x509.VerifyOptions{Roots: roots, Intermediates: intermediates, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}})
+		assert.NoError(err)
+	}
+
+	key, err := certificate.DecodePEMPrivateKey(cert.GetPrivateKey())
+	assert.NoError(err)
+	assert.True(key.PublicKey.Equal(chain[0].PublicKey))
+
+	// Certificates don't outlive the intermediate
+	cert, err = cm.IssueCertificate("bookbuyer.default.cluster.local", nil, nil, 48*time.Hour)
+	assert.NoError(err)
+	assert.Equal(intermediate.cert.NotAfter, cert.GetExpiration())
+}
@@ -0,0 +1,12 @@
+package externalca
+
+import (
+	"errors"
+)
+
+var errNoSigningCert = errors.New("no intermediate CA certificate")
+var errNoRootCert = errors.New("no root certificate")
+var errNotCA = errors.New("certificate is not a CA")
+var errKeyMismatch = errors.New("private key does not match the intermediate CA certificate")
+var errNoPrivateKey = errors.New("no private key")
+var errUnsupportedKey = errors.New("unsupported private key")
@@ -0,0 +1,10 @@
+// Package externalca implements a provider signing certificates with an intermediate CA supplied by the operator,
+// so that the certificates of the mesh chain up to an enterprise root. The certificates are issued by tresor with the
+// intermediate CA as signer.
+package externalca
+
+import (
+	"github.com/openservicemesh/osm/pkg/logger"
+)
+
+var log = logger.New("externalca")
@@ -3,10 +3,13 @@ package providers
 import (
 	"context"
 
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/cache"
 
 	"github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
 	"github.com/openservicemesh/osm/pkg/certificate"
+	configClientset "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned"
 	"github.com/openservicemesh/osm/pkg/k8s/informers"
 )
 
@@ -15,6 +18,13 @@ import (
 // `certificate.Provider`s from those MRCs
 type MRCComposer struct {
 	informerCollection *informers.InformerCollection
+	configClient       configClientset.Interface
+
+	// namespace is the namespace of the leases recording the unacknowledged proxies of the control plane replicas
+	namespace string
+	// replicaName is the name of the control plane replica, unique across the replicas
+	replicaName string
+
 	MRCProviderGenerator
 }
 
@@ -68,5 +78,58 @@ func (m *MRCComposer) Watch(ctx context.Context) (<-chan certificate.MRCEvent, e
 		DeleteFunc: func(obj interface{}) {},
 	})
 
+	// The issuers of the MRCs are generated from the Secrets holding their CA, so that an update to such a Secret,
+	// such as the renewal of the intermediate CA of an external CA provider, requires generating the issuer again
+	onSecretUpdate := func(obj interface{}) {
+		secret, ok := obj.(*corev1.Secret)
+		if !ok {
+			return
+		}
+		for _, mrc := range m.mrcsReferencingSecret(secret) {
+			log.Debug().Msgf("received update event for Secret %s/%s of MRC %s/%s", secret.Namespace, secret.Name, mrc.GetNamespace(), mrc.GetName())
+			eventChan <- certificate.MRCEvent{
+				Type: certificate.MRCEventSecretUpdated,
+				MRC:  mrc,
+			}
+		}
+	}
+	m.informerCollection.AddEventHandler(informers.InformerKeySecret, cache.ResourceEventHandlerFuncs{
+		AddFunc: onSecretUpdate,
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldSecret, oldOk := oldObj.(*corev1.Secret)
+			newSecret, newOk := newObj.(*corev1.Secret)
+			// Periodic resyncs don't change the Secret
+			if oldOk && newOk && oldSecret.ResourceVersion == newSecret.ResourceVersion {
+				return
+			}
+			onSecretUpdate(newObj)
+		},
+	})
+
 	return eventChan, nil
 }
+
+// mrcsReferencingSecret returns the MRCs whose CA is held by the given Secret
+func (m *MRCComposer) mrcsReferencingSecret(secret *corev1.Secret) []*v1alpha2.MeshRootCertificate {
+	mrcs, _ := m.List()
+	var referencing []*v1alpha2.MeshRootCertificate
+	for _, mrc := range mrcs {
+		externalCA := mrc.Spec.Provider.ExternalCA
+		if externalCA == nil {
+			continue
+		}
+		ns := externalCA.SecretRef.Namespace
+		if ns == "" {
+			ns = mrc.Namespace
+		}
+		if ns == secret.Namespace && externalCA.SecretRef.Name == secret.Name {
+			referencing = append(referencing, mrc)
+		}
+	}
+	return referencing
+}
+
+// UpdateMRCStatus updates the status subresource of the given MRC through the Kubernetes API
+func (m *MRCComposer) UpdateMRCStatus(mrc *v1alpha2.MeshRootCertificate) (*v1alpha2.MeshRootCertificate, error) {
+	return m.configClient.ConfigV1alpha2().MeshRootCertificates(mrc.Namespace).UpdateStatus(context.Background(), mrc, metav1.UpdateOptions{})
+}
@@ -0,0 +1,88 @@
+package providers
+
+import (
+	"testing"
+
+	tassert "github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	fakeConfigClientset "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned/fake"
+	"github.com/openservicemesh/osm/pkg/k8s/informers"
+)
+
+func TestMRCsReferencingSecret(t *testing.T) {
+	assert := tassert.New(t)
+
+	ic, err := informers.NewInformerCollection("osm", nil, informers.WithConfigClient(fakeConfigClientset.NewSimpleClientset(), "osm-mesh-config", "osm-system"))
+	assert.NoError(err)
+
+	mrcs := []*v1alpha2.MeshRootCertificate{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "external-ca", Namespace: "osm-system"},
+			Spec: v1alpha2.MeshRootCertificateSpec{
+				Provider: v1alpha2.ProviderSpec{
+					ExternalCA: &v1alpha2.ExternalCAProviderSpec{SecretRef: v1.SecretReference{Name: "mesh-intermediate", Namespace: "pki"}},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "external-ca-default-namespace", Namespace: "osm-system"},
+			Spec: v1alpha2.MeshRootCertificateSpec{
+				Provider: v1alpha2.ProviderSpec{
+					ExternalCA: &v1alpha2.ExternalCAProviderSpec{SecretRef: v1.SecretReference{Name: "mesh-intermediate"}},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "tresor", Namespace: "osm-system"},
+			Spec: v1alpha2.MeshRootCertificateSpec{
+				Provider: v1alpha2.ProviderSpec{
+					Tresor: &v1alpha2.TresorProviderSpec{},
+				},
+			},
+		},
+	}
+	for _, mrc := range mrcs {
+		assert.NoError(ic.Add(informers.InformerKeyMeshRootCertificate, mrc, t))
+	}
+
+	m := &MRCComposer{informerCollection: ic}
+
+	testCases := []struct {
+		name        string
+		secret      *v1.Secret
+		expectedMRC string
+	}{
+		{
+			name:        "secret in the namespace of the reference",
+			secret:      &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "mesh-intermediate", Namespace: "pki"}},
+			expectedMRC: "external-ca",
+		},
+		{
+			name:        "secret in the namespace of the MRC",
+			secret:      &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "mesh-intermediate", Namespace: "osm-system"}},
+			expectedMRC: "external-ca-default-namespace",
+		},
+		{
+			name:   "secret not referenced by any MRC",
+			secret: &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "pki"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			referencing := m.mrcsReferencingSecret(tc.secret)
+			if tc.expectedMRC == "" {
+				assert.Empty(referencing)
+				return
+			}
+			if assert.Len(referencing, 1) {
+				assert.Equal(tc.expectedMRC, referencing[0].Name)
+			}
+		})
+	}
+}
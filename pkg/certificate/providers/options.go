@@ -36,8 +36,25 @@ func (options VaultOptions) Validate() error {
 		return errors.New("VaultHost not specified in Hashi Vault options")
 	}
 
-	if options.VaultToken == "" && (options.VaultTokenSecretKey == "" || options.VaultTokenSecretName == "") {
-		return errors.New("VaultTokenSecretKey and VaultTokenSecretName must both specified if VaultToken is not specified in Hashi Vault options")
+	switch options.VaultAuthMethod {
+	case "", VaultTokenAuthMethod:
+		if options.VaultToken == "" && (options.VaultTokenSecretKey == "" || options.VaultTokenSecretName == "") {
+			return errors.New("VaultTokenSecretKey and VaultTokenSecretName must both specified if VaultToken is not specified in Hashi Vault options")
+		}
+	case VaultKubernetesAuthMethod:
+		if options.VaultKubernetesAuthRole == "" {
+			return errors.New("VaultKubernetesAuthRole not specified in Hashi Vault options with the kubernetes auth method")
+		}
+	case VaultAppRoleAuthMethod:
+		if options.VaultAppRoleID == "" {
+			return errors.New("VaultAppRoleID not specified in Hashi Vault options with the approle auth method")
+		}
+		if options.VaultAppRoleSecretIDSecretName == "" || options.VaultAppRoleSecretIDSecretKey == "" {
+			return errors.New("VaultAppRoleSecretIDSecretName and VaultAppRoleSecretIDSecretKey must both be specified in Hashi Vault options with the approle auth method")
+		}
+	default:
+		return fmt.Errorf("VaultAuthMethod in Hashi Vault options must be one of [%s, %s, %s], got %s",
+			VaultTokenAuthMethod, VaultKubernetesAuthMethod, VaultAppRoleAuthMethod, options.VaultAuthMethod)
 	}
 
 	if options.VaultRole == "" {
@@ -64,12 +81,40 @@ func (options VaultOptions) AsProviderSpec() v1alpha2.ProviderSpec {
 					Key:       options.VaultTokenSecretKey,
 				},
 			},
-			Role: options.VaultRole,
-			Port: options.VaultPort,
+			Role:      options.VaultRole,
+			Port:      options.VaultPort,
+			Namespace: options.VaultNamespace,
+			Auth:      options.vaultAuthSpec(),
 		},
 	}
 }
 
+// vaultAuthSpec returns the Vault auth spec generated from the vault options, nil for the token auth method
+func (options VaultOptions) vaultAuthSpec() *v1alpha2.VaultAuthSpec {
+	switch options.VaultAuthMethod {
+	case VaultKubernetesAuthMethod:
+		return &v1alpha2.VaultAuthSpec{
+			Kubernetes: &v1alpha2.VaultKubernetesAuthSpec{
+				Role:      options.VaultKubernetesAuthRole,
+				MountPath: options.VaultKubernetesAuthMountPath,
+			},
+		}
+	case VaultAppRoleAuthMethod:
+		return &v1alpha2.VaultAuthSpec{
+			AppRole: &v1alpha2.VaultAppRoleAuthSpec{
+				RoleID: options.VaultAppRoleID,
+				SecretIDRef: v1alpha2.SecretKeyReferenceSpec{
+					Name:      options.VaultAppRoleSecretIDSecretName,
+					Namespace: options.VaultTokenSecretNamespace,
+					Key:       options.VaultAppRoleSecretIDSecretKey,
+				},
+				MountPath: options.VaultAppRoleMountPath,
+			},
+		}
+	}
+	return nil
+}
+
 // Validate validates the options for cert-manager.io certificate provider
 func (options CertManagerOptions) Validate() error {
 	if options.IssuerName == "" {
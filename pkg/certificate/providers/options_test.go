@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	tassert "github.com/stretchr/testify/assert"
+
+	"github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
 )
 
 func TestValidateCertManagerOptions(t *testing.T) {
@@ -144,6 +146,62 @@ func TestValidateVaultOptions(t *testing.T) {
 			},
 			expectErr: false,
 		},
+		{
+			testName: "Kubernetes auth without a token",
+			options: VaultOptions{
+				VaultProtocol:           "https",
+				VaultHost:               "vault-host",
+				VaultRole:               "role",
+				VaultAuthMethod:         VaultKubernetesAuthMethod,
+				VaultKubernetesAuthRole: "osm",
+			},
+			expectErr: false,
+		},
+		{
+			testName: "Kubernetes auth without a role",
+			options: VaultOptions{
+				VaultProtocol:   "https",
+				VaultHost:       "vault-host",
+				VaultRole:       "role",
+				VaultAuthMethod: VaultKubernetesAuthMethod,
+			},
+			expectErr: true,
+		},
+		{
+			testName: "AppRole auth",
+			options: VaultOptions{
+				VaultProtocol:                  "https",
+				VaultHost:                      "vault-host",
+				VaultRole:                      "role",
+				VaultAuthMethod:                VaultAppRoleAuthMethod,
+				VaultAppRoleID:                 "role-id",
+				VaultAppRoleSecretIDSecretName: "secret",
+				VaultAppRoleSecretIDSecretKey:  "secret-id",
+			},
+			expectErr: false,
+		},
+		{
+			testName: "AppRole auth without a secret ID secret",
+			options: VaultOptions{
+				VaultProtocol:   "https",
+				VaultHost:       "vault-host",
+				VaultRole:       "role",
+				VaultAuthMethod: VaultAppRoleAuthMethod,
+				VaultAppRoleID:  "role-id",
+			},
+			expectErr: true,
+		},
+		{
+			testName: "Unknown auth method",
+			options: VaultOptions{
+				VaultProtocol:   "https",
+				VaultHost:       "vault-host",
+				VaultToken:      "vault-token",
+				VaultRole:       "role",
+				VaultAuthMethod: "ldap",
+			},
+			expectErr: true,
+		},
 	}
 
 	for _, t := range testCases {
@@ -155,3 +213,39 @@ func TestValidateVaultOptions(t *testing.T) {
 		}
 	}
 }
+
+func TestVaultOptionsAsProviderSpec(t *testing.T) {
+	assert := tassert.New(t)
+
+	options := VaultOptions{
+		VaultProtocol:                  "https",
+		VaultHost:                      "vault-host",
+		VaultPort:                      8200,
+		VaultRole:                      "role",
+		VaultNamespace:                 "osm-ns",
+		VaultTokenSecretNamespace:      "osm-system",
+		VaultAuthMethod:                VaultAppRoleAuthMethod,
+		VaultAppRoleID:                 "role-id",
+		VaultAppRoleSecretIDSecretName: "secret",
+		VaultAppRoleSecretIDSecretKey:  "secret-id",
+	}
+	spec := options.AsProviderSpec().Vault
+	assert.Equal("osm-ns", spec.Namespace)
+	assert.Nil(spec.Auth.Kubernetes)
+	assert.Equal(&v1alpha2.VaultAppRoleAuthSpec{
+		RoleID: "role-id",
+		SecretIDRef: v1alpha2.SecretKeyReferenceSpec{
+			Name:      "secret",
+			Namespace: "osm-system",
+			Key:       "secret-id",
+		},
+	}, spec.Auth.AppRole)
+
+	options.VaultAuthMethod = VaultKubernetesAuthMethod
+	options.VaultKubernetesAuthRole = "osm"
+	spec = options.AsProviderSpec().Vault
+	assert.Equal(&v1alpha2.VaultKubernetesAuthSpec{Role: "osm"}, spec.Auth.Kubernetes)
+
+	options.VaultAuthMethod = VaultTokenAuthMethod
+	assert.Nil(options.AsProviderSpec().Vault.Auth)
+}
@@ -1,11 +1,13 @@
 package tresor
 
 import (
+	"crypto"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"fmt"
+	"net/url"
 	"strings"
 	"time"
 
@@ -36,9 +38,36 @@ func New(
 	return &certManager, nil
 }
 
+// NewWithIntermediate constructs a new certificate client signing with the given intermediate CA and its private key.
+// The issued certificates carry the given chain of intermediates up to the roots, and don't outlive the intermediate CA.
+func NewWithIntermediate(
+	signer *x509.Certificate,
+	signerKey crypto.Signer,
+	intermediates pem.Certificate,
+	roots pem.RootCertificate,
+	certificatesOrganization string,
+	keySize int) (*CertManager, error) {
+	if signer == nil || signerKey == nil {
+		return nil, errNoIssuingCA
+	}
+
+	if keySize == 0 {
+		return nil, fmt.Errorf("key bit size cannot be zero")
+	}
+
+	return &CertManager{
+		signer:                   signer,
+		signerKey:                signerKey,
+		intermediates:            intermediates,
+		roots:                    roots,
+		certificatesOrganization: certificatesOrganization,
+		keySize:                  keySize,
+	}, nil
+}
+
 // IssueCertificate requests a new signed certificate from the configured cert-manager issuer.
-func (cm *CertManager) IssueCertificate(cn certificate.CommonName, saNames []string, validityPeriod time.Duration) (*certificate.Certificate, error) {
-	if cm.ca == nil {
+func (cm *CertManager) IssueCertificate(cn certificate.CommonName, saNames []string, uriSANs []*url.URL, validityPeriod time.Duration) (*certificate.Certificate, error) {
+	if cm.ca == nil && cm.signer == nil {
 		// TODO(#3962): metric might not be scraped before process restart resulting from this error
 		log.Error().Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrInvalidCA)).
 			Msgf("Invalid CA provided for issuance of certificate with CN=%s", cn)
@@ -58,18 +87,31 @@ func (cm *CertManager) IssueCertificate(cn certificate.CommonName, saNames []str
 		return nil, fmt.Errorf("%s: %w", errGeneratingSerialNumber.Error(), err)
 	}
 
+	signer, signerKey, err := cm.getSigner()
+	if err != nil {
+		return nil, err
+	}
+
 	now := time.Now()
+	notAfter := now.Add(validityPeriod)
+	if cm.signer != nil && notAfter.After(signer.NotAfter) {
+		// A certificate can't outlive the intermediate CA it chains through
+		log.Warn().Msgf("Capping the expiration of certificate with CN=%s to the expiration of intermediate CA %s on %s", cn, signer.Subject, signer.NotAfter)
+		notAfter = signer.NotAfter
+	}
+
 	template := x509.Certificate{
 		SerialNumber: serialNumber,
 
 		DNSNames: []string{string(cn)},
+		URIs:     uriSANs,
 
 		Subject: pkix.Name{
 			CommonName:   string(cn),
 			Organization: []string{cm.certificatesOrganization},
 		},
 		NotBefore: now,
-		NotAfter:  now.Add(validityPeriod),
+		NotAfter:  notAfter,
 
 		KeyUsage:              x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
 		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
@@ -84,23 +126,7 @@ func (cm *CertManager) IssueCertificate(cn certificate.CommonName, saNames []str
 		template.DNSNames = uniqueSubjectAlternativeNames(template.DNSNames)
 	}
 
-	x509Root, err := certificate.DecodePEMCertificate(cm.ca.GetCertificateChain())
-	if err != nil {
-		// TODO(#3962): metric might not be scraped before process restart resulting from this error
-		log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrDecodingPEMCert)).
-			Msg("Error decoding Root Certificate's PEM")
-		return nil, fmt.Errorf("%s: %w", errCreateCert.Error(), err)
-	}
-
-	rsaKeyRoot, err := certificate.DecodePEMPrivateKey(cm.ca.GetPrivateKey())
-	if err != nil {
-		// TODO(#3962): metric might not be scraped before process restart resulting from this error
-		log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrDecodingPEMPrivateKey)).
-			Msg("Error decoding Root Certificate's Private Key PEM ")
-		return nil, fmt.Errorf("%s: %w", errCreateCert.Error(), err)
-	}
-
-	derBytes, err := x509.CreateCertificate(rand.Reader, &template, x509Root, &certPrivKey.PublicKey, rsaKeyRoot)
+	derBytes, err := x509.CreateCertificate(rand.Reader, &template, signer, &certPrivKey.PublicKey, signerKey)
 	if err != nil {
 		// TODO(#3962): metric might not be scraped before process restart resulting from this error
 		log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrCreatingCert)).
@@ -124,22 +150,57 @@ func (cm *CertManager) IssueCertificate(cn certificate.CommonName, saNames []str
 		return nil, err
 	}
 
+	roots := cm.roots
+	if cm.signer == nil {
+		roots = pem.RootCertificate(cm.ca.GetCertificateChain())
+	}
+
 	cert := &certificate.Certificate{
 		CommonName:   cn,
 		SANames:      template.DNSNames,
 		SerialNumber: certificate.SerialNumber(serialNumber.String()),
-		CertChain:    certPEM,
+		CertChain:    append(certPEM, cm.intermediates...),
 		PrivateKey:   privKeyPEM,
-		IssuingCA:    pem.RootCertificate(cm.ca.GetCertificateChain()),
-		TrustedCAs:   pem.RootCertificate(cm.ca.GetCertificateChain()),
+		IssuingCA:    roots,
+		TrustedCAs:   roots,
 		Expiration:   template.NotAfter,
 	}
 
+	for _, uri := range uriSANs {
+		cert.URISANames = append(cert.URISANames, uri.String())
+	}
+
 	log.Trace().Msgf("Created new certificate for SerialNumber=%s; validity=%+v; expires on %+v; serial: %x", serialNumber, validityPeriod, template.NotAfter, template.SerialNumber)
 
 	return cert, nil
 }
 
+// getSigner returns the CA certificate signing the issued certificates and its private key: the intermediate CA
+// when one is configured, the root certificate otherwise.
+func (cm *CertManager) getSigner() (*x509.Certificate, crypto.Signer, error) {
+	if cm.signer != nil {
+		return cm.signer, cm.signerKey, nil
+	}
+
+	x509Root, err := certificate.DecodePEMCertificate(cm.ca.GetCertificateChain())
+	if err != nil {
+		// TODO(#3962): metric might not be scraped before process restart resulting from this error
+		log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrDecodingPEMCert)).
+			Msg("Error decoding Root Certificate's PEM")
+		return nil, nil, fmt.Errorf("%s: %w", errCreateCert.Error(), err)
+	}
+
+	rsaKeyRoot, err := certificate.DecodePEMPrivateKey(cm.ca.GetPrivateKey())
+	if err != nil {
+		// TODO(#3962): metric might not be scraped before process restart resulting from this error
+		log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrDecodingPEMPrivateKey)).
+			Msg("Error decoding Root Certificate's Private Key PEM ")
+		return nil, nil, fmt.Errorf("%s: %w", errCreateCert.Error(), err)
+	}
+
+	return x509Root, rsaKeyRoot, nil
+}
+
 func uniqueSubjectAlternativeNames(saNames []string, excludeSANS ...string) []string {
 	if len(saNames) > 1 {
 		sanMap := make(map[string]uint8)
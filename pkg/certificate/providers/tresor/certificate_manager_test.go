@@ -1,6 +1,7 @@
 package tresor
 
 import (
+	"net/url"
 	"time"
 
 	. "github.com/onsi/ginkgo"
@@ -37,7 +38,7 @@ var _ = Describe("Test Certificate Manager", func() {
 		)
 		It("should issue a certificate", func() {
 			Expect(newCertError).ToNot(HaveOccurred())
-			cert, issueCertificateError := m.IssueCertificate(serviceFQDN, nil, validity)
+			cert, issueCertificateError := m.IssueCertificate(serviceFQDN, nil, nil, validity)
 			Expect(issueCertificateError).ToNot(HaveOccurred())
 			Expect(cert.GetCommonName()).To(Equal(certificate.CommonName(serviceFQDN)))
 
@@ -58,6 +59,19 @@ var _ = Describe("Test Certificate Manager", func() {
 			Expect(err).ToNot(HaveOccurred(), string(pemRootCert))
 			Expect(xRootCert.Subject.CommonName).To(Equal(cn.String()))
 		})
+
+		It("should issue a certificate with URI SANs", func() {
+			Expect(newCertError).ToNot(HaveOccurred())
+			spiffeID, err := url.Parse("spiffe://cluster.local/ns/ns-1/sa/sa-1")
+			Expect(err).ToNot(HaveOccurred())
+			cert, issueCertificateError := m.IssueCertificate(serviceFQDN, nil, []*url.URL{spiffeID}, validity)
+			Expect(issueCertificateError).ToNot(HaveOccurred())
+			Expect(cert.URISANames).To(Equal([]string{spiffeID.String()}))
+
+			xCert, err := certificate.DecodePEMCertificate(cert.GetCertificateChain())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(xCert.URIs).To(Equal([]*url.URL{spiffeID}))
+		})
 	})
 
 	Context("Test nil certificate issue", func() {
@@ -77,7 +91,7 @@ var _ = Describe("Test Certificate Manager", func() {
 
 		m := &CertManager{}
 		It("should return errNoIssuingCA error", func() {
-			cert, issueCertificateError := m.IssueCertificate(serviceFQDN, nil, validity)
+			cert, issueCertificateError := m.IssueCertificate(serviceFQDN, nil, nil, validity)
 			Expect(cert).To(BeNil())
 			Expect(issueCertificateError).To(Equal(errNoIssuingCA))
 		})
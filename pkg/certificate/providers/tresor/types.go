@@ -2,9 +2,12 @@
 package tresor
 
 import (
+	"crypto"
+	"crypto/x509"
 	"math/big"
 
 	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/certificate/pem"
 	"github.com/openservicemesh/osm/pkg/logger"
 )
 
@@ -26,7 +29,18 @@ var (
 // CertManager implements certificate.Manager
 type CertManager struct {
 	// The Certificate Authority root certificate to be used by this certificate manager
-	ca                       *certificate.Certificate
+	ca *certificate.Certificate
+
+	// The intermediate CA signing the newly issued certificates instead of the root certificate, and its private key
+	signer    *x509.Certificate
+	signerKey crypto.Signer
+
+	// The chain of intermediate certificates from the signer up to, and excluding, the roots
+	intermediates pem.Certificate
+
+	// The root certificates the intermediate chains up to
+	roots pem.RootCertificate
+
 	certificatesOrganization string
 	keySize                  int
 }
@@ -32,6 +32,17 @@ const (
 	CertManagerKind Kind = "cert-manager"
 )
 
+const (
+	// VaultTokenAuthMethod authenticates to Vault with a static token
+	VaultTokenAuthMethod = "token"
+
+	// VaultKubernetesAuthMethod authenticates to Vault with the ServiceAccount token of the control plane
+	VaultKubernetesAuthMethod = "kubernetes"
+
+	// VaultAppRoleAuthMethod authenticates to Vault with an AppRole role ID and secret ID
+	VaultAppRoleAuthMethod = "approle"
+)
+
 var (
 	// ValidCertificateProviders is the list of supported certificate providers
 	ValidCertificateProviders = []Kind{TresorKind, VaultKind, CertManagerKind}
@@ -62,6 +73,16 @@ type VaultOptions struct {
 	VaultTokenSecretNamespace string
 	VaultTokenSecretName      string
 	VaultTokenSecretKey       string
+	VaultNamespace            string
+
+	// VaultAuthMethod is the auth method used to obtain the Vault token, one of token, kubernetes or approle
+	VaultAuthMethod                string
+	VaultKubernetesAuthRole        string
+	VaultKubernetesAuthMountPath   string
+	VaultAppRoleID                 string
+	VaultAppRoleMountPath          string
+	VaultAppRoleSecretIDSecretName string
+	VaultAppRoleSecretIDSecretKey  string
 }
 
 // CertManagerOptions is a type that specifies 'cert-manager.io' certificate provider options
@@ -0,0 +1,226 @@
+package vault
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/hashicorp/vault/api"
+)
+
+const (
+	// DefaultKubernetesAuthMountPath is the default path the Vault Kubernetes auth method is mounted at
+	DefaultKubernetesAuthMountPath = "kubernetes"
+
+	// DefaultAppRoleAuthMountPath is the default path the Vault AppRole auth method is mounted at
+	DefaultAppRoleAuthMountPath = "approle"
+
+	// DefaultServiceAccountTokenPath is the default path of the ServiceAccount token mounted in pods
+	DefaultServiceAccountTokenPath = "/var/run/secrets/kubernetes.io/serviceaccount/token" // #nosec G101
+
+	// loginRetryInterval is the interval between attempts to log in again to Vault once the token can no longer be renewed
+	loginRetryInterval = 10 * time.Second
+)
+
+// AuthMethod is a method to obtain a Vault token
+type AuthMethod interface {
+	// Login authenticates to Vault and returns the secret holding the Vault token
+	Login(ctx context.Context, client *api.Client) (*api.Secret, error)
+
+	// CanRelogin returns whether Login can be called again to obtain a new token once the current one expires
+	CanRelogin() bool
+}
+
+// TokenAuth authenticates with a static Vault token
+type TokenAuth struct {
+	Token string
+}
+
+// KubernetesAuth authenticates with the ServiceAccount token of the pod using the Vault Kubernetes auth method
+type KubernetesAuth struct {
+	Role                    string
+	MountPath               string
+	ServiceAccountTokenPath string
+}
+
+// AppRoleAuth authenticates using the Vault AppRole auth method
+type AppRoleAuth struct {
+	RoleID    string
+	SecretID  string
+	MountPath string
+}
+
+// Login returns the static token, along with its TTL and renewability when the token can look itself up
+func (a *TokenAuth) Login(ctx context.Context, client *api.Client) (*api.Secret, error) {
+	if a.Token == "" {
+		return nil, fmt.Errorf("vault token must not be empty")
+	}
+
+	secret := &api.Secret{Auth: &api.SecretAuth{ClientToken: a.Token}}
+
+	// The lookup is best effort, so it is not retried to avoid delaying the creation of the CertManager
+	lookupClient, err := client.CloneWithHeaders()
+	if err != nil {
+		return nil, err
+	}
+	lookupClient.SetMaxRetries(0)
+	lookupClient.SetToken(a.Token)
+	self, err := lookupClient.Auth().Token().LookupSelfWithContext(ctx)
+	if err != nil {
+		// The token may not be allowed to look itself up, in which case it is used as is without renewal
+		log.Warn().Err(err).Msg("Error looking up the Vault token, it will not be renewed")
+		return secret, nil
+	}
+	if secret.Auth.Renewable, err = self.TokenIsRenewable(); err != nil {
+		return secret, nil
+	}
+	ttl, err := self.TokenTTL()
+	if err != nil {
+		return secret, nil
+	}
+	secret.Auth.LeaseDuration = int(ttl.Seconds())
+
+	return secret, nil
+}
+
+// CanRelogin returns false, a static token can't be replaced once it expires
+func (a *TokenAuth) CanRelogin() bool {
+	return false
+}
+
+// Login logs in with the ServiceAccount token read from the token file, so that projected tokens are picked up when rotated
+func (a *KubernetesAuth) Login(ctx context.Context, client *api.Client) (*api.Secret, error) {
+	if a.Role == "" {
+		return nil, fmt.Errorf("vault kubernetes auth role must not be empty")
+	}
+
+	tokenPath := a.ServiceAccountTokenPath
+	if tokenPath == "" {
+		tokenPath = DefaultServiceAccountTokenPath
+	}
+	jwt, err := os.ReadFile(tokenPath) // #nosec G304
+	if err != nil {
+		return nil, fmt.Errorf("error reading ServiceAccount token from %s: %w", tokenPath, err)
+	}
+
+	return login(ctx, client, a.MountPath, DefaultKubernetesAuthMountPath, map[string]interface{}{
+		"role": a.Role,
+		"jwt":  string(jwt),
+	})
+}
+
+// CanRelogin returns true
+func (a *KubernetesAuth) CanRelogin() bool {
+	return true
+}
+
+// Login logs in with the AppRole role ID and secret ID
+func (a *AppRoleAuth) Login(ctx context.Context, client *api.Client) (*api.Secret, error) {
+	if a.RoleID == "" {
+		return nil, fmt.Errorf("vault approle role ID must not be empty")
+	}
+
+	return login(ctx, client, a.MountPath, DefaultAppRoleAuthMountPath, map[string]interface{}{
+		"role_id":   a.RoleID,
+		"secret_id": a.SecretID,
+	})
+}
+
+// CanRelogin returns true
+func (a *AppRoleAuth) CanRelogin() bool {
+	return true
+}
+
+func login(ctx context.Context, client *api.Client, mountPath, defaultMountPath string, data map[string]interface{}) (*api.Secret, error) {
+	if mountPath == "" {
+		mountPath = defaultMountPath
+	}
+
+	// Login requests keep the namespace header but must not carry a previous, possibly expired, token
+	loginClient, err := client.CloneWithHeaders()
+	if err != nil {
+		return nil, err
+	}
+	loginClient.ClearToken()
+
+	secret, err := loginClient.Logical().WriteWithContext(ctx, fmt.Sprintf("auth/%s/login", mountPath), data)
+	if err != nil {
+		return nil, fmt.Errorf("error logging in to Vault with the auth method mounted at %s: %w", mountPath, err)
+	}
+	if secret == nil || secret.Auth == nil || secret.Auth.ClientToken == "" {
+		return nil, fmt.Errorf("no token returned by the Vault auth method mounted at %s", mountPath)
+	}
+
+	return secret, nil
+}
+
+// manageTokenLifetime renews the Vault token of the given secret for as long as possible, and logs in again
+// once it can no longer be renewed if the auth method allows it. It returns when the context is done.
+func (cm *CertManager) manageTokenLifetime(ctx context.Context, auth AuthMethod, secret *api.Secret) {
+	for {
+		if secret.Auth.LeaseDuration <= 0 {
+			// The token does not expire
+			return
+		}
+
+		watcher, err := cm.client.NewLifetimeWatcher(&api.LifetimeWatcherInput{Secret: secret})
+		if err != nil {
+			log.Error().Err(err).Msg("Error creating a Vault token lifetime watcher")
+			return
+		}
+		go watcher.Start()
+		if !cm.watchToken(ctx, watcher) {
+			return
+		}
+
+		if !auth.CanRelogin() {
+			log.Warn().Msg("The Vault token can no longer be renewed and the auth method can't obtain a new one")
+			return
+		}
+
+		secret = cm.relogin(ctx, auth)
+		if secret == nil {
+			return
+		}
+	}
+}
+
+// watchToken logs the renewals of the token until the token can no longer be renewed, in which case it returns true,
+// or the context is done, in which case it returns false
+func (cm *CertManager) watchToken(ctx context.Context, watcher *api.LifetimeWatcher) bool {
+	defer watcher.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case err := <-watcher.DoneCh():
+			if err != nil {
+				log.Error().Err(err).Msg("Error renewing the Vault token")
+			}
+			return true
+		case renewal := <-watcher.RenewCh():
+			log.Debug().Msgf("Renewed the Vault token at %s", renewal.RenewedAt)
+		}
+	}
+}
+
+// relogin logs in to Vault until it succeeds and sets the new token on the client.
+// It returns nil if the context is done first.
+func (cm *CertManager) relogin(ctx context.Context, auth AuthMethod) *api.Secret {
+	for {
+		secret, err := auth.Login(ctx, cm.client)
+		if err == nil {
+			cm.client.SetToken(secret.Auth.ClientToken)
+			log.Info().Msg("Logged in to Vault again")
+			return secret
+		}
+		log.Error().Err(err).Msgf("Error logging in to Vault again, retrying in %s", loginRetryInterval)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(loginRetryInterval):
+		}
+	}
+}
@@ -0,0 +1,242 @@
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeVault is a fake Vault HTTP server implementing the auth endpoints used by the CertManager
+type fakeVault struct {
+	*httptest.Server
+
+	mu         sync.Mutex
+	logins     int
+	namespaces []string
+
+	leaseDuration int
+	renewable     bool
+}
+
+func newFakeVault(t *testing.T) *fakeVault {
+	v := &fakeVault{leaseDuration: 3600, renewable: true}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/auth/kubernetes/login", v.login(func(data map[string]interface{}) bool {
+		return data["role"] == "osm" && data["jwt"] == "sa-token"
+	}))
+	mux.HandleFunc("/v1/auth/custom-approle/login", v.login(func(data map[string]interface{}) bool {
+		return data["role_id"] == "role-id" && data["secret_id"] == "secret-id"
+	}))
+	mux.HandleFunc("/v1/auth/token/lookup-self", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "static-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		writeJSON(w, map[string]interface{}{
+			"data": map[string]interface{}{"renewable": true, "ttl": 7200},
+		})
+	})
+
+	v.Server = httptest.NewServer(mux)
+	t.Cleanup(v.Close)
+	return v
+}
+
+func (v *fakeVault) login(valid func(map[string]interface{}) bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var data map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&data); err != nil || !valid(data) {
+			w.WriteHeader(http.StatusBadRequest)
+			writeJSON(w, map[string]interface{}{"errors": []string{"invalid credentials"}})
+			return
+		}
+
+		v.mu.Lock()
+		defer v.mu.Unlock()
+		v.logins++
+		v.namespaces = append(v.namespaces, r.Header.Get("X-Vault-Namespace"))
+		writeJSON(w, map[string]interface{}{
+			"auth": map[string]interface{}{
+				"client_token":   fmt.Sprintf("token-%d", v.logins),
+				"lease_duration": v.leaseDuration,
+				"renewable":      v.renewable,
+			},
+		})
+	}
+}
+
+func (v *fakeVault) loginCount() int {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.logins
+}
+
+func writeJSON(w http.ResponseWriter, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeTokenFile(t *testing.T, token string) string {
+	path := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(path, []byte(token), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestNewWithAuthMethods(t *testing.T) {
+	fake := newFakeVault(t)
+
+	testCases := []struct {
+		name          string
+		options       Options
+		expectedToken string
+		expectedErr   bool
+	}{
+		{
+			name: "kubernetes auth in a namespace",
+			options: Options{
+				Auth:      &KubernetesAuth{Role: "osm", ServiceAccountTokenPath: writeTokenFile(t, "sa-token")},
+				Namespace: "osm-ns",
+			},
+			expectedToken: "token-1",
+		},
+		{
+			name: "kubernetes auth with an invalid ServiceAccount token",
+			options: Options{
+				Auth: &KubernetesAuth{Role: "osm", ServiceAccountTokenPath: writeTokenFile(t, "invalid")},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "kubernetes auth without a ServiceAccount token file",
+			options: Options{
+				Auth: &KubernetesAuth{Role: "osm", ServiceAccountTokenPath: filepath.Join(t.TempDir(), "missing")},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "approle auth with a custom mount path",
+			options: Options{
+				Auth: &AppRoleAuth{RoleID: "role-id", SecretID: "secret-id", MountPath: "custom-approle"},
+			},
+			expectedToken: "token-2",
+		},
+		{
+			name: "approle auth with an invalid secret ID",
+			options: Options{
+				Auth: &AppRoleAuth{RoleID: "role-id", SecretID: "invalid", MountPath: "custom-approle"},
+			},
+			expectedErr: true,
+		},
+		{
+			name:          "static token auth",
+			options:       Options{Auth: &TokenAuth{Token: "static-token"}},
+			expectedToken: "static-token",
+		},
+		{
+			name:        "no auth method",
+			options:     Options{},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := assert.New(t)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			cm, err := NewWithOptions(ctx, fake.URL, vaultRole, tc.options)
+			if tc.expectedErr {
+				a.Error(err)
+				return
+			}
+			a.NoError(err)
+			a.Equal(tc.expectedToken, cm.client.Token())
+		})
+	}
+
+	a := assert.New(t)
+	a.Equal([]string{"osm-ns", ""}, fake.namespaces)
+}
+
+func TestTokenAuthLogin(t *testing.T) {
+	a := assert.New(t)
+	fake := newFakeVault(t)
+
+	cm, err := NewWithOptions(context.Background(), fake.URL, vaultRole, Options{Auth: &TokenAuth{Token: "static-token"}})
+	a.NoError(err)
+
+	secret, err := (&TokenAuth{Token: "static-token"}).Login(context.Background(), cm.client)
+	a.NoError(err)
+	a.True(secret.Auth.Renewable)
+	a.Equal(7200, secret.Auth.LeaseDuration)
+
+	// A token that can't look itself up is used without renewal
+	secret, err = (&TokenAuth{Token: "other-token"}).Login(context.Background(), cm.client)
+	a.NoError(err)
+	a.Equal("other-token", secret.Auth.ClientToken)
+	a.Zero(secret.Auth.LeaseDuration)
+
+	_, err = (&TokenAuth{}).Login(context.Background(), cm.client)
+	a.Error(err)
+}
+
+func TestManageTokenLifetimeRelogin(t *testing.T) {
+	a := assert.New(t)
+	fake := newFakeVault(t)
+	// Short lived tokens that can't be renewed require logging in again
+	fake.leaseDuration = 1
+	fake.renewable = false
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cm, err := NewWithOptions(ctx, fake.URL, vaultRole, Options{
+		Auth: &AppRoleAuth{RoleID: "role-id", SecretID: "secret-id", MountPath: "custom-approle"},
+	})
+	a.NoError(err)
+	a.Equal("token-1", cm.client.Token())
+
+	a.Eventually(func() bool {
+		return fake.loginCount() >= 2 && cm.client.Token() != "token-1"
+	}, 10*time.Second, 100*time.Millisecond)
+}
+
+func TestRelease(t *testing.T) {
+	a := assert.New(t)
+	fake := newFakeVault(t)
+	fake.leaseDuration = 1
+	fake.renewable = false
+
+	cm, err := NewWithOptions(context.Background(), fake.URL, vaultRole, Options{
+		Auth: &AppRoleAuth{RoleID: "role-id", SecretID: "secret-id", MountPath: "custom-approle"},
+	})
+	a.NoError(err)
+	a.Eventually(func() bool {
+		return fake.loginCount() >= 2
+	}, 10*time.Second, 100*time.Millisecond)
+
+	cm.Release()
+	// a login may be in flight when the CertManager is released
+	time.Sleep(100 * time.Millisecond)
+	released := fake.loginCount()
+
+	// the token is no longer obtained again once the CertManager is released
+	time.Sleep(2 * time.Second)
+	a.Equal(released, fake.loginCount())
+
+	// releasing the CertManager again is a noop
+	cm.Release()
+}
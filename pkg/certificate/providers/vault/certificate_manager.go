@@ -1,7 +1,9 @@
 package vault
 
 import (
+	"context"
 	"fmt"
+	"net/url"
 	"strings"
 	"time"
 
@@ -24,15 +26,35 @@ const (
 	issuingCAField    = "issuing_ca"
 	commonNameField   = "common_name"
 	ttlField          = "ttl"
+	uriSANsField      = "uri_sans"
 )
 
+// Options are the options of a Vault CertManager
+type Options struct {
+	// Auth is the method used to obtain the Vault token
+	Auth AuthMethod
+
+	// Namespace is the Vault Enterprise namespace of the PKI secrets engine and the auth method
+	Namespace string
+}
+
 // New constructs a new certificate client using Vault's cert-manager
 func New(vaultAddr, token, role string) (*CertManager, error) {
+	if token == "" {
+		return nil, fmt.Errorf("vault token must not be empty")
+	}
+	return NewWithOptions(context.Background(), vaultAddr, role, Options{Auth: &TokenAuth{Token: token}})
+}
+
+// NewWithOptions constructs a new certificate client using Vault's cert-manager, authenticating with the given auth method.
+// The Vault token is renewed, or obtained again when the auth method allows it, until the context is done or the
+// CertManager is released.
+func NewWithOptions(ctx context.Context, vaultAddr, role string, options Options) (*CertManager, error) {
 	if vaultAddr == "" {
 		return nil, fmt.Errorf("vault address must not be empty")
 	}
-	if token == "" {
-		return nil, fmt.Errorf("vault token must not be empty")
+	if options.Auth == nil {
+		return nil, fmt.Errorf("vault auth method must be specified")
 	}
 	if role == "" {
 		return nil, fmt.Errorf("vault role must not be empty")
@@ -47,16 +69,34 @@ func New(vaultAddr, token, role string) (*CertManager, error) {
 	if c.client, err = api.NewClient(config); err != nil {
 		return nil, fmt.Errorf("error creating Vault CertManager without TLS at %s, got err: %w", vaultAddr, err)
 	}
+	if options.Namespace != "" {
+		c.client.SetNamespace(options.Namespace)
+	}
+
+	secret, err := options.Auth.Login(ctx, c.client)
+	if err != nil {
+		return nil, err
+	}
+	c.client.SetToken(secret.Auth.ClientToken)
 	log.Info().Msgf("Created Vault CertManager, with role=%q at %v", role, vaultAddr)
 
-	c.client.SetToken(token)
+	ctx, c.cancel = context.WithCancel(ctx)
+	go c.manageTokenLifetime(ctx, options.Auth, secret)
 
 	return c, nil
 }
 
+// Release stops renewing the Vault token of the CertManager, which can no longer issue certificates once the token
+// expires. It implements the certificate.ReleasableIssuer interface.
+func (cm *CertManager) Release() {
+	if cm.cancel != nil {
+		cm.cancel()
+	}
+}
+
 // IssueCertificate requests a new signed certificate from the configured Vault issuer.
-func (cm *CertManager) IssueCertificate(cn certificate.CommonName, saNames []string, validityPeriod time.Duration) (*certificate.Certificate, error) {
-	secret, err := cm.client.Logical().Write(getIssueURL(cm.role), getIssuanceData(cn, validityPeriod))
+func (cm *CertManager) IssueCertificate(cn certificate.CommonName, saNames []string, uriSANs []*url.URL, validityPeriod time.Duration) (*certificate.Certificate, error) {
+	secret, err := cm.client.Logical().Write(getIssueURL(cm.role), getIssuanceData(cn, uriSANs, validityPeriod))
 	if err != nil {
 		// TODO(#3962): metric might not be scraped before process restart resulting from this error
 		log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrIssuingCert)).
@@ -64,6 +104,9 @@ func (cm *CertManager) IssueCertificate(cn certificate.CommonName, saNames []str
 		return nil, err
 	}
 	cert := newCert(cn, secret, uniqueSubjectAlternativeNames(saNames), time.Now().Add(validityPeriod))
+	for _, uri := range uriSANs {
+		cert.URISANames = append(cert.URISANames, uri.String())
+	}
 	return cert, nil
 }
 
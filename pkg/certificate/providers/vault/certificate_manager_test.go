@@ -147,7 +147,7 @@ func TestIssueCertificate(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.description, func(t *testing.T) {
 			tassert := assert.New(t)
-			_, err = cm.IssueCertificate(tc.cn, nil, tc.vP)
+			_, err = cm.IssueCertificate(tc.cn, nil, nil, tc.vP)
 			if tc.wantErr {
 				tassert.Error(err, "expected error, got nil")
 			} else {
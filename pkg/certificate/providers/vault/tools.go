@@ -2,6 +2,8 @@ package vault
 
 import (
 	"fmt"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/openservicemesh/osm/pkg/certificate"
@@ -15,9 +17,18 @@ func getIssueURL(role string) string {
 	return fmt.Sprintf("pki/issue/%+v", role)
 }
 
-func getIssuanceData(cn certificate.CommonName, validityPeriod time.Duration) map[string]interface{} {
-	return map[string]interface{}{
+func getIssuanceData(cn certificate.CommonName, uriSANs []*url.URL, validityPeriod time.Duration) map[string]interface{} {
+	data := map[string]interface{}{
 		commonNameField: cn.String(),
 		ttlField:        getDurationInMinutes(validityPeriod),
 	}
+	if len(uriSANs) > 0 {
+		// The Vault role must allow the URI SANs requested, see the allowed_uri_sans role parameter
+		var uris []string
+		for _, uri := range uriSANs {
+			uris = append(uris, uri.String())
+		}
+		data[uriSANsField] = strings.Join(uris, ",")
+	}
+	return data
 }
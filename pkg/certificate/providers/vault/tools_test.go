@@ -2,6 +2,7 @@ package vault
 
 import (
 	"fmt"
+	"net/url"
 	"time"
 
 	. "github.com/onsi/ginkgo"
@@ -34,12 +35,25 @@ var _ = Describe("Test tools", func() {
 	Context("Test cert issuance data for request", func() {
 		It("creates a map w/ correct fields", func() {
 			cn := certificate.CommonName("blah.foo.com")
-			actual := getIssuanceData(cn, 8123*time.Minute)
+			actual := getIssuanceData(cn, nil, 8123*time.Minute)
 			expected := map[string]interface{}{
 				"common_name": "blah.foo.com",
 				"ttl":         "135h",
 			}
 			Expect(actual).To(Equal(expected))
 		})
+
+		It("adds the URI SANs to the request", func() {
+			cn := certificate.CommonName("blah.foo.com")
+			spiffeID, err := url.Parse("spiffe://foo.com/ns/foo/sa/blah")
+			Expect(err).ToNot(HaveOccurred())
+			actual := getIssuanceData(cn, []*url.URL{spiffeID}, 8123*time.Minute)
+			expected := map[string]interface{}{
+				"common_name": "blah.foo.com",
+				"ttl":         "135h",
+				"uri_sans":    "spiffe://foo.com/ns/foo/sa/blah",
+			}
+			Expect(actual).To(Equal(expected))
+		})
 	})
 })
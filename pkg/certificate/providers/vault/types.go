@@ -2,6 +2,8 @@
 package vault
 
 import (
+	"context"
+
 	"github.com/hashicorp/vault/api"
 )
 
@@ -12,4 +14,7 @@ type CertManager struct {
 
 	// The Vault role configured for OSM and passed as a CLI.
 	role string
+
+	// cancel stops managing the lifetime of the Vault token
+	cancel context.CancelFunc
 }
@@ -0,0 +1,181 @@
+package certificate
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+// SetTrustBundleAcknowledger sets the TrustBundleAcknowledger used to decide when the stages of a root
+// certificate rotation can advance. Root certificate rotations are only driven by a Manager with an acknowledger.
+func (m *Manager) SetTrustBundleAcknowledger(acknowledger TrustBundleAcknowledger) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.trustBundleAcknowledger = acknowledger
+}
+
+// advanceRootRotation advances the stage of an in progress root certificate rotation once all the
+// connected proxies have applied certificates issued for the current stage, across all the replicas of the
+// control plane when the MRC client aggregates their acknowledgements. A rotation goes through:
+//  1. validatingRollout: the root of the new MRC is added to the trust bundle, certificates are still issued from the active MRC
+//  2. issuingRollout: certificates are issued from the new MRC, the root of the active MRC is still trusted
+//  3. active: the previously active MRC becomes inactive and its root is removed from the trust bundle
+//
+// A rotation is started by creating an MRC without a state while another MRC is active.
+func (m *Manager) advanceRootRotation() {
+	m.mu.Lock()
+	acknowledger := m.trustBundleAcknowledger
+	signingIssuer, validatingIssuer := m.signingIssuer, m.validatingIssuer
+	var active, candidate *v1alpha2.MeshRootCertificate
+	for _, name := range m.sortedMRCNames() {
+		mrc := m.mrcIssuers[name].mrc
+		switch mrc.Status.State {
+		case constants.MRCStateActive:
+			if active == nil {
+				active = mrc
+			}
+		case constants.MRCStateValidatingRollout, constants.MRCStateIssuingRollout:
+			// a rotation in progress takes precedence over one yet to start
+			candidate = mrc
+		case "":
+			if candidate == nil {
+				candidate = mrc
+			}
+		}
+	}
+	m.mu.Unlock()
+
+	if acknowledger == nil || m.mrcClient == nil || signingIssuer == nil || validatingIssuer == nil {
+		return
+	}
+
+	pending := acknowledger.CountUnacknowledgedProxies(signingIssuer.ID, validatingIssuer.ID)
+	// The proxies are spread across the replicas of the control plane, each replica only knows its connected proxies
+	if aggregator, ok := m.mrcClient.(AcknowledgementAggregator); ok {
+		total, err := aggregator.AggregateUnacknowledgedProxies(signingIssuer.ID, validatingIssuer.ID, pending)
+		if err != nil {
+			log.Error().Err(err).Msg("Error aggregating the unacknowledged proxies of the control plane replicas")
+			return
+		}
+		pending = total
+	}
+
+	if candidate == nil {
+		if active != nil {
+			m.updateAcknowledgedCondition(active, pending)
+		}
+		return
+	}
+
+	switch candidate.Status.State {
+	case "":
+		if active == nil {
+			// There is no root to rotate from, so the MRC becomes active right away
+			log.Info().Msgf("Activating MRC %s", candidate.Name)
+			m.updateMRCStatus(newMRCStage(candidate, constants.MRCStateActive, true, true, constants.MRCConditionReasonActive))
+			return
+		}
+		log.Info().Msgf("Starting root certificate rotation from MRC %s to MRC %s: adding the root of MRC %s to the trust bundle", active.Name, candidate.Name, candidate.Name)
+		m.updateMRCStatus(newMRCStage(candidate, constants.MRCStateValidatingRollout, true, false, constants.MRCConditionReasonTrustBundleRollout))
+
+	case constants.MRCStateValidatingRollout:
+		if pending > 0 {
+			m.updateAcknowledgedCondition(candidate, pending)
+			return
+		}
+		log.Info().Msgf("All proxies trust the root of MRC %s, issuing certificates from it", candidate.Name)
+		if !m.updateMRCStatus(newMRCStage(candidate, constants.MRCStateIssuingRollout, true, true, constants.MRCConditionReasonIssuerRollout)) {
+			return
+		}
+		if active != nil {
+			m.updateMRCStatus(newMRCStage(active, constants.MRCStateActive, true, false, constants.MRCConditionReasonIssuerRollout))
+		}
+
+	case constants.MRCStateIssuingRollout:
+		if pending > 0 {
+			m.updateAcknowledgedCondition(candidate, pending)
+			return
+		}
+		if active != nil {
+			log.Info().Msgf("All proxies use certificates issued from MRC %s, removing the root of MRC %s from the trust bundle", candidate.Name, active.Name)
+			if !m.updateMRCStatus(newMRCStage(active, constants.MRCStateInactive, false, false, constants.MRCConditionReasonRootRemoval)) {
+				return
+			}
+		}
+		m.updateMRCStatus(newMRCStage(candidate, constants.MRCStateActive, true, true, constants.MRCConditionReasonActive))
+	}
+}
+
+// updateMRCStatus writes the status of the given MRC and observes the updated MRC right away,
+// so that the issuers change and a stage is not advanced twice before the update event is received.
+// It returns whether the update succeeded.
+func (m *Manager) updateMRCStatus(mrc *v1alpha2.MeshRootCertificate) bool {
+	updated, err := m.mrcClient.UpdateMRCStatus(mrc)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error updating the status of MRC %s", mrc.Name)
+		return false
+	}
+
+	if err := m.observeMRC(m.mrcClient, updated); err != nil {
+		log.Error().Err(err).Msgf("Error observing updated MRC %s", mrc.Name)
+	}
+	return true
+}
+
+// updateAcknowledgedCondition updates the Acknowledged condition of the given MRC if it changed.
+func (m *Manager) updateAcknowledgedCondition(mrc *v1alpha2.MeshRootCertificate, pending int) {
+	updated := mrc.DeepCopy()
+	setMRCAcknowledgedCondition(updated, pending)
+
+	existing := meta.FindStatusCondition(mrc.Status.Conditions, constants.MRCConditionTypeAcknowledged)
+	condition := meta.FindStatusCondition(updated.Status.Conditions, constants.MRCConditionTypeAcknowledged)
+	if existing != nil && existing.Status == condition.Status && existing.Reason == condition.Reason &&
+		existing.Message == condition.Message && existing.ObservedGeneration == condition.ObservedGeneration {
+		return
+	}
+
+	m.updateMRCStatus(updated)
+}
+
+// newMRCStage returns a copy of the given MRC in the given state with the conditions of the stage.
+func newMRCStage(mrc *v1alpha2.MeshRootCertificate, state string, trusted, issuing bool, reason string) *v1alpha2.MeshRootCertificate {
+	mrc = mrc.DeepCopy()
+	mrc.Status.State = state
+	setMRCCondition(mrc, constants.MRCConditionTypeTrusted, trusted, reason, "")
+	setMRCCondition(mrc, constants.MRCConditionTypeIssuing, issuing, reason, "")
+	if state == constants.MRCStateInactive {
+		meta.RemoveStatusCondition(&mrc.Status.Conditions, constants.MRCConditionTypeAcknowledged)
+	} else {
+		setMRCCondition(mrc, constants.MRCConditionTypeAcknowledged, false, constants.MRCConditionReasonProxiesPending,
+			"Waiting for connected proxies to apply certificates issued for this stage")
+	}
+	return mrc
+}
+
+func setMRCAcknowledgedCondition(mrc *v1alpha2.MeshRootCertificate, pending int) {
+	if pending > 0 {
+		setMRCCondition(mrc, constants.MRCConditionTypeAcknowledged, false, constants.MRCConditionReasonProxiesPending,
+			fmt.Sprintf("%d connected proxies have not applied certificates issued for this stage", pending))
+		return
+	}
+	setMRCCondition(mrc, constants.MRCConditionTypeAcknowledged, true, constants.MRCConditionReasonProxiesAcknowledged,
+		"All connected proxies have applied certificates issued for this stage")
+}
+
+func setMRCCondition(mrc *v1alpha2.MeshRootCertificate, conditionType string, status bool, reason, message string) {
+	conditionStatus := metav1.ConditionFalse
+	if status {
+		conditionStatus = metav1.ConditionTrue
+	}
+	meta.SetStatusCondition(&mrc.Status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             conditionStatus,
+		ObservedGeneration: mrc.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
@@ -0,0 +1,150 @@
+package certificate
+
+import (
+	"testing"
+
+	tassert "github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+type fakeTrustBundleAcknowledger struct {
+	pending int
+}
+
+func (a *fakeTrustBundleAcknowledger) CountUnacknowledgedProxies(_, _ string) int {
+	return a.pending
+}
+
+func TestAdvanceRootRotation(t *testing.T) {
+	assert := tassert.New(t)
+
+	newMRC := func(name, state string) *v1alpha2.MeshRootCertificate {
+		return &v1alpha2.MeshRootCertificate{
+			ObjectMeta: v1.ObjectMeta{Name: name},
+			Status:     v1alpha2.MeshRootCertificateStatus{State: state},
+		}
+	}
+	mrcClient := &fakeMRCClient{}
+	acknowledger := &fakeTrustBundleAcknowledger{}
+	m := &Manager{mrcClient: mrcClient}
+
+	assert.NoError(m.handleMRCEvent(mrcClient, MRCEvent{Type: MRCEventAdded, MRC: newMRC("old-mrc", constants.MRCStateActive)}))
+	assert.NoError(m.handleMRCEvent(mrcClient, MRCEvent{Type: MRCEventAdded, MRC: newMRC("new-mrc", "")}))
+
+	getMRC := func(name string) *v1alpha2.MeshRootCertificate {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		return m.mrcIssuers[name].mrc
+	}
+	assertStage := func(oldState, newState, signingID, validatingID string) {
+		assert.Equal(oldState, getMRC("old-mrc").Status.State)
+		assert.Equal(newState, getMRC("new-mrc").Status.State)
+		assert.Equal(signingID, m.signingIssuer.ID)
+		assert.Equal(validatingID, m.validatingIssuer.ID)
+	}
+	assertCondition := func(name, conditionType string, status v1.ConditionStatus, reason string) {
+		condition := meta.FindStatusCondition(getMRC(name).Status.Conditions, conditionType)
+		if assert.NotNil(condition) {
+			assert.Equal(status, condition.Status)
+			assert.Equal(reason, condition.Reason)
+		}
+	}
+
+	// Rotations are not driven without an acknowledger
+	m.advanceRootRotation()
+	assertStage(constants.MRCStateActive, "", "old-mrc", "old-mrc")
+
+	m.SetTrustBundleAcknowledger(acknowledger)
+
+	// The new root is added to the trust bundle
+	m.advanceRootRotation()
+	assertStage(constants.MRCStateActive, constants.MRCStateValidatingRollout, "old-mrc", "new-mrc")
+	assertCondition("new-mrc", constants.MRCConditionTypeTrusted, v1.ConditionTrue, constants.MRCConditionReasonTrustBundleRollout)
+	assertCondition("new-mrc", constants.MRCConditionTypeIssuing, v1.ConditionFalse, constants.MRCConditionReasonTrustBundleRollout)
+
+	// The stage does not advance while proxies have not applied the new trust bundle
+	acknowledger.pending = 2
+	m.advanceRootRotation()
+	assertStage(constants.MRCStateActive, constants.MRCStateValidatingRollout, "old-mrc", "new-mrc")
+	assertCondition("new-mrc", constants.MRCConditionTypeAcknowledged, v1.ConditionFalse, constants.MRCConditionReasonProxiesPending)
+	assert.Contains(meta.FindStatusCondition(getMRC("new-mrc").Status.Conditions, constants.MRCConditionTypeAcknowledged).Message, "2 connected proxies")
+
+	// Certificates are issued from the new root
+	acknowledger.pending = 0
+	m.advanceRootRotation()
+	assertStage(constants.MRCStateActive, constants.MRCStateIssuingRollout, "new-mrc", "old-mrc")
+	assertCondition("new-mrc", constants.MRCConditionTypeIssuing, v1.ConditionTrue, constants.MRCConditionReasonIssuerRollout)
+	assertCondition("old-mrc", constants.MRCConditionTypeTrusted, v1.ConditionTrue, constants.MRCConditionReasonIssuerRollout)
+	assertCondition("old-mrc", constants.MRCConditionTypeIssuing, v1.ConditionFalse, constants.MRCConditionReasonIssuerRollout)
+
+	// The old root is removed
+	m.advanceRootRotation()
+	assertStage(constants.MRCStateInactive, constants.MRCStateActive, "new-mrc", "new-mrc")
+	assertCondition("old-mrc", constants.MRCConditionTypeTrusted, v1.ConditionFalse, constants.MRCConditionReasonRootRemoval)
+	assertCondition("new-mrc", constants.MRCConditionTypeIssuing, v1.ConditionTrue, constants.MRCConditionReasonActive)
+	assert.Nil(meta.FindStatusCondition(getMRC("old-mrc").Status.Conditions, constants.MRCConditionTypeAcknowledged))
+
+	// Proxies acknowledge the certificates issued from the new root
+	m.advanceRootRotation()
+	assertCondition("new-mrc", constants.MRCConditionTypeAcknowledged, v1.ConditionTrue, constants.MRCConditionReasonProxiesAcknowledged)
+}
+
+func TestAdvanceRootRotationWithoutActiveMRC(t *testing.T) {
+	assert := tassert.New(t)
+
+	mrcClient := &fakeMRCClient{}
+	m := &Manager{mrcClient: mrcClient}
+	m.SetTrustBundleAcknowledger(&fakeTrustBundleAcknowledger{})
+
+	assert.NoError(m.handleMRCEvent(mrcClient, MRCEvent{Type: MRCEventAdded, MRC: &v1alpha2.MeshRootCertificate{
+		ObjectMeta: v1.ObjectMeta{Name: "my-mrc"},
+	}}))
+	assert.Equal("my-mrc", m.signingIssuer.ID)
+
+	m.advanceRootRotation()
+	assert.Equal(constants.MRCStateActive, m.mrcIssuers["my-mrc"].mrc.Status.State)
+	assert.Equal("my-mrc", m.signingIssuer.ID)
+	assert.Equal("my-mrc", m.validatingIssuer.ID)
+}
+
+type fakeAggregatingMRCClient struct {
+	fakeMRCClient
+	otherReplicasPending int
+}
+
+func (c *fakeAggregatingMRCClient) AggregateUnacknowledgedProxies(_, _ string, pending int) (int, error) {
+	return pending + c.otherReplicasPending, nil
+}
+
+func TestAdvanceRootRotationAcrossReplicas(t *testing.T) {
+	assert := tassert.New(t)
+
+	mrcClient := &fakeAggregatingMRCClient{}
+	m := &Manager{mrcClient: mrcClient}
+	m.SetTrustBundleAcknowledger(&fakeTrustBundleAcknowledger{})
+
+	assert.NoError(m.handleMRCEvent(mrcClient, MRCEvent{Type: MRCEventAdded, MRC: &v1alpha2.MeshRootCertificate{
+		ObjectMeta: v1.ObjectMeta{Name: "old-mrc"},
+		Status:     v1alpha2.MeshRootCertificateStatus{State: constants.MRCStateActive},
+	}}))
+	assert.NoError(m.handleMRCEvent(mrcClient, MRCEvent{Type: MRCEventAdded, MRC: &v1alpha2.MeshRootCertificate{
+		ObjectMeta: v1.ObjectMeta{Name: "new-mrc"},
+	}}))
+
+	m.advanceRootRotation()
+	assert.Equal(constants.MRCStateValidatingRollout, m.mrcIssuers["new-mrc"].mrc.Status.State)
+
+	// The stage does not advance while the proxies of another replica have not applied the new trust bundle
+	mrcClient.otherReplicasPending = 3
+	m.advanceRootRotation()
+	assert.Equal(constants.MRCStateValidatingRollout, m.mrcIssuers["new-mrc"].mrc.Status.State)
+	assert.Contains(meta.FindStatusCondition(m.mrcIssuers["new-mrc"].mrc.Status.Conditions, constants.MRCConditionTypeAcknowledged).Message, "3 connected proxies")
+
+	mrcClient.otherReplicasPending = 0
+	m.advanceRootRotation()
+	assert.Equal(constants.MRCStateIssuingRollout, m.mrcIssuers["new-mrc"].mrc.Status.State)
+}
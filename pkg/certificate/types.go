@@ -4,6 +4,7 @@ package certificate
 
 import (
 	"context"
+	"net/url"
 	"sync"
 	"time"
 
@@ -63,6 +64,9 @@ type Certificate struct {
 	// The SubjectAlternateNames of the certificate
 	SANames []string
 
+	// The URI SubjectAlternateNames of the certificate, such as the SPIFFE ID of a workload
+	URISANames []string
+
 	// The serial number of the certificate
 	SerialNumber SerialNumber
 
@@ -84,19 +88,35 @@ type Certificate struct {
 	signingIssuerID    string
 	validatingIssuerID string
 
+	// the UUID of the proxy which requested the certificate, if any
+	proxyUUID string
+
 	certType CertType
 }
 
 // Issuer is the interface for a certificate authority that can issue certificates from a given root certificate.
 type Issuer interface {
-	// IssueCertificate issues a new certificate.
-	IssueCertificate(CommonName, []string, time.Duration) (*Certificate, error)
+	// IssueCertificate issues a new certificate with the given DNS and URI subject alternative names.
+	IssueCertificate(CommonName, []string, []*url.URL, time.Duration) (*Certificate, error)
+}
+
+// ReleasableIssuer is an Issuer holding resources, such as background routines, which must be released once the
+// Issuer is no longer used. The Manager releases the issuers generated from MRCs once they are replaced or dropped.
+type ReleasableIssuer interface {
+	Issuer
+
+	// Release releases the resources held by the Issuer. It is safe to call Release more than once.
+	Release()
 }
 
 type issuer struct {
 	Issuer
 	ID          string
 	TrustDomain string
+	// SpiffeEnabled is set when service certificates issued by this issuer carry the SPIFFE ID of the workload
+	SpiffeEnabled bool
+	// Provider is the kind of certificate provider backing this issuer
+	Provider string
 	// memoized once the first certificate is issued
 	CertificateAuthority pem.RootCertificate
 }
@@ -112,14 +132,46 @@ type Manager struct {
 	serviceCertValidityDuration func() time.Duration
 	msgBroker                   *messaging.Broker
 
+	mrcClient MRCClient
+
 	mu            sync.Mutex // mu syncrhonizes acces to the below resources.
 	signingIssuer *issuer
 	// equal to signingIssuer if there is no additional public cert issuer.
 	validatingIssuer *issuer
+	// the MRCs observed and the issuers generated from them, keyed by MRC name
+	mrcIssuers map[string]*mrcIssuer
+	// set when the manager drives the stages of root certificate rotations
+	trustBundleAcknowledger TrustBundleAcknowledger
+
+	auditMu    sync.Mutex // auditMu synchronizes access to auditSinks
+	auditSinks []AuditSink
 
 	group singleflight.Group
 }
 
+// mrcIssuer is an MRC observed by the Manager along with the issuer generated from it.
+type mrcIssuer struct {
+	mrc    *v1alpha2.MeshRootCertificate
+	issuer *issuer
+}
+
+// TrustBundleAcknowledger reports whether the proxies connected to the control plane have applied the
+// certificates issued for the current stage of a root certificate rotation. It is typically backed by a proxy registry.
+type TrustBundleAcknowledger interface {
+	// CountUnacknowledgedProxies returns the number of connected proxies that have not applied a certificate
+	// signed by the given signing issuer and carrying the trust bundle of the given validating issuer.
+	CountUnacknowledgedProxies(signingIssuerID, validatingIssuerID string) int
+}
+
+// AcknowledgementAggregator aggregates the acknowledgements of the proxies connected to the replicas of the control
+// plane. When the MRCClient of a Manager implements it, the stages of a root certificate rotation only advance once
+// the proxies connected to every replica have applied the certificates issued for the current stage.
+type AcknowledgementAggregator interface {
+	// AggregateUnacknowledgedProxies records the number of unacknowledged proxies connected to this replica for the given
+	// issuers, and returns the number of unacknowledged proxies connected to all the live replicas.
+	AggregateUnacknowledgedProxies(signingIssuerID, validatingIssuerID string, pending int) (int, error)
+}
+
 // MRCClient is an interface that can watch for changes to the MRC. It is typically backed by a k8s informer.
 type MRCClient interface {
 	List() ([]*v1alpha2.MeshRootCertificate, error)
@@ -127,6 +179,9 @@ type MRCClient interface {
 
 	// GetCertIssuerForMRC returns an Issuer based on the provided MRC.
 	GetCertIssuerForMRC(mrc *v1alpha2.MeshRootCertificate) (Issuer, pem.RootCertificate, error)
+
+	// UpdateMRCStatus updates the status of the provided MRC and returns the updated MRC.
+	UpdateMRCStatus(mrc *v1alpha2.MeshRootCertificate) (*v1alpha2.MeshRootCertificate, error)
 }
 
 // MRCEventType is a type alias for a string describing the type of MRC event
@@ -145,6 +200,10 @@ var (
 
 	// MRCEventUpdated is the type of announcement emitted when we observe an update to a Kubernetes MeshRootCertificate
 	MRCEventUpdated MRCEventType = "meshrootcertificate-updated"
+
+	// MRCEventSecretUpdated is the type of announcement emitted when we observe an update to the Kubernetes Secret
+	// holding the CA of a MeshRootCertificate, such as the intermediate CA of an external CA provider
+	MRCEventSecretUpdated MRCEventType = "meshrootcertificate-secret-updated"
 )
 
 // MRCEventBroker describes any type that allows the caller to Watch() MRCEvents
@@ -0,0 +1,196 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/k8s"
+)
+
+// InterceptorMaps is the list of the eBPF maps of osm-interceptor which can be inspected
+var InterceptorMaps = []string{"pod_fib", "nat_fib", "cgr_fib", "mark_fib"}
+
+// InterceptorTraceEvent is a redirect decision of the eBPF programs of osm-interceptor
+type InterceptorTraceEvent struct {
+	SrcIP        string `json:"srcIP,omitempty"`
+	DstIP        string `json:"dstIP"`
+	DstPort      uint16 `json:"dstPort"`
+	RedirectPort uint16 `json:"redirectPort,omitempty"`
+	PID          uint32 `json:"pid"`
+	Decision     string `json:"decision"`
+	Reason       string `json:"reason,omitempty"`
+	Lost         uint64 `json:"lost,omitempty"`
+}
+
+// GetInterceptorPods returns the running osm-interceptor pods sorted by node, only the pod on the given node if
+// node is not empty
+func GetInterceptorPods(clientSet kubernetes.Interface, osmNamespace string, node string) ([]corev1.Pod, error) {
+	pods, err := clientSet.CoreV1().Pods(osmNamespace).List(context.TODO(), metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", constants.AppLabel, constants.OSMInterceptorName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Error listing %s pods in namespace %s: %w", constants.OSMInterceptorName, osmNamespace, err)
+	}
+
+	var running []corev1.Pod
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != corev1.PodRunning || (node != "" && pod.Spec.NodeName != node) {
+			continue
+		}
+		running = append(running, pod)
+	}
+	if len(running) == 0 {
+		if node != "" {
+			return nil, fmt.Errorf("No running %s pod found on node %s in namespace %s", constants.OSMInterceptorName, node, osmNamespace)
+		}
+		return nil, fmt.Errorf("No running %s pod found in namespace %s", constants.OSMInterceptorName, osmNamespace)
+	}
+	sort.Slice(running, func(i, j int) bool {
+		return running[i].Spec.NodeName < running[j].Spec.NodeName
+	})
+	return running, nil
+}
+
+// GetInterceptorMap returns the entries of an eBPF map of the given osm-interceptor pod
+func GetInterceptorMap(clientSet kubernetes.Interface, config *rest.Config, osmNamespace string, podName string, localPort uint16, mapName string) ([]interface{}, error) {
+	if !IsInterceptorMap(mapName) {
+		return nil, fmt.Errorf("Invalid map %s, must be one of %s", mapName, strings.Join(InterceptorMaps, ", "))
+	}
+	responses, err := getFromPod(clientSet, config, osmNamespace, podName, localPort, constants.InterceptorDebugPort, true, "debug/maps/"+mapName)
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving map %s from pod %s in namespace %s: %w", mapName, podName, osmNamespace, err)
+	}
+
+	var entries []interface{}
+	if err := json.Unmarshal(responses[0], &entries); err != nil {
+		return nil, fmt.Errorf("Error parsing map %s from pod %s in namespace %s: %w", mapName, podName, osmNamespace, err)
+	}
+	if entries == nil {
+		entries = []interface{}{}
+	}
+	return entries, nil
+}
+
+// IsInterceptorMap returns true if the given map of osm-interceptor can be inspected
+func IsInterceptorMap(mapName string) bool {
+	for _, m := range InterceptorMaps {
+		if m == mapName {
+			return true
+		}
+	}
+	return false
+}
+
+// StreamInterceptorTrace port forwards the given local port to the debug server of the given osm-interceptor pod
+// and calls handle for each redirect decision until the port forwarding is interrupted. The decisions are filtered
+// by the ip addresses of a meshed pod if pod is not empty, in the <namespace>/<name> format, and by an ip address if
+// ip is not empty.
+func StreamInterceptorTrace(clientSet kubernetes.Interface, config *rest.Config, osmNamespace string, podName string, localPort uint16, pod string, ip string, handle func(InterceptorTraceEvent) error) error {
+	dialer, err := k8s.DialerToPod(config, clientSet, podName, osmNamespace)
+	if err != nil {
+		return err
+	}
+
+	portForwarder, err := k8s.NewPortForwarder(dialer, fmt.Sprintf("%d:%d", localPort, constants.InterceptorDebugPort))
+	if err != nil {
+		return fmt.Errorf("Error setting up port forwarding: %w", err)
+	}
+
+	query := url.Values{}
+	if pod != "" {
+		query.Set("pod", pod)
+	}
+	if ip != "" {
+		query.Set("ip", ip)
+	}
+	traceURL := fmt.Sprintf("http://localhost:%d/debug/trace?%s", localPort, query.Encode())
+
+	return portForwarder.Start(func(pf *k8s.PortForwarder) error {
+		// the port forwarding is stopped on interrupt, which ends the stream
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go func() {
+			select {
+			case <-pf.Done():
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+
+		err := streamTrace(ctx, traceURL, handle)
+
+		select {
+		case <-pf.Done():
+			return nil
+		default:
+			pf.Stop()
+		}
+		return err
+	})
+}
+
+func streamTrace(ctx context.Context, traceURL string, handle func(InterceptorTraceEvent) error) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, traceURL, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("Error fetching url %s: %w", traceURL, err)
+	}
+	//nolint: errcheck
+	//#nosec G307
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("Error fetching url %s: HTTP status %d: %s", traceURL, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	dec := json.NewDecoder(resp.Body)
+	for {
+		var ev InterceptorTraceEvent
+		if err := dec.Decode(&ev); err != nil {
+			if ctx.Err() != nil || errors.Is(err, context.Canceled) {
+				return nil
+			}
+			return fmt.Errorf("Error reading the redirect decisions: %w", err)
+		}
+		if err := handle(ev); err != nil {
+			return err
+		}
+	}
+}
+
+// FormatInterceptorTraceEvent renders a redirect decision on a single line
+func FormatInterceptorTraceEvent(node string, ev InterceptorTraceEvent) string {
+	if ev.Lost > 0 {
+		return fmt.Sprintf("[%s] lost %d redirect decisions", node, ev.Lost)
+	}
+	src := ev.SrcIP
+	if src == "" {
+		src = "?"
+	}
+	line := fmt.Sprintf("[%s] %-18s pid=%d %s -> %s", node, ev.Decision, ev.PID, src, net.JoinHostPort(ev.DstIP, strconv.Itoa(int(ev.DstPort))))
+	if ev.RedirectPort != 0 && ev.RedirectPort != ev.DstPort {
+		line += fmt.Sprintf(" redirected to port %d", ev.RedirectPort)
+	}
+	if ev.Reason != "" {
+		line += fmt.Sprintf(" (%s)", ev.Reason)
+	}
+	return line
+}
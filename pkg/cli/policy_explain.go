@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+// trafficExplanationPath is the path of the debug endpoint of the controller explaining traffic between two pods
+const trafficExplanationPath = "debug/policy/explain"
+
+// GetTrafficExplanation returns the explanation of how the policies of the mesh apply to traffic from the source pod to
+// the destination pod, both in the namespace/name format, on the given port and for the given HTTP method and path when
+// they are not empty. The explanation is computed by the debug server of the osm-controller pod in the given namespace,
+// reached through the given controller local port.
+func GetTrafficExplanation(clientSet kubernetes.Interface, config *rest.Config, osmNamespace string, source string, destination string,
+	port uint16, method string, path string, controllerLocalPort uint16) (*trafficpolicy.TrafficExplanation, error) {
+	controllerPod, err := getRunningControllerPod(clientSet, osmNamespace)
+	if err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	query.Set("source", source)
+	query.Set("destination", destination)
+	query.Set("port", strconv.Itoa(int(port)))
+	if method != "" {
+		query.Set("method", method)
+	}
+	if path != "" {
+		query.Set("path", path)
+	}
+
+	responses, err := getFromPod(clientSet, config, osmNamespace, controllerPod.Name, controllerLocalPort, constants.DebugPort, true,
+		fmt.Sprintf("%s?%s", trafficExplanationPath, query.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving the explanation of the traffic from the controller, make sure the debug server is enabled: %w", err)
+	}
+
+	explanation := &trafficpolicy.TrafficExplanation{}
+	if err := json.Unmarshal(responses[0], explanation); err != nil {
+		return nil, fmt.Errorf("Error parsing the explanation of the traffic: %w", err)
+	}
+	return explanation, nil
+}
@@ -0,0 +1,36 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+// policyPreviewPath is the path of the debug endpoint of the controller previewing the impact of a policy
+const policyPreviewPath = "debug/policy/preview"
+
+// GetPolicyPreview returns the impact of applying the given JSON encoded policy on the config of the proxies of the
+// mesh, without applying it. The preview is computed by the debug server of the osm-controller pod in the given
+// namespace, reached through the given controller local port.
+func GetPolicyPreview(clientSet kubernetes.Interface, config *rest.Config, osmNamespace string, policyJSON []byte, controllerLocalPort uint16) (*trafficpolicy.PolicyPreview, error) {
+	controllerPod, err := getRunningControllerPod(clientSet, osmNamespace)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := postToPod(clientSet, config, osmNamespace, controllerPod.Name, controllerLocalPort, constants.DebugPort, policyPreviewPath, policyJSON)
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving the preview of the policy from the controller, make sure the debug server is enabled: %w", err)
+	}
+
+	preview := &trafficpolicy.PolicyPreview{}
+	if err := json.Unmarshal(response, preview); err != nil {
+		return nil, fmt.Errorf("Error parsing the preview of the policy: %w", err)
+	}
+	return preview, nil
+}
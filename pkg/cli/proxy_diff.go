@@ -0,0 +1,289 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+// ResourceDiffStatus is the status of a resource which differs between the config computed by the controller for a
+// proxy and the config the proxy runs
+type ResourceDiffStatus string
+
+const (
+	// ResourceMissing is the status of a resource computed by the controller which the proxy does not run
+	ResourceMissing ResourceDiffStatus = "missing"
+
+	// ResourceUnexpected is the status of a resource the proxy runs which the controller does not compute
+	ResourceUnexpected ResourceDiffStatus = "unexpected"
+
+	// ResourceChanged is the status of a resource whose config differs
+	ResourceChanged ResourceDiffStatus = "changed"
+
+	// intendedConfigPath is the path of the debug endpoint of the controller returning the config it computes for a proxy
+	intendedConfigPath = "debug/proxy/config"
+
+	// pipyOtherConfigKind is the kind of the fields of a PipyConf which are not part of a resource
+	pipyOtherConfigKind = "config"
+)
+
+// ResourceDiff is a resource which differs between the config computed by the controller for a proxy and the config
+// the proxy runs
+type ResourceDiff struct {
+	// Kind is the kind of the resource, such as listener or cluster for Envoy, or outboundTrafficMatch for Pipy
+	Kind string `json:"kind"`
+
+	// Name is the name of the resource
+	Name string `json:"name"`
+
+	// Status is the status of the resource
+	Status ResourceDiffStatus `json:"status"`
+
+	// Fields are the differing fields of a changed resource
+	Fields []ConfigDifference `json:"fields,omitempty"`
+}
+
+// envoyResourceKinds are the kinds of resources of the config of Envoy sidecars, by key in the intended config
+var envoyResourceKinds = map[string]string{
+	"clusters":  "cluster",
+	"endpoints": "endpoints",
+	"listeners": "listener",
+	"routes":    "route",
+}
+
+// envoyConfigDumpResources describes where to find the resources of each kind in an Envoy config dump
+var envoyConfigDumpResources = map[string]struct {
+	dumpType string
+	list     string
+	path     []string
+	name     string
+}{
+	"cluster":   {dumpType: "ClustersConfigDump", list: "dynamic_active_clusters", path: []string{"cluster"}, name: "name"},
+	"endpoints": {dumpType: "EndpointsConfigDump", list: "dynamic_endpoint_configs", path: []string{"endpoint_config"}, name: "cluster_name"},
+	"listener":  {dumpType: "ListenersConfigDump", list: "dynamic_listeners", path: []string{"active_state", "listener"}, name: "name"},
+	"route":     {dumpType: "RoutesConfigDump", list: "dynamic_route_configs", path: []string{"route_config"}, name: "name"},
+}
+
+// pipyResourceSections are the sections of a PipyConf holding resources keyed by name, by kind of resource
+var pipyResourceSections = map[string][]string{
+	"inboundTrafficMatch":  {"Inbound", "TrafficMatches"},
+	"inboundCluster":       {"Inbound", "ClustersConfigs"},
+	"outboundTrafficMatch": {"Outbound", "TrafficMatches"},
+	"outboundCluster":      {"Outbound", "ClustersConfigs"},
+	"forwardTrafficMatch":  {"Forward", "ForwardMatches"},
+	"egressGateway":        {"Forward", "EgressGateways"},
+	"pluginChain":          {"Chains"},
+}
+
+// pipyConfigMetadata are the fields of a PipyConf set when it is published, which are not compared
+var pipyConfigMetadata = []string{"Ts", "Version"}
+
+// GetProxyConfigDiff returns the resources which differ between the config the controller computes for the sidecar
+// of a pod and the config the sidecar runs. The config computed by the controller is fetched from the debug server of
+// the osm-controller pod in the given namespace through the given controller local port.
+func GetProxyConfigDiff(clientSet kubernetes.Interface, config *rest.Config, osmNamespace string, namespace string, podName string, localPort uint16, controllerLocalPort uint16) ([]ResourceDiff, error) {
+	pod, err := getMeshedPod(clientSet, namespace, podName)
+	if err != nil {
+		return nil, err
+	}
+	proxyUUID := pod.Labels[constants.SidecarUniqueIDLabelName]
+
+	controllerPod, err := getRunningControllerPod(clientSet, osmNamespace)
+	if err != nil {
+		return nil, err
+	}
+	responses, err := getFromPod(clientSet, config, osmNamespace, controllerPod.Name, controllerLocalPort, constants.DebugPort, true,
+		fmt.Sprintf("%s?uuid=%s", intendedConfigPath, proxyUUID))
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving the config computed by the controller for pod %s in namespace %s, make sure the debug server is enabled: %w", podName, namespace, err)
+	}
+	intended := responses[0]
+
+	if GetSidecarClass(pod) == constants.SidecarClassPipy {
+		state, err := GetPipySidecarState(clientSet, config, namespace, podName, localPort, PipyQueryConfig)
+		if err != nil {
+			return nil, err
+		}
+		controllerConfig, err := parsePipyConfig(intended)
+		if err != nil {
+			return nil, fmt.Errorf("Error parsing the config computed by the controller for pod %s in namespace %s: %w", podName, namespace, err)
+		}
+		return DiffPipyResources(controllerConfig, state.Config), nil
+	}
+
+	responses, err = getFromPod(clientSet, config, namespace, podName, localPort, constants.SidecarAdminPort, true, "config_dump?include_eds")
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving proxy config for pod %s in namespace %s: %w", podName, namespace, err)
+	}
+	return DiffEnvoyResources(intended, responses[0])
+}
+
+// DiffEnvoyResources returns the resources which differ between the xDS resources computed by the controller for an
+// Envoy sidecar and the dynamic resources of the config dump of the sidecar
+func DiffEnvoyResources(intendedJSON []byte, configDumpJSON []byte) ([]ResourceDiff, error) {
+	var intended map[string][]map[string]interface{}
+	if err := json.Unmarshal(intendedJSON, &intended); err != nil {
+		return nil, fmt.Errorf("Error parsing the config computed by the controller: %w", err)
+	}
+	var configDump struct {
+		Configs []map[string]interface{} `json:"configs"`
+	}
+	if err := json.Unmarshal(configDumpJSON, &configDump); err != nil {
+		return nil, fmt.Errorf("Error parsing the config dump of the sidecar: %w", err)
+	}
+
+	var diffs []ResourceDiff
+	for key, kind := range envoyResourceKinds {
+		location := envoyConfigDumpResources[kind]
+
+		intendedResources := make(map[string]interface{})
+		for _, resource := range intended[key] {
+			if name, ok := resource[location.name].(string); ok {
+				intendedResources[name] = resource
+			}
+		}
+
+		liveResources := make(map[string]interface{})
+		for _, dump := range configDump.Configs {
+			if dumpType, _ := dump["@type"].(string); !strings.HasSuffix(dumpType, "."+location.dumpType) {
+				continue
+			}
+			entries, _ := dump[location.list].([]interface{})
+			for _, entry := range entries {
+				resource, ok := lookup(entry, location.path...).(map[string]interface{})
+				if !ok {
+					continue
+				}
+				// Resources are dumped as Any messages
+				delete(resource, "@type")
+				if name, ok := resource[location.name].(string); ok {
+					liveResources[name] = resource
+				}
+			}
+		}
+
+		diffs = append(diffs, diffResources(kind, intendedResources, liveResources)...)
+	}
+
+	sortResourceDiffs(diffs)
+	return diffs, nil
+}
+
+// DiffPipyResources returns the resources which differ between the PipyConf computed by the controller for a Pipy
+// sidecar and the PipyConf the sidecar runs. Fields which are not part of a resource are reported as a single
+// resource of kind config.
+func DiffPipyResources(intended, live map[string]interface{}) []ResourceDiff {
+	intended, live = copyJSONObject(intended), copyJSONObject(live)
+	for _, field := range pipyConfigMetadata {
+		delete(intended, field)
+		delete(live, field)
+	}
+
+	var diffs []ResourceDiff
+	for kind, path := range pipyResourceSections {
+		intendedResources, _ := remove(intended, path...).(map[string]interface{})
+		liveResources, _ := remove(live, path...).(map[string]interface{})
+		diffs = append(diffs, diffResources(kind, intendedResources, liveResources)...)
+	}
+	if fields := DiffPipyConfig(live, intended); len(fields) > 0 {
+		diffs = append(diffs, ResourceDiff{Kind: pipyOtherConfigKind, Status: ResourceChanged, Fields: fields})
+	}
+
+	sortResourceDiffs(diffs)
+	return diffs
+}
+
+// diffResources returns the differences between resources of the given kind keyed by name
+func diffResources(kind string, intended, live map[string]interface{}) []ResourceDiff {
+	var diffs []ResourceDiff
+	for name, resource := range intended {
+		liveResource, ok := live[name]
+		if !ok {
+			diffs = append(diffs, ResourceDiff{Kind: kind, Name: name, Status: ResourceMissing})
+			continue
+		}
+		var fields []ConfigDifference
+		diffValues("", liveResource, resource, &fields)
+		if len(fields) > 0 {
+			sort.Slice(fields, func(i, j int) bool {
+				return fields[i].Path < fields[j].Path
+			})
+			diffs = append(diffs, ResourceDiff{Kind: kind, Name: name, Status: ResourceChanged, Fields: fields})
+		}
+	}
+	for name := range live {
+		if _, ok := intended[name]; !ok {
+			diffs = append(diffs, ResourceDiff{Kind: kind, Name: name, Status: ResourceUnexpected})
+		}
+	}
+	return diffs
+}
+
+func sortResourceDiffs(diffs []ResourceDiff) {
+	sort.Slice(diffs, func(i, j int) bool {
+		if diffs[i].Kind != diffs[j].Kind {
+			return diffs[i].Kind < diffs[j].Kind
+		}
+		return diffs[i].Name < diffs[j].Name
+	})
+}
+
+// lookup returns the value at the given path of nested JSON objects, or nil if there is none
+func lookup(value interface{}, path ...string) interface{} {
+	for _, key := range path {
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		value = obj[key]
+	}
+	return value
+}
+
+// remove removes and returns the value at the given path of nested JSON objects, or nil if there is none.
+// Objects left empty by the removal are removed too.
+func remove(obj map[string]interface{}, path ...string) interface{} {
+	if obj == nil || len(path) == 0 {
+		return nil
+	}
+	if len(path) == 1 {
+		value := obj[path[0]]
+		delete(obj, path[0])
+		return value
+	}
+
+	child, ok := obj[path[0]].(map[string]interface{})
+	if !ok {
+		if obj[path[0]] == nil {
+			// A null section holds no resources either
+			delete(obj, path[0])
+		}
+		return nil
+	}
+	value := remove(child, path[1:]...)
+	if len(child) == 0 {
+		delete(obj, path[0])
+	}
+	return value
+}
+
+// copyJSONObject returns a copy of the given decoded JSON object in which nested objects are copied as well
+func copyJSONObject(obj map[string]interface{}) map[string]interface{} {
+	if obj == nil {
+		return nil
+	}
+	objCopy := make(map[string]interface{}, len(obj))
+	for key, value := range obj {
+		if nested, ok := value.(map[string]interface{}); ok {
+			value = copyJSONObject(nested)
+		}
+		objCopy[key] = value
+	}
+	return objCopy
+}
@@ -0,0 +1,137 @@
+package cli
+
+import (
+	"testing"
+
+	tassert "github.com/stretchr/testify/assert"
+)
+
+func TestDiffEnvoyResources(t *testing.T) {
+	assert := tassert.New(t)
+
+	intended := []byte(`{
+ "clusters": [
+  {"name": "bookstore/bookstore|14001", "connect_timeout": "1s"},
+  {"name": "bookstore/bookstore-v2|14001", "connect_timeout": "1s"}
+ ],
+ "endpoints": [
+  {"cluster_name": "bookstore/bookstore|14001", "endpoints": [{"lb_endpoints": [{"endpoint": {"address": {"socket_address": {"address": "10.0.0.1"}}}}]}]}
+ ],
+ "listeners": [
+  {"name": "outbound-listener", "address": {"socket_address": {"port_value": 15001}}}
+ ],
+ "routes": []
+}`)
+	configDump := []byte(`{
+ "configs": [
+  {
+   "@type": "type.googleapis.com/envoy.admin.v3.ClustersConfigDump",
+   "static_clusters": [{"cluster": {"@type": "type.googleapis.com/envoy.config.cluster.v3.Cluster", "name": "osm-controller"}}],
+   "dynamic_active_clusters": [
+    {"version_info": "2", "cluster": {"@type": "type.googleapis.com/envoy.config.cluster.v3.Cluster", "name": "bookstore/bookstore|14001", "connect_timeout": "5s"}},
+    {"version_info": "2", "cluster": {"@type": "type.googleapis.com/envoy.config.cluster.v3.Cluster", "name": "bookstore/bookstore-v1|14001", "connect_timeout": "1s"}}
+   ]
+  },
+  {
+   "@type": "type.googleapis.com/envoy.admin.v3.ListenersConfigDump",
+   "dynamic_listeners": [
+    {"name": "outbound-listener", "active_state": {"listener": {"@type": "type.googleapis.com/envoy.config.listener.v3.Listener", "name": "outbound-listener", "address": {"socket_address": {"port_value": 15001}}}}}
+   ]
+  },
+  {
+   "@type": "type.googleapis.com/envoy.admin.v3.EndpointsConfigDump",
+   "dynamic_endpoint_configs": [
+    {"endpoint_config": {"@type": "type.googleapis.com/envoy.config.endpoint.v3.ClusterLoadAssignment", "cluster_name": "bookstore/bookstore|14001", "endpoints": [{"lb_endpoints": [{"endpoint": {"address": {"socket_address": {"address": "10.0.0.2"}}}}]}]}}
+   ]
+  }
+ ]
+}`)
+
+	diffs, err := DiffEnvoyResources(intended, configDump)
+	assert.NoError(err)
+	assert.Equal([]ResourceDiff{
+		{
+			Kind:   "cluster",
+			Name:   "bookstore/bookstore-v1|14001",
+			Status: ResourceUnexpected,
+		},
+		{
+			Kind:   "cluster",
+			Name:   "bookstore/bookstore-v2|14001",
+			Status: ResourceMissing,
+		},
+		{
+			Kind:   "cluster",
+			Name:   "bookstore/bookstore|14001",
+			Status: ResourceChanged,
+			Fields: []ConfigDifference{{Path: "connect_timeout", Sidecar: "5s", Controller: "1s"}},
+		},
+		{
+			Kind:   "endpoints",
+			Name:   "bookstore/bookstore|14001",
+			Status: ResourceChanged,
+			Fields: []ConfigDifference{{Path: "endpoints[0].lb_endpoints[0].endpoint.address.socket_address.address", Sidecar: "10.0.0.2", Controller: "10.0.0.1"}},
+		},
+	}, diffs)
+
+	_, err = DiffEnvoyResources([]byte("Not Found"), configDump)
+	assert.Error(err)
+}
+
+func TestDiffPipyResources(t *testing.T) {
+	assert := tassert.New(t)
+
+	intended, err := parsePipyConfig([]byte(`{
+ "Spec": {"SidecarLogLevel": "error"},
+ "Certificate": {"CommonName": "bookbuyer", "PrivateKey": ""},
+ "Inbound": null,
+ "Outbound": {
+  "TrafficMatches": {"14001": [{"Port": 14001, "Protocol": "http"}], "14002": [{"Port": 14002, "Protocol": "tcp"}]},
+  "ClustersConfigs": {"bookstore/bookstore|14001": {"Endpoints": {"10.0.0.1:14001": {"Weight": 100}}}}
+ },
+ "Chains": {"outbound-http": ["modules/outbound-http-routing.js"]}
+}`))
+	assert.NoError(err)
+	live, err := parsePipyConfig([]byte(`{
+ "Ts": "2022-11-09T08:00:00Z",
+ "Version": "8712934",
+ "Spec": {"SidecarLogLevel": "debug"},
+ "Certificate": {"CommonName": "bookbuyer", "PrivateKey": "key"},
+ "Outbound": {
+  "TrafficMatches": {"14001": [{"Port": 14001, "Protocol": "tcp"}]},
+  "ClustersConfigs": {"bookstore/bookstore|14001": {"Endpoints": {"10.0.0.1:14001": {"Weight": 100}}}, "bookstore/bookstore-v1|14001": {}}
+ },
+ "Chains": {"outbound-http": ["modules/outbound-http-routing.js"]}
+}`))
+	assert.NoError(err)
+
+	assert.Equal([]ResourceDiff{
+		{
+			Kind:   "config",
+			Status: ResourceChanged,
+			Fields: []ConfigDifference{{Path: "Spec.SidecarLogLevel", Sidecar: "debug", Controller: "error"}},
+		},
+		{
+			Kind:   "outboundCluster",
+			Name:   "bookstore/bookstore-v1|14001",
+			Status: ResourceUnexpected,
+		},
+		{
+			Kind:   "outboundTrafficMatch",
+			Name:   "14001",
+			Status: ResourceChanged,
+			Fields: []ConfigDifference{{Path: "[0].Protocol", Sidecar: "tcp", Controller: "http"}},
+		},
+		{
+			Kind:   "outboundTrafficMatch",
+			Name:   "14002",
+			Status: ResourceMissing,
+		},
+	}, DiffPipyResources(intended, live))
+
+	// The configs are not modified
+	assert.Contains(live, "Version")
+	assert.Contains(intended, "Outbound")
+
+	assert.Empty(DiffPipyResources(live, live))
+}
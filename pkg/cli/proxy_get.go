@@ -1,10 +1,12 @@
 package cli
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -18,7 +20,20 @@ import (
 
 // GetSidecarProxyConfig returns the sidecar proxy config of a pod
 func GetSidecarProxyConfig(clientSet kubernetes.Interface, config *rest.Config, namespace string, podName string, localPort uint16, query string) ([]byte, error) {
-	// Check if the pod belongs to a mesh
+	if _, err := getMeshedPod(clientSet, namespace, podName); err != nil {
+		return nil, err
+	}
+
+	responses, err := getFromPod(clientSet, config, namespace, podName, localPort, constants.SidecarAdminPort, false, query)
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving proxy config for pod %s in namespace %s: %w", podName, namespace, err)
+	}
+
+	return responses[0], nil
+}
+
+// getMeshedPod returns the given pod if it is a running pod which belongs to a mesh
+func getMeshedPod(clientSet kubernetes.Interface, namespace string, podName string) (*corev1.Pod, error) {
 	pod, err := clientSet.CoreV1().Pods(namespace).Get(context.TODO(), podName, metav1.GetOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("Could not find pod %s in namespace %s", podName, namespace)
@@ -29,40 +44,93 @@ func GetSidecarProxyConfig(clientSet kubernetes.Interface, config *rest.Config,
 	if pod.Status.Phase != corev1.PodRunning {
 		return nil, fmt.Errorf("Pod %s in namespace %s is not running", podName, namespace)
 	}
+	return pod, nil
+}
 
+// getFromPod port forwards the given local port to the given port of a pod and returns the bodies of the
+// responses to HTTP GET requests for each of the given paths. If checkStatus is true, responses whose status
+// is not 200 OK are returned as errors.
+func getFromPod(clientSet kubernetes.Interface, config *rest.Config, namespace string, podName string, localPort uint16, podPort uint16, checkStatus bool, paths ...string) ([][]byte, error) {
 	dialer, err := k8s.DialerToPod(config, clientSet, podName, namespace)
 	if err != nil {
 		return nil, err
 	}
 
-	portForwarder, err := k8s.NewPortForwarder(dialer, fmt.Sprintf("%d:%d", localPort, constants.SidecarAdminPort))
+	portForwarder, err := k8s.NewPortForwarder(dialer, fmt.Sprintf("%d:%d", localPort, podPort))
 	if err != nil {
 		return nil, fmt.Errorf("Error setting up port forwarding: %w", err)
 	}
 
-	var sidecarProxyConfig []byte
+	var responses [][]byte
 	err = portForwarder.Start(func(pf *k8s.PortForwarder) error {
 		defer pf.Stop()
-		url := fmt.Sprintf("http://localhost:%d/%s", localPort, query)
+		for _, path := range paths {
+			url := fmt.Sprintf("http://localhost:%d/%s", localPort, path)
+
+			// #nosec G107: Potential HTTP request made with variable url
+			resp, err := http.Get(url)
+			if err != nil {
+				return fmt.Errorf("Error fetching url %s: %w", url, err)
+			}
+
+			body, err := ioutil.ReadAll(resp.Body)
+			_ = resp.Body.Close()
+			if err != nil {
+				return fmt.Errorf("Error rendering HTTP response: %w", err)
+			}
+			if checkStatus && resp.StatusCode != http.StatusOK {
+				return fmt.Errorf("Error fetching url %s: HTTP status %d", url, resp.StatusCode)
+			}
+			responses = append(responses, body)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return responses, nil
+}
+
+// postToPod port forwards the given local port to the given port of a pod and returns the body of the response to an
+// HTTP POST request of the given JSON body to the given path. Responses whose status is not 200 OK are returned as
+// errors holding their body.
+func postToPod(clientSet kubernetes.Interface, config *rest.Config, namespace string, podName string, localPort uint16, podPort uint16, path string, body []byte) ([]byte, error) {
+	dialer, err := k8s.DialerToPod(config, clientSet, podName, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	portForwarder, err := k8s.NewPortForwarder(dialer, fmt.Sprintf("%d:%d", localPort, podPort))
+	if err != nil {
+		return nil, fmt.Errorf("Error setting up port forwarding: %w", err)
+	}
+
+	var response []byte
+	err = portForwarder.Start(func(pf *k8s.PortForwarder) error {
+		defer pf.Stop()
+		url := fmt.Sprintf("http://localhost:%d/%s", localPort, path)
 
 		// #nosec G107: Potential HTTP request made with variable url
-		resp, err := http.Get(url)
+		resp, err := http.Post(url, "application/json", bytes.NewReader(body))
 		if err != nil {
-			return fmt.Errorf("Error fetching url %s: %w", url, err)
+			return fmt.Errorf("Error posting to url %s: %w", url, err)
 		}
-		//nolint: errcheck
-		//#nosec G307
-		defer resp.Body.Close()
 
-		sidecarProxyConfig, err = ioutil.ReadAll(resp.Body)
+		respBody, err := ioutil.ReadAll(resp.Body)
+		_ = resp.Body.Close()
 		if err != nil {
 			return fmt.Errorf("Error rendering HTTP response: %w", err)
 		}
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("Error posting to url %s: HTTP status %d: %s", url, resp.StatusCode, strings.TrimSpace(string(respBody)))
+		}
+		response = respBody
 		return nil
 	})
 	if err != nil {
-		return nil, fmt.Errorf("Error retrieving proxy config for pod %s in namespace %s: %w", podName, namespace, err)
+		return nil, err
 	}
 
-	return sidecarProxyConfig, nil
+	return response, nil
 }
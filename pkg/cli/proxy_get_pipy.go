@@ -0,0 +1,357 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+const (
+	// PipyQueryConfig is the query for the PipyConf loaded by a Pipy sidecar
+	PipyQueryConfig = "config_dump"
+
+	// PipyQueryPlugins is the query for the plugin chains loaded by a Pipy sidecar
+	PipyQueryPlugins = "plugins"
+
+	// PipyQueryMetrics is the query for the metrics of a Pipy sidecar
+	PipyQueryMetrics = "metrics"
+
+	// PipyQueryVersion is the query for the version of the codebase loaded by a Pipy sidecar
+	PipyQueryVersion = "version"
+
+	// PipyQueryAll is the query for the config, plugins, metrics and version of a Pipy sidecar
+	PipyQueryAll = "all"
+
+	// redactedValue replaces the secrets of a PipyConf
+	redactedValue = "<redacted>"
+)
+
+// pipyQueryPaths are the paths of the sidecar admin API queried for each Pipy query
+var pipyQueryPaths = map[string][]string{
+	PipyQueryConfig:  {"config_dump"},
+	PipyQueryPlugins: {"plugins"},
+	PipyQueryMetrics: {"stats/prometheus"},
+	PipyQueryVersion: {"version"},
+	PipyQueryAll:     {"config_dump", "plugins", "stats/prometheus", "version"},
+}
+
+// PipySidecarState is the state of a Pipy sidecar
+type PipySidecarState struct {
+	// Version is the version of the codebase loaded by the sidecar
+	Version string `json:"version,omitempty"`
+
+	// Timestamp is the time at which the loaded codebase was published by the controller
+	Timestamp string `json:"timestamp,omitempty"`
+
+	// Plugins are the plugins loaded by the sidecar, by mount point
+	Plugins map[string][]string `json:"plugins,omitempty"`
+
+	// Metrics are the metrics of the sidecar, by series
+	Metrics map[string]float64 `json:"metrics,omitempty"`
+
+	// Config is the PipyConf loaded by the sidecar, whose private key is redacted
+	Config map[string]interface{} `json:"config,omitempty"`
+}
+
+// ConfigDifference is a difference between the config loaded by a sidecar and the one computed by the controller
+type ConfigDifference struct {
+	// Path is the path of the differing field in the config
+	Path string `json:"path"`
+
+	// Sidecar is the value of the field in the config loaded by the sidecar, nil if the field is missing
+	Sidecar interface{} `json:"sidecar"`
+
+	// Controller is the value of the field in the config computed by the controller, nil if the field is missing
+	Controller interface{} `json:"controller"`
+}
+
+// IsPipyQuery returns whether the given query is a query supported for Pipy sidecars which yields structured output
+func IsPipyQuery(query string) bool {
+	_, ok := pipyQueryPaths[query]
+	return ok
+}
+
+// GetSidecarClass returns the class of the sidecar of the given pod, constants.SidecarClassPipy or
+// constants.SidecarClassEnvoy, or an empty string if the pod has no sidecar
+func GetSidecarClass(pod *corev1.Pod) string {
+	for _, container := range pod.Spec.Containers {
+		if container.Name != constants.SidecarContainerName {
+			continue
+		}
+		switch {
+		case len(container.Command) > 0 && container.Command[0] == constants.SidecarClassEnvoy:
+			return constants.SidecarClassEnvoy
+		case len(container.Command) > 0 && container.Command[0] == constants.SidecarClassPipy,
+			len(container.Args) > 0 && container.Args[0] == constants.SidecarClassPipy:
+			return constants.SidecarClassPipy
+		case strings.Contains(container.Image, constants.SidecarClassPipy):
+			return constants.SidecarClassPipy
+		default:
+			return constants.SidecarClassEnvoy
+		}
+	}
+	return ""
+}
+
+// GetMeshedPodSidecarClass returns the class of the sidecar of a running pod which belongs to a mesh
+func GetMeshedPodSidecarClass(clientSet kubernetes.Interface, namespace string, podName string) (string, error) {
+	pod, err := getMeshedPod(clientSet, namespace, podName)
+	if err != nil {
+		return "", err
+	}
+	return GetSidecarClass(pod), nil
+}
+
+// GetPipySidecarState returns the state of the Pipy sidecar of a pod requested by the given Pipy query
+func GetPipySidecarState(clientSet kubernetes.Interface, config *rest.Config, namespace string, podName string, localPort uint16, query string) (*PipySidecarState, error) {
+	paths, ok := pipyQueryPaths[query]
+	if !ok {
+		return nil, fmt.Errorf("Unsupported query %s for Pipy sidecars", query)
+	}
+	if _, err := getMeshedPod(clientSet, namespace, podName); err != nil {
+		return nil, err
+	}
+
+	responses, err := getFromPod(clientSet, config, namespace, podName, localPort, constants.SidecarAdminPort, true, paths...)
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving the state of the Pipy sidecar of pod %s in namespace %s: %w", podName, namespace, err)
+	}
+
+	state := &PipySidecarState{}
+	for i, path := range paths {
+		if err := state.parse(path, responses[i]); err != nil {
+			return nil, fmt.Errorf("Error parsing the response to %s of the Pipy sidecar of pod %s in namespace %s: %w", path, podName, namespace, err)
+		}
+	}
+	return state, nil
+}
+
+// parse sets the field of the state returned by the given path of the sidecar admin API
+func (s *PipySidecarState) parse(path string, body []byte) error {
+	switch path {
+	case "config_dump":
+		conf, err := parsePipyConfig(body)
+		if err != nil {
+			return err
+		}
+		s.Config = conf
+	case "plugins":
+		return json.Unmarshal(body, &s.Plugins)
+	case "stats/prometheus":
+		metrics, err := parsePrometheusMetrics(body)
+		if err != nil {
+			return err
+		}
+		s.Metrics = metrics
+	case "version":
+		version := struct {
+			Version string
+			Ts      string
+		}{}
+		if err := json.Unmarshal(body, &version); err != nil {
+			return err
+		}
+		s.Version, s.Timestamp = version.Version, version.Ts
+	}
+	return nil
+}
+
+// GetPipyControllerConfig returns the PipyConf the controller computed and published to its repo server for the
+// Pipy sidecar of a pod. The config is fetched from the osm-controller pod in the given namespace, from the
+// repo path the sidecar of the pod loads its codebase from.
+func GetPipyControllerConfig(clientSet kubernetes.Interface, config *rest.Config, osmNamespace string, namespace string, podName string, localPort uint16) (map[string]interface{}, error) {
+	pod, err := getMeshedPod(clientSet, namespace, podName)
+	if err != nil {
+		return nil, err
+	}
+	repoURL, err := getPipyRepoURL(pod)
+	if err != nil {
+		return nil, err
+	}
+	repoPort, err := strconv.ParseUint(repoURL.Port(), 10, 16)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid port in repo URL %s of pod %s in namespace %s", repoURL, podName, namespace)
+	}
+
+	controllerPod, err := getRunningControllerPod(clientSet, osmNamespace)
+	if err != nil {
+		return nil, err
+	}
+
+	configPath := strings.TrimPrefix(strings.TrimSuffix(repoURL.Path, "/"), "/") + "/config.json"
+	responses, err := getFromPod(clientSet, config, osmNamespace, controllerPod.Name, localPort, uint16(repoPort), true, configPath)
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving the config computed by the controller for pod %s in namespace %s: %w", podName, namespace, err)
+	}
+
+	return parsePipyConfig(responses[0])
+}
+
+// getPipyRepoURL returns the URL of the codebase the Pipy sidecar of the given pod loads, its last argument
+func getPipyRepoURL(pod *corev1.Pod) (*url.URL, error) {
+	for _, container := range pod.Spec.Containers {
+		if container.Name != constants.SidecarContainerName || len(container.Args) == 0 {
+			continue
+		}
+		repoURL, err := url.Parse(container.Args[len(container.Args)-1])
+		if err != nil || repoURL.Host == "" {
+			break
+		}
+		if _, _, err := net.SplitHostPort(repoURL.Host); err != nil {
+			break
+		}
+		return repoURL, nil
+	}
+	return nil, fmt.Errorf("Could not find the repo URL of the Pipy sidecar of pod %s in namespace %s", pod.Name, pod.Namespace)
+}
+
+// getRunningControllerPod returns a running osm-controller pod in the given namespace
+func getRunningControllerPod(clientSet kubernetes.Interface, osmNamespace string) (*corev1.Pod, error) {
+	pods, err := clientSet.CoreV1().Pods(osmNamespace).List(context.TODO(), metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", constants.AppLabel, constants.OSMControllerName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Error listing %s pods in namespace %s: %w", constants.OSMControllerName, osmNamespace, err)
+	}
+	for i := range pods.Items {
+		if pods.Items[i].Status.Phase == corev1.PodRunning {
+			return &pods.Items[i], nil
+		}
+	}
+	return nil, fmt.Errorf("No running %s pod found in namespace %s", constants.OSMControllerName, osmNamespace)
+}
+
+// parsePipyConfig parses a PipyConf and redacts the private key of its certificate
+func parsePipyConfig(body []byte) (map[string]interface{}, error) {
+	var conf map[string]interface{}
+	if err := json.Unmarshal(body, &conf); err != nil {
+		return nil, err
+	}
+	if cert, ok := conf["Certificate"].(map[string]interface{}); ok {
+		if _, ok := cert["PrivateKey"]; ok {
+			cert["PrivateKey"] = redactedValue
+		}
+	}
+	return conf, nil
+}
+
+// parsePrometheusMetrics parses metrics in the Prometheus text format into the values of their series,
+// keyed by the series names as they appear in the text format
+func parsePrometheusMetrics(body []byte) (map[string]float64, error) {
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	metrics := make(map[string]float64)
+	for name, family := range families {
+		for _, m := range family.GetMetric() {
+			labels := m.GetLabel()
+			switch family.GetType() {
+			case dto.MetricType_COUNTER:
+				metrics[seriesName(name, labels)] = m.GetCounter().GetValue()
+			case dto.MetricType_GAUGE:
+				metrics[seriesName(name, labels)] = m.GetGauge().GetValue()
+			case dto.MetricType_HISTOGRAM:
+				for _, bucket := range m.GetHistogram().GetBucket() {
+					le := strconv.FormatFloat(bucket.GetUpperBound(), 'g', -1, 64)
+					metrics[seriesName(name+"_bucket", labels, "le", le)] = float64(bucket.GetCumulativeCount())
+				}
+				metrics[seriesName(name+"_sum", labels)] = m.GetHistogram().GetSampleSum()
+				metrics[seriesName(name+"_count", labels)] = float64(m.GetHistogram().GetSampleCount())
+			case dto.MetricType_SUMMARY:
+				for _, quantile := range m.GetSummary().GetQuantile() {
+					q := strconv.FormatFloat(quantile.GetQuantile(), 'g', -1, 64)
+					metrics[seriesName(name, labels, "quantile", q)] = quantile.GetValue()
+				}
+				metrics[seriesName(name+"_sum", labels)] = m.GetSummary().GetSampleSum()
+				metrics[seriesName(name+"_count", labels)] = float64(m.GetSummary().GetSampleCount())
+			default:
+				metrics[seriesName(name, labels)] = m.GetUntyped().GetValue()
+			}
+		}
+	}
+	return metrics, nil
+}
+
+// seriesName returns the name of a series in the Prometheus text format, with its labels sorted by name.
+// extraLabel is an optional pair of label name and value added to the labels.
+func seriesName(name string, labels []*dto.LabelPair, extraLabel ...string) string {
+	var pairs []string
+	for _, label := range labels {
+		pairs = append(pairs, fmt.Sprintf("%s=%q", label.GetName(), label.GetValue()))
+	}
+	if len(extraLabel) == 2 {
+		pairs = append(pairs, fmt.Sprintf("%s=%q", extraLabel[0], extraLabel[1]))
+	}
+	if len(pairs) == 0 {
+		return name
+	}
+	sort.Strings(pairs)
+	return fmt.Sprintf("%s{%s}", name, strings.Join(pairs, ","))
+}
+
+// DiffPipyConfig returns the differences between the PipyConf loaded by a sidecar and the one computed by the
+// controller, sorted by path
+func DiffPipyConfig(sidecar, controller map[string]interface{}) []ConfigDifference {
+	var diffs []ConfigDifference
+	diffValues("", sidecar, controller, &diffs)
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].Path < diffs[j].Path
+	})
+	return diffs
+}
+
+// diffValues appends the differences between two decoded JSON values to diffs, recursing into objects and
+// arrays of the same length
+func diffValues(path string, sidecar, controller interface{}, diffs *[]ConfigDifference) {
+	switch s := sidecar.(type) {
+	case map[string]interface{}:
+		if c, ok := controller.(map[string]interface{}); ok {
+			for key, value := range s {
+				diffValues(joinPath(path, key), value, c[key], diffs)
+			}
+			for key, value := range c {
+				if _, ok := s[key]; !ok {
+					diffValues(joinPath(path, key), nil, value, diffs)
+				}
+			}
+			return
+		}
+	case []interface{}:
+		if c, ok := controller.([]interface{}); ok && len(s) == len(c) {
+			for i := range s {
+				diffValues(fmt.Sprintf("%s[%d]", path, i), s[i], c[i], diffs)
+			}
+			return
+		}
+	}
+
+	if !reflect.DeepEqual(sidecar, controller) {
+		*diffs = append(*diffs, ConfigDifference{Path: path, Sidecar: sidecar, Controller: controller})
+	}
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,173 @@
+package cli
+
+import (
+	"testing"
+
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+func TestGetSidecarClass(t *testing.T) {
+	testCases := []struct {
+		name       string
+		containers []corev1.Container
+		expected   string
+	}{
+		{
+			name: "envoy sidecar",
+			containers: []corev1.Container{
+				{Name: "app"},
+				{Name: constants.SidecarContainerName, Image: "envoyproxy/envoy-alpine:v1.22.2", Command: []string{"envoy"}},
+			},
+			expected: constants.SidecarClassEnvoy,
+		},
+		{
+			name: "pipy sidecar",
+			containers: []corev1.Container{
+				{Name: "app"},
+				{Name: constants.SidecarContainerName, Image: "flomesh/pipy:0.90.0", Args: []string{"pipy", "--admin-port=6060", "http://osm-controller.osm-system:6060/repo/osm-edge-sidecar/uuid.sidecar.sa.ns/"}},
+			},
+			expected: constants.SidecarClassPipy,
+		},
+		{
+			name: "pipy sidecar started with a custom command",
+			containers: []corev1.Container{
+				{Name: constants.SidecarContainerName, Image: "registry.local/flomesh/pipy:0.90.0", Command: []string{"/bin/sh"}},
+			},
+			expected: constants.SidecarClassPipy,
+		},
+		{
+			name:       "no sidecar",
+			containers: []corev1.Container{{Name: "app"}},
+			expected:   "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pod := &corev1.Pod{Spec: corev1.PodSpec{Containers: tc.containers}}
+			tassert.Equal(t, tc.expected, GetSidecarClass(pod))
+		})
+	}
+}
+
+func TestGetPipyRepoURL(t *testing.T) {
+	assert := tassert.New(t)
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "bookbuyer", Namespace: "bookbuyer"},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{{
+			Name: constants.SidecarContainerName,
+			Args: []string{"pipy", "--admin-port=6060", "http://osm-controller.osm-system:6060/repo/osm-edge-sidecar/uuid.sidecar.bookbuyer.bookbuyer/"},
+		}}},
+	}
+	repoURL, err := getPipyRepoURL(pod)
+	assert.NoError(err)
+	assert.Equal("6060", repoURL.Port())
+	assert.Equal("/repo/osm-edge-sidecar/uuid.sidecar.bookbuyer.bookbuyer/", repoURL.Path)
+
+	pod.Spec.Containers[0].Args = []string{"pipy", "main.js"}
+	_, err = getPipyRepoURL(pod)
+	assert.Error(err)
+}
+
+func TestParsePipyConfig(t *testing.T) {
+	assert := tassert.New(t)
+
+	conf, err := parsePipyConfig([]byte(`{"Version":"1","Certificate":{"CertChain":"cert","PrivateKey":"key"}}`))
+	assert.NoError(err)
+	assert.Equal("1", conf["Version"])
+	assert.Equal(map[string]interface{}{"CertChain": "cert", "PrivateKey": redactedValue}, conf["Certificate"])
+
+	_, err = parsePipyConfig([]byte("Not Found"))
+	assert.Error(err)
+}
+
+func TestParsePrometheusMetrics(t *testing.T) {
+	assert := tassert.New(t)
+
+	metrics, err := parsePrometheusMetrics([]byte(`# TYPE sidecar_cluster_upstream_rq_total counter
+sidecar_cluster_upstream_rq_total{sidecar_cluster_name="bookstore/bookstore-v1|14001",source_namespace="bookbuyer"} 42
+# TYPE sidecar_server_live gauge
+sidecar_server_live 1
+# TYPE sidecar_cluster_upstream_rq_time histogram
+sidecar_cluster_upstream_rq_time_bucket{sidecar_cluster_name="bookstore",le="5"} 3
+sidecar_cluster_upstream_rq_time_bucket{sidecar_cluster_name="bookstore",le="+Inf"} 4
+sidecar_cluster_upstream_rq_time_sum{sidecar_cluster_name="bookstore"} 27
+sidecar_cluster_upstream_rq_time_count{sidecar_cluster_name="bookstore"} 4
+`))
+	assert.NoError(err)
+	assert.Equal(map[string]float64{
+		`sidecar_cluster_upstream_rq_total{sidecar_cluster_name="bookstore/bookstore-v1|14001",source_namespace="bookbuyer"}`: 42,
+		`sidecar_server_live`: 1,
+		`sidecar_cluster_upstream_rq_time_bucket{le="5",sidecar_cluster_name="bookstore"}`:    3,
+		`sidecar_cluster_upstream_rq_time_bucket{le="+Inf",sidecar_cluster_name="bookstore"}`: 4,
+		`sidecar_cluster_upstream_rq_time_sum{sidecar_cluster_name="bookstore"}`:              27,
+		`sidecar_cluster_upstream_rq_time_count{sidecar_cluster_name="bookstore"}`:            4,
+	}, metrics)
+
+	_, err = parsePrometheusMetrics([]byte("not metrics {"))
+	assert.Error(err)
+}
+
+func TestPipySidecarStateParse(t *testing.T) {
+	assert := tassert.New(t)
+
+	state := &PipySidecarState{}
+	assert.NoError(state.parse("plugins", []byte(`{"inbound-http":["modules/inbound-tls-termination.js","plugins/token-verifier.js"]}`)))
+	assert.NoError(state.parse("version", []byte(`{"Version":"8712934","Ts":"2022-11-09T08:00:00Z"}`)))
+	assert.Equal(map[string][]string{"inbound-http": {"modules/inbound-tls-termination.js", "plugins/token-verifier.js"}}, state.Plugins)
+	assert.Equal("8712934", state.Version)
+	assert.Equal("2022-11-09T08:00:00Z", state.Timestamp)
+
+	assert.Error(state.parse("version", []byte("Not Found")))
+}
+
+func TestDiffPipyConfig(t *testing.T) {
+	testCases := []struct {
+		name       string
+		sidecar    string
+		controller string
+		expected   []ConfigDifference
+	}{
+		{
+			name:       "identical configs",
+			sidecar:    `{"Version":"1","Outbound":{"TrafficMatches":{"14001":[{"Port":14001}]}}}`,
+			controller: `{"Version":"1","Outbound":{"TrafficMatches":{"14001":[{"Port":14001}]}}}`,
+		},
+		{
+			name:       "changed, added and removed fields",
+			sidecar:    `{"Version":"1","Spec":{"Probes":null},"Outbound":{"TrafficMatches":{"14001":[{"Port":14001,"Protocol":"http"}]}}}`,
+			controller: `{"Version":"2","Inbound":{"TrafficMatches":{}},"Outbound":{"TrafficMatches":{"14001":[{"Port":14001,"Protocol":"tcp"}]}}}`,
+			expected: []ConfigDifference{
+				{Path: "Inbound", Controller: map[string]interface{}{"TrafficMatches": map[string]interface{}{}}},
+				{Path: "Outbound.TrafficMatches.14001[0].Protocol", Sidecar: "http", Controller: "tcp"},
+				{Path: "Spec", Sidecar: map[string]interface{}{"Probes": nil}},
+				{Path: "Version", Sidecar: "1", Controller: "2"},
+			},
+		},
+		{
+			name:       "arrays of different lengths",
+			sidecar:    `{"Chains":{"inbound-http":["a.js"]}}`,
+			controller: `{"Chains":{"inbound-http":["a.js","b.js"]}}`,
+			expected: []ConfigDifference{
+				{Path: "Chains.inbound-http", Sidecar: []interface{}{"a.js"}, Controller: []interface{}{"a.js", "b.js"}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			sidecar, err := parsePipyConfig([]byte(tc.sidecar))
+			assert.NoError(err)
+			controller, err := parsePipyConfig([]byte(tc.controller))
+			assert.NoError(err)
+			assert.Equal(tc.expected, DiffPipyConfig(sidecar, controller))
+		})
+	}
+}
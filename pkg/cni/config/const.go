@@ -13,6 +13,8 @@
 // Package config defines the constants that are used by multiple other packages within OSM.
 package config
 
+import "github.com/openservicemesh/osm/pkg/constants"
+
 const (
 	// CNICreatePodURL is the route for cni plugin for creating pod
 	CNICreatePodURL = "/v1/cni/create-pod"
@@ -25,4 +27,13 @@ const (
 	OsmPodFibEbpfMap = "/sys/fs/bpf/tc/globals/osm_pod_fib"
 	// OsmNatFibEbpfMap is the mount point of osm_nat_fib map
 	OsmNatFibEbpfMap = "/sys/fs/bpf/tc/globals/osm_nat_fib"
+	// OsmCgrFibEbpfMap is the mount point of osm_cgr_fib map
+	OsmCgrFibEbpfMap = "/sys/fs/bpf/osm_cgr_fib"
+	// OsmMarkFibEbpfMap is the mount point of osm_mark_fib map
+	OsmMarkFibEbpfMap = "/sys/fs/bpf/osm_mark_fib"
+	// OsmTraceEventsEbpfMap is the mount point of osm_trace_events map
+	OsmTraceEventsEbpfMap = "/sys/fs/bpf/tc/globals/osm_trace_events"
+
+	// DebugServerDefaultPort is the default port on which osm-interceptor exposes its debug server
+	DebugServerDefaultPort = constants.InterceptorDebugPort
 )
@@ -37,4 +37,10 @@ var (
 	Context string
 	// EnableHotRestart indicates HotRestart feature enable/disable
 	EnableHotRestart = false
+	// OsmNamespace defines the namespace of the OSM control plane
+	OsmNamespace string
+	// MeshConfigName defines the name of the OSM MeshConfig
+	MeshConfigName string
+	// DebugServerPort defines the port of the debug server, 0 disables the debug server
+	DebugServerPort int
 )
@@ -0,0 +1,149 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// runDebugServer serves the debug endpoints of the interceptor on localhost
+func runDebugServer(port int) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/policies", policiesHandler)
+	mux.HandleFunc("/debug/maps/", mapsHandler)
+	mux.HandleFunc("/debug/trace", traceHandler)
+
+	server := &http.Server{
+		Addr:              fmt.Sprintf("127.0.0.1:%d", port),
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	log.Infof("debug server listening on %s", server.Addr)
+	if err := server.ListenAndServe(); err != nil {
+		log.Errorf("debug server error: %v", err)
+	}
+}
+
+// policiesHandler dumps the effective interception policies of the pods on this node,
+// or of a single pod when the pod query parameter is set to <namespace>/<name>.
+func policiesHandler(w http.ResponseWriter, r *http.Request) {
+	var body interface{}
+	if pod := r.URL.Query().Get("pod"); pod != "" {
+		namespace, name, found := strings.Cut(pod, "/")
+		if !found {
+			http.Error(w, fmt.Sprintf("invalid pod %q, expected <namespace>/<name>", pod), http.StatusBadRequest)
+			return
+		}
+		p, ok := policies.get(namespace, name)
+		if !ok {
+			http.Error(w, fmt.Sprintf("pod %s not found", pod), http.StatusNotFound)
+			return
+		}
+		body = p
+	} else {
+		body = policies.list()
+	}
+	writeJSON(w, body)
+}
+
+// mapListers list the entries of the ebpf maps served by mapsHandler
+var mapListers = map[string]func() (interface{}, error){
+	"pod_fib":  func() (interface{}, error) { return listPodFibMap() },
+	"nat_fib":  func() (interface{}, error) { return listNatFibMap() },
+	"cgr_fib":  func() (interface{}, error) { return listCgrFibMap() },
+	"mark_fib": func() (interface{}, error) { return listMarkFibMap() },
+}
+
+// mapsHandler dumps the entries of the ebpf map named by the last element of the path, one of pod_fib, nat_fib,
+// cgr_fib or mark_fib.
+func mapsHandler(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, "/debug/maps/")
+	list, ok := mapListers[name]
+	if !ok {
+		http.Error(w, fmt.Sprintf("unknown map %q", name), http.StatusNotFound)
+		return
+	}
+	entries, err := list()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, entries)
+}
+
+// traceHandler streams the redirect decisions of the eBPF programs as JSON lines until the client disconnects.
+// The decisions can be filtered by the ip addresses of a pod with the pod query parameter set to <namespace>/<name>,
+// or by an ip address with the ip query parameter.
+func traceHandler(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	var ips []string
+	if ip := r.URL.Query().Get("ip"); ip != "" {
+		ips = append(ips, ip)
+	}
+	if pod := r.URL.Query().Get("pod"); pod != "" {
+		namespace, name, _ := strings.Cut(pod, "/")
+		p, ok := policies.get(namespace, name)
+		if !ok {
+			http.Error(w, fmt.Sprintf("pod %s not found", pod), http.StatusNotFound)
+			return
+		}
+		ips = append(ips, p.IPs...)
+	}
+
+	events, err := decisionTracer.subscribe()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer decisionTracer.unsubscribe(events)
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	enc := json.NewEncoder(w)
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case ev := <-events:
+			if len(ips) > 0 && ev.Lost == 0 && !contains(ips, ev.SrcIP) && !contains(ips, ev.DstIP) {
+				continue
+			}
+			if err := enc.Encode(ev); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+func writeJSON(w http.ResponseWriter, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(body); err != nil {
+		log.Errorf("encode response error: %v", err)
+	}
+}
@@ -22,8 +22,11 @@ import (
 )
 
 var (
-	podFibMap *ebpf.Map
-	natFibMap *ebpf.Map
+	podFibMap      *ebpf.Map
+	natFibMap      *ebpf.Map
+	cgrFibMap      *ebpf.Map
+	markFibMap     *ebpf.Map
+	traceEventsMap *ebpf.Map
 )
 
 // InitLoadPinnedMap init, load and pinned mapsß
@@ -37,6 +40,18 @@ func InitLoadPinnedMap() error {
 	if err != nil {
 		return fmt.Errorf("load map[%s] error: %v", err, config.OsmNatFibEbpfMap)
 	}
+	cgrFibMap, err = ebpf.LoadPinnedMap(config.OsmCgrFibEbpfMap, &ebpf.LoadPinOptions{})
+	if err != nil {
+		return fmt.Errorf("load map[%s] error: %v", config.OsmCgrFibEbpfMap, err)
+	}
+	markFibMap, err = ebpf.LoadPinnedMap(config.OsmMarkFibEbpfMap, &ebpf.LoadPinOptions{})
+	if err != nil {
+		return fmt.Errorf("load map[%s] error: %v", config.OsmMarkFibEbpfMap, err)
+	}
+	traceEventsMap, err = ebpf.LoadPinnedMap(config.OsmTraceEventsEbpfMap, &ebpf.LoadPinOptions{})
+	if err != nil {
+		return fmt.Errorf("load map[%s] error: %v", config.OsmTraceEventsEbpfMap, err)
+	}
 	return nil
 }
 
@@ -55,3 +70,27 @@ func GetNatFibMap() *ebpf.Map {
 	}
 	return natFibMap
 }
+
+// GetCgrFibMap returns cgroup fib map
+func GetCgrFibMap() *ebpf.Map {
+	if cgrFibMap == nil {
+		_ = InitLoadPinnedMap()
+	}
+	return cgrFibMap
+}
+
+// GetMarkFibMap returns mark fib map
+func GetMarkFibMap() *ebpf.Map {
+	if markFibMap == nil {
+		_ = InitLoadPinnedMap()
+	}
+	return markFibMap
+}
+
+// GetTraceEventsMap returns the perf event array of the redirect decisions
+func GetTraceEventsMap() *ebpf.Map {
+	if traceEventsMap == nil {
+		_ = InitLoadPinnedMap()
+	}
+	return traceEventsMap
+}
@@ -20,42 +20,39 @@ import (
 	"strconv"
 	"strings"
 	"syscall"
-	"time"
 	"unsafe"
 
 	"github.com/cilium/ebpf"
 	log "github.com/sirupsen/logrus"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
 
+	configv1alpha2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
 	"github.com/openservicemesh/osm/pkg/cni/config"
 	"github.com/openservicemesh/osm/pkg/cni/controller/helpers"
 	"github.com/openservicemesh/osm/pkg/cni/util"
 	"github.com/openservicemesh/osm/pkg/constants"
+	configClientset "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned"
 )
 
-func runLocalPodController(skip bool, client kubernetes.Interface, stop chan struct{}) error {
+func runLocalPodController(skip bool, client kubernetes.Interface, configClient configClientset.Interface, stop chan struct{}) error {
 	var err error
 
 	if err = helpers.InitLoadPinnedMap(); err != nil {
 		return fmt.Errorf("failed to load ebpf maps: %v", err)
 	}
 
-	go func() {
-		for {
-			tracePodFibMap()
-			traceNatFibMap()
-			time.Sleep(time.Second * 20)
-		}
-	}()
-
-	w := newWatcher(createLocalPodController(client))
+	w := newWatcher(createLocalPodController(client, configClient))
 
 	if err = w.start(); err != nil {
 		return fmt.Errorf("start watcher failed: %v", err)
 	}
 
 	log.Info("Pod watcher Ready")
+	if config.DebugServerPort > 0 {
+		go runDebugServer(config.DebugServerPort)
+	}
 	if err = helpers.AttachProgs(skip); err != nil {
 		return fmt.Errorf("failed to attach ebpf programs: %v", err)
 	}
@@ -75,24 +72,37 @@ func runLocalPodController(skip bool, client kubernetes.Interface, stop chan str
 	return nil
 }
 
-func createLocalPodController(client kubernetes.Interface) watcher {
+func createLocalPodController(client kubernetes.Interface, configClient configClientset.Interface) watcher {
 	localName, err := os.Hostname()
 	if err != nil {
 		panic(err)
 	}
 	return watcher{
 		Client:          client,
+		ConfigClient:    configClient,
 		CurrentNodeName: localName,
 		OnAddFunc:       addFunc,
 		OnUpdateFunc:    updateFunc,
 		OnDeleteFunc:    deleteFunc,
+		NamespaceHandler: cache.ResourceEventHandlerFuncs{
+			AddFunc:    namespaceAddFunc,
+			UpdateFunc: namespaceUpdateFunc,
+			DeleteFunc: namespaceDeleteFunc,
+		},
+		MeshConfigHandler: cache.ResourceEventHandlerFuncs{
+			AddFunc:    meshConfigAddFunc,
+			UpdateFunc: meshConfigUpdateFunc,
+			DeleteFunc: meshConfigDeleteFunc,
+		},
 	}
 }
 
 const maxItemLen = 10 // todo changeme
 
+// cidr stores IPv4 networks in the last 4 bytes of net with a mask offset by 96 bits,
+// so that IPv4 and IPv6 networks are matched in the same way by the ebpf programs.
 type cidr struct {
-	net  uint32 // network order
+	net  [4]uint32 // network order
 	mask uint8
 	_    [3]uint8 // pad
 }
@@ -115,34 +125,84 @@ func isInjectedSidecar(pod *v1.Pod) bool {
 	return false
 }
 
+// getPodIPs returns the IPv4 and IPv6 addresses of a dual-stack pod
+func getPodIPs(pod *v1.Pod) []string {
+	if len(pod.Status.PodIPs) == 0 {
+		if len(pod.Status.PodIP) == 0 {
+			return nil
+		}
+		return []string{pod.Status.PodIP}
+	}
+	var ips []string
+	for _, ip := range pod.Status.PodIPs {
+		ips = append(ips, ip.IP)
+	}
+	return ips
+}
+
 func addFunc(obj interface{}) {
 	if disableWatch {
 		return
 	}
 	pod, ok := obj.(*v1.Pod)
-	if !ok || len(pod.Status.PodIP) == 0 {
+	if !ok || len(getPodIPs(pod)) == 0 {
 		return
 	}
 	if !isInjectedSidecar(pod) {
 		return
 	}
 	log.Debugf("got pod updated %s/%s", pod.Namespace, pod.Name)
+	policies.setPod(pod)
+}
 
-	_ip, _ := util.IP2Pointer(pod.Status.PodIP)
-	log.Infof("update osm_pod_fib with ip: %s", pod.Status.PodIP)
-	p := podConfig{}
-	parsePodConfigFromAnnotations(pod.Annotations, &p)
-	err := helpers.GetPodFibMap().Update(_ip, &p, ebpf.UpdateAny)
-	if err != nil {
-		log.Errorf("update osm_pod_fib %s error: %v", pod.Status.PodIP, err)
+// applyPodPolicy updates the osm_pod_fib map with the policy of a pod, the entries of the previous IPs are deleted
+func applyPodPolicy(prev, cur *podPolicy) {
+	var curIPs []string
+	if cur != nil {
+		curIPs = cur.IPs
+	}
+	if prev != nil {
+		for _, ip := range prev.IPs {
+			if contains(curIPs, ip) {
+				continue
+			}
+			_ip, err := util.IP2Pointer(ip)
+			if err == nil {
+				_ = helpers.GetPodFibMap().Delete(_ip)
+			}
+		}
+	}
+	if cur == nil {
+		return
+	}
+	p := cur.podConfig()
+	for _, ip := range curIPs {
+		_ip, err := util.IP2Pointer(ip)
+		if err != nil {
+			log.Errorf("update osm_pod_fib %s error: %v", ip, err)
+			continue
+		}
+		log.Infof("update osm_pod_fib with ip: %s", ip)
+		if err = helpers.GetPodFibMap().Update(_ip, &p, ebpf.UpdateAny); err != nil {
+			log.Errorf("update osm_pod_fib %s error: %v", ip, err)
+		}
+	}
+}
+
+func contains(list []string, item string) bool {
+	for _, i := range list {
+		if i == item {
+			return true
+		}
 	}
+	return false
 }
 
-func getPortsFromString(v string) []uint16 {
+func getPortsFromList(list []string) []uint16 {
 	var ports []uint16
-	for _, vv := range strings.Split(v, ",") {
-		if p := strings.TrimSpace(vv); p != "" {
-			port, err := strconv.ParseUint(vv, 10, 16)
+	for _, v := range list {
+		if p := strings.TrimSpace(v); p != "" {
+			port, err := strconv.ParseUint(p, 10, 16)
 			if err == nil {
 				ports = append(ports, uint16(port))
 			}
@@ -151,29 +211,30 @@ func getPortsFromString(v string) []uint16 {
 	return ports
 }
 
-func getIPRangesFromString(v string) []cidr {
+func getIPRangesFromList(list []string) []cidr {
 	var ranges []cidr
-	for _, vv := range strings.Split(v, ",") {
-		if vv == "*" {
-			ranges = append(ranges, cidr{
-				net:  0,
-				mask: 0,
-			})
+	for _, v := range list {
+		p := strings.TrimSpace(v)
+		if p == "*" {
+			ranges = append(ranges, cidr{})
 			continue
 		}
-		if p := strings.TrimSpace(vv); p != "" {
-			_, n, err := net.ParseCIDR(vv)
+		if p != "" {
+			_, n, err := net.ParseCIDR(p)
 			if err != nil {
-				log.Errorf("parse cidr from %s error: %v", vv, err)
+				log.Errorf("parse cidr from %s error: %v", p, err)
 				continue
 			}
 			c := cidr{}
 			ones, _ := n.Mask.Size()
-			c.mask = uint8(ones)
-			if len(n.IP) == 16 {
-				c.net = *(*uint32)(unsafe.Pointer(&n.IP[12]))
+			if ip4 := n.IP.To4(); ip4 != nil {
+				c.mask = uint8(ones + 96)
+				c.net[3] = *(*uint32)(unsafe.Pointer(&ip4[0]))
 			} else {
-				c.net = *(*uint32)(unsafe.Pointer(&n.IP[0]))
+				c.mask = uint8(ones)
+				for i := range c.net {
+					c.net[i] = *(*uint32)(unsafe.Pointer(&n.IP[i*4]))
+				}
 			}
 			ranges = append(ranges, c)
 		}
@@ -181,111 +242,68 @@ func getIPRangesFromString(v string) []cidr {
 	return ranges
 }
 
-func parsePodConfigFromAnnotations(annotations map[string]string, pod *podConfig) {
-	statusPort := 15021
-	if v, ok := annotations["openservicemesh.io/port"]; ok {
-		vv, err := strconv.ParseUint(v, 10, 16)
-		if err == nil {
-			statusPort = int(vv)
-		}
-	}
-	pod.statusPort = uint16(statusPort)
-	excludeInboundPorts := []uint16{15006, 15001, 15008, 15090, 15021, 15020, 15000} // todo changeme
-	if v, ok := annotations["openservicemesh.io/inbound-port-exclusion-list"]; ok {
-		excludeInboundPorts = append(excludeInboundPorts, getPortsFromString(v)...)
-	}
-	if len(excludeInboundPorts) > 0 {
-		for i, p := range excludeInboundPorts {
-			if i >= maxItemLen {
-				break
-			}
-			pod.excludeInPorts[i] = p
-		}
-	}
-	if v, ok := annotations["openservicemesh.io/outbound-port-exclusion-list"]; ok {
-		excludeOutboundPorts := getPortsFromString(v)
-		if len(excludeOutboundPorts) > 0 {
-			for i, p := range excludeOutboundPorts {
-				if i >= maxItemLen {
-					break
-				}
-				pod.excludeOutPorts[i] = p
-			}
-		}
-	}
-
-	if v, ok := annotations["openservicemesh.io/inbound-port-inclusion-list"]; ok {
-		includeInboundPorts := getPortsFromString(v)
-		if len(includeInboundPorts) > 0 {
-			for i, p := range includeInboundPorts {
-				if i >= maxItemLen {
-					break
-				}
-				pod.includeInPorts[i] = p
-			}
-		}
-	}
-	if v, ok := annotations["openservicemesh.io/outbound-port-inclusion-list"]; ok {
-		includeOutboundPorts := getPortsFromString(v)
-		if len(includeOutboundPorts) > 0 {
-			for i, p := range includeOutboundPorts {
-				if i >= maxItemLen {
-					break
-				}
-				pod.includeOutPorts[i] = p
-			}
-		}
-	}
-
-	if v, ok := annotations["openservicemesh.io/outbound-ip-range-exclusion-list"]; ok {
-		excludeOutboundIPRanges := getIPRangesFromString(v)
-		if len(excludeOutboundIPRanges) > 0 {
-			for i, p := range excludeOutboundIPRanges {
-				if i >= maxItemLen {
-					break
-				}
-				pod.excludeOutRanges[i] = p
-			}
-		}
-	}
-	if v, ok := annotations["openservicemesh.io/outbound-ip-range-inclusion-list"]; ok {
-		includeOutboundIPRanges := getIPRangesFromString(v)
-		if len(includeOutboundIPRanges) > 0 {
-			for i, p := range includeOutboundIPRanges {
-				if i >= maxItemLen {
-					break
-				}
-				pod.includeOutRanges[i] = p
-			}
-		}
-	}
-}
-
 func updateFunc(old, cur interface{}) {
 	if disableWatch {
 		return
 	}
-	oldPod, ok := old.(*v1.Pod)
-	if !ok {
-		return
-	}
 	curPod, ok := cur.(*v1.Pod)
 	if !ok {
 		return
 	}
-	if oldPod.Status.PodIP != curPod.Status.PodIP {
-		// only care about ip changes
-		addFunc(cur)
+	if !isInjectedSidecar(curPod) || len(getPodIPs(curPod)) == 0 {
+		deleteFunc(old)
+		return
 	}
+	// the policy is only pushed to the ebpf maps when the ips or the annotations of the pod change
+	policies.setPod(curPod)
 }
 
 func deleteFunc(obj interface{}) {
 	if disableWatch {
 		return
 	}
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
 	if pod, ok := obj.(*v1.Pod); ok {
 		log.Debugf("got pod delete %s/%s", pod.Namespace, pod.Name)
-		_ip, _ := util.IP2Pointer(pod.Status.PodIP)
-		_ = helpers.GetPodFibMap().Delete(_ip)
+		policies.deletePod(pod)
+	}
+}
+
+func namespaceAddFunc(obj interface{}) {
+	if ns, ok := obj.(*v1.Namespace); ok {
+		annotations := ns.Annotations
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		policies.setNamespace(ns.Name, annotations)
+	}
+}
+
+func namespaceUpdateFunc(_, cur interface{}) {
+	namespaceAddFunc(cur)
+}
+
+func namespaceDeleteFunc(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	if ns, ok := obj.(*v1.Namespace); ok {
+		policies.setNamespace(ns.Name, nil)
+	}
+}
+
+func meshConfigAddFunc(obj interface{}) {
+	if meshConfig, ok := obj.(*configv1alpha2.MeshConfig); ok {
+		policies.setMeshConfig(meshConfig)
 	}
 }
+
+func meshConfigUpdateFunc(_, cur interface{}) {
+	meshConfigAddFunc(cur)
+}
+
+func meshConfigDeleteFunc(_ interface{}) {
+	policies.setMeshConfig(nil)
+}
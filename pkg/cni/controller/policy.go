@@ -0,0 +1,283 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+
+	configv1alpha2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+)
+
+// The annotations configuring the interception of the traffic of a pod. They can be set on the pod or on its namespace:
+// a namespace annotation overrides the corresponding MeshConfig list, and a pod annotation is merged with the
+// list resulting from the MeshConfig and the namespace, like the sidecar injector does for the init container.
+const (
+	statusPortAnnotation                   = "openservicemesh.io/port"
+	inboundPortExclusionListAnnotation     = "openservicemesh.io/inbound-port-exclusion-list"
+	outboundPortExclusionListAnnotation    = "openservicemesh.io/outbound-port-exclusion-list"
+	inboundPortInclusionListAnnotation     = "openservicemesh.io/inbound-port-inclusion-list"
+	outboundPortInclusionListAnnotation    = "openservicemesh.io/outbound-port-inclusion-list"
+	outboundIPRangeExclusionListAnnotation = "openservicemesh.io/outbound-ip-range-exclusion-list"
+	outboundIPRangeInclusionListAnnotation = "openservicemesh.io/outbound-ip-range-inclusion-list"
+
+	defaultStatusPort = 15021
+)
+
+// defaultInboundPortExclusionList is the list of the sidecar ports which are never intercepted
+var defaultInboundPortExclusionList = []uint16{15006, 15001, 15008, 15090, 15021, 15020, 15000} // todo changeme
+
+// podPolicy is the effective interception policy of a pod
+type podPolicy struct {
+	Namespace                    string   `json:"namespace"`
+	Name                         string   `json:"name"`
+	IPs                          []string `json:"ips"`
+	StatusPort                   uint16   `json:"statusPort"`
+	InboundPortExclusionList     []uint16 `json:"inboundPortExclusionList,omitempty"`
+	InboundPortInclusionList     []uint16 `json:"inboundPortInclusionList,omitempty"`
+	OutboundPortExclusionList    []uint16 `json:"outboundPortExclusionList,omitempty"`
+	OutboundPortInclusionList    []uint16 `json:"outboundPortInclusionList,omitempty"`
+	OutboundIPRangeExclusionList []string `json:"outboundIPRangeExclusionList,omitempty"`
+	OutboundIPRangeInclusionList []string `json:"outboundIPRangeInclusionList,omitempty"`
+}
+
+// newPodPolicy merges the MeshConfig traffic spec, the namespace annotations and the pod annotations into the
+// effective interception policy of the pod
+func newPodPolicy(pod *v1.Pod, traffic *configv1alpha2.TrafficSpec, namespaceAnnotations map[string]string) *podPolicy {
+	if traffic == nil {
+		traffic = &configv1alpha2.TrafficSpec{}
+	}
+	merge := func(annotation string, global []string) []string {
+		list := global
+		if v, ok := namespaceAnnotations[annotation]; ok {
+			list = splitList(v)
+		}
+		if v, ok := pod.Annotations[annotation]; ok {
+			list = mergeLists(list, splitList(v))
+		}
+		return list
+	}
+
+	p := &podPolicy{
+		Namespace:  pod.Namespace,
+		Name:       pod.Name,
+		IPs:        getPodIPs(pod),
+		StatusPort: defaultStatusPort,
+	}
+	for _, annotations := range []map[string]string{namespaceAnnotations, pod.Annotations} {
+		if v, ok := annotations[statusPortAnnotation]; ok {
+			if port, err := strconv.ParseUint(v, 10, 16); err == nil {
+				p.StatusPort = uint16(port)
+			}
+		}
+	}
+	p.InboundPortExclusionList = append(append([]uint16{}, defaultInboundPortExclusionList...),
+		getPortsFromList(merge(inboundPortExclusionListAnnotation, intsToStrings(traffic.InboundPortExclusionList)))...)
+	p.OutboundPortExclusionList = getPortsFromList(merge(outboundPortExclusionListAnnotation, intsToStrings(traffic.OutboundPortExclusionList)))
+	p.InboundPortInclusionList = getPortsFromList(merge(inboundPortInclusionListAnnotation, nil))
+	p.OutboundPortInclusionList = getPortsFromList(merge(outboundPortInclusionListAnnotation, nil))
+	p.OutboundIPRangeExclusionList = merge(outboundIPRangeExclusionListAnnotation, traffic.OutboundIPRangeExclusionList)
+	p.OutboundIPRangeInclusionList = merge(outboundIPRangeInclusionListAnnotation, traffic.OutboundIPRangeInclusionList)
+	return p
+}
+
+// podConfig returns the value of the pod in the osm_pod_fib map
+func (p *podPolicy) podConfig() podConfig {
+	c := podConfig{statusPort: p.StatusPort}
+	key := fmt.Sprintf("%s/%s", p.Namespace, p.Name)
+	copyPorts := func(dst *[maxItemLen]uint16, ports []uint16, name string) {
+		if len(ports) > maxItemLen {
+			log.Warnf("pod %s has %d %s, only the first %d are applied", key, len(ports), name, maxItemLen)
+		}
+		for i := 0; i < len(ports) && i < maxItemLen; i++ {
+			dst[i] = ports[i]
+		}
+	}
+	copyRanges := func(dst *[maxItemLen]cidr, ipRanges []string, name string) {
+		ranges := getIPRangesFromList(ipRanges)
+		if len(ranges) > maxItemLen {
+			log.Warnf("pod %s has %d %s, only the first %d are applied", key, len(ranges), name, maxItemLen)
+		}
+		for i := 0; i < len(ranges) && i < maxItemLen; i++ {
+			dst[i] = ranges[i]
+		}
+	}
+	copyPorts(&c.excludeInPorts, p.InboundPortExclusionList, "inbound port exclusions")
+	copyPorts(&c.excludeOutPorts, p.OutboundPortExclusionList, "outbound port exclusions")
+	copyPorts(&c.includeInPorts, p.InboundPortInclusionList, "inbound port inclusions")
+	copyPorts(&c.includeOutPorts, p.OutboundPortInclusionList, "outbound port inclusions")
+	copyRanges(&c.excludeOutRanges, p.OutboundIPRangeExclusionList, "outbound IP range exclusions")
+	copyRanges(&c.includeOutRanges, p.OutboundIPRangeInclusionList, "outbound IP range inclusions")
+	return c
+}
+
+// policyStore keeps the MeshConfig, the namespaces and the pods the interception policies of the pods on this node
+// are computed from, and applies the policies which changed to the ebpf maps.
+type policyStore struct {
+	sync.RWMutex
+	traffic    *configv1alpha2.TrafficSpec
+	namespaces map[string]map[string]string
+	pods       map[string]*v1.Pod
+	policies   map[string]*podPolicy
+
+	// apply updates the ebpf maps when the policy of a pod changes, prev or cur is nil when the pod is added or deleted.
+	apply func(prev, cur *podPolicy)
+}
+
+func newPolicyStore(apply func(prev, cur *podPolicy)) *policyStore {
+	return &policyStore{
+		namespaces: make(map[string]map[string]string),
+		pods:       make(map[string]*v1.Pod),
+		policies:   make(map[string]*podPolicy),
+		apply:      apply,
+	}
+}
+
+// policies is the store of the interception policies of the pods on this node
+var policies = newPolicyStore(applyPodPolicy)
+
+func podKey(namespace, name string) string {
+	return fmt.Sprintf("%s/%s", namespace, name)
+}
+
+// setMeshConfig updates the mesh-wide traffic lists and the policies of all the pods
+func (s *policyStore) setMeshConfig(meshConfig *configv1alpha2.MeshConfig) {
+	s.Lock()
+	defer s.Unlock()
+	if meshConfig == nil {
+		s.traffic = nil
+	} else {
+		traffic := meshConfig.Spec.Traffic
+		s.traffic = &traffic
+	}
+	for key := range s.pods {
+		s.sync(key)
+	}
+}
+
+// setNamespace updates the annotations of a namespace and the policies of its pods, nil annotations delete the namespace
+func (s *policyStore) setNamespace(name string, annotations map[string]string) {
+	s.Lock()
+	defer s.Unlock()
+	if annotations == nil {
+		delete(s.namespaces, name)
+	} else {
+		s.namespaces[name] = annotations
+	}
+	for key, pod := range s.pods {
+		if pod.Namespace == name {
+			s.sync(key)
+		}
+	}
+}
+
+// setPod updates the policy of a pod
+func (s *policyStore) setPod(pod *v1.Pod) {
+	s.Lock()
+	defer s.Unlock()
+	key := podKey(pod.Namespace, pod.Name)
+	s.pods[key] = pod
+	s.sync(key)
+}
+
+// deletePod deletes the policy of a pod
+func (s *policyStore) deletePod(pod *v1.Pod) {
+	s.Lock()
+	defer s.Unlock()
+	key := podKey(pod.Namespace, pod.Name)
+	delete(s.pods, key)
+	s.sync(key)
+}
+
+// sync recomputes the policy of a pod and applies it if it changed, it must be called with the lock held
+func (s *policyStore) sync(key string) {
+	prev := s.policies[key]
+	var cur *podPolicy
+	if pod, ok := s.pods[key]; ok {
+		cur = newPodPolicy(pod, s.traffic, s.namespaces[pod.Namespace])
+	}
+	if reflect.DeepEqual(prev, cur) {
+		return
+	}
+	if cur == nil {
+		delete(s.policies, key)
+	} else {
+		s.policies[key] = cur
+	}
+	s.apply(prev, cur)
+}
+
+// get returns the policy of a pod
+func (s *policyStore) get(namespace, name string) (*podPolicy, bool) {
+	s.RLock()
+	defer s.RUnlock()
+	p, ok := s.policies[podKey(namespace, name)]
+	return p, ok
+}
+
+// list returns the policies of all the pods sorted by namespace and name
+func (s *policyStore) list() []*podPolicy {
+	s.RLock()
+	defer s.RUnlock()
+	list := make([]*podPolicy, 0, len(s.policies))
+	for _, p := range s.policies {
+		list = append(list, p)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return podKey(list[i].Namespace, list[i].Name) < podKey(list[j].Namespace, list[j].Name)
+	})
+	return list
+}
+
+func splitList(v string) []string {
+	var list []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// mergeLists returns the items of a followed by the items of b which are not in a
+func mergeLists(a, b []string) []string {
+	merged := append([]string{}, a...)
+	for _, item := range b {
+		found := false
+		for _, existing := range merged {
+			if existing == item {
+				found = true
+				break
+			}
+		}
+		if !found {
+			merged = append(merged, item)
+		}
+	}
+	return merged
+}
+
+func intsToStrings(ints []int) []string {
+	var strs []string
+	for _, i := range ints {
+		strs = append(strs, strconv.Itoa(i))
+	}
+	return strs
+}
@@ -0,0 +1,165 @@
+package controller
+
+import (
+	"testing"
+
+	tassert "github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	configv1alpha2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+)
+
+func newTestPod(annotations map[string]string, ips ...string) *v1.Pod {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "ns",
+			Name:        "pod",
+			Annotations: annotations,
+		},
+	}
+	for _, ip := range ips {
+		pod.Status.PodIPs = append(pod.Status.PodIPs, v1.PodIP{IP: ip})
+	}
+	return pod
+}
+
+func TestNewPodPolicy(t *testing.T) {
+	traffic := &configv1alpha2.TrafficSpec{
+		OutboundIPRangeExclusionList: []string{"10.0.0.0/8"},
+		OutboundPortExclusionList:    []int{6379},
+		InboundPortExclusionList:     []int{9090},
+	}
+
+	testCases := []struct {
+		name                  string
+		traffic               *configv1alpha2.TrafficSpec
+		namespaceAnnotations  map[string]string
+		podAnnotations        map[string]string
+		expectedStatusPort    uint16
+		expectedInPorts       []uint16
+		expectedOutPorts      []uint16
+		expectedOutExclusions []string
+	}{
+		{
+			name:               "no MeshConfig",
+			expectedStatusPort: defaultStatusPort,
+			expectedInPorts:    defaultInboundPortExclusionList,
+		},
+		{
+			name:                  "MeshConfig only",
+			traffic:               traffic,
+			expectedStatusPort:    defaultStatusPort,
+			expectedInPorts:       append(append([]uint16{}, defaultInboundPortExclusionList...), 9090),
+			expectedOutPorts:      []uint16{6379},
+			expectedOutExclusions: []string{"10.0.0.0/8"},
+		},
+		{
+			name:    "namespace overrides MeshConfig",
+			traffic: traffic,
+			namespaceAnnotations: map[string]string{
+				outboundIPRangeExclusionListAnnotation: "192.168.0.0/16",
+				outboundPortExclusionListAnnotation:    "",
+				statusPortAnnotation:                   "8080",
+			},
+			expectedStatusPort:    8080,
+			expectedInPorts:       append(append([]uint16{}, defaultInboundPortExclusionList...), 9090),
+			expectedOutExclusions: []string{"192.168.0.0/16"},
+		},
+		{
+			name:    "pod merges with namespace and MeshConfig",
+			traffic: traffic,
+			namespaceAnnotations: map[string]string{
+				outboundIPRangeExclusionListAnnotation: "192.168.0.0/16",
+			},
+			podAnnotations: map[string]string{
+				outboundIPRangeExclusionListAnnotation: "192.168.0.0/16, fd00::/8",
+				outboundPortExclusionListAnnotation:    "6379,3306",
+			},
+			expectedStatusPort:    defaultStatusPort,
+			expectedInPorts:       append(append([]uint16{}, defaultInboundPortExclusionList...), 9090),
+			expectedOutPorts:      []uint16{6379, 3306},
+			expectedOutExclusions: []string{"192.168.0.0/16", "fd00::/8"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := tassert.New(t)
+
+			p := newPodPolicy(newTestPod(tc.podAnnotations, "10.1.1.1"), tc.traffic, tc.namespaceAnnotations)
+			a.Equal(tc.expectedStatusPort, p.StatusPort)
+			a.Equal(tc.expectedInPorts, p.InboundPortExclusionList)
+			a.Equal(tc.expectedOutPorts, p.OutboundPortExclusionList)
+			a.Equal(tc.expectedOutExclusions, p.OutboundIPRangeExclusionList)
+			a.Equal([]string{"10.1.1.1"}, p.IPs)
+		})
+	}
+}
+
+func TestPolicyStore(t *testing.T) {
+	a := tassert.New(t)
+
+	type applied struct {
+		prev, cur *podPolicy
+	}
+	var calls []applied
+	s := newPolicyStore(func(prev, cur *podPolicy) {
+		calls = append(calls, applied{prev, cur})
+	})
+
+	pod := newTestPod(nil, "10.1.1.1")
+	s.setPod(pod)
+	a.Len(calls, 1)
+	a.Nil(calls[0].prev)
+	a.NotNil(calls[0].cur)
+
+	// an unchanged pod is not applied again
+	s.setPod(pod)
+	a.Len(calls, 1)
+
+	// a namespace override is applied live to the pods of the namespace
+	s.setNamespace("ns", map[string]string{outboundPortExclusionListAnnotation: "22"})
+	a.Len(calls, 2)
+	a.Equal([]uint16{22}, calls[1].cur.OutboundPortExclusionList)
+
+	// other namespaces do not affect the pod
+	s.setNamespace("other", map[string]string{outboundPortExclusionListAnnotation: "23"})
+	a.Len(calls, 2)
+
+	s.setMeshConfig(&configv1alpha2.MeshConfig{
+		Spec: configv1alpha2.MeshConfigSpec{
+			Traffic: configv1alpha2.TrafficSpec{OutboundIPRangeExclusionList: []string{"10.0.0.0/8"}},
+		},
+	})
+	a.Len(calls, 3)
+	a.Equal([]string{"10.0.0.0/8"}, calls[2].cur.OutboundIPRangeExclusionList)
+
+	p, ok := s.get("ns", "pod")
+	a.True(ok)
+	a.Equal(calls[2].cur, p)
+	a.Len(s.list(), 1)
+
+	s.deletePod(pod)
+	a.Len(calls, 4)
+	a.Nil(calls[3].cur)
+	_, ok = s.get("ns", "pod")
+	a.False(ok)
+	a.Empty(s.list())
+}
+
+func TestPodConfig(t *testing.T) {
+	a := tassert.New(t)
+
+	p := &podPolicy{
+		StatusPort:                   15021,
+		OutboundPortExclusionList:    []uint16{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11},
+		OutboundIPRangeExclusionList: []string{"10.0.0.0/8", "fd00::/8"},
+	}
+	c := p.podConfig()
+	a.Equal(uint16(15021), c.statusPort)
+	a.Equal(uint16(10), c.excludeOutPorts[maxItemLen-1])
+	a.Equal(uint8(8+96), c.excludeOutRanges[0].mask)
+	a.Equal(uint8(8), c.excludeOutRanges[1].mask)
+	a.Equal(uint8(0), c.excludeOutRanges[2].mask)
+}
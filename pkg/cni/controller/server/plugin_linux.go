@@ -41,7 +41,6 @@ import (
 	"github.com/openservicemesh/osm/pkg/cni/controller/helpers"
 	"github.com/openservicemesh/osm/pkg/cni/file"
 	"github.com/openservicemesh/osm/pkg/cni/plugin"
-	"github.com/openservicemesh/osm/pkg/cni/util"
 )
 
 type qdisc struct {
@@ -50,6 +49,42 @@ type qdisc struct {
 	managedClsact bool
 }
 
+// netnsIPs is the value of osm_mark_fib, which stores the IPv4 and IPv6 addresses of a pod network namespace.
+type netnsIPs struct {
+	ip  [16]byte // IPv4, stored in the last 4 bytes
+	ip6 [16]byte
+}
+
+// getNetnsIPs returns the first IPv4 and the first global IPv6 addresses of addrs
+func getNetnsIPs(addrs []net.Addr) (netnsIPs, error) {
+	ips := netnsIPs{}
+	var found4, found6 bool
+	for _, addr := range addrs {
+		var ip net.IP
+		switch v := addr.(type) {
+		case *net.IPNet: // nolint: typecheck
+			ip = v.IP
+		case *net.IPAddr: // nolint: typecheck
+			ip = v.IP
+		}
+		if ip4 := ip.To4(); ip4 != nil {
+			if !found4 {
+				copy(ips.ip[12:], ip4)
+				found4 = true
+			}
+		} else if ip6 := ip.To16(); ip6 != nil && !ip6.IsLinkLocalUnicast() {
+			if !found6 {
+				copy(ips.ip6[:], ip6)
+				found6 = true
+			}
+		}
+	}
+	if !found4 && !found6 {
+		return ips, fmt.Errorf("no ip address in %v", addrs)
+	}
+	return ips, nil
+}
+
 func getMarkKeyOfNetns(netns string) uint32 {
 	// todo check conflict?
 	algorithm := fnv.New32a()
@@ -153,11 +188,7 @@ func (s *server) buildListener(netns string) error {
 		log.Errorf("no ip address for %s", netns)
 		return nil
 	}
-	if len(addrs) != 1 {
-		log.Warnf("get ip address for %s: res: %v, only support single ip address", netns, addrs)
-	}
-
-	lc := s.listenConfig(addrs[0], netns)
+	lc := s.listenConfig(addrs, netns)
 	var l net.Listener
 	l, err = lc.Listen(context.Background(), "tcp", "0.0.0.0:39807")
 	if err != nil {
@@ -198,7 +229,7 @@ func (s *server) buildListener(netns string) error {
 	return nil
 }
 
-func (s *server) listenConfig(addr net.Addr, netns string) net.ListenConfig {
+func (s *server) listenConfig(addrs []net.Addr, netns string) net.ListenConfig {
 	return net.ListenConfig{
 		Control: func(network, address string, conn syscall.RawConn) error {
 			var operr error
@@ -208,19 +239,13 @@ func (s *server) listenConfig(addr net.Addr, netns string) net.ListenConfig {
 					operr = err
 					return
 				}
-				var ip unsafe.Pointer
-				switch v := addr.(type) { // todo instead of hash
-				case *net.IPNet: // nolint: typecheck
-					ip, err = util.IP2Pointer(v.IP.String())
-				case *net.IPAddr: // nolint: typecheck
-					ip, err = util.IP2Pointer(v.String())
-				}
+				ips, err := getNetnsIPs(addrs) // todo instead of hash
 				if err != nil {
 					operr = err
 					return
 				}
 				key := getMarkKeyOfNetns(netns)
-				operr = m.Update(key, ip, ebpf.UpdateAny)
+				operr = m.Update(key, unsafe.Pointer(&ips), ebpf.UpdateAny)
 				if operr != nil {
 					return
 				}
@@ -19,6 +19,7 @@ import (
 
 	"github.com/openservicemesh/osm/pkg/cni/config"
 	"github.com/openservicemesh/osm/pkg/cni/kube"
+	configClientset "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned"
 )
 
 var (
@@ -29,6 +30,7 @@ var (
 func Run(disableWatcher, skip bool, cniReady chan struct{}, stop chan struct{}) error {
 	var err error
 	var client kubernetes.Interface
+	var configClient configClientset.Interface
 
 	// create and check start up configuration
 	err = NewOptions()
@@ -42,10 +44,15 @@ func Run(disableWatcher, skip bool, cniReady chan struct{}, stop chan struct{})
 		return fmt.Errorf("create client error: %v", err)
 	}
 
+	configClient, err = kube.GetConfigClientWithFile(config.KubeConfig, config.Context)
+	if err != nil {
+		return fmt.Errorf("create config client error: %v", err)
+	}
+
 	disableWatch = disableWatcher
 
 	// run local ip controller
-	if err = runLocalPodController(skip, client, stop); err != nil {
+	if err = runLocalPodController(skip, client, configClient, stop); err != nil {
 		return fmt.Errorf("run local ip controller error: %v", err)
 	}
 
@@ -14,107 +14,396 @@ package controller
 
 import (
 	"encoding/binary"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"net"
+	"os"
+	"sync"
 	"unsafe"
 
+	"github.com/cilium/ebpf/perf"
+	log "github.com/sirupsen/logrus"
+
 	"github.com/openservicemesh/osm/pkg/cni/controller/helpers"
 )
 
-const debug = false
-
-// IPAddr supports IPv6
-type IPAddr [4]byte
+// IPAddr supports IPv6, IPv4 addresses are stored in the last 4 bytes like the ebpf maps do.
+type IPAddr [16]byte
 
 func (ip *IPAddr) String() string {
-	return net.IPv4(ip[0], ip[1], ip[2], ip[3]).String()
-}
-
-// PodConfig is the config of meshed pod
-type PodConfig struct {
-	StatusPort       uint16
-	Pad              uint16             // pad `json:"-"`
-	ExcludeOutRanges [maxItemLen]cidr   `json:"-"`
-	IncludeOutRanges [maxItemLen]cidr   `json:"-"`
-	IncludeInPorts   [maxItemLen]uint16 `json:"-"`
-	IncludeOutPorts  [maxItemLen]uint16 `json:"-"`
-	ExcludeInPorts   [maxItemLen]uint16 `json:"-"`
-	ExcludeOutPorts  [maxItemLen]uint16 `json:"-"`
-}
-
-func tracePodFibMap() {
-	if !debug {
-		return
-	}
-	localPodIpsMap := helpers.GetPodFibMap()
-	entries := localPodIpsMap.Iterate()
-	key := make([]byte, 16)
-	value := PodConfig{}
-	fmt.Println("-----------[osm_pod_fib]-----------")
-	for entries.Next(unsafe.Pointer(&key[0]), unsafe.Pointer(&value)) {
-		ipv4 := net.IPv4(key[12], key[13], key[14], key[15])
-		bytes, _ := json.Marshal(value)
-		fmt.Println("GetPodFibMap.Iterate:", ipv4.String(), string(bytes))
+	return ipString(ip[:])
+}
+
+// ipString returns the string of an ip address read from the ebpf maps.
+func ipString(ip []byte) string {
+	for _, b := range ip[:12] {
+		if b != 0 {
+			return net.IP(ip).String()
+		}
+	}
+	return net.IPv4(ip[12], ip[13], ip[14], ip[15]).String()
+}
+
+// ipStringOrEmpty returns the string of an ip address read from the ebpf maps, or an empty string if it is not set.
+func ipStringOrEmpty(ip []byte) string {
+	for _, b := range ip {
+		if b != 0 {
+			return ipString(ip)
+		}
+	}
+	return ""
+}
+
+// ntohs converts a port in network order read from the ebpf maps
+func ntohs(port uint16) uint16 {
+	b := (*[2]byte)(unsafe.Pointer(&port))
+	return binary.BigEndian.Uint16(b[:])
+}
+
+func (c *cidr) String() string {
+	ip := make([]byte, 16)
+	for i, n := range c.net {
+		*(*uint32)(unsafe.Pointer(&ip[i*4])) = n
+	}
+	for _, b := range ip[:12] {
+		if b != 0 || c.mask < 96 {
+			return fmt.Sprintf("%s/%d", net.IP(ip).String(), c.mask)
+		}
+	}
+	return fmt.Sprintf("%s/%d", net.IPv4(ip[12], ip[13], ip[14], ip[15]).String(), c.mask-96)
+}
+
+// PodFibEntry is an entry of the osm_pod_fib map
+type PodFibEntry struct {
+	IP               string   `json:"ip"`
+	StatusPort       uint16   `json:"statusPort"`
+	ExcludeOutRanges []string `json:"excludeOutRanges,omitempty"`
+	IncludeOutRanges []string `json:"includeOutRanges,omitempty"`
+	IncludeInPorts   []uint16 `json:"includeInPorts,omitempty"`
+	IncludeOutPorts  []uint16 `json:"includeOutPorts,omitempty"`
+	ExcludeInPorts   []uint16 `json:"excludeInPorts,omitempty"`
+	ExcludeOutPorts  []uint16 `json:"excludeOutPorts,omitempty"`
+}
+
+func listPorts(ports [maxItemLen]uint16) []uint16 {
+	var list []uint16
+	for _, p := range ports {
+		if p != 0 {
+			list = append(list, p)
+		}
+	}
+	return list
+}
+
+func listRanges(ranges [maxItemLen]cidr) []string {
+	var list []string
+	for _, r := range ranges {
+		if r.net != [4]uint32{} {
+			list = append(list, r.String())
+		}
 	}
+	return list
 }
 
-// Pair 4 tuples
-type Pair struct {
-	srcIP   []byte
-	dstIP   []byte
-	srcPort uint16
-	dstPort uint16
+func listPodFibMap() ([]PodFibEntry, error) {
+	m := helpers.GetPodFibMap()
+	if m == nil {
+		return nil, errors.New("osm_pod_fib map is not loaded")
+	}
+	var list []PodFibEntry
+	key := IPAddr{}
+	value := podConfig{}
+	entries := m.Iterate()
+	for entries.Next(unsafe.Pointer(&key[0]), unsafe.Pointer(&value)) {
+		list = append(list, PodFibEntry{
+			IP:               key.String(),
+			StatusPort:       value.statusPort,
+			ExcludeOutRanges: listRanges(value.excludeOutRanges),
+			IncludeOutRanges: listRanges(value.includeOutRanges),
+			IncludeInPorts:   listPorts(value.includeInPorts),
+			IncludeOutPorts:  listPorts(value.includeOutPorts),
+			ExcludeInPorts:   listPorts(value.excludeInPorts),
+			ExcludeOutPorts:  listPorts(value.excludeOutPorts),
+		})
+	}
+	return list, entries.Err()
 }
 
-func (p *Pair) String() string {
-	srcIP := net.IPv4(p.srcIP[12], p.srcIP[13], p.srcIP[14], p.srcIP[15])
-	dstIP := net.IPv4(p.dstIP[12], p.dstIP[13], p.dstIP[14], p.dstIP[15])
-	return fmt.Sprintf(`Pair{ %s:%d->%s:%d }`, srcIP.String(), p.srcPort, dstIP.String(), p.dstPort)
+// pair is the key of the osm_nat_fib map
+type pair struct {
+	srcIP   IPAddr
+	dstIP   IPAddr
+	srcPort uint16 // network order
+	dstPort uint16 // network order
 }
 
-// OriginInfo is a wrapper for the original request information.
-type OriginInfo struct {
-	dstIP   []byte
-	procID  uint32
-	dstPort uint16
+// originInfo is the value of the osm_nat_fib map
+type originInfo struct {
+	ip   IPAddr
+	pid  uint32
+	port uint16 // network order
 	// last bit means that ip of process is detected.
 	flags uint16
 }
 
-func (g *OriginInfo) String() string {
-	ip := net.IPv4(g.dstIP[12], g.dstIP[13], g.dstIP[14], g.dstIP[15])
-	return fmt.Sprintf(`Origin{ Proc[%d] DstIP[%s] DstPort[%d] Flags[%d] }`, g.procID, ip.String(), g.dstPort, g.flags)
+// NatFibEntry is an entry of the osm_nat_fib map, the original destination of a redirected connection
+type NatFibEntry struct {
+	SrcIP      string `json:"srcIP"`
+	SrcPort    uint16 `json:"srcPort"`
+	DstIP      string `json:"dstIP"`
+	DstPort    uint16 `json:"dstPort"`
+	OriginIP   string `json:"originIP"`
+	OriginPort uint16 `json:"originPort"`
+	PID        uint32 `json:"pid"`
+	Flags      uint16 `json:"flags"`
+}
+
+func listNatFibMap() ([]NatFibEntry, error) {
+	m := helpers.GetNatFibMap()
+	if m == nil {
+		return nil, errors.New("osm_nat_fib map is not loaded")
+	}
+	var list []NatFibEntry
+	key := pair{}
+	value := originInfo{}
+	entries := m.Iterate()
+	for entries.Next(unsafe.Pointer(&key), unsafe.Pointer(&value)) {
+		list = append(list, NatFibEntry{
+			SrcIP:      key.srcIP.String(),
+			SrcPort:    ntohs(key.srcPort),
+			DstIP:      key.dstIP.String(),
+			DstPort:    ntohs(key.dstPort),
+			OriginIP:   value.ip.String(),
+			OriginPort: ntohs(value.port),
+			PID:        value.pid,
+			Flags:      value.flags,
+		})
+	}
+	return list, entries.Err()
+}
+
+// cgroupInfo is the value of the osm_cgr_fib map
+type cgroupInfo struct {
+	id            uint64
+	isInMesh      uint32
+	ip            IPAddr
+	ip6           IPAddr
+	flags         uint16
+	detectedFlags uint16
+}
+
+// CgrFibEntry is an entry of the osm_cgr_fib map, the cached mesh membership and ip addresses of a cgroup
+type CgrFibEntry struct {
+	CgroupID      uint64 `json:"cgroupID"`
+	InMesh        bool   `json:"inMesh"`
+	IP            string `json:"ip,omitempty"`
+	IP6           string `json:"ip6,omitempty"`
+	Flags         uint16 `json:"flags"`
+	DetectedFlags uint16 `json:"detectedFlags"`
+}
+
+func listCgrFibMap() ([]CgrFibEntry, error) {
+	m := helpers.GetCgrFibMap()
+	if m == nil {
+		return nil, errors.New("osm_cgr_fib map is not loaded")
+	}
+	var list []CgrFibEntry
+	var key uint64
+	value := cgroupInfo{}
+	entries := m.Iterate()
+	for entries.Next(unsafe.Pointer(&key), unsafe.Pointer(&value)) {
+		list = append(list, CgrFibEntry{
+			CgroupID:      key,
+			InMesh:        value.isInMesh != 0,
+			IP:            ipStringOrEmpty(value.ip[:]),
+			IP6:           ipStringOrEmpty(value.ip6[:]),
+			Flags:         value.flags,
+			DetectedFlags: value.detectedFlags,
+		})
+	}
+	return list, entries.Err()
+}
+
+// MarkFibEntry is an entry of the osm_mark_fib map, the ip addresses of a pod network namespace
+type MarkFibEntry struct {
+	Mark uint32 `json:"mark"`
+	IP   string `json:"ip,omitempty"`
+	IP6  string `json:"ip6,omitempty"`
+}
+
+func listMarkFibMap() ([]MarkFibEntry, error) {
+	m := helpers.GetMarkFibMap()
+	if m == nil {
+		return nil, errors.New("osm_mark_fib map is not loaded")
+	}
+	var list []MarkFibEntry
+	var key uint32
+	value := struct {
+		ip  IPAddr
+		ip6 IPAddr
+	}{}
+	entries := m.Iterate()
+	for entries.Next(unsafe.Pointer(&key), unsafe.Pointer(&value)) {
+		list = append(list, MarkFibEntry{
+			Mark: key,
+			IP:   ipStringOrEmpty(value.ip[:]),
+			IP6:  ipStringOrEmpty(value.ip6[:]),
+		})
+	}
+	return list, entries.Err()
+}
+
+// The redirect decisions of the connect and sendmsg programs and the reasons of the bypass decisions, see bpf/headers/trace.h
+const (
+	traceBypass           = 0
+	traceAppToSidecar     = 1
+	traceSidecarToApp     = 2
+	traceSidecarToSidecar = 3
+	traceAppToDNSProxy    = 4
+
+	traceReasonLocalhost          = 1
+	traceReasonExcludeOutPort     = 2
+	traceReasonExcludeOutRange    = 3
+	traceReasonNotIncludeOutPort  = 4
+	traceReasonNotIncludeOutRange = 5
+	traceReasonDstNotInNode       = 6
+	traceReasonExcludeInPort      = 7
+	traceReasonNotIncludeInPort   = 8
+	traceReasonDNSMultiServer     = 9
+)
+
+var traceDecisions = map[uint8]string{
+	traceBypass:           "bypass",
+	traceAppToSidecar:     "app-to-sidecar",
+	traceSidecarToApp:     "sidecar-to-app",
+	traceSidecarToSidecar: "sidecar-to-sidecar",
+	traceAppToDNSProxy:    "app-to-dns-proxy",
+}
+
+var traceReasons = map[uint8]string{
+	traceReasonLocalhost:          "localhost",
+	traceReasonExcludeOutPort:     "outbound port excluded",
+	traceReasonExcludeOutRange:    "outbound ip range excluded",
+	traceReasonNotIncludeOutPort:  "outbound port not included",
+	traceReasonNotIncludeOutRange: "outbound ip range not included",
+	traceReasonDstNotInNode:       "destination not in node",
+	traceReasonExcludeInPort:      "inbound port excluded",
+	traceReasonNotIncludeInPort:   "inbound port not included",
+	traceReasonDNSMultiServer:     "socket queries another DNS server",
+}
+
+// traceEvent is a redirect decision emitted by the connect programs to the osm_trace_events map
+type traceEvent struct {
+	srcIP        IPAddr
+	dstIP        IPAddr
+	dstPort      uint16 // network order
+	redirectPort uint16 // network order
+	pid          uint32
+	decision     uint8
+	reason       uint8
+	family       uint8
+	_            uint8 // pad
 }
 
-func traceNatFibMap() {
-	if !debug {
-		return
+// TraceEvent is a redirect decision of the connect programs
+type TraceEvent struct {
+	SrcIP        string `json:"srcIP,omitempty"`
+	DstIP        string `json:"dstIP"`
+	DstPort      uint16 `json:"dstPort"`
+	RedirectPort uint16 `json:"redirectPort,omitempty"`
+	PID          uint32 `json:"pid"`
+	Decision     string `json:"decision"`
+	Reason       string `json:"reason,omitempty"`
+	// Lost is the number of events dropped by the kernel because they were not read in time
+	Lost uint64 `json:"lost,omitempty"`
+}
+
+func newTraceEvent(ev *traceEvent) TraceEvent {
+	decision, ok := traceDecisions[ev.decision]
+	if !ok {
+		decision = fmt.Sprintf("unknown(%d)", ev.decision)
 	}
-	pairOriginalMap := helpers.GetNatFibMap()
-	entries := pairOriginalMap.Iterate()
-	key := make([]byte, 36)
-	value := make([]byte, 24)
+	return TraceEvent{
+		SrcIP:        ipStringOrEmpty(ev.srcIP[:]),
+		DstIP:        ev.dstIP.String(),
+		DstPort:      ntohs(ev.dstPort),
+		RedirectPort: ntohs(ev.redirectPort),
+		PID:          ev.pid,
+		Decision:     decision,
+		Reason:       traceReasons[ev.reason],
+	}
+}
 
-	pair := Pair{}
-	pair.srcIP = make([]byte, 16)
-	pair.dstIP = make([]byte, 16)
+// tracer fans out the redirect decisions read from the osm_trace_events map to its subscribers. The map is only
+// read while there are subscribers, the kernel drops the events when no reader is attached.
+type tracer struct {
+	sync.Mutex
+	reader      *perf.Reader
+	subscribers map[chan TraceEvent]struct{}
+}
+
+var decisionTracer = &tracer{subscribers: make(map[chan TraceEvent]struct{})}
 
-	origin := OriginInfo{}
-	origin.dstIP = make([]byte, 16)
+// subscribe returns a channel receiving the redirect decisions, events are dropped when the channel is full.
+func (t *tracer) subscribe() (chan TraceEvent, error) {
+	t.Lock()
+	defer t.Unlock()
+	if t.reader == nil {
+		m := helpers.GetTraceEventsMap()
+		if m == nil {
+			return nil, errors.New("osm_trace_events map is not loaded")
+		}
+		reader, err := perf.NewReader(m, os.Getpagesize()*16)
+		if err != nil {
+			return nil, fmt.Errorf("read osm_trace_events error: %v", err)
+		}
+		t.reader = reader
+		go t.run(reader)
+	}
+	ch := make(chan TraceEvent, 256)
+	t.subscribers[ch] = struct{}{}
+	return ch, nil
+}
 
-	fmt.Println("-----------[osm_nat_fib]-----------")
-	for entries.Next(unsafe.Pointer(&key[0]), unsafe.Pointer(&value[0])) {
-		copy(pair.srcIP, key[0:16])
-		copy(pair.dstIP, key[16:32])
-		pair.srcPort = binary.BigEndian.Uint16(key[32:34])
-		pair.dstPort = binary.BigEndian.Uint16(key[34:36])
+// unsubscribe stops sending the redirect decisions to the channel, the reader is closed with the last subscriber.
+func (t *tracer) unsubscribe(ch chan TraceEvent) {
+	t.Lock()
+	defer t.Unlock()
+	delete(t.subscribers, ch)
+	if len(t.subscribers) == 0 && t.reader != nil {
+		_ = t.reader.Close()
+		t.reader = nil
+	}
+}
 
-		copy(origin.dstIP, value[0:16])
-		origin.procID = binary.BigEndian.Uint32(value[16:20])
-		origin.dstPort = binary.BigEndian.Uint16(value[20:22])
-		origin.flags = binary.BigEndian.Uint16(value[22:24])
+func (t *tracer) run(reader *perf.Reader) {
+	for {
+		record, err := reader.Read()
+		if err != nil {
+			if !errors.Is(err, perf.ErrClosed) {
+				log.Errorf("read osm_trace_events error: %v", err)
+			}
+			return
+		}
+		var ev TraceEvent
+		if record.LostSamples > 0 {
+			ev = TraceEvent{Lost: record.LostSamples}
+		} else if len(record.RawSample) >= int(unsafe.Sizeof(traceEvent{})) {
+			ev = newTraceEvent((*traceEvent)(unsafe.Pointer(&record.RawSample[0])))
+		} else {
+			continue
+		}
+		t.publish(ev)
+	}
+}
 
-		fmt.Println("GetNatFibMap.Iterate:", pair.String(), origin.String())
+func (t *tracer) publish(ev TraceEvent) {
+	t.Lock()
+	defer t.Unlock()
+	for ch := range t.subscribers {
+		select {
+		case ch <- ev:
+		default:
+		}
 	}
 }
@@ -0,0 +1,95 @@
+package controller
+
+import (
+	"net"
+	"testing"
+	"unsafe"
+
+	tassert "github.com/stretchr/testify/assert"
+)
+
+func htons(port uint16) uint16 {
+	var n uint16
+	b := (*[2]byte)(unsafe.Pointer(&n))
+	b[0], b[1] = byte(port>>8), byte(port)
+	return n
+}
+
+func ipAddr(ip string) IPAddr {
+	addr := IPAddr{}
+	parsed := net.ParseIP(ip)
+	if ip4 := parsed.To4(); ip4 != nil {
+		copy(addr[12:], ip4)
+	} else {
+		copy(addr[:], parsed)
+	}
+	return addr
+}
+
+func TestCidrString(t *testing.T) {
+	a := tassert.New(t)
+
+	ranges := getIPRangesFromList([]string{"10.0.0.0/8", "fd00::/8", "0.0.0.0/0"})
+	a.Len(ranges, 3)
+	a.Equal("10.0.0.0/8", ranges[0].String())
+	a.Equal("fd00::/8", ranges[1].String())
+
+	var list [maxItemLen]cidr
+	copy(list[:], ranges)
+	// unset ranges are not listed
+	a.Equal([]string{"10.0.0.0/8", "fd00::/8"}, listRanges(list))
+}
+
+func TestNewTraceEvent(t *testing.T) {
+	a := tassert.New(t)
+
+	ev := newTraceEvent(&traceEvent{
+		srcIP:        ipAddr("10.244.1.12"),
+		dstIP:        ipAddr("10.96.0.20"),
+		dstPort:      htons(14001),
+		redirectPort: htons(15001),
+		pid:          42,
+		decision:     traceAppToSidecar,
+	})
+	a.Equal(TraceEvent{
+		SrcIP:        "10.244.1.12",
+		DstIP:        "10.96.0.20",
+		DstPort:      14001,
+		RedirectPort: 15001,
+		PID:          42,
+		Decision:     "app-to-sidecar",
+	}, ev)
+
+	ev = newTraceEvent(&traceEvent{
+		dstIP:    ipAddr("fd00::20"),
+		dstPort:  htons(6379),
+		decision: traceBypass,
+		reason:   traceReasonExcludeOutPort,
+	})
+	a.Equal("", ev.SrcIP)
+	a.Equal("fd00::20", ev.DstIP)
+	a.Equal(uint16(6379), ev.DstPort)
+	a.Equal("bypass", ev.Decision)
+	a.Equal("outbound port excluded", ev.Reason)
+
+	ev = newTraceEvent(&traceEvent{
+		dstIP:    ipAddr("10.96.0.10"),
+		dstPort:  htons(53),
+		decision: traceBypass,
+		reason:   traceReasonDNSMultiServer,
+	})
+	a.Equal("bypass", ev.Decision)
+	a.Equal("socket queries another DNS server", ev.Reason)
+
+	a.Equal("unknown(9)", newTraceEvent(&traceEvent{decision: 9}).Decision)
+}
+
+func TestTraceEventSize(t *testing.T) {
+	a := tassert.New(t)
+
+	// sizes of the structs of bpf/headers
+	a.Equal(uintptr(44), unsafe.Sizeof(traceEvent{}))
+	a.Equal(uintptr(36), unsafe.Sizeof(pair{}))
+	a.Equal(uintptr(24), unsafe.Sizeof(originInfo{}))
+	a.Equal(uintptr(48), unsafe.Sizeof(cgroupInfo{}))
+}
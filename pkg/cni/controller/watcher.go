@@ -22,15 +22,22 @@ import (
 	"k8s.io/client-go/tools/cache"
 
 	"github.com/openservicemesh/osm/pkg/cni/config"
+	configClientset "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned"
+	configInformers "github.com/openservicemesh/osm/pkg/gen/client/config/informers/externalversions"
 )
 
 type watcher struct {
 	Client          kubernetes.Interface
+	ConfigClient    configClientset.Interface
 	CurrentNodeName string
 	OnAddFunc       func(obj interface{})
 	OnUpdateFunc    func(oldObj, newObj interface{})
 	OnDeleteFunc    func(obj interface{})
-	Stop            chan struct{}
+
+	// Namespace and MeshConfig handlers, the policies of the pods are recomputed when they change
+	NamespaceHandler  cache.ResourceEventHandler
+	MeshConfigHandler cache.ResourceEventHandler
+	Stop              chan struct{}
 }
 
 func (w *watcher) start() error {
@@ -51,6 +58,24 @@ func (w *watcher) start() error {
 		DeleteFunc: w.OnDeleteFunc,
 	})
 	kubeInformerFactory.Start(w.Stop)
+
+	if w.NamespaceHandler != nil {
+		nsInformerFactory := kubeinformer.NewSharedInformerFactory(w.Client, 30*time.Second)
+		_, _ = nsInformerFactory.Core().V1().Namespaces().Informer().AddEventHandler(w.NamespaceHandler)
+		nsInformerFactory.Start(w.Stop)
+	}
+
+	if w.ConfigClient != nil && w.MeshConfigHandler != nil {
+		configInformerFactory := configInformers.NewSharedInformerFactoryWithOptions(
+			w.ConfigClient, 30*time.Second,
+			configInformers.WithNamespace(config.OsmNamespace),
+			configInformers.WithTweakListOptions(func(o *metav1.ListOptions) {
+				o.FieldSelector = fields.OneTermEqualSelector("metadata.name", config.MeshConfigName).String()
+			}),
+		)
+		_, _ = configInformerFactory.Config().V1alpha2().MeshConfigs().Informer().AddEventHandler(w.MeshConfigHandler)
+		configInformerFactory.Start(w.Stop)
+	}
 	return nil
 }
 
@@ -60,11 +85,14 @@ func (w *watcher) shutdown() {
 
 func newWatcher(watch watcher) *watcher {
 	return &watcher{
-		Client:          watch.Client,
-		CurrentNodeName: watch.CurrentNodeName,
-		OnAddFunc:       watch.OnAddFunc,
-		OnUpdateFunc:    watch.OnUpdateFunc,
-		OnDeleteFunc:    watch.OnDeleteFunc,
-		Stop:            make(chan struct{}),
+		Client:            watch.Client,
+		ConfigClient:      watch.ConfigClient,
+		CurrentNodeName:   watch.CurrentNodeName,
+		OnAddFunc:         watch.OnAddFunc,
+		OnUpdateFunc:      watch.OnUpdateFunc,
+		OnDeleteFunc:      watch.OnDeleteFunc,
+		NamespaceHandler:  watch.NamespaceHandler,
+		MeshConfigHandler: watch.MeshConfigHandler,
+		Stop:              make(chan struct{}),
 	}
 }
@@ -19,6 +19,8 @@ import (
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
+
+	configClientset "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned"
 )
 
 func getK8sConfigConfigWithFile(kubeconfig, context string) *rest.Config {
@@ -63,3 +65,12 @@ func GetKubernetesClientWithFile(kubeconfig, context string) (kubernetes.Interfa
 	}
 	return clientset, err
 }
+
+// GetConfigClientWithFile returns OSM config client.
+func GetConfigClientWithFile(kubeconfig, context string) (configClientset.Interface, error) {
+	clientset, err := configClientset.NewForConfig(getK8sConfigConfigWithFile(kubeconfig, context))
+	if err != nil {
+		return nil, err
+	}
+	return clientset, err
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -15,6 +16,7 @@ import (
 	"github.com/openservicemesh/osm/pkg/auth"
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/errcode"
+	"github.com/openservicemesh/osm/pkg/ratelimit"
 	"github.com/openservicemesh/osm/pkg/trafficpolicy"
 )
 
@@ -83,6 +85,14 @@ func (c *Client) IsEgressEnabled() bool {
 	return c.getMeshConfig().Spec.Traffic.EnableEgress
 }
 
+// GetPolicyConflictMode returns how policies conflicting with existing policies are admitted, defaulting to rejecting them
+func (c *Client) GetPolicyConflictMode() configv1alpha2.PolicyConflictMode {
+	if mode := c.getMeshConfig().Spec.Traffic.PolicyConflictMode; mode != "" {
+		return mode
+	}
+	return configv1alpha2.PolicyConflictModeReject
+}
+
 // IsDebugServerEnabled determines whether osm debug HTTP server is enabled
 func (c *Client) IsDebugServerEnabled() bool {
 	return c.getMeshConfig().Spec.Observability.EnableDebugServer
@@ -373,6 +383,44 @@ func (c *Client) GetCertKeyBitSize() int {
 	return bitSize
 }
 
+// IsSpiffeEnabled returns whether service certificates carry the SPIFFE ID of the workload
+func (c *Client) IsSpiffeEnabled() bool {
+	return c.getMeshConfig().Spec.Certificate.SpiffeEnabled
+}
+
+// GetFederatedTrustDomains returns the names of the SPIFFE trust domains federated with the mesh, sorted by name
+func (c *Client) GetFederatedTrustDomains() []string {
+	var trustDomains []string
+	for trustDomain := range c.GetFederatedTrustBundles() {
+		trustDomains = append(trustDomains, trustDomain)
+	}
+	sort.Strings(trustDomains)
+	return trustDomains
+}
+
+// GetFederatedTrustBundles returns the PEM encoded trust bundles of the SPIFFE trust domains federated with the mesh,
+// keyed by trust domain. Federation requires SPIFFE IDs, as a peer certificate is validated against the trust bundle
+// of the trust domain of its SPIFFE ID, so no trust domain is federated when SPIFFE is disabled.
+func (c *Client) GetFederatedTrustBundles() map[string]string {
+	federatedTrustDomains := c.getMeshConfig().Spec.Certificate.FederatedTrustDomains
+	if len(federatedTrustDomains) == 0 {
+		return nil
+	}
+	if !c.IsSpiffeEnabled() {
+		log.Warn().Msg("Federated trust domains are ignored as SPIFFE is disabled")
+		return nil
+	}
+	trustBundles := make(map[string]string)
+	for _, federated := range federatedTrustDomains {
+		if federated.TrustBundle == "" {
+			log.Warn().Msgf("Federated trust domain %s has no trust bundle", federated.TrustDomain)
+			continue
+		}
+		trustBundles[federated.TrustDomain] = federated.TrustBundle
+	}
+	return trustBundles
+}
+
 // IsPrivilegedInitContainer returns whether init containers should be privileged
 func (c *Client) IsPrivilegedInitContainer() bool {
 	return c.getMeshConfig().Spec.Sidecar.EnablePrivilegedInitContainer
@@ -416,6 +464,31 @@ func (c *Client) GetInboundExternalAuthConfig() auth.ExtAuthConfig {
 	return extAuthConfig
 }
 
+// GetGlobalRateLimitConfig returns the global rate limit service configuration, if any
+func (c *Client) GetGlobalRateLimitConfig() ratelimit.GlobalRateLimitConfig {
+	rateLimitMeshConfig := c.getMeshConfig().Spec.Traffic.GlobalRateLimitService
+
+	rateLimitConfig := ratelimit.GlobalRateLimitConfig{
+		Enable:           rateLimitMeshConfig.Enable,
+		Address:          rateLimitMeshConfig.Address,
+		Port:             rateLimitMeshConfig.Port,
+		Domain:           rateLimitMeshConfig.Domain,
+		FailureModeAllow: rateLimitMeshConfig.FailureModeAllow,
+	}
+	if rateLimitConfig.Domain == "" {
+		rateLimitConfig.Domain = constants.DefaultGlobalRateLimitDomain
+	}
+
+	duration, err := time.ParseDuration(rateLimitMeshConfig.Timeout)
+	if err != nil {
+		log.Debug().Err(err).Msgf("GlobalRateLimitTimeout: Not a valid duration %s. defaulting to 1s.", rateLimitMeshConfig.Timeout)
+		duration = 1 * time.Second
+	}
+	rateLimitConfig.Timeout = duration
+
+	return rateLimitConfig
+}
+
 // GetFeatureFlags returns OSM's feature flags
 func (c *Client) GetFeatureFlags() configv1alpha2.FeatureFlags {
 	return c.getMeshConfig().Spec.FeatureFlags
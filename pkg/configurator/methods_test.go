@@ -12,6 +12,7 @@ import (
 	configv1alpha2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
 	testclient "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned/fake"
 	"github.com/openservicemesh/osm/pkg/k8s/informers"
+	"github.com/openservicemesh/osm/pkg/ratelimit"
 
 	"github.com/openservicemesh/osm/pkg/constants"
 )
@@ -322,6 +323,54 @@ func TestCreateUpdateConfig(t *testing.T) {
 				assert.Equal(defaultCertKeyBitSize, cfg.GetCertKeyBitSize())
 			},
 		},
+		{
+			name: "IsSpiffeEnabled",
+			initialMeshConfigData: &configv1alpha2.MeshConfigSpec{
+				Certificate: configv1alpha2.CertificateSpec{
+					SpiffeEnabled: true,
+				},
+			},
+			checkCreate: func(assert *tassert.Assertions, cfg Configurator) {
+				assert.True(cfg.IsSpiffeEnabled())
+			},
+			updatedMeshConfigData: &configv1alpha2.MeshConfigSpec{
+				Certificate: configv1alpha2.CertificateSpec{
+					SpiffeEnabled: false,
+				},
+			},
+			checkUpdate: func(assert *tassert.Assertions, cfg Configurator) {
+				assert.False(cfg.IsSpiffeEnabled())
+			},
+		},
+		{
+			name: "GetFederatedTrustDomains",
+			initialMeshConfigData: &configv1alpha2.MeshConfigSpec{
+				Certificate: configv1alpha2.CertificateSpec{
+					SpiffeEnabled: true,
+					FederatedTrustDomains: []configv1alpha2.FederatedTrustDomainSpec{
+						{TrustDomain: "example.org", TrustBundle: "bundle-2"},
+						{TrustDomain: "example.com", TrustBundle: "bundle-1"},
+						{TrustDomain: "no-bundle.com"},
+					},
+				},
+			},
+			checkCreate: func(assert *tassert.Assertions, cfg Configurator) {
+				assert.Equal([]string{"example.com", "example.org"}, cfg.GetFederatedTrustDomains())
+				assert.Equal(map[string]string{"example.com": "bundle-1", "example.org": "bundle-2"}, cfg.GetFederatedTrustBundles())
+			},
+			// Federation requires SPIFFE IDs
+			updatedMeshConfigData: &configv1alpha2.MeshConfigSpec{
+				Certificate: configv1alpha2.CertificateSpec{
+					FederatedTrustDomains: []configv1alpha2.FederatedTrustDomainSpec{
+						{TrustDomain: "example.com", TrustBundle: "bundle-1"},
+					},
+				},
+			},
+			checkUpdate: func(assert *tassert.Assertions, cfg Configurator) {
+				assert.Empty(cfg.GetFederatedTrustDomains())
+				assert.Empty(cfg.GetFederatedTrustBundles())
+			},
+		},
 		{
 			name: "IsPrivilegedInitContainer",
 			initialMeshConfigData: &configv1alpha2.MeshConfigSpec{
@@ -375,6 +424,53 @@ func TestCreateUpdateConfig(t *testing.T) {
 				assert.Equal(interval, time.Duration(0))
 			},
 		},
+		{
+			name:                  "GetGlobalRateLimitConfig",
+			initialMeshConfigData: &configv1alpha2.MeshConfigSpec{},
+			checkCreate: func(assert *tassert.Assertions, cfg Configurator) {
+				assert.Equal(ratelimit.GlobalRateLimitConfig{
+					Domain:  constants.DefaultGlobalRateLimitDomain,
+					Timeout: time.Second,
+				}, cfg.GetGlobalRateLimitConfig())
+			},
+			updatedMeshConfigData: &configv1alpha2.MeshConfigSpec{
+				Traffic: configv1alpha2.TrafficSpec{
+					GlobalRateLimitService: configv1alpha2.GlobalRateLimitServiceSpec{
+						Enable:           true,
+						Address:          "ratelimit.ratelimit.svc.cluster.local",
+						Port:             8081,
+						Domain:           "mesh",
+						Timeout:          "250ms",
+						FailureModeAllow: true,
+					},
+				},
+			},
+			checkUpdate: func(assert *tassert.Assertions, cfg Configurator) {
+				assert.Equal(ratelimit.GlobalRateLimitConfig{
+					Enable:           true,
+					Address:          "ratelimit.ratelimit.svc.cluster.local",
+					Port:             8081,
+					Domain:           "mesh",
+					Timeout:          250 * time.Millisecond,
+					FailureModeAllow: true,
+				}, cfg.GetGlobalRateLimitConfig())
+			},
+		},
+		{
+			name:                  "GetPolicyConflictMode",
+			initialMeshConfigData: &configv1alpha2.MeshConfigSpec{},
+			checkCreate: func(assert *tassert.Assertions, cfg Configurator) {
+				assert.Equal(configv1alpha2.PolicyConflictModeReject, cfg.GetPolicyConflictMode())
+			},
+			updatedMeshConfigData: &configv1alpha2.MeshConfigSpec{
+				Traffic: configv1alpha2.TrafficSpec{
+					PolicyConflictMode: configv1alpha2.PolicyConflictModeWarn,
+				},
+			},
+			checkUpdate: func(assert *tassert.Assertions, cfg Configurator) {
+				assert.Equal(configv1alpha2.PolicyConflictModeWarn, cfg.GetPolicyConflictMode())
+			},
+		},
 		{
 			name:                  "GetMaxDataplaneConnections",
 			initialMeshConfigData: &configv1alpha2.MeshConfigSpec{},
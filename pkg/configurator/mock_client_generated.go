@@ -11,6 +11,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	v1alpha2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
 	auth "github.com/openservicemesh/osm/pkg/auth"
+	ratelimit "github.com/openservicemesh/osm/pkg/ratelimit"
 	trafficpolicy "github.com/openservicemesh/osm/pkg/trafficpolicy"
 	v1 "k8s.io/api/core/v1"
 )
@@ -80,6 +81,34 @@ func (mr *MockConfiguratorMockRecorder) GetFeatureFlags() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeatureFlags", reflect.TypeOf((*MockConfigurator)(nil).GetFeatureFlags))
 }
 
+// GetFederatedTrustBundles mocks base method.
+func (m *MockConfigurator) GetFederatedTrustBundles() map[string]string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFederatedTrustBundles")
+	ret0, _ := ret[0].(map[string]string)
+	return ret0
+}
+
+// GetFederatedTrustBundles indicates an expected call of GetFederatedTrustBundles.
+func (mr *MockConfiguratorMockRecorder) GetFederatedTrustBundles() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFederatedTrustBundles", reflect.TypeOf((*MockConfigurator)(nil).GetFederatedTrustBundles))
+}
+
+// GetFederatedTrustDomains mocks base method.
+func (m *MockConfigurator) GetFederatedTrustDomains() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFederatedTrustDomains")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// GetFederatedTrustDomains indicates an expected call of GetFederatedTrustDomains.
+func (mr *MockConfiguratorMockRecorder) GetFederatedTrustDomains() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFederatedTrustDomains", reflect.TypeOf((*MockConfigurator)(nil).GetFederatedTrustDomains))
+}
+
 // GetGlobalPluginChains mocks base method.
 func (m *MockConfigurator) GetGlobalPluginChains() map[string][]trafficpolicy.Plugin {
 	m.ctrl.T.Helper()
@@ -94,6 +123,20 @@ func (mr *MockConfiguratorMockRecorder) GetGlobalPluginChains() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGlobalPluginChains", reflect.TypeOf((*MockConfigurator)(nil).GetGlobalPluginChains))
 }
 
+// GetGlobalRateLimitConfig mocks base method.
+func (m *MockConfigurator) GetGlobalRateLimitConfig() ratelimit.GlobalRateLimitConfig {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGlobalRateLimitConfig")
+	ret0, _ := ret[0].(ratelimit.GlobalRateLimitConfig)
+	return ret0
+}
+
+// GetGlobalRateLimitConfig indicates an expected call of GetGlobalRateLimitConfig.
+func (mr *MockConfiguratorMockRecorder) GetGlobalRateLimitConfig() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGlobalRateLimitConfig", reflect.TypeOf((*MockConfigurator)(nil).GetGlobalRateLimitConfig))
+}
+
 // GetInboundExternalAuthConfig mocks base method.
 func (m *MockConfigurator) GetInboundExternalAuthConfig() auth.ExtAuthConfig {
 	m.ctrl.T.Helper()
@@ -235,6 +278,20 @@ func (mr *MockConfiguratorMockRecorder) GetOSMNamespace() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOSMNamespace", reflect.TypeOf((*MockConfigurator)(nil).GetOSMNamespace))
 }
 
+// GetPolicyConflictMode mocks base method.
+func (m *MockConfigurator) GetPolicyConflictMode() v1alpha2.PolicyConflictMode {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPolicyConflictMode")
+	ret0, _ := ret[0].(v1alpha2.PolicyConflictMode)
+	return ret0
+}
+
+// GetPolicyConflictMode indicates an expected call of GetPolicyConflictMode.
+func (mr *MockConfiguratorMockRecorder) GetPolicyConflictMode() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPolicyConflictMode", reflect.TypeOf((*MockConfigurator)(nil).GetPolicyConflictMode))
+}
+
 // GetProxyResources mocks base method.
 func (m *MockConfigurator) GetProxyResources() v1.ResourceRequirements {
 	m.ctrl.T.Helper()
@@ -599,6 +656,20 @@ func (mr *MockConfiguratorMockRecorder) IsRemoteLoggingEnabled() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsRemoteLoggingEnabled", reflect.TypeOf((*MockConfigurator)(nil).IsRemoteLoggingEnabled))
 }
 
+// IsSpiffeEnabled mocks base method.
+func (m *MockConfigurator) IsSpiffeEnabled() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsSpiffeEnabled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsSpiffeEnabled indicates an expected call of IsSpiffeEnabled.
+func (mr *MockConfiguratorMockRecorder) IsSpiffeEnabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsSpiffeEnabled", reflect.TypeOf((*MockConfigurator)(nil).IsSpiffeEnabled))
+}
+
 // IsTracingEnabled mocks base method.
 func (m *MockConfigurator) IsTracingEnabled() bool {
 	m.ctrl.T.Helper()
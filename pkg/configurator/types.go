@@ -11,6 +11,7 @@ import (
 
 	"github.com/openservicemesh/osm/pkg/auth"
 	"github.com/openservicemesh/osm/pkg/logger"
+	"github.com/openservicemesh/osm/pkg/ratelimit"
 	"github.com/openservicemesh/osm/pkg/trafficpolicy"
 )
 
@@ -45,6 +46,9 @@ type Configurator interface {
 	// IsEgressEnabled determines whether egress is globally enabled in the mesh or not
 	IsEgressEnabled() bool
 
+	// GetPolicyConflictMode returns how policies conflicting with existing policies are admitted
+	GetPolicyConflictMode() configv1alpha2.PolicyConflictMode
+
 	// IsDebugServerEnabled determines whether osm debug HTTP server is enabled
 	IsDebugServerEnabled() bool
 
@@ -133,6 +137,16 @@ type Configurator interface {
 	// GetCertKeyBitSize returns the certificate key bit size
 	GetCertKeyBitSize() int
 
+	// IsSpiffeEnabled returns whether service certificates carry the SPIFFE ID of the workload
+	IsSpiffeEnabled() bool
+
+	// GetFederatedTrustDomains returns the names of the SPIFFE trust domains federated with the mesh, sorted by name
+	GetFederatedTrustDomains() []string
+
+	// GetFederatedTrustBundles returns the PEM encoded trust bundles of the SPIFFE trust domains federated with the mesh,
+	// keyed by trust domain
+	GetFederatedTrustBundles() map[string]string
+
 	// IsPrivilegedInitContainer determines whether init containers should be privileged
 	IsPrivilegedInitContainer() bool
 
@@ -146,6 +160,9 @@ type Configurator interface {
 	// GetInboundExternalAuthConfig returns the External Authentication configuration for incoming traffic, if any
 	GetInboundExternalAuthConfig() auth.ExtAuthConfig
 
+	// GetGlobalRateLimitConfig returns the global rate limit service configuration, if any
+	GetGlobalRateLimitConfig() ratelimit.GlobalRateLimitConfig
+
 	// GetFeatureFlags returns OSM's feature flags
 	GetFeatureFlags() configv1alpha2.FeatureFlags
 
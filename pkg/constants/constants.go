@@ -58,6 +58,9 @@ const (
 	// DefaultOSMLogLevel is the default OSM log level if none is specified
 	DefaultOSMLogLevel = "info"
 
+	// DefaultGlobalRateLimitDomain is the default domain of the global rate limit service if none is specified
+	DefaultGlobalRateLimitDomain = "osm"
+
 	// SidecarPrometheusInboundListenerPort is Sidecar's inbound listener port number for prometheus
 	SidecarPrometheusInboundListenerPort = 15010
 
@@ -73,6 +76,9 @@ const (
 	// ValidatorWebhookPort is the port on which the resource validator webhook listens
 	ValidatorWebhookPort = 9093
 
+	// InterceptorDebugPort is the port on which osm-interceptor exposes its debug server on localhost
+	InterceptorDebugPort = 15988
+
 	// OSMControllerName is the name of the OSM Controller (formerly ADS service).
 	OSMControllerName = "osm-controller"
 
@@ -82,6 +88,9 @@ const (
 	// OSMBootstrapName is the name of the OSM Bootstrap.
 	OSMBootstrapName = "osm-bootstrap"
 
+	// OSMInterceptorName is the name of the OSM Interceptor.
+	OSMInterceptorName = "osm-interceptor"
+
 	// ProxyServerPort is the port on which the Aggregated Discovery Service (ADS) listens for new gRPC connections from Envoy proxies
 	ProxyServerPort = 15128
 
@@ -212,6 +221,39 @@ const (
 	MRCStateError = "error"
 )
 
+// Conditions used by the MeshRootCertificate during a root certificate rotation
+const (
+	// MRCConditionTypeTrusted is the condition type indicating whether the root certificate of the
+	// MeshRootCertificate is part of the trust bundle distributed to proxies
+	MRCConditionTypeTrusted = "Trusted"
+
+	// MRCConditionTypeIssuing is the condition type indicating whether the root certificate of the
+	// MeshRootCertificate signs the certificates issued to proxies
+	MRCConditionTypeIssuing = "Issuing"
+
+	// MRCConditionTypeAcknowledged is the condition type indicating whether all connected proxies
+	// have applied certificates issued for the current stage of the MeshRootCertificate
+	MRCConditionTypeAcknowledged = "Acknowledged"
+
+	// MRCConditionReasonTrustBundleRollout is the condition reason of the stage adding the new root certificate to the trust bundle
+	MRCConditionReasonTrustBundleRollout = "TrustBundleRollout"
+
+	// MRCConditionReasonIssuerRollout is the condition reason of the stage issuing certificates from the new root certificate
+	MRCConditionReasonIssuerRollout = "IssuerRollout"
+
+	// MRCConditionReasonRootRemoval is the condition reason of the stage removing the old root certificate from the trust bundle
+	MRCConditionReasonRootRemoval = "RootRemoval"
+
+	// MRCConditionReasonActive is the condition reason of an MeshRootCertificate that became the only active root certificate
+	MRCConditionReasonActive = "Active"
+
+	// MRCConditionReasonProxiesPending is the condition reason when some proxies have not acknowledged the current stage
+	MRCConditionReasonProxiesPending = "ProxiesPending"
+
+	// MRCConditionReasonProxiesAcknowledged is the condition reason when all proxies have acknowledged the current stage
+	MRCConditionReasonProxiesAcknowledged = "ProxiesAcknowledged"
+)
+
 // Labels used by the control plane
 const (
 	// IgnoreLabel is the label used to ignore a resource
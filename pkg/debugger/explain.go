@@ -0,0 +1,72 @@
+package debugger
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// explainSourceQueryKey is the query key of the source pod of the traffic to explain, as namespace/name
+	explainSourceQueryKey = "source"
+
+	// explainDestinationQueryKey is the query key of the destination pod of the traffic to explain, as namespace/name
+	explainDestinationQueryKey = "destination"
+
+	// explainPortQueryKey is the query key of the destination port of the traffic to explain
+	explainPortQueryKey = "port"
+
+	// explainMethodQueryKey is the query key of the optional HTTP method of the traffic to explain
+	explainMethodQueryKey = "method"
+
+	// explainPathQueryKey is the query key of the optional HTTP path of the traffic to explain
+	explainPathQueryKey = "path"
+)
+
+func (ds DebugConfig) getTrafficExplanationHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+
+		port, err := strconv.ParseUint(query.Get(explainPortQueryKey), 10, 16)
+		if err != nil || port == 0 {
+			http.Error(w, fmt.Sprintf("Invalid %s query parameter %q", explainPortQueryKey, query.Get(explainPortQueryKey)), http.StatusBadRequest)
+			return
+		}
+
+		source, err := ds.getPodForQuery(query.Get(explainSourceQueryKey))
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Error getting the source pod: %s", err), http.StatusBadRequest)
+			return
+		}
+		destination, err := ds.getPodForQuery(query.Get(explainDestinationQueryKey))
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Error getting the destination pod: %s", err), http.StatusBadRequest)
+			return
+		}
+
+		explanation := ds.meshCatalogDebugger.ExplainTraffic(source, destination, uint16(port), query.Get(explainMethodQueryKey), query.Get(explainPathQueryKey))
+		explanationJSON, err := json.Marshal(explanation)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error marshalling traffic explanation %+v", explanation)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		_, _ = fmt.Fprint(w, string(explanationJSON))
+	})
+}
+
+// getPodForQuery returns the pod with the given namespaced name, in the namespace/name format
+func (ds DebugConfig) getPodForQuery(namespacedName string) (*corev1.Pod, error) {
+	chunks := strings.Split(namespacedName, "/")
+	if len(chunks) != 2 || chunks[0] == "" || chunks[1] == "" {
+		return nil, fmt.Errorf("invalid pod %q, must be in the namespace/name format", namespacedName)
+	}
+	return ds.kubeClient.CoreV1().Pods(chunks[0]).Get(context.Background(), chunks[1], metav1.GetOptions{})
+}
@@ -0,0 +1,89 @@
+package debugger
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+func TestGetTrafficExplanationHandler(t *testing.T) {
+	source := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "bookbuyer", Name: "bookbuyer"}}
+	destination := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "bookstore", Name: "bookstore"}}
+
+	testCases := []struct {
+		name               string
+		query              string
+		expectExplain      bool
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name:               "traffic is explained",
+			query:              "source=bookbuyer/bookbuyer&destination=bookstore/bookstore&port=14001&method=GET&path=/books-bought",
+			expectExplain:      true,
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"source":{"namespace":"bookbuyer","name":"bookbuyer","identity":"bookbuyer.bookbuyer","meshed":true},"destination":{"namespace":"bookstore","name":"bookstore","identity":"bookstore.bookstore","meshed":true},"port":14001,"method":"GET","path":"/books-bought","allowed":true,"reason":"Permissive traffic policy mode allows traffic between all the services of meshed pods","mechanisms":[{"mechanism":"permissive","verdict":"allow","rule":"Permissive traffic policy mode allows traffic between all the services of meshed pods"}]}`,
+		},
+		{
+			name:               "invalid port",
+			query:              "source=bookbuyer/bookbuyer&destination=bookstore/bookstore&port=http",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "invalid source",
+			query:              "source=bookbuyer&destination=bookstore/bookstore&port=14001",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "destination not found",
+			query:              "source=bookbuyer/bookbuyer&destination=bookstore/bookstore-v2&port=14001",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			mockCtrl := gomock.NewController(t)
+			mock := NewMockMeshCatalogDebugger(mockCtrl)
+
+			ds := DebugConfig{
+				meshCatalogDebugger: mock,
+				kubeClient:          fake.NewSimpleClientset(source, destination),
+			}
+
+			if tc.expectExplain {
+				rule := "Permissive traffic policy mode allows traffic between all the services of meshed pods"
+				mock.EXPECT().ExplainTraffic(source, destination, uint16(14001), "GET", "/books-bought").Return(&trafficpolicy.TrafficExplanation{
+					Source:      trafficpolicy.ExplainedPod{Namespace: "bookbuyer", Name: "bookbuyer", Identity: "bookbuyer.bookbuyer", Meshed: true},
+					Destination: trafficpolicy.ExplainedPod{Namespace: "bookstore", Name: "bookstore", Identity: "bookstore.bookstore", Meshed: true},
+					Port:        14001,
+					Method:      "GET",
+					Path:        "/books-bought",
+					Allowed:     true,
+					Reason:      rule,
+					Mechanisms: []trafficpolicy.MechanismExplanation{
+						{Mechanism: trafficpolicy.ExplainMechanismPermissive, Verdict: trafficpolicy.ExplainVerdictAllow, Rule: rule},
+					},
+				})
+			}
+
+			responseRecorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodGet, "/debug/policy/explain?"+tc.query, nil)
+			ds.getTrafficExplanationHandler().ServeHTTP(responseRecorder, request)
+
+			assert.Equal(tc.expectedStatusCode, responseRecorder.Code)
+			if tc.expectedBody != "" {
+				assert.Equal(tc.expectedBody, responseRecorder.Body.String())
+			}
+		})
+	}
+}
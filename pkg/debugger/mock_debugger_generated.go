@@ -10,9 +10,12 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	certificate "github.com/openservicemesh/osm/pkg/certificate"
 	identity "github.com/openservicemesh/osm/pkg/identity"
+	trafficpolicy "github.com/openservicemesh/osm/pkg/trafficpolicy"
 	v1alpha3 "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/access/v1alpha3"
 	v1alpha4 "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/specs/v1alpha4"
 	v1alpha40 "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/split/v1alpha4"
+	v1 "k8s.io/api/core/v1"
+	v10 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // MockCertificateManagerDebugger is a mock of CertificateManagerDebugger interface.
@@ -75,6 +78,20 @@ func (m *MockMeshCatalogDebugger) EXPECT() *MockMeshCatalogDebuggerMockRecorder
 	return m.recorder
 }
 
+// ExplainTraffic mocks base method.
+func (m *MockMeshCatalogDebugger) ExplainTraffic(arg0, arg1 *v1.Pod, arg2 uint16, arg3, arg4 string) *trafficpolicy.TrafficExplanation {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExplainTraffic", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*trafficpolicy.TrafficExplanation)
+	return ret0
+}
+
+// ExplainTraffic indicates an expected call of ExplainTraffic.
+func (mr *MockMeshCatalogDebuggerMockRecorder) ExplainTraffic(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExplainTraffic", reflect.TypeOf((*MockMeshCatalogDebugger)(nil).ExplainTraffic), arg0, arg1, arg2, arg3, arg4)
+}
+
 // ListSMIPolicies mocks base method.
 func (m *MockMeshCatalogDebugger) ListSMIPolicies() ([]*v1alpha40.TrafficSplit, []identity.K8sServiceAccount, []*v1alpha4.HTTPRouteGroup, []*v1alpha3.TrafficTarget) {
 	m.ctrl.T.Helper()
@@ -91,3 +108,18 @@ func (mr *MockMeshCatalogDebuggerMockRecorder) ListSMIPolicies() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSMIPolicies", reflect.TypeOf((*MockMeshCatalogDebugger)(nil).ListSMIPolicies))
 }
+
+// PreviewPolicy mocks base method.
+func (m *MockMeshCatalogDebugger) PreviewPolicy(arg0 v10.Object) (*trafficpolicy.PolicyPreview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PreviewPolicy", arg0)
+	ret0, _ := ret[0].(*trafficpolicy.PolicyPreview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PreviewPolicy indicates an expected call of PreviewPolicy.
+func (mr *MockMeshCatalogDebuggerMockRecorder) PreviewPolicy(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreviewPolicy", reflect.TypeOf((*MockMeshCatalogDebugger)(nil).PreviewPolicy), arg0)
+}
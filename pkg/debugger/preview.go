@@ -0,0 +1,48 @@
+package debugger
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/openservicemesh/osm/pkg/policy"
+)
+
+// maxPolicyPreviewBodySize is the maximum size of the policy posted to the policy preview endpoint
+const maxPolicyPreviewBodySize = 1 << 20
+
+func (ds DebugConfig) getPolicyPreviewHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, fmt.Sprintf("Method %s not allowed, the policy to preview must be posted", r.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxPolicyPreviewBodySize))
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Error reading the policy to preview: %s", err), http.StatusBadRequest)
+			return
+		}
+		obj, err := policy.DecodePolicy(body)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Invalid policy to preview: %s", err), http.StatusBadRequest)
+			return
+		}
+
+		preview, err := ds.meshCatalogDebugger.PreviewPolicy(obj)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error previewing policy %s/%s", obj.GetNamespace(), obj.GetName())
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		previewJSON, err := json.Marshal(preview)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error marshalling policy preview %+v", preview)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		_, _ = fmt.Fprint(w, string(previewJSON))
+	})
+}
@@ -0,0 +1,84 @@
+package debugger
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+func TestGetPolicyPreviewHandler(t *testing.T) {
+	testCases := []struct {
+		name               string
+		method             string
+		body               string
+		expectPreview      bool
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name:               "policy is previewed",
+			method:             http.MethodPost,
+			body:               `{"apiVersion":"policy.openservicemesh.io/v1alpha1","kind":"Retry","metadata":{"name":"retry","namespace":"bookbuyer"}}`,
+			expectPreview:      true,
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"kind":"Retry","namespace":"bookbuyer","name":"retry","proxies":[{"namespace":"bookbuyer","name":"bookbuyer","identity":"bookbuyer.bookbuyer","changes":[{"section":"outbound","path":"trafficMatches[0].name","before":"a","after":"b"}]}],"unchangedProxies":1}`,
+		},
+		{
+			name:               "method not allowed",
+			method:             http.MethodGet,
+			expectedStatusCode: http.StatusMethodNotAllowed,
+		},
+		{
+			name:               "invalid policy",
+			method:             http.MethodPost,
+			body:               `{"apiVersion":"policy.openservicemesh.io/v1alpha1","kind":"AccessCert","metadata":{"name":"cert","namespace":"bookbuyer"}}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			mockCtrl := gomock.NewController(t)
+			mock := NewMockMeshCatalogDebugger(mockCtrl)
+
+			ds := DebugConfig{
+				meshCatalogDebugger: mock,
+			}
+
+			if tc.expectPreview {
+				mock.EXPECT().PreviewPolicy(gomock.Any()).Return(&trafficpolicy.PolicyPreview{
+					Kind:      "Retry",
+					Namespace: "bookbuyer",
+					Name:      "retry",
+					Proxies: []trafficpolicy.ProxyConfigPreview{
+						{
+							Namespace: "bookbuyer",
+							Name:      "bookbuyer",
+							Identity:  "bookbuyer.bookbuyer",
+							Changes: []trafficpolicy.ConfigChange{
+								{Section: trafficpolicy.PreviewSectionOutbound, Path: "trafficMatches[0].name", Before: "a", After: "b"},
+							},
+						},
+					},
+					UnchangedProxies: 1,
+				}, nil)
+			}
+
+			responseRecorder := httptest.NewRecorder()
+			request := httptest.NewRequest(tc.method, "/debug/policy/preview", strings.NewReader(tc.body))
+			ds.getPolicyPreviewHandler().ServeHTTP(responseRecorder, request)
+
+			assert.Equal(tc.expectedStatusCode, responseRecorder.Code)
+			if tc.expectedBody != "" {
+				assert.Equal(tc.expectedBody, responseRecorder.Body.String())
+			}
+		})
+	}
+}
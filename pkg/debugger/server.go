@@ -15,11 +15,13 @@ import (
 // GetHandlers implements DebugConfig interface and returns the rest of URLs and the handling functions.
 func (ds DebugConfig) GetHandlers(handlers map[string]http.Handler) map[string]http.Handler {
 	for url, handler := range map[string]http.Handler{
-		"/debug/certs":         ds.getCertHandler(),
-		"/debug/policies":      ds.getSMIPoliciesHandler(),
-		"/debug/config":        ds.getOSMConfigHandler(),
-		"/debug/namespaces":    ds.getMonitoredNamespacesHandler(),
-		"/debug/feature-flags": ds.getFeatureFlags(),
+		"/debug/certs":          ds.getCertHandler(),
+		"/debug/policies":       ds.getSMIPoliciesHandler(),
+		"/debug/policy/explain": ds.getTrafficExplanationHandler(),
+		"/debug/policy/preview": ds.getPolicyPreviewHandler(),
+		"/debug/config":         ds.getOSMConfigHandler(),
+		"/debug/namespaces":     ds.getMonitoredNamespacesHandler(),
+		"/debug/feature-flags":  ds.getFeatureFlags(),
 
 		// Pprof handlers
 		"/debug/pprof/":        http.HandlerFunc(pprof.Index),
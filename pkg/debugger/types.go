@@ -5,6 +5,8 @@ import (
 	access "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/access/v1alpha3"
 	spec "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/specs/v1alpha4"
 	split "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/split/v1alpha4"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 
@@ -14,6 +16,7 @@ import (
 	"github.com/openservicemesh/osm/pkg/k8s"
 	"github.com/openservicemesh/osm/pkg/logger"
 	"github.com/openservicemesh/osm/pkg/messaging"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
 )
 
 var log = logger.New("debugger")
@@ -39,4 +42,12 @@ type CertificateManagerDebugger interface {
 type MeshCatalogDebugger interface {
 	// ListSMIPolicies lists the SMI policies detected by OSM.
 	ListSMIPolicies() ([]*split.TrafficSplit, []identity.K8sServiceAccount, []*spec.HTTPRouteGroup, []*access.TrafficTarget)
+
+	// ExplainTraffic explains how the policies of the mesh apply to traffic from the source pod to the destination pod
+	// on the given port, for the given HTTP method and path when they are not empty.
+	ExplainTraffic(source, destination *corev1.Pod, port uint16, method, path string) *trafficpolicy.TrafficExplanation
+
+	// PreviewPolicy returns the impact of applying the given policy on the config of the proxies of the mesh, without
+	// applying it.
+	PreviewPolicy(metav1.Object) (*trafficpolicy.PolicyPreview, error)
 }
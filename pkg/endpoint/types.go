@@ -46,6 +46,13 @@ type Endpoint struct {
 
 	// Path is a name with which a web service is accessed.
 	Path string `json:"path,omitempty"`
+
+	// ZoneHints is the list of zones the endpoint is hinted to serve traffic for,
+	// as published by topology aware routing on the EndpointSlice.
+	ZoneHints []string `json:"zoneHints,omitempty"`
+
+	// Terminating indicates the endpoint is terminating but is still serving traffic.
+	Terminating bool `json:"terminating,omitempty"`
 }
 
 func (ep Endpoint) String() string {
@@ -0,0 +1,166 @@
+package gatewayapi
+
+import (
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayAPIv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayAPIv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/openservicemesh/osm/pkg/announcements"
+	"github.com/openservicemesh/osm/pkg/k8s"
+	"github.com/openservicemesh/osm/pkg/k8s/informers"
+	"github.com/openservicemesh/osm/pkg/messaging"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+// NewGatewayAPIController returns a gatewayapi.Controller interface related to functionality provided by the route resources
+// in the gateway.networking.k8s.io API group
+func NewGatewayAPIController(informerCollection *informers.InformerCollection, kubeController k8s.Controller, msgBroker *messaging.Broker) *Client {
+	client := &Client{
+		informers:      informerCollection,
+		kubeController: kubeController,
+	}
+
+	shouldObserve := func(obj interface{}) bool {
+		object, ok := obj.(metav1.Object)
+		if !ok {
+			return false
+		}
+		return kubeController.IsMonitoredNamespace(object.GetNamespace())
+	}
+
+	httpRouteEventTypes := k8s.EventTypes{
+		Add:    announcements.GatewayAPIHTTPRouteAdded,
+		Update: announcements.GatewayAPIHTTPRouteUpdated,
+		Delete: announcements.GatewayAPIHTTPRouteDeleted,
+	}
+	client.informers.AddEventHandler(informers.InformerKeyGatewayAPIHTTPRoute, k8s.GetEventHandlerFuncs(shouldObserve, httpRouteEventTypes, msgBroker))
+
+	grpcRouteEventTypes := k8s.EventTypes{
+		Add:    announcements.GatewayAPIGRPCRouteAdded,
+		Update: announcements.GatewayAPIGRPCRouteUpdated,
+		Delete: announcements.GatewayAPIGRPCRouteDeleted,
+	}
+	client.informers.AddEventHandler(informers.InformerKeyGatewayAPIGRPCRoute, k8s.GetEventHandlerFuncs(shouldObserve, grpcRouteEventTypes, msgBroker))
+
+	tcpRouteEventTypes := k8s.EventTypes{
+		Add:    announcements.GatewayAPITCPRouteAdded,
+		Update: announcements.GatewayAPITCPRouteUpdated,
+		Delete: announcements.GatewayAPITCPRouteDeleted,
+	}
+	client.informers.AddEventHandler(informers.InformerKeyGatewayAPITCPRoute, k8s.GetEventHandlerFuncs(shouldObserve, tcpRouteEventTypes, msgBroker))
+
+	return client
+}
+
+// ListHTTPRoutes lists the HTTPRoute resources having a parentRef to the given MeshService
+func (c *Client) ListHTTPRoutes(svc service.MeshService) []*gatewayAPIv1beta1.HTTPRoute {
+	var routes []*gatewayAPIv1beta1.HTTPRoute
+	for _, routeIface := range c.informers.List(informers.InformerKeyGatewayAPIHTTPRoute) {
+		route := routeIface.(*gatewayAPIv1beta1.HTTPRoute)
+
+		if !c.kubeController.IsMonitoredNamespace(route.Namespace) {
+			continue
+		}
+		if len(ServiceParentRefs(route.Namespace, route.Spec.ParentRefs, svc)) == 0 {
+			continue
+		}
+		routes = append(routes, route)
+	}
+
+	sort.SliceStable(routes, func(i, j int) bool {
+		return routeLess(&routes[i].ObjectMeta, &routes[j].ObjectMeta)
+	})
+	return routes
+}
+
+// ListGRPCRoutes lists the GRPCRoute resources having a parentRef to the given MeshService
+func (c *Client) ListGRPCRoutes(svc service.MeshService) []*gatewayAPIv1alpha2.GRPCRoute {
+	var routes []*gatewayAPIv1alpha2.GRPCRoute
+	for _, routeIface := range c.informers.List(informers.InformerKeyGatewayAPIGRPCRoute) {
+		route := routeIface.(*gatewayAPIv1alpha2.GRPCRoute)
+
+		if !c.kubeController.IsMonitoredNamespace(route.Namespace) {
+			continue
+		}
+		if len(ServiceParentRefs(route.Namespace, route.Spec.ParentRefs, svc)) == 0 {
+			continue
+		}
+		routes = append(routes, route)
+	}
+
+	sort.SliceStable(routes, func(i, j int) bool {
+		return routeLess(&routes[i].ObjectMeta, &routes[j].ObjectMeta)
+	})
+	return routes
+}
+
+// ListTCPRoutes lists the TCPRoute resources having a parentRef to the given MeshService
+func (c *Client) ListTCPRoutes(svc service.MeshService) []*gatewayAPIv1alpha2.TCPRoute {
+	var routes []*gatewayAPIv1alpha2.TCPRoute
+	for _, routeIface := range c.informers.List(informers.InformerKeyGatewayAPITCPRoute) {
+		route := routeIface.(*gatewayAPIv1alpha2.TCPRoute)
+
+		if !c.kubeController.IsMonitoredNamespace(route.Namespace) {
+			continue
+		}
+		if len(ServiceParentRefs(route.Namespace, route.Spec.ParentRefs, svc)) == 0 {
+			continue
+		}
+		routes = append(routes, route)
+	}
+
+	sort.SliceStable(routes, func(i, j int) bool {
+		return routeLess(&routes[i].ObjectMeta, &routes[j].ObjectMeta)
+	})
+	return routes
+}
+
+// ServiceParentRefs returns the parentRefs of a route in the given namespace that reference the given MeshService.
+// A parentRef references a MeshService when it is of kind Service in the core API group, its name and namespace
+// (defaulting to the route's namespace) match the MeshService, and its port, if specified, matches the MeshService's port.
+func ServiceParentRefs(routeNamespace string, parentRefs []gatewayAPIv1beta1.ParentReference, svc service.MeshService) []gatewayAPIv1beta1.ParentReference {
+	var matched []gatewayAPIv1beta1.ParentReference
+	for _, parentRef := range parentRefs {
+		if !IsServiceRef(parentRef.Group, parentRef.Kind) {
+			continue
+		}
+		namespace := routeNamespace
+		if parentRef.Namespace != nil {
+			namespace = string(*parentRef.Namespace)
+		}
+		if namespace != svc.Namespace || string(parentRef.Name) != svc.Name {
+			continue
+		}
+		if parentRef.Port != nil && uint16(*parentRef.Port) != svc.Port {
+			continue
+		}
+		matched = append(matched, parentRef)
+	}
+	return matched
+}
+
+// IsServiceRef returns true if the given group and kind of a parentRef or backendRef refer to a Service.
+// The group defaults to the core API group and the kind defaults to Service when unspecified.
+func IsServiceRef(group *gatewayAPIv1beta1.Group, kind *gatewayAPIv1beta1.Kind) bool {
+	if group != nil && *group != GroupCore {
+		return false
+	}
+	if kind != nil && *kind != KindService {
+		return false
+	}
+	return true
+}
+
+// routeLess orders routes by creation timestamp and then by their <namespace>/<name>, which is the order
+// used by the Gateway API specification to resolve conflicts between routes
+func routeLess(a, b *metav1.ObjectMeta) bool {
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.CreationTimestamp.Before(&b.CreationTimestamp)
+	}
+	if a.Namespace != b.Namespace {
+		return a.Namespace < b.Namespace
+	}
+	return a.Name < b.Name
+}
@@ -0,0 +1,156 @@
+package gatewayapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayAPIv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayAPIv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+	fakeGatewayAPIClient "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned/fake"
+
+	"github.com/openservicemesh/osm/pkg/k8s"
+	"github.com/openservicemesh/osm/pkg/k8s/informers"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+func TestListRoutes(t *testing.T) {
+	a := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockKubeController := k8s.NewMockController(mockCtrl)
+	mockKubeController.EXPECT().IsMonitoredNamespace("test").Return(true).AnyTimes()
+	mockKubeController.EXPECT().IsMonitoredNamespace("unmonitored").Return(false).AnyTimes()
+
+	informerCollection, err := informers.NewInformerCollection("osm", nil, informers.WithGatewayAPIClient(fakeGatewayAPIClient.NewSimpleClientset()))
+	a.Nil(err)
+	c := NewGatewayAPIController(informerCollection, mockKubeController, nil)
+
+	svc := service.MeshService{Name: "foo", Namespace: "test", Port: 80}
+	now := time.Now()
+	group, kind := GroupCore, KindService
+	serviceParent := gatewayAPIv1beta1.ParentReference{
+		Group: &group,
+		Kind:  &kind,
+		Name:  "foo",
+	}
+
+	httpRoutes := []*gatewayAPIv1beta1.HTTPRoute{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "newer", Namespace: "test", CreationTimestamp: metav1.NewTime(now)},
+			Spec: gatewayAPIv1beta1.HTTPRouteSpec{
+				CommonRouteSpec: gatewayAPIv1beta1.CommonRouteSpec{ParentRefs: []gatewayAPIv1beta1.ParentReference{serviceParent}},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "older", Namespace: "test", CreationTimestamp: metav1.NewTime(now.Add(-time.Minute))},
+			Spec: gatewayAPIv1beta1.HTTPRouteSpec{
+				CommonRouteSpec: gatewayAPIv1beta1.CommonRouteSpec{ParentRefs: []gatewayAPIv1beta1.ParentReference{{Name: "foo"}}},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "other-service", Namespace: "test"},
+			Spec: gatewayAPIv1beta1.HTTPRouteSpec{
+				CommonRouteSpec: gatewayAPIv1beta1.CommonRouteSpec{ParentRefs: []gatewayAPIv1beta1.ParentReference{{Name: "bar"}}},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "unmonitored", Namespace: "unmonitored"},
+			Spec: gatewayAPIv1beta1.HTTPRouteSpec{
+				CommonRouteSpec: gatewayAPIv1beta1.CommonRouteSpec{ParentRefs: []gatewayAPIv1beta1.ParentReference{
+					{Name: "foo", Namespace: (*gatewayAPIv1beta1.Namespace)(&svc.Namespace)},
+				}},
+			},
+		},
+	}
+	for _, route := range httpRoutes {
+		a.Nil(informerCollection.Add(informers.InformerKeyGatewayAPIHTTPRoute, route, t))
+	}
+
+	grpcRoute := &gatewayAPIv1alpha2.GRPCRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "grpc", Namespace: "test"},
+		Spec: gatewayAPIv1alpha2.GRPCRouteSpec{
+			CommonRouteSpec: gatewayAPIv1beta1.CommonRouteSpec{ParentRefs: []gatewayAPIv1beta1.ParentReference{serviceParent}},
+		},
+	}
+	a.Nil(informerCollection.Add(informers.InformerKeyGatewayAPIGRPCRoute, grpcRoute, t))
+
+	tcpRoute := &gatewayAPIv1alpha2.TCPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "tcp", Namespace: "test"},
+		Spec: gatewayAPIv1alpha2.TCPRouteSpec{
+			CommonRouteSpec: gatewayAPIv1beta1.CommonRouteSpec{ParentRefs: []gatewayAPIv1beta1.ParentReference{serviceParent}},
+		},
+	}
+	a.Nil(informerCollection.Add(informers.InformerKeyGatewayAPITCPRoute, tcpRoute, t))
+
+	actualHTTPRoutes := c.ListHTTPRoutes(svc)
+	a.Len(actualHTTPRoutes, 2)
+	a.Equal("older", actualHTTPRoutes[0].Name)
+	a.Equal("newer", actualHTTPRoutes[1].Name)
+
+	a.Equal([]*gatewayAPIv1alpha2.GRPCRoute{grpcRoute}, c.ListGRPCRoutes(svc))
+	a.Equal([]*gatewayAPIv1alpha2.TCPRoute{tcpRoute}, c.ListTCPRoutes(svc))
+
+	a.Empty(c.ListHTTPRoutes(service.MeshService{Name: "baz", Namespace: "test", Port: 80}))
+}
+
+func TestServiceParentRefs(t *testing.T) {
+	svc := service.MeshService{Name: "foo", Namespace: "test", Port: 80}
+	gatewayGroup := gatewayAPIv1beta1.Group("gateway.networking.k8s.io")
+	gatewayKind := gatewayAPIv1beta1.Kind("Gateway")
+	otherNamespace := gatewayAPIv1beta1.Namespace("other")
+	testNamespace := gatewayAPIv1beta1.Namespace("test")
+	port80 := gatewayAPIv1beta1.PortNumber(80)
+	port90 := gatewayAPIv1beta1.PortNumber(90)
+
+	testCases := []struct {
+		name           string
+		routeNamespace string
+		parentRefs     []gatewayAPIv1beta1.ParentReference
+		expected       []gatewayAPIv1beta1.ParentReference
+	}{
+		{
+			name:           "parentRef defaults to a Service in the route's namespace",
+			routeNamespace: "test",
+			parentRefs:     []gatewayAPIv1beta1.ParentReference{{Name: "foo"}},
+			expected:       []gatewayAPIv1beta1.ParentReference{{Name: "foo"}},
+		},
+		{
+			name:           "parentRef to a Gateway is ignored",
+			routeNamespace: "test",
+			parentRefs:     []gatewayAPIv1beta1.ParentReference{{Group: &gatewayGroup, Kind: &gatewayKind, Name: "foo"}},
+			expected:       nil,
+		},
+		{
+			name:           "parentRef to a Service in a different namespace",
+			routeNamespace: "test",
+			parentRefs:     []gatewayAPIv1beta1.ParentReference{{Name: "foo", Namespace: &otherNamespace}},
+			expected:       nil,
+		},
+		{
+			name:           "consumer route in a different namespace referencing the Service",
+			routeNamespace: "other",
+			parentRefs:     []gatewayAPIv1beta1.ParentReference{{Name: "foo", Namespace: &testNamespace}},
+			expected:       []gatewayAPIv1beta1.ParentReference{{Name: "foo", Namespace: &testNamespace}},
+		},
+		{
+			name:           "parentRef port must match the Service port",
+			routeNamespace: "test",
+			parentRefs: []gatewayAPIv1beta1.ParentReference{
+				{Name: "foo", Port: &port90},
+				{Name: "foo", Port: &port80},
+			},
+			expected: []gatewayAPIv1beta1.ParentReference{{Name: "foo", Port: &port80}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := tassert.New(t)
+			a.Equal(tc.expected, ServiceParentRefs(tc.routeNamespace, tc.parentRefs, svc))
+		})
+	}
+}
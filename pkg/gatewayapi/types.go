@@ -0,0 +1,46 @@
+// Package gatewayapi implements the Kubernetes client for the route resources in the gateway.networking.k8s.io API group
+// that are attached to Services, as described by the GAMMA (Gateway API for Mesh Management and Administration) initiative.
+package gatewayapi
+
+import (
+	gatewayAPIv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayAPIv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/openservicemesh/osm/pkg/k8s"
+	"github.com/openservicemesh/osm/pkg/k8s/informers"
+	"github.com/openservicemesh/osm/pkg/logger"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+var (
+	log = logger.New("gatewayapi-controller")
+)
+
+const (
+	// ControllerName is the name of the controller written to the status of the Gateway API routes managed by OSM
+	ControllerName gatewayAPIv1beta1.GatewayController = "openservicemesh.io/osm-controller"
+
+	// KindService is the kind of a Service referenced by a parentRef or a backendRef
+	KindService gatewayAPIv1beta1.Kind = "Service"
+
+	// GroupCore is the API group of a Service referenced by a parentRef or a backendRef
+	GroupCore gatewayAPIv1beta1.Group = ""
+)
+
+// Client is the type used to represent the Kubernetes Client for the gateway.networking.k8s.io API group
+type Client struct {
+	informers      *informers.InformerCollection
+	kubeController k8s.Controller
+}
+
+// Controller is the interface for the functionality provided by the route resources part of the gateway.networking.k8s.io API group
+type Controller interface {
+	// ListHTTPRoutes lists the HTTPRoute resources having a parentRef to the given MeshService
+	ListHTTPRoutes(service.MeshService) []*gatewayAPIv1beta1.HTTPRoute
+
+	// ListGRPCRoutes lists the GRPCRoute resources having a parentRef to the given MeshService
+	ListGRPCRoutes(service.MeshService) []*gatewayAPIv1alpha2.GRPCRoute
+
+	// ListTCPRoutes lists the TCPRoute resources having a parentRef to the given MeshService
+	ListTCPRoutes(service.MeshService) []*gatewayAPIv1alpha2.TCPRoute
+}
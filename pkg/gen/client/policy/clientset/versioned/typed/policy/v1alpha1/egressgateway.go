@@ -37,6 +37,7 @@ type EgressGatewaysGetter interface {
 type EgressGatewayInterface interface {
 	Create(ctx context.Context, egressGateway *v1alpha1.EgressGateway, opts v1.CreateOptions) (*v1alpha1.EgressGateway, error)
 	Update(ctx context.Context, egressGateway *v1alpha1.EgressGateway, opts v1.UpdateOptions) (*v1alpha1.EgressGateway, error)
+	UpdateStatus(ctx context.Context, egressGateway *v1alpha1.EgressGateway, opts v1.UpdateOptions) (*v1alpha1.EgressGateway, error)
 	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
 	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
 	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.EgressGateway, error)
@@ -132,6 +133,22 @@ func (c *egressGateways) Update(ctx context.Context, egressGateway *v1alpha1.Egr
 	return
 }
 
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *egressGateways) UpdateStatus(ctx context.Context, egressGateway *v1alpha1.EgressGateway, opts v1.UpdateOptions) (result *v1alpha1.EgressGateway, err error) {
+	result = &v1alpha1.EgressGateway{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("egressgateways").
+		Name(egressGateway.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(egressGateway).
+		Do(ctx).
+		Into(result)
+	return
+}
+
 // Delete takes name of the egressGateway and deletes it. Returns an error if one occurs.
 func (c *egressGateways) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
 	return c.client.Delete().
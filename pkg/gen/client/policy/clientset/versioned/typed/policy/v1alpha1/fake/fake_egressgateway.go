@@ -99,6 +99,18 @@ func (c *FakeEgressGateways) Update(ctx context.Context, egressGateway *v1alpha1
 	return obj.(*v1alpha1.EgressGateway), err
 }
 
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeEgressGateways) UpdateStatus(ctx context.Context, egressGateway *v1alpha1.EgressGateway, opts v1.UpdateOptions) (*v1alpha1.EgressGateway, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(egressgatewaysResource, "status", c.ns, egressGateway), &v1alpha1.EgressGateway{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.EgressGateway), err
+}
+
 // Delete takes name of the egressGateway and deletes it. Returns an error if one occurs.
 func (c *FakeEgressGateways) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
 	_, err := c.Fake.
@@ -41,10 +41,18 @@ func (c *FakePolicyV1alpha1) EgressGateways(namespace string) v1alpha1.EgressGat
 	return &FakeEgressGateways{c, namespace}
 }
 
+func (c *FakePolicyV1alpha1) FaultInjections(namespace string) v1alpha1.FaultInjectionInterface {
+	return &FakeFaultInjections{c, namespace}
+}
+
 func (c *FakePolicyV1alpha1) IngressBackends(namespace string) v1alpha1.IngressBackendInterface {
 	return &FakeIngressBackends{c, namespace}
 }
 
+func (c *FakePolicyV1alpha1) RequestAuthentications(namespace string) v1alpha1.RequestAuthenticationInterface {
+	return &FakeRequestAuthentications{c, namespace}
+}
+
 func (c *FakePolicyV1alpha1) Retries(namespace string) v1alpha1.RetryInterface {
 	return &FakeRetries{c, namespace}
 }
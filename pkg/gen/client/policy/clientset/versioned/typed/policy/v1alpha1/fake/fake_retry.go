@@ -99,6 +99,18 @@ func (c *FakeRetries) Update(ctx context.Context, retry *v1alpha1.Retry, opts v1
 	return obj.(*v1alpha1.Retry), err
 }
 
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeRetries) UpdateStatus(ctx context.Context, retry *v1alpha1.Retry, opts v1.UpdateOptions) (*v1alpha1.Retry, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(retriesResource, "status", c.ns, retry), &v1alpha1.Retry{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.Retry), err
+}
+
 // Delete takes name of the retry and deletes it. Returns an error if one occurs.
 func (c *FakeRetries) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
 	_, err := c.Fake.
@@ -23,8 +23,12 @@ type EgressExpansion interface{}
 
 type EgressGatewayExpansion interface{}
 
+type FaultInjectionExpansion interface{}
+
 type IngressBackendExpansion interface{}
 
+type RequestAuthenticationExpansion interface{}
+
 type RetryExpansion interface{}
 
 type UpstreamTrafficSettingExpansion interface{}
@@ -29,7 +29,9 @@ type PolicyV1alpha1Interface interface {
 	AccessControlsGetter
 	EgressesGetter
 	EgressGatewaysGetter
+	FaultInjectionsGetter
 	IngressBackendsGetter
+	RequestAuthenticationsGetter
 	RetriesGetter
 	UpstreamTrafficSettingsGetter
 }
@@ -55,10 +57,18 @@ func (c *PolicyV1alpha1Client) EgressGateways(namespace string) EgressGatewayInt
 	return newEgressGateways(c, namespace)
 }
 
+func (c *PolicyV1alpha1Client) FaultInjections(namespace string) FaultInjectionInterface {
+	return newFaultInjections(c, namespace)
+}
+
 func (c *PolicyV1alpha1Client) IngressBackends(namespace string) IngressBackendInterface {
 	return newIngressBackends(c, namespace)
 }
 
+func (c *PolicyV1alpha1Client) RequestAuthentications(namespace string) RequestAuthenticationInterface {
+	return newRequestAuthentications(c, namespace)
+}
+
 func (c *PolicyV1alpha1Client) Retries(namespace string) RetryInterface {
 	return newRetries(c, namespace)
 }
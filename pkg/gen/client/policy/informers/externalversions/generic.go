@@ -58,8 +58,12 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Policy().V1alpha1().Egresses().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("egressgateways"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Policy().V1alpha1().EgressGateways().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("faultinjections"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Policy().V1alpha1().FaultInjections().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("ingressbackends"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Policy().V1alpha1().IngressBackends().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("requestauthentications"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Policy().V1alpha1().RequestAuthentications().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("retries"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Policy().V1alpha1().Retries().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("upstreamtrafficsettings"):
@@ -29,8 +29,12 @@ type Interface interface {
 	Egresses() EgressInformer
 	// EgressGateways returns a EgressGatewayInformer.
 	EgressGateways() EgressGatewayInformer
+	// FaultInjections returns a FaultInjectionInformer.
+	FaultInjections() FaultInjectionInformer
 	// IngressBackends returns a IngressBackendInformer.
 	IngressBackends() IngressBackendInformer
+	// RequestAuthentications returns a RequestAuthenticationInformer.
+	RequestAuthentications() RequestAuthenticationInformer
 	// Retries returns a RetryInformer.
 	Retries() RetryInformer
 	// UpstreamTrafficSettings returns a UpstreamTrafficSettingInformer.
@@ -68,11 +72,21 @@ func (v *version) EgressGateways() EgressGatewayInformer {
 	return &egressGatewayInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// FaultInjections returns a FaultInjectionInformer.
+func (v *version) FaultInjections() FaultInjectionInformer {
+	return &faultInjectionInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // IngressBackends returns a IngressBackendInformer.
 func (v *version) IngressBackends() IngressBackendInformer {
 	return &ingressBackendInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// RequestAuthentications returns a RequestAuthenticationInformer.
+func (v *version) RequestAuthentications() RequestAuthenticationInformer {
+	return &requestAuthenticationInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // Retries returns a RetryInformer.
 func (v *version) Retries() RetryInformer {
 	return &retryInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
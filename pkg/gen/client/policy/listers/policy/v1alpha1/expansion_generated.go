@@ -47,6 +47,14 @@ type EgressGatewayListerExpansion interface{}
 // EgressGatewayNamespaceLister.
 type EgressGatewayNamespaceListerExpansion interface{}
 
+// FaultInjectionListerExpansion allows custom methods to be added to
+// FaultInjectionLister.
+type FaultInjectionListerExpansion interface{}
+
+// FaultInjectionNamespaceListerExpansion allows custom methods to be added to
+// FaultInjectionNamespaceLister.
+type FaultInjectionNamespaceListerExpansion interface{}
+
 // IngressBackendListerExpansion allows custom methods to be added to
 // IngressBackendLister.
 type IngressBackendListerExpansion interface{}
@@ -55,6 +63,14 @@ type IngressBackendListerExpansion interface{}
 // IngressBackendNamespaceLister.
 type IngressBackendNamespaceListerExpansion interface{}
 
+// RequestAuthenticationListerExpansion allows custom methods to be added to
+// RequestAuthenticationLister.
+type RequestAuthenticationListerExpansion interface{}
+
+// RequestAuthenticationNamespaceListerExpansion allows custom methods to be added to
+// RequestAuthenticationNamespaceLister.
+type RequestAuthenticationNamespaceListerExpansion interface{}
+
 // RetryListerExpansion allows custom methods to be added to
 // RetryLister.
 type RetryListerExpansion interface{}
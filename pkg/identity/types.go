@@ -3,12 +3,16 @@ package identity
 
 import (
 	"fmt"
+	"net/url"
 	"strings"
 )
 
 const (
 	// namespaceNameSeparator used for marshalling/unmarshalling MeshService to a string or vice versa
 	namespaceNameSeparator = "/"
+
+	// SpiffeScheme is the URI scheme of a SPIFFE ID
+	SpiffeScheme = "spiffe"
 )
 
 // ServiceIdentity is the type used to represent the identity for a service
@@ -49,6 +53,52 @@ func (si ServiceIdentity) AsPrincipal(trustDomain string) string {
 	return fmt.Sprintf("%s.%s", si.String(), trustDomain)
 }
 
+// AsSpiffeID converts the ServiceIdentity to a SPIFFE ID in the given trust domain,
+// of the form spiffe://<trust-domain>/ns/<namespace>/sa/<service-account>.
+// A ServiceIdentity that is not of the form <ServiceAccount>.<Namespace> has no SPIFFE ID, an empty string is returned.
+func (si ServiceIdentity) AsSpiffeID(trustDomain string) string {
+	if si.IsWildcard() {
+		return si.String()
+	}
+	if !si.IsValid() {
+		return ""
+	}
+	// A ServiceAccount name may contain dots while a namespace may not, so the namespace is the last segment
+	s := si.String()
+	i := strings.LastIndex(s, ".")
+	return K8sServiceAccount{Name: s[:i], Namespace: s[i+1:]}.AsSpiffeID(trustDomain)
+}
+
+// IsValid returns whether the ServiceIdentity is of the form <ServiceAccount>.<Namespace>
+func (si ServiceIdentity) IsValid() bool {
+	i := strings.LastIndex(si.String(), ".")
+	return i > 0 && i < len(si)-1
+}
+
+// AsPrincipals returns the principals the ServiceIdentity is authenticated as.
+// Without federated trust domains, these are its principal and its SPIFFE ID in the given trust domain, both
+// issued by the mesh's CA.
+// With federated trust domains, a peer is authenticated by its SPIFFE ID only, which is validated against the trust
+// bundle of its own trust domain: the principal is not used as any federated CA could issue a certificate with it.
+// A ServiceIdentity that is not of the form <ServiceAccount>.<Namespace> has no SPIFFE ID and is only authenticated
+// as its principal.
+func (si ServiceIdentity) AsPrincipals(trustDomain string, federatedTrustDomains ...string) []string {
+	if si.IsWildcard() {
+		return []string{WildcardPrincipal}
+	}
+	if !si.IsValid() {
+		return []string{si.AsPrincipal(trustDomain)}
+	}
+	if len(federatedTrustDomains) == 0 {
+		return []string{si.AsPrincipal(trustDomain), si.AsSpiffeID(trustDomain)}
+	}
+	principals := []string{si.AsSpiffeID(trustDomain)}
+	for _, federatedTrustDomain := range federatedTrustDomains {
+		principals = append(principals, si.AsSpiffeID(federatedTrustDomain))
+	}
+	return principals
+}
+
 // ToK8sServiceAccount converts a ServiceIdentity to a K8sServiceAccount to help with transition from K8sServiceAccount to ServiceIdentity
 func (si ServiceIdentity) ToK8sServiceAccount() K8sServiceAccount {
 	// By convention as of release-v0.8 ServiceIdentity is in the format: <ServiceAccount>.<Namespace>.cluster.local
@@ -82,3 +132,27 @@ func (sa K8sServiceAccount) ToServiceIdentity() ServiceIdentity {
 func (sa K8sServiceAccount) AsPrincipal(trustDomain string) string {
 	return sa.ToServiceIdentity().AsPrincipal(trustDomain)
 }
+
+// AsSpiffeID converts the K8sServiceAccount to a SPIFFE ID in the given trust domain.
+func (sa K8sServiceAccount) AsSpiffeID(trustDomain string) string {
+	return fmt.Sprintf("%s://%s/ns/%s/sa/%s", SpiffeScheme, trustDomain, sa.Namespace, sa.Name)
+}
+
+// AsPrincipals converts the K8sServiceAccount to the principals it is authenticated as.
+func (sa K8sServiceAccount) AsPrincipals(trustDomain string, federatedTrustDomains ...string) []string {
+	return sa.ToServiceIdentity().AsPrincipals(trustDomain, federatedTrustDomains...)
+}
+
+// FromSpiffeID returns the ServiceIdentity and the trust domain of the given SPIFFE ID.
+func FromSpiffeID(spiffeID string) (ServiceIdentity, string, error) {
+	u, err := url.Parse(spiffeID)
+	if err != nil {
+		return "", "", err
+	}
+	// The path of a Kubernetes workload's SPIFFE ID is /ns/<namespace>/sa/<service-account>
+	chunks := strings.Split(strings.TrimPrefix(u.Path, "/"), "/")
+	if u.Scheme != SpiffeScheme || u.Host == "" || len(chunks) != 4 || chunks[0] != "ns" || chunks[2] != "sa" {
+		return "", "", fmt.Errorf("invalid SPIFFE ID %q", spiffeID)
+	}
+	return New(chunks[3], chunks[1]), u.Host, nil
+}
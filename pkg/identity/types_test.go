@@ -56,3 +56,43 @@ func TestToServiceIdentity(t *testing.T) {
 		assert.Equal(si, tc.expectedServiceIdentity)
 	}
 }
+
+func TestSpiffeID(t *testing.T) {
+	assert := tassert.New(t)
+
+	si := ServiceIdentity("foo.bar")
+	assert.Equal("spiffe://cluster.local/ns/bar/sa/foo", si.AsSpiffeID("cluster.local"))
+	assert.Equal("spiffe://cluster.local/ns/bar/sa/foo", K8sServiceAccount{Name: "foo", Namespace: "bar"}.AsSpiffeID("cluster.local"))
+	assert.Equal(WildcardPrincipal, WildcardServiceIdentity.AsSpiffeID("cluster.local"))
+
+	// A ServiceAccount name may contain dots
+	assert.Equal("spiffe://cluster.local/ns/bar/sa/foo.v1", ServiceIdentity("foo.v1.bar").AsSpiffeID("cluster.local"))
+	assert.True(ServiceIdentity("foo.v1.bar").IsValid())
+	assert.False(ServiceIdentity("foo").IsValid())
+	assert.False(ServiceIdentity("foo.").IsValid())
+	assert.Empty(ServiceIdentity("foo").AsSpiffeID("cluster.local"))
+	assert.Empty(ServiceIdentity("foo.").AsSpiffeID("cluster.local"))
+	// An identity that is not of the form <ServiceAccount>.<Namespace> is only authenticated as its principal
+	assert.Equal([]string{"foo.cluster.local"}, ServiceIdentity("foo").AsPrincipals("cluster.local", "example.com"))
+
+	assert.Equal([]string{
+		"foo.bar.cluster.local",
+		"spiffe://cluster.local/ns/bar/sa/foo",
+	}, si.AsPrincipals("cluster.local"))
+	// The principal is not accepted with federated trust domains
+	assert.Equal([]string{
+		"spiffe://cluster.local/ns/bar/sa/foo",
+		"spiffe://example.com/ns/bar/sa/foo",
+	}, si.AsPrincipals("cluster.local", "example.com"))
+	assert.Equal([]string{WildcardPrincipal}, WildcardServiceIdentity.AsPrincipals("cluster.local", "example.com"))
+
+	actual, trustDomain, err := FromSpiffeID("spiffe://example.com/ns/bar/sa/foo")
+	assert.NoError(err)
+	assert.Equal(si, actual)
+	assert.Equal("example.com", trustDomain)
+
+	for _, invalid := range []string{"foo.bar.cluster.local", "https://example.com/ns/bar/sa/foo", "spiffe:///ns/bar/sa/foo", "spiffe://example.com/ns/bar"} {
+		_, _, err = FromSpiffeID(invalid)
+		assert.Error(err, invalid)
+	}
+}
@@ -45,6 +45,15 @@ func GetInitContainerSpec(containerName string, cfg configurator.Configurator, o
 					},
 				},
 			},
+			{
+				Name: "POD_IPS",
+				ValueFrom: &corev1.EnvVarSource{
+					FieldRef: &corev1.ObjectFieldSelector{
+						APIVersion: "v1",
+						FieldPath:  "status.podIPs",
+					},
+				},
+			},
 		},
 	}
 }
@@ -2,6 +2,7 @@ package injector
 
 import (
 	"fmt"
+	"net"
 	"strconv"
 	"strings"
 
@@ -67,8 +68,32 @@ var iptablesInboundStaticRules = []string{
 	"-A OSM_PROXY_INBOUND -p tcp -j OSM_PROXY_IN_REDIRECT",
 }
 
-// GenerateIptablesCommands generates a list of iptables commands to set up sidecar interception and redirection
+// GenerateIptablesCommands generates a list of iptables commands to set up sidecar interception and redirection.
+// IPv6 traffic is intercepted by the same rules applied with ip6tables when the pod has an IPv6 address.
 func GenerateIptablesCommands(proxyMode configv1alpha2.LocalProxyMode, enabledDNSProxy bool, outboundIPRangeExclusionList []string, outboundIPRangeInclusionList []string, outboundPortExclusionList []int, inboundPortExclusionList []int, networkInterfaceExclusionList []string) string {
+	exclusions4, exclusions6 := splitIPRangesByFamily(outboundIPRangeExclusionList)
+	inclusions4, inclusions6 := splitIPRangesByFamily(outboundIPRangeInclusionList)
+	inclusionEnabled := len(outboundIPRangeInclusionList) > 0
+
+	rules4 := generateIptablesRules(false, proxyMode, enabledDNSProxy, exclusions4, inclusions4, inclusionEnabled, outboundPortExclusionList, inboundPortExclusionList, networkInterfaceExclusionList)
+	rules6 := generateIptablesRules(true, proxyMode, enabledDNSProxy, exclusions6, inclusions6, inclusionEnabled, outboundPortExclusionList, inboundPortExclusionList, networkInterfaceExclusionList)
+
+	cmd := fmt.Sprintf(`iptables-restore --noflush <<EOF
+%s
+EOF
+POD_IP6=$(echo "$POD_IPS" | tr ',' '\n' | grep ':' | head -n 1)
+if [ -n "$POD_IP6" ]; then
+ip6tables-restore --noflush <<EOF
+%s
+EOF
+fi
+`, rules4, rules6)
+
+	return cmd
+}
+
+// generateIptablesRules generates the iptables rules of an IP family in the iptables-restore format
+func generateIptablesRules(ipv6 bool, proxyMode configv1alpha2.LocalProxyMode, enabledDNSProxy bool, outboundIPRangeExclusionList []string, outboundIPRangeInclusionList []string, inclusionEnabled bool, outboundPortExclusionList []int, inboundPortExclusionList []int, networkInterfaceExclusionList []string) string {
 	var rules strings.Builder
 
 	fmt.Fprintln(&rules, `# OSM sidecar interception rules
@@ -101,8 +126,17 @@ func GenerateIptablesCommands(proxyMode configv1alpha2.LocalProxyMode, enabledDN
 	}
 
 	// 3. Create outbound rules
-	cmds = append(cmds, iptablesOutboundStaticRules...)
-	if enabledDNSProxy {
+	localhost, podIP := "127.0.0.1/32", "$POD_IP"
+	if ipv6 {
+		localhost, podIP = "::1/128", "$POD_IP6"
+		for _, rule := range iptablesOutboundStaticRules {
+			cmds = append(cmds, strings.ReplaceAll(rule, "127.0.0.1/32", localhost))
+		}
+	} else {
+		cmds = append(cmds, iptablesOutboundStaticRules...)
+	}
+	// The local DNS proxy only listens on an IPv4 address
+	if enabledDNSProxy && !ipv6 {
 		cmds = append(cmds, iptablesDNSOutboundStaticRules...)
 	}
 
@@ -110,7 +144,7 @@ func GenerateIptablesCommands(proxyMode configv1alpha2.LocalProxyMode, enabledDN
 		// For sidecar -> local service container proxying, send traffic to pod IP instead of localhost
 		// *Note: it is important to use the insert option '-I' instead of the append option '-A' to ensure the
 		// DNAT to the pod ip for sidecar -> localhost traffic happens before the rule that redirects traffic to the proxy
-		cmds = append(cmds, fmt.Sprintf("-I OUTPUT -p tcp -o lo -d 127.0.0.1/32 -m owner --uid-owner %d -j DNAT --to-destination $POD_IP", constants.SidecarUID))
+		cmds = append(cmds, fmt.Sprintf("-I OUTPUT -p tcp -o lo -d %s -m owner --uid-owner %d -j DNAT --to-destination %s", localhost, constants.SidecarUID, podIP))
 	}
 
 	// Ignore outbound traffic in specified interfaces
@@ -143,7 +177,8 @@ func GenerateIptablesCommands(proxyMode configv1alpha2.LocalProxyMode, enabledDN
 	}
 
 	// 6. Create dynamic outbound IP range inclusion rules
-	if len(outboundIPRangeInclusionList) > 0 {
+	// *Note: the inclusion list applies to both IP families, traffic of a family without included IP ranges is not redirected
+	if inclusionEnabled {
 		// Redirect specified IP ranges to the proxy
 		for _, cidr := range outboundIPRangeInclusionList {
 			rule := fmt.Sprintf("-A OSM_PROXY_OUTBOUND -d %s -j OSM_PROXY_OUT_REDIRECT", cidr)
@@ -162,10 +197,17 @@ func GenerateIptablesCommands(proxyMode configv1alpha2.LocalProxyMode, enabledDN
 
 	fmt.Fprint(&rules, "COMMIT")
 
-	cmd := fmt.Sprintf(`iptables-restore --noflush <<EOF
-%s
-EOF
-`, rules.String())
+	return rules.String()
+}
 
-	return cmd
+// splitIPRangesByFamily splits IP ranges into IPv4 and IPv6 ranges, ranges which can not be parsed are kept as IPv4 ranges
+func splitIPRangesByFamily(ipRanges []string) (ipv4Ranges []string, ipv6Ranges []string) {
+	for _, ipRange := range ipRanges {
+		if ip, _, err := net.ParseCIDR(ipRange); err == nil && ip.To4() == nil {
+			ipv6Ranges = append(ipv6Ranges, ipRange)
+		} else {
+			ipv4Ranges = append(ipv4Ranges, ipRange)
+		}
+	}
+	return
 }
@@ -87,7 +87,7 @@ func BenchmarkPodCreationHandler(b *testing.B) {
 		informers.WithKubeClient(kubeClient),
 		informers.WithConfigClient(configClient, tests.OsmMeshConfigName, tests.OsmNamespace),
 	)
-	kubeController := k8s.NewKubernetesController(informerCollection, policyClient, pluginClient, msgBroker)
+	kubeController := k8s.NewKubernetesController(informerCollection, policyClient, pluginClient, nil, msgBroker)
 	if err != nil {
 		b.Fatalf("Failed to create kubeController: %s", err.Error())
 	}
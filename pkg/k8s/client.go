@@ -9,10 +9,14 @@ import (
 	mapset "github.com/deckarep/golang-set"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/pointer"
+	gatewayAPIv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayAPIv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+	gatewayAPIClientset "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned"
 
 	pluginv1alpha1 "github.com/openservicemesh/osm/pkg/apis/plugin/v1alpha1"
 	policyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
@@ -30,17 +34,18 @@ import (
 )
 
 // NewKubernetesController returns a new kubernetes.Controller which means to provide access to locally-cached k8s resources
-func NewKubernetesController(informerCollection *osminformers.InformerCollection, policyClient policyv1alpha1Client.Interface, pluginClient pluginv1alpha1Client.Interface, msgBroker *messaging.Broker, selectInformers ...InformerKey) Controller {
-	return newClient(informerCollection, policyClient, pluginClient, msgBroker, selectInformers...)
+func NewKubernetesController(informerCollection *osminformers.InformerCollection, policyClient policyv1alpha1Client.Interface, pluginClient pluginv1alpha1Client.Interface, gatewayAPIClient gatewayAPIClientset.Interface, msgBroker *messaging.Broker, selectInformers ...InformerKey) Controller {
+	return newClient(informerCollection, policyClient, pluginClient, gatewayAPIClient, msgBroker, selectInformers...)
 }
 
-func newClient(informerCollection *osminformers.InformerCollection, policyClient policyv1alpha1Client.Interface, pluginClient pluginv1alpha1Client.Interface, msgBroker *messaging.Broker, selectInformers ...InformerKey) *client {
+func newClient(informerCollection *osminformers.InformerCollection, policyClient policyv1alpha1Client.Interface, pluginClient pluginv1alpha1Client.Interface, gatewayAPIClient gatewayAPIClientset.Interface, msgBroker *messaging.Broker, selectInformers ...InformerKey) *client {
 	// Initialize client object
 	c := &client{
-		informers:    informerCollection,
-		msgBroker:    msgBroker,
-		policyClient: policyClient,
-		pluginClient: pluginClient,
+		informers:        informerCollection,
+		msgBroker:        msgBroker,
+		policyClient:     policyClient,
+		pluginClient:     pluginClient,
+		gatewayAPIClient: gatewayAPIClient,
 	}
 
 	// Initialize informers
@@ -50,11 +55,12 @@ func newClient(informerCollection *osminformers.InformerCollection, policyClient
 		ServiceAccounts: c.initServiceAccountsMonitor,
 		Pods:            c.initPodMonitor,
 		Endpoints:       c.initEndpointMonitor,
+		EndpointSlices:  c.initEndpointSliceMonitor,
 	}
 
 	// If specific informers are not selected to be initialized, initialize all informers
 	if len(selectInformers) == 0 {
-		selectInformers = []InformerKey{Namespaces, Services, ServiceAccounts, Pods, Endpoints}
+		selectInformers = []InformerKey{Namespaces, Services, ServiceAccounts, Pods, Endpoints, EndpointSlices}
 	}
 
 	for _, informer := range selectInformers {
@@ -122,6 +128,15 @@ func (c *client) initEndpointMonitor() {
 	c.informers.AddEventHandler(osminformers.InformerKeyEndpoints, GetEventHandlerFuncs(c.shouldObserve, eptEventTypes, c.msgBroker))
 }
 
+func (c *client) initEndpointSliceMonitor() {
+	epsEventTypes := EventTypes{
+		Add:    announcements.EndpointSliceAdded,
+		Update: announcements.EndpointSliceUpdated,
+		Delete: announcements.EndpointSliceDeleted,
+	}
+	c.informers.AddEventHandler(osminformers.InformerKeyEndpointSlices, GetEventHandlerFuncs(c.shouldObserve, epsEventTypes, c.msgBroker))
+}
+
 // IsMonitoredNamespace returns a boolean indicating if the namespace is among the list of monitored namespaces
 func (c client) IsMonitoredNamespace(namespace string) bool {
 	return c.informers.IsMonitoredNamespace(namespace)
@@ -222,6 +237,21 @@ func (c client) GetEndpoints(svc service.MeshService) (*corev1.Endpoints, error)
 	return nil, nil
 }
 
+// ListEndpointSlicesForService returns the EndpointSlices for a given service, otherwise returns nil if
+// none are found or error if the API errored out.
+func (c client) ListEndpointSlicesForService(svc service.MeshService) ([]*discoveryv1.EndpointSlice, error) {
+	items, err := c.informers.ByIndex(osminformers.InformerKeyEndpointSlices, osminformers.EndpointSliceServiceIndex, svc.NamespacedKey())
+	if err != nil {
+		return nil, err
+	}
+
+	var endpointSlices []*discoveryv1.EndpointSlice
+	for _, item := range items {
+		endpointSlices = append(endpointSlices, item.(*discoveryv1.EndpointSlice))
+	}
+	return endpointSlices, nil
+}
+
 // ListServiceIdentitiesForService lists ServiceAccounts associated with the given service
 func (c client) ListServiceIdentitiesForService(svc service.MeshService) ([]identity.K8sServiceAccount, error) {
 	var svcAccounts []identity.K8sServiceAccount
@@ -286,6 +316,26 @@ func (c client) UpdateStatus(resource interface{}) (metav1.Object, error) {
 		obj := resource.(*policyv1alpha1.UpstreamTrafficSetting)
 		return c.policyClient.PolicyV1alpha1().UpstreamTrafficSettings(obj.Namespace).UpdateStatus(context.Background(), obj, metav1.UpdateOptions{})
 
+	case *policyv1alpha1.RequestAuthentication:
+		obj := resource.(*policyv1alpha1.RequestAuthentication)
+		return c.policyClient.PolicyV1alpha1().RequestAuthentications(obj.Namespace).UpdateStatus(context.Background(), obj, metav1.UpdateOptions{})
+
+	case *policyv1alpha1.Retry:
+		obj := resource.(*policyv1alpha1.Retry)
+		return c.policyClient.PolicyV1alpha1().Retries(obj.Namespace).UpdateStatus(context.Background(), obj, metav1.UpdateOptions{})
+
+	case *policyv1alpha1.FaultInjection:
+		obj := resource.(*policyv1alpha1.FaultInjection)
+		return c.policyClient.PolicyV1alpha1().FaultInjections(obj.Namespace).UpdateStatus(context.Background(), obj, metav1.UpdateOptions{})
+
+	case *policyv1alpha1.Egress:
+		obj := resource.(*policyv1alpha1.Egress)
+		return c.policyClient.PolicyV1alpha1().Egresses(obj.Namespace).UpdateStatus(context.Background(), obj, metav1.UpdateOptions{})
+
+	case *policyv1alpha1.EgressGateway:
+		obj := resource.(*policyv1alpha1.EgressGateway)
+		return c.policyClient.PolicyV1alpha1().EgressGateways(obj.Namespace).UpdateStatus(context.Background(), obj, metav1.UpdateOptions{})
+
 	case *pluginv1alpha1.Plugin:
 		obj := resource.(*pluginv1alpha1.Plugin)
 		return c.pluginClient.PluginV1alpha1().Plugins(obj.Namespace).UpdateStatus(context.Background(), obj, metav1.UpdateOptions{})
@@ -294,6 +344,31 @@ func (c client) UpdateStatus(resource interface{}) (metav1.Object, error) {
 		obj := resource.(*pluginv1alpha1.PluginChain)
 		return c.pluginClient.PluginV1alpha1().PluginChains(obj.Namespace).UpdateStatus(context.Background(), obj, metav1.UpdateOptions{})
 
+	case *pluginv1alpha1.PluginConfig:
+		obj := resource.(*pluginv1alpha1.PluginConfig)
+		return c.pluginClient.PluginV1alpha1().PluginConfigs(obj.Namespace).UpdateStatus(context.Background(), obj, metav1.UpdateOptions{})
+
+	case *gatewayAPIv1beta1.HTTPRoute:
+		if c.gatewayAPIClient == nil {
+			return nil, errGatewayAPIClientNotInitialized
+		}
+		obj := resource.(*gatewayAPIv1beta1.HTTPRoute)
+		return c.gatewayAPIClient.GatewayV1beta1().HTTPRoutes(obj.Namespace).UpdateStatus(context.Background(), obj, metav1.UpdateOptions{})
+
+	case *gatewayAPIv1alpha2.GRPCRoute:
+		if c.gatewayAPIClient == nil {
+			return nil, errGatewayAPIClientNotInitialized
+		}
+		obj := resource.(*gatewayAPIv1alpha2.GRPCRoute)
+		return c.gatewayAPIClient.GatewayV1alpha2().GRPCRoutes(obj.Namespace).UpdateStatus(context.Background(), obj, metav1.UpdateOptions{})
+
+	case *gatewayAPIv1alpha2.TCPRoute:
+		if c.gatewayAPIClient == nil {
+			return nil, errGatewayAPIClientNotInitialized
+		}
+		obj := resource.(*gatewayAPIv1alpha2.TCPRoute)
+		return c.gatewayAPIClient.GatewayV1alpha2().TCPRoutes(obj.Namespace).UpdateStatus(context.Background(), obj, metav1.UpdateOptions{})
+
 	default:
 		return nil, fmt.Errorf("Unsupported type: %T", t)
 	}
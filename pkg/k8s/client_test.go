@@ -8,12 +8,16 @@ import (
 	tassert "github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/fake"
 	testclient "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/utils/pointer"
+	gatewayAPIv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayAPIv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+	fakeGatewayAPIClient "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned/fake"
 
 	policyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
 	fakePolicyClient "github.com/openservicemesh/osm/pkg/gen/client/policy/clientset/versioned/fake"
@@ -66,7 +70,7 @@ func TestIsMonitoredNamespace(t *testing.T) {
 
 			ic, err := informers.NewInformerCollection(testMeshName, nil, informers.WithKubeClient(testclient.NewSimpleClientset()))
 			a.Nil(err)
-			c := newClient(ic, nil, nil, nil)
+			c := newClient(ic, nil, nil, nil, nil)
 			_ = ic.Add(informers.InformerKeyNamespace, tc.namespace, t)
 
 			actual := c.IsMonitoredNamespace(tc.ns)
@@ -109,7 +113,7 @@ func TestGetNamespace(t *testing.T) {
 			a := tassert.New(t)
 			ic, err := informers.NewInformerCollection(testMeshName, nil, informers.WithKubeClient(testclient.NewSimpleClientset()))
 			a.Nil(err)
-			c := newClient(ic, nil, nil, nil)
+			c := newClient(ic, nil, nil, nil, nil)
 			_ = ic.Add(informers.InformerKeyNamespace, tc.namespace, t)
 
 			actual := c.GetNamespace(tc.ns)
@@ -156,7 +160,7 @@ func TestListMonitoredNamespaces(t *testing.T) {
 			a := tassert.New(t)
 			ic, err := informers.NewInformerCollection(testMeshName, nil, informers.WithKubeClient(testclient.NewSimpleClientset()))
 			a.Nil(err)
-			c := newClient(ic, nil, nil, nil)
+			c := newClient(ic, nil, nil, nil, nil)
 			for _, ns := range tc.namespaces {
 				_ = ic.Add(informers.InformerKeyNamespace, ns, t)
 			}
@@ -215,7 +219,7 @@ func TestGetService(t *testing.T) {
 			a := tassert.New(t)
 			ic, err := informers.NewInformerCollection(testMeshName, nil, informers.WithKubeClient(testclient.NewSimpleClientset()))
 			a.Nil(err)
-			c := newClient(ic, nil, nil, nil)
+			c := newClient(ic, nil, nil, nil, nil)
 			_ = ic.Add(informers.InformerKeyService, tc.service, t)
 
 			actual := c.GetService(tc.svc)
@@ -272,7 +276,7 @@ func TestListServices(t *testing.T) {
 			a := tassert.New(t)
 			ic, err := informers.NewInformerCollection(testMeshName, nil, informers.WithKubeClient(testclient.NewSimpleClientset()))
 			a.Nil(err)
-			c := newClient(ic, nil, nil, nil)
+			c := newClient(ic, nil, nil, nil, nil)
 			_ = ic.Add(informers.InformerKeyNamespace, tc.namespace, t)
 
 			for _, s := range tc.services {
@@ -329,7 +333,7 @@ func TestListServiceAccounts(t *testing.T) {
 			a := tassert.New(t)
 			ic, err := informers.NewInformerCollection(testMeshName, nil, informers.WithKubeClient(testclient.NewSimpleClientset()))
 			a.Nil(err)
-			c := newClient(ic, nil, nil, nil)
+			c := newClient(ic, nil, nil, nil, nil)
 			_ = ic.Add(informers.InformerKeyNamespace, tc.namespace, t)
 
 			for _, s := range tc.sa {
@@ -386,7 +390,7 @@ func TestListPods(t *testing.T) {
 			a := tassert.New(t)
 			ic, err := informers.NewInformerCollection(testMeshName, nil, informers.WithKubeClient(testclient.NewSimpleClientset()))
 			a.Nil(err)
-			c := newClient(ic, nil, nil, nil)
+			c := newClient(ic, nil, nil, nil, nil)
 			_ = ic.Add(informers.InformerKeyNamespace, tc.namespace, t)
 
 			for _, p := range tc.pods {
@@ -440,7 +444,7 @@ func TestGetEndpoints(t *testing.T) {
 			a := tassert.New(t)
 			ic, err := informers.NewInformerCollection(testMeshName, nil, informers.WithKubeClient(testclient.NewSimpleClientset()))
 			a.Nil(err)
-			c := newClient(ic, nil, nil, nil)
+			c := newClient(ic, nil, nil, nil, nil)
 			_ = ic.Add(informers.InformerKeyEndpoints, tc.endpoints, t)
 
 			actual, err := c.GetEndpoints(tc.svc)
@@ -450,6 +454,98 @@ func TestGetEndpoints(t *testing.T) {
 	}
 }
 
+func TestListEndpointSlicesForService(t *testing.T) {
+	testCases := []struct {
+		name           string
+		endpointSlices []*discoveryv1.EndpointSlice
+		svc            service.MeshService
+		expected       []string
+	}{
+		{
+			name: "lists the slices owned by the service",
+			endpointSlices: []*discoveryv1.EndpointSlice{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "foo-ipv4",
+						Namespace: "ns1",
+						Labels:    map[string]string{discoveryv1.LabelServiceName: "foo"},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "foo-ipv6",
+						Namespace: "ns1",
+						Labels:    map[string]string{discoveryv1.LabelServiceName: "foo"},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "foo-other-ns",
+						Namespace: "ns2",
+						Labels:    map[string]string{discoveryv1.LabelServiceName: "foo"},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "bar",
+						Namespace: "ns1",
+						Labels:    map[string]string{discoveryv1.LabelServiceName: "bar"},
+					},
+				},
+			},
+			svc:      service.MeshService{Name: "foo", Namespace: "ns1"},
+			expected: []string{"foo-ipv4", "foo-ipv6"},
+		},
+		{
+			name: "lists the slices of the parent service for a headless subdomain-ed service",
+			endpointSlices: []*discoveryv1.EndpointSlice{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "foo-abcde",
+						Namespace: "ns1",
+						Labels:    map[string]string{discoveryv1.LabelServiceName: "foo"},
+					},
+				},
+			},
+			svc:      service.MeshService{Name: "foo-0.foo", Namespace: "ns1"},
+			expected: []string{"foo-abcde"},
+		},
+		{
+			name: "returns nil if the slice has no service label",
+			endpointSlices: []*discoveryv1.EndpointSlice{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "foo",
+						Namespace: "ns1",
+					},
+				},
+			},
+			svc:      service.MeshService{Name: "foo", Namespace: "ns1"},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := tassert.New(t)
+			ic, err := informers.NewInformerCollection(testMeshName, nil, informers.WithKubeClient(testclient.NewSimpleClientset()))
+			a.Nil(err)
+			c := newClient(ic, nil, nil, nil, nil)
+			for _, eps := range tc.endpointSlices {
+				_ = ic.Add(informers.InformerKeyEndpointSlices, eps, t)
+			}
+
+			actual, err := c.ListEndpointSlicesForService(tc.svc)
+			a.Nil(err)
+			var actualNames []string
+			for _, eps := range actual {
+				actualNames = append(actualNames, eps.Name)
+			}
+			a.ElementsMatch(tc.expected, actualNames)
+		})
+	}
+}
+
 func TestListServiceIdentitiesForService(t *testing.T) {
 	testCases := []struct {
 		name      string
@@ -540,7 +636,7 @@ func TestListServiceIdentitiesForService(t *testing.T) {
 
 			ic, err := informers.NewInformerCollection(testMeshName, nil, informers.WithKubeClient(testclient.NewSimpleClientset()))
 			a.Nil(err)
-			c := newClient(ic, nil, nil, nil)
+			c := newClient(ic, nil, nil, nil, nil)
 			_ = ic.Add(informers.InformerKeyNamespace, tc.namespace, t)
 			for _, p := range tc.pods {
 				_ = ic.Add(informers.InformerKeyPod, p, t)
@@ -699,10 +795,38 @@ func TestUpdateStatus(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "valid Retry resource",
+			existingResource: &policyv1alpha1.Retry{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "foo",
+					Namespace:  "bar",
+					Generation: 2,
+				},
+			},
+			updatedResource: &policyv1alpha1.Retry{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "foo",
+					Namespace:  "bar",
+					Generation: 2,
+				},
+				Status: policyv1alpha1.RetryStatus{
+					ObservedGeneration: 2,
+					Conditions: []metav1.Condition{
+						{
+							Type:               string(policyv1alpha1.PolicyConditionAccepted),
+							Status:             metav1.ConditionTrue,
+							Reason:             string(policyv1alpha1.PolicyReasonAccepted),
+							ObservedGeneration: 2,
+						},
+					},
+				},
+			},
+		},
 		{
 			name:             "unsupported resource",
 			existingResource: &policyv1alpha1.Egress{},
-			updatedResource:  &policyv1alpha1.Egress{},
+			updatedResource:  &corev1.Pod{},
 			expectErr:        true,
 		},
 	}
@@ -714,7 +838,82 @@ func TestUpdateStatus(t *testing.T) {
 			policyClient := fakePolicyClient.NewSimpleClientset(tc.existingResource.(runtime.Object))
 			ic, err := informers.NewInformerCollection(testMeshName, nil, informers.WithKubeClient(kubeClient), informers.WithPolicyClient(policyClient))
 			a.Nil(err)
-			c := NewKubernetesController(ic, policyClient, nil, nil)
+			c := NewKubernetesController(ic, policyClient, nil, nil, nil)
+			_, err = c.UpdateStatus(tc.updatedResource)
+			a.Equal(tc.expectErr, err != nil)
+		})
+	}
+}
+
+func TestUpdateGatewayAPIRouteStatus(t *testing.T) {
+	testCases := []struct {
+		name             string
+		existingResource runtime.Object
+		updatedResource  interface{}
+		withClient       bool
+		expectErr        bool
+	}{
+		{
+			name: "valid HTTPRoute resource",
+			existingResource: &gatewayAPIv1beta1.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "bar"},
+			},
+			updatedResource: &gatewayAPIv1beta1.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "bar"},
+				Status: gatewayAPIv1beta1.HTTPRouteStatus{
+					RouteStatus: gatewayAPIv1beta1.RouteStatus{
+						Parents: []gatewayAPIv1beta1.RouteParentStatus{{ControllerName: "test"}},
+					},
+				},
+			},
+			withClient: true,
+		},
+		{
+			name: "valid GRPCRoute resource",
+			existingResource: &gatewayAPIv1alpha2.GRPCRoute{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "bar"},
+			},
+			updatedResource: &gatewayAPIv1alpha2.GRPCRoute{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "bar"},
+			},
+			withClient: true,
+		},
+		{
+			name: "valid TCPRoute resource",
+			existingResource: &gatewayAPIv1alpha2.TCPRoute{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "bar"},
+			},
+			updatedResource: &gatewayAPIv1alpha2.TCPRoute{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "bar"},
+			},
+			withClient: true,
+		},
+		{
+			name: "Gateway API client not initialized",
+			existingResource: &gatewayAPIv1beta1.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "bar"},
+			},
+			updatedResource: &gatewayAPIv1beta1.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "bar"},
+			},
+			withClient: false,
+			expectErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := tassert.New(t)
+			kubeClient := testclient.NewSimpleClientset()
+			ic, err := informers.NewInformerCollection(testMeshName, nil, informers.WithKubeClient(kubeClient))
+			a.Nil(err)
+
+			var c Controller
+			if tc.withClient {
+				c = NewKubernetesController(ic, nil, nil, fakeGatewayAPIClient.NewSimpleClientset(tc.existingResource), nil)
+			} else {
+				c = NewKubernetesController(ic, nil, nil, nil, nil)
+			}
 			_, err = c.UpdateStatus(tc.updatedResource)
 			a.Equal(tc.expectErr, err != nil)
 		})
@@ -1086,7 +1285,7 @@ func TestK8sServicesToMeshServices(t *testing.T) {
 			ic, err := informers.NewInformerCollection(testMeshName, nil, informers.WithKubeClient(fakeClient))
 			assert.Nil(err)
 
-			kubeController := NewKubernetesController(ic, nil, nil, nil)
+			kubeController := NewKubernetesController(ic, nil, nil, nil, nil)
 			assert.NotNil(kubeController)
 
 			actual := ServiceToMeshServices(kubeController, tc.svc)
@@ -1153,7 +1352,7 @@ func TestGetPodForProxy(t *testing.T) {
 	ic, err := informers.NewInformerCollection(testMeshName, stop, informers.WithKubeClient(kubeClient))
 	assert.Nil(err)
 
-	kubeController := NewKubernetesController(ic, nil, nil, messaging.NewBroker(nil))
+	kubeController := NewKubernetesController(ic, nil, nil, nil, messaging.NewBroker(nil))
 
 	testCases := []struct {
 		name  string
@@ -1368,7 +1567,7 @@ func TestGetTargetPortForServicePort(t *testing.T) {
 
 			ic, err := informers.NewInformerCollection(testMeshName, nil, informers.WithKubeClient(testclient.NewSimpleClientset()))
 			a.Nil(err)
-			c := newClient(ic, nil, nil, nil)
+			c := newClient(ic, nil, nil, nil, nil)
 			_ = ic.Add(informers.InformerKeyService, tc.svc, t)
 			_ = ic.Add(informers.InformerKeyEndpoints, tc.endpoints, t)
 
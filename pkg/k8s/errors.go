@@ -17,4 +17,7 @@ var (
 
 	// errNamespaceDoesNotMatchProxy is an error for when the namespace of the Pod does not match the xDS certificate.
 	errNamespaceDoesNotMatchProxy = fmt.Errorf("namespace does not match proxy")
+
+	// errGatewayAPIClientNotInitialized is an error for when the status of a Gateway API resource is updated without a Gateway API client.
+	errGatewayAPIClientNotInitialized = fmt.Errorf("gateway API client not initialized")
 )
@@ -15,11 +15,15 @@ import (
 	smiTrafficSpecInformers "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/specs/informers/externalversions"
 	smiTrafficSplitClient "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/split/clientset/versioned"
 	smiTrafficSplitInformers "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/split/informers/externalversions"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
+	gatewayAPIClientset "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned"
+	gatewayAPIInformers "sigs.k8s.io/gateway-api/pkg/client/informers/externalversions"
 
 	"github.com/openservicemesh/osm/pkg/constants"
 	configClientset "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned"
@@ -78,7 +82,38 @@ func WithKubeClient(kubeClient kubernetes.Interface) InformerCollectionOption {
 		ic.informers[InformerKeyServiceAccount] = v1api.ServiceAccounts().Informer()
 		ic.informers[InformerKeyPod] = v1api.Pods().Informer()
 		ic.informers[InformerKeyEndpoints] = v1api.Endpoints().Informer()
+
+		endpointSliceInformer := informerFactory.Discovery().V1().EndpointSlices().Informer()
+		if err := endpointSliceInformer.AddIndexers(cache.Indexers{EndpointSliceServiceIndex: endpointSliceServiceIndexFunc}); err != nil {
+			log.Error().Err(err).Msgf("Error adding %s index to %s informer", EndpointSliceServiceIndex, InformerKeyEndpointSlices)
+		}
+		ic.informers[InformerKeyEndpointSlices] = endpointSliceInformer
+	}
+}
+
+// WithSecretClient sets the kubeClient for the Secret informer of the InformerCollection. Secrets are only watched by
+// the components resolving the Secrets referenced by policies, such as the JSON Web Key Sets of RequestAuthentications,
+// or by MeshRootCertificates, such as the intermediate CA of an external CA provider.
+func WithSecretClient(kubeClient kubernetes.Interface) InformerCollectionOption {
+	return func(ic *InformerCollection) {
+		informerFactory := informers.NewSharedInformerFactory(kubeClient, DefaultKubeEventResyncInterval)
+
+		ic.informers[InformerKeySecret] = informerFactory.Core().V1().Secrets().Informer()
+	}
+}
+
+// endpointSliceServiceIndexFunc indexes an EndpointSlice by the <namespace>/<name> of the Service
+// referenced by its 'kubernetes.io/service-name' label. Slices without this label are not indexed.
+func endpointSliceServiceIndexFunc(obj interface{}) ([]string, error) {
+	eps, ok := obj.(*discoveryv1.EndpointSlice)
+	if !ok {
+		return nil, nil
 	}
+	svcName, ok := eps.Labels[discoveryv1.LabelServiceName]
+	if !ok || svcName == "" {
+		return nil, nil
+	}
+	return []string{types.NamespacedName{Namespace: eps.Namespace, Name: svcName}.String()}, nil
 }
 
 // WithSMIClients sets the SMI clients for the InformerCollection
@@ -95,6 +130,17 @@ func WithSMIClients(smiTrafficSplitClient smiTrafficSplitClient.Interface, smiTr
 	}
 }
 
+// WithGatewayAPIClient sets the Gateway API client for the InformerCollection
+func WithGatewayAPIClient(gatewayAPIClient gatewayAPIClientset.Interface) InformerCollectionOption {
+	return func(ic *InformerCollection) {
+		informerFactory := gatewayAPIInformers.NewSharedInformerFactory(gatewayAPIClient, DefaultKubeEventResyncInterval)
+
+		ic.informers[InformerKeyGatewayAPIHTTPRoute] = informerFactory.Gateway().V1beta1().HTTPRoutes().Informer()
+		ic.informers[InformerKeyGatewayAPIGRPCRoute] = informerFactory.Gateway().V1alpha2().GRPCRoutes().Informer()
+		ic.informers[InformerKeyGatewayAPITCPRoute] = informerFactory.Gateway().V1alpha2().TCPRoutes().Informer()
+	}
+}
+
 // WithConfigClient sets the config client for the InformerCollection
 func WithConfigClient(configClient configClientset.Interface, meshConfigName, osmNamespace string) InformerCollectionOption {
 	return func(ic *InformerCollection) {
@@ -119,6 +165,8 @@ func WithPolicyClient(policyClient policyClientset.Interface) InformerCollection
 		ic.informers[InformerKeyIngressBackend] = informerFactory.Policy().V1alpha1().IngressBackends().Informer()
 		ic.informers[InformerKeyUpstreamTrafficSetting] = informerFactory.Policy().V1alpha1().UpstreamTrafficSettings().Informer()
 		ic.informers[InformerKeyRetry] = informerFactory.Policy().V1alpha1().Retries().Informer()
+		ic.informers[InformerKeyFaultInjection] = informerFactory.Policy().V1alpha1().FaultInjections().Informer()
+		ic.informers[InformerKeyRequestAuthentication] = informerFactory.Policy().V1alpha1().RequestAuthentications().Informer()
 		ic.informers[InformerKeyAccessControl] = informerFactory.Policy().V1alpha1().AccessControls().Informer()
 		ic.informers[InformerKeyAccessCert] = informerFactory.Policy().V1alpha1().AccessCerts().Informer()
 	}
@@ -253,6 +301,17 @@ func (ic *InformerCollection) List(informerKey InformerKey) []interface{} {
 	return informer.GetStore().List()
 }
 
+// ByIndex returns the items from the store of the informer indexed by the given InformerKey whose
+// index named indexName matches the given indexedValue
+func (ic *InformerCollection) ByIndex(informerKey InformerKey, indexName, indexedValue string) ([]interface{}, error) {
+	informer, ok := ic.informers[informerKey]
+	if !ok {
+		return nil, nil
+	}
+
+	return informer.GetIndexer().ByIndex(indexName, indexedValue)
+}
+
 // IsMonitoredNamespace returns a boolean indicating if the namespace is among the list of monitored namespaces
 func (ic InformerCollection) IsMonitoredNamespace(namespace string) bool {
 	_, exists, _ := ic.informers[InformerKeyNamespace].GetStore().GetByKey(namespace)
@@ -19,8 +19,12 @@ const (
 	InformerKeyPod InformerKey = "Pod"
 	// InformerKeyEndpoints is the InformerKey for a Endpoints informer
 	InformerKeyEndpoints InformerKey = "Endpoints"
+	// InformerKeyEndpointSlices is the InformerKey for a EndpointSlices informer
+	InformerKeyEndpointSlices InformerKey = "EndpointSlices"
 	// InformerKeyServiceAccount is the InformerKey for a ServiceAccount informer
 	InformerKeyServiceAccount InformerKey = "ServiceAccount"
+	// InformerKeySecret is the InformerKey for a Secret informer
+	InformerKeySecret InformerKey = "Secret"
 
 	// InformerKeyTrafficSplit is the InformerKey for a TrafficSplit informer
 	InformerKeyTrafficSplit InformerKey = "TrafficSplit"
@@ -31,6 +35,13 @@ const (
 	// InformerKeyTCPRoute is the InformerKey for a TCPRoute informer
 	InformerKeyTCPRoute InformerKey = "TCPRoute"
 
+	// InformerKeyGatewayAPIHTTPRoute is the InformerKey for a Gateway API HTTPRoute informer
+	InformerKeyGatewayAPIHTTPRoute InformerKey = "GatewayAPIHTTPRoute"
+	// InformerKeyGatewayAPIGRPCRoute is the InformerKey for a Gateway API GRPCRoute informer
+	InformerKeyGatewayAPIGRPCRoute InformerKey = "GatewayAPIGRPCRoute"
+	// InformerKeyGatewayAPITCPRoute is the InformerKey for a Gateway API TCPRoute informer
+	InformerKeyGatewayAPITCPRoute InformerKey = "GatewayAPITCPRoute"
+
 	// InformerKeyMeshConfig is the InformerKey for a MeshConfig informer
 	InformerKeyMeshConfig InformerKey = "MeshConfig"
 	// InformerKeyMeshRootCertificate is the InformerKey for a MeshRootCertificate informer
@@ -46,6 +57,10 @@ const (
 	InformerKeyUpstreamTrafficSetting InformerKey = "UpstreamTrafficSetting"
 	// InformerKeyRetry is the InformerKey for a Retry informer
 	InformerKeyRetry InformerKey = "Retry"
+	// InformerKeyFaultInjection is the InformerKey for a FaultInjection informer
+	InformerKeyFaultInjection InformerKey = "FaultInjection"
+	// InformerKeyRequestAuthentication is the InformerKey for a RequestAuthentication informer
+	InformerKeyRequestAuthentication InformerKey = "RequestAuthentication"
 	// InformerKeyAccessControl is the InformerKey for a AccessControl informer
 	InformerKeyAccessControl InformerKey = "AccessControl"
 	// InformerKeyAccessCert is the InformerKey for a AccessCert informer
@@ -67,6 +82,12 @@ const (
 	InformerKeyPluginConfig InformerKey = "PluginConfig"
 )
 
+const (
+	// EndpointSliceServiceIndex is the name of the index over EndpointSlices keyed by
+	// the <namespace>/<name> of the Service owning the slice
+	EndpointSliceServiceIndex = "service"
+)
+
 const (
 	// DefaultKubeEventResyncInterval is the default resync interval for k8s events
 	// This is set to 0 because we do not need resyncs from k8s client, and have our
@@ -12,7 +12,8 @@ import (
 	models "github.com/openservicemesh/osm/pkg/models"
 	service "github.com/openservicemesh/osm/pkg/service"
 	v1 "k8s.io/api/core/v1"
-	v10 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v10 "k8s.io/api/discovery/v1"
+	v11 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	types "k8s.io/apimachinery/pkg/types"
 )
 
@@ -126,6 +127,21 @@ func (mr *MockControllerMockRecorder) IsMonitoredNamespace(arg0 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsMonitoredNamespace", reflect.TypeOf((*MockController)(nil).IsMonitoredNamespace), arg0)
 }
 
+// ListEndpointSlicesForService mocks base method.
+func (m *MockController) ListEndpointSlicesForService(arg0 service.MeshService) ([]*v10.EndpointSlice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEndpointSlicesForService", arg0)
+	ret0, _ := ret[0].([]*v10.EndpointSlice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEndpointSlicesForService indicates an expected call of ListEndpointSlicesForService.
+func (mr *MockControllerMockRecorder) ListEndpointSlicesForService(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEndpointSlicesForService", reflect.TypeOf((*MockController)(nil).ListEndpointSlicesForService), arg0)
+}
+
 // ListMonitoredNamespaces mocks base method.
 func (m *MockController) ListMonitoredNamespaces() ([]string, error) {
 	m.ctrl.T.Helper()
@@ -199,10 +215,10 @@ func (mr *MockControllerMockRecorder) ListServices() *gomock.Call {
 }
 
 // UpdateStatus mocks base method.
-func (m *MockController) UpdateStatus(arg0 interface{}) (v10.Object, error) {
+func (m *MockController) UpdateStatus(arg0 interface{}) (v11.Object, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "UpdateStatus", arg0)
-	ret0, _ := ret[0].(v10.Object)
+	ret0, _ := ret[0].(v11.Object)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -1,5 +1,5 @@
 // Package k8s implements the Kubernetes Controller interface to monitor and retrieve information regarding
-// Kubernetes resources such as Namespaces, Services, Pods, Endpoints, and ServiceAccounts.
+// Kubernetes resources such as Namespaces, Services, Pods, Endpoints, EndpointSlices, and ServiceAccounts.
 package k8s
 
 import (
@@ -7,8 +7,10 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	gatewayAPIClientset "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned"
 
 	pluginv1alpha1Client "github.com/openservicemesh/osm/pkg/gen/client/plugin/clientset/versioned"
 	policyv1alpha1Client "github.com/openservicemesh/osm/pkg/gen/client/policy/clientset/versioned"
@@ -62,16 +64,19 @@ const (
 	Pods InformerKey = "Pods"
 	// Endpoints lookup identifier
 	Endpoints InformerKey = "Endpoints"
+	// EndpointSlices lookup identifier
+	EndpointSlices InformerKey = "EndpointSlices"
 	// ServiceAccounts lookup identifier
 	ServiceAccounts InformerKey = "ServiceAccounts"
 )
 
 // client is the type used to represent the k8s client for the native k8s resources
 type client struct {
-	policyClient policyv1alpha1Client.Interface
-	pluginClient pluginv1alpha1Client.Interface
-	informers    *informers.InformerCollection
-	msgBroker    *messaging.Broker
+	policyClient     policyv1alpha1Client.Interface
+	pluginClient     pluginv1alpha1Client.Interface
+	gatewayAPIClient gatewayAPIClientset.Interface
+	informers        *informers.InformerCollection
+	msgBroker        *messaging.Broker
 }
 
 // Controller is the controller interface for K8s services
@@ -105,6 +110,9 @@ type Controller interface {
 	// GetEndpoints returns the endpoints for a given service, if found
 	GetEndpoints(service.MeshService) (*corev1.Endpoints, error)
 
+	// ListEndpointSlicesForService returns the EndpointSlices owned by the given service
+	ListEndpointSlicesForService(service.MeshService) ([]*discoveryv1.EndpointSlice, error)
+
 	// UpdateStatus updates the status subresource for the given resource and GroupVersionKind
 	// The object within the 'interface{}' must be a pointer to the underlying resource
 	UpdateStatus(interface{}) (metav1.Object, error)
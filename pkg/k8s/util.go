@@ -6,6 +6,7 @@ import (
 
 	goversion "github.com/hashicorp/go-version"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 
@@ -197,6 +198,32 @@ func NamespacedNameFrom(name string) (types.NamespacedName, error) {
 	return nsName, nil
 }
 
+// GetPodZone returns the zone of the given pod as published on the EndpointSlices of the services selecting it,
+// or an empty string if the pod is not listed with a zone on any EndpointSlice
+func GetPodZone(c Controller, pod *corev1.Pod) string {
+	for _, svc := range c.ListServices() {
+		if svc.Namespace != pod.Namespace || len(svc.Spec.Selector) == 0 {
+			continue
+		}
+		if !labels.Set(svc.Spec.Selector).AsSelector().Matches(labels.Set(pod.Labels)) {
+			continue
+		}
+		endpointSlices, err := c.ListEndpointSlicesForService(service.MeshService{Namespace: svc.Namespace, Name: svc.Name})
+		if err != nil {
+			log.Error().Err(err).Msgf("Error listing EndpointSlices of service %s/%s", svc.Namespace, svc.Name)
+			continue
+		}
+		for _, eps := range endpointSlices {
+			for _, ep := range eps.Endpoints {
+				if ep.TargetRef != nil && ep.TargetRef.UID == pod.UID && ep.Zone != nil {
+					return *ep.Zone
+				}
+			}
+		}
+	}
+	return ""
+}
+
 // IsHeadlessService determines whether or not a corev1.Service is a headless service
 func IsHeadlessService(svc corev1.Service) bool {
 	return len(svc.Spec.ClusterIP) == 0 || svc.Spec.ClusterIP == corev1.ClusterIPNone
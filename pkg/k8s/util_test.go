@@ -7,11 +7,14 @@ import (
 	"github.com/golang/mock/gomock"
 	tassert "github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/version"
 	fakediscovery "k8s.io/client-go/discovery/fake"
 	"k8s.io/client-go/kubernetes"
 	fakeclient "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/pointer"
 
 	"github.com/openservicemesh/osm/pkg/service"
 	"github.com/openservicemesh/osm/pkg/tests"
@@ -329,3 +332,37 @@ func TestGetSubdomainFromHostname(t *testing.T) {
 		})
 	}
 }
+
+func TestGetPodZone(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	mockController := NewMockController(mockCtrl)
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "bookstore-1", Namespace: "ns1", UID: "uid-1", Labels: map[string]string{"app": "bookstore"}},
+	}
+	mockController.EXPECT().ListServices().Return([]*corev1.Service{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "bookbuyer", Namespace: "ns1"},
+			Spec:       corev1.ServiceSpec{Selector: map[string]string{"app": "bookbuyer"}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "bookstore", Namespace: "ns1"},
+			Spec:       corev1.ServiceSpec{Selector: map[string]string{"app": "bookstore"}},
+		},
+	}).Times(2)
+	mockController.EXPECT().ListEndpointSlicesForService(service.MeshService{Namespace: "ns1", Name: "bookstore"}).Return([]*discoveryv1.EndpointSlice{
+		{
+			Endpoints: []discoveryv1.Endpoint{
+				{TargetRef: &corev1.ObjectReference{UID: "uid-2"}, Zone: pointer.String("zone-b")},
+				{TargetRef: &corev1.ObjectReference{UID: "uid-1"}, Zone: pointer.String("zone-a")},
+			},
+		},
+	}, nil).Times(2)
+
+	assert.Equal("zone-a", GetPodZone(mockController, pod))
+
+	// A pod not listed on the EndpointSlices has no known zone
+	pod.UID = "uid-3"
+	assert.Empty(GetPodZone(mockController, pod))
+}
@@ -270,6 +270,8 @@ func getProxyUpdateEvent(msg events.PubSubMessage) *proxyUpdateEvent {
 		//
 		// Endpoint event
 		announcements.EndpointAdded, announcements.EndpointDeleted, announcements.EndpointUpdated,
+		// EndpointSlice event
+		announcements.EndpointSliceAdded, announcements.EndpointSliceDeleted, announcements.EndpointSliceUpdated,
 		// k8s Ingress event
 		announcements.IngressAdded, announcements.IngressDeleted, announcements.IngressUpdated,
 		// k8s IngressClass event
@@ -287,6 +289,12 @@ func getProxyUpdateEvent(msg events.PubSubMessage) *proxyUpdateEvent {
 		announcements.AccessControlAdded, announcements.AccessControlDeleted, announcements.AccessControlUpdated,
 		// Retry event
 		announcements.RetryPolicyAdded, announcements.RetryPolicyDeleted, announcements.RetryPolicyUpdated,
+		// FaultInjection event
+		announcements.FaultInjectionPolicyAdded, announcements.FaultInjectionPolicyDeleted, announcements.FaultInjectionPolicyUpdated,
+		// RequestAuthentication event
+		announcements.RequestAuthenticationAdded, announcements.RequestAuthenticationDeleted, announcements.RequestAuthenticationUpdated,
+		// Secret referenced by a RequestAuthentication event
+		announcements.RequestAuthenticationSecretAdded, announcements.RequestAuthenticationSecretDeleted, announcements.RequestAuthenticationSecretUpdated,
 		// UpstreamTrafficSetting event
 		announcements.UpstreamTrafficSettingAdded, announcements.UpstreamTrafficSettingDeleted, announcements.UpstreamTrafficSettingUpdated,
 		//
@@ -301,6 +309,15 @@ func getProxyUpdateEvent(msg events.PubSubMessage) *proxyUpdateEvent {
 		// SMI TrafficTarget event
 		announcements.TrafficTargetAdded, announcements.TrafficTargetDeleted, announcements.TrafficTargetUpdated,
 		//
+		// Gateway API resource events
+		//
+		// Gateway API HTTPRoute event
+		announcements.GatewayAPIHTTPRouteAdded, announcements.GatewayAPIHTTPRouteDeleted, announcements.GatewayAPIHTTPRouteUpdated,
+		// Gateway API GRPCRoute event
+		announcements.GatewayAPIGRPCRouteAdded, announcements.GatewayAPIGRPCRouteDeleted, announcements.GatewayAPIGRPCRouteUpdated,
+		// Gateway API TCPRoute event
+		announcements.GatewayAPITCPRouteAdded, announcements.GatewayAPITCPRouteDeleted, announcements.GatewayAPITCPRouteUpdated,
+		//
 		// MultiCluster events
 		//
 		// ServiceImport event
@@ -346,6 +363,9 @@ func getProxyUpdateEvent(msg events.PubSubMessage) *proxyUpdateEvent {
 			prevSpec.Traffic.InboundExternalAuthorization.Enable != newSpec.Traffic.InboundExternalAuthorization.Enable ||
 			// Only trigger an update on InboundExternalAuthorization field changes if the new spec has the 'Enable' flag set to true.
 			(newSpec.Traffic.InboundExternalAuthorization.Enable && (prevSpec.Traffic.InboundExternalAuthorization != newSpec.Traffic.InboundExternalAuthorization)) ||
+			prevSpec.Traffic.GlobalRateLimitService.Enable != newSpec.Traffic.GlobalRateLimitService.Enable ||
+			// Only trigger an update on GlobalRateLimitService field changes if the new spec has the 'Enable' flag set to true.
+			(newSpec.Traffic.GlobalRateLimitService.Enable && (prevSpec.Traffic.GlobalRateLimitService != newSpec.Traffic.GlobalRateLimitService)) ||
 			prevSpec.FeatureFlags != newSpec.FeatureFlags ||
 			!reflect.DeepEqual(prevSpec.PluginChains, newSpec.PluginChains) ||
 			!reflect.DeepEqual(prevSpec.ClusterSet, newSpec.ClusterSet) {
@@ -272,6 +272,58 @@ func TestGetProxyUpdateEvent(t *testing.T) {
 			expectEvent:   true,
 			expectedTopic: announcements.ProxyUpdate.String(),
 		},
+		{
+			name: "MeshConfig update enabling the global rate limit service results in proxy update",
+			msg: events.PubSubMessage{
+				Kind: announcements.MeshConfigUpdated,
+				OldObj: &configv1alpha2.MeshConfig{
+					Spec: configv1alpha2.MeshConfigSpec{
+						Traffic: configv1alpha2.TrafficSpec{
+							GlobalRateLimitService: configv1alpha2.GlobalRateLimitServiceSpec{
+								Address: "ratelimit.ratelimit.svc.cluster.local",
+							},
+						},
+					},
+				},
+				NewObj: &configv1alpha2.MeshConfig{
+					Spec: configv1alpha2.MeshConfigSpec{
+						Traffic: configv1alpha2.TrafficSpec{
+							GlobalRateLimitService: configv1alpha2.GlobalRateLimitServiceSpec{
+								Enable:  true,
+								Address: "ratelimit.ratelimit.svc.cluster.local",
+							},
+						},
+					},
+				},
+			},
+			expectEvent:   true,
+			expectedTopic: announcements.ProxyUpdate.String(),
+		},
+		{
+			name: "MeshConfig update of a disabled global rate limit service does not result in proxy update",
+			msg: events.PubSubMessage{
+				Kind: announcements.MeshConfigUpdated,
+				OldObj: &configv1alpha2.MeshConfig{
+					Spec: configv1alpha2.MeshConfigSpec{
+						Traffic: configv1alpha2.TrafficSpec{
+							GlobalRateLimitService: configv1alpha2.GlobalRateLimitServiceSpec{
+								Address: "ratelimit.ratelimit.svc.cluster.local",
+							},
+						},
+					},
+				},
+				NewObj: &configv1alpha2.MeshConfig{
+					Spec: configv1alpha2.MeshConfigSpec{
+						Traffic: configv1alpha2.TrafficSpec{
+							GlobalRateLimitService: configv1alpha2.GlobalRateLimitServiceSpec{
+								Address: "ratelimit.default.svc.cluster.local",
+							},
+						},
+					},
+				},
+			},
+			expectEvent: false,
+		},
 		{
 			name: "Namespace event",
 			msg: events.PubSubMessage{
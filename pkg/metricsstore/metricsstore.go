@@ -71,6 +71,9 @@ type MetricsStore struct {
 	// CertXdsIssuedCounter the histogram to track the time to issue a certificates
 	CertIssuedTime *prometheus.HistogramVec
 
+	// CertRotationTotal is the metric counter for the number of certificates rotated
+	CertRotationTotal *prometheus.CounterVec
+
 	/*
 	 * ErrCode metrics
 	 */
@@ -225,6 +228,13 @@ func init() {
 		},
 		[]string{})
 
+	defaultMetricsStore.CertRotationTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsRootNamespace,
+		Subsystem: "cert",
+		Name:      "rotation_total",
+		Help:      "Represents the number of certificates rotated",
+	}, []string{"provider"})
+
 	/*
 	 * ErrCode metrics
 	 */
@@ -2,9 +2,11 @@ package policy
 
 import (
 	"context"
+	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 
 	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
@@ -22,6 +24,18 @@ const (
 	kindSvcAccount = "ServiceAccount"
 )
 
+// trafficPolicyInformerKeys are the keys of the informers of the policies configuring the traffic of the sidecars
+var trafficPolicyInformerKeys = []informers.InformerKey{
+	informers.InformerKeyAccessControl,
+	informers.InformerKeyEgress,
+	informers.InformerKeyEgressGateway,
+	informers.InformerKeyFaultInjection,
+	informers.InformerKeyIngressBackend,
+	informers.InformerKeyRequestAuthentication,
+	informers.InformerKeyRetry,
+	informers.InformerKeyUpstreamTrafficSetting,
+}
+
 // NewPolicyController returns a policy.Controller interface related to functionality provided by the resources in the policy.openservicemesh.io API group
 func NewPolicyController(informerCollection *informers.InformerCollection, kubeClient kubernetes.Interface, kubeController k8s.Controller, msgBroker *messaging.Broker) *Client {
 	client := &Client{
@@ -80,6 +94,35 @@ func NewPolicyController(informerCollection *informers.InformerCollection, kubeC
 	}
 	client.informers.AddEventHandler(informers.InformerKeyRetry, k8s.GetEventHandlerFuncs(shouldObserve, retryEventTypes, msgBroker))
 
+	faultInjectionEventTypes := k8s.EventTypes{
+		Add:    announcements.FaultInjectionPolicyAdded,
+		Update: announcements.FaultInjectionPolicyUpdated,
+		Delete: announcements.FaultInjectionPolicyDeleted,
+	}
+	client.informers.AddEventHandler(informers.InformerKeyFaultInjection, k8s.GetEventHandlerFuncs(shouldObserve, faultInjectionEventTypes, msgBroker))
+
+	requestAuthenticationEventTypes := k8s.EventTypes{
+		Add:    announcements.RequestAuthenticationAdded,
+		Update: announcements.RequestAuthenticationUpdated,
+		Delete: announcements.RequestAuthenticationDeleted,
+	}
+	client.informers.AddEventHandler(informers.InformerKeyRequestAuthentication, k8s.GetEventHandlerFuncs(shouldObserve, requestAuthenticationEventTypes, msgBroker))
+
+	// Only the Secrets referenced by RequestAuthentication policies affect the configuration of the sidecars
+	shouldObserveSecret := func(obj interface{}) bool {
+		secret, ok := obj.(*corev1.Secret)
+		if !ok {
+			return false
+		}
+		return kubeController.IsMonitoredNamespace(secret.Namespace) && client.isRequestAuthenticationSecret(secret)
+	}
+	requestAuthenticationSecretEventTypes := k8s.EventTypes{
+		Add:    announcements.RequestAuthenticationSecretAdded,
+		Update: announcements.RequestAuthenticationSecretUpdated,
+		Delete: announcements.RequestAuthenticationSecretDeleted,
+	}
+	client.informers.AddEventHandler(informers.InformerKeySecret, k8s.GetEventHandlerFuncs(shouldObserveSecret, requestAuthenticationSecretEventTypes, msgBroker))
+
 	upstreamTrafficSettingEventTypes := k8s.EventTypes{
 		Add:    announcements.UpstreamTrafficSettingAdded,
 		Update: announcements.UpstreamTrafficSettingUpdated,
@@ -93,7 +136,7 @@ func NewPolicyController(informerCollection *informers.InformerCollection, kubeC
 // ListEgressGateways lists egress gateways
 func (c *Client) ListEgressGateways() []*policyV1alpha1.EgressGateway {
 	var egressGateways []*policyV1alpha1.EgressGateway
-	for _, egressGatewayIface := range c.informers.List(informers.InformerKeyEgressGateway) {
+	for _, egressGatewayIface := range c.list(informers.InformerKeyEgressGateway) {
 		egressGateway := egressGatewayIface.(*policyV1alpha1.EgressGateway)
 		egressGateways = append(egressGateways, egressGateway)
 	}
@@ -105,7 +148,7 @@ func (c *Client) ListEgressGateways() []*policyV1alpha1.EgressGateway {
 func (c *Client) ListEgressPoliciesForSourceIdentity(source identity.K8sServiceAccount) []*policyV1alpha1.Egress {
 	var policies []*policyV1alpha1.Egress
 
-	for _, egressIface := range c.informers.List(informers.InformerKeyEgress) {
+	for _, egressIface := range c.list(informers.InformerKeyEgress) {
 		egressPolicy := egressIface.(*policyV1alpha1.Egress)
 
 		if !c.kubeController.IsMonitoredNamespace(egressPolicy.Namespace) {
@@ -130,7 +173,7 @@ func (c *Client) GetEgressSourceSecret(secretReference corev1.SecretReference) (
 
 // GetIngressBackendPolicy returns the IngressBackend policy for the given backend MeshService
 func (c *Client) GetIngressBackendPolicy(svc service.MeshService) *policyV1alpha1.IngressBackend {
-	for _, ingressBackendIface := range c.informers.List(informers.InformerKeyIngressBackend) {
+	for _, ingressBackendIface := range c.list(informers.InformerKeyIngressBackend) {
 		ingressBackend := ingressBackendIface.(*policyV1alpha1.IngressBackend)
 
 		if ingressBackend.Namespace != svc.Namespace {
@@ -155,7 +198,7 @@ func (c *Client) GetIngressBackendPolicy(svc service.MeshService) *policyV1alpha
 func (c *Client) ListRetryPolicies(source identity.K8sServiceAccount) []*policyV1alpha1.Retry {
 	var retries []*policyV1alpha1.Retry
 
-	for _, retryInterface := range c.informers.List(informers.InformerKeyRetry) {
+	for _, retryInterface := range c.list(informers.InformerKeyRetry) {
 		retry := retryInterface.(*policyV1alpha1.Retry)
 		if retry.Spec.Source.Kind == kindSvcAccount && retry.Spec.Source.Name == source.Name && retry.Spec.Source.Namespace == source.Namespace {
 			retries = append(retries, retry)
@@ -165,9 +208,23 @@ func (c *Client) ListRetryPolicies(source identity.K8sServiceAccount) []*policyV
 	return retries
 }
 
+// ListFaultInjectionPolicies returns the fault injection policies for the given source identity based on service accounts.
+func (c *Client) ListFaultInjectionPolicies(source identity.K8sServiceAccount) []*policyV1alpha1.FaultInjection {
+	var faultInjections []*policyV1alpha1.FaultInjection
+
+	for _, faultInjectionInterface := range c.list(informers.InformerKeyFaultInjection) {
+		faultInjection := faultInjectionInterface.(*policyV1alpha1.FaultInjection)
+		if faultInjection.Spec.Source.Kind == kindSvcAccount && faultInjection.Spec.Source.Name == source.Name && faultInjection.Spec.Source.Namespace == source.Namespace {
+			faultInjections = append(faultInjections, faultInjection)
+		}
+	}
+
+	return faultInjections
+}
+
 // GetAccessControlPolicy returns the AccessControl policy for the given backend MeshService
 func (c *Client) GetAccessControlPolicy(svc service.MeshService) *policyV1alpha1.AccessControl {
-	for _, aclIface := range c.informers.List(informers.InformerKeyAccessControl) {
+	for _, aclIface := range c.list(informers.InformerKeyAccessControl) {
 		acl := aclIface.(*policyV1alpha1.AccessControl)
 
 		if acl.Namespace != svc.Namespace {
@@ -188,6 +245,57 @@ func (c *Client) GetAccessControlPolicy(svc service.MeshService) *policyV1alpha1
 	return nil
 }
 
+// GetRequestAuthenticationPolicy returns the RequestAuthentication policy for the given backend MeshService
+func (c *Client) GetRequestAuthenticationPolicy(svc service.MeshService) *policyV1alpha1.RequestAuthentication {
+	for _, requestAuthnIface := range c.list(informers.InformerKeyRequestAuthentication) {
+		requestAuthn := requestAuthnIface.(*policyV1alpha1.RequestAuthentication)
+
+		if requestAuthn.Namespace != svc.Namespace {
+			continue
+		}
+
+		// Return the first RequestAuthentication corresponding to the given MeshService.
+		// Multiple RequestAuthentication policies for the same backend will be prevented
+		// using a validating webhook.
+		for _, backend := range requestAuthn.Spec.Backends {
+			if backend.Name == svc.Name && backend.Port.Number == int(svc.TargetPort) {
+				return requestAuthn
+			}
+		}
+	}
+
+	return nil
+}
+
+// GetRequestAuthenticationSecret returns the secret resource holding the JSON Web Key Set of a RequestAuthentication policy
+func (c *Client) GetRequestAuthenticationSecret(secretReference corev1.SecretReference) (*corev1.Secret, error) {
+	key := types.NamespacedName{Namespace: secretReference.Namespace, Name: secretReference.Name}.String()
+	secretIface, exists, err := c.informers.GetByKey(informers.InformerKeySecret, key)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("secret %s not found", key)
+	}
+	return secretIface.(*corev1.Secret), nil
+}
+
+// isRequestAuthenticationSecret returns true if the given secret holds the JSON Web Key Set of a RequestAuthentication policy
+func (c *Client) isRequestAuthenticationSecret(secret *corev1.Secret) bool {
+	for _, requestAuthnIface := range c.list(informers.InformerKeyRequestAuthentication) {
+		requestAuthn := requestAuthnIface.(*policyV1alpha1.RequestAuthentication)
+		if requestAuthn.Namespace != secret.Namespace {
+			continue
+		}
+		for _, jwtRule := range requestAuthn.Spec.JWTRules {
+			if jwtRule.JWKSSecretRef != nil && jwtRule.JWKSSecretRef.Name == secret.Name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // GetUpstreamTrafficSetting returns the UpstreamTrafficSetting resource that matches the given options
 func (c *Client) GetUpstreamTrafficSetting(options UpstreamTrafficSettingGetOpt) *policyV1alpha1.UpstreamTrafficSetting {
 	if options.MeshService == nil && options.NamespacedName == nil && options.Host == "" {
@@ -197,7 +305,7 @@ func (c *Client) GetUpstreamTrafficSetting(options UpstreamTrafficSettingGetOpt)
 
 	if options.NamespacedName != nil {
 		// Filter by namespaced name
-		resource, exists, err := c.informers.GetByKey(informers.InformerKeyUpstreamTrafficSetting, options.NamespacedName.String())
+		resource, exists, err := c.getByKey(informers.InformerKeyUpstreamTrafficSetting, options.NamespacedName.String())
 		if exists && err == nil {
 			return resource.(*policyV1alpha1.UpstreamTrafficSetting)
 		}
@@ -205,14 +313,14 @@ func (c *Client) GetUpstreamTrafficSetting(options UpstreamTrafficSettingGetOpt)
 	}
 
 	// Filter by MeshService
-	for _, resource := range c.informers.List(informers.InformerKeyUpstreamTrafficSetting) {
+	for _, resource := range c.list(informers.InformerKeyUpstreamTrafficSetting) {
 		upstreamTrafficSetting := resource.(*policyV1alpha1.UpstreamTrafficSetting)
 
 		if upstreamTrafficSetting.Spec.Host == options.Host {
 			return upstreamTrafficSetting
 		}
 
-		if upstreamTrafficSetting.Namespace == options.MeshService.Namespace &&
+		if options.MeshService != nil && upstreamTrafficSetting.Namespace == options.MeshService.Namespace &&
 			upstreamTrafficSetting.Spec.Host == options.MeshService.FQDN() {
 			return upstreamTrafficSetting
 		}
@@ -220,3 +328,17 @@ func (c *Client) GetUpstreamTrafficSetting(options UpstreamTrafficSettingGetOpt)
 
 	return nil
 }
+
+// ListPolicies lists the policies of every kind of the policy.openservicemesh.io API group configuring the traffic
+// of the sidecars, ordered by kind
+func (c *Client) ListPolicies() []metav1.Object {
+	var policies []metav1.Object
+	for _, informerKey := range trafficPolicyInformerKeys {
+		for _, resource := range c.list(informerKey) {
+			if policy, ok := resource.(metav1.Object); ok {
+				policies = append(policies, policy)
+			}
+		}
+	}
+	return policies
+}
@@ -7,8 +7,10 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	fakeKubeClient "k8s.io/client-go/kubernetes/fake"
 
 	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
 	fakePolicyClient "github.com/openservicemesh/osm/pkg/gen/client/policy/clientset/versioned/fake"
@@ -684,6 +686,218 @@ func TestListRetryPolicy(t *testing.T) {
 	}
 }
 
+func TestListFaultInjectionPolicies(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockKubeController := k8s.NewMockController(mockCtrl)
+	mockKubeController.EXPECT().IsMonitoredNamespace("test").Return(true).AnyTimes()
+
+	faultInjection := &policyV1alpha1.FaultInjection{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "fault-1",
+			Namespace: "test",
+		},
+		Spec: policyV1alpha1.FaultInjectionSpec{
+			Source: policyV1alpha1.FaultInjectionSrcDstSpec{
+				Kind:      "ServiceAccount",
+				Name:      "sa-1",
+				Namespace: "test",
+			},
+			Destinations: []policyV1alpha1.FaultInjectionSrcDstSpec{
+				{
+					Kind:      "Service",
+					Name:      "s1",
+					Namespace: "test",
+				},
+			},
+			HTTPRoutes: []policyV1alpha1.HTTPFaultInjectionRouteSpec{
+				{
+					Path: "/api",
+					Abort: &policyV1alpha1.HTTPFaultAbortSpec{
+						Percentage: 10,
+						HTTPStatus: 503,
+					},
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name                    string
+		allFaultInjections      []*policyV1alpha1.FaultInjection
+		source                  identity.K8sServiceAccount
+		expectedFaultInjections []*policyV1alpha1.FaultInjection
+	}{
+		{
+			name:                    "matching fault injection policy not found for source identity test/sa-3",
+			allFaultInjections:      []*policyV1alpha1.FaultInjection{faultInjection},
+			source:                  identity.K8sServiceAccount{Name: "sa-3", Namespace: "test"},
+			expectedFaultInjections: nil,
+		},
+		{
+			name:                    "matching fault injection policy found for source identity test/sa-1",
+			allFaultInjections:      []*policyV1alpha1.FaultInjection{faultInjection},
+			source:                  identity.K8sServiceAccount{Name: "sa-1", Namespace: "test"},
+			expectedFaultInjections: []*policyV1alpha1.FaultInjection{faultInjection},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("Running test case %d: %s", i, tc.name), func(t *testing.T) {
+			a := assert.New(t)
+
+			fakeClient := fakePolicyClient.NewSimpleClientset()
+			informerCollection, err := informers.NewInformerCollection("osm", nil, informers.WithPolicyClient(fakeClient))
+			a.Nil(err)
+			c := NewPolicyController(informerCollection, nil, mockKubeController, nil)
+			a.NotNil(c)
+
+			for _, faultInjectionPolicy := range tc.allFaultInjections {
+				err := c.informers.Add(informers.InformerKeyFaultInjection, faultInjectionPolicy, t)
+				a.Nil(err)
+			}
+
+			actual := c.ListFaultInjectionPolicies(tc.source)
+			a.ElementsMatch(tc.expectedFaultInjections, actual)
+		})
+	}
+}
+
+func TestGetRequestAuthenticationPolicy(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockKubeController := k8s.NewMockController(mockCtrl)
+	mockKubeController.EXPECT().IsMonitoredNamespace("test").Return(true).AnyTimes()
+
+	requestAuthn := &policyV1alpha1.RequestAuthentication{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "authn-1",
+			Namespace: "test",
+		},
+		Spec: policyV1alpha1.RequestAuthenticationSpec{
+			Backends: []policyV1alpha1.RequestAuthenticationBackendSpec{
+				{
+					Name: "backend1",
+					Port: policyV1alpha1.PortSpec{
+						Number:   80,
+						Protocol: "http",
+					},
+				},
+			},
+			JWTRules: []policyV1alpha1.JWTRuleSpec{
+				{
+					Issuer: "https://issuer.example.com",
+					JWKS:   `{"keys":[]}`,
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name                 string
+		allResources         []*policyV1alpha1.RequestAuthentication
+		backend              service.MeshService
+		expectedRequestAuthn *policyV1alpha1.RequestAuthentication
+	}{
+		{
+			name:                 "RequestAuthentication policy found",
+			allResources:         []*policyV1alpha1.RequestAuthentication{requestAuthn},
+			backend:              service.MeshService{Name: "backend1", Namespace: "test", TargetPort: 80, Protocol: "http"},
+			expectedRequestAuthn: requestAuthn,
+		},
+		{
+			name:                 "RequestAuthentication policy port does not match MeshService.TargetPort",
+			allResources:         []*policyV1alpha1.RequestAuthentication{requestAuthn},
+			backend:              service.MeshService{Name: "backend1", Namespace: "test", TargetPort: 90, Protocol: "http"},
+			expectedRequestAuthn: nil,
+		},
+		{
+			name:                 "RequestAuthentication policy namespace does not match MeshService.Namespace",
+			allResources:         []*policyV1alpha1.RequestAuthentication{requestAuthn},
+			backend:              service.MeshService{Name: "backend1", Namespace: "test-1", TargetPort: 80, Protocol: "http"},
+			expectedRequestAuthn: nil,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("Running test case %d: %s", i, tc.name), func(t *testing.T) {
+			a := assert.New(t)
+
+			fakeClient := fakePolicyClient.NewSimpleClientset()
+			informerCollection, err := informers.NewInformerCollection("osm", nil, informers.WithPolicyClient(fakeClient))
+			a.Nil(err)
+			c := NewPolicyController(informerCollection, nil, mockKubeController, nil)
+			a.NotNil(c)
+
+			for _, resource := range tc.allResources {
+				err := c.informers.Add(informers.InformerKeyRequestAuthentication, resource, t)
+				a.Nil(err)
+			}
+
+			actual := c.GetRequestAuthenticationPolicy(tc.backend)
+			a.Equal(tc.expectedRequestAuthn, actual)
+		})
+	}
+}
+
+func TestGetRequestAuthenticationSecret(t *testing.T) {
+	a := assert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockKubeController := k8s.NewMockController(mockCtrl)
+	mockKubeController.EXPECT().IsMonitoredNamespace(gomock.Any()).Return(true).AnyTimes()
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "jwks",
+			Namespace: "test",
+		},
+		Data: map[string][]byte{"jwks": []byte(`{"keys":[]}`)},
+	}
+
+	informerCollection, err := informers.NewInformerCollection("osm", nil,
+		informers.WithPolicyClient(fakePolicyClient.NewSimpleClientset()), informers.WithSecretClient(fakeKubeClient.NewSimpleClientset()))
+	a.Nil(err)
+	c := NewPolicyController(informerCollection, nil, mockKubeController, nil)
+	a.Nil(c.informers.Add(informers.InformerKeySecret, secret, t))
+
+	actual, err := c.GetRequestAuthenticationSecret(corev1.SecretReference{Name: "jwks", Namespace: "test"})
+	a.Nil(err)
+	a.Equal(secret, actual)
+
+	_, err = c.GetRequestAuthenticationSecret(corev1.SecretReference{Name: "jwks", Namespace: "test-1"})
+	a.NotNil(err)
+
+	// the secret is only referenced once a RequestAuthentication policy in its namespace refers to it
+	a.False(c.isRequestAuthenticationSecret(secret))
+	a.Nil(c.informers.Add(informers.InformerKeyRequestAuthentication, &policyV1alpha1.RequestAuthentication{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "authn-1",
+			Namespace: "test-1",
+		},
+		Spec: policyV1alpha1.RequestAuthenticationSpec{
+			JWTRules: []policyV1alpha1.JWTRuleSpec{{Issuer: "https://issuer.example.com", JWKSSecretRef: &policyV1alpha1.JWKSSecretReferenceSpec{Name: "jwks"}}},
+		},
+	}, t))
+	a.False(c.isRequestAuthenticationSecret(secret))
+	a.Nil(c.informers.Add(informers.InformerKeyRequestAuthentication, &policyV1alpha1.RequestAuthentication{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "authn-1",
+			Namespace: "test",
+		},
+		Spec: policyV1alpha1.RequestAuthenticationSpec{
+			JWTRules: []policyV1alpha1.JWTRuleSpec{
+				{Issuer: "https://other.example.com", JWKS: `{"keys":[]}`},
+				{Issuer: "https://issuer.example.com", JWKSSecretRef: &policyV1alpha1.JWKSSecretReferenceSpec{Name: "jwks"}},
+			},
+		},
+	}, t))
+	a.True(c.isRequestAuthenticationSecret(secret))
+}
+
 func TestGetUpstreamTrafficSetting(t *testing.T) {
 	testCases := []struct {
 		name         string
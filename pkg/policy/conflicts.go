@@ -2,6 +2,7 @@ package policy
 
 import (
 	"fmt"
+	"strings"
 
 	mapset "github.com/deckarep/golang-set"
 
@@ -77,3 +78,54 @@ func DetectAccessControlConflicts(x policyv1alpha1.AccessControl, y policyv1alph
 
 	return conflicts
 }
+
+// DetectRetryConflicts detects conflicts between the given Retry resources. Retry resources conflict when they apply
+// to the same source and destination, in which case only one of their retry policies is applied.
+func DetectRetryConflicts(x policyv1alpha1.Retry, y policyv1alpha1.Retry) []error {
+	var conflicts []error // multiple conflicts could exist
+
+	// Retry resources for different sources never conflict
+	if x.Spec.Source != y.Spec.Source {
+		return nil
+	}
+
+	// Check if the destinations conflict
+	xSet := mapset.NewSet()
+	for _, destination := range x.Spec.Destinations {
+		xSet.Add(destination)
+	}
+	ySet := mapset.NewSet()
+	for _, destination := range y.Spec.Destinations {
+		ySet.Add(destination)
+	}
+
+	duplicates := xSet.Intersect(ySet)
+	for d := range duplicates.Iter() {
+		destination := d.(policyv1alpha1.RetrySrcDstSpec)
+		err := fmt.Errorf("Destination %s %s/%s for source %s %s/%s specified in %s and %s conflicts",
+			destination.Kind, destination.Namespace, destination.Name, x.Spec.Source.Kind, x.Spec.Source.Namespace, x.Spec.Source.Name, x.Name, y.Name)
+		conflicts = append(conflicts, err)
+	}
+
+	return conflicts
+}
+
+// DetectUpstreamTrafficSettingConflicts detects conflicts between the given UpstreamTrafficSetting resources.
+// UpstreamTrafficSetting resources conflict when they specify the same host, in which case only one of them is applied.
+func DetectUpstreamTrafficSettingConflicts(x policyv1alpha1.UpstreamTrafficSetting, y policyv1alpha1.UpstreamTrafficSetting) []error {
+	if !strings.EqualFold(x.Spec.Host, y.Spec.Host) {
+		return nil
+	}
+
+	return []error{fmt.Errorf("Host %s specified in %s and %s conflicts", x.Spec.Host, x.Name, y.Name)}
+}
+
+// DetectEgressGatewayConflicts detects conflicts between the given EgressGateway resources. EgressGateway resources
+// conflict when they both specify global egress gateways, in which case only one of them is applied.
+func DetectEgressGatewayConflicts(x policyv1alpha1.EgressGateway, y policyv1alpha1.EgressGateway) []error {
+	if len(x.Spec.GlobalEgressGateways) == 0 || len(y.Spec.GlobalEgressGateways) == 0 {
+		return nil
+	}
+
+	return []error{fmt.Errorf("Global egress gateways specified in %s and %s conflict", x.Name, y.Name)}
+}
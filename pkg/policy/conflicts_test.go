@@ -386,3 +386,185 @@ func TestDetectAccessControlConflicts(t *testing.T) {
 		})
 	}
 }
+
+func TestDetectRetryConflicts(t *testing.T) {
+	source := policyv1alpha1.RetrySrcDstSpec{Kind: "ServiceAccount", Name: "client", Namespace: "foo"}
+	destination1 := policyv1alpha1.RetrySrcDstSpec{Kind: "Service", Name: "server1", Namespace: "bar"}
+	destination2 := policyv1alpha1.RetrySrcDstSpec{Kind: "Service", Name: "server2", Namespace: "bar"}
+
+	testCases := []struct {
+		name              string
+		x                 policyv1alpha1.Retry
+		y                 policyv1alpha1.Retry
+		conflictsExpected int
+	}{
+		{
+			name: "single destination conflict",
+			x: policyv1alpha1.Retry{
+				ObjectMeta: metav1.ObjectMeta{Name: "retry-1", Namespace: "foo"},
+				Spec: policyv1alpha1.RetrySpec{
+					Source:       source,
+					Destinations: []policyv1alpha1.RetrySrcDstSpec{destination1},
+				},
+			},
+			y: policyv1alpha1.Retry{
+				ObjectMeta: metav1.ObjectMeta{Name: "retry-2", Namespace: "foo"},
+				Spec: policyv1alpha1.RetrySpec{
+					Source:       source,
+					Destinations: []policyv1alpha1.RetrySrcDstSpec{destination1, destination2},
+				},
+			},
+			conflictsExpected: 1,
+		},
+		{
+			name: "multiple destination conflicts",
+			x: policyv1alpha1.Retry{
+				ObjectMeta: metav1.ObjectMeta{Name: "retry-1", Namespace: "foo"},
+				Spec: policyv1alpha1.RetrySpec{
+					Source:       source,
+					Destinations: []policyv1alpha1.RetrySrcDstSpec{destination1, destination2},
+				},
+			},
+			y: policyv1alpha1.Retry{
+				ObjectMeta: metav1.ObjectMeta{Name: "retry-2", Namespace: "foo"},
+				Spec: policyv1alpha1.RetrySpec{
+					Source:       source,
+					Destinations: []policyv1alpha1.RetrySrcDstSpec{destination2, destination1},
+				},
+			},
+			conflictsExpected: 2,
+		},
+		{
+			name: "no conflict for different destinations",
+			x: policyv1alpha1.Retry{
+				ObjectMeta: metav1.ObjectMeta{Name: "retry-1", Namespace: "foo"},
+				Spec: policyv1alpha1.RetrySpec{
+					Source:       source,
+					Destinations: []policyv1alpha1.RetrySrcDstSpec{destination1},
+				},
+			},
+			y: policyv1alpha1.Retry{
+				ObjectMeta: metav1.ObjectMeta{Name: "retry-2", Namespace: "foo"},
+				Spec: policyv1alpha1.RetrySpec{
+					Source:       source,
+					Destinations: []policyv1alpha1.RetrySrcDstSpec{destination2},
+				},
+			},
+			conflictsExpected: 0,
+		},
+		{
+			name: "no conflict for different sources",
+			x: policyv1alpha1.Retry{
+				ObjectMeta: metav1.ObjectMeta{Name: "retry-1", Namespace: "foo"},
+				Spec: policyv1alpha1.RetrySpec{
+					Source:       source,
+					Destinations: []policyv1alpha1.RetrySrcDstSpec{destination1},
+				},
+			},
+			y: policyv1alpha1.Retry{
+				ObjectMeta: metav1.ObjectMeta{Name: "retry-2", Namespace: "foo"},
+				Spec: policyv1alpha1.RetrySpec{
+					Source:       policyv1alpha1.RetrySrcDstSpec{Kind: "ServiceAccount", Name: "other", Namespace: "foo"},
+					Destinations: []policyv1alpha1.RetrySrcDstSpec{destination1},
+				},
+			},
+			conflictsExpected: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := assert.New(t)
+
+			conflicts := DetectRetryConflicts(tc.x, tc.y)
+			a.Len(conflicts, tc.conflictsExpected)
+		})
+	}
+}
+
+func TestDetectUpstreamTrafficSettingConflicts(t *testing.T) {
+	testCases := []struct {
+		name              string
+		x                 policyv1alpha1.UpstreamTrafficSetting
+		y                 policyv1alpha1.UpstreamTrafficSetting
+		conflictsExpected int
+	}{
+		{
+			name: "same host conflicts",
+			x: policyv1alpha1.UpstreamTrafficSetting{
+				ObjectMeta: metav1.ObjectMeta{Name: "uts-1", Namespace: "test"},
+				Spec:       policyv1alpha1.UpstreamTrafficSettingSpec{Host: "httpbin.test.svc.cluster.local"},
+			},
+			y: policyv1alpha1.UpstreamTrafficSetting{
+				ObjectMeta: metav1.ObjectMeta{Name: "uts-2", Namespace: "test"},
+				Spec:       policyv1alpha1.UpstreamTrafficSettingSpec{Host: "HTTPBIN.test.svc.cluster.local"},
+			},
+			conflictsExpected: 1,
+		},
+		{
+			name: "different hosts do not conflict",
+			x: policyv1alpha1.UpstreamTrafficSetting{
+				ObjectMeta: metav1.ObjectMeta{Name: "uts-1", Namespace: "test"},
+				Spec:       policyv1alpha1.UpstreamTrafficSettingSpec{Host: "httpbin.test.svc.cluster.local"},
+			},
+			y: policyv1alpha1.UpstreamTrafficSetting{
+				ObjectMeta: metav1.ObjectMeta{Name: "uts-2", Namespace: "test"},
+				Spec:       policyv1alpha1.UpstreamTrafficSettingSpec{Host: "bookstore.test.svc.cluster.local"},
+			},
+			conflictsExpected: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := assert.New(t)
+
+			conflicts := DetectUpstreamTrafficSettingConflicts(tc.x, tc.y)
+			a.Len(conflicts, tc.conflictsExpected)
+		})
+	}
+}
+
+func TestDetectEgressGatewayConflicts(t *testing.T) {
+	globalGateways := []policyv1alpha1.GatewayBindingSubject{{Service: "fsm-egress-gateway", Namespace: "osm-system"}}
+
+	testCases := []struct {
+		name              string
+		x                 policyv1alpha1.EgressGateway
+		y                 policyv1alpha1.EgressGateway
+		conflictsExpected int
+	}{
+		{
+			name: "both with global gateways conflict",
+			x: policyv1alpha1.EgressGateway{
+				ObjectMeta: metav1.ObjectMeta{Name: "egress-gateway-1", Namespace: "test"},
+				Spec:       policyv1alpha1.EgressGatewaySpec{GlobalEgressGateways: globalGateways},
+			},
+			y: policyv1alpha1.EgressGateway{
+				ObjectMeta: metav1.ObjectMeta{Name: "egress-gateway-2", Namespace: "test"},
+				Spec:       policyv1alpha1.EgressGatewaySpec{GlobalEgressGateways: globalGateways},
+			},
+			conflictsExpected: 1,
+		},
+		{
+			name: "single global gateway does not conflict",
+			x: policyv1alpha1.EgressGateway{
+				ObjectMeta: metav1.ObjectMeta{Name: "egress-gateway-1", Namespace: "test"},
+				Spec:       policyv1alpha1.EgressGatewaySpec{GlobalEgressGateways: globalGateways},
+			},
+			y: policyv1alpha1.EgressGateway{
+				ObjectMeta: metav1.ObjectMeta{Name: "egress-gateway-2", Namespace: "test"},
+			},
+			conflictsExpected: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := assert.New(t)
+
+			conflicts := DetectEgressGatewayConflicts(tc.x, tc.y)
+			a.Len(conflicts, tc.conflictsExpected)
+		})
+	}
+}
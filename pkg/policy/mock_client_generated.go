@@ -12,6 +12,7 @@ import (
 	identity "github.com/openservicemesh/osm/pkg/identity"
 	service "github.com/openservicemesh/osm/pkg/service"
 	v1 "k8s.io/api/core/v1"
+	v10 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // MockController is a mock of Controller interface.
@@ -80,6 +81,35 @@ func (mr *MockControllerMockRecorder) GetIngressBackendPolicy(arg0 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIngressBackendPolicy", reflect.TypeOf((*MockController)(nil).GetIngressBackendPolicy), arg0)
 }
 
+// GetRequestAuthenticationPolicy mocks base method.
+func (m *MockController) GetRequestAuthenticationPolicy(arg0 service.MeshService) *v1alpha1.RequestAuthentication {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRequestAuthenticationPolicy", arg0)
+	ret0, _ := ret[0].(*v1alpha1.RequestAuthentication)
+	return ret0
+}
+
+// GetRequestAuthenticationPolicy indicates an expected call of GetRequestAuthenticationPolicy.
+func (mr *MockControllerMockRecorder) GetRequestAuthenticationPolicy(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRequestAuthenticationPolicy", reflect.TypeOf((*MockController)(nil).GetRequestAuthenticationPolicy), arg0)
+}
+
+// GetRequestAuthenticationSecret mocks base method.
+func (m *MockController) GetRequestAuthenticationSecret(arg0 v1.SecretReference) (*v1.Secret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRequestAuthenticationSecret", arg0)
+	ret0, _ := ret[0].(*v1.Secret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRequestAuthenticationSecret indicates an expected call of GetRequestAuthenticationSecret.
+func (mr *MockControllerMockRecorder) GetRequestAuthenticationSecret(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRequestAuthenticationSecret", reflect.TypeOf((*MockController)(nil).GetRequestAuthenticationSecret), arg0)
+}
+
 // GetUpstreamTrafficSetting mocks base method.
 func (m *MockController) GetUpstreamTrafficSetting(arg0 UpstreamTrafficSettingGetOpt) *v1alpha1.UpstreamTrafficSetting {
 	m.ctrl.T.Helper()
@@ -122,6 +152,34 @@ func (mr *MockControllerMockRecorder) ListEgressPoliciesForSourceIdentity(arg0 i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEgressPoliciesForSourceIdentity", reflect.TypeOf((*MockController)(nil).ListEgressPoliciesForSourceIdentity), arg0)
 }
 
+// ListFaultInjectionPolicies mocks base method.
+func (m *MockController) ListFaultInjectionPolicies(arg0 identity.K8sServiceAccount) []*v1alpha1.FaultInjection {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFaultInjectionPolicies", arg0)
+	ret0, _ := ret[0].([]*v1alpha1.FaultInjection)
+	return ret0
+}
+
+// ListFaultInjectionPolicies indicates an expected call of ListFaultInjectionPolicies.
+func (mr *MockControllerMockRecorder) ListFaultInjectionPolicies(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFaultInjectionPolicies", reflect.TypeOf((*MockController)(nil).ListFaultInjectionPolicies), arg0)
+}
+
+// ListPolicies mocks base method.
+func (m *MockController) ListPolicies() []v10.Object {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPolicies")
+	ret0, _ := ret[0].([]v10.Object)
+	return ret0
+}
+
+// ListPolicies indicates an expected call of ListPolicies.
+func (mr *MockControllerMockRecorder) ListPolicies() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPolicies", reflect.TypeOf((*MockController)(nil).ListPolicies))
+}
+
 // ListRetryPolicies mocks base method.
 func (m *MockController) ListRetryPolicies(arg0 identity.K8sServiceAccount) []*v1alpha1.Retry {
 	m.ctrl.T.Helper()
@@ -135,3 +193,18 @@ func (mr *MockControllerMockRecorder) ListRetryPolicies(arg0 interface{}) *gomoc
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRetryPolicies", reflect.TypeOf((*MockController)(nil).ListRetryPolicies), arg0)
 }
+
+// Preview mocks base method.
+func (m *MockController) Preview(arg0 v10.Object) (Controller, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Preview", arg0)
+	ret0, _ := ret[0].(Controller)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Preview indicates an expected call of Preview.
+func (mr *MockControllerMockRecorder) Preview(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Preview", reflect.TypeOf((*MockController)(nil).Preview), arg0)
+}
@@ -0,0 +1,122 @@
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/k8s/informers"
+)
+
+// previewableKinds are the kinds of the policy.openservicemesh.io API group which can be previewed, by kind
+var previewableKinds = map[string]func() metav1.Object{
+	"AccessControl":          func() metav1.Object { return &policyV1alpha1.AccessControl{} },
+	"Egress":                 func() metav1.Object { return &policyV1alpha1.Egress{} },
+	"EgressGateway":          func() metav1.Object { return &policyV1alpha1.EgressGateway{} },
+	"FaultInjection":         func() metav1.Object { return &policyV1alpha1.FaultInjection{} },
+	"IngressBackend":         func() metav1.Object { return &policyV1alpha1.IngressBackend{} },
+	"RequestAuthentication":  func() metav1.Object { return &policyV1alpha1.RequestAuthentication{} },
+	"Retry":                  func() metav1.Object { return &policyV1alpha1.Retry{} },
+	"UpstreamTrafficSetting": func() metav1.Object { return &policyV1alpha1.UpstreamTrafficSetting{} },
+}
+
+// previewedPolicy is a policy served by a Client as if it were applied
+type previewedPolicy struct {
+	informerKey informers.InformerKey
+	key         string
+	obj         metav1.Object
+}
+
+// DecodePolicy decodes a JSON encoded resource of the policy.openservicemesh.io API group which can be previewed
+func DecodePolicy(data []byte) (metav1.Object, error) {
+	typeMeta := metav1.TypeMeta{}
+	if err := json.Unmarshal(data, &typeMeta); err != nil {
+		return nil, fmt.Errorf("error decoding the policy: %w", err)
+	}
+	if typeMeta.APIVersion != policyV1alpha1.SchemeGroupVersion.String() {
+		return nil, fmt.Errorf("unsupported apiVersion %q, must be %s", typeMeta.APIVersion, policyV1alpha1.SchemeGroupVersion)
+	}
+	newObject, ok := previewableKinds[typeMeta.Kind]
+	if !ok {
+		return nil, fmt.Errorf("unsupported kind %q", typeMeta.Kind)
+	}
+
+	obj := newObject()
+	if err := json.Unmarshal(data, obj); err != nil {
+		return nil, fmt.Errorf("error decoding the %s policy: %w", typeMeta.Kind, err)
+	}
+	if obj.GetName() == "" || obj.GetNamespace() == "" {
+		return nil, fmt.Errorf("the name and namespace of the %s policy must be specified", typeMeta.Kind)
+	}
+	return obj, nil
+}
+
+// Preview returns a Controller serving the same policies as the client as if the given policy were applied, that is
+// replacing the policy of the same kind, namespace and name if any, or added to the policies otherwise
+func (c *Client) Preview(obj metav1.Object) (Controller, error) {
+	var informerKey informers.InformerKey
+	switch obj.(type) {
+	case *policyV1alpha1.AccessControl:
+		informerKey = informers.InformerKeyAccessControl
+	case *policyV1alpha1.Egress:
+		informerKey = informers.InformerKeyEgress
+	case *policyV1alpha1.EgressGateway:
+		informerKey = informers.InformerKeyEgressGateway
+	case *policyV1alpha1.FaultInjection:
+		informerKey = informers.InformerKeyFaultInjection
+	case *policyV1alpha1.IngressBackend:
+		informerKey = informers.InformerKeyIngressBackend
+	case *policyV1alpha1.RequestAuthentication:
+		informerKey = informers.InformerKeyRequestAuthentication
+	case *policyV1alpha1.Retry:
+		informerKey = informers.InformerKeyRetry
+	case *policyV1alpha1.UpstreamTrafficSetting:
+		informerKey = informers.InformerKeyUpstreamTrafficSetting
+	default:
+		return nil, fmt.Errorf("policies of type %T cannot be previewed", obj)
+	}
+
+	previewClient := *c
+	previewClient.preview = &previewedPolicy{
+		informerKey: informerKey,
+		key:         types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}.String(),
+		obj:         obj,
+	}
+	return &previewClient, nil
+}
+
+// list returns the contents of the store of the informer indexed by the given InformerKey, including the previewed
+// policy if any
+func (c *Client) list(informerKey informers.InformerKey) []interface{} {
+	objs := c.informers.List(informerKey)
+	if c.preview == nil || c.preview.informerKey != informerKey {
+		return objs
+	}
+
+	previewed := make([]interface{}, 0, len(objs)+1)
+	replaced := false
+	for _, obj := range objs {
+		if object, ok := obj.(metav1.Object); ok && object.GetNamespace() == c.preview.obj.GetNamespace() && object.GetName() == c.preview.obj.GetName() {
+			// Keep the position of the replaced policy, since the first matching policy applies
+			obj = c.preview.obj
+			replaced = true
+		}
+		previewed = append(previewed, obj)
+	}
+	if !replaced {
+		previewed = append(previewed, c.preview.obj)
+	}
+	return previewed
+}
+
+// getByKey retrieves an item from the store of the informer indexed by the given InformerKey, or the previewed
+// policy if it has the given key
+func (c *Client) getByKey(informerKey informers.InformerKey, objectKey string) (interface{}, bool, error) {
+	if c.preview != nil && c.preview.informerKey == informerKey && c.preview.key == objectKey {
+		return c.preview.obj, true, nil
+	}
+	return c.informers.GetByKey(informerKey, objectKey)
+}
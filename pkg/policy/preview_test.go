@@ -0,0 +1,123 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	fakePolicyClient "github.com/openservicemesh/osm/pkg/gen/client/policy/clientset/versioned/fake"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/k8s"
+	"github.com/openservicemesh/osm/pkg/k8s/informers"
+)
+
+func TestDecodePolicy(t *testing.T) {
+	testCases := []struct {
+		name        string
+		data        string
+		expected    metav1.Object
+		expectedErr bool
+	}{
+		{
+			name: "Retry is decoded",
+			data: `{"apiVersion":"policy.openservicemesh.io/v1alpha1","kind":"Retry","metadata":{"name":"retry","namespace":"test"},"spec":{"source":{"kind":"ServiceAccount","name":"sa","namespace":"test"}}}`,
+			expected: &policyV1alpha1.Retry{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "policy.openservicemesh.io/v1alpha1", Kind: "Retry"},
+				ObjectMeta: metav1.ObjectMeta{Name: "retry", Namespace: "test"},
+				Spec: policyV1alpha1.RetrySpec{
+					Source: policyV1alpha1.RetrySrcDstSpec{Kind: "ServiceAccount", Name: "sa", Namespace: "test"},
+				},
+			},
+		},
+		{
+			name:        "unsupported apiVersion",
+			data:        `{"apiVersion":"access.smi-spec.io/v1alpha3","kind":"TrafficTarget","metadata":{"name":"tt","namespace":"test"}}`,
+			expectedErr: true,
+		},
+		{
+			name:        "unsupported kind",
+			data:        `{"apiVersion":"policy.openservicemesh.io/v1alpha1","kind":"AccessCert","metadata":{"name":"cert","namespace":"test"}}`,
+			expectedErr: true,
+		},
+		{
+			name:        "missing namespace",
+			data:        `{"apiVersion":"policy.openservicemesh.io/v1alpha1","kind":"Retry","metadata":{"name":"retry"}}`,
+			expectedErr: true,
+		},
+		{
+			name:        "invalid JSON",
+			data:        `{"apiVersion":`,
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := assert.New(t)
+
+			obj, err := DecodePolicy([]byte(tc.data))
+			a.Equal(tc.expectedErr, err != nil)
+			a.Equal(tc.expected, obj)
+		})
+	}
+}
+
+func TestPreview(t *testing.T) {
+	a := assert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	source := identity.K8sServiceAccount{Name: "sa", Namespace: "test"}
+	newRetry := func(name string, destination string) *policyV1alpha1.Retry {
+		return &policyV1alpha1.Retry{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test"},
+			Spec: policyV1alpha1.RetrySpec{
+				Source:       policyV1alpha1.RetrySrcDstSpec{Kind: "ServiceAccount", Name: source.Name, Namespace: source.Namespace},
+				Destinations: []policyV1alpha1.RetrySrcDstSpec{{Kind: "Service", Name: destination, Namespace: "test"}},
+			},
+		}
+	}
+	existingRetry := newRetry("retry-1", "s1")
+	existingSetting := &policyV1alpha1.UpstreamTrafficSetting{
+		ObjectMeta: metav1.ObjectMeta{Name: "setting", Namespace: "test"},
+		Spec:       policyV1alpha1.UpstreamTrafficSettingSpec{Host: "s1.test.svc.cluster.local"},
+	}
+
+	informerCollection, err := informers.NewInformerCollection("osm", nil, informers.WithPolicyClient(fakePolicyClient.NewSimpleClientset()))
+	a.Nil(err)
+	c := NewPolicyController(informerCollection, nil, k8s.NewMockController(mockCtrl), nil)
+	a.Nil(c.informers.Add(informers.InformerKeyRetry, existingRetry, t))
+	a.Nil(c.informers.Add(informers.InformerKeyUpstreamTrafficSetting, existingSetting, t))
+
+	// A new policy is added to the existing policies
+	addedRetry := newRetry("retry-2", "s2")
+	preview, err := c.Preview(addedRetry)
+	a.Nil(err)
+	a.ElementsMatch([]*policyV1alpha1.Retry{existingRetry, addedRetry}, preview.ListRetryPolicies(source))
+
+	// An updated policy replaces the existing policy
+	updatedRetry := newRetry("retry-1", "s3")
+	preview, err = c.Preview(updatedRetry)
+	a.Nil(err)
+	a.Equal([]*policyV1alpha1.Retry{updatedRetry}, preview.ListRetryPolicies(source))
+
+	// Previewing a policy does not change the policies of the client
+	a.Equal([]*policyV1alpha1.Retry{existingRetry}, c.ListRetryPolicies(source))
+
+	// Policies retrieved by key are replaced as well
+	updatedSetting := existingSetting.DeepCopy()
+	updatedSetting.Spec.Host = "s2.test.svc.cluster.local"
+	preview, err = c.Preview(updatedSetting)
+	a.Nil(err)
+	a.Equal(updatedSetting, preview.GetUpstreamTrafficSetting(UpstreamTrafficSettingGetOpt{NamespacedName: &types.NamespacedName{Namespace: "test", Name: "setting"}}))
+	a.Nil(preview.GetUpstreamTrafficSetting(UpstreamTrafficSettingGetOpt{Host: "s1.test.svc.cluster.local"}))
+	a.Equal(existingSetting, c.GetUpstreamTrafficSetting(UpstreamTrafficSettingGetOpt{Host: "s1.test.svc.cluster.local"}))
+
+	// Only policies of the policy.openservicemesh.io API group can be previewed
+	_, err = c.Preview(&policyV1alpha1.AccessCert{})
+	a.NotNil(err)
+}
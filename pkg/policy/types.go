@@ -3,6 +3,7 @@ package policy
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 
@@ -24,6 +25,9 @@ type Client struct {
 	informers      *informers.InformerCollection
 	kubeClient     kubernetes.Interface
 	kubeController k8s.Controller
+
+	// preview is the policy served as if it were applied, only set on clients returned by Preview
+	preview *previewedPolicy
 }
 
 // Controller is the interface for the functionality provided by the resources part of the policy.openservicemesh.io API group
@@ -43,11 +47,26 @@ type Controller interface {
 	// ListRetryPolicies returns the Retry policies for the given source identity
 	ListRetryPolicies(identity.K8sServiceAccount) []*policyv1alpha1.Retry
 
+	// ListFaultInjectionPolicies returns the FaultInjection policies for the given source identity
+	ListFaultInjectionPolicies(identity.K8sServiceAccount) []*policyv1alpha1.FaultInjection
+
+	// GetRequestAuthenticationPolicy returns the RequestAuthentication policy for the given backend MeshService
+	GetRequestAuthenticationPolicy(service.MeshService) *policyv1alpha1.RequestAuthentication
+
+	// GetRequestAuthenticationSecret returns the secret resource holding the JSON Web Key Set of a RequestAuthentication policy
+	GetRequestAuthenticationSecret(corev1.SecretReference) (*corev1.Secret, error)
+
 	// GetAccessControlPolicy returns the AccessControl policy for the given backend MeshService
 	GetAccessControlPolicy(service.MeshService) *policyv1alpha1.AccessControl
 
 	// GetUpstreamTrafficSetting returns the UpstreamTrafficSetting resource that matches the given options
 	GetUpstreamTrafficSetting(UpstreamTrafficSettingGetOpt) *policyv1alpha1.UpstreamTrafficSetting
+
+	// ListPolicies lists the policies of every kind configuring the traffic of the sidecars
+	ListPolicies() []metav1.Object
+
+	// Preview returns a Controller serving the same policies as if the given policy were applied
+	Preview(metav1.Object) (Controller, error)
 }
 
 // UpstreamTrafficSettingGetOpt specifies the options used to filter UpstreamTrafficSetting objects as a part of its getter
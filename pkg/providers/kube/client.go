@@ -3,10 +3,13 @@ package kube
 
 import (
 	"net"
+	"strconv"
 
 	mapset "github.com/deckarep/golang-set"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/pointer"
 
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/endpoint"
@@ -37,6 +40,16 @@ func (c *client) GetID() string {
 func (c *client) ListEndpointsForService(svc service.MeshService) []endpoint.Endpoint {
 	log.Trace().Msgf("Getting Endpoints for MeshService %s on Kubernetes", svc)
 
+	// EndpointSlices are preferred over Endpoints since they are not truncated for large
+	// services and carry addresses of every IP family. Endpoints are only used as a fallback
+	// when no EndpointSlice exists for the service.
+	endpointSlices, err := c.kubeController.ListEndpointSlicesForService(svc)
+	if err == nil && len(endpointSlices) > 0 {
+		endpoints := listEndpointsFromSlices(svc, endpointSlices)
+		log.Trace().Msgf("Endpoints for MeshService %s from EndpointSlices: %v", svc, endpoints)
+		return endpoints
+	}
+
 	kubernetesEndpoints, err := c.kubeController.GetEndpoints(svc)
 	if err != nil || kubernetesEndpoints == nil {
 		log.Info().Msgf("No k8s endpoints found for MeshService %s", svc)
@@ -77,6 +90,83 @@ func (c *client) ListEndpointsForService(svc service.MeshService) []endpoint.End
 	return endpoints
 }
 
+// listEndpointsFromSlices merges the endpoints of the given EndpointSlices belonging to the given service.
+// Ready endpoints are returned when there is at least one. Otherwise, endpoints that are terminating
+// but still serving are returned so in-flight traffic can drain gracefully.
+func listEndpointsFromSlices(svc service.MeshService, endpointSlices []*discoveryv1.EndpointSlice) []endpoint.Endpoint {
+	var readyEndpoints, terminatingEndpoints []endpoint.Endpoint
+	// The same address may transiently be present in more than one slice
+	seen := make(map[string]struct{})
+
+	for _, eps := range endpointSlices {
+		if eps.AddressType == discoveryv1.AddressTypeFQDN {
+			continue
+		}
+		for _, port := range eps.Ports {
+			if port.Port == nil {
+				continue
+			}
+			// If a TargetPort is specified for the service, filter the endpoint by this port.
+			if svc.TargetPort != 0 && *port.Port != int32(svc.TargetPort) {
+				continue
+			}
+			for _, ep := range eps.Endpoints {
+				if svc.Subdomain() != "" && svc.Subdomain() != pointer.StringDeref(ep.Hostname, "") {
+					// if there's a subdomain on this meshservice, make sure it matches the endpoint's hostname
+					continue
+				}
+
+				// Per the EndpointSlice API, a nil condition must be interpreted as true for 'ready' and 'serving',
+				// and as false for 'terminating'. 'serving' defaults to 'ready' for clusters that do not set it.
+				ready := pointer.BoolDeref(ep.Conditions.Ready, true)
+				serving := pointer.BoolDeref(ep.Conditions.Serving, ready)
+				terminating := pointer.BoolDeref(ep.Conditions.Terminating, false)
+				if !ready && !(serving && terminating) {
+					continue
+				}
+
+				var zoneHints []string
+				if ep.Hints != nil {
+					for _, zone := range ep.Hints.ForZones {
+						zoneHints = append(zoneHints, zone.Name)
+					}
+				}
+
+				for _, address := range ep.Addresses {
+					ip := net.ParseIP(address)
+					if ip == nil {
+						log.Error().Msgf("Error parsing endpoint IP address %s for MeshService %s", address, svc)
+						continue
+					}
+					key := net.JoinHostPort(ip.String(), strconv.Itoa(int(*port.Port)))
+					if _, ok := seen[key]; ok {
+						continue
+					}
+					seen[key] = struct{}{}
+
+					ept := endpoint.Endpoint{
+						IP:        ip,
+						Port:      endpoint.Port(*port.Port),
+						Zone:      pointer.StringDeref(ep.Zone, ""),
+						ZoneHints: zoneHints,
+					}
+					if ready {
+						readyEndpoints = append(readyEndpoints, ept)
+					} else {
+						ept.Terminating = true
+						terminatingEndpoints = append(terminatingEndpoints, ept)
+					}
+				}
+			}
+		}
+	}
+
+	if len(readyEndpoints) == 0 {
+		return terminatingEndpoints
+	}
+	return readyEndpoints
+}
+
 // ListEndpointsForIdentity retrieves the list of IP addresses for the given service account
 // Note: ServiceIdentity must be in the format "name.namespace" [https://github.com/openservicemesh/osm/issues/3188]
 func (c *client) ListEndpointsForIdentity(serviceIdentity identity.ServiceIdentity) []endpoint.Endpoint {
@@ -9,9 +9,11 @@ import (
 	"github.com/google/uuid"
 	tassert "github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	testclient "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/pointer"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -55,6 +57,7 @@ var _ = Describe("Test Kube client Provider (w/o kubecontroller)", func() {
 
 	It("should correctly return a list of endpoints for a service", func() {
 		// Should be empty for now
+		mockKubeController.EXPECT().ListEndpointSlicesForService(meshSvc).Return(nil, nil)
 		mockKubeController.EXPECT().GetEndpoints(meshSvc).Return(&corev1.Endpoints{
 			ObjectMeta: metav1.ObjectMeta{
 				Namespace: meshSvc.Namespace,
@@ -93,6 +96,7 @@ var _ = Describe("Test Kube client Provider (w/o kubecontroller)", func() {
 			TargetPort: 90,
 		}
 		// Should be empty for now
+		mockKubeController.EXPECT().ListEndpointSlicesForService(subdomainedSvc).Return(nil, nil)
 		mockKubeController.EXPECT().GetEndpoints(subdomainedSvc).Return(&corev1.Endpoints{
 			ObjectMeta: metav1.ObjectMeta{
 				Namespace: subdomainedSvc.Namespace,
@@ -136,6 +140,8 @@ var _ = Describe("Test Kube client Provider (w/o kubecontroller)", func() {
 			// No TargetPort
 		}
 
+		mockKubeController.EXPECT().ListEndpointSlicesForService(svc).Return(nil, nil)
+
 		mockKubeController.EXPECT().GetEndpoints(svc).Return(&corev1.Endpoints{
 			ObjectMeta: metav1.ObjectMeta{
 				Namespace: svc.Namespace,
@@ -171,6 +177,111 @@ var _ = Describe("Test Kube client Provider (w/o kubecontroller)", func() {
 		}))
 	})
 
+	It("should merge the EndpointSlices of a dual-stack service", func() {
+		mockKubeController.EXPECT().ListEndpointSlicesForService(meshSvc).Return([]*discoveryv1.EndpointSlice{
+			{
+				ObjectMeta:  metav1.ObjectMeta{Name: "test-ipv4", Namespace: meshSvc.Namespace},
+				AddressType: discoveryv1.AddressTypeIPv4,
+				Endpoints: []discoveryv1.Endpoint{
+					{
+						Addresses: []string{"8.8.8.8"},
+						Zone:      pointer.String("zone-a"),
+						Hints: &discoveryv1.EndpointHints{
+							ForZones: []discoveryv1.ForZone{{Name: "zone-a"}},
+						},
+					},
+					{
+						// Not ready, should be ignored
+						Addresses:  []string{"9.9.9.9"},
+						Conditions: discoveryv1.EndpointConditions{Ready: pointer.Bool(false)},
+					},
+				},
+				Ports: []discoveryv1.EndpointPort{
+					{Port: pointer.Int32(int32(meshSvc.TargetPort))},
+					{Port: pointer.Int32(8888)}, // Does not match meshSvc.TargetPort, should be ignored
+				},
+			},
+			{
+				ObjectMeta:  metav1.ObjectMeta{Name: "test-ipv6", Namespace: meshSvc.Namespace},
+				AddressType: discoveryv1.AddressTypeIPv6,
+				Endpoints: []discoveryv1.Endpoint{
+					{
+						Addresses:  []string{"fd00::1"},
+						Conditions: discoveryv1.EndpointConditions{Ready: pointer.Bool(true)},
+					},
+				},
+				Ports: []discoveryv1.EndpointPort{
+					{Port: pointer.Int32(int32(meshSvc.TargetPort))},
+				},
+			},
+			{
+				// Duplicate of an address in another slice, should be ignored
+				ObjectMeta:  metav1.ObjectMeta{Name: "test-dup", Namespace: meshSvc.Namespace},
+				AddressType: discoveryv1.AddressTypeIPv4,
+				Endpoints: []discoveryv1.Endpoint{
+					{
+						Addresses: []string{"8.8.8.8"},
+					},
+				},
+				Ports: []discoveryv1.EndpointPort{
+					{Port: pointer.Int32(int32(meshSvc.TargetPort))},
+				},
+			},
+		}, nil)
+
+		Expect(c.ListEndpointsForService(meshSvc)).To(Equal([]endpoint.Endpoint{
+			{
+				IP:        net.IPv4(8, 8, 8, 8),
+				Port:      endpoint.Port(meshSvc.TargetPort),
+				Zone:      "zone-a",
+				ZoneHints: []string{"zone-a"},
+			},
+			{
+				IP:   net.ParseIP("fd00::1"),
+				Port: endpoint.Port(meshSvc.TargetPort),
+			},
+		}))
+	})
+
+	It("should fall back to serving terminating endpoints when no EndpointSlice endpoint is ready", func() {
+		mockKubeController.EXPECT().ListEndpointSlicesForService(meshSvc).Return([]*discoveryv1.EndpointSlice{
+			{
+				ObjectMeta:  metav1.ObjectMeta{Name: "test-ipv4", Namespace: meshSvc.Namespace},
+				AddressType: discoveryv1.AddressTypeIPv4,
+				Endpoints: []discoveryv1.Endpoint{
+					{
+						Addresses: []string{"8.8.8.8"},
+						Conditions: discoveryv1.EndpointConditions{
+							Ready:       pointer.Bool(false),
+							Serving:     pointer.Bool(true),
+							Terminating: pointer.Bool(true),
+						},
+					},
+					{
+						// Terminating and no longer serving, should be ignored
+						Addresses: []string{"9.9.9.9"},
+						Conditions: discoveryv1.EndpointConditions{
+							Ready:       pointer.Bool(false),
+							Serving:     pointer.Bool(false),
+							Terminating: pointer.Bool(true),
+						},
+					},
+				},
+				Ports: []discoveryv1.EndpointPort{
+					{Port: pointer.Int32(int32(meshSvc.TargetPort))},
+				},
+			},
+		}, nil)
+
+		Expect(c.ListEndpointsForService(meshSvc)).To(Equal([]endpoint.Endpoint{
+			{
+				IP:          net.IPv4(8, 8, 8, 8),
+				Port:        endpoint.Port(meshSvc.TargetPort),
+				Terminating: true,
+			},
+		}))
+	})
+
 	It("GetResolvableEndpoints should properly return endpoints based on ClusterIP when set", func() {
 		// If the service has cluster IP, expect the cluster IP + port
 		mockKubeController.EXPECT().GetService(tests.BookbuyerService).Return(&corev1.Service{
@@ -218,6 +329,8 @@ var _ = Describe("Test Kube client Provider (w/o kubecontroller)", func() {
 			},
 		})
 
+		mockKubeController.EXPECT().ListEndpointSlicesForService(meshSvc).Return(nil, nil)
+
 		mockKubeController.EXPECT().GetEndpoints(meshSvc).Return(&corev1.Endpoints{
 			ObjectMeta: metav1.ObjectMeta{
 				Namespace: meshSvc.Namespace,
@@ -270,6 +383,8 @@ var _ = Describe("Test Kube client Provider (w/o kubecontroller)", func() {
 			},
 		})
 
+		mockKubeController.EXPECT().ListEndpointSlicesForService(meshSvc).Return(nil, nil)
+
 		mockKubeController.EXPECT().GetEndpoints(meshSvc).Return(&corev1.Endpoints{
 			ObjectMeta: metav1.ObjectMeta{
 				Namespace: meshSvc.Namespace,
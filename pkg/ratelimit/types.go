@@ -0,0 +1,28 @@
+// Package ratelimit implements the GlobalRateLimitConfig struct.
+package ratelimit
+
+import (
+	"time"
+)
+
+// GlobalRateLimitConfig implements a generic subset of the global rate limiting settings used to configure
+// the remote rate limit service through HttpFilters
+type GlobalRateLimitConfig struct {
+	// Enable enables/disables global rate limiting.
+	Enable bool
+
+	// Address is the address of the rate limit service.
+	Address string
+
+	// Port is the remote destination port of the rate limit service.
+	Port uint16
+
+	// Domain is the domain the rate limit service applies its rate limit configuration for.
+	Domain string
+
+	// Timeout defines the timeout to consider for the rate limit service to reply in time.
+	Timeout time.Duration
+
+	// FailureModeAllow allows specifying if traffic should succeed or fail if the rate limit service fails to respond.
+	FailureModeAllow bool
+}
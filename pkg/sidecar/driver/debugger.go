@@ -0,0 +1,47 @@
+package driver
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/k8s"
+)
+
+const (
+	// ProxyUUIDQueryKey is the query parameter of debug requests holding the UUID of a proxy
+	ProxyUUIDQueryKey = "uuid"
+
+	// PodQueryKey is the query parameter of debug requests holding the <namespace>/<name> of the pod of a proxy
+	PodQueryKey = "pod"
+)
+
+// GetProxyUUIDForDebugRequest returns the UUID of the proxy a debug request is about, given either by the uuid
+// query parameter or by the pod query parameter naming the pod the proxy runs in as <namespace>/<name>
+func GetProxyUUIDForDebugRequest(kubeController k8s.Controller, r *http.Request) (string, error) {
+	if proxyUUID := r.URL.Query().Get(ProxyUUIDQueryKey); proxyUUID != "" {
+		return proxyUUID, nil
+	}
+
+	namespacedPod := r.URL.Query().Get(PodQueryKey)
+	if namespacedPod == "" {
+		return "", fmt.Errorf("one of the %s or %s query parameters is required", ProxyUUIDQueryKey, PodQueryKey)
+	}
+	namespace, name, ok := strings.Cut(namespacedPod, "/")
+	if !ok {
+		return "", fmt.Errorf("the %s query parameter must be of the form <namespace>/<name>, got %s", PodQueryKey, namespacedPod)
+	}
+
+	for _, pod := range kubeController.ListPods() {
+		if pod.Namespace != namespace || pod.Name != name {
+			continue
+		}
+		proxyUUID, ok := pod.Labels[constants.SidecarUniqueIDLabelName]
+		if !ok {
+			return "", fmt.Errorf("pod %s has no proxy", namespacedPod)
+		}
+		return proxyUUID, nil
+	}
+	return "", fmt.Errorf("pod %s not found in the mesh", namespacedPod)
+}
@@ -0,0 +1,79 @@
+package driver
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/k8s"
+)
+
+func TestGetProxyUUIDForDebugRequest(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	mockKubeController := k8s.NewMockController(mockCtrl)
+	mockKubeController.EXPECT().ListPods().Return([]*corev1.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "bookbuyer",
+				Namespace: "bookbuyer",
+				Labels:    map[string]string{constants.SidecarUniqueIDLabelName: "1f0b0a4e-5c1e-4f0e-9b5e-3c8f7c6e2a10"},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "bookstore", Namespace: "bookstore"},
+		},
+	}).AnyTimes()
+
+	testCases := []struct {
+		name         string
+		url          string
+		expectedUUID string
+		expectError  bool
+	}{
+		{
+			name:         "uuid query parameter",
+			url:          "/debug/proxy/config?uuid=7b1e4d5f-53f4-4f4e-a0c2-3a0d2c3b0a11",
+			expectedUUID: "7b1e4d5f-53f4-4f4e-a0c2-3a0d2c3b0a11",
+		},
+		{
+			name:         "pod query parameter",
+			url:          "/debug/proxy/config?pod=bookbuyer/bookbuyer",
+			expectedUUID: "1f0b0a4e-5c1e-4f0e-9b5e-3c8f7c6e2a10",
+		},
+		{
+			name:        "pod without a proxy",
+			url:         "/debug/proxy/config?pod=bookstore/bookstore",
+			expectError: true,
+		},
+		{
+			name:        "pod not found",
+			url:         "/debug/proxy/config?pod=bookbuyer/bookthief",
+			expectError: true,
+		},
+		{
+			name:        "pod without namespace",
+			url:         "/debug/proxy/config?pod=bookbuyer",
+			expectError: true,
+		},
+		{
+			name:        "no query parameter",
+			url:         "/debug/proxy/config",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			proxyUUID, err := GetProxyUUIDForDebugRequest(mockKubeController, httptest.NewRequest("GET", tc.url, nil))
+			assert.Equal(tc.expectedUUID, proxyUUID)
+			assert.Equal(tc.expectError, err != nil)
+		})
+	}
+}
@@ -1,8 +1,11 @@
 package ads
 
 import (
+	"fmt"
 	"time"
 
+	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
 	"github.com/jinzhu/copier"
 
 	"github.com/openservicemesh/osm/pkg/sidecar/providers/envoy"
@@ -24,3 +27,30 @@ func (s *Server) GetXDSLog() map[string]map[envoy.TypeURI][]time.Time {
 
 	return logsCopy
 }
+
+// GenerateResources returns the xDS resources the controller currently computes for the given proxy, by type,
+// without sending them. SDS resources are not generated, so that no certificate is issued and no secret is exposed.
+func (s *Server) GenerateResources(proxy *envoy.Proxy) (map[envoy.TypeURI][]types.Resource, error) {
+	resources := make(map[envoy.TypeURI][]types.Resource)
+	for _, typeURI := range envoy.XDSResponseOrder {
+		if typeURI == envoy.TypeSDS {
+			continue
+		}
+		handler, ok := s.xdsHandlers[typeURI]
+		if !ok {
+			return nil, errUnknownTypeURL
+		}
+
+		// As for updates driven by OSM, the resources requested are the ones the proxy subscribed to
+		request := &xds_discovery.DiscoveryRequest{
+			TypeUrl:       typeURI.String(),
+			ResourceNames: getResourceSliceFromMapset(proxy.GetSubscribedResources(typeURI)),
+		}
+		typeResources, err := handler(s.catalog, proxy, request, s.cfg, s.certManager, s.proxyRegistry)
+		if err != nil {
+			return nil, fmt.Errorf("error generating %s resources for proxy %s: %w", typeURI.Short(), proxy, err)
+		}
+		resources[typeURI] = typeResources
+	}
+	return resources, nil
+}
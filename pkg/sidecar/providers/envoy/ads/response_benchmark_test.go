@@ -64,7 +64,7 @@ func setupTestServer(b *testing.B) {
 	if err != nil {
 		b.Fatalf("Failed to create informer collection: %s", err)
 	}
-	kubeController := k8s.NewKubernetesController(informerCollection, policyClient, pluginClient, msgBroker)
+	kubeController := k8s.NewKubernetesController(informerCollection, policyClient, pluginClient, nil, msgBroker)
 	policyController := policy.NewPolicyController(informerCollection, kubeClient, kubeController, msgBroker)
 	pluginController := plugin.NewPluginController(informerCollection, kubeClient, kubeController, msgBroker)
 	multiclusterController := multicluster.NewMultiClusterController(informerCollection, kubeClient, kubeController, msgBroker)
@@ -132,6 +132,7 @@ func setupTestServer(b *testing.B) {
 		policyController,
 		pluginController,
 		multiclusterController,
+		nil,
 		stop,
 		osmConfigurator,
 		[]service.Provider{kubeProvider},
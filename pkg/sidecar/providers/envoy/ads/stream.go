@@ -15,6 +15,7 @@ import (
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/errcode"
 	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/k8s"
 	"github.com/openservicemesh/osm/pkg/k8s/events"
 	"github.com/openservicemesh/osm/pkg/messaging"
 	"github.com/openservicemesh/osm/pkg/metricsstore"
@@ -258,6 +259,9 @@ func respondToRequest(proxy *envoy.Proxy, discoveryRequest *xds_discovery.Discov
 	// At this point, there is no error and nonces match. It can either be an ACK or envoy could still be
 	// requesting a different set of resources on the current version for non-wildcard TypeURIs.
 	proxy.SetLastAppliedVersion(typeURL, requestVersion)
+	if typeURL == envoy.TypeSDS && requestVersion == proxy.GetLastSentVersion(typeURL) {
+		proxy.AcknowledgeSentServiceCert()
+	}
 
 	// For Wildcard TypeURIs we are done. Resource names in requests are always empty, nonce alone is enough
 	// to ACK wildcard types.
@@ -386,6 +390,7 @@ func (s *Server) recordPodMetadata(p *envoy.Proxy) error {
 		},
 		WorkloadKind: workloadKind,
 		WorkloadName: workloadName,
+		Zone:         k8s.GetPodZone(s.kubecontroller, pod),
 	}
 
 	// Verify Service account matches (cert to pod Service Account)
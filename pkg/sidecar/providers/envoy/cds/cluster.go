@@ -63,6 +63,7 @@ func getUpstreamServiceCluster(downstreamIdentity identity.ServiceIdentity, conf
 	}
 
 	applyUpstreamTrafficSetting(config.UpstreamTrafficSetting, upstreamCluster, httpProtocolOptions)
+	applyLoadBalancer(config.UpstreamTrafficSetting, upstreamCluster)
 
 	typedHTTPProtocolOptions, err := getTypedHTTPProtocolOptions(httpProtocolOptions)
 	if err != nil {
@@ -357,7 +358,16 @@ func applyUpstreamTrafficSetting(upstreamTrafficSetting *policyv1alpha1.Upstream
 		Thresholds: []*xds_cluster.CircuitBreakers_Thresholds{threshold},
 	}
 
-	if upstreamTrafficSetting == nil || upstreamTrafficSetting.Spec.ConnectionSettings == nil {
+	if upstreamTrafficSetting == nil {
+		return
+	}
+
+	// Apply outlier detection settings
+	if upstreamTrafficSetting.Spec.OutlierDetection != nil {
+		upstreamCluster.OutlierDetection = getOutlierDetection(upstreamTrafficSetting.Spec.OutlierDetection)
+	}
+
+	if upstreamTrafficSetting.Spec.ConnectionSettings == nil {
 		return
 	}
 
@@ -395,3 +405,49 @@ func applyUpstreamTrafficSetting(upstreamTrafficSetting *policyv1alpha1.Upstream
 		}
 	}
 }
+
+// getOutlierDetection returns the outlier detection config for the given outlier detection settings.
+// Settings that are not specified fall back to Envoy's defaults, which match the defaults of the API.
+func getOutlierDetection(outlierDetection *policyv1alpha1.OutlierDetectionSpec) *xds_cluster.OutlierDetection {
+	od := &xds_cluster.OutlierDetection{
+		// Endpoints are only ejected based on consecutive errors
+		EnforcingSuccessRate: wrapperspb.UInt32(0),
+	}
+
+	if outlierDetection.ConsecutiveErrors != nil {
+		od.Consecutive_5Xx = wrapperspb.UInt32(*outlierDetection.ConsecutiveErrors)
+	}
+	if outlierDetection.Interval != nil {
+		od.Interval = durationpb.New(outlierDetection.Interval.Duration)
+	}
+	if outlierDetection.BaseEjectionTime != nil {
+		od.BaseEjectionTime = durationpb.New(outlierDetection.BaseEjectionTime.Duration)
+	}
+	if outlierDetection.MaxEjectionPercent != nil {
+		od.MaxEjectionPercent = wrapperspb.UInt32(*outlierDetection.MaxEjectionPercent)
+	}
+
+	return od
+}
+
+// applyLoadBalancer configures the load balancing policy of an EDS cluster
+// based on the given UpstreamTrafficSetting. The hash keys used by the
+// RING_HASH and MAGLEV policies are configured on the routes to the cluster.
+func applyLoadBalancer(upstreamTrafficSetting *policyv1alpha1.UpstreamTrafficSetting, upstreamCluster *xds_cluster.Cluster) {
+	if upstreamTrafficSetting == nil || upstreamTrafficSetting.Spec.LoadBalancer == nil {
+		return
+	}
+
+	switch upstreamTrafficSetting.Spec.LoadBalancer.Type {
+	case policyv1alpha1.LoadBalancerLeastRequest:
+		upstreamCluster.LbPolicy = xds_cluster.Cluster_LEAST_REQUEST
+	case policyv1alpha1.LoadBalancerRandom:
+		upstreamCluster.LbPolicy = xds_cluster.Cluster_RANDOM
+	case policyv1alpha1.LoadBalancerRingHash:
+		upstreamCluster.LbPolicy = xds_cluster.Cluster_RING_HASH
+	case policyv1alpha1.LoadBalancerMaglev:
+		upstreamCluster.LbPolicy = xds_cluster.Cluster_MAGLEV
+	default:
+		upstreamCluster.LbPolicy = xds_cluster.Cluster_ROUND_ROBIN
+	}
+}
@@ -38,6 +38,7 @@ func TestGetUpstreamServiceCluster(t *testing.T) {
 		name                            string
 		clusterConfig                   trafficpolicy.MeshClusterConfig
 		expectedCircuitBreakerThreshold *xds_cluster.CircuitBreakers
+		expectedOutlierDetection        *xds_cluster.OutlierDetection
 	}{
 		{
 			name: "EDS based cluster adds health checks when configured",
@@ -89,6 +90,30 @@ func TestGetUpstreamServiceCluster(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Cluster with outlier detection",
+			clusterConfig: trafficpolicy.MeshClusterConfig{
+				Name:    "default/bookstore-v1_14001",
+				Service: upstreamSvc,
+				UpstreamTrafficSetting: &policyv1alpha1.UpstreamTrafficSetting{
+					Spec: policyv1alpha1.UpstreamTrafficSettingSpec{
+						OutlierDetection: &policyv1alpha1.OutlierDetectionSpec{
+							ConsecutiveErrors:  &thresholdUintVal,
+							Interval:           thresholdDuration,
+							BaseEjectionTime:   thresholdDuration,
+							MaxEjectionPercent: &thresholdUintVal,
+						},
+					},
+				},
+			},
+			expectedOutlierDetection: &xds_cluster.OutlierDetection{
+				Consecutive_5Xx:      wrapperspb.UInt32(thresholdUintVal),
+				Interval:             durationpb.New(thresholdDuration.Duration),
+				BaseEjectionTime:     durationpb.New(thresholdDuration.Duration),
+				MaxEjectionPercent:   wrapperspb.UInt32(thresholdUintVal),
+				EnforcingSuccessRate: wrapperspb.UInt32(0),
+			},
+		},
 		{
 			name: "Cluster without circuit breaker but with valid UpstreamTrafficSetting should not error/panic",
 			clusterConfig: trafficpolicy.MeshClusterConfig{
@@ -117,6 +142,8 @@ func TestGetUpstreamServiceCluster(t *testing.T) {
 			if tc.expectedCircuitBreakerThreshold != nil {
 				assert.Equal(tc.expectedCircuitBreakerThreshold, remoteCluster.CircuitBreakers)
 			}
+
+			assert.Equal(tc.expectedOutlierDetection, remoteCluster.OutlierDetection)
 		})
 	}
 }
@@ -507,3 +534,82 @@ func TestFormatAltStatNameForPrometheus(t *testing.T) {
 		})
 	}
 }
+
+func TestGetOutlierDetection(t *testing.T) {
+	assert := tassert.New(t)
+
+	// Unspecified settings fall back to Envoy's defaults
+	actual := getOutlierDetection(&policyv1alpha1.OutlierDetectionSpec{})
+	assert.Equal(&xds_cluster.OutlierDetection{EnforcingSuccessRate: wrapperspb.UInt32(0)}, actual)
+
+	var consecutiveErrors uint32 = 7
+	actual = getOutlierDetection(&policyv1alpha1.OutlierDetectionSpec{ConsecutiveErrors: &consecutiveErrors})
+	assert.Equal(wrapperspb.UInt32(consecutiveErrors), actual.Consecutive_5Xx)
+	assert.Nil(actual.Interval)
+	assert.Nil(actual.BaseEjectionTime)
+	assert.Nil(actual.MaxEjectionPercent)
+}
+
+func TestApplyLoadBalancer(t *testing.T) {
+	testCases := []struct {
+		name                   string
+		upstreamTrafficSetting *policyv1alpha1.UpstreamTrafficSetting
+		expectedLbPolicy       xds_cluster.Cluster_LbPolicy
+	}{
+		{
+			name:                   "no UpstreamTrafficSetting",
+			upstreamTrafficSetting: nil,
+			expectedLbPolicy:       xds_cluster.Cluster_ROUND_ROBIN,
+		},
+		{
+			name:                   "no load balancer",
+			upstreamTrafficSetting: &policyv1alpha1.UpstreamTrafficSetting{},
+			expectedLbPolicy:       xds_cluster.Cluster_ROUND_ROBIN,
+		},
+		{
+			name:                   "least request",
+			upstreamTrafficSetting: newLoadBalancerUpstreamTrafficSetting(policyv1alpha1.LoadBalancerLeastRequest),
+			expectedLbPolicy:       xds_cluster.Cluster_LEAST_REQUEST,
+		},
+		{
+			name:                   "random",
+			upstreamTrafficSetting: newLoadBalancerUpstreamTrafficSetting(policyv1alpha1.LoadBalancerRandom),
+			expectedLbPolicy:       xds_cluster.Cluster_RANDOM,
+		},
+		{
+			name:                   "ring hash",
+			upstreamTrafficSetting: newLoadBalancerUpstreamTrafficSetting(policyv1alpha1.LoadBalancerRingHash),
+			expectedLbPolicy:       xds_cluster.Cluster_RING_HASH,
+		},
+		{
+			name:                   "maglev",
+			upstreamTrafficSetting: newLoadBalancerUpstreamTrafficSetting(policyv1alpha1.LoadBalancerMaglev),
+			expectedLbPolicy:       xds_cluster.Cluster_MAGLEV,
+		},
+		{
+			name:                   "unspecified type",
+			upstreamTrafficSetting: newLoadBalancerUpstreamTrafficSetting(""),
+			expectedLbPolicy:       xds_cluster.Cluster_ROUND_ROBIN,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			cluster := &xds_cluster.Cluster{LbPolicy: xds_cluster.Cluster_ROUND_ROBIN}
+			applyLoadBalancer(tc.upstreamTrafficSetting, cluster)
+			assert.Equal(tc.expectedLbPolicy, cluster.LbPolicy)
+		})
+	}
+}
+
+func newLoadBalancerUpstreamTrafficSetting(lbType policyv1alpha1.LoadBalancerType) *policyv1alpha1.UpstreamTrafficSetting {
+	return &policyv1alpha1.UpstreamTrafficSetting{
+		Spec: policyv1alpha1.UpstreamTrafficSettingSpec{
+			LoadBalancer: &policyv1alpha1.LoadBalancerSpec{
+				Type: lbType,
+			},
+		},
+	}
+}
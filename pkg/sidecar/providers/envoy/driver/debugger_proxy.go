@@ -2,12 +2,18 @@
 package driver
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"sort"
 	"time"
 
+	"google.golang.org/protobuf/encoding/protojson"
+
 	"github.com/openservicemesh/osm/pkg/models"
+	"github.com/openservicemesh/osm/pkg/sidecar/driver"
+	"github.com/openservicemesh/osm/pkg/sidecar/providers/envoy"
+	"github.com/openservicemesh/osm/pkg/sidecar/providers/envoy/ads"
 	"github.com/openservicemesh/osm/pkg/sidecar/providers/envoy/registry"
 )
 
@@ -61,7 +67,7 @@ func (sd EnvoySidecarDriver) printProxies(proxyRegistry *registry.ProxyRegistry,
 
 func (sd EnvoySidecarDriver) getConfigDump(proxyRegistry *registry.ProxyRegistry, uuid string, w http.ResponseWriter) {
 	proxy := proxyRegistry.GetConnectedProxy(uuid)
-	if proxy != nil {
+	if proxy == nil {
 		msg := fmt.Sprintf("Proxy for UUID %s not found, may have been disconnected", uuid)
 		log.Error().Msg(msg)
 		http.Error(w, msg, http.StatusNotFound)
@@ -98,3 +104,61 @@ func (sd EnvoySidecarDriver) getProxy(proxyRegistry *registry.ProxyRegistry, uui
 	envoyConfig := sd.getSidecarConfig(pod, "certs")
 	_, _ = fmt.Fprintf(w, "%s", envoyConfig)
 }
+
+// intendedConfigKeys are the keys of the xDS resources of each type returned by getIntendedConfig
+var intendedConfigKeys = map[envoy.TypeURI]string{
+	envoy.TypeCDS: "clusters",
+	envoy.TypeEDS: "endpoints",
+	envoy.TypeLDS: "listeners",
+	envoy.TypeRDS: "routes",
+}
+
+// getIntendedConfig returns the xDS resources the controller currently computes for the proxy of the requested UUID
+// or pod, as lists of JSON objects keyed by the kind of resource
+func (sd EnvoySidecarDriver) getIntendedConfig(xdsServer *ads.Server, proxyRegistry *registry.ProxyRegistry) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		uuid, err := driver.GetProxyUUIDForDebugRequest(sd.ctx.MeshCatalog.GetKubeController(), r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		proxy := proxyRegistry.GetConnectedProxy(uuid)
+		if proxy == nil {
+			msg := fmt.Sprintf("Proxy for UUID %s not found, may have been disconnected", uuid)
+			log.Error().Msg(msg)
+			http.Error(w, msg, http.StatusNotFound)
+			return
+		}
+
+		resources, err := xdsServer.GenerateResources(proxy)
+		if err != nil {
+			msg := fmt.Sprintf("Error generating the config of proxy %s", proxy.GetName())
+			log.Error().Err(err).Msg(msg)
+			http.Error(w, msg, http.StatusInternalServerError)
+			return
+		}
+
+		// Resources are marshaled with the proto field names, as in the config dump of Envoy
+		marshaler := protojson.MarshalOptions{UseProtoNames: true}
+		config := make(map[string][]json.RawMessage)
+		for typeURI, typeResources := range resources {
+			key := intendedConfigKeys[typeURI]
+			config[key] = []json.RawMessage{}
+			for _, resource := range typeResources {
+				resourceJSON, err := marshaler.Marshal(resource)
+				if err != nil {
+					msg := fmt.Sprintf("Error marshaling the %s resources of proxy %s", typeURI.Short(), proxy.GetName())
+					log.Error().Err(err).Msg(msg)
+					http.Error(w, msg, http.StatusInternalServerError)
+					return
+				}
+				config[key] = append(config[key], resourceJSON)
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", " ")
+		_ = enc.Encode(config)
+	})
+}
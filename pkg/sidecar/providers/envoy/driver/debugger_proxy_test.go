@@ -0,0 +1,69 @@
+package driver
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	tassert "github.com/stretchr/testify/assert"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/k8s"
+	"github.com/openservicemesh/osm/pkg/models"
+	"github.com/openservicemesh/osm/pkg/sidecar/driver"
+	"github.com/openservicemesh/osm/pkg/sidecar/providers/envoy"
+	"github.com/openservicemesh/osm/pkg/sidecar/providers/envoy/registry"
+)
+
+func TestGetConfigDump(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
+	mockKubeController := k8s.NewMockController(mockCtrl)
+	mockCatalog.EXPECT().GetKubeController().Return(mockKubeController).AnyTimes()
+
+	proxyRegistry := registry.NewProxyRegistry(nil, nil)
+	proxy := envoy.NewProxy(models.KindSidecar, uuid.New(), identity.New("bookbuyer", "default"), nil)
+	proxyRegistry.RegisterProxy(proxy)
+	defer proxyRegistry.UnregisterProxy(proxy)
+
+	sd := EnvoySidecarDriver{ctx: &driver.ControllerContext{MeshCatalog: mockCatalog}}
+
+	testCases := []struct {
+		name            string
+		uuid            string
+		expectedStatus  int
+		expectedMessage string
+	}{
+		{
+			name:            "proxy not connected",
+			uuid:            uuid.New().String(),
+			expectedStatus:  http.StatusNotFound,
+			expectedMessage: "not found, may have been disconnected",
+		},
+		{
+			name:            "connected proxy is looked up",
+			uuid:            proxy.UUID.String(),
+			expectedStatus:  http.StatusNotFound,
+			expectedMessage: "Error getting Pod from proxy",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			if tc.uuid == proxy.UUID.String() {
+				mockKubeController.EXPECT().GetPodForProxy(proxy).Return(nil, errors.New("pod not found"))
+			}
+
+			w := httptest.NewRecorder()
+			sd.getConfigDump(proxyRegistry, tc.uuid, w)
+			assert.Equal(tc.expectedStatus, w.Code)
+			assert.Contains(w.Body.String(), tc.expectedMessage)
+		})
+	}
+}
@@ -38,11 +38,13 @@ func (sd EnvoySidecarDriver) Start(ctx context.Context) (health.Probes, error) {
 	proxyMapper := &registry.KubeProxyServiceMapper{KubeController: k8sClient}
 	proxyRegistry := registry.NewProxyRegistry(proxyMapper, ctrlCtx.MsgBroker)
 	go proxyRegistry.ReleaseCertificateHandler(certManager, ctrlCtx.Stop)
+	certManager.SetTrustBundleAcknowledger(proxyRegistry)
 	// Create and start the ADS gRPC service
 	xdsServer := ads.NewADSServer(ctrlCtx.MeshCatalog, proxyRegistry, cfg.IsDebugServerEnabled(), ctrlCtx.OsmNamespace, cfg, certManager, k8sClient, ctrlCtx.MsgBroker)
 
 	ctrlCtx.DebugHandlers["/debug/proxy"] = sd.getProxies(proxyRegistry)
 	ctrlCtx.DebugHandlers["/debug/xds"] = sd.getXDSHandler(xdsServer)
+	ctrlCtx.DebugHandlers["/debug/proxy/config"] = sd.getIntendedConfig(xdsServer, proxyRegistry)
 
 	return xdsServer, xdsServer.Start(ctx, cancel, int(proxyServerPort), proxyServiceCert)
 }
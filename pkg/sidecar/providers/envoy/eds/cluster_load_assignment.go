@@ -15,10 +15,16 @@ const (
 	localZone             = "local"
 	localClusterPriority  = uint32(0)
 	remoteClusterPriority = uint32(1)
+
+	// localUnhintedPriority is the priority of the local endpoints that are not hinted for the zone of the
+	// downstream, which only receive traffic when the endpoints hinted for it are unhealthy
+	localUnhintedPriority = uint32(1)
 )
 
-// newClusterLoadAssignment returns the cluster load assignments for the given service and its endpoints
-func newClusterLoadAssignment(svc service.MeshService, serviceEndpoints []endpoint.Endpoint) *xds_endpoint.ClusterLoadAssignment {
+// newClusterLoadAssignment returns the cluster load assignments for the given service and its endpoints.
+// When the zone of the downstream is known and the local endpoints carry zone hints, the endpoints hinted for the
+// zone of the downstream are preferred over the other local endpoints.
+func newClusterLoadAssignment(svc service.MeshService, serviceEndpoints []endpoint.Endpoint, downstreamZone string) *xds_endpoint.ClusterLoadAssignment {
 	localLbEndpoints := &xds_endpoint.LocalityLbEndpoints{
 		Locality: &xds_core.Locality{
 			Zone: localZone,
@@ -41,6 +47,14 @@ func newClusterLoadAssignment(svc service.MeshService, serviceEndpoints []endpoi
 		return cla
 	}
 
+	preferHinted := useZoneHints(serviceEndpoints, downstreamZone)
+	unhintedLbEndpoints := &xds_endpoint.LocalityLbEndpoints{
+		Locality: &xds_core.Locality{
+			Zone: localZone,
+		},
+		Priority: localUnhintedPriority,
+	}
+
 	for _, meshEndpoint := range serviceEndpoints {
 		lbEpt := &xds_endpoint.LbEndpoint{
 			HostIdentifier: &xds_endpoint.LbEndpoint_Endpoint{
@@ -50,8 +64,19 @@ func newClusterLoadAssignment(svc service.MeshService, serviceEndpoints []endpoi
 			},
 		}
 
+		// Terminating endpoints are only present when the service has no ready endpoints. They still serve
+		// traffic, so they are healthy rather than draining, which would exclude them from load balancing.
+		if meshEndpoint.Terminating {
+			lbEpt.HealthStatus = xds_core.HealthStatus_HEALTHY
+		}
+
 		// Endpoint without a weight set implies it belongs to the local cluster
 		if meshEndpoint.Weight == 0 {
+			if preferHinted && !isHintedForZone(meshEndpoint, downstreamZone) {
+				unhintedLbEndpoints.LbEndpoints = append(unhintedLbEndpoints.LbEndpoints, lbEpt)
+				log.Trace().Msgf("Adding local endpoint not hinted for zone %s: cluster=%s, endpoint=%s", downstreamZone, svc, meshEndpoint)
+				continue
+			}
 			localLbEndpoints.LbEndpoints = append(localLbEndpoints.LbEndpoints, lbEpt)
 			log.Trace().Msgf("Adding local endpoint: cluster=%s, endpoint=%s", svc, meshEndpoint)
 			continue
@@ -75,5 +100,38 @@ func newClusterLoadAssignment(svc service.MeshService, serviceEndpoints []endpoi
 		log.Trace().Msgf("Adding Endpoint: cluster=%s, endpoint=%s, weight=%d", svc, meshEndpoint, meshEndpoint.Weight)
 	}
 
+	if len(unhintedLbEndpoints.LbEndpoints) > 0 {
+		cla.Endpoints = append(cla.Endpoints, unhintedLbEndpoints)
+	}
+
 	return cla
 }
+
+// useZoneHints returns whether the zone hints of the local endpoints apply to a downstream in the given zone.
+// Like kube-proxy, hints are only used when every local endpoint has some and at least one is hinted for the zone.
+func useZoneHints(endpoints []endpoint.Endpoint, zone string) bool {
+	if zone == "" {
+		return false
+	}
+	hinted := false
+	for _, ep := range endpoints {
+		if ep.Weight != 0 {
+			continue
+		}
+		if len(ep.ZoneHints) == 0 {
+			return false
+		}
+		hinted = hinted || isHintedForZone(ep, zone)
+	}
+	return hinted
+}
+
+// isHintedForZone returns whether the given endpoint is hinted to serve traffic for the given zone
+func isHintedForZone(ep endpoint.Endpoint, zone string) bool {
+	for _, hint := range ep.ZoneHints {
+		if hint == zone {
+			return true
+		}
+	}
+	return false
+}
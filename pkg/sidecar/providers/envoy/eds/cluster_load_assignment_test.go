@@ -17,10 +17,11 @@ import (
 
 func TestNewClusterLoadAssignment(t *testing.T) {
 	testCases := []struct {
-		name      string
-		svc       service.MeshService
-		endpoints []endpoint.Endpoint
-		expected  *xds_endpoint.ClusterLoadAssignment
+		name           string
+		svc            service.MeshService
+		endpoints      []endpoint.Endpoint
+		downstreamZone string
+		expected       *xds_endpoint.ClusterLoadAssignment
 	}{
 		{
 			name: "multiple endpoints per cluster within the same locality",
@@ -56,6 +57,120 @@ func TestNewClusterLoadAssignment(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "terminating endpoints still serving are healthy",
+			svc:  service.MeshService{Namespace: "ns1", Name: "bookstore-1", TargetPort: 80},
+			endpoints: []endpoint.Endpoint{
+				{IP: net.ParseIP("1.1.1.1"), Port: 80, Terminating: true},
+				{IP: net.ParseIP("fd00::1"), Port: 80, Terminating: true},
+			},
+			expected: &xds_endpoint.ClusterLoadAssignment{
+				ClusterName: "ns1/bookstore-1|80",
+				Endpoints: []*xds_endpoint.LocalityLbEndpoints{
+					{
+						Locality: &xds_core.Locality{
+							Zone: localZone,
+						},
+						LbEndpoints: []*xds_endpoint.LbEndpoint{
+							{
+								HostIdentifier: &xds_endpoint.LbEndpoint_Endpoint{
+									Endpoint: &xds_endpoint.Endpoint{
+										Address: envoy.GetAddress("1.1.1.1", 80),
+									},
+								},
+								HealthStatus: xds_core.HealthStatus_HEALTHY,
+							},
+							{
+								HostIdentifier: &xds_endpoint.LbEndpoint_Endpoint{
+									Endpoint: &xds_endpoint.Endpoint{
+										Address: envoy.GetAddress("fd00::1", 80),
+									},
+								},
+								HealthStatus: xds_core.HealthStatus_HEALTHY,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "endpoints hinted for the zone of the downstream are preferred",
+			svc:  service.MeshService{Namespace: "ns1", Name: "bookstore-1", TargetPort: 80},
+			endpoints: []endpoint.Endpoint{
+				{IP: net.ParseIP("1.1.1.1"), Port: 80, Zone: "zone-a", ZoneHints: []string{"zone-a"}},
+				{IP: net.ParseIP("2.2.2.2"), Port: 80, Zone: "zone-b", ZoneHints: []string{"zone-b"}},
+			},
+			downstreamZone: "zone-a",
+			expected: &xds_endpoint.ClusterLoadAssignment{
+				ClusterName: "ns1/bookstore-1|80",
+				Endpoints: []*xds_endpoint.LocalityLbEndpoints{
+					{
+						Locality: &xds_core.Locality{
+							Zone: localZone,
+						},
+						LbEndpoints: []*xds_endpoint.LbEndpoint{
+							{
+								HostIdentifier: &xds_endpoint.LbEndpoint_Endpoint{
+									Endpoint: &xds_endpoint.Endpoint{
+										Address: envoy.GetAddress("1.1.1.1", 80),
+									},
+								},
+							},
+						},
+					},
+					{
+						Locality: &xds_core.Locality{
+							Zone: localZone,
+						},
+						LbEndpoints: []*xds_endpoint.LbEndpoint{
+							{
+								HostIdentifier: &xds_endpoint.LbEndpoint_Endpoint{
+									Endpoint: &xds_endpoint.Endpoint{
+										Address: envoy.GetAddress("2.2.2.2", 80),
+									},
+								},
+							},
+						},
+						Priority: localUnhintedPriority,
+					},
+				},
+			},
+		},
+		{
+			name: "zone hints are ignored when some endpoints have none",
+			svc:  service.MeshService{Namespace: "ns1", Name: "bookstore-1", TargetPort: 80},
+			endpoints: []endpoint.Endpoint{
+				{IP: net.ParseIP("1.1.1.1"), Port: 80, Zone: "zone-a", ZoneHints: []string{"zone-a"}},
+				{IP: net.ParseIP("2.2.2.2"), Port: 80, Zone: "zone-b"},
+			},
+			downstreamZone: "zone-a",
+			expected: &xds_endpoint.ClusterLoadAssignment{
+				ClusterName: "ns1/bookstore-1|80",
+				Endpoints: []*xds_endpoint.LocalityLbEndpoints{
+					{
+						Locality: &xds_core.Locality{
+							Zone: localZone,
+						},
+						LbEndpoints: []*xds_endpoint.LbEndpoint{
+							{
+								HostIdentifier: &xds_endpoint.LbEndpoint_Endpoint{
+									Endpoint: &xds_endpoint.Endpoint{
+										Address: envoy.GetAddress("1.1.1.1", 80),
+									},
+								},
+							},
+							{
+								HostIdentifier: &xds_endpoint.LbEndpoint_Endpoint{
+									Endpoint: &xds_endpoint.Endpoint{
+										Address: envoy.GetAddress("2.2.2.2", 80),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name:      "no endpoints for cluster",
 			svc:       service.MeshService{Namespace: "ns1", Name: "bookstore-1", TargetPort: 80},
@@ -77,7 +192,7 @@ func TestNewClusterLoadAssignment(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			assert := tassert.New(t)
-			actual := newClusterLoadAssignment(tc.svc, tc.endpoints)
+			actual := newClusterLoadAssignment(tc.svc, tc.endpoints, tc.downstreamZone)
 			assert.True(cmp.Equal(tc.expected, actual, protocmp.Transform()), cmp.Diff(tc.expected, actual, protocmp.Transform()))
 		})
 	}
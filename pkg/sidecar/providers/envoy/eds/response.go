@@ -44,7 +44,7 @@ func fulfillEDSRequest(meshCatalog catalog.MeshCataloger, proxy *envoy.Proxy, re
 		}
 		endpoints := meshCatalog.ListAllowedUpstreamEndpointsForService(proxy.Identity, meshSvc)
 		log.Trace().Msgf("Endpoints for upstream cluster %s for downstream proxy identity %s: %v", cluster, proxy.Identity, endpoints)
-		loadAssignment := newClusterLoadAssignment(meshSvc, endpoints)
+		loadAssignment := newClusterLoadAssignment(meshSvc, endpoints, proxyZone(proxy))
 		rdsResources = append(rdsResources, loadAssignment)
 	}
 
@@ -57,13 +57,21 @@ func generateEDSConfig(meshCatalog catalog.MeshCataloger, proxy *envoy.Proxy) ([
 	upstreamSvcEndpoints := getUpstreamEndpointsForProxyIdentity(meshCatalog, proxy.Identity)
 
 	for svc, endpoints := range upstreamSvcEndpoints {
-		loadAssignment := newClusterLoadAssignment(svc, endpoints)
+		loadAssignment := newClusterLoadAssignment(svc, endpoints, proxyZone(proxy))
 		edsResources = append(edsResources, loadAssignment)
 	}
 
 	return edsResources, nil
 }
 
+// proxyZone returns the zone of the pod of the given proxy, or an empty string if it is unknown
+func proxyZone(proxy *envoy.Proxy) string {
+	if !proxy.HasPodMetadata() {
+		return ""
+	}
+	return proxy.PodMetadata.Zone
+}
+
 // clusterToMeshSvc returns the MeshService associated with the given cluster name
 func clusterToMeshSvc(cluster string) (service.MeshService, error) {
 	splitFunc := func(r rune) bool {
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	xds_route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	xds_http_fault "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/fault/v3"
 	xds_local_ratelimit "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/local_ratelimit/v3"
 	xds_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
 	"github.com/golang/protobuf/ptypes/any"
@@ -12,7 +13,9 @@ import (
 	"github.com/openservicemesh/osm/pkg/auth"
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/protobuf"
+	"github.com/openservicemesh/osm/pkg/ratelimit"
 	"github.com/openservicemesh/osm/pkg/sidecar/providers/envoy"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
 )
 
 // connectionDirection defines, for filter terms, the direction of a connection from
@@ -40,6 +43,8 @@ type httpConnManagerOptions struct {
 	// Additional filters
 	wasmStatsHeaders         map[string]string
 	extAuthConfig            *auth.ExtAuthConfig
+	globalRateLimitConfig    *ratelimit.GlobalRateLimitConfig
+	requestAuthentication    *trafficpolicy.RequestAuthentication
 	enableActiveHealthChecks bool
 
 	// Tracing options
@@ -92,6 +97,32 @@ func (options httpConnManagerOptions) build() (*xds_hcm.HttpConnectionManager, e
 		},
 	}
 
+	// For outbound connections, add the fault injection filter
+	if options.direction == outbound {
+		connManager.HttpFilters = append(connManager.HttpFilters, &xds_hcm.HttpFilter{
+			Name: envoy.HTTPFaultFilterName,
+			ConfigType: &xds_hcm.HttpFilter_TypedConfig{
+				// Since no faults are defined here, the filter is a no-op at the
+				// listener level. The faults are applied at the Route level.
+				TypedConfig: protobuf.MustMarshalAny(&xds_http_fault.HTTPFault{}),
+			},
+		})
+	}
+
+	// For outbound connections, add the global rate limit filter
+	if options.direction == outbound && options.globalRateLimitConfig != nil {
+		connManager.HttpFilters = append(connManager.HttpFilters, getGlobalRateLimitHTTPFilter(options.globalRateLimitConfig))
+	}
+
+	// For inbound connections, add the JWT authentication filters
+	if options.direction == inbound && options.requestAuthentication != nil {
+		jwtFilters, err := getJWTAuthnHTTPFilters(options.requestAuthentication)
+		if err != nil {
+			return nil, fmt.Errorf("Error getting JWT authentication filters for HTTP connection manager: %w", err)
+		}
+		connManager.HttpFilters = append(connManager.HttpFilters, jwtFilters...)
+	}
+
 	// For inbound connections, add the Authz filter
 	if options.direction == inbound && options.extAuthConfig != nil {
 		connManager.HttpFilters = append(connManager.HttpFilters, getExtAuthzHTTPFilter(options.extAuthConfig))
@@ -96,6 +96,7 @@ func (lb *listenerBuilder) getInboundHTTPFilters(trafficMatch *trafficpolicy.Tra
 		// Additional filters
 		wasmStatsHeaders:         lb.getWASMStatsHeaders(),
 		extAuthConfig:            lb.getExtAuthConfig(),
+		requestAuthentication:    trafficMatch.RequestAuthentication,
 		enableActiveHealthChecks: lb.cfg.GetFeatureFlags().EnableSidecarActiveHealthChecks,
 
 		// Tracing options
@@ -320,8 +321,9 @@ func (lb *listenerBuilder) getOutboundHTTPFilter(routeConfigName string) (*xds_l
 		rdsRoutConfigName: routeConfigName,
 
 		// Additional filters
-		wasmStatsHeaders: lb.statsHeaders,
-		extAuthConfig:    nil, // Ext auth is not configured for outbound connections
+		wasmStatsHeaders:      lb.statsHeaders,
+		extAuthConfig:         nil, // Ext auth is not configured for outbound connections
+		globalRateLimitConfig: lb.getGlobalRateLimitConfig(),
 
 		// Tracing options
 		enableTracing:      lb.cfg.IsTracingEnabled(),
@@ -0,0 +1,196 @@
+package lds
+
+import (
+	"fmt"
+	"strings"
+
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	xds_rbac "github.com/envoyproxy/go-control-plane/envoy/config/rbac/v3"
+	xds_route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	xds_jwt_authn "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/jwt_authn/v3"
+	xds_http_rbac "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/rbac/v3"
+	xds_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	xds_matcher "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	policyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/sidecar/providers/envoy"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+// getJWTAuthnHTTPFilters returns the HTTP filters that verify the JWT of inbound requests and
+// authorize the requests based on its claims, given a RequestAuthentication configuration.
+func getJWTAuthnHTTPFilters(requestAuthn *trafficpolicy.RequestAuthentication) ([]*xds_hcm.HttpFilter, error) {
+	var filters []*xds_hcm.HttpFilter
+
+	if len(requestAuthn.Providers) > 0 {
+		jwtAuthn, err := anypb.New(buildJWTAuthentication(requestAuthn.Providers))
+		if err != nil {
+			return nil, fmt.Errorf("Error marshalling JWT authentication config: %w", err)
+		}
+		filters = append(filters, &xds_hcm.HttpFilter{
+			Name: envoy.HTTPJWTAuthnFilterName,
+			ConfigType: &xds_hcm.HttpFilter_TypedConfig{
+				TypedConfig: jwtAuthn,
+			},
+		})
+	}
+
+	// Requests are authorized on the claims of the JWT if rules are specified. If none of the
+	// providers could be configured, the RBAC filter has no policies and denies all requests.
+	if len(requestAuthn.Rules) > 0 || len(requestAuthn.Providers) == 0 {
+		jwtRBAC, err := anypb.New(&xds_http_rbac.RBAC{
+			Rules: &xds_rbac.RBAC{
+				Action:   xds_rbac.RBAC_ALLOW,
+				Policies: buildJWTRBACPolicies(requestAuthn.Rules),
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("Error marshalling JWT RBAC config: %w", err)
+		}
+		filters = append(filters, &xds_hcm.HttpFilter{
+			Name: envoy.HTTPJWTRBACFilterName,
+			ConfigType: &xds_hcm.HttpFilter_TypedConfig{
+				TypedConfig: jwtRBAC,
+			},
+		})
+	}
+
+	return filters, nil
+}
+
+// buildJWTAuthentication returns the JWT authentication config requiring every request
+// to carry a JWT that is valid for any of the given providers
+func buildJWTAuthentication(providers []trafficpolicy.JWTProvider) *xds_jwt_authn.JwtAuthentication {
+	jwtAuthn := &xds_jwt_authn.JwtAuthentication{
+		Providers: make(map[string]*xds_jwt_authn.JwtProvider),
+	}
+
+	var requirements []*xds_jwt_authn.JwtRequirement
+	for _, provider := range providers {
+		jwtAuthn.Providers[provider.Name] = &xds_jwt_authn.JwtProvider{
+			Issuer:    provider.Issuer,
+			Audiences: provider.Audiences,
+			JwksSourceSpecifier: &xds_jwt_authn.JwtProvider_LocalJwks{
+				LocalJwks: &xds_core.DataSource{
+					Specifier: &xds_core.DataSource_InlineString{
+						InlineString: provider.JWKS,
+					},
+				},
+			},
+			Forward:           provider.ForwardOriginalToken,
+			PayloadInMetadata: envoy.JWTPayloadMetadataKey,
+		}
+		requirements = append(requirements, &xds_jwt_authn.JwtRequirement{
+			RequiresType: &xds_jwt_authn.JwtRequirement_ProviderName{
+				ProviderName: provider.Name,
+			},
+		})
+	}
+
+	requirement := requirements[0]
+	if len(requirements) > 1 {
+		requirement = &xds_jwt_authn.JwtRequirement{
+			RequiresType: &xds_jwt_authn.JwtRequirement_RequiresAny{
+				RequiresAny: &xds_jwt_authn.JwtRequirementOrList{
+					Requirements: requirements,
+				},
+			},
+		}
+	}
+
+	jwtAuthn.Rules = []*xds_jwt_authn.RequirementRule{
+		{
+			Match: &xds_route.RouteMatch{
+				PathSpecifier: &xds_route.RouteMatch_Prefix{
+					Prefix: "/",
+				},
+			},
+			RequirementType: &xds_jwt_authn.RequirementRule_Requires{
+				Requires: requirement,
+			},
+		},
+	}
+
+	return jwtAuthn
+}
+
+// buildJWTRBACPolicies returns an RBAC policy per authorization rule, matching the
+// claims of the JWT in the payload written to the dynamic metadata
+func buildJWTRBACPolicies(rules []policyv1alpha1.JWTAuthorizationRuleSpec) map[string]*xds_rbac.Policy {
+	policies := make(map[string]*xds_rbac.Policy)
+
+	for i, rule := range rules {
+		var claimPrincipals []*xds_rbac.Principal
+		for _, claim := range rule.Claims {
+			claimPrincipals = append(claimPrincipals, buildJWTClaimPrincipal(claim))
+		}
+
+		policies[fmt.Sprintf("jwt-rule-%d", i)] = &xds_rbac.Policy{
+			Permissions: []*xds_rbac.Permission{
+				{
+					Rule: &xds_rbac.Permission_Any{Any: true},
+				},
+			},
+			Principals: []*xds_rbac.Principal{
+				{
+					Identifier: &xds_rbac.Principal_AndIds{
+						AndIds: &xds_rbac.Principal_Set{Ids: claimPrincipals},
+					},
+				},
+			},
+		}
+	}
+
+	return policies
+}
+
+// buildJWTClaimPrincipal returns a principal matching the given claim if its value, or any element
+// of its list value, is one of the allowed values
+func buildJWTClaimPrincipal(claim policyv1alpha1.JWTClaimMatchSpec) *xds_rbac.Principal {
+	path := []*xds_matcher.MetadataMatcher_PathSegment{
+		{
+			Segment: &xds_matcher.MetadataMatcher_PathSegment_Key{Key: envoy.JWTPayloadMetadataKey},
+		},
+	}
+	for _, key := range strings.Split(claim.Name, ".") {
+		path = append(path, &xds_matcher.MetadataMatcher_PathSegment{
+			Segment: &xds_matcher.MetadataMatcher_PathSegment_Key{Key: key},
+		})
+	}
+
+	var valuePrincipals []*xds_rbac.Principal
+	for _, value := range claim.Values {
+		stringMatch := &xds_matcher.ValueMatcher{
+			MatchPattern: &xds_matcher.ValueMatcher_StringMatch{
+				StringMatch: &xds_matcher.StringMatcher{
+					MatchPattern: &xds_matcher.StringMatcher_Exact{Exact: value},
+				},
+			},
+		}
+		listMatch := &xds_matcher.ValueMatcher{
+			MatchPattern: &xds_matcher.ValueMatcher_ListMatch{
+				ListMatch: &xds_matcher.ListMatcher{
+					MatchPattern: &xds_matcher.ListMatcher_OneOf{OneOf: stringMatch},
+				},
+			},
+		}
+		for _, valueMatch := range []*xds_matcher.ValueMatcher{stringMatch, listMatch} {
+			valuePrincipals = append(valuePrincipals, &xds_rbac.Principal{
+				Identifier: &xds_rbac.Principal_Metadata{
+					Metadata: &xds_matcher.MetadataMatcher{
+						Filter: envoy.JWTAuthnMetadataNamespace,
+						Path:   path,
+						Value:  valueMatch,
+					},
+				},
+			})
+		}
+	}
+
+	return &xds_rbac.Principal{
+		Identifier: &xds_rbac.Principal_OrIds{
+			OrIds: &xds_rbac.Principal_Set{Ids: valuePrincipals},
+		},
+	}
+}
@@ -0,0 +1,115 @@
+package lds
+
+import (
+	"testing"
+
+	xds_jwt_authn "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/jwt_authn/v3"
+	xds_http_rbac "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/rbac/v3"
+	"github.com/stretchr/testify/assert"
+
+	policyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/sidecar/providers/envoy"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+func TestGetJWTAuthnHTTPFilters(t *testing.T) {
+	provider := func(name string) trafficpolicy.JWTProvider {
+		return trafficpolicy.JWTProvider{
+			Name:      name,
+			Issuer:    "https://" + name,
+			Audiences: []string{"bookstore"},
+			JWKS:      `{"keys":[]}`,
+		}
+	}
+
+	testCases := []struct {
+		name                string
+		requestAuthn        *trafficpolicy.RequestAuthentication
+		expectedFilterNames []string
+		expectedRequiresAny bool
+		expectedRBACRules   int
+	}{
+		{
+			name: "single provider without authorization rules",
+			requestAuthn: &trafficpolicy.RequestAuthentication{
+				Providers: []trafficpolicy.JWTProvider{provider("p1")},
+			},
+			expectedFilterNames: []string{envoy.HTTPJWTAuthnFilterName},
+		},
+		{
+			name: "multiple providers with authorization rules",
+			requestAuthn: &trafficpolicy.RequestAuthentication{
+				Providers: []trafficpolicy.JWTProvider{provider("p1"), provider("p2")},
+				Rules: []policyv1alpha1.JWTAuthorizationRuleSpec{
+					{Claims: []policyv1alpha1.JWTClaimMatchSpec{{Name: "groups", Values: []string{"admin", "ops"}}}},
+					{Claims: []policyv1alpha1.JWTClaimMatchSpec{{Name: "realm.role", Values: []string{"buyer"}}}},
+				},
+			},
+			expectedFilterNames: []string{envoy.HTTPJWTAuthnFilterName, envoy.HTTPJWTRBACFilterName},
+			expectedRequiresAny: true,
+			expectedRBACRules:   2,
+		},
+		{
+			name:                "no providers denies all requests",
+			requestAuthn:        &trafficpolicy.RequestAuthentication{},
+			expectedFilterNames: []string{envoy.HTTPJWTRBACFilterName},
+			expectedRBACRules:   0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := assert.New(t)
+
+			filters, err := getJWTAuthnHTTPFilters(tc.requestAuthn)
+			a.Nil(err)
+
+			var filterNames []string
+			for _, filter := range filters {
+				filterNames = append(filterNames, filter.Name)
+
+				switch filter.Name {
+				case envoy.HTTPJWTAuthnFilterName:
+					jwtAuthn := &xds_jwt_authn.JwtAuthentication{}
+					a.Nil(filter.GetTypedConfig().UnmarshalTo(jwtAuthn))
+					a.Len(jwtAuthn.Providers, len(tc.requestAuthn.Providers))
+					for _, p := range tc.requestAuthn.Providers {
+						a.Equal(p.Issuer, jwtAuthn.Providers[p.Name].Issuer)
+						a.Equal(p.JWKS, jwtAuthn.Providers[p.Name].GetLocalJwks().GetInlineString())
+						a.Equal(envoy.JWTPayloadMetadataKey, jwtAuthn.Providers[p.Name].PayloadInMetadata)
+					}
+					a.Len(jwtAuthn.Rules, 1)
+					a.Equal("/", jwtAuthn.Rules[0].Match.GetPrefix())
+					a.Equal(tc.expectedRequiresAny, jwtAuthn.Rules[0].GetRequires().GetRequiresAny() != nil)
+
+				case envoy.HTTPJWTRBACFilterName:
+					rbac := &xds_http_rbac.RBAC{}
+					a.Nil(filter.GetTypedConfig().UnmarshalTo(rbac))
+					a.Len(rbac.Rules.Policies, tc.expectedRBACRules)
+				}
+			}
+			a.Equal(tc.expectedFilterNames, filterNames)
+		})
+	}
+}
+
+func TestBuildJWTClaimPrincipal(t *testing.T) {
+	a := assert.New(t)
+
+	principal := buildJWTClaimPrincipal(policyv1alpha1.JWTClaimMatchSpec{Name: "realm.roles", Values: []string{"admin", "ops"}})
+
+	// A string and a list matcher per allowed value
+	ids := principal.GetOrIds().GetIds()
+	a.Len(ids, 4)
+	for _, id := range ids {
+		metadata := id.GetMetadata()
+		a.Equal(envoy.JWTAuthnMetadataNamespace, metadata.Filter)
+		a.Len(metadata.Path, 3)
+		a.Equal(envoy.JWTPayloadMetadataKey, metadata.Path[0].GetKey())
+		a.Equal("realm", metadata.Path[1].GetKey())
+		a.Equal("roles", metadata.Path[2].GetKey())
+	}
+	a.Equal("admin", ids[0].GetMetadata().Value.GetStringMatch().GetExact())
+	a.Equal("admin", ids[1].GetMetadata().Value.GetListMatch().GetOneOf().GetStringMatch().GetExact())
+	a.Equal("ops", ids[2].GetMetadata().Value.GetStringMatch().GetExact())
+}
@@ -0,0 +1,65 @@
+package lds
+
+import (
+	"net"
+	"strconv"
+
+	envoy_config_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	xds_ratelimit_config "github.com/envoyproxy/go-control-plane/envoy/config/ratelimit/v3"
+	xds_ratelimit "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ratelimit/v3"
+	xds_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/openservicemesh/osm/pkg/errcode"
+	"github.com/openservicemesh/osm/pkg/ratelimit"
+	"github.com/openservicemesh/osm/pkg/sidecar/providers/envoy"
+)
+
+const (
+	// globalRateLimitStatPrefix is the prefix for the stats of the global rate limit filter
+	globalRateLimitStatPrefix = "global_rate_limit"
+)
+
+func (lb *listenerBuilder) getGlobalRateLimitConfig() *ratelimit.GlobalRateLimitConfig {
+	rateLimitConfig := lb.cfg.GetGlobalRateLimitConfig()
+	if rateLimitConfig.Enable {
+		return &rateLimitConfig
+	}
+	return nil
+}
+
+// getGlobalRateLimitHTTPFilter returns an envoy HttpFilter given a GlobalRateLimitConfig configuration.
+// The descriptors sent to the rate limit service are configured on the routes.
+func getGlobalRateLimitHTTPFilter(rateLimitConfig *ratelimit.GlobalRateLimitConfig) *xds_hcm.HttpFilter {
+	rateLimit := &xds_ratelimit.RateLimit{
+		Domain:  rateLimitConfig.Domain,
+		Timeout: durationpb.New(rateLimitConfig.Timeout),
+		RateLimitService: &xds_ratelimit_config.RateLimitServiceConfig{
+			GrpcService: &envoy_config_core_v3.GrpcService{
+				TargetSpecifier: &envoy_config_core_v3.GrpcService_GoogleGrpc_{
+					GoogleGrpc: &envoy_config_core_v3.GrpcService_GoogleGrpc{
+						TargetUri:  net.JoinHostPort(rateLimitConfig.Address, strconv.Itoa(int(rateLimitConfig.Port))),
+						StatPrefix: globalRateLimitStatPrefix,
+					},
+				},
+				Timeout: durationpb.New(rateLimitConfig.Timeout),
+			},
+			TransportApiVersion: envoy_config_core_v3.ApiVersion_V3,
+		},
+		FailureModeDeny: !rateLimitConfig.FailureModeAllow,
+	}
+
+	rateLimitMarshalled, err := anypb.New(rateLimit)
+	if err != nil {
+		log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrMarshallingXDSResource)).
+			Msg("Failed to marshal global rate limit config")
+	}
+
+	return &xds_hcm.HttpFilter{
+		Name: envoy.HTTPRateLimitFilterName,
+		ConfigType: &xds_hcm.HttpFilter_TypedConfig{
+			TypedConfig: rateLimitMarshalled,
+		},
+	}
+}
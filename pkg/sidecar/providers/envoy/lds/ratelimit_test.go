@@ -0,0 +1,209 @@
+package lds
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	xds_ratelimit_common "github.com/envoyproxy/go-control-plane/envoy/extensions/common/ratelimit/v3"
+	xds_ratelimit "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ratelimit/v3"
+	xds_rls "github.com/envoyproxy/go-control-plane/envoy/service/ratelimit/v3"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/ratelimit"
+	"github.com/openservicemesh/osm/pkg/sidecar/providers/envoy"
+)
+
+// fakeRateLimitService is a rate limit service speaking the Envoy RLS protocol that allows
+// the given number of hits per descriptor before reporting OVER_LIMIT
+type fakeRateLimitService struct {
+	xds_rls.UnimplementedRateLimitServiceServer
+
+	limit    uint32
+	mu       sync.Mutex
+	hits     map[string]uint32
+	requests []*xds_rls.RateLimitRequest
+}
+
+func (s *fakeRateLimitService) ShouldRateLimit(_ context.Context, req *xds_rls.RateLimitRequest) (*xds_rls.RateLimitResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, req)
+	resp := &xds_rls.RateLimitResponse{OverallCode: xds_rls.RateLimitResponse_OK}
+	for _, descriptor := range req.Descriptors {
+		var key []string
+		for _, entry := range descriptor.Entries {
+			key = append(key, entry.Key+"="+entry.Value)
+		}
+		id := req.Domain + "/" + strings.Join(key, ",")
+		s.hits[id]++
+		code := xds_rls.RateLimitResponse_OK
+		if s.hits[id] > s.limit {
+			code = xds_rls.RateLimitResponse_OVER_LIMIT
+			resp.OverallCode = xds_rls.RateLimitResponse_OVER_LIMIT
+		}
+		resp.Statuses = append(resp.Statuses, &xds_rls.RateLimitResponse_DescriptorStatus{Code: code})
+	}
+	return resp, nil
+}
+
+func TestGetGlobalRateLimitConfig(t *testing.T) {
+	testCases := []struct {
+		name            string
+		rateLimitConfig ratelimit.GlobalRateLimitConfig
+		expected        *ratelimit.GlobalRateLimitConfig
+	}{
+		{
+			name: "global rate limit is disabled",
+			rateLimitConfig: ratelimit.GlobalRateLimitConfig{
+				Enable:  false,
+				Address: "ratelimit.osm-system.svc.cluster.local",
+				Port:    8081,
+			},
+			expected: nil,
+		},
+		{
+			name: "global rate limit is enabled",
+			rateLimitConfig: ratelimit.GlobalRateLimitConfig{
+				Enable:  true,
+				Address: "ratelimit.osm-system.svc.cluster.local",
+				Port:    8081,
+				Domain:  "osm",
+				Timeout: time.Second,
+			},
+			expected: &ratelimit.GlobalRateLimitConfig{
+				Enable:  true,
+				Address: "ratelimit.osm-system.svc.cluster.local",
+				Port:    8081,
+				Domain:  "osm",
+				Timeout: time.Second,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := assert.New(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+			lb := &listenerBuilder{
+				cfg: mockConfigurator,
+			}
+
+			mockConfigurator.EXPECT().GetGlobalRateLimitConfig().Return(tc.rateLimitConfig).Times(1)
+
+			actual := lb.getGlobalRateLimitConfig()
+			a.Equal(tc.expected, actual)
+		})
+	}
+}
+
+func TestGetGlobalRateLimitHTTPFilter(t *testing.T) {
+	a := assert.New(t)
+
+	filter := getGlobalRateLimitHTTPFilter(&ratelimit.GlobalRateLimitConfig{
+		Enable:           true,
+		Address:          "ratelimit.osm-system.svc.cluster.local",
+		Port:             8081,
+		Domain:           "osm",
+		Timeout:          2 * time.Second,
+		FailureModeAllow: true,
+	})
+	a.Equal(envoy.HTTPRateLimitFilterName, filter.Name)
+
+	rateLimit := &xds_ratelimit.RateLimit{}
+	a.Nil(filter.GetTypedConfig().UnmarshalTo(rateLimit))
+	a.Equal("osm", rateLimit.Domain)
+	a.Equal(2*time.Second, rateLimit.Timeout.AsDuration())
+	a.False(rateLimit.FailureModeDeny)
+	a.Equal("ratelimit.osm-system.svc.cluster.local:8081", rateLimit.RateLimitService.GrpcService.GetGoogleGrpc().TargetUri)
+
+	// IPv6 addresses are enclosed in brackets
+	filter = getGlobalRateLimitHTTPFilter(&ratelimit.GlobalRateLimitConfig{
+		Enable:  true,
+		Address: "fd00::10",
+		Port:    8081,
+		Domain:  "osm",
+	})
+	a.Nil(filter.GetTypedConfig().UnmarshalTo(rateLimit))
+	a.Equal("[fd00::10]:8081", rateLimit.RateLimitService.GrpcService.GetGoogleGrpc().TargetUri)
+}
+
+func TestGlobalRateLimitWithFakeRateLimitService(t *testing.T) {
+	a := assert.New(t)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	a.Nil(err)
+
+	rls := &fakeRateLimitService{limit: 2, hits: map[string]uint32{}}
+	server := grpc.NewServer()
+	xds_rls.RegisterRateLimitServiceServer(server, rls)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	defer server.Stop()
+
+	host, portStr, err := net.SplitHostPort(listener.Addr().String())
+	a.Nil(err)
+	port, err := strconv.ParseUint(portStr, 10, 16)
+	a.Nil(err)
+
+	filter := getGlobalRateLimitHTTPFilter(&ratelimit.GlobalRateLimitConfig{
+		Enable:  true,
+		Address: host,
+		Port:    uint16(port),
+		Domain:  "osm",
+		Timeout: time.Second,
+	})
+	rateLimit := &xds_ratelimit.RateLimit{}
+	a.Nil(filter.GetTypedConfig().UnmarshalTo(rateLimit))
+
+	// Connect to the rate limit service the way the sidecar would, using the filter config
+	conn, err := grpc.Dial(rateLimit.RateLimitService.GrpcService.GetGoogleGrpc().TargetUri,
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	a.Nil(err)
+	defer conn.Close() //nolint: errcheck,gosec
+
+	client := xds_rls.NewRateLimitServiceClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), rateLimit.Timeout.AsDuration())
+	defer cancel()
+
+	request := &xds_rls.RateLimitRequest{
+		Domain: rateLimit.Domain,
+		Descriptors: []*xds_ratelimit_common.RateLimitDescriptor{
+			{
+				Entries: []*xds_ratelimit_common.RateLimitDescriptor_Entry{
+					{Key: "destination", Value: "bookstore.bookstore.svc.cluster.local"},
+					{Key: "source_identity", Value: "bookbuyer.bookbuyer"},
+				},
+			},
+		},
+		HitsAddend: 1,
+	}
+
+	for i := 0; i < 2; i++ {
+		resp, err := client.ShouldRateLimit(ctx, request)
+		a.Nil(err)
+		a.Equal(xds_rls.RateLimitResponse_OK, resp.OverallCode)
+	}
+	resp, err := client.ShouldRateLimit(ctx, request)
+	a.Nil(err)
+	a.Equal(xds_rls.RateLimitResponse_OVER_LIMIT, resp.OverallCode)
+
+	rls.mu.Lock()
+	defer rls.mu.Unlock()
+	a.Len(rls.requests, 3)
+	a.Equal("osm", rls.requests[0].Domain)
+	a.Len(rls.requests[0].Descriptors[0].Entries, 2)
+}
@@ -50,7 +50,7 @@ func (lb *listenerBuilder) buildInboundRBACPolicies() (*xds_network_rbac.RBAC, e
 	rbacPolicies := make(map[string]*xds_rbac.Policy)
 	// Build an RBAC policies based on SMI TrafficTarget policies
 	for _, targetPolicy := range trafficTargets {
-		rbacPolicies[targetPolicy.Name] = buildRBACPolicyFromTrafficTarget(targetPolicy, lb.trustDomain)
+		rbacPolicies[targetPolicy.Name] = buildRBACPolicyFromTrafficTarget(targetPolicy, lb.trustDomain, lb.cfg.GetFederatedTrustDomains())
 	}
 
 	log.Debug().Msgf("RBAC policy for proxy with identity %s: %+v", proxyIdentity, rbacPolicies)
@@ -68,12 +68,15 @@ func (lb *listenerBuilder) buildInboundRBACPolicies() (*xds_network_rbac.RBAC, e
 }
 
 // buildRBACPolicyFromTrafficTarget creates an XDS RBAC policy from the given traffic target policy
-func buildRBACPolicyFromTrafficTarget(trafficTarget trafficpolicy.TrafficTargetWithRoutes, trustDomain string) *xds_rbac.Policy {
+func buildRBACPolicyFromTrafficTarget(trafficTarget trafficpolicy.TrafficTargetWithRoutes, trustDomain string, federatedTrustDomains []string) *xds_rbac.Policy {
 	pb := &rbac.PolicyBuilder{}
 
-	// Create the list of identities for this policy
+	// Create the list of identities for this policy.
+	// A downstream is authenticated either by its principal or by its SPIFFE ID when its certificate has one.
 	for _, downstreamIdentity := range trafficTarget.Sources {
-		pb.AddPrincipal(downstreamIdentity.AsPrincipal(trustDomain))
+		for _, principal := range downstreamIdentity.AsPrincipals(trustDomain, federatedTrustDomains...) {
+			pb.AddPrincipal(principal)
+		}
 	}
 	// Create the list of permissions for this policy
 	for _, tcpRouteMatch := range trafficTarget.TCPRouteMatches {
@@ -10,6 +10,7 @@ import (
 	xds_rbac "github.com/envoyproxy/go-control-plane/envoy/config/rbac/v3"
 
 	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/identity"
 	"github.com/openservicemesh/osm/pkg/sidecar/providers/envoy"
 	"github.com/openservicemesh/osm/pkg/sidecar/providers/envoy/rbac"
@@ -18,8 +19,9 @@ import (
 
 func TestBuildRBACPolicyFromTrafficTarget(t *testing.T) {
 	testCases := []struct {
-		name          string
-		trafficTarget trafficpolicy.TrafficTargetWithRoutes
+		name                  string
+		trafficTarget         trafficpolicy.TrafficTargetWithRoutes
+		federatedTrustDomains []string
 
 		expectedPolicy *xds_rbac.Policy
 	}{
@@ -44,7 +46,9 @@ func TestBuildRBACPolicyFromTrafficTarget(t *testing.T) {
 				},
 				Principals: []*xds_rbac.Principal{
 					rbac.GetAuthenticatedPrincipal("sa-2.ns-2.cluster.local"),
+					rbac.GetAuthenticatedPrincipal("spiffe://cluster.local/ns/ns-2/sa/sa-2"),
 					rbac.GetAuthenticatedPrincipal("sa-3.ns-3.cluster.local"),
+					rbac.GetAuthenticatedPrincipal("spiffe://cluster.local/ns/ns-3/sa/sa-3"),
 				},
 			},
 		},
@@ -77,7 +81,35 @@ func TestBuildRBACPolicyFromTrafficTarget(t *testing.T) {
 				},
 				Principals: []*xds_rbac.Principal{
 					rbac.GetAuthenticatedPrincipal("sa-2.ns-2.cluster.local"),
+					rbac.GetAuthenticatedPrincipal("spiffe://cluster.local/ns/ns-2/sa/sa-2"),
 					rbac.GetAuthenticatedPrincipal("sa-3.ns-3.cluster.local"),
+					rbac.GetAuthenticatedPrincipal("spiffe://cluster.local/ns/ns-3/sa/sa-3"),
+				},
+			},
+		},
+
+		{
+			// Test 3
+			name: "traffic target with federated trust domains",
+			trafficTarget: trafficpolicy.TrafficTargetWithRoutes{
+				Name:        "ns-1/test-1",
+				Destination: identity.ServiceIdentity("sa-1.ns-1"),
+				Sources: []identity.ServiceIdentity{
+					identity.ServiceIdentity("sa-2.ns-2"),
+				},
+			},
+			federatedTrustDomains: []string{"example.com"},
+
+			expectedPolicy: &xds_rbac.Policy{
+				Permissions: []*xds_rbac.Permission{
+					{
+						Rule: &xds_rbac.Permission_Any{Any: true},
+					},
+				},
+				// The principal is not allowed, a federated CA could issue a certificate with it as a DNS SAN
+				Principals: []*xds_rbac.Principal{
+					rbac.GetAuthenticatedPrincipal("spiffe://cluster.local/ns/ns-2/sa/sa-2"),
+					rbac.GetAuthenticatedPrincipal("spiffe://example.com/ns/ns-2/sa/sa-2"),
 				},
 			},
 		},
@@ -88,7 +120,7 @@ func TestBuildRBACPolicyFromTrafficTarget(t *testing.T) {
 			assert := tassert.New(t)
 
 			// Test the RBAC policies
-			policy := buildRBACPolicyFromTrafficTarget(tc.trafficTarget, "cluster.local")
+			policy := buildRBACPolicyFromTrafficTarget(tc.trafficTarget, "cluster.local", tc.federatedTrustDomains)
 
 			assert.Equal(tc.expectedPolicy, policy)
 		})
@@ -100,10 +132,13 @@ func TestBuildInboundRBACPolicies(t *testing.T) {
 	defer mockCtrl.Finish()
 
 	mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
+	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+	mockConfigurator.EXPECT().GetFederatedTrustDomains().Return(nil).AnyTimes()
 	proxySvcAccount := identity.K8sServiceAccount{Name: "sa-1", Namespace: "ns-1"}
 
 	lb := &listenerBuilder{
 		meshCatalog:     mockCatalog,
+		cfg:             mockConfigurator,
 		serviceIdentity: proxySvcAccount.ToServiceIdentity(),
 	}
 
@@ -188,10 +223,13 @@ func TestBuildRBACFilter(t *testing.T) {
 	defer mockCtrl.Finish()
 
 	mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
+	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+	mockConfigurator.EXPECT().GetFederatedTrustDomains().Return(nil).AnyTimes()
 	proxySvcAccount := identity.K8sServiceAccount{Name: "sa-1", Namespace: "ns-1"}.ToServiceIdentity()
 
 	lb := &listenerBuilder{
 		meshCatalog:     mockCatalog,
+		cfg:             mockConfigurator,
 		serviceIdentity: proxySvcAccount,
 	}
 
@@ -4,11 +4,13 @@ import (
 	"fmt"
 	"net"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	mapset "github.com/deckarep/golang-set"
 	"github.com/google/uuid"
 
+	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/identity"
 	"github.com/openservicemesh/osm/pkg/models"
 )
@@ -39,6 +41,11 @@ type Proxy struct {
 	// kind is the proxy's kind (ex. sidecar, gateway)
 	kind models.ProxyKind
 
+	// The service certificates last sent to and last acknowledged by the proxy over SDS,
+	// used to track the progress of root certificate rotations
+	sentServiceCert    atomic.Value
+	appliedServiceCert atomic.Value
+
 	// Records metadata around the Kubernetes Pod on which this Envoy Proxy is installed.
 	// This could be nil if the Envoy is not operating in a Kubernetes cluster (VM for example)
 	// NOTE: This field may be not be set at the time Proxy struct is initialized. This would
@@ -62,6 +69,7 @@ type PodMetadata struct {
 	EnvoyNodeID    string
 	WorkloadKind   string
 	WorkloadName   string
+	Zone           string
 }
 
 // HasPodMetadata answers the question - has the Pod metadata been recorded for the given Envoy proxy
@@ -215,6 +223,24 @@ func (p *Proxy) SetSubscribedResources(typeURI TypeURI, resourcesSet mapset.Set)
 	p.subscribedResources[typeURI] = resourcesSet
 }
 
+// SetSentServiceCert records the service certificate last sent to the proxy.
+func (p *Proxy) SetSentServiceCert(cert *certificate.Certificate) {
+	p.sentServiceCert.Store(cert)
+}
+
+// AcknowledgeSentServiceCert records that the service certificate last sent to the proxy was applied.
+func (p *Proxy) AcknowledgeSentServiceCert() {
+	if cert, ok := p.sentServiceCert.Load().(*certificate.Certificate); ok {
+		p.appliedServiceCert.Store(cert)
+	}
+}
+
+// GetAppliedServiceCert returns the service certificate last applied by the proxy.
+func (p *Proxy) GetAppliedServiceCert() *certificate.Certificate {
+	cert, _ := p.appliedServiceCert.Load().(*certificate.Certificate)
+	return cert
+}
+
 // Kind return the proxy's kind
 func (p *Proxy) Kind() models.ProxyKind {
 	return p.kind
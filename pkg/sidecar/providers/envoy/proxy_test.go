@@ -15,6 +15,7 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/identity"
 	"github.com/openservicemesh/osm/pkg/models"
@@ -280,3 +281,24 @@ func TestSubscribedResources(t *testing.T) {
 	assert.True(res.Contains("B"))
 	assert.True(res.Contains("C"))
 }
+
+func TestAcknowledgeSentServiceCert(t *testing.T) {
+	a := tassert.New(t)
+	proxy := NewProxy(models.KindSidecar, uuid.New(), identity.New("svc-acc", "namespace"), nil)
+
+	// Nothing is acknowledged before a certificate is sent
+	proxy.AcknowledgeSentServiceCert()
+	a.Nil(proxy.GetAppliedServiceCert())
+
+	first := &certificate.Certificate{CommonName: "first"}
+	proxy.SetSentServiceCert(first)
+	a.Nil(proxy.GetAppliedServiceCert())
+	proxy.AcknowledgeSentServiceCert()
+	a.Equal(first, proxy.GetAppliedServiceCert())
+
+	second := &certificate.Certificate{CommonName: "second"}
+	proxy.SetSentServiceCert(second)
+	a.Equal(first, proxy.GetAppliedServiceCert())
+	proxy.AcknowledgeSentServiceCert()
+	a.Equal(second, proxy.GetAppliedServiceCert())
+}
@@ -48,7 +48,7 @@ func NewResponse(cataloger catalog.MeshCataloger, proxy *envoy.Proxy, discoveryR
 	outboundMeshTrafficPolicy := cataloger.GetOutboundMeshTrafficPolicy(proxy.Identity)
 
 	if outboundMeshTrafficPolicy != nil {
-		outboundMeshRouteConfig := route.BuildOutboundMeshRouteConfiguration(outboundMeshTrafficPolicy.HTTPRouteConfigsPerPort)
+		outboundMeshRouteConfig := route.BuildOutboundMeshRouteConfiguration(outboundMeshTrafficPolicy.HTTPRouteConfigsPerPort, outboundMeshTrafficPolicy.ClustersConfigs)
 		for _, config := range outboundMeshRouteConfig {
 			rdsResources = append(rdsResources, config)
 		}
@@ -72,7 +72,7 @@ func NewResponse(cataloger catalog.MeshCataloger, proxy *envoy.Proxy, discoveryR
 		ingressTrafficPolicies = trafficpolicy.MergeInboundPolicies(ingressTrafficPolicies, ingressPolicy.HTTPRoutePolicies...)
 	}
 	if len(ingressTrafficPolicies) > 0 {
-		ingressRouteConfig := route.BuildIngressConfiguration(ingressTrafficPolicies, trustDomain)
+		ingressRouteConfig := route.BuildIngressConfiguration(ingressTrafficPolicies, trustDomain, cfg.GetFederatedTrustDomains())
 		rdsResources = append(rdsResources, ingressRouteConfig)
 	}
 
@@ -261,6 +261,8 @@ func TestNewResponse(t *testing.T) {
 			mockMeshSpec := smi.NewMockMeshSpec(mockCtrl)
 			mockEndpointProvider := endpoint.NewMockProvider(mockCtrl)
 			mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+			mockConfigurator.EXPECT().GetFederatedTrustDomains().Return(nil).AnyTimes()
+			mockConfigurator.EXPECT().GetFederatedTrustDomains().Return(nil).AnyTimes()
 			mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
 			kubeClient := testclient.NewSimpleClientset()
 			proxy, err := getBookstoreV1Proxy(kubeClient)
@@ -437,6 +439,7 @@ func TestResponseRequestCompletion(t *testing.T) {
 	defer mockCtrl.Finish()
 	mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
 	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+	mockConfigurator.EXPECT().GetFederatedTrustDomains().Return(nil).AnyTimes()
 
 	uuid := uuid.New()
 	testProxy := envoy.NewProxy(models.KindSidecar, uuid, identity.New("some-service", "some-namespace"), nil)
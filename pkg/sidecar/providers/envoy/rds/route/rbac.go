@@ -2,12 +2,14 @@ package route
 
 import (
 	"errors"
+	"strings"
 
 	xds_rbac "github.com/envoyproxy/go-control-plane/envoy/config/rbac/v3"
 	xds_http_rbac "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/rbac/v3"
 	"github.com/golang/protobuf/ptypes/any"
 	"google.golang.org/protobuf/types/known/anypb"
 
+	"github.com/openservicemesh/osm/pkg/identity"
 	"github.com/openservicemesh/osm/pkg/sidecar/providers/envoy/rbac"
 	"github.com/openservicemesh/osm/pkg/trafficpolicy"
 )
@@ -19,16 +21,30 @@ const (
 // buildInboundRBACFilterForRule builds an HTTP RBAC per route filter based on the given traffic policy rule.
 // The principals in the RBAC policy are derived from the allowed service accounts specified in the given rule.
 // The permissions in the RBAC policy are implicitly set to ANY (all permissions).
-func buildInboundRBACFilterForRule(rule *trafficpolicy.Rule, trustDomain string) (*any.Any, error) {
+func buildInboundRBACFilterForRule(rule *trafficpolicy.Rule, trustDomain string, federatedTrustDomains []string) (*any.Any, error) {
 	if rule.AllowedPrincipals == nil {
 		return nil, errors.New("traffipolicy.Rule.AllowedPrincipals not set")
 	}
 
 	pb := &rbac.PolicyBuilder{}
 
-	// Create the list of principals for this policy
+	// Create the list of principals for this policy.
+	// A downstream in the mesh is authenticated by its principal or its SPIFFE ID, see identity.ServiceIdentity.AsPrincipals.
+	// Any other principal, such as a wildcard, an ingress client or a principal in a foreign trust domain, is kept as is.
 	for downstream := range rule.AllowedPrincipals.Iter() {
-		pb.AddPrincipal(downstream.(string))
+		principal := downstream.(string)
+		if !strings.HasSuffix(principal, "."+trustDomain) {
+			pb.AddPrincipal(principal)
+			continue
+		}
+		downstreamIdentity := identity.FromPrincipal(principal, trustDomain)
+		if !downstreamIdentity.IsValid() {
+			pb.AddPrincipal(principal)
+			continue
+		}
+		for _, p := range downstreamIdentity.AsPrincipals(trustDomain, federatedTrustDomains...) {
+			pb.AddPrincipal(p)
+		}
 	}
 
 	// A single RBAC policy per route
@@ -17,10 +17,11 @@ import (
 
 func TestBuildInboundRBACFilterForRule(t *testing.T) {
 	testCases := []struct {
-		name               string
-		rule               *trafficpolicy.Rule
-		expectedRBACPolicy *xds_rbac.Policy
-		expectError        bool
+		name                  string
+		rule                  *trafficpolicy.Rule
+		federatedTrustDomains []string
+		expectedRBACPolicy    *xds_rbac.Policy
+		expectError           bool
 	}{
 		{
 			name: "valid trafficpolicy rule with restricted downstream identities",
@@ -37,7 +38,62 @@ func TestBuildInboundRBACFilterForRule(t *testing.T) {
 			expectedRBACPolicy: &xds_rbac.Policy{
 				Principals: []*xds_rbac.Principal{
 					rbac.GetAuthenticatedPrincipal("foo.ns-1.cluster.local"),
+					rbac.GetAuthenticatedPrincipal("spiffe://cluster.local/ns/ns-1/sa/foo"),
 					rbac.GetAuthenticatedPrincipal("bar.ns-2.cluster.local"),
+					rbac.GetAuthenticatedPrincipal("spiffe://cluster.local/ns/ns-2/sa/bar"),
+				},
+				Permissions: []*xds_rbac.Permission{
+					{
+						Rule: &xds_rbac.Permission_Any{Any: true},
+					},
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "federated trust domains only allow downstream SPIFFE IDs",
+			rule: &trafficpolicy.Rule{
+				Route: trafficpolicy.RouteWeightedClusters{
+					HTTPRouteMatch:   tests.BookstoreBuyHTTPRoute,
+					WeightedClusters: mapset.NewSet(tests.BookstoreV1DefaultWeightedCluster),
+				},
+				AllowedPrincipals: mapset.NewSet(
+					identity.K8sServiceAccount{Name: "foo", Namespace: "ns-1"}.AsPrincipal("cluster.local"),
+				),
+			},
+			federatedTrustDomains: []string{"example.com"},
+			expectedRBACPolicy: &xds_rbac.Policy{
+				Principals: []*xds_rbac.Principal{
+					rbac.GetAuthenticatedPrincipal("spiffe://cluster.local/ns/ns-1/sa/foo"),
+					rbac.GetAuthenticatedPrincipal("spiffe://example.com/ns/ns-1/sa/foo"),
+				},
+				Permissions: []*xds_rbac.Permission{
+					{
+						Rule: &xds_rbac.Permission_Any{Any: true},
+					},
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "principals that are not mesh identities are kept as is",
+			rule: &trafficpolicy.Rule{
+				Route: trafficpolicy.RouteWeightedClusters{
+					HTTPRouteMatch:   tests.BookstoreBuyHTTPRoute,
+					WeightedClusters: mapset.NewSet(tests.BookstoreV1DefaultWeightedCluster),
+				},
+				AllowedPrincipals: mapset.NewSet(
+					"nginx",            // ingress client without a dot
+					"x.ns.example.org", // principal in a foreign trust domain
+					"nginx.cluster.local",
+				),
+			},
+			federatedTrustDomains: []string{"example.com"},
+			expectedRBACPolicy: &xds_rbac.Policy{
+				Principals: []*xds_rbac.Principal{
+					rbac.GetAuthenticatedPrincipal("nginx"),
+					rbac.GetAuthenticatedPrincipal("x.ns.example.org"),
+					rbac.GetAuthenticatedPrincipal("nginx.cluster.local"),
 				},
 				Permissions: []*xds_rbac.Permission{
 					{
@@ -89,7 +145,7 @@ func TestBuildInboundRBACFilterForRule(t *testing.T) {
 		t.Run(fmt.Sprintf("Test case %d: %s", i, tc.name), func(t *testing.T) {
 			assert := tassert.New(t)
 
-			rbacFilter, err := buildInboundRBACFilterForRule(tc.rule, "cluster.local")
+			rbacFilter, err := buildInboundRBACFilterForRule(tc.rule, "cluster.local", tc.federatedTrustDomains)
 
 			assert.Equal(tc.expectError, err != nil)
 			if err != nil {
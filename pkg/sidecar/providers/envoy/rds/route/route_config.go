@@ -3,12 +3,15 @@ package route
 import (
 	"fmt"
 	"sort"
+	"strings"
 	"time"
 
 	mapset "github.com/deckarep/golang-set"
 	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	xds_route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	xds_common_fault "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/common/fault/v3"
+	xds_http_fault "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/fault/v3"
 	xds_local_ratelimit "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/local_ratelimit/v3"
 	xds_matcher "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
 	xds_type "github.com/envoyproxy/go-control-plane/envoy/type/v3"
@@ -77,7 +80,7 @@ func BuildInboundMeshRouteConfiguration(portSpecificRouteConfigs map[int][]*traf
 		routeConfig := NewRouteConfigurationStub(GetInboundMeshRouteConfigNameForPort(port))
 		for _, config := range configs {
 			virtualHost := buildVirtualHostStub(inboundVirtualHost, config.Name, config.Hostnames)
-			virtualHost.Routes = buildInboundRoutes(config.Rules, trustDomain)
+			virtualHost.Routes = buildInboundRoutes(config.Rules, trustDomain, cfg.GetFederatedTrustDomains())
 			applyInboundVirtualHostConfig(virtualHost, config)
 			routeConfig.VirtualHosts = append(routeConfig.VirtualHosts, virtualHost)
 		}
@@ -116,6 +119,31 @@ func applyInboundVirtualHostConfig(vhost *xds_route.VirtualHost, policy *traffic
 	// Add other typed filter configs below when necessary
 
 	vhost.TypedPerFilterConfig = config
+
+	applyJWTClaimHeaders(vhost, policy.RequestAuthentication)
+}
+
+// applyJWTClaimHeaders sets the request headers the claims of a verified JWT are copied to.
+// The headers are removed from the request first so that they cannot be set by the downstream,
+// and are only added if the claim is present in the payload of the JWT.
+func applyJWTClaimHeaders(vhost *xds_route.VirtualHost, requestAuthn *trafficpolicy.RequestAuthentication) {
+	if requestAuthn == nil {
+		return
+	}
+
+	for _, provider := range requestAuthn.Providers {
+		for _, claimToHeader := range provider.ClaimToHeaders {
+			metadataPath := strings.Join(append([]string{envoy.JWTPayloadMetadataKey}, strings.Split(claimToHeader.Claim, ".")...), ":")
+			vhost.RequestHeadersToRemove = append(vhost.RequestHeadersToRemove, claimToHeader.Header)
+			vhost.RequestHeadersToAdd = append(vhost.RequestHeadersToAdd, &xds_core.HeaderValueOption{
+				Header: &xds_core.HeaderValue{
+					Key:   claimToHeader.Header,
+					Value: fmt.Sprintf("%%DYNAMIC_METADATA(%s:%s)%%", envoy.JWTAuthnMetadataNamespace, metadataPath),
+				},
+				Append: &wrapperspb.BoolValue{Value: false},
+			})
+		}
+	}
 }
 
 // getLocalRateLimitFilterConfig returns the marshalled HTTP local rate limiting config for the given policy
@@ -192,7 +220,7 @@ func getRateLimitHeaderValueOptions(headerValues []policyv1alpha1.HTTPHeaderValu
 }
 
 // BuildIngressConfiguration constructs the Envoy constructs ([]*xds_route.RouteConfiguration) for implementing ingress routes
-func BuildIngressConfiguration(ingress []*trafficpolicy.InboundTrafficPolicy, trustDomain string) *xds_route.RouteConfiguration {
+func BuildIngressConfiguration(ingress []*trafficpolicy.InboundTrafficPolicy, trustDomain string, federatedTrustDomains []string) *xds_route.RouteConfiguration {
 	if len(ingress) == 0 {
 		return nil
 	}
@@ -200,17 +228,23 @@ func BuildIngressConfiguration(ingress []*trafficpolicy.InboundTrafficPolicy, tr
 	ingressRouteConfig := NewRouteConfigurationStub(IngressRouteConfigName)
 	for _, in := range ingress {
 		virtualHost := buildVirtualHostStub(ingressVirtualHost, in.Name, in.Hostnames)
-		virtualHost.Routes = buildInboundRoutes(in.Rules, trustDomain)
+		virtualHost.Routes = buildInboundRoutes(in.Rules, trustDomain, federatedTrustDomains)
 		ingressRouteConfig.VirtualHosts = append(ingressRouteConfig.VirtualHosts, virtualHost)
 	}
 
 	return ingressRouteConfig
 }
 
-// BuildOutboundMeshRouteConfiguration constructs the Envoy construct (*xds_route.RouteConfiguration) for the given outbound mesh route configs
-func BuildOutboundMeshRouteConfiguration(portSpecificRouteConfigs map[int][]*trafficpolicy.OutboundTrafficPolicy) []*xds_route.RouteConfiguration {
+// BuildOutboundMeshRouteConfiguration constructs the Envoy construct (*xds_route.RouteConfiguration) for the given outbound mesh route configs.
+// The given mesh cluster configs are the clusters the proxy is configured with, requests are only mirrored to these clusters.
+func BuildOutboundMeshRouteConfiguration(portSpecificRouteConfigs map[int][]*trafficpolicy.OutboundTrafficPolicy, clusterConfigs []*trafficpolicy.MeshClusterConfig) []*xds_route.RouteConfiguration {
 	var routeConfigs []*xds_route.RouteConfiguration
 
+	meshClusters := mapset.NewSet()
+	for _, clusterConfig := range clusterConfigs {
+		meshClusters.Add(clusterConfig.Name)
+	}
+
 	// An Envoy RouteConfiguration will exist for each HTTP upstream port.
 	// This is required to avoid route conflicts that can arise when the same host header
 	// has different routes on different destination ports for that host.
@@ -218,7 +252,7 @@ func BuildOutboundMeshRouteConfiguration(portSpecificRouteConfigs map[int][]*tra
 		routeConfig := NewRouteConfigurationStub(GetOutboundMeshRouteConfigNameForPort(port))
 		for _, config := range configs {
 			virtualHost := buildVirtualHostStub(outboundVirtualHost, config.Name, config.Hostnames)
-			virtualHost.Routes = buildOutboundRoutes(config.Routes)
+			virtualHost.Routes = buildOutboundRoutes(config.Routes, meshClusters)
 			routeConfig.VirtualHosts = append(routeConfig.VirtualHosts, virtualHost)
 		}
 		routeConfigs = append(routeConfigs, routeConfig)
@@ -270,7 +304,7 @@ func buildVirtualHostStub(namePrefix string, host string, domains []string) *xds
 }
 
 // buildInboundRoutes takes a route information from the given inbound traffic policy and returns a list of xds routes
-func buildInboundRoutes(rules []*trafficpolicy.Rule, trustDomain string) []*xds_route.Route {
+func buildInboundRoutes(rules []*trafficpolicy.Rule, trustDomain string, federatedTrustDomains []string) []*xds_route.Route {
 	var routes []*xds_route.Route
 	for _, rule := range rules {
 		// For a given route path, sanitize the methods in case there
@@ -279,7 +313,7 @@ func buildInboundRoutes(rules []*trafficpolicy.Rule, trustDomain string) []*xds_
 
 		// Create an RBAC policy derived from 'trafficpolicy.Rule'
 		// Each route is associated with an RBAC policy
-		rbacConfig, err := buildInboundRBACFilterForRule(rule, trustDomain)
+		rbacConfig, err := buildInboundRBACFilterForRule(rule, trustDomain, federatedTrustDomains)
 		if err != nil {
 			log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrBuildingRBACPolicyForRoute)).
 				Msgf("Error building RBAC policy for rule [%v], skipping route addition", rule)
@@ -318,20 +352,86 @@ func applyInboundRouteConfig(route *xds_route.Route, rbacConfig *any.Any, rateLi
 	route.TypedPerFilterConfig = perFilterConfig
 }
 
-func buildOutboundRoutes(outRoutes []*trafficpolicy.RouteWeightedClusters) []*xds_route.Route {
+func buildOutboundRoutes(outRoutes []*trafficpolicy.RouteWeightedClusters, meshClusters mapset.Set) []*xds_route.Route {
 	var routes []*xds_route.Route
 	for _, outRoute := range outRoutes {
 		// Create temp variable to avoid potentially overwriting the loop variable
 		tempOutbound := *outRoute
+
+		// Requests can only be mirrored to clusters the proxy is configured with
+		if outRoute.Mirror != nil && !meshClusters.Contains(outRoute.Mirror.ClusterName.String()) {
+			log.Warn().Msgf("Mirror cluster %s for route %s is not reachable by the proxy, ignoring mirror policy",
+				outRoute.Mirror.ClusterName, outRoute.HTTPRouteMatch.Path)
+			tempOutbound.Mirror = nil
+		}
+
+		if outRoute.PreserveRouteMatch {
+			// Each HTTP method corresponds to a separate route
+			for _, httpMethod := range sanitizeHTTPMethods(outRoute.HTTPRouteMatch.Methods) {
+				route := buildRoute(tempOutbound, httpMethod)
+				applyOutboundRouteConfig(route, outRoute.FaultInjection)
+				routes = append(routes, route)
+			}
+			continue
+		}
+
 		tempOutbound.HTTPRouteMatch.PathMatchType = trafficpolicy.PathMatchRegex
 		tempOutbound.HTTPRouteMatch.Path = constants.RegexMatchAll
 		tempOutbound.HTTPRouteMatch.Headers = map[string]string{}
-		routes = append(routes, buildRoute(tempOutbound, constants.WildcardHTTPMethod))
+		route := buildRoute(tempOutbound, constants.WildcardHTTPMethod)
+		applyOutboundRouteConfig(route, outRoute.FaultInjection)
+		routes = append(routes, route)
 	}
 
 	return routes
 }
 
+func applyOutboundRouteConfig(route *xds_route.Route, fault *policyv1alpha1.HTTPFaultInjectionRouteSpec) {
+	if route == nil || fault == nil {
+		return
+	}
+
+	// Apply fault injection config
+	if filter, err := getFaultFilterConfig(fault); err != nil {
+		log.Error().Err(err).Msgf("Error applying fault injection config for route path %s, ignoring it", fault.Path)
+	} else {
+		route.TypedPerFilterConfig = map[string]*any.Any{
+			envoy.HTTPFaultFilterName: filter,
+		}
+	}
+}
+
+// getFaultFilterConfig returns the marshalled HTTP fault injection config for the given policy
+func getFaultFilterConfig(fault *policyv1alpha1.HTTPFaultInjectionRouteSpec) (*any.Any, error) {
+	config := &xds_http_fault.HTTPFault{}
+
+	if fault.Delay != nil {
+		config.Delay = &xds_common_fault.FaultDelay{
+			FaultDelaySecifier: &xds_common_fault.FaultDelay_FixedDelay{
+				FixedDelay: durationpb.New(fault.Delay.FixedDelay.Duration),
+			},
+			Percentage: &xds_type.FractionalPercent{
+				Numerator:   fault.Delay.Percentage,
+				Denominator: xds_type.FractionalPercent_HUNDRED,
+			},
+		}
+	}
+
+	if fault.Abort != nil {
+		config.Abort = &xds_http_fault.FaultAbort{
+			ErrorType: &xds_http_fault.FaultAbort_HttpStatus{
+				HttpStatus: fault.Abort.HTTPStatus,
+			},
+			Percentage: &xds_type.FractionalPercent{
+				Numerator:   fault.Abort.Percentage,
+				Denominator: xds_type.FractionalPercent_HUNDRED,
+			},
+		}
+	}
+
+	return anypb.New(config)
+}
+
 func buildEgressRoutes(routingRules []*trafficpolicy.EgressHTTPRoutingRule) []*xds_route.Route {
 	var routes []*xds_route.Route
 	for _, rule := range routingRules {
@@ -367,6 +467,11 @@ func buildRoute(weightedClusters trafficpolicy.RouteWeightedClusters, method str
 		},
 	}
 
+	applyRouteTimeouts(route.GetRoute(), weightedClusters.Timeout)
+	applyRouteMirror(route.GetRoute(), weightedClusters.Mirror)
+	applyRouteHashPolicy(route.GetRoute(), weightedClusters.HashKeys)
+	applyRouteRateLimits(route.GetRoute(), weightedClusters.RateLimitDescriptors)
+
 	switch weightedClusters.HTTPRouteMatch.PathMatchType {
 	case trafficpolicy.PathMatchRegex:
 		route.Match.PathSpecifier = &xds_route.RouteMatch_SafeRegex{
@@ -443,6 +548,109 @@ func buildRetryPolicy(retry *policyv1alpha1.RetryPolicySpec) *xds_route.RetryPol
 	return rp
 }
 
+// applyRouteTimeouts applies the given per route timeouts to the route action
+func applyRouteTimeouts(action *xds_route.RouteAction, timeout *policyv1alpha1.HTTPRouteTimeoutSpec) {
+	if timeout == nil {
+		return
+	}
+
+	if timeout.Request != nil {
+		action.Timeout = durationpb.New(timeout.Request.Duration)
+	}
+
+	// IdleTimeout default uses the HTTP connection manager's stream idle timeout
+	if timeout.Idle != nil {
+		action.IdleTimeout = durationpb.New(timeout.Idle.Duration)
+	}
+}
+
+// applyRouteMirror applies the given mirror policy to the route action, such that the configured
+// fraction of requests is mirrored to the mirror cluster. Responses from the mirror cluster are discarded.
+func applyRouteMirror(action *xds_route.RouteAction, mirror *trafficpolicy.MirrorPolicy) {
+	if mirror == nil {
+		return
+	}
+
+	action.RequestMirrorPolicies = []*xds_route.RouteAction_RequestMirrorPolicy{
+		{
+			Cluster: mirror.ClusterName.String(),
+			RuntimeFraction: &xds_core.RuntimeFractionalPercent{
+				DefaultValue: &xds_type.FractionalPercent{
+					Numerator:   mirror.Percentage,
+					Denominator: xds_type.FractionalPercent_HUNDRED,
+				},
+			},
+		},
+	}
+}
+
+// applyRouteHashPolicy applies the given load balancer hash keys to the route action. Each hash policy
+// is terminal, so the first key present on a request is used to select the upstream endpoint.
+func applyRouteHashPolicy(action *xds_route.RouteAction, hashKeys []policyv1alpha1.LoadBalancerHashKeySpec) {
+	for _, key := range hashKeys {
+		hashPolicy := &xds_route.RouteAction_HashPolicy{Terminal: true}
+		switch {
+		case key.Header != "":
+			hashPolicy.PolicySpecifier = &xds_route.RouteAction_HashPolicy_Header_{
+				Header: &xds_route.RouteAction_HashPolicy_Header{
+					HeaderName: key.Header,
+				},
+			}
+		case key.Cookie != nil:
+			cookie := &xds_route.RouteAction_HashPolicy_Cookie{
+				Name: key.Cookie.Name,
+			}
+			// Envoy generates the cookie on the response when a TTL is set and the cookie is absent
+			if key.Cookie.TTL != nil {
+				cookie.Ttl = durationpb.New(key.Cookie.TTL.Duration)
+				cookie.Path = "/"
+			}
+			hashPolicy.PolicySpecifier = &xds_route.RouteAction_HashPolicy_Cookie_{
+				Cookie: cookie,
+			}
+		case key.SourceIP:
+			hashPolicy.PolicySpecifier = &xds_route.RouteAction_HashPolicy_ConnectionProperties_{
+				ConnectionProperties: &xds_route.RouteAction_HashPolicy_ConnectionProperties{
+					SourceIp: true,
+				},
+			}
+		default:
+			continue
+		}
+		action.HashPolicy = append(action.HashPolicy, hashPolicy)
+	}
+}
+
+// applyRouteRateLimits applies the given global rate limit descriptors to the route action. Envoy only
+// sends a descriptor to the rate limit service if the values of all of its entries are present on the request.
+func applyRouteRateLimits(action *xds_route.RouteAction, descriptors []trafficpolicy.RateLimitDescriptor) {
+	for _, descriptor := range descriptors {
+		rateLimit := &xds_route.RateLimit{}
+		for _, entry := range descriptor {
+			if entry.Header != "" {
+				rateLimit.Actions = append(rateLimit.Actions, &xds_route.RateLimit_Action{
+					ActionSpecifier: &xds_route.RateLimit_Action_RequestHeaders_{
+						RequestHeaders: &xds_route.RateLimit_Action_RequestHeaders{
+							HeaderName:    entry.Header,
+							DescriptorKey: entry.Key,
+						},
+					},
+				})
+				continue
+			}
+			rateLimit.Actions = append(rateLimit.Actions, &xds_route.RateLimit_Action{
+				ActionSpecifier: &xds_route.RateLimit_Action_GenericKey_{
+					GenericKey: &xds_route.RateLimit_Action_GenericKey{
+						DescriptorKey:   entry.Key,
+						DescriptorValue: entry.Value,
+					},
+				},
+			})
+		}
+		action.RateLimits = append(action.RateLimits, rateLimit)
+	}
+}
+
 // sanitizeHTTPMethods takes in a list of HTTP methods including a wildcard (*) and returns a wildcard if any of
 // the methods is a wildcard or sanitizes the input list to avoid duplicates.
 func sanitizeHTTPMethods(allowedMethods []string) []string {
@@ -6,13 +6,18 @@ import (
 	"time"
 
 	mapset "github.com/deckarep/golang-set"
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	xds_route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	xds_common_fault "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/common/fault/v3"
+	xds_http_fault "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/fault/v3"
 	xds_matcher "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	xds_type "github.com/envoyproxy/go-control-plane/envoy/type/v3"
 	"github.com/golang/mock/gomock"
 	"github.com/golang/protobuf/ptypes/any"
 	"github.com/golang/protobuf/ptypes/duration"
 	"github.com/golang/protobuf/ptypes/wrappers"
 	tassert "github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -212,6 +217,7 @@ func TestBuildInboundMeshRouteConfiguration(t *testing.T) {
 			mockCtrl := gomock.NewController(t)
 			defer mockCtrl.Finish()
 			mockCfg := configurator.NewMockConfigurator(mockCtrl)
+			mockCfg.EXPECT().GetFederatedTrustDomains().Return(nil).AnyTimes()
 
 			mockCfg.EXPECT().GetFeatureFlags().Return(configv1alpha2.FeatureFlags{
 				EnableWASMStats: false,
@@ -298,6 +304,7 @@ func TestBuildInboundMeshRouteConfiguration(t *testing.T) {
 			defer mockCtrl.Finish()
 
 			mockCfg := configurator.NewMockConfigurator(mockCtrl)
+			mockCfg.EXPECT().GetFederatedTrustDomains().Return(nil).AnyTimes()
 
 			mockCfg.EXPECT().GetFeatureFlags().Return(configv1alpha2.FeatureFlags{
 				EnableWASMStats: tc.wasmEnabled,
@@ -388,7 +395,7 @@ func TestBuildIngressRouteConfiguration(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			assert := tassert.New(t)
-			actual := BuildIngressConfiguration(tc.ingressPolicies, "cluster.local")
+			actual := BuildIngressConfiguration(tc.ingressPolicies, "cluster.local", nil)
 
 			if tc.expectedRouteConfigFields == nil {
 				assert.Nil(actual)
@@ -501,7 +508,7 @@ func TestBuildInboundRoutes(t *testing.T) {
 
 	for i, tc := range testCases {
 		t.Run(fmt.Sprintf("Testing test case %d: %s", i, tc.name), func(t *testing.T) {
-			actual := buildInboundRoutes(tc.inputRules, "cluster.local")
+			actual := buildInboundRoutes(tc.inputRules, "cluster.local", nil)
 			tc.expectFunc(tassert.New(t), actual)
 		})
 	}
@@ -531,7 +538,7 @@ func TestBuildOutboundRoutes(t *testing.T) {
 			},
 		},
 	}
-	actual := buildOutboundRoutes(input)
+	actual := buildOutboundRoutes(input, mapset.NewSet())
 	assert.Equal(1, len(actual))
 	assert.Equal(".*", actual[0].GetMatch().GetSafeRegex().Regex)
 	assert.Equal(".*", actual[0].GetMatch().GetHeaders()[0].GetSafeRegexMatch().Regex)
@@ -548,6 +555,71 @@ func TestBuildOutboundRoutes(t *testing.T) {
 		},
 	}
 	assert.Equal(retry, actual[0].GetRoute().GetRetryPolicy())
+
+	// Routes that must preserve their match are not widened to a wildcard match
+	input = []*trafficpolicy.RouteWeightedClusters{
+		{
+			HTTPRouteMatch: trafficpolicy.HTTPRouteMatch{
+				Path:          "/hello",
+				PathMatchType: trafficpolicy.PathMatchPrefix,
+				Methods:       []string{"GET", "POST"},
+				Headers:       map[string]string{"hello": "world"},
+			},
+			WeightedClusters:   mapset.NewSet(testWeightedCluster),
+			PreserveRouteMatch: true,
+		},
+	}
+	actual = buildOutboundRoutes(input, mapset.NewSet())
+	assert.Equal(2, len(actual))
+	for _, route := range actual {
+		assert.Equal("/hello", route.GetMatch().GetPrefix())
+		assert.Equal(2, len(route.GetMatch().GetHeaders()))
+	}
+
+	// The settings of a route sharing the host with other routes only apply to the requests on the path of the route
+	input = []*trafficpolicy.RouteWeightedClusters{
+		{
+			HTTPRouteMatch: trafficpolicy.HTTPRouteMatch{
+				Path:          "/slow",
+				PathMatchType: trafficpolicy.PathMatchRegex,
+				Methods:       []string{constants.WildcardHTTPMethod},
+			},
+			WeightedClusters:   mapset.NewSet(testWeightedCluster),
+			Timeout:            &policyv1alpha1.HTTPRouteTimeoutSpec{Request: &thresholdTimeoutDuration},
+			PreserveRouteMatch: true,
+		},
+		{
+			HTTPRouteMatch:   trafficpolicy.WildCardRouteMatch,
+			WeightedClusters: mapset.NewSet(testWeightedCluster),
+		},
+	}
+	actual = buildOutboundRoutes(input, mapset.NewSet())
+	assert.Equal(2, len(actual))
+	assert.Equal("/slow", actual[0].GetMatch().GetSafeRegex().Regex)
+	assert.Equal(durationpb.New(thresholdTimeoutDuration.Duration), actual[0].GetRoute().GetTimeout())
+	assert.Equal(".*", actual[1].GetMatch().GetSafeRegex().Regex)
+	assert.Equal(&duration.Duration{Seconds: 0}, actual[1].GetRoute().GetTimeout())
+
+	// Requests are only mirrored to the clusters the proxy is configured with
+	input = []*trafficpolicy.RouteWeightedClusters{
+		{
+			HTTPRouteMatch:     trafficpolicy.WildCardRouteMatch,
+			WeightedClusters:   mapset.NewSet(testWeightedCluster),
+			Mirror:             &trafficpolicy.MirrorPolicy{ClusterName: "testMirrorCluster", Percentage: 100},
+			PreserveRouteMatch: true,
+		},
+		{
+			HTTPRouteMatch:   trafficpolicy.WildCardRouteMatch,
+			WeightedClusters: mapset.NewSet(testWeightedCluster),
+			Mirror:           &trafficpolicy.MirrorPolicy{ClusterName: "unknownCluster", Percentage: 100},
+		},
+	}
+	actual = buildOutboundRoutes(input, mapset.NewSet("testCluster", "testMirrorCluster"))
+	assert.Equal(2, len(actual))
+	assert.Equal(1, len(actual[0].GetRoute().GetRequestMirrorPolicies()))
+	assert.Equal("testMirrorCluster", actual[0].GetRoute().GetRequestMirrorPolicies()[0].Cluster)
+	assert.Empty(actual[1].GetRoute().GetRequestMirrorPolicies())
+	assert.Equal("unknownCluster", input[1].Mirror.ClusterName.String())
 }
 
 func TestBuildRoute(t *testing.T) {
@@ -925,6 +997,392 @@ func TestBuildRetryPolicy(t *testing.T) {
 	}
 }
 
+func TestApplyRouteTimeouts(t *testing.T) {
+	requestTimeout := metav1.Duration{Duration: 30 * time.Second}
+	idleTimeout := metav1.Duration{Duration: 5 * time.Second}
+
+	testCases := []struct {
+		name      string
+		timeout   *policyv1alpha1.HTTPRouteTimeoutSpec
+		expAction *xds_route.RouteAction
+	}{
+		{
+			name:    "no timeout",
+			timeout: nil,
+			expAction: &xds_route.RouteAction{
+				Timeout: durationpb.New(0),
+			},
+		},
+		{
+			name: "request timeout only",
+			timeout: &policyv1alpha1.HTTPRouteTimeoutSpec{
+				Request: &requestTimeout,
+			},
+			expAction: &xds_route.RouteAction{
+				Timeout: durationpb.New(requestTimeout.Duration),
+			},
+		},
+		{
+			name: "request and idle timeouts",
+			timeout: &policyv1alpha1.HTTPRouteTimeoutSpec{
+				Request: &requestTimeout,
+				Idle:    &idleTimeout,
+			},
+			expAction: &xds_route.RouteAction{
+				Timeout:     durationpb.New(requestTimeout.Duration),
+				IdleTimeout: durationpb.New(idleTimeout.Duration),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			actual := &xds_route.RouteAction{Timeout: durationpb.New(0)}
+			applyRouteTimeouts(actual, tc.timeout)
+			assert.Equal(tc.expAction, actual)
+		})
+	}
+}
+
+func TestApplyRouteMirror(t *testing.T) {
+	testCases := []struct {
+		name      string
+		mirror    *trafficpolicy.MirrorPolicy
+		expAction *xds_route.RouteAction
+	}{
+		{
+			name:      "no mirror",
+			mirror:    nil,
+			expAction: &xds_route.RouteAction{},
+		},
+		{
+			name: "mirror a fraction of requests",
+			mirror: &trafficpolicy.MirrorPolicy{
+				ClusterName: "ns/bookstore-v2|80",
+				Percentage:  10,
+			},
+			expAction: &xds_route.RouteAction{
+				RequestMirrorPolicies: []*xds_route.RouteAction_RequestMirrorPolicy{
+					{
+						Cluster: "ns/bookstore-v2|80",
+						RuntimeFraction: &xds_core.RuntimeFractionalPercent{
+							DefaultValue: &xds_type.FractionalPercent{
+								Numerator:   10,
+								Denominator: xds_type.FractionalPercent_HUNDRED,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			actual := &xds_route.RouteAction{}
+			applyRouteMirror(actual, tc.mirror)
+			assert.Equal(tc.expAction, actual)
+		})
+	}
+}
+
+func TestApplyJWTClaimHeaders(t *testing.T) {
+	testCases := []struct {
+		name         string
+		requestAuthn *trafficpolicy.RequestAuthentication
+		expVhost     *xds_route.VirtualHost
+	}{
+		{
+			name:         "no request authentication",
+			requestAuthn: nil,
+			expVhost:     &xds_route.VirtualHost{},
+		},
+		{
+			name: "claims copied to headers",
+			requestAuthn: &trafficpolicy.RequestAuthentication{
+				Providers: []trafficpolicy.JWTProvider{
+					{
+						Name: "ns/authn/0",
+						ClaimToHeaders: []policyv1alpha1.ClaimToHeaderSpec{
+							{Claim: "sub", Header: "x-user"},
+							{Claim: "realm.role", Header: "x-role"},
+						},
+					},
+				},
+			},
+			expVhost: &xds_route.VirtualHost{
+				RequestHeadersToRemove: []string{"x-user", "x-role"},
+				RequestHeadersToAdd: []*xds_core.HeaderValueOption{
+					{
+						Header: &xds_core.HeaderValue{
+							Key:   "x-user",
+							Value: "%DYNAMIC_METADATA(envoy.filters.http.jwt_authn:jwt_payload:sub)%",
+						},
+						Append: &wrapperspb.BoolValue{Value: false},
+					},
+					{
+						Header: &xds_core.HeaderValue{
+							Key:   "x-role",
+							Value: "%DYNAMIC_METADATA(envoy.filters.http.jwt_authn:jwt_payload:realm:role)%",
+						},
+						Append: &wrapperspb.BoolValue{Value: false},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			actual := &xds_route.VirtualHost{}
+			applyJWTClaimHeaders(actual, tc.requestAuthn)
+			assert.Equal(tc.expVhost, actual)
+		})
+	}
+}
+
+func TestApplyRouteHashPolicy(t *testing.T) {
+	testCases := []struct {
+		name      string
+		hashKeys  []policyv1alpha1.LoadBalancerHashKeySpec
+		expAction *xds_route.RouteAction
+	}{
+		{
+			name:      "no hash keys",
+			hashKeys:  nil,
+			expAction: &xds_route.RouteAction{},
+		},
+		{
+			name: "header, cookie and source IP hash keys",
+			hashKeys: []policyv1alpha1.LoadBalancerHashKeySpec{
+				{Header: "x-user-id"},
+				{Cookie: &policyv1alpha1.LoadBalancerCookieSpec{Name: "session", TTL: &metav1.Duration{Duration: time.Hour}}},
+				{Cookie: &policyv1alpha1.LoadBalancerCookieSpec{Name: "user"}},
+				{SourceIP: true},
+			},
+			expAction: &xds_route.RouteAction{
+				HashPolicy: []*xds_route.RouteAction_HashPolicy{
+					{
+						PolicySpecifier: &xds_route.RouteAction_HashPolicy_Header_{
+							Header: &xds_route.RouteAction_HashPolicy_Header{HeaderName: "x-user-id"},
+						},
+						Terminal: true,
+					},
+					{
+						PolicySpecifier: &xds_route.RouteAction_HashPolicy_Cookie_{
+							Cookie: &xds_route.RouteAction_HashPolicy_Cookie{
+								Name: "session",
+								Ttl:  durationpb.New(time.Hour),
+								Path: "/",
+							},
+						},
+						Terminal: true,
+					},
+					{
+						PolicySpecifier: &xds_route.RouteAction_HashPolicy_Cookie_{
+							Cookie: &xds_route.RouteAction_HashPolicy_Cookie{Name: "user"},
+						},
+						Terminal: true,
+					},
+					{
+						PolicySpecifier: &xds_route.RouteAction_HashPolicy_ConnectionProperties_{
+							ConnectionProperties: &xds_route.RouteAction_HashPolicy_ConnectionProperties{SourceIp: true},
+						},
+						Terminal: true,
+					},
+				},
+			},
+		},
+		{
+			name:      "empty hash key is ignored",
+			hashKeys:  []policyv1alpha1.LoadBalancerHashKeySpec{{}},
+			expAction: &xds_route.RouteAction{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			actual := &xds_route.RouteAction{}
+			applyRouteHashPolicy(actual, tc.hashKeys)
+			assert.Equal(tc.expAction, actual)
+		})
+	}
+}
+
+func TestApplyRouteRateLimits(t *testing.T) {
+	testCases := []struct {
+		name        string
+		descriptors []trafficpolicy.RateLimitDescriptor
+		expAction   *xds_route.RouteAction
+	}{
+		{
+			name:        "no descriptors",
+			descriptors: nil,
+			expAction:   &xds_route.RouteAction{},
+		},
+		{
+			name: "static and header entries",
+			descriptors: []trafficpolicy.RateLimitDescriptor{
+				{
+					{Key: "destination", Value: "bookstore.bookstore.svc.cluster.local"},
+					{Key: "x-user-id", Header: "x-user-id"},
+				},
+				{
+					{Key: "destination", Value: "bookstore.bookstore.svc.cluster.local"},
+					{Key: "path", Header: ":path"},
+				},
+			},
+			expAction: &xds_route.RouteAction{
+				RateLimits: []*xds_route.RateLimit{
+					{
+						Actions: []*xds_route.RateLimit_Action{
+							{
+								ActionSpecifier: &xds_route.RateLimit_Action_GenericKey_{
+									GenericKey: &xds_route.RateLimit_Action_GenericKey{
+										DescriptorKey:   "destination",
+										DescriptorValue: "bookstore.bookstore.svc.cluster.local",
+									},
+								},
+							},
+							{
+								ActionSpecifier: &xds_route.RateLimit_Action_RequestHeaders_{
+									RequestHeaders: &xds_route.RateLimit_Action_RequestHeaders{
+										HeaderName:    "x-user-id",
+										DescriptorKey: "x-user-id",
+									},
+								},
+							},
+						},
+					},
+					{
+						Actions: []*xds_route.RateLimit_Action{
+							{
+								ActionSpecifier: &xds_route.RateLimit_Action_GenericKey_{
+									GenericKey: &xds_route.RateLimit_Action_GenericKey{
+										DescriptorKey:   "destination",
+										DescriptorValue: "bookstore.bookstore.svc.cluster.local",
+									},
+								},
+							},
+							{
+								ActionSpecifier: &xds_route.RateLimit_Action_RequestHeaders_{
+									RequestHeaders: &xds_route.RateLimit_Action_RequestHeaders{
+										HeaderName:    ":path",
+										DescriptorKey: "path",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			actual := &xds_route.RouteAction{}
+			applyRouteRateLimits(actual, tc.descriptors)
+			assert.Equal(tc.expAction, actual)
+		})
+	}
+}
+
+func TestApplyOutboundRouteConfig(t *testing.T) {
+	testCases := []struct {
+		name     string
+		fault    *policyv1alpha1.HTTPFaultInjectionRouteSpec
+		expFault *xds_http_fault.HTTPFault
+	}{
+		{
+			name:     "no fault",
+			fault:    nil,
+			expFault: nil,
+		},
+		{
+			name: "delay only",
+			fault: &policyv1alpha1.HTTPFaultInjectionRouteSpec{
+				Delay: &policyv1alpha1.HTTPFaultDelaySpec{
+					Percentage: 50,
+					FixedDelay: thresholdTimeoutDuration,
+				},
+			},
+			expFault: &xds_http_fault.HTTPFault{
+				Delay: &xds_common_fault.FaultDelay{
+					FaultDelaySecifier: &xds_common_fault.FaultDelay_FixedDelay{
+						FixedDelay: durationpb.New(thresholdTimeoutDuration.Duration),
+					},
+					Percentage: &xds_type.FractionalPercent{
+						Numerator:   50,
+						Denominator: xds_type.FractionalPercent_HUNDRED,
+					},
+				},
+			},
+		},
+		{
+			name: "delay and abort",
+			fault: &policyv1alpha1.HTTPFaultInjectionRouteSpec{
+				Delay: &policyv1alpha1.HTTPFaultDelaySpec{
+					Percentage: 100,
+					FixedDelay: thresholdBackoffDuration,
+				},
+				Abort: &policyv1alpha1.HTTPFaultAbortSpec{
+					Percentage: 10,
+					HTTPStatus: 503,
+				},
+			},
+			expFault: &xds_http_fault.HTTPFault{
+				Delay: &xds_common_fault.FaultDelay{
+					FaultDelaySecifier: &xds_common_fault.FaultDelay_FixedDelay{
+						FixedDelay: durationpb.New(thresholdBackoffDuration.Duration),
+					},
+					Percentage: &xds_type.FractionalPercent{
+						Numerator:   100,
+						Denominator: xds_type.FractionalPercent_HUNDRED,
+					},
+				},
+				Abort: &xds_http_fault.FaultAbort{
+					ErrorType: &xds_http_fault.FaultAbort_HttpStatus{
+						HttpStatus: 503,
+					},
+					Percentage: &xds_type.FractionalPercent{
+						Numerator:   10,
+						Denominator: xds_type.FractionalPercent_HUNDRED,
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			route := &xds_route.Route{}
+			applyOutboundRouteConfig(route, tc.fault)
+
+			if tc.expFault == nil {
+				assert.Nil(route.TypedPerFilterConfig)
+				return
+			}
+			assert.Len(route.TypedPerFilterConfig, 1)
+			actual := &xds_http_fault.HTTPFault{}
+			assert.Nil(route.TypedPerFilterConfig[envoy.HTTPFaultFilterName].UnmarshalTo(actual))
+			assert.True(proto.Equal(tc.expFault, actual))
+		})
+	}
+}
+
 func TestSanitizeHTTPMethods(t *testing.T) {
 	testCases := []struct {
 		name                   string
@@ -1516,7 +1974,7 @@ func TestBuildOutboundMeshRouteConfiguration(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			assert := tassert.New(t)
 
-			actual := BuildOutboundMeshRouteConfiguration(tc.portSpecificRouteConfigs)
+			actual := BuildOutboundMeshRouteConfiguration(tc.portSpecificRouteConfigs, nil)
 			assert.ElementsMatch(tc.expectedRouteConfigs, actual)
 		})
 	}
@@ -39,3 +39,19 @@ func (pr *ProxyRegistry) UnregisterProxy(p *envoy.Proxy) {
 func (pr *ProxyRegistry) GetConnectedProxyCount() int {
 	return len(pr.ListConnectedProxies())
 }
+
+// CountUnacknowledgedProxies returns the number of connected proxies that have not applied a service certificate
+// signed by the given signing issuer and carrying the trust bundle of the given validating issuer.
+// It implements the certificate.TrustBundleAcknowledger interface.
+func (pr *ProxyRegistry) CountUnacknowledgedProxies(signingIssuerID, validatingIssuerID string) int {
+	count := 0
+	pr.connectedProxies.Range(func(_, proxyIface interface{}) bool {
+		cert := proxyIface.(*envoy.Proxy).GetAppliedServiceCert()
+		// A proxy without a certificate yet is issued one by the current issuers, so it does not hold back a rotation
+		if cert != nil && (cert.GetSigningIssuerID() != signingIssuerID || cert.GetValidatingIssuerID() != validatingIssuerID) {
+			count++
+		}
+		return true
+	})
+	return count
+}
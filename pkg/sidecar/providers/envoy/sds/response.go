@@ -1,11 +1,14 @@
 package sds
 
 import (
+	"sort"
+
 	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	xds_auth "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
 	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
 	xds_matcher "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	"google.golang.org/protobuf/types/known/anypb"
 
 	"github.com/openservicemesh/osm/pkg/catalog"
 	"github.com/openservicemesh/osm/pkg/certificate"
@@ -18,7 +21,7 @@ import (
 )
 
 // NewResponse creates a new Secrets Discovery Response.
-func NewResponse(meshCatalog catalog.MeshCataloger, proxy *envoy.Proxy, request *xds_discovery.DiscoveryRequest, _ configurator.Configurator, certManager *certificate.Manager, _ *registry.ProxyRegistry) ([]types.Resource, error) {
+func NewResponse(meshCatalog catalog.MeshCataloger, proxy *envoy.Proxy, request *xds_discovery.DiscoveryRequest, cfg configurator.Configurator, certManager *certificate.Manager, _ *registry.ProxyRegistry) ([]types.Resource, error) {
 	log.Info().Str("proxy", proxy.String()).Msg("Composing SDS Discovery Response")
 
 	// OSM currently relies on kubernetes ServiceAccount for service identity
@@ -27,6 +30,8 @@ func NewResponse(meshCatalog catalog.MeshCataloger, proxy *envoy.Proxy, request
 		certManager:     certManager,
 		serviceIdentity: proxy.Identity,
 		TrustDomain:     certManager.GetTrustDomain(),
+
+		federatedTrustBundles: cfg.GetFederatedTrustBundles(),
 	}
 
 	var sdsResources []types.Resource
@@ -37,11 +42,12 @@ func NewResponse(meshCatalog catalog.MeshCataloger, proxy *envoy.Proxy, request
 	log.Info().Str("proxy", proxy.String()).Msgf("Creating SDS response for request for resources %v", requestedCerts)
 
 	// 1. Issue a service certificate for this proxy
-	cert, err := certManager.IssueCertificate(s.serviceIdentity.String(), certificate.Service)
+	cert, err := certManager.IssueCertificate(s.serviceIdentity.String(), certificate.Service, certificate.RequestedByProxy(proxy.UUID.String()))
 	if err != nil {
 		log.Error().Err(err).Str("proxy", proxy.String()).Msgf("Error issuing a certificate for proxy")
 		return nil, err
 	}
+	proxy.SetSentServiceCert(cert)
 
 	// 2. Create SDS secret resources based on the requested certs in the DiscoveryRequest
 	// request.ResourceNames is expected to be a list of either "service-cert:namespace/service" or "root-cert:namespace/service"
@@ -122,17 +128,18 @@ func getServiceCertSecret(cert *certificate.Certificate, name string) (*xds_auth
 }
 
 func (s *sdsImpl) getRootCert(cert *certificate.Certificate, sdscert secrets.SDSCert) (*xds_auth.Secret, error) {
+	validationContext, err := s.getValidationContext(cert)
+	if err != nil {
+		log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrMarshallingXDSResource)).
+			Msgf("Error building the validation context for cert %s", sdscert)
+		return nil, err
+	}
+
 	secret := &xds_auth.Secret{
 		// The Name field must match the tls_context.common_tls_context.tls_certificate_sds_secret_configs.name
 		Name: sdscert.String(),
 		Type: &xds_auth.Secret_ValidationContext{
-			ValidationContext: &xds_auth.CertificateValidationContext{
-				TrustedCa: &xds_core.DataSource{
-					Specifier: &xds_core.DataSource_InlineBytes{
-						InlineBytes: cert.GetTrustedCAs(),
-					},
-				},
-			},
+			ValidationContext: validationContext,
 		},
 	}
 
@@ -159,21 +166,87 @@ func (s *sdsImpl) getRootCert(cert *certificate.Certificate, sdscert secrets.SDS
 	}
 	svcIdentitiesInCertRequest := s.meshCatalog.ListServiceIdentitiesForService(*meshSvc)
 
-	secret.GetValidationContext().MatchSubjectAltNames = getSubjectAltNamesFromSvcIdentities(svcIdentitiesInCertRequest, s.TrustDomain)
+	secret.GetValidationContext().MatchSubjectAltNames = getSubjectAltNamesFromSvcIdentities(svcIdentitiesInCertRequest, s.TrustDomain, len(s.federatedTrustBundles) > 0)
 	return secret, nil
 }
 
+// getValidationContext returns the context used to validate a peer certificate.
+// Without federation, peers are validated against the mesh's trusted CAs. With federation, the SPIFFE
+// certificate validator binds each trust bundle to its trust domain, so that a federated CA can only
+// vouch for SPIFFE IDs in its own trust domain.
+func (s *sdsImpl) getValidationContext(cert *certificate.Certificate) (*xds_auth.CertificateValidationContext, error) {
+	if len(s.federatedTrustBundles) == 0 {
+		return &xds_auth.CertificateValidationContext{
+			TrustedCa: &xds_core.DataSource{
+				Specifier: &xds_core.DataSource_InlineBytes{
+					InlineBytes: cert.GetTrustedCAs(),
+				},
+			},
+		}, nil
+	}
+
+	spiffeValidator := &xds_auth.SPIFFECertValidatorConfig{
+		TrustDomains: []*xds_auth.SPIFFECertValidatorConfig_TrustDomain{
+			{
+				Name: s.TrustDomain,
+				TrustBundle: &xds_core.DataSource{
+					Specifier: &xds_core.DataSource_InlineBytes{
+						InlineBytes: cert.GetTrustedCAs(),
+					},
+				},
+			},
+		},
+	}
+
+	federatedTrustDomains := make([]string, 0, len(s.federatedTrustBundles))
+	for trustDomain := range s.federatedTrustBundles {
+		federatedTrustDomains = append(federatedTrustDomains, trustDomain)
+	}
+	// Sort the trust domains so the generated config is stable across responses
+	sort.Strings(federatedTrustDomains)
+	for _, trustDomain := range federatedTrustDomains {
+		spiffeValidator.TrustDomains = append(spiffeValidator.TrustDomains, &xds_auth.SPIFFECertValidatorConfig_TrustDomain{
+			Name: trustDomain,
+			TrustBundle: &xds_core.DataSource{
+				Specifier: &xds_core.DataSource_InlineString{
+					InlineString: s.federatedTrustBundles[trustDomain],
+				},
+			},
+		})
+	}
+
+	marshalledValidator, err := anypb.New(spiffeValidator)
+	if err != nil {
+		return nil, err
+	}
+
+	return &xds_auth.CertificateValidationContext{
+		CustomValidatorConfig: &xds_core.TypedExtensionConfig{
+			Name:        envoy.SPIFFECertValidatorName,
+			TypedConfig: marshalledValidator,
+		},
+	}, nil
+}
+
 // Note: ServiceIdentity must be in the format "name.namespace" [https://github.com/openservicemesh/osm/issues/3188]
-func getSubjectAltNamesFromSvcIdentities(serviceIdentities []identity.ServiceIdentity, trustDomain string) []*xds_matcher.StringMatcher {
+// A peer is matched by its principal, or by its SPIFFE ID when its certificate has one. When spiffeOnly is set,
+// as is the case with federation, a peer is only matched by its SPIFFE ID in the mesh's trust domain.
+func getSubjectAltNamesFromSvcIdentities(serviceIdentities []identity.ServiceIdentity, trustDomain string, spiffeOnly bool) []*xds_matcher.StringMatcher {
 	var matchSANs []*xds_matcher.StringMatcher
 
 	for _, si := range serviceIdentities {
-		match := xds_matcher.StringMatcher{
-			MatchPattern: &xds_matcher.StringMatcher_Exact{
-				Exact: si.AsPrincipal(trustDomain),
-			},
+		principals := si.AsPrincipals(trustDomain)
+		if spiffeOnly {
+			principals = []string{si.AsSpiffeID(trustDomain)}
+		}
+		for _, principal := range principals {
+			match := xds_matcher.StringMatcher{
+				MatchPattern: &xds_matcher.StringMatcher_Exact{
+					Exact: principal,
+				},
+			}
+			matchSANs = append(matchSANs, &match)
 		}
-		matchSANs = append(matchSANs, &match)
 	}
 
 	return matchSANs
@@ -20,6 +20,7 @@ import (
 	"github.com/openservicemesh/osm/pkg/catalog"
 	catalogFake "github.com/openservicemesh/osm/pkg/catalog/fake"
 	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/certificate/pem"
 	tresorFake "github.com/openservicemesh/osm/pkg/certificate/providers/tresor/fake"
 	"github.com/openservicemesh/osm/pkg/identity"
 	"github.com/openservicemesh/osm/pkg/k8s/informers"
@@ -297,7 +298,10 @@ func TestGetSDSSecrets(t *testing.T) {
 			requestedCerts: []string{"root-cert-for-mtls-outbound:ns-2/service-2"}, // root-cert requested
 
 			// expectations
-			expectedSANs:        []string{"sa-2.ns-2.cluster.local", "sa-3.ns-2.cluster.local"},
+			expectedSANs: []string{
+				"sa-2.ns-2.cluster.local", "spiffe://cluster.local/ns/ns-2/sa/sa-2",
+				"sa-3.ns-2.cluster.local", "spiffe://cluster.local/ns/ns-2/sa/sa-3",
+			},
 			expectedSecretCount: 1,
 		},
 		// Test case 2 end -------------------------------
@@ -394,6 +398,7 @@ func TestGetSDSSecrets(t *testing.T) {
 func TestGetSubjectAltNamesFromSvcAccount(t *testing.T) {
 	type testCase struct {
 		serviceIdentities   []identity.ServiceIdentity
+		spiffeOnly          bool
 		expectedSANMatchers []*xds_matcher.StringMatcher
 	}
 
@@ -409,11 +414,34 @@ func TestGetSubjectAltNamesFromSvcAccount(t *testing.T) {
 						Exact: "sa-1.ns-1.cluster.local",
 					},
 				},
+				{
+					MatchPattern: &xds_matcher.StringMatcher_Exact{
+						Exact: "spiffe://cluster.local/ns/ns-1/sa/sa-1",
+					},
+				},
 				{
 					MatchPattern: &xds_matcher.StringMatcher_Exact{
 						Exact: "sa-2.ns-2.cluster.local",
 					},
 				},
+				{
+					MatchPattern: &xds_matcher.StringMatcher_Exact{
+						Exact: "spiffe://cluster.local/ns/ns-2/sa/sa-2",
+					},
+				},
+			},
+		},
+		{
+			serviceIdentities: []identity.ServiceIdentity{
+				identity.K8sServiceAccount{Name: "sa-1", Namespace: "ns-1"}.ToServiceIdentity(),
+			},
+			spiffeOnly: true,
+			expectedSANMatchers: []*xds_matcher.StringMatcher{
+				{
+					MatchPattern: &xds_matcher.StringMatcher_Exact{
+						Exact: "spiffe://cluster.local/ns/ns-1/sa/sa-1",
+					},
+				},
 			},
 		},
 	}
@@ -422,7 +450,7 @@ func TestGetSubjectAltNamesFromSvcAccount(t *testing.T) {
 		t.Run(fmt.Sprintf("Testing test case %d", i), func(t *testing.T) {
 			assert := tassert.New(t)
 
-			actual := getSubjectAltNamesFromSvcIdentities(tc.serviceIdentities, "cluster.local")
+			actual := getSubjectAltNamesFromSvcIdentities(tc.serviceIdentities, "cluster.local", tc.spiffeOnly)
 			assert.ElementsMatch(actual, tc.expectedSANMatchers)
 		})
 	}
@@ -464,3 +492,36 @@ func TestSubjectAltNamesToStr(t *testing.T) {
 		})
 	}
 }
+
+func TestGetValidationContext(t *testing.T) {
+	assert := tassert.New(t)
+
+	cert := &certificate.Certificate{TrustedCAs: pem.RootCertificate("mesh-ca")}
+
+	s := &sdsImpl{TrustDomain: "cluster.local"}
+	validationContext, err := s.getValidationContext(cert)
+	assert.Nil(err)
+	assert.Equal([]byte("mesh-ca"), validationContext.GetTrustedCa().GetInlineBytes())
+	assert.Nil(validationContext.GetCustomValidatorConfig())
+
+	s.federatedTrustBundles = map[string]string{
+		"example.org": "federated-ca-2",
+		"example.com": "federated-ca-1",
+	}
+	validationContext, err = s.getValidationContext(cert)
+	assert.Nil(err)
+	// Federated trust bundles must not be merged into the mesh's trusted CAs
+	assert.Nil(validationContext.GetTrustedCa())
+	assert.Equal(envoy.SPIFFECertValidatorName, validationContext.GetCustomValidatorConfig().GetName())
+
+	spiffeValidator := &xds_auth.SPIFFECertValidatorConfig{}
+	assert.Nil(validationContext.GetCustomValidatorConfig().GetTypedConfig().UnmarshalTo(spiffeValidator))
+
+	var trustDomains, trustBundles []string
+	for _, trustDomain := range spiffeValidator.GetTrustDomains() {
+		trustDomains = append(trustDomains, trustDomain.GetName())
+		trustBundles = append(trustBundles, string(trustDomain.GetTrustBundle().GetInlineBytes())+trustDomain.GetTrustBundle().GetInlineString())
+	}
+	assert.Equal([]string{"cluster.local", "example.com", "example.org"}, trustDomains)
+	assert.Equal([]string{"mesh-ca", "federated-ca-1", "federated-ca-2"}, trustBundles)
+}
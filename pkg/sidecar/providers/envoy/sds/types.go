@@ -18,4 +18,7 @@ type sdsImpl struct {
 	meshCatalog     catalog.MeshCataloger
 	certManager     *certificate.Manager
 	TrustDomain     string
+
+	// federatedTrustBundles maps the SPIFFE trust domains federated with the mesh to their PEM encoded trust bundles
+	federatedTrustBundles map[string]string
 }
@@ -97,6 +97,9 @@ const (
 
 	HTTPExtAuthzFilterName    = "http_external_authz"
 	HTTPHealthCheckFilterName = "http_health_check"
+	HTTPRateLimitFilterName   = "http_global_rate_limit"
+	HTTPJWTAuthnFilterName    = "http_jwt_authn"
+	HTTPJWTRBACFilterName     = "http_jwt_rbac"
 
 	// The HTTP typed filters referenced in the RDS configuration still need to
 	// use wellknown names. These filters are configured as a map where the key is
@@ -104,6 +107,7 @@ const (
 	// See https://github.com/envoyproxy/envoy/issues/21759#issuecomment-1163570994
 	HTTPRBACFilterName           = "envoy.filters.http.rbac"
 	HTTPLocalRateLimitFilterName = "envoy.filters.http.local_ratelimit"
+	HTTPFaultFilterName          = "envoy.filters.http.fault"
 
 	// Network (L4) filters
 	TCPProxyFilterName         = "tcp_proxy"
@@ -116,6 +120,16 @@ const (
 	HTTPInspectorFilterName = "http_inspector"
 )
 
+// SPIFFECertValidatorName is the name of the Envoy certificate validator that binds trust bundles to SPIFFE trust domains
+const SPIFFECertValidatorName = "envoy.tls.cert_validator.spiffe"
+
+// JWT authentication dynamic metadata - the payload of a verified JWT is written to the
+// JWTPayloadMetadataKey key of the JWTAuthnMetadataNamespace namespace
+const (
+	JWTAuthnMetadataNamespace = "envoy.filters.http.jwt_authn"
+	JWTPayloadMetadataKey     = "jwt_payload"
+)
+
 // Filter TypeURLs - used by Envoy to determine the filter to use
 const (
 	HTTPRouterFilterTypeURL    = "type.googleapis.com/envoy.extensions.filters.http.router.v3.Router"
@@ -2,13 +2,16 @@
 package driver
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"sort"
 	"time"
 
 	"github.com/openservicemesh/osm/pkg/models"
+	"github.com/openservicemesh/osm/pkg/sidecar/driver"
 	"github.com/openservicemesh/osm/pkg/sidecar/providers/pipy/registry"
+	"github.com/openservicemesh/osm/pkg/sidecar/providers/pipy/repo"
 )
 
 const (
@@ -61,7 +64,7 @@ func (sd PipySidecarDriver) printProxies(proxyRegistry *registry.ProxyRegistry,
 
 func (sd PipySidecarDriver) getConfigDump(proxyRegistry *registry.ProxyRegistry, uuid string, w http.ResponseWriter) {
 	proxy := proxyRegistry.GetConnectedProxy(uuid)
-	if proxy != nil {
+	if proxy == nil {
 		msg := fmt.Sprintf("Proxy for UUID %s not found, may have been disconnected", uuid)
 		log.Error().Msg(msg)
 		http.Error(w, msg, http.StatusNotFound)
@@ -98,3 +101,34 @@ func (sd PipySidecarDriver) getProxy(proxyRegistry *registry.ProxyRegistry, uuid
 	pipyConfig := sd.getSidecarConfig(pod, "certs")
 	_, _ = fmt.Fprintf(w, "%s", pipyConfig)
 }
+
+// getIntendedConfig returns the PipyConf the controller currently computes for the proxy of the requested UUID or pod
+func (sd PipySidecarDriver) getIntendedConfig(repoServer *repo.Server, proxyRegistry *registry.ProxyRegistry) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		uuid, err := driver.GetProxyUUIDForDebugRequest(sd.ctx.MeshCatalog.GetKubeController(), r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		proxy := proxyRegistry.GetConnectedProxy(uuid)
+		if proxy == nil {
+			msg := fmt.Sprintf("Proxy for UUID %s not found, may have been disconnected", uuid)
+			log.Error().Msg(msg)
+			http.Error(w, msg, http.StatusNotFound)
+			return
+		}
+
+		pipyConf, err := repoServer.GenerateSidecarConf(proxy)
+		if err != nil {
+			msg := fmt.Sprintf("Error generating the config of proxy %s", proxy.GetName())
+			log.Error().Err(err).Msg(msg)
+			http.Error(w, msg, http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", " ")
+		_ = enc.Encode(pipyConf)
+	})
+}
@@ -37,10 +37,12 @@ func (sd PipySidecarDriver) Start(ctx context.Context) (health.Probes, error) {
 	proxyMapper := &registry.KubeProxyServiceMapper{KubeController: k8sClient}
 	proxyRegistry := registry.NewProxyRegistry(proxyMapper, ctrlCtx.MsgBroker)
 	go proxyRegistry.ReleaseCertificateHandler(certManager, ctrlCtx.Stop)
+	certManager.SetTrustBundleAcknowledger(proxyRegistry)
 	// Create and start the pipy repo http service
 	repoServer := repo.NewRepoServer(ctrlCtx.MeshCatalog, proxyRegistry, cfg.IsDebugServerEnabled(), ctrlCtx.OsmNamespace, cfg, certManager, k8sClient, ctrlCtx.MsgBroker)
 
 	ctrlCtx.DebugHandlers["/debug/proxy"] = sd.getProxies(proxyRegistry)
+	ctrlCtx.DebugHandlers["/debug/proxy/config"] = sd.getIntendedConfig(repoServer, proxyRegistry)
 
 	return repoServer, repoServer.Start(proxyServerPort, proxyServiceCert)
 }
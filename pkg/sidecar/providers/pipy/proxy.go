@@ -5,6 +5,7 @@ import (
 	"net"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
@@ -46,6 +47,11 @@ type Proxy struct {
 	// The version of Pipy Repo Codebase
 	ETag uint64
 
+	// The sidecar certificate of the codebase last published to the Pipy Repo and the one last reported as running
+	// by the Pipy instance fetching the codebase, used to track the progress of root certificate rotations
+	publishedSidecarCert atomic.Value
+	appliedSidecarCert   atomic.Value
+
 	Mutex *sync.RWMutex
 	Quit  chan bool
 
@@ -161,6 +167,40 @@ func (p *Proxy) GetAddr() string {
 	return p.Addr.String()
 }
 
+// PublishedSidecarCert is the sidecar certificate of a codebase published to the Pipy Repo
+type PublishedSidecarCert struct {
+	Cert *certificate.Certificate
+
+	// Version is the version of the config of the published codebase
+	Version string
+
+	// StatsAddr is the address of the stats listener of the Pipy instance fetching the codebase,
+	// which reports the version of the config it runs
+	StatsAddr string
+}
+
+// SetPublishedSidecarCert records the sidecar certificate of the codebase last published for the proxy.
+func (p *Proxy) SetPublishedSidecarCert(published *PublishedSidecarCert) {
+	p.publishedSidecarCert.Store(published)
+}
+
+// GetPublishedSidecarCert returns the sidecar certificate of the codebase last published for the proxy.
+func (p *Proxy) GetPublishedSidecarCert() *PublishedSidecarCert {
+	published, _ := p.publishedSidecarCert.Load().(*PublishedSidecarCert)
+	return published
+}
+
+// SetAppliedSidecarCert records the sidecar certificate of the codebase the Pipy instance of the proxy reported running.
+func (p *Proxy) SetAppliedSidecarCert(cert *certificate.Certificate) {
+	p.appliedSidecarCert.Store(cert)
+}
+
+// GetAppliedSidecarCert returns the sidecar certificate of the codebase the Pipy instance of the proxy reported running.
+func (p *Proxy) GetAppliedSidecarCert() *certificate.Certificate {
+	cert, _ := p.appliedSidecarCert.Load().(*certificate.Certificate)
+	return cert
+}
+
 // Kind return the proxy's kind
 func (p *Proxy) Kind() models.ProxyKind {
 	return p.kind
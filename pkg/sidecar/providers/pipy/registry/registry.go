@@ -1,6 +1,16 @@
 package registry
 
 import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/messaging"
 	"github.com/openservicemesh/osm/pkg/sidecar/providers/pipy"
 )
@@ -54,3 +64,94 @@ func (pr *ProxyRegistry) UnregisterProxy(p *pipy.Proxy) {
 func (pr *ProxyRegistry) GetConnectedProxyCount() int {
 	return len(pr.ListConnectedProxies())
 }
+
+// CountUnacknowledgedProxies returns the number of connected proxies whose Pipy instance has not reported running a
+// codebase carrying a sidecar certificate signed by the given signing issuer along with the trust bundle of the given
+// validating issuer. It implements the certificate.TrustBundleAcknowledger interface.
+func (pr *ProxyRegistry) CountUnacknowledgedProxies(signingIssuerID, validatingIssuerID string) int {
+	isCurrent := func(cert *certificate.Certificate) bool {
+		return cert != nil && cert.GetSigningIssuerID() == signingIssuerID && cert.GetValidatingIssuerID() == validatingIssuerID
+	}
+
+	count := 0
+	var pending []*pipy.Proxy
+	connectedProxies.Range(func(_, proxyIface interface{}) bool {
+		proxy := proxyIface.(*pipy.Proxy)
+		published := proxy.GetPublishedSidecarCert()
+		// A proxy without a certificate yet is issued one by the current issuers, so it does not hold back a rotation
+		if published == nil || published.Cert == nil || isCurrent(proxy.GetAppliedSidecarCert()) {
+			return true
+		}
+		if !isCurrent(published.Cert) {
+			count++
+			return true
+		}
+		pending = append(pending, proxy)
+		return true
+	})
+
+	// The Pipy instances of the proxies whose codebase carrying a current certificate was published are asked
+	// for the version of the config they run, the certificate is applied once they run the published version.
+	var acknowledged int32
+	var wg sync.WaitGroup
+	queries := make(chan struct{}, maxConcurrentVersionQueries)
+	for _, proxy := range pending {
+		wg.Add(1)
+		queries <- struct{}{}
+		go func(proxy *pipy.Proxy) {
+			defer func() {
+				<-queries
+				wg.Done()
+			}()
+			if acknowledgePublishedSidecarCert(proxy) {
+				atomic.AddInt32(&acknowledged, 1)
+			}
+		}(proxy)
+	}
+	wg.Wait()
+
+	return count + len(pending) - int(acknowledged)
+}
+
+// acknowledgePublishedSidecarCert records the published sidecar certificate of the given proxy as applied if the
+// Pipy instance fetching the codebase of the proxy runs the published version of its config.
+func acknowledgePublishedSidecarCert(proxy *pipy.Proxy) bool {
+	published := proxy.GetPublishedSidecarCert()
+	if len(published.StatsAddr) == 0 {
+		return false
+	}
+	version, err := getRunningConfigVersion(published.StatsAddr)
+	if err != nil {
+		log.Debug().Err(err).Str("proxy", proxy.String()).Msg("Error getting the version of the config run by proxy")
+		return false
+	}
+	if version != published.Version {
+		return false
+	}
+	proxy.SetAppliedSidecarCert(published.Cert)
+	return true
+}
+
+// getRunningConfigVersion returns the version of the config run by the Pipy instance whose stats listener is at the
+// given address.
+var getRunningConfigVersion = func(statsAddr string) (string, error) {
+	client := &http.Client{Timeout: runningConfigVersionTimeout}
+	resp, err := client.Get(fmt.Sprintf("http://%s/version", net.JoinHostPort(statsAddr, strconv.Itoa(constants.SidecarAdminPort))))
+	if err != nil {
+		return "", err
+	}
+	//nolint: errcheck
+	//#nosec G307
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	var version struct {
+		Version string
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&version); err != nil {
+		return "", err
+	}
+	return version.Version, nil
+}
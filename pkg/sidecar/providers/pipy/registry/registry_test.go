@@ -0,0 +1,96 @@
+package registry
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	tassert "github.com/stretchr/testify/assert"
+
+	"github.com/openservicemesh/osm/pkg/certificate/providers/tresor/fake"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/models"
+	"github.com/openservicemesh/osm/pkg/sidecar/providers/pipy"
+)
+
+func TestCountUnacknowledgedProxies(t *testing.T) {
+	// the certificates of the fake have no issuer IDs, so they are current for the empty issuer IDs
+	cert := fake.NewFakeCertificate()
+
+	testCases := []struct {
+		name                string
+		published           *pipy.PublishedSidecarCert
+		runningVersion      string
+		runningVersionErr   error
+		signingIssuerID     string
+		expectedCount       int
+		expectedAppliedCert bool
+	}{
+		{
+			name:          "proxy without a published certificate",
+			published:     nil,
+			expectedCount: 0,
+		},
+		{
+			name:                "Pipy instance runs the published version",
+			published:           &pipy.PublishedSidecarCert{Cert: cert, Version: "2", StatsAddr: "10.0.0.1"},
+			runningVersion:      "2",
+			expectedCount:       0,
+			expectedAppliedCert: true,
+		},
+		{
+			name:           "Pipy instance has not fetched the published version yet",
+			published:      &pipy.PublishedSidecarCert{Cert: cert, Version: "2", StatsAddr: "10.0.0.1"},
+			runningVersion: "1",
+			expectedCount:  1,
+		},
+		{
+			name:              "Pipy instance does not report the version of its config",
+			published:         &pipy.PublishedSidecarCert{Cert: cert, Version: "2", StatsAddr: "10.0.0.1"},
+			runningVersionErr: errors.New("connection refused"),
+			expectedCount:     1,
+		},
+		{
+			name:          "address of the Pipy instance is unknown",
+			published:     &pipy.PublishedSidecarCert{Cert: cert, Version: "2"},
+			expectedCount: 1,
+		},
+		{
+			name:            "published certificate is not signed by the current issuer",
+			published:       &pipy.PublishedSidecarCert{Cert: cert, Version: "2", StatsAddr: "10.0.0.1"},
+			runningVersion:  "2",
+			signingIssuerID: "new-mrc",
+			expectedCount:   1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			oldGetRunningConfigVersion := getRunningConfigVersion
+			getRunningConfigVersion = func(statsAddr string) (string, error) {
+				assert.Equal("10.0.0.1", statsAddr)
+				return tc.runningVersion, tc.runningVersionErr
+			}
+			defer func() {
+				getRunningConfigVersion = oldGetRunningConfigVersion
+			}()
+
+			proxy := pipy.NewProxy(models.KindSidecar, uuid.New(), identity.New("foo", "bar"), nil)
+			if tc.published != nil {
+				proxy.SetPublishedSidecarCert(tc.published)
+			}
+			connectedProxies.Store(proxy.UUID.String(), proxy)
+			defer connectedProxies.Delete(proxy.UUID.String())
+
+			pr := NewProxyRegistry(nil, nil)
+			assert.Equal(tc.expectedCount, pr.CountUnacknowledgedProxies(tc.signingIssuerID, ""))
+			if tc.expectedAppliedCert {
+				assert.Equal(cert, proxy.GetAppliedSidecarCert())
+			} else {
+				assert.Nil(proxy.GetAppliedSidecarCert())
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package registry
 
 import (
 	"sync"
+	"time"
 
 	"github.com/openservicemesh/osm/pkg/logger"
 	"github.com/openservicemesh/osm/pkg/messaging"
@@ -14,6 +15,14 @@ var (
 	connectedProxies sync.Map
 )
 
+const (
+	// maxConcurrentVersionQueries is the maximum number of Pipy instances asked at once for the version of their config
+	maxConcurrentVersionQueries = 16
+
+	// runningConfigVersionTimeout is the timeout to get the version of the config run by a Pipy instance
+	runningConfigVersionTimeout = 2 * time.Second
+)
+
 // ProxyRegistry keeps track of Sidecar proxies as they connect and disconnect
 // from the control plane.
 type ProxyRegistry struct {
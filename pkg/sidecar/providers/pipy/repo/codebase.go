@@ -21,6 +21,9 @@ var codebaseConnectUpstreamJs []byte
 //go:embed codebase/dns-main.js
 var codebaseDNSMainJs []byte
 
+//go:embed codebase/load-balancer.js
+var codebaseLoadBalancerJs []byte
+
 //go:embed codebase/logging.js
 var codebaseLoggingJs []byte
 
@@ -39,6 +42,9 @@ var codebaseModulesInboundHTTPLoadBalancingJs []byte
 //go:embed codebase/modules/inbound-http-routing.js
 var codebaseModulesInboundHTTPRoutingJs []byte
 
+//go:embed codebase/modules/inbound-jwt-authn.js
+var codebaseModulesInboundJWTAuthnJs []byte
+
 //go:embed codebase/modules/inbound-logging-http.js
 var codebaseModulesInboundLoggingHTTPJs []byte
 
@@ -72,12 +78,21 @@ var codebaseModulesInboundTracingHTTPJs []byte
 //go:embed codebase/modules/outbound-circuit-breaker.js
 var codebaseModulesOutboundCircuitBreakerJs []byte
 
+//go:embed codebase/modules/outbound-fault-injection.js
+var codebaseModulesOutboundFaultInjectionJs []byte
+
+//go:embed codebase/modules/outbound-global-rate-limit.js
+var codebaseModulesOutboundGlobalRateLimitJs []byte
+
 //go:embed codebase/modules/outbound-http-default.js
 var codebaseModulesOutboundHTTPDefaultJs []byte
 
 //go:embed codebase/modules/outbound-http-load-balancing.js
 var codebaseModulesOutboundHTTPLoadBalancingJs []byte
 
+//go:embed codebase/modules/outbound-http-mirror.js
+var codebaseModulesOutboundHTTPMirrorJs []byte
+
 //go:embed codebase/modules/outbound-http-routing.js
 var codebaseModulesOutboundHTTPRoutingJs []byte
 
@@ -123,12 +138,14 @@ var osmCodebaseItems = []client.BatchItem{
 	{Filename: "connect-tls.js", Content: codebaseConnectTLSJs},
 	{Filename: "connect-upstream.js", Content: codebaseConnectUpstreamJs},
 	{Filename: "dns-main.js", Content: codebaseDNSMainJs},
+	{Filename: "load-balancer.js", Content: codebaseLoadBalancerJs},
 	{Filename: "logging.js", Content: codebaseLoggingJs},
 	{Filename: "main.js", Content: codebaseMainJs},
 	{Filename: "metrics.js", Content: codebaseMetricsJs},
 	{Filename: "modules/inbound-http-default.js", Content: codebaseModulesInboundHTTPDefaultJs},
 	{Filename: "modules/inbound-http-load-balancing.js", Content: codebaseModulesInboundHTTPLoadBalancingJs},
 	{Filename: "modules/inbound-http-routing.js", Content: codebaseModulesInboundHTTPRoutingJs},
+	{Filename: "modules/inbound-jwt-authn.js", Content: codebaseModulesInboundJWTAuthnJs},
 	{Filename: "modules/inbound-logging-http.js", Content: codebaseModulesInboundLoggingHTTPJs},
 	{Filename: "modules/inbound-main.js", Content: codebaseModulesInboundMainJs},
 	{Filename: "modules/inbound-metrics-http.js", Content: codebaseModulesInboundMetricsHTTPJs},
@@ -140,8 +157,11 @@ var osmCodebaseItems = []client.BatchItem{
 	{Filename: "modules/inbound-tls-termination.js", Content: codebaseModulesInboundTLSTerminationJs},
 	{Filename: "modules/inbound-tracing-http.js", Content: codebaseModulesInboundTracingHTTPJs},
 	{Filename: "modules/outbound-circuit-breaker.js", Content: codebaseModulesOutboundCircuitBreakerJs},
+	{Filename: "modules/outbound-fault-injection.js", Content: codebaseModulesOutboundFaultInjectionJs},
+	{Filename: "modules/outbound-global-rate-limit.js", Content: codebaseModulesOutboundGlobalRateLimitJs},
 	{Filename: "modules/outbound-http-default.js", Content: codebaseModulesOutboundHTTPDefaultJs},
 	{Filename: "modules/outbound-http-load-balancing.js", Content: codebaseModulesOutboundHTTPLoadBalancingJs},
+	{Filename: "modules/outbound-http-mirror.js", Content: codebaseModulesOutboundHTTPMirrorJs},
 	{Filename: "modules/outbound-http-routing.js", Content: codebaseModulesOutboundHTTPRoutingJs},
 	{Filename: "modules/outbound-logging-http.js", Content: codebaseModulesOutboundLoggingHTTPJs},
 	{Filename: "modules/outbound-main.js", Content: codebaseModulesOutboundMainJs},
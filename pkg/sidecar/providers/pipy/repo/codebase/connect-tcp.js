@@ -13,6 +13,7 @@ pipy({
 .export('connect-tcp', {
   __target: null,
   __metricLabel: null,
+  __timeout: null,
 })
 
 .pipeline()
@@ -46,6 +47,12 @@ pipy({
   () => __target.startsWith('127.0.0.1:'), (
     $=>$.connect(() => __target, { bind: '127.0.0.6' })
   ),
+  () => __timeout, (
+    $=>$.connect(() => __target, () => ({
+      readTimeout: __timeout.Request || 0,
+      idleTimeout: __timeout.Idle || 0,
+    }))
+  ),
   (
     $=>$.connect(() => __target)
   )
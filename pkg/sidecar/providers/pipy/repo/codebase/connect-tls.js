@@ -4,10 +4,15 @@
   certChain = config?.Certificate?.CertChain,
   privateKey = config?.Certificate?.PrivateKey,
   issuingCA = config?.Certificate?.IssuingCA,
+  trustedCAs = config?.Certificate?.TrustedCAs,
 
   listIssuingCA = (
     (cas = []) => (
-      issuingCA && cas.push(new crypto.Certificate(issuingCA)),
+      trustedCAs?.length > 0 ? (
+        trustedCAs.forEach(ca => cas.push(new crypto.Certificate(ca)))
+      ) : (
+        issuingCA && cas.push(new crypto.Certificate(issuingCA))
+      ),
       Object.values(config?.Outbound?.TrafficMatches || {}).map(
         a => a.map(
           o => Object.values(o.DestinationIPRanges || {}).map(
@@ -26,7 +31,7 @@
     )
   )(),
 
-  certCache = new algo.Cache(certString => new crypto.Certificate(certString)),
+  certCache = new algo.Cache(certString => new crypto.CertificateChain(certString)),
   keyCache = new algo.Cache(keyString => new crypto.PrivateKey(keyString)),
 ) => (
 
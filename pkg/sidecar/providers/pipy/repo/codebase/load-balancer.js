@@ -0,0 +1,178 @@
+((
+  {
+    shuffle,
+  } = pipy.solve('utils.js'),
+
+  // Number of virtual nodes on the hash ring, shared by the endpoints by weight
+  ringHashSize = 1024,
+
+  // Size of the Maglev lookup table, must be a prime number
+  maglevTableSize = 5003,
+
+  // Exact multiplication of unsigned 32-bit integers modulo 2^32
+  mul32 = (a, b) => ((a * (b >>> 16)) % 65536 * 65536 + a * (b & 0xffff)) % 4294967296,
+
+  // FNV-1a hash of a string followed by the MurmurHash3 finalizer,
+  // as an unsigned 32-bit integer
+  hash = str => (
+    (
+      h = String(str).split('').reduce(
+        (h, c) => mul32((h ^ c.charCodeAt(0)) >>> 0, 16777619),
+        2166136261
+      ),
+    ) => (
+      h = mul32((h ^ (h >>> 16)) >>> 0, 0x85ebca6b),
+      h = mul32((h ^ (h >>> 13)) >>> 0, 0xc2b2ae35),
+      (h ^ (h >>> 16)) >>> 0
+    )
+  )(),
+
+  // Endpoints without weight are only used for failover
+  weightedEntries = weights => Object.entries(weights).filter(([_, w]) => w > 0),
+
+  // Target objects are reused so that upstream connections are shared per endpoint
+  makeTargets = weights => Object.fromEntries(Object.keys(weights).map(id => [id, { id }])),
+
+  makeRandom = (weights) => (
+    (
+      entries = weightedEntries(weights),
+      total = entries.reduce((sum, [_, w]) => sum + w, 0),
+      targets = makeTargets(weights),
+    ) => ({
+      next: () => (
+        (
+          r = Math.random() * total,
+        ) => targets[entries.find(([_, w]) => (r -= w) < 0)?.[0]]
+      )(),
+    })
+  )(),
+
+  // Picks two random endpoints and selects the one with the fewest
+  // active requests relative to its weight
+  makeLeastRequest = (weights) => (
+    (
+      entries = weightedEntries(weights),
+      active = Object.fromEntries(entries.map(([id]) => [id, 0])),
+      targets = makeTargets(weights),
+      pick = () => entries[Math.random() * entries.length | 0],
+    ) => ({
+      next: () => (
+        entries.length > 0 ? (
+          (
+            a = pick(),
+            b = pick(),
+            e = active[a[0]] / a[1] <= active[b[0]] / b[1] ? a : b,
+          ) => (
+            active[e[0]]++,
+            targets[e[0]]
+          )
+        )() : undefined
+      ),
+      free: id => void (
+        active[id] > 0 && active[id]--
+      ),
+    })
+  )(),
+
+  makeRingHash = (weights) => (
+    (
+      entries = weightedEntries(weights),
+      total = entries.reduce((sum, [_, w]) => sum + w, 0),
+      targets = makeTargets(weights),
+      ring = entries.reduce(
+        (ring, [id, w]) => (
+          new Array(Math.max(1, Math.round(ringHashSize * w / total))).fill(0).forEach(
+            (_, i) => ring.push({ point: hash(id + '#' + i), id })
+          ),
+          ring
+        ),
+        []
+      ).sort((a, b) => a.point - b.point),
+
+      // Returns the index of the first virtual node at or after the given point
+      search = (point, lo, hi) => (
+        lo < hi ? (
+          (
+            mid = (lo + hi) >>> 1,
+          ) => ring[mid].point < point ? search(point, mid + 1, hi) : search(point, lo, mid)
+        )() : lo
+      ),
+    ) => ({
+      next: key => (
+        ring.length > 0 ? (
+          targets[ring[search(hash(key || algo.uuid()), 0, ring.length) % ring.length].id]
+        ) : undefined
+      ),
+    })
+  )(),
+
+  makeMaglev = (weights) => (
+    (
+      entries = weightedEntries(weights),
+      maxWeight = entries.reduce((max, [_, w]) => Math.max(max, w), 0),
+      targets = makeTargets(weights),
+      table = new Array(maglevTableSize).fill(null),
+      steps = new Array(maglevTableSize).fill(0),
+      filled = 0,
+      permutations = entries.map(
+        ([id, w]) => ({
+          id,
+          offset: hash(id) % maglevTableSize,
+          skip: hash(id + '#skip') % (maglevTableSize - 1) + 1,
+          ratio: w / maxWeight,
+          credit: 0,
+          next: 0,
+        })
+      ),
+
+      // Each endpoint claims its next preferred free slot in turn,
+      // as often as its weight relative to the heaviest endpoint allows
+      claim = p => (
+        (p.credit += p.ratio) >= 1 && (
+          (
+            slot = 0,
+          ) => (
+            p.credit -= 1,
+            steps.find(
+              () => (
+                slot = (p.offset + p.next * p.skip) % maglevTableSize,
+                p.next++,
+                table[slot] === null
+              )
+            ),
+            table[slot] = p.id,
+            filled++
+          )
+        )(),
+        filled === maglevTableSize
+      ),
+    ) => (
+      permutations.length > 0 && steps.find(
+        () => permutations.find(claim)
+      ),
+      {
+        next: key => (
+          permutations.length > 0 ? (
+            targets[table[hash(key || algo.uuid()) % maglevTableSize]]
+          ) : undefined
+        ),
+      }
+    )
+  )(),
+
+  isHashing = type => type === 'RingHash' || type === 'Maglev',
+) => (
+  {
+    isHashing,
+
+    // Returns a load balancer for the given endpoint weights whose next()
+    // returns the target selected for the given hash key, if any
+    makeLoadBalancer: (type, weights) => (
+      type === 'LeastRequest' ? makeLeastRequest(weights) :
+      type === 'Random' ? makeRandom(weights) :
+      type === 'RingHash' ? makeRingHash(weights) :
+      type === 'Maglev' ? makeMaglev(weights) :
+      new algo.RoundRobinLoadBalancer(shuffle(weights))
+    ),
+  }
+))()
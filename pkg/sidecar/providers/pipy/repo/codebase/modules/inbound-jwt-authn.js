@@ -0,0 +1,128 @@
+((
+  jwtDeniedCounter = new stats.Counter('sidecar_inbound_jwt_authn_denied', ['reason']),
+
+  claimOf = (payload, name) => name.split('.').reduce((value, key) => value?.[key], payload),
+
+  matchClaim = (value, allowedValues) => (
+    Array.isArray(value) ? (
+      value.some(v => allowedValues.includes(String(v)))
+    ) : (
+      value !== undefined && value !== null && allowedValues.includes(String(value))
+    )
+  ),
+
+  makeProvider = provider => (
+    {
+      issuer: provider.Issuer,
+      audiences: provider.Audiences?.length > 0 ? provider.Audiences : null,
+      keys: (provider.Keys || []).map(k => ({ kid: k.kid, key: new crypto.JWK(k) })).filter(k => k.key.isValid),
+      forward: Boolean(provider.ForwardOriginalToken),
+      claimToHeaders: Object.entries(provider.ClaimToHeaders || {}).map(([claim, header]) => [claim, header.toLowerCase()]),
+    }
+  ),
+
+  authnCache = new algo.Cache(
+    requestAuthn => (
+      (
+        providers = (requestAuthn.Providers || []).map(makeProvider),
+      ) => ({
+        providers,
+        rules: requestAuthn.Rules || [],
+        claimHeaders: providers.flatMap(p => p.claimToHeaders.map(([, header]) => header)),
+      })
+    )()
+  ),
+
+  verify = (authn, token) => (
+    (
+      jwt = new crypto.JWT(token),
+      payload = jwt.isValid ? jwt.payload : null,
+      kid = jwt.isValid ? jwt.header?.kid : undefined,
+      now = Date.now() / 1000,
+    ) => (
+      payload &&
+      (!payload.exp || payload.exp > now) &&
+      (!payload.nbf || payload.nbf <= now) &&
+      (
+        (
+          provider = authn.providers.find(
+            p => p.issuer === payload.iss &&
+              (!p.audiences || matchClaim(payload.aud, p.audiences)) &&
+              p.keys.some(k => (!kid || !k.kid || k.kid === kid) && jwt.verify(k.key))
+          ),
+        ) => provider && { provider, payload }
+      )()
+    )
+  )(),
+
+  authorize = (authn, payload) => (
+    authn.rules.length === 0 || authn.rules.some(
+      rule => Object.entries(rule.Claims || {}).every(([name, values]) => matchClaim(claimOf(payload, name), values))
+    )
+  ),
+) => pipy({
+  _authn: null,
+  _status: 0,
+})
+
+.import({
+  __service: 'inbound-http-routing',
+})
+
+.pipeline()
+.branch(
+  () => __service?.RequestAuthentication && (_authn = authnCache.get(__service.RequestAuthentication)), (
+    $=>$
+    .handleMessageStart(
+      msg => (
+        (
+          headers = msg.head.headers,
+          authorization = headers.authorization || '',
+          token = authorization.startsWith('Bearer ') ? authorization.substring(7).trim() : '',
+          verified = token ? verify(_authn, token) : null,
+        ) => (
+          _authn.claimHeaders.forEach(header => delete headers[header]),
+          !verified ? (
+            _status = 401,
+            jwtDeniedCounter.withLabels('unauthenticated').increase()
+          ) : !authorize(_authn, verified.payload) ? (
+            _status = 403,
+            jwtDeniedCounter.withLabels('unauthorized').increase()
+          ) : (
+            verified.provider.claimToHeaders.forEach(
+              ([claim, header]) => (
+                (value = claimOf(verified.payload, claim)) => (
+                  value !== undefined && value !== null && (
+                    headers[header] = typeof value === 'object' ? JSON.stringify(value) : String(value)
+                  )
+                )
+              )()
+            ),
+            !verified.provider.forward && (delete headers.authorization)
+          )
+        )
+      )()
+    )
+    .branch(
+      () => _status === 401, (
+        $=>$.replaceMessage(
+          () => [
+            new Message({ status: 401, headers: { 'www-authenticate': 'Bearer realm="' + (__service?.name || '') + '"' } }, 'Jwt verification fails'),
+            new StreamEnd
+          ]
+        )
+      ),
+      () => _status === 403, (
+        $=>$.replaceMessage(
+          () => [new Message({ status: 403 }, 'RBAC: access denied'), new StreamEnd]
+        )
+      ), (
+        $=>$.chain()
+      )
+    )
+  ), (
+    $=>$.chain()
+  )
+)
+
+)()
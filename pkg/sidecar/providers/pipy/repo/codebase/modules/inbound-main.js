@@ -104,6 +104,7 @@
       'modules/inbound-metrics-http.js',
       'modules/inbound-tracing-http.js',
       'modules/inbound-logging-http.js',
+      'modules/inbound-jwt-authn.js',
       'modules/inbound-throttle-service.js',
       'modules/inbound-throttle-route.js',
       'modules/inbound-http-load-balancing.js',
@@ -5,6 +5,14 @@
   privateKey = config?.Certificate?.PrivateKey,
   issuingCA = config?.Certificate?.IssuingCA,
 
+  trustedCAs = (
+    config?.Certificate?.TrustedCAs?.length > 0 ? (
+      config.Certificate.TrustedCAs.map(ca => new crypto.Certificate(ca))
+    ) : (
+      issuingCA ? [new crypto.Certificate(issuingCA)] : []
+    )
+  ),
+
   sourceIPRangesCache = new algo.Cache((sourceIPRanges) => (
     sourceIPRanges ? (
       Object.entries(sourceIPRanges).map(
@@ -39,10 +47,10 @@
     !_tlsConfig || _tlsConfig?.mTLS), (
     $=>$.acceptTLS({
       certificate: () => ({
-        cert: new crypto.Certificate(certChain),
+        cert: new crypto.CertificateChain(certChain),
         key: new crypto.PrivateKey(privateKey),
       }),
-      trusted: issuingCA ? [new crypto.Certificate(issuingCA)] : [],
+      trusted: trustedCAs,
       verify: (ok, cert) => (
         _tlsConfig?.mTLS && !_tlsConfig?.skipClientCertValidation && (
           _tlsConfig?.authenticatedPrincipals && (_forbiddenTLS = true),
@@ -0,0 +1,54 @@
+((
+  faultDelayCounter = new stats.Counter('sidecar_cluster_upstream_rq_fault_delay', ['sidecar_cluster_name']),
+  faultAbortCounter = new stats.Counter('sidecar_cluster_upstream_rq_fault_abort', ['sidecar_cluster_name']),
+
+  hit = (percentage) => percentage > 0 && Math.random() * 100 < percentage,
+) => pipy({
+  _fault: null,
+  _delay: 0,
+  _abort: false,
+})
+
+.import({
+  __route: 'outbound-http-routing',
+  __cluster: 'outbound-http-routing',
+})
+
+.pipeline()
+.onStart(
+  () => void (
+    (_fault = __route?.Fault) && (
+      _fault.Delay && hit(_fault.Delay.Percentage) && (
+        _delay = _fault.Delay.FixedDelay,
+        faultDelayCounter.withLabels(__cluster?.name).increase()
+      ),
+      _fault.Abort && hit(_fault.Abort.Percentage) && (
+        _abort = true,
+        faultAbortCounter.withLabels(__cluster?.name).increase()
+      )
+    )
+  )
+)
+.branch(
+  () => _delay > 0, (
+    $=>$.wait(
+      () => new Timeout(_delay).wait()
+    )
+  ), (
+    $=>$
+  )
+)
+.branch(
+  () => _abort, (
+    $=>$.replaceMessage(
+      () => [
+        new Message({ status: _fault.Abort.HTTPStatus }, 'fault filter abort'),
+        new StreamEnd
+      ]
+    )
+  ), (
+    $=>$.chain()
+  )
+)
+
+)()
@@ -0,0 +1,149 @@
+((
+  config = pipy.solve('config.js'),
+  rateLimitService = config?.Spec?.GlobalRateLimit,
+  rateLimitTarget = rateLimitService && (
+    rateLimitService.Address.includes(':') ? `[${rateLimitService.Address}]:${rateLimitService.Port}` : `${rateLimitService.Address}:${rateLimitService.Port}`
+  ),
+
+  globalRateLimitOverLimitCounter = new stats.Counter('sidecar_cluster_ratelimit_over_limit', ['sidecar_cluster_name']),
+  globalRateLimitErrorCounter = new stats.Counter('sidecar_cluster_ratelimit_error', ['sidecar_cluster_name']),
+
+  // RateLimitResponse codes of the Envoy RLS protocol
+  codeOK = 1,
+  codeOverLimit = 2,
+
+  varint = n => n < 128 ? [n] : [(n & 127) | 128, ...varint(Math.floor(n / 128))],
+
+  // Length-delimited protobuf field
+  field = (number, data) => new Data([...varint(number << 3 | 2), ...varint(data.size)]).push(data),
+
+  concat = items => items.reduce((data, item) => data.push(item), new Data),
+
+  // Encodes a RateLimitRequest message with a hits_addend of 1
+  encodeRequest = (domain, descriptors) => concat([
+    field(1, new Data(domain)),
+    ...descriptors.map(
+      entries => field(2, concat(entries.map(
+        ([key, value]) => field(1, concat([field(1, new Data(key)), field(2, new Data(value))]))
+      )))
+    ),
+    new Data([3 << 3, 1]),
+  ]),
+
+  // Prepends the gRPC length prefix to a message
+  frame = data => new Data([0, data.size >>> 24 & 255, data.size >>> 16 & 255, data.size >>> 8 & 255, data.size & 255]).push(data),
+
+  // Returns the overall_code of a framed RateLimitResponse message, which is its first field if set
+  decodeOverallCode = body => (
+    (
+      bytes = body?.toArray?.() || [],
+    ) => bytes.length > 6 && bytes[5] === (1 << 3) ? bytes[6] : 0
+  )(),
+
+  // A descriptor is only sent if all of its entries have a value on the request
+  getDescriptors = (descriptors, head) => (
+    descriptors.map(
+      entries => entries.map(
+        ({ Key, Value, Header }) => [
+          Key,
+          Header ? (Header === ':path' ? head.path : head.headers?.[Header.toLowerCase()]) : Value
+        ]
+      )
+    ).filter(
+      entries => entries.every(([_, value]) => value)
+    )
+  ),
+) => pipy({
+  _descriptors: null,
+  _request: null,
+  _responded: false,
+  _rejected: false,
+})
+
+.import({
+  __route: 'outbound-http-routing',
+  __cluster: 'outbound-http-routing',
+})
+
+.pipeline()
+.handleMessageStart(
+  msg => (
+    rateLimitService && __route?.RateLimitDescriptors && (
+      _descriptors = getDescriptors(__route.RateLimitDescriptors, msg.head),
+      _descriptors.length === 0 && (_descriptors = null)
+    )
+  )
+)
+.branch(
+  () => _descriptors, (
+    $=>$
+    .replaceMessage(
+      msg => (
+        _request = msg,
+        new Message(
+          {
+            method: 'POST',
+            path: '/envoy.service.ratelimit.v3.RateLimitService/ShouldRateLimit',
+            headers: {
+              'host': rateLimitTarget,
+              'content-type': 'application/grpc',
+              'te': 'trailers',
+            },
+          },
+          frame(encodeRequest(rateLimitService.Domain, _descriptors))
+        )
+      )
+    )
+    .muxHTTP(() => rateLimitTarget, { version: 2 }).to(
+      $=>$.connect(() => rateLimitTarget, {
+        connectTimeout: rateLimitService.Timeout,
+        readTimeout: rateLimitService.Timeout,
+      })
+    )
+    .replaceMessage(
+      msg => (
+        (
+          code = decodeOverallCode(msg?.body),
+        ) => (
+          _responded = true,
+          code === codeOverLimit ? (
+            _rejected = true,
+            globalRateLimitOverLimitCounter.withLabels(__cluster?.name).increase(),
+            [new Message({ status: 429, headers: { 'x-ratelimited': 'true' } }), new StreamEnd]
+          ) : code === codeOK || rateLimitService.FailureModeAllow ? (
+            code !== codeOK && globalRateLimitErrorCounter.withLabels(__cluster?.name).increase(),
+            _request
+          ) : (
+            _rejected = true,
+            globalRateLimitErrorCounter.withLabels(__cluster?.name).increase(),
+            [new Message({ status: 500 }), new StreamEnd]
+          )
+        )
+      )()
+    )
+    .replaceStreamEnd(
+      // The stream ends without a response if the rate limit service is unreachable
+      e => _responded ? e : (
+        _responded = true,
+        globalRateLimitErrorCounter.withLabels(__cluster?.name).increase(),
+        rateLimitService.FailureModeAllow ? (
+          [_request, new StreamEnd]
+        ) : (
+          _rejected = true,
+          [new Message({ status: 500 }), new StreamEnd]
+        )
+      )
+    )
+  ), (
+    $=>$
+  )
+)
+.branch(
+  () => _rejected, (
+    $=>$
+  ), (
+    $=>$.chain()
+  )
+)
+
+)()
@@ -4,9 +4,12 @@
   privateKey = config?.Certificate?.PrivateKey,
   isDebugEnabled = config?.Spec?.SidecarLogLevel === 'debug',
   {
-    shuffle,
     failover,
   } = pipy.solve('utils.js'),
+  {
+    isHashing,
+    makeLoadBalancer,
+  } = pipy.solve('load-balancer.js'),
 
   retryCounter = new stats.Counter('sidecar_cluster_upstream_rq_retry', ['sidecar_cluster_name']),
   retrySuccessCounter = new stats.Counter('sidecar_cluster_upstream_rq_retry_success', ['sidecar_cluster_name']),
@@ -14,16 +17,140 @@
   retryOverflowCounter = new stats.Counter('sidecar_cluster_upstream_rq_retry_overflow', ['sidecar_cluster_name']),
   retryBackoffCounter = new stats.Counter('sidecar_cluster_upstream_rq_retry_backoff_exponential', ['sidecar_cluster_name']),
   retryBackoffLimitCounter = new stats.Counter('sidecar_cluster_upstream_rq_retry_backoff_ratelimited', ['sidecar_cluster_name']),
+  ejectionCounter = new stats.Counter('sidecar_cluster_outlier_detection_ejections_total', ['sidecar_cluster_name']),
+
+  makeOutlierDetection = (clusterConfig) => (
+    (
+      od = clusterConfig.OutlierDetection,
+      endpoints = Object.keys(clusterConfig.Endpoints || {}),
+      states = Object.fromEntries(endpoints.map(ep => [ep, { errors: 0, ejections: 0, ejectedUntil: 0 }])),
+      obj = {
+        endpoints,
+        // At least one endpoint can be ejected regardless of MaxEjectionPercent
+        maxEjections: Math.max(1, Math.floor(endpoints.length * od.MaxEjectionPercent / 100)),
+        ejectedCount: 0,
+        lastSweep: 0,
+        ejectionCounter: ejectionCounter.withLabels(clusterConfig.name),
+      },
+    ) => (
+      obj.ejectionCounter.zero(),
+
+      // Returns ejected endpoints whose ejection time has elapsed to the pool,
+      // and decreases the ejection multiplier of the healthy endpoints
+      obj.sweep = (now) => void (
+        (now - obj.lastSweep >= od.Interval * 1000) && (
+          obj.lastSweep = now,
+          Object.values(states).forEach(
+            state => (
+              state.ejectedUntil > 0 ? (
+                state.ejectedUntil <= now && (
+                  state.ejectedUntil = 0,
+                  obj.ejectedCount--
+                )
+              ) : (
+                state.ejections > 0 && state.ejections--
+              )
+            )
+          )
+        )
+      ),
+
+      obj.isEjected = (target) => states[target]?.ejectedUntil > 0,
+
+      // Ejects the endpoint after ConsecutiveErrors consecutive failures
+      obj.report = (target, failed) => void (
+        (state = states[target]) => state && (
+          failed ? (
+            ++state.errors >= od.ConsecutiveErrors && state.ejectedUntil === 0 && obj.ejectedCount < obj.maxEjections && (
+              state.errors = 0,
+              state.ejections++,
+              state.ejectedUntil = Date.now() + od.BaseEjectionTime * 1000 * state.ejections,
+              obj.ejectedCount++,
+              obj.ejectionCounter.increase()
+            )
+          ) : (
+            state.errors = 0
+          )
+        )
+      )(),
+
+      obj
+    )
+  )(),
+
+  // Picks the next endpoint that is not ejected, falling back to
+  // the balancer's choice when every endpoint has been ejected.
+  // The hash key is salted on each attempt so that consistent
+  // hashing moves on to another endpoint. Ejected endpoints picked
+  // before another attempt are freed, as they are not used.
+  nextTarget = (clusterConfig, hashKey) => (
+    (
+      od = clusterConfig.outlierDetection,
+      balancer = clusterConfig.targetBalancer,
+      target = null,
+    ) => (
+      od.sweep(Date.now()),
+      od.endpoints.find(
+        (_, i) => (
+          target && balancer?.free?.(target.id),
+          target = balancer?.next?.(hashKey && i > 0 ? hashKey + '#' + i : hashKey)
+        ) && !od.isEjected(target.id)
+      ),
+      target
+    )
+  )(),
+
+  getCookie = (cookies, name) => (
+    cookies && cookies.split(';').map(c => c.trim()).find(c => c.startsWith(name + '='))?.substring?.(name.length + 1)
+  ),
+
+  // Returns the value of the first hash key present on the request. A missing
+  // cookie with a TTL is generated, and set on the response to make the
+  // subsequent requests from the client stick to the same endpoint.
+  getHashKey = (hashKeys, head) => (
+    (
+      key = undefined,
+    ) => (
+      hashKeys.find(
+        k => (
+          k.Header ? (
+            key = head?.headers?.[k.Header.toLowerCase()]
+          ) : k.Cookie ? (
+            (key = getCookie(head?.headers?.cookie, k.Cookie.Name)) || (
+              k.Cookie.TTL > 0 && (
+                key = algo.uuid(),
+                _setCookie = k.Cookie.Name + '=' + key + '; Path=/; Max-Age=' + Math.floor(k.Cookie.TTL)
+              )
+            )
+          ) : k.SourceIP ? (
+            key = __inbound.remoteAddress
+          ) : false
+        )
+      ),
+      key
+    )
+  )(),
+
+  selectTarget = (hashKey) => (
+    _targetObject = _clusterConfig.outlierDetection ? nextTarget(_clusterConfig, hashKey) : _clusterConfig.targetBalancer?.next?.(hashKey),
+    __target = _targetObject?.id,
+    _clusterConfig.targetBalancer?.free && (
+      _balancedTarget = __target
+    )
+  ),
 
   makeClusterConfig = (clusterConfig) => (
     clusterConfig && (
       (
         endpointAttributes = {},
         obj = {
-          targetBalancer: clusterConfig.Endpoints && new algo.RoundRobinLoadBalancer(
-            shuffle(Object.fromEntries(Object.entries(clusterConfig.Endpoints).map(([k, v]) => (endpointAttributes[k] = v, [k, v.Weight]))))
+          targetBalancer: clusterConfig.Endpoints && makeLoadBalancer(
+            clusterConfig.LoadBalancer?.Type,
+            Object.fromEntries(Object.entries(clusterConfig.Endpoints).map(([k, v]) => (endpointAttributes[k] = v, [k, v.Weight])))
           ),
           endpointAttributes,
+          // The target is selected once the request head carrying the hash keys is received
+          hashKeys: isHashing(clusterConfig.LoadBalancer?.Type) ? (clusterConfig.LoadBalancer.HashKeys || []) : null,
           failoverBalancer: clusterConfig.Endpoints && failover(Object.fromEntries(Object.entries(clusterConfig.Endpoints).map(([k, v]) => [k, v.Weight]))),
           needRetry: Boolean(clusterConfig.RetryPolicy?.NumRetries),
           numRetries: clusterConfig.RetryPolicy?.NumRetries,
@@ -45,6 +172,7 @@
           retryOverflowCounter: retryOverflowCounter.withLabels(clusterConfig.name),
           retryBackoffCounter: retryBackoffCounter.withLabels(clusterConfig.name),
           retryBackoffLimitCounter: retryBackoffLimitCounter.withLabels(clusterConfig.name),
+          outlierDetection: clusterConfig.OutlierDetection && clusterConfig.Endpoints && makeOutlierDetection(clusterConfig),
           muxHttpOptions: {
             version: () => __isHTTP2 ? 2 : 1,
             maxMessages: clusterConfig.ConnectionSettings?.http?.MaxRequestsPerConnection
@@ -86,6 +214,9 @@
   _failoverObject: null,
   _targetObject: null,
   _muxHttpOptions: null,
+  _outlierReported: false,
+  _balancedTarget: null,
+  _setCookie: null,
 })
 
 .import({
@@ -98,14 +229,14 @@
   __cluster: 'outbound-http-routing',
   __metricLabel: 'connect-tcp',
   __target: 'connect-tcp',
+  __timeout: 'connect-tcp',
 })
 
 .pipeline()
 .onStart(
   () => void (
     (_clusterConfig = clusterConfigs.get(__cluster)) && (
-      _targetObject = _clusterConfig.targetBalancer?.next?.(),
-      __target = _targetObject?.id,
+      !_clusterConfig.hashKeys && selectTarget(),
       _muxHttpOptions = _clusterConfig.muxHttpOptions,
       _clusterConfig.failoverBalancer && (
         _failoverObject = _clusterConfig.failoverBalancer.next()
@@ -115,6 +246,7 @@
 )
 .handleMessageStart(
   msg => (
+    _clusterConfig?.hashKeys && selectTarget(getHashKey(_clusterConfig.hashKeys, msg.head)),
     __target && (
       (
         attrs = _clusterConfig?.endpointAttributes?.[__target]
@@ -170,6 +302,28 @@
     $=>$.link('upstream')
   )
 )
+.branch(
+  () => _setCookie, (
+    $=>$.handleMessageStart(
+      msg => (
+        // Leave the response intact if the upstream sets its own cookies
+        msg?.head?.headers && !msg.head.headers['set-cookie'] && (
+          msg.head.headers['set-cookie'] = _setCookie
+        )
+      )
+    )
+  ), (
+    $=>$
+  )
+)
+.handleStreamEnd(
+  () => (
+    _balancedTarget && (
+      _clusterConfig.targetBalancer.free(_balancedTarget),
+      _balancedTarget = null
+    )
+  )
+)
 
 .pipeline('upstream')
 .handleStreamStart(
@@ -181,7 +335,8 @@
         __cert = __cluster.SourceCert
       )
     ),
-    __metricLabel = __cluster?.name
+    __metricLabel = __cluster?.name,
+    __timeout = __route?.Timeout
   )
 )
 .branch(
@@ -202,5 +357,27 @@
     $=>$.muxHTTP(() => _targetObject, () => _muxHttpOptions).to($=>$.use('connect-upstream.js'))
   )
 )
+.branch(
+  () => __target && _clusterConfig?.outlierDetection, (
+    $=>$
+    .handleStreamStart(
+      () => _outlierReported = false
+    )
+    .handleMessageStart(
+      msg => (
+        _outlierReported = true,
+        _clusterConfig.outlierDetection.report(__target, msg?.head?.status >= 500)
+      )
+    )
+    .handleStreamEnd(
+      e => (
+        // Connection failures end the stream without a response
+        !_outlierReported && e.error && _clusterConfig.outlierDetection.report(__target, true)
+      )
+    )
+  ), (
+    $=>$
+  )
+)
 
 )()
@@ -0,0 +1,70 @@
+((
+  config = pipy.solve('config.js'),
+  {
+    shuffle,
+  } = pipy.solve('utils.js'),
+
+  mirrorCounter = new stats.Counter('sidecar_cluster_upstream_rq_mirror', ['sidecar_cluster_name']),
+
+  mirrorClusterCache = new algo.Cache(
+    (clusterName => (
+      (cluster = config?.Outbound?.ClustersConfigs?.[clusterName]) => (
+        cluster?.Endpoints ? {
+          name: clusterName,
+          targetBalancer: new algo.RoundRobinLoadBalancer(
+            shuffle(Object.fromEntries(Object.entries(cluster.Endpoints).map(([k, v]) => [k, v.Weight])))
+          ),
+        } : null
+      )
+    )())
+  ),
+
+  hit = (percentage) => percentage > 0 && Math.random() * 100 < percentage,
+) => pipy({
+  _mirrorCluster: null,
+  _mirrorTarget: null,
+})
+
+.import({
+  __isHTTP2: 'outbound',
+  __route: 'outbound-http-routing',
+  __metricLabel: 'connect-tcp',
+  __target: 'connect-tcp',
+  __timeout: 'connect-tcp',
+})
+
+.pipeline()
+.onStart(
+  () => void (
+    __route?.Mirror && hit(__route.Mirror.Percentage) && (
+      (_mirrorCluster = mirrorClusterCache.get(__route.Mirror.ClusterName)) && (
+        _mirrorTarget = _mirrorCluster.targetBalancer.next()?.id
+      )
+    )
+  )
+)
+.branch(
+  () => _mirrorTarget, (
+    $=>$
+    .fork().to(
+      $=>$
+      .handleStreamStart(
+        () => (
+          mirrorCounter.withLabels(_mirrorCluster.name).increase(),
+          __target = _mirrorTarget,
+          __metricLabel = _mirrorCluster.name,
+          __timeout = null
+        )
+      )
+      .muxHTTP(() => _mirrorTarget, () => ({ version: __isHTTP2 ? 2 : 1 })).to(
+        $=>$.use('connect-upstream.js')
+      )
+      .dummy()
+    )
+    .chain()
+  ), (
+    $=>$.chain()
+  )
+)
+
+)()
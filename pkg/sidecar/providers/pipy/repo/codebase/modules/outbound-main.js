@@ -81,7 +81,10 @@
       'modules/outbound-metrics-http.js',
       'modules/outbound-tracing-http.js',
       'modules/outbound-logging-http.js',
+      'modules/outbound-fault-injection.js',
+      'modules/outbound-global-rate-limit.js',
       'modules/outbound-circuit-breaker.js',
+      'modules/outbound-http-mirror.js',
       'modules/outbound-http-load-balancing.js',
       'modules/outbound-http-default.js',
     ]*/
@@ -3,10 +3,23 @@
   specEnableEgress = config?.Spec?.Traffic?.EnableEgress,
   isDebugEnabled = config?.Spec?.SidecarLogLevel === 'debug',
 
-  targetBalancers = new algo.Cache(target => new algo.RoundRobinLoadBalancer(
+  {
+    isHashing,
+    makeLoadBalancer,
+  } = pipy.solve('load-balancer.js'),
+
+  targetBalancers = new algo.Cache(target => makeLoadBalancer(
+    target?.LoadBalancer?.Type,
     Object.fromEntries(Object.entries(target?.Endpoints || {}).map(([k, v]) => [k, v.Weight || 100]))
   )),
-) => pipy()
+
+  // Only the source IP address can be hashed for TCP connections
+  getHashKey = target => (
+    isHashing(target?.LoadBalancer?.Type) && target.LoadBalancer.HashKeys?.some?.(k => k.SourceIP) ? __inbound.remoteAddress : undefined
+  ),
+) => pipy({
+  _targetBalancer: null,
+})
 
 .import({
   __port: 'outbound',
@@ -20,7 +33,7 @@
 .pipeline()
 .handleStreamStart(
   () => (
-    __target = __cluster && targetBalancers.get(__cluster)?.next?.()?.id,
+    __target = __cluster && (_targetBalancer = targetBalancers.get(__cluster))?.next?.(getHashKey(__cluster))?.id,
     !__target && (specEnableEgress || __port?.TcpServiceRouteRules?.AllowedEgressTraffic) && (
       __target = __inbound.destinationAddress + ':' + __inbound.destinationPort,
       __cluster = {name: __target},
@@ -53,5 +66,10 @@
     $=>$.use('connect-upstream.js')
   )
 )
+.handleStreamEnd(
+  () => (
+    _targetBalancer?.free && __target && _targetBalancer.free(__target)
+  )
+)
 
 )()
\ No newline at end of file
@@ -67,6 +67,18 @@ pipy({
           new Message(out.join('\n'))
         )
       ),
+    () => (_statsPath === '/stats/prometheus'), $ => $
+      .muxHTTP(() => prometheusTarget).to($ => $
+        .connect(() => prometheusTarget)
+      ),
+    () => (_statsPath === '/plugins'), $ => $
+      .replaceMessage(
+        () => new Message(JSON.stringify(config?.Chains || {}, null, 2))
+      ),
+    () => (_statsPath === '/version'), $ => $
+      .replaceMessage(
+        () => new Message(JSON.stringify({ Version: config?.Version || '', Ts: config?.Ts || '' }, null, 2))
+      ),
     () => (_statsPath === '/listeners'), $ => $
       .replaceMessage(
         (msg) => (
@@ -0,0 +1,31 @@
+package repo
+
+import (
+	"github.com/openservicemesh/osm/pkg/sidecar/providers/pipy"
+)
+
+// GenerateSidecarConf returns the PipyConf the controller currently computes for the given proxy, without publishing
+// it to the repo. Unlike the published config, no certificate is issued or rotated and no plugin is published: the
+// certificate of the config is the current sidecar certificate of the proxy, without its private key. The config
+// carries no version nor timestamp.
+func (s *Server) GenerateSidecarConf(proxy *pipy.Proxy) (*PipyConf, error) {
+	proxy.Mutex.Lock()
+	defer proxy.Mutex.Unlock()
+
+	pipyConf, _, err := s.generateSidecarConf(proxy, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if proxy.SidecarCert != nil {
+		pipyConf.Certificate = &Certificate{
+			CommonName: &proxy.SidecarCert.CommonName,
+			Expiration: proxy.SidecarCert.Expiration.Format("2006-01-02 15:04:05"),
+			CertChain:  string(proxy.SidecarCert.CertChain),
+			IssuingCA:  string(proxy.SidecarCert.IssuingCA),
+			TrustedCAs: splitPEMCertificates(string(proxy.SidecarCert.GetTrustedCAs())),
+		}
+	}
+
+	return pipyConf, nil
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"time"
 
+	mapset "github.com/deckarep/golang-set"
+
 	"github.com/openservicemesh/osm/pkg/catalog"
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/errcode"
@@ -42,11 +44,23 @@ func (job *PipyConfGeneratorJob) Run() {
 	proxy.Mutex.Lock()
 	defer proxy.Mutex.Unlock()
 
+	pipyConf, pluginSetV, err := s.generateSidecarConf(proxy, true)
+	if err != nil {
+		return
+	}
+	job.publishSidecarConf(s.repoClient, proxy, pipyConf, pluginSetV)
+}
+
+// generateSidecarConf computes the PipyConf of the given proxy along with the version of the plugin set it uses.
+// The sidecar certificate of the proxy is only issued or rotated, and the plugins only published to the repo, when
+// publish is true: otherwise the repo server and the proxy are left untouched.
+// The caller must hold the lock of the proxy.
+func (s *Server) generateSidecarConf(proxy *pipy.Proxy, publish bool) (*PipyConf, string, error) {
 	proxyServices, err := s.proxyRegistry.ListProxyServices(proxy)
 	if err != nil {
 		log.Warn().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrFetchingServiceList)).
 			Msgf("Error looking up services for Sidecar with name=%s", proxy.GetName())
-		return
+		return nil, "", err
 	}
 
 	cataloger := s.catalog
@@ -54,8 +68,10 @@ func (job *PipyConfGeneratorJob) Run() {
 
 	probes(proxy, pipyConf)
 	features(s, proxy, pipyConf)
-	certs(s, proxy, pipyConf, proxyServices)
-	pluginSetV := plugin(cataloger, s, pipyConf, proxy)
+	if publish {
+		certs(s, proxy, pipyConf, proxyServices)
+	}
+	pluginSetV := plugin(cataloger, s, pipyConf, proxy, publish)
 	inbound(cataloger, proxy.Identity, s, pipyConf, proxyServices)
 	outbound(cataloger, proxy.Identity, s, pipyConf, proxy)
 	egress(cataloger, proxy.Identity, s, pipyConf, proxy)
@@ -63,7 +79,7 @@ func (job *PipyConfGeneratorJob) Run() {
 	balance(pipyConf)
 	reorder(pipyConf)
 	endpoints(pipyConf, s)
-	job.publishSidecarConf(s.repoClient, proxy, pipyConf, pluginSetV)
+	return pipyConf, pluginSetV, nil
 }
 
 func endpoints(pipyConf *PipyConf, s *Server) {
@@ -192,7 +208,7 @@ func inbound(cataloger catalog.MeshCataloger, serviceIdentity identity.ServiceId
 	}
 }
 
-func plugin(cataloger catalog.MeshCataloger, s *Server, pipyConf *PipyConf, proxy *pipy.Proxy) (pluginSetVersion string) {
+func plugin(cataloger catalog.MeshCataloger, s *Server, pipyConf *PipyConf, proxy *pipy.Proxy, publish bool) (pluginSetVersion string) {
 	pipyConf.Chains = nil
 
 	defer func() {
@@ -221,7 +237,13 @@ func plugin(cataloger catalog.MeshCataloger, s *Server, pipyConf *PipyConf, prox
 		log.Warn().Str("proxy", proxy.String()).Str("namespace", pod.Namespace).Msg("Could not find namespace for connecting proxy.")
 	}
 
-	pluginSet, pluginPri := s.updatePlugins()
+	var pluginSet mapset.Set
+	var pluginPri map[string]float32
+	if publish {
+		pluginSet, pluginPri = s.updatePlugins()
+	} else {
+		pluginSet, pluginPri, _, _ = s.listPlugins()
+	}
 	plugin2MountPoint2Config, mountPoint2Plugins := walkPluginChain(pluginChains, ns, pod, pluginSet, s, proxy)
 	meshSvc2Plugin2MountPoint2Config := walkPluginConfig(cataloger, plugin2MountPoint2Config)
 
@@ -262,7 +284,8 @@ func certs(s *Server, proxy *pipy.Proxy, pipyConf *PipyConf, proxyServices []ser
 
 				sidecarCert, certErr := s.certManager.IssueCertificate(cnPrefix, certificate.Service,
 					certificate.SubjectAlternativeNames(sans...),
-					certificate.ValidityDurationProvided(&certValidityPeriod))
+					certificate.ValidityDurationProvided(&certValidityPeriod),
+					certificate.RequestedByProxy(proxy.UUID.String()))
 				if certErr != nil {
 					proxy.SidecarCert = nil
 				} else {
@@ -285,6 +308,7 @@ func features(s *Server, proxy *pipy.Proxy, pipyConf *PipyConf) {
 		pipyConf.setEnableEgress((*meshConf).IsEgressEnabled())
 		pipyConf.setEnablePermissiveTrafficPolicyMode((*meshConf).IsPermissiveTrafficPolicyMode())
 		pipyConf.setLocalDNSProxy((*meshConf).IsLocalDNSProxyEnabled(), (*meshConf).GetLocalDNSProxyPrimaryUpstream(), (*meshConf).GetLocalDNSProxySecondaryUpstream())
+		pipyConf.setGlobalRateLimit((*meshConf).GetGlobalRateLimitConfig())
 		clusterProps := (*meshConf).GetMeshConfig().Spec.ClusterSet.Properties
 		if len(clusterProps) > 0 {
 			pipyConf.Spec.ClusterSet = make(map[string]string)
@@ -322,6 +346,7 @@ func (job *PipyConfGeneratorJob) publishSidecarConf(repoClient *client.PipyRepoC
 	if proxy.SidecarCert != nil {
 		pipyConf.Certificate = &Certificate{
 			Expiration: proxy.SidecarCert.Expiration.Format("2006-01-02 15:04:05"),
+			TrustedCAs: splitPEMCertificates(string(proxy.SidecarCert.GetTrustedCAs())),
 		}
 	}
 	bytes, jsonErr := json.Marshal(pipyConf)
@@ -367,7 +392,18 @@ func (job *PipyConfGeneratorJob) publishSidecarConf(repoClient *client.PipyRepoC
 				_, _ = repoClient.Delete(codebase)
 			} else {
 				proxy.ETag = codebaseCurV
+				proxy.SetPublishedSidecarCert(&pipy.PublishedSidecarCert{
+					Cert:      proxy.SidecarCert,
+					Version:   fmt.Sprintf("%d", codebaseCurV),
+					StatsAddr: proxy.GetAddr(),
+				})
 			}
+		} else {
+			proxy.SetPublishedSidecarCert(&pipy.PublishedSidecarCert{
+				Cert:      proxy.SidecarCert,
+				Version:   fmt.Sprintf("%d", codebaseCurV),
+				StatsAddr: proxy.GetAddr(),
+			})
 		}
 	}
 }
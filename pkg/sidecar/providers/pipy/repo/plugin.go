@@ -22,23 +22,7 @@ import (
 )
 
 func (s *Server) updatePlugins() (pluginSet mapset.Set, pluginPri map[string]float32) {
-	var pluginItems []client.BatchItem
-	var pluginVers []string
-	pluginSet = mapset.NewSet()
-	pluginPri = make(map[string]float32)
-
-	plugins := s.catalog.GetPlugins()
-	for _, pluginItem := range plugins {
-		uri := getPluginURI(pluginItem.Name)
-		bytes := []byte(pluginItem.Script)
-		pluginSet.Add(pluginItem.Name)
-		pluginPri[pluginItem.Name] = pluginItem.Priority
-		pluginItems = append(pluginItems, client.BatchItem{
-			Filename: uri,
-			Content:  bytes,
-		})
-		pluginVers = append(pluginVers, fmt.Sprintf("%s:%f:%d", uri, pluginItem.Priority, hash(bytes)))
-	}
+	pluginSet, pluginPri, pluginItems, pluginVers := s.listPlugins()
 
 	diffSet := s.pluginSet.Difference(pluginSet)
 	diffPlugins := diffSet.ToSlice()
@@ -76,6 +60,27 @@ func (s *Server) updatePlugins() (pluginSet mapset.Set, pluginPri map[string]flo
 	return
 }
 
+// listPlugins returns the names and the priorities of the plugins of the mesh, along with the repo items of their
+// scripts and their versions, without publishing them to the repo.
+func (s *Server) listPlugins() (pluginSet mapset.Set, pluginPri map[string]float32, pluginItems []client.BatchItem, pluginVers []string) {
+	pluginSet = mapset.NewSet()
+	pluginPri = make(map[string]float32)
+
+	plugins := s.catalog.GetPlugins()
+	for _, pluginItem := range plugins {
+		uri := getPluginURI(pluginItem.Name)
+		bytes := []byte(pluginItem.Script)
+		pluginSet.Add(pluginItem.Name)
+		pluginPri[pluginItem.Name] = pluginItem.Priority
+		pluginItems = append(pluginItems, client.BatchItem{
+			Filename: uri,
+			Content:  bytes,
+		})
+		pluginVers = append(pluginVers, fmt.Sprintf("%s:%f:%d", uri, pluginItem.Priority, hash(bytes)))
+	}
+	return
+}
+
 // getPluginURI return the URI of the plugin.
 func getPluginURI(name string) string {
 	return fmt.Sprintf("plugins/%s.js", name)
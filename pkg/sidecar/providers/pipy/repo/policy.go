@@ -1,20 +1,30 @@
 package repo
 
 import (
+	"encoding/json"
+	"encoding/pem"
 	"fmt"
+	"net"
 	"reflect"
 	"regexp"
 	"sort"
 	"strings"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 
 	multiclusterv1alpha1 "github.com/openservicemesh/osm/pkg/apis/multicluster/v1alpha1"
 	policyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/identity"
 	"github.com/openservicemesh/osm/pkg/k8s"
+	"github.com/openservicemesh/osm/pkg/ratelimit"
+	"github.com/openservicemesh/osm/pkg/service"
 	"github.com/openservicemesh/osm/pkg/sidecar/providers/pipy/registry"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
 	"github.com/openservicemesh/osm/pkg/utils/cidr"
 )
 
@@ -22,6 +32,20 @@ var (
 	addrWithPort, _ = regexp.Compile(`:\d+$`)
 )
 
+// hasPort returns true if the given address already carries a port. Bare IPv6
+// addresses such as 'fd00::1' are not mistaken for an address with a port.
+func hasPort(address Address) bool {
+	if net.ParseIP(string(address)) != nil {
+		return false
+	}
+	return addrWithPort.MatchString(string(address))
+}
+
+// joinHostPort combines the given address and port, enclosing IPv6 addresses in brackets
+func joinHostPort(address Address, port Port) string {
+	return net.JoinHostPort(string(address), fmt.Sprintf("%d", port))
+}
+
 func (plugin *Pluggable) setPlugins(plugins map[string]*runtime.RawExtension) {
 	plugin.Plugins = plugins
 }
@@ -50,6 +74,37 @@ func (p *PipyConf) setLocalDNSProxy(enable bool, primary, secondary string) {
 	}
 }
 
+// splitPEMCertificates returns each PEM encoded certificate of the bundle as a separate entry
+func splitPEMCertificates(bundle string) []string {
+	var certs []string
+	rest := []byte(bundle)
+	for {
+		var block *pem.Block
+		if block, rest = pem.Decode(rest); block == nil {
+			break
+		}
+		if block.Type != certificate.TypeCertificate {
+			continue
+		}
+		certs = append(certs, string(pem.EncodeToMemory(block)))
+	}
+	return certs
+}
+
+func (p *PipyConf) setGlobalRateLimit(rateLimitConfig ratelimit.GlobalRateLimitConfig) {
+	if !rateLimitConfig.Enable {
+		p.Spec.GlobalRateLimit = nil
+		return
+	}
+	p.Spec.GlobalRateLimit = &GlobalRateLimitService{
+		Address:          rateLimitConfig.Address,
+		Port:             rateLimitConfig.Port,
+		Domain:           rateLimitConfig.Domain,
+		Timeout:          rateLimitConfig.Timeout.Seconds(),
+		FailureModeAllow: rateLimitConfig.FailureModeAllow,
+	}
+}
+
 func (p *PipyConf) setEnableSidecarActiveHealthChecks(enableSidecarActiveHealthChecks bool) (update bool) {
 	if update = p.Spec.FeatureFlags.EnableSidecarActiveHealthChecks != enableSidecarActiveHealthChecks; update {
 		p.Spec.FeatureFlags.EnableSidecarActiveHealthChecks = enableSidecarActiveHealthChecks
@@ -171,6 +226,7 @@ func (p *PipyConf) rebalancedForwardClusters() {
 func (p *PipyConf) copyAllowedEndpoints(kubeController k8s.Controller, proxyRegistry *registry.ProxyRegistry) bool {
 	ready := true
 	p.AllowedEndpoints = make(map[string]string)
+	connectedPods := make(map[types.NamespacedName]*corev1.Pod)
 	allPods := kubeController.ListPods()
 	for _, pod := range allPods {
 		proxyUUID, err := GetProxyUUIDFromPod(pod)
@@ -186,7 +242,9 @@ func (p *PipyConf) copyAllowedEndpoints(kubeController k8s.Controller, proxyRegi
 		if len(proxy.GetAddr()) == 0 {
 			ready = false
 		}
+		connectedPods[types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}] = pod
 	}
+	p.copyAllowedEndpointSliceAddresses(kubeController, connectedPods)
 	if p.Inbound == nil {
 		return ready
 	}
@@ -205,6 +263,63 @@ func (p *PipyConf) copyAllowedEndpoints(kubeController k8s.Controller, proxyRegi
 	return ready
 }
 
+// copyAllowedEndpointSliceAddresses allows every address published in EndpointSlices for the given
+// connected pods, so that all the addresses of dual-stack pods are allowed and not only the one
+// the proxy connected with. Only the EndpointSlices of the services selecting a connected pod are
+// looked up, from the service index of the EndpointSlice informer.
+func (p *PipyConf) copyAllowedEndpointSliceAddresses(kubeController k8s.Controller, connectedPods map[types.NamespacedName]*corev1.Pod) {
+	if len(connectedPods) == 0 {
+		return
+	}
+	podsByNamespace := make(map[string][]*corev1.Pod)
+	for _, pod := range connectedPods {
+		podsByNamespace[pod.Namespace] = append(podsByNamespace[pod.Namespace], pod)
+	}
+	for _, svc := range kubeController.ListServices() {
+		if !selectsAnyPod(svc, podsByNamespace[svc.Namespace]) {
+			continue
+		}
+		meshSvc := service.MeshService{Namespace: svc.Namespace, Name: svc.Name}
+		endpointSlices, err := kubeController.ListEndpointSlicesForService(meshSvc)
+		if err != nil {
+			continue
+		}
+		for _, eps := range endpointSlices {
+			for _, ep := range eps.Endpoints {
+				if ep.TargetRef == nil || ep.TargetRef.Kind != "Pod" {
+					continue
+				}
+				podKey := types.NamespacedName{Namespace: ep.TargetRef.Namespace, Name: ep.TargetRef.Name}
+				if podKey.Namespace == "" {
+					podKey.Namespace = eps.Namespace
+				}
+				if _, connected := connectedPods[podKey]; !connected {
+					continue
+				}
+				for _, address := range ep.Addresses {
+					if _, exists := p.AllowedEndpoints[address]; !exists {
+						p.AllowedEndpoints[address] = fmt.Sprintf("%s.%s", podKey.Namespace, podKey.Name)
+					}
+				}
+			}
+		}
+	}
+}
+
+// selectsAnyPod returns true if the selector of the given service selects one of the given pods
+func selectsAnyPod(svc *corev1.Service, pods []*corev1.Pod) bool {
+	if len(svc.Spec.Selector) == 0 {
+		return false
+	}
+	selector := labels.Set(svc.Spec.Selector).AsSelector()
+	for _, pod := range pods {
+		if selector.Matches(labels.Set(pod.Labels)) {
+			return true
+		}
+	}
+	return false
+}
+
 func (itm *InboundTrafficMatch) addSourceIPRange(ipRange SourceIPRange, sourceSpec *SourceSecuritySpec) {
 	if itm.SourceIPRanges == nil {
 		itm.SourceIPRanges = make(map[SourceIPRange]*SourceSecuritySpec)
@@ -392,6 +507,50 @@ func (hrrs *InboundHTTPRouteRules) setHTTPServiceRateLimit(rateLimit *policyv1al
 	}
 }
 
+func (hrrs *InboundHTTPRouteRules) setRequestAuthentication(requestAuthn *trafficpolicy.RequestAuthentication) {
+	if requestAuthn == nil {
+		hrrs.RequestAuthentication = nil
+		return
+	}
+
+	hrrs.RequestAuthentication = &HTTPRequestAuthentication{
+		Providers: make([]*JWTProvider, 0, len(requestAuthn.Providers)),
+	}
+	for _, provider := range requestAuthn.Providers {
+		jwks := struct {
+			Keys []map[string]interface{} `json:"keys"`
+		}{}
+		if err := json.Unmarshal([]byte(provider.JWKS), &jwks); err != nil {
+			log.Error().Err(err).Msgf("Invalid JWKS for JWT provider %s, ignoring it", provider.Name)
+			continue
+		}
+
+		jwtProvider := &JWTProvider{
+			Issuer:               provider.Issuer,
+			Audiences:            provider.Audiences,
+			Keys:                 jwks.Keys,
+			ForwardOriginalToken: provider.ForwardOriginalToken,
+		}
+		for _, claimToHeader := range provider.ClaimToHeaders {
+			if jwtProvider.ClaimToHeaders == nil {
+				jwtProvider.ClaimToHeaders = make(map[string]string)
+			}
+			jwtProvider.ClaimToHeaders[claimToHeader.Claim] = claimToHeader.Header
+		}
+		hrrs.RequestAuthentication.Providers = append(hrrs.RequestAuthentication.Providers, jwtProvider)
+	}
+
+	for _, rule := range requestAuthn.Rules {
+		jwtRule := &JWTAuthorizationRule{
+			Claims: make(map[string][]string),
+		}
+		for _, claim := range rule.Claims {
+			jwtRule.Claims[claim.Name] = claim.Values
+		}
+		hrrs.RequestAuthentication.Rules = append(hrrs.RequestAuthentication.Rules, jwtRule)
+	}
+}
+
 func (hrrs *InboundHTTPRouteRules) addAllowedEndpoint(address Address, serviceName ServiceName) {
 	if hrrs.AllowedEndpoints == nil {
 		hrrs.AllowedEndpoints = make(AllowedEndpoints)
@@ -431,6 +590,68 @@ func (hrrs *OutboundHTTPRouteRules) newHTTPServiceRouteRule(matchRule *HTTPMatch
 	return routeRule, false
 }
 
+func (hrr *OutboundHTTPRouteRule) setTimeout(timeout *policyv1alpha1.HTTPRouteTimeoutSpec) {
+	if timeout == nil || (timeout.Request == nil && timeout.Idle == nil) {
+		hrr.Timeout = nil
+		return
+	}
+	hrr.Timeout = new(HTTPRouteTimeout)
+	if timeout.Request != nil {
+		request := timeout.Request.Seconds()
+		hrr.Timeout.Request = &request
+	}
+	if timeout.Idle != nil {
+		idle := timeout.Idle.Seconds()
+		hrr.Timeout.Idle = &idle
+	}
+}
+
+func (hrr *OutboundHTTPRouteRule) setFaultInjection(fault *policyv1alpha1.HTTPFaultInjectionRouteSpec) {
+	if fault == nil || (fault.Delay == nil && fault.Abort == nil) {
+		hrr.Fault = nil
+		return
+	}
+	hrr.Fault = new(HTTPFaultInjection)
+	if fault.Delay != nil {
+		hrr.Fault.Delay = &HTTPFaultDelay{
+			Percentage: fault.Delay.Percentage,
+			FixedDelay: fault.Delay.FixedDelay.Seconds(),
+		}
+	}
+	if fault.Abort != nil {
+		hrr.Fault.Abort = &HTTPFaultAbort{
+			Percentage: fault.Abort.Percentage,
+			HTTPStatus: fault.Abort.HTTPStatus,
+		}
+	}
+}
+
+func (hrr *OutboundHTTPRouteRule) setMirror(mirror *trafficpolicy.MirrorPolicy) {
+	if mirror == nil {
+		hrr.Mirror = nil
+		return
+	}
+	hrr.Mirror = &HTTPRouteMirror{
+		ClusterName: ClusterName(mirror.ClusterName),
+		Percentage:  mirror.Percentage,
+	}
+}
+
+func (hrr *OutboundHTTPRouteRule) setRateLimitDescriptors(descriptors []trafficpolicy.RateLimitDescriptor) {
+	hrr.RateLimitDescriptors = nil
+	for _, descriptor := range descriptors {
+		var entries RateLimitDescriptor
+		for _, entry := range descriptor {
+			entries = append(entries, RateLimitDescriptorEntry{
+				Key:    entry.Key,
+				Value:  entry.Value,
+				Header: entry.Header,
+			})
+		}
+		hrr.RateLimitDescriptors = append(hrr.RateLimitDescriptors, entries)
+	}
+}
+
 func (hrrs *OutboundHTTPRouteRules) setEgressForwardGateway(egresssGateway *string) {
 	hrrs.EgressForwardGateway = egresssGateway
 }
@@ -524,13 +745,13 @@ func (otp *ClusterConfigs) addWeightedZoneEndpoint(address Address, port Port, w
 }
 
 func (wes *WeightedEndpoints) addWeightedEndpoint(address Address, port Port, weight Weight) {
-	if addrWithPort.MatchString(string(address)) {
+	if hasPort(address) {
 		httpHostPort := HTTPHostPort(address)
 		(*wes)[httpHostPort] = &WeightedZoneEndpoint{
 			Weight: weight,
 		}
 	} else {
-		httpHostPort := HTTPHostPort(fmt.Sprintf("%s:%d", address, port))
+		httpHostPort := HTTPHostPort(joinHostPort(address, port))
 		(*wes)[httpHostPort] = &WeightedZoneEndpoint{
 			Weight: weight,
 		}
@@ -538,7 +759,7 @@ func (wes *WeightedEndpoints) addWeightedEndpoint(address Address, port Port, we
 }
 
 func (wes *WeightedEndpoints) addWeightedZoneEndpoint(address Address, port Port, weight Weight, cluster, lbType, contextPath string) {
-	if addrWithPort.MatchString(string(address)) {
+	if hasPort(address) {
 		httpHostPort := HTTPHostPort(address)
 		(*wes)[httpHostPort] = &WeightedZoneEndpoint{
 			Weight:      weight,
@@ -547,7 +768,7 @@ func (wes *WeightedEndpoints) addWeightedZoneEndpoint(address Address, port Port
 			ContextPath: contextPath,
 		}
 	} else {
-		httpHostPort := HTTPHostPort(fmt.Sprintf("%s:%d", address, port))
+		httpHostPort := HTTPHostPort(joinHostPort(address, port))
 		(*wes)[httpHostPort] = &WeightedZoneEndpoint{
 			Weight:      weight,
 			Cluster:     cluster,
@@ -558,11 +779,11 @@ func (wes *WeightedEndpoints) addWeightedZoneEndpoint(address Address, port Port
 }
 
 func (we *WeightedEndpoint) addWeightedEndpoint(address Address, port Port, weight Weight) {
-	if addrWithPort.MatchString(string(address)) {
+	if hasPort(address) {
 		httpHostPort := HTTPHostPort(address)
 		(*we)[httpHostPort] = weight
 	} else {
-		httpHostPort := HTTPHostPort(fmt.Sprintf("%s:%d", address, port))
+		httpHostPort := HTTPHostPort(joinHostPort(address, port))
 		(*we)[httpHostPort] = weight
 	}
 }
@@ -612,6 +833,59 @@ func (otp *ClusterConfigs) setConnectionSettings(connectionSettings *policyv1alp
 	}
 }
 
+func (otp *ClusterConfigs) setOutlierDetection(outlierDetection *policyv1alpha1.OutlierDetectionSpec) {
+	if outlierDetection == nil {
+		otp.OutlierDetection = nil
+		return
+	}
+	otp.OutlierDetection = &OutlierDetection{
+		ConsecutiveErrors:  defaultOutlierDetectionConsecutiveErrors,
+		Interval:           defaultOutlierDetectionInterval.Seconds(),
+		BaseEjectionTime:   defaultOutlierDetectionBaseEjectionTime.Seconds(),
+		MaxEjectionPercent: defaultOutlierDetectionMaxEjectionPercent,
+	}
+	if outlierDetection.ConsecutiveErrors != nil {
+		otp.OutlierDetection.ConsecutiveErrors = *outlierDetection.ConsecutiveErrors
+	}
+	if outlierDetection.Interval != nil {
+		otp.OutlierDetection.Interval = outlierDetection.Interval.Seconds()
+	}
+	if outlierDetection.BaseEjectionTime != nil {
+		otp.OutlierDetection.BaseEjectionTime = outlierDetection.BaseEjectionTime.Seconds()
+	}
+	if outlierDetection.MaxEjectionPercent != nil {
+		otp.OutlierDetection.MaxEjectionPercent = *outlierDetection.MaxEjectionPercent
+	}
+}
+
+func (otp *ClusterConfigs) setLoadBalancer(loadBalancer *policyv1alpha1.LoadBalancerSpec) {
+	if loadBalancer == nil {
+		otp.LoadBalancer = nil
+		return
+	}
+	otp.LoadBalancer = &LoadBalancer{
+		Type: loadBalancer.Type,
+	}
+	if len(otp.LoadBalancer.Type) == 0 {
+		otp.LoadBalancer.Type = policyv1alpha1.LoadBalancerRoundRobin
+	}
+	for _, hashKey := range loadBalancer.HashKeys {
+		key := LoadBalancerHashKey{
+			Header:   hashKey.Header,
+			SourceIP: hashKey.SourceIP,
+		}
+		if hashKey.Cookie != nil {
+			key.Cookie = &LoadBalancerCookie{
+				Name: hashKey.Cookie.Name,
+			}
+			if hashKey.Cookie.TTL != nil {
+				key.Cookie.TTL = hashKey.Cookie.TTL.Seconds()
+			}
+		}
+		otp.LoadBalancer.HashKeys = append(otp.LoadBalancer.HashKeys, key)
+	}
+}
+
 func (otp *ClusterConfigs) setRetryPolicy(retryPolicy *policyv1alpha1.RetryPolicySpec) {
 	if retryPolicy == nil {
 		otp.RetryPolicy = nil
@@ -27,6 +27,20 @@ var (
 	log = logger.New("flomesh-pipy")
 )
 
+const (
+	// defaultOutlierDetectionConsecutiveErrors is the default number of consecutive errors after which an endpoint is ejected
+	defaultOutlierDetectionConsecutiveErrors uint32 = 5
+
+	// defaultOutlierDetectionInterval is the default time interval between ejection sweep analysis
+	defaultOutlierDetectionInterval = 10 * time.Second
+
+	// defaultOutlierDetectionBaseEjectionTime is the default base time an endpoint is ejected for
+	defaultOutlierDetectionBaseEjectionTime = 30 * time.Second
+
+	// defaultOutlierDetectionMaxEjectionPercent is the default maximum percentage of endpoints that can be ejected
+	defaultOutlierDetectionMaxEjectionPercent uint32 = 10
+)
+
 // Server implements the Aggregate Discovery Services
 type Server struct {
 	catalog        catalog.MeshCataloger
@@ -219,6 +233,26 @@ type MeshConfigSpec struct {
 	}
 	ClusterSet    map[string]string
 	LocalDNSProxy *LocalDNSProxy `json:"LocalDNSProxy,omitempty"`
+	// GlobalRateLimit is the external rate limit service outbound HTTP requests are checked against
+	GlobalRateLimit *GlobalRateLimitService `json:"GlobalRateLimit,omitempty"`
+}
+
+// GlobalRateLimitService is the type to represent the external rate limit service speaking the Envoy RLS protocol.
+type GlobalRateLimitService struct {
+	// Address defines the host of the rate limit service.
+	Address string `json:"Address"`
+
+	// Port defines the gRPC port of the rate limit service.
+	Port uint16 `json:"Port"`
+
+	// Domain defines the domain sent with each request to the rate limit service.
+	Domain string `json:"Domain"`
+
+	// Timeout defines the timeout in seconds for requests to the rate limit service.
+	Timeout float64 `json:"Timeout"`
+
+	// FailureModeAllow defines whether requests are allowed when the rate limit service is unavailable.
+	FailureModeAllow bool `json:"FailureModeAllow"`
 }
 
 // Certificate represents an x509 certificate.
@@ -241,6 +275,10 @@ type Certificate struct {
 
 	// Certificate authority signing this certificate
 	IssuingCA string
+
+	// PEM encoded root certificates trusted by the proxy, each as a separate entry.
+	// During a root certificate rotation it holds both the old and the new root.
+	TrustedCAs []string `json:"TrustedCAs,omitempty"`
 }
 
 // RetryPolicy is the type used to represent the retry policy specified in the Retry policy specification.
@@ -280,8 +318,9 @@ type InboundHTTPRouteRuleSlice []*InboundHTTPRouteRule
 type InboundHTTPRouteRules struct {
 	RouteRules InboundHTTPRouteRuleSlice `json:"RouteRules"`
 	Pluggable
-	HTTPRateLimit    *HTTPRateLimit   `json:"RateLimit"`
-	AllowedEndpoints AllowedEndpoints `json:"AllowedEndpoints"`
+	HTTPRateLimit         *HTTPRateLimit             `json:"RateLimit"`
+	AllowedEndpoints      AllowedEndpoints           `json:"AllowedEndpoints"`
+	RequestAuthentication *HTTPRequestAuthentication `json:"RequestAuthentication,omitempty"`
 }
 
 // InboundHTTPServiceRouteRules is a wrapper type of map[HTTPRouteRuleName]*InboundHTTPRouteRules
@@ -310,6 +349,114 @@ type InboundTrafficMatches map[Port]*InboundTrafficMatch
 // OutboundHTTPRouteRule http route rule
 type OutboundHTTPRouteRule struct {
 	HTTPRouteRule
+	Timeout *HTTPRouteTimeout   `json:"Timeout,omitempty"`
+	Fault   *HTTPFaultInjection `json:"Fault,omitempty"`
+	Mirror  *HTTPRouteMirror    `json:"Mirror,omitempty"`
+
+	RateLimitDescriptors []RateLimitDescriptor `json:"RateLimitDescriptors,omitempty"`
+}
+
+// RateLimitDescriptor is the type used to represent a descriptor sent to the global rate limit service.
+type RateLimitDescriptor []RateLimitDescriptorEntry
+
+// RateLimitDescriptorEntry is the type used to represent an entry of a rate limit descriptor.
+type RateLimitDescriptorEntry struct {
+	// Key defines the key of the descriptor entry.
+	Key string `json:"Key"`
+
+	// Value defines the static value of the descriptor entry.
+	// +optional
+	Value string `json:"Value,omitempty"`
+
+	// Header defines the request header the value of the descriptor entry is read from.
+	// +optional
+	Header string `json:"Header,omitempty"`
+}
+
+// HTTPRequestAuthentication is the type used to represent the JWT authentication of inbound HTTP requests.
+type HTTPRequestAuthentication struct {
+	// Providers defines the list of JWT issuers trusted by the service.
+	// Requests are rejected if no provider is specified.
+	Providers []*JWTProvider `json:"Providers"`
+
+	// Rules defines the list of authorization rules matched against the claims of a valid JWT.
+	// +optional
+	Rules []*JWTAuthorizationRule `json:"Rules,omitempty"`
+}
+
+// JWTProvider is the type used to represent a JWT issuer trusted by a service.
+type JWTProvider struct {
+	// Issuer defines the issuer of the JWT.
+	Issuer string `json:"Issuer"`
+
+	// Audiences defines the list of audiences allowed to access the service.
+	// +optional
+	Audiences []string `json:"Audiences,omitempty"`
+
+	// Keys defines the JSON Web Keys used to verify the signature of the JWT.
+	Keys []map[string]interface{} `json:"Keys"`
+
+	// ForwardOriginalToken defines whether the JWT is forwarded to the service.
+	ForwardOriginalToken bool `json:"ForwardOriginalToken"`
+
+	// ClaimToHeaders defines the request headers claims of the JWT are copied to, keyed by claim name.
+	// +optional
+	ClaimToHeaders map[string]string `json:"ClaimToHeaders,omitempty"`
+}
+
+// JWTAuthorizationRule is the type used to represent an authorization rule matched against the claims of a JWT.
+type JWTAuthorizationRule struct {
+	// Claims defines the allowed values of the claims the JWT must match, keyed by claim name.
+	Claims map[string][]string `json:"Claims"`
+}
+
+// HTTPRouteMirror is the type used to represent the mirror policy per HTTP route.
+type HTTPRouteMirror struct {
+	// ClusterName defines the cluster requests are mirrored to.
+	ClusterName ClusterName `json:"ClusterName"`
+
+	// Percentage defines the percentage of requests to mirror.
+	Percentage uint32 `json:"Percentage"`
+}
+
+// HTTPFaultInjection is the type used to represent the faults injected per HTTP route.
+type HTTPFaultInjection struct {
+	// Delay defines the delay injected before requests are forwarded upstream.
+	// +optional
+	Delay *HTTPFaultDelay `json:"Delay,omitempty"`
+
+	// Abort defines the error response injected in place of the upstream response.
+	// +optional
+	Abort *HTTPFaultAbort `json:"Abort,omitempty"`
+}
+
+// HTTPFaultDelay is the type used to represent the delay injected per HTTP route.
+type HTTPFaultDelay struct {
+	// Percentage defines the percentage of requests to delay.
+	Percentage uint32 `json:"Percentage"`
+
+	// FixedDelay defines the delay in seconds.
+	FixedDelay float64 `json:"FixedDelay"`
+}
+
+// HTTPFaultAbort is the type used to represent the abort injected per HTTP route.
+type HTTPFaultAbort struct {
+	// Percentage defines the percentage of requests to abort.
+	Percentage uint32 `json:"Percentage"`
+
+	// HTTPStatus defines the HTTP status code returned for aborted requests.
+	HTTPStatus uint32 `json:"HTTPStatus"`
+}
+
+// HTTPRouteTimeout is the type used to represent the timeouts applied per HTTP route.
+type HTTPRouteTimeout struct {
+	// Request defines the timeout in seconds for the entire request.
+	// +optional
+	Request *float64 `json:"Request,omitempty"`
+
+	// Idle defines the timeout in seconds for which the stream of a request may remain idle.
+	// +optional
+	Idle *float64 `json:"Idle,omitempty"`
 }
 
 // OutboundHTTPRouteRuleSlice http route rule array
@@ -374,6 +521,8 @@ type WeightedEndpoints map[HTTPHostPort]*WeightedZoneEndpoint
 type ClusterConfigs struct {
 	Endpoints          *WeightedEndpoints  `json:"Endpoints"`
 	ConnectionSettings *ConnectionSettings `json:"ConnectionSettings,omitempty"`
+	OutlierDetection   *OutlierDetection   `json:"OutlierDetection,omitempty"`
+	LoadBalancer       *LoadBalancer       `json:"LoadBalancer,omitempty"`
 	RetryPolicy        *RetryPolicy        `json:"RetryPolicy,omitempty"`
 	SourceCert         *Certificate        `json:"SourceCert,omitempty"`
 }
@@ -413,6 +562,61 @@ type ConnectionSettings struct {
 	HTTP *HTTPConnectionSettings `json:"http,omitempty"`
 }
 
+// OutlierDetection defines the outlier detection settings for an
+// upstream host.
+type OutlierDetection struct {
+	// ConsecutiveErrors specifies the number of consecutive errors
+	// after which an endpoint is ejected.
+	ConsecutiveErrors uint32 `json:"ConsecutiveErrors"`
+
+	// Interval specifies the time interval in seconds between
+	// ejection sweep analysis.
+	Interval float64 `json:"Interval"`
+
+	// BaseEjectionTime specifies the base time in seconds an
+	// endpoint is ejected for.
+	BaseEjectionTime float64 `json:"BaseEjectionTime"`
+
+	// MaxEjectionPercent specifies the maximum percentage of
+	// endpoints that can be ejected at the same time.
+	MaxEjectionPercent uint32 `json:"MaxEjectionPercent"`
+}
+
+// LoadBalancer defines the load balancing settings for an
+// upstream host.
+type LoadBalancer struct {
+	// Type specifies the load balancing algorithm.
+	Type v1alpha1.LoadBalancerType `json:"Type"`
+
+	// HashKeys specifies the keys hashed to select an endpoint
+	// with the consistent hashing algorithms.
+	HashKeys []LoadBalancerHashKey `json:"HashKeys,omitempty"`
+}
+
+// LoadBalancerHashKey defines a key hashed by the consistent
+// hashing load balancing algorithms.
+type LoadBalancerHashKey struct {
+	// Header specifies the name of the HTTP header whose value is hashed.
+	Header string `json:"Header,omitempty"`
+
+	// Cookie specifies the HTTP cookie whose value is hashed.
+	Cookie *LoadBalancerCookie `json:"Cookie,omitempty"`
+
+	// SourceIP specifies whether the source IP address is hashed.
+	SourceIP bool `json:"SourceIP,omitempty"`
+}
+
+// LoadBalancerCookie defines the HTTP cookie hashed by the
+// consistent hashing load balancing algorithms.
+type LoadBalancerCookie struct {
+	// Name specifies the name of the cookie.
+	Name string `json:"Name"`
+
+	// TTL specifies the lifetime in seconds of the cookie generated
+	// when a request does not carry the cookie.
+	TTL float64 `json:"TTL,omitempty"`
+}
+
 // TCPConnectionSettings defines the TCP connection settings for an
 // upstream host.
 type TCPConnectionSettings struct {
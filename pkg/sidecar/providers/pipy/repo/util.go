@@ -47,6 +47,7 @@ func generatePipyInboundTrafficPolicy(meshCatalog catalog.MeshCataloger, _ ident
 			ruleName := HTTPRouteRuleName(httpRouteConfig.Name)
 			hsrrs := tm.newHTTPServiceRouteRules(ruleName)
 			hsrrs.setHTTPServiceRateLimit(trafficMatch.RateLimit)
+			hsrrs.setRequestAuthentication(trafficMatch.RequestAuthentication)
 			hsrrs.setPlugins(pipyConf.getTrafficMatchPluginConfigs(trafficMatch.Name))
 			for _, hostname := range httpRouteConfig.Hostnames {
 				tm.addHTTPHostPort2Service(HTTPHostPort(hostname), ruleName)
@@ -186,6 +187,24 @@ func generatePipyOutboundTrafficRoutePolicy(_ catalog.MeshCataloger, proxyIdenti
 					}
 
 					hsrr, _ := hsrrs.newHTTPServiceRouteRule(httpMatch)
+					hsrr.setTimeout(route.Timeout)
+					hsrr.setFaultInjection(route.FaultInjection)
+					hsrr.setRateLimitDescriptors(route.RateLimitDescriptors)
+					if route.Mirror != nil {
+						// Requests can only be mirrored to clusters the proxy is configured with
+						if getMeshClusterConfigs(outboundPolicy.ClustersConfigs, route.Mirror.ClusterName) == nil {
+							log.Warn().Msgf("Mirror cluster %s for route %s is not reachable by proxy %s, ignoring mirror policy",
+								route.Mirror.ClusterName, route.HTTPRouteMatch.Path, proxyIdentity)
+						} else {
+							hsrr.setMirror(route.Mirror)
+							if _, exist := dependClusters[route.Mirror.ClusterName]; !exist {
+								mirrorCluster := new(WeightedCluster)
+								mirrorCluster.ClusterName = route.Mirror.ClusterName
+								mirrorCluster.Weight = constants.ClusterWeightAcceptAll
+								dependClusters[mirrorCluster.ClusterName] = mirrorCluster
+							}
+						}
+					}
 					for cluster := range route.WeightedClusters.Iter() {
 						serviceCluster := cluster.(service.WeightedCluster)
 						weightedCluster := new(WeightedCluster)
@@ -406,6 +425,8 @@ func generatePipyOutboundTrafficBalancePolicy(meshCatalog catalog.MeshCataloger,
 				if clusterConfig.UpstreamTrafficSetting.Spec.ConnectionSettings != nil {
 					clusterConfigs.setConnectionSettings(clusterConfig.UpstreamTrafficSetting.Spec.ConnectionSettings)
 				}
+				clusterConfigs.setOutlierDetection(clusterConfig.UpstreamTrafficSetting.Spec.OutlierDetection)
+				clusterConfigs.setLoadBalancer(clusterConfig.UpstreamTrafficSetting.Spec.LoadBalancer)
 			}
 			if cluster.RetryPolicy != nil {
 				clusterConfigs.setRetryPolicy(cluster.RetryPolicy)
@@ -790,6 +811,7 @@ func generatePipyEgressTrafficBalancePolicy(meshCatalog catalog.MeshCataloger, _
 		clusterConfigs.addWeightedEndpoint(address, port, weight)
 		if clusterConfig.UpstreamTrafficSetting != nil {
 			clusterConfigs.setConnectionSettings(clusterConfig.UpstreamTrafficSetting.Spec.ConnectionSettings)
+			clusterConfigs.setOutlierDetection(clusterConfig.UpstreamTrafficSetting.Spec.OutlierDetection)
 		}
 		if clusterConfig.SourceMTLS != nil {
 			clusterConfigs.SourceCert = new(Certificate)
@@ -58,7 +58,7 @@ func bootstrapClient(stop chan struct{}, t *testing.T) (*Client, *fakeKubeClient
 		return nil, nil, err
 	}
 
-	kubernetesClient := k8s.NewKubernetesController(informerCollection, nil, nil, msgBroker)
+	kubernetesClient := k8s.NewKubernetesController(informerCollection, nil, nil, nil, msgBroker)
 
 	fakeClientSet := &fakeKubeClientSet{
 		kubeClient:                kubeClient,
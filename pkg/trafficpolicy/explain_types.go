@@ -0,0 +1,135 @@
+package trafficpolicy
+
+import policyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+
+// ExplainVerdict is the verdict of a mechanism of the mesh on traffic between two pods
+type ExplainVerdict string
+
+const (
+	// ExplainVerdictAllow is the verdict of a mechanism allowing the traffic
+	ExplainVerdictAllow ExplainVerdict = "allow"
+
+	// ExplainVerdictDeny is the verdict of a mechanism applicable to the traffic which does not allow it
+	ExplainVerdictDeny ExplainVerdict = "deny"
+
+	// ExplainVerdictNotApplicable is the verdict of a mechanism which does not apply to the traffic
+	ExplainVerdictNotApplicable ExplainVerdict = "notApplicable"
+
+	// ExplainVerdictUnknown is the verdict of a mechanism which applies to the traffic but whose decision is only
+	// known at runtime, such as plugins run by the sidecars
+	ExplainVerdictUnknown ExplainVerdict = "unknown"
+)
+
+const (
+	// ExplainMechanismPermissive is the permissive traffic policy mode
+	ExplainMechanismPermissive = "permissive"
+
+	// ExplainMechanismSMI is SMI TrafficTargets along with their HTTPRouteGroups and TCPRoutes
+	ExplainMechanismSMI = "smi"
+
+	// ExplainMechanismAccessControl is AccessControl policies
+	ExplainMechanismAccessControl = "accessControl"
+
+	// ExplainMechanismIngressBackend is IngressBackend policies
+	ExplainMechanismIngressBackend = "ingressBackend"
+
+	// ExplainMechanismEgress is Egress policies and the global egress setting
+	ExplainMechanismEgress = "egress"
+
+	// ExplainMechanismPlugins is the plugin chains run by the sidecars
+	ExplainMechanismPlugins = "plugins"
+)
+
+// TrafficExplanation explains how the policies of the mesh apply to traffic from a source pod to a destination pod
+type TrafficExplanation struct {
+	// Source is the pod the traffic originates from
+	Source ExplainedPod `json:"source"`
+
+	// Destination is the pod the traffic is directed to
+	Destination ExplainedPod `json:"destination"`
+
+	// Port is the port the traffic is directed to
+	Port uint16 `json:"port"`
+
+	// Method is the HTTP method of the request, any method when empty
+	Method string `json:"method,omitempty"`
+
+	// Path is the HTTP path of the request, any path when empty
+	Path string `json:"path,omitempty"`
+
+	// DestinationServices are the services of the destination pod exposing the port
+	DestinationServices []string `json:"destinationServices,omitempty"`
+
+	// Allowed is whether the traffic is allowed
+	Allowed bool `json:"allowed"`
+
+	// Reason is the rule allowing the traffic, or why it is denied
+	Reason string `json:"reason"`
+
+	// Mechanisms are the verdicts of each mechanism of the mesh on the traffic
+	Mechanisms []MechanismExplanation `json:"mechanisms"`
+
+	// Settings are the settings applied to the traffic
+	// +optional
+	Settings *TrafficSettingsExplanation `json:"settings,omitempty"`
+}
+
+// ExplainedPod is a pod the traffic explained by a TrafficExplanation flows between
+type ExplainedPod struct {
+	// Namespace is the namespace of the pod
+	Namespace string `json:"namespace"`
+
+	// Name is the name of the pod
+	Name string `json:"name"`
+
+	// IP is the IP address of the pod
+	IP string `json:"ip,omitempty"`
+
+	// Identity is the service identity of the pod
+	Identity string `json:"identity"`
+
+	// Meshed is whether the pod runs a sidecar
+	Meshed bool `json:"meshed"`
+}
+
+// MechanismExplanation is the verdict of a mechanism of the mesh on traffic
+type MechanismExplanation struct {
+	// Mechanism is the name of the mechanism, one of the ExplainMechanism constants
+	Mechanism string `json:"mechanism"`
+
+	// Verdict is the verdict of the mechanism
+	Verdict ExplainVerdict `json:"verdict"`
+
+	// MatchedObjects are the objects of the mechanism which apply to the traffic, as Kind namespace/name
+	MatchedObjects []string `json:"matchedObjects,omitempty"`
+
+	// Rule is the rule which decided the verdict
+	Rule string `json:"rule,omitempty"`
+}
+
+// TrafficSettingsExplanation is the set of settings applied to traffic directed to an upstream service
+type TrafficSettingsExplanation struct {
+	// UpstreamTrafficSetting is the UpstreamTrafficSetting the settings originate from, as namespace/name
+	// +optional
+	UpstreamTrafficSetting string `json:"upstreamTrafficSetting,omitempty"`
+
+	// Retry is the retry policy applied by the source
+	// +optional
+	Retry *policyv1alpha1.RetryPolicySpec `json:"retry,omitempty"`
+
+	// RateLimit is the rate limit applied by the destination to all the traffic to the service
+	// +optional
+	RateLimit *policyv1alpha1.RateLimitSpec `json:"rateLimit,omitempty"`
+
+	// RouteRateLimit is the rate limit applied by the destination to the HTTP route of the request
+	// +optional
+	RouteRateLimit *policyv1alpha1.HTTPPerRouteRateLimitSpec `json:"routeRateLimit,omitempty"`
+
+	// ConnectionSettings are the circuit breaking thresholds applied by the source
+	// +optional
+	ConnectionSettings *policyv1alpha1.ConnectionSettingsSpec `json:"connectionSettings,omitempty"`
+
+	// OutlierDetection is the outlier detection applied by the source
+	// +optional
+	OutlierDetection *policyv1alpha1.OutlierDetectionSpec `json:"outlierDetection,omitempty"`
+}
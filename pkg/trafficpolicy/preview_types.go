@@ -0,0 +1,69 @@
+package trafficpolicy
+
+const (
+	// PreviewSectionInbound is the inbound mesh traffic policy of a proxy
+	PreviewSectionInbound = "inbound"
+
+	// PreviewSectionOutbound is the outbound mesh traffic policy of a proxy
+	PreviewSectionOutbound = "outbound"
+
+	// PreviewSectionIngress is the ingress traffic policies of the services of a proxy
+	PreviewSectionIngress = "ingress"
+
+	// PreviewSectionAccessControl is the access control traffic policies of the services of a proxy
+	PreviewSectionAccessControl = "accessControl"
+
+	// PreviewSectionEgress is the egress traffic policy of a proxy
+	PreviewSectionEgress = "egress"
+
+	// PreviewSectionEgressGateway is the egress gateway policy of the mesh
+	PreviewSectionEgressGateway = "egressGateway"
+)
+
+// PolicyPreview is the impact of applying a policy on the config of the proxies of the mesh
+type PolicyPreview struct {
+	// Kind is the kind of the previewed policy
+	Kind string `json:"kind"`
+
+	// Namespace is the namespace of the previewed policy
+	Namespace string `json:"namespace"`
+
+	// Name is the name of the previewed policy
+	Name string `json:"name"`
+
+	// Proxies are the proxies whose config would change, ordered by namespace and name of their pod
+	Proxies []ProxyConfigPreview `json:"proxies,omitempty"`
+
+	// UnchangedProxies is the number of proxies whose config would not change
+	UnchangedProxies int `json:"unchangedProxies"`
+}
+
+// ProxyConfigPreview is the change of the config of a proxy caused by applying a policy
+type ProxyConfigPreview struct {
+	// Namespace is the namespace of the pod of the proxy
+	Namespace string `json:"namespace"`
+
+	// Name is the name of the pod of the proxy
+	Name string `json:"name"`
+
+	// Identity is the service identity of the proxy
+	Identity string `json:"identity"`
+
+	// Changes are the changed fields of the config of the proxy, ordered by section and path
+	Changes []ConfigChange `json:"changes"`
+}
+
+// ConfigChange is a changed field of the config of a proxy
+type ConfigChange struct {
+	// Section is the section of the config holding the field, one of the PreviewSection constants
+	Section string `json:"section"`
+
+	// Path is the path of the field in the section
+	Path string `json:"path"`
+
+	// Before is the value of the field before applying the policy, nil if the field is missing
+	Before interface{} `json:"before"`
+
+	// After is the value of the field after applying the policy, nil if the field is missing
+	After interface{} `json:"after"`
+}
@@ -37,20 +37,30 @@ func NewRouteWeightedCluster(route HTTPRouteMatch, weightedClusters []service.We
 		return routeWC
 	}
 
-	// Apply the corresponding per route rate limit policy for the given
-	// HTTPRouteMatch's path
-	var perRouteRateLimit *policyv1alpha1.HTTPPerRouteRateLimitSpec
-	for _, httpRoute := range upstreamTrafficSetting.Spec.HTTPRoutes {
-		if httpRoute.Path == route.Path {
-			perRouteRateLimit = httpRoute.RateLimit
-			break
-		}
+	// Apply the corresponding per route rate limit and timeout policies
+	// for the given HTTPRouteMatch's path
+	if httpRoute := getHTTPRouteSpec(route, upstreamTrafficSetting); httpRoute != nil {
+		routeWC.RateLimit = httpRoute.RateLimit
+		routeWC.Timeout = httpRoute.Timeout
 	}
-	routeWC.RateLimit = perRouteRateLimit
 
 	return routeWC
 }
 
+// getHTTPRouteSpec returns the HTTPRouteSpec in the given UpstreamTrafficSetting whose path
+// corresponds to the given HTTPRouteMatch's path, or nil if there is no such HTTPRouteSpec
+func getHTTPRouteSpec(route HTTPRouteMatch, upstreamTrafficSetting *policyv1alpha1.UpstreamTrafficSetting) *policyv1alpha1.HTTPRouteSpec {
+	if upstreamTrafficSetting == nil {
+		return nil
+	}
+	for i, httpRoute := range upstreamTrafficSetting.Spec.HTTPRoutes {
+		if httpRoute.Path == route.Path {
+			return &upstreamTrafficSetting.Spec.HTTPRoutes[i]
+		}
+	}
+	return nil
+}
+
 // NewInboundTrafficPolicy takes a name, list of hostnames, UpstreamTrafficSetting, and returns an *InboundTrafficPolicy
 func NewInboundTrafficPolicy(name string, hostnames []string, upstreamTrafficSetting *policyv1alpha1.UpstreamTrafficSetting) *InboundTrafficPolicy {
 	policy := &InboundTrafficPolicy{
@@ -112,6 +122,197 @@ func (out *OutboundTrafficPolicy) AddRoute(httpRouteMatch HTTPRouteMatch, retryP
 	return nil
 }
 
+// ApplyRouteTimeouts applies the per route timeouts specified in the given UpstreamTrafficSetting
+// to the Routes on the OutboundTrafficPolicy
+func (out *OutboundTrafficPolicy) ApplyRouteTimeouts(upstreamTrafficSetting *policyv1alpha1.UpstreamTrafficSetting) {
+	if upstreamTrafficSetting == nil {
+		return
+	}
+
+	var paths []string
+	var timeouts []*policyv1alpha1.HTTPRouteTimeoutSpec
+	for _, httpRoute := range upstreamTrafficSetting.Spec.HTTPRoutes {
+		if httpRoute.Timeout == nil {
+			continue
+		}
+		paths = append(paths, httpRoute.Path)
+		timeouts = append(timeouts, httpRoute.Timeout)
+	}
+
+	out.applyPathSettings(paths,
+		func(route *RouteWeightedClusters, i int) { route.Timeout = timeouts[i] },
+		func(route *RouteWeightedClusters) bool { return route.Timeout != nil })
+}
+
+// ApplyRouteMirrors applies the given mirror policies, keyed by route path, to the Routes on the
+// OutboundTrafficPolicy
+func (out *OutboundTrafficPolicy) ApplyRouteMirrors(mirrors map[string]*MirrorPolicy) {
+	// Sort the paths so that the Routes added for them are always in the same order
+	paths := make([]string, 0, len(mirrors))
+	for path := range mirrors {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	out.applyPathSettings(paths,
+		func(route *RouteWeightedClusters, i int) { route.Mirror = mirrors[paths[i]] },
+		func(route *RouteWeightedClusters) bool { return route.Mirror != nil })
+}
+
+// ApplyLoadBalancerHashKeys applies the load balancer hash keys specified in the given
+// UpstreamTrafficSetting to all the Routes on the OutboundTrafficPolicy
+func (out *OutboundTrafficPolicy) ApplyLoadBalancerHashKeys(upstreamTrafficSetting *policyv1alpha1.UpstreamTrafficSetting) {
+	if upstreamTrafficSetting == nil || upstreamTrafficSetting.Spec.LoadBalancer == nil {
+		return
+	}
+	for _, route := range out.Routes {
+		route.HashKeys = upstreamTrafficSetting.Spec.LoadBalancer.HashKeys
+	}
+}
+
+// ApplyRateLimitDescriptors applies the given global rate limit descriptors to all the Routes on the
+// OutboundTrafficPolicy
+func (out *OutboundTrafficPolicy) ApplyRateLimitDescriptors(descriptors []RateLimitDescriptor) {
+	for _, route := range out.Routes {
+		route.RateLimitDescriptors = descriptors
+	}
+}
+
+// ApplyFaultInjection applies the given HTTP route faults to the Routes on the OutboundTrafficPolicy,
+// a fault without a path applies to all the paths
+func (out *OutboundTrafficPolicy) ApplyFaultInjection(faults []policyv1alpha1.HTTPFaultInjectionRouteSpec) {
+	paths := make([]string, 0, len(faults))
+	for _, fault := range faults {
+		paths = append(paths, fault.Path)
+	}
+
+	out.applyPathSettings(paths,
+		func(route *RouteWeightedClusters, i int) { route.FaultInjection = &faults[i] },
+		func(route *RouteWeightedClusters) bool { return route.FaultInjection != nil })
+}
+
+// applyPathSettings applies the settings for the given paths to the Routes on the OutboundTrafficPolicy.
+// The settings for a wildcard path are applied to every Route without settings of its own, only the first
+// wildcard path being used, and the settings for any other path are applied to the Routes for that path.
+// set applies the settings for the path at the given index to a Route and isSet returns whether a Route
+// already has settings.
+func (out *OutboundTrafficPolicy) applyPathSettings(paths []string, set func(route *RouteWeightedClusters, i int), isSet func(route *RouteWeightedClusters) bool) {
+	wildcardIndex := -1
+	for i, path := range paths {
+		if isWildCardPath(path) {
+			if wildcardIndex < 0 {
+				wildcardIndex = i
+			}
+			continue
+		}
+		for _, route := range out.getRoutesForPath(path) {
+			set(route, i)
+		}
+	}
+
+	if wildcardIndex < 0 {
+		return
+	}
+	for _, route := range out.Routes {
+		if !isSet(route) {
+			set(route, wildcardIndex)
+		}
+	}
+}
+
+// getRoutesForPath returns the Routes on the OutboundTrafficPolicy matching the given path regex, so that
+// the settings for the path can be applied to them. The settings for a path must only apply to the requests
+// on that path, so the matching Routes are marked to preserve their match instead of having it widened to a
+// wildcard match on outbound routes. If no Route matches the path, a Route matching the path with the upstream
+// clusters and settings of the wildcard Route is placed right ahead of the wildcard Route, so that it takes
+// precedence over the wildcard Route but not over the other Routes. Nothing is returned if there is no Route
+// matching the path and no wildcard Route.
+func (out *OutboundTrafficPolicy) getRoutesForPath(path string) []*RouteWeightedClusters {
+	var routes []*RouteWeightedClusters
+	wildcardRouteIndex := -1
+	for i, route := range out.Routes {
+		if route.HTTPRouteMatch.PathMatchType != PathMatchRegex {
+			continue
+		}
+		if route.HTTPRouteMatch.Path == path {
+			route.PreserveRouteMatch = true
+			routes = append(routes, route)
+		}
+		if wildcardRouteIndex < 0 && reflect.DeepEqual(route.HTTPRouteMatch, WildCardRouteMatch) {
+			wildcardRouteIndex = i
+		}
+	}
+	if len(routes) > 0 || wildcardRouteIndex < 0 {
+		return routes
+	}
+
+	pathRoute := out.Routes[wildcardRouteIndex].deepCopy()
+	pathRoute.HTTPRouteMatch = HTTPRouteMatch{
+		Path:          path,
+		PathMatchType: PathMatchRegex,
+		Methods:       []string{constants.WildcardHTTPMethod},
+	}
+	pathRoute.PreserveRouteMatch = true
+
+	out.Routes = append(out.Routes[:wildcardRouteIndex], append([]*RouteWeightedClusters{pathRoute}, out.Routes[wildcardRouteIndex:]...)...)
+	return []*RouteWeightedClusters{pathRoute}
+}
+
+// deepCopy returns a copy of the Route that shares no state with it, so that either can be changed
+// without affecting the other
+func (route *RouteWeightedClusters) deepCopy() *RouteWeightedClusters {
+	c := *route
+
+	c.HTTPRouteMatch.Methods = append([]string(nil), route.HTTPRouteMatch.Methods...)
+	if route.HTTPRouteMatch.Headers != nil {
+		c.HTTPRouteMatch.Headers = make(map[string]string, len(route.HTTPRouteMatch.Headers))
+		for k, v := range route.HTTPRouteMatch.Headers {
+			c.HTTPRouteMatch.Headers[k] = v
+		}
+	}
+	if route.WeightedClusters != nil {
+		c.WeightedClusters = route.WeightedClusters.Clone()
+	}
+	c.RetryPolicy = route.RetryPolicy.DeepCopy()
+	c.RateLimit = route.RateLimit.DeepCopy()
+	c.Timeout = route.Timeout.DeepCopy()
+	c.FaultInjection = route.FaultInjection.DeepCopy()
+	if route.Mirror != nil {
+		mirror := *route.Mirror
+		c.Mirror = &mirror
+	}
+	if route.HashKeys != nil {
+		c.HashKeys = make([]policyv1alpha1.LoadBalancerHashKeySpec, len(route.HashKeys))
+		for i := range route.HashKeys {
+			route.HashKeys[i].DeepCopyInto(&c.HashKeys[i])
+		}
+	}
+	if route.RateLimitDescriptors != nil {
+		c.RateLimitDescriptors = make([]RateLimitDescriptor, len(route.RateLimitDescriptors))
+		for i, descriptor := range route.RateLimitDescriptors {
+			c.RateLimitDescriptors[i] = append(RateLimitDescriptor(nil), descriptor...)
+		}
+	}
+
+	return &c
+}
+
+// isWildCardPath returns a boolean indicating if the given route path matches all the paths
+func isWildCardPath(path string) bool {
+	return path == "" || path == constants.RegexMatchAll
+}
+
+// PreserveRouteMatch marks the Route on the OutboundTrafficPolicy with the given HTTP route match so that
+// its match is programmed as is on outbound routes. It is a no-op if no such Route exists.
+func (out *OutboundTrafficPolicy) PreserveRouteMatch(httpRouteMatch HTTPRouteMatch) {
+	for _, existingRoute := range out.Routes {
+		if reflect.DeepEqual(existingRoute.HTTPRouteMatch, httpRouteMatch) {
+			existingRoute.PreserveRouteMatch = true
+			return
+		}
+	}
+}
+
 // MergeInboundPolicies merges latest InboundTrafficPolicies into a slice of InboundTrafficPolicies that already exists (original)
 // allowPartialHostnamesMatch when set to true merges inbound policies by partially comparing (subset of one another) the hostnames of the original traffic policy to the latest traffic policy
 // A partial match on hostnames should be allowed for the following scenarios :
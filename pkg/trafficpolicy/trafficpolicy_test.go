@@ -10,6 +10,7 @@ import (
 
 	policyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
 
+	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/identity"
 	"github.com/openservicemesh/osm/pkg/service"
 )
@@ -475,6 +476,9 @@ func TestNewRouteWeightedCluster(t *testing.T) {
 			Unit:     "second",
 		},
 	}
+	perRouteTimeoutConfig := &policyv1alpha1.HTTPRouteTimeoutSpec{
+		Request: &metav1.Duration{Duration: 30 * time.Second},
+	}
 
 	testCases := []struct {
 		name                   string
@@ -509,6 +513,26 @@ func TestNewRouteWeightedCluster(t *testing.T) {
 				RateLimit:        perRouteRateLimitConfig,
 			},
 		},
+		{
+			name:             "per route timeout",
+			route:            testHTTPRouteMatch,
+			weightedClusters: []service.WeightedCluster{testWeightedCluster},
+			upstreamTrafficSetting: &policyv1alpha1.UpstreamTrafficSetting{
+				Spec: policyv1alpha1.UpstreamTrafficSettingSpec{
+					HTTPRoutes: []policyv1alpha1.HTTPRouteSpec{
+						{
+							Path:    testHTTPRouteMatch.Path, // matches path on HTTPRouteMatch
+							Timeout: perRouteTimeoutConfig,
+						},
+					},
+				},
+			},
+			expected: &RouteWeightedClusters{
+				HTTPRouteMatch:   testHTTPRouteMatch,
+				WeightedClusters: mapset.NewSet(testWeightedCluster),
+				Timeout:          perRouteTimeoutConfig,
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -521,6 +545,278 @@ func TestNewRouteWeightedCluster(t *testing.T) {
 	}
 }
 
+func TestApplyRouteTimeouts(t *testing.T) {
+	assert := tassert.New(t)
+
+	helloTimeout := &policyv1alpha1.HTTPRouteTimeoutSpec{
+		Request: &metav1.Duration{Duration: 30 * time.Second},
+		Idle:    &metav1.Duration{Duration: 5 * time.Second},
+	}
+	newTimeout := &policyv1alpha1.HTTPRouteTimeoutSpec{
+		Request: &metav1.Duration{Duration: 10 * time.Second},
+	}
+	allTimeout := &policyv1alpha1.HTTPRouteTimeoutSpec{
+		Request: &metav1.Duration{Duration: time.Minute},
+	}
+	upstreamTrafficSetting := &policyv1alpha1.UpstreamTrafficSetting{
+		Spec: policyv1alpha1.UpstreamTrafficSettingSpec{
+			HTTPRoutes: []policyv1alpha1.HTTPRouteSpec{
+				{
+					Path:    testHTTPRouteMatch.Path,
+					Timeout: helloTimeout,
+				},
+				{
+					Path:    constants.RegexMatchAll,
+					Timeout: allTimeout,
+				},
+				{
+					Path:    "/new",
+					Timeout: newTimeout,
+				},
+				{
+					// Routes are not added for paths without a timeout
+					Path: "/other",
+				},
+			},
+		},
+	}
+
+	// Two routes sharing the same host, and the wildcard route
+	out := &OutboundTrafficPolicy{
+		Routes: []*RouteWeightedClusters{
+			{HTTPRouteMatch: testHTTPRouteMatch, WeightedClusters: mapset.NewSet(testWeightedCluster)},
+			{HTTPRouteMatch: testHTTPRouteMatch2, WeightedClusters: mapset.NewSet(testWeightedCluster)},
+			{HTTPRouteMatch: WildCardRouteMatch, WeightedClusters: mapset.NewSet(testWeightedCluster2)},
+		},
+	}
+
+	out.ApplyRouteTimeouts(nil)
+	assert.Len(out.Routes, 3)
+	for _, route := range out.Routes {
+		assert.Nil(route.Timeout)
+		assert.False(route.PreserveRouteMatch)
+	}
+
+	out.ApplyRouteTimeouts(upstreamTrafficSetting)
+	assert.Len(out.Routes, 4)
+
+	// The timeout for the path of a route only applies to that route, whose match is preserved
+	assert.Equal(testHTTPRouteMatch, out.Routes[0].HTTPRouteMatch)
+	assert.True(out.Routes[0].PreserveRouteMatch)
+	assert.Equal(helloTimeout, out.Routes[0].Timeout)
+
+	// The wildcard timeout applies to the routes without a timeout of their own
+	assert.Equal(testHTTPRouteMatch2, out.Routes[1].HTTPRouteMatch)
+	assert.False(out.Routes[1].PreserveRouteMatch)
+	assert.Equal(allTimeout, out.Routes[1].Timeout)
+
+	// A route matching the path of the timeout is placed right ahead of the wildcard route
+	assert.Equal(HTTPRouteMatch{
+		Path:          "/new",
+		PathMatchType: PathMatchRegex,
+		Methods:       []string{constants.WildcardHTTPMethod},
+	}, out.Routes[2].HTTPRouteMatch)
+	assert.True(out.Routes[2].PreserveRouteMatch)
+	assert.Equal(newTimeout, out.Routes[2].Timeout)
+	assert.True(out.Routes[2].WeightedClusters.Equal(mapset.NewSet(testWeightedCluster2)))
+
+	assert.Equal(WildCardRouteMatch, out.Routes[3].HTTPRouteMatch)
+	assert.False(out.Routes[3].PreserveRouteMatch)
+	assert.Equal(allTimeout, out.Routes[3].Timeout)
+
+	// The route added for a path shares no state with the wildcard route it is copied from
+	out.Routes[2].WeightedClusters.Add(testWeightedCluster)
+	assert.True(out.Routes[3].WeightedClusters.Equal(mapset.NewSet(testWeightedCluster2)))
+}
+
+func TestApplyRouteMirrors(t *testing.T) {
+	assert := tassert.New(t)
+
+	helloMirror := &MirrorPolicy{
+		ClusterName: "testNamespace1/testCluster-v2|80",
+		Percentage:  10,
+	}
+	newMirror := &MirrorPolicy{
+		ClusterName: "testNamespace1/testCluster-v3|80",
+		Percentage:  50,
+	}
+	allMirror := &MirrorPolicy{
+		ClusterName: "testNamespace1/testCluster-v4|80",
+		Percentage:  100,
+	}
+
+	// Two routes sharing the same host, and the wildcard route
+	out := &OutboundTrafficPolicy{
+		Routes: []*RouteWeightedClusters{
+			{HTTPRouteMatch: testHTTPRouteMatch, WeightedClusters: mapset.NewSet(testWeightedCluster)},
+			{HTTPRouteMatch: testHTTPRouteMatch2, WeightedClusters: mapset.NewSet(testWeightedCluster)},
+			{HTTPRouteMatch: WildCardRouteMatch, WeightedClusters: mapset.NewSet(testWeightedCluster2)},
+		},
+	}
+
+	out.ApplyRouteMirrors(nil)
+	assert.Len(out.Routes, 3)
+	for _, route := range out.Routes {
+		assert.Nil(route.Mirror)
+		assert.False(route.PreserveRouteMatch)
+	}
+
+	out.ApplyRouteMirrors(map[string]*MirrorPolicy{
+		testHTTPRouteMatch.Path: helloMirror,
+		"/new":                  newMirror,
+		constants.RegexMatchAll: allMirror,
+	})
+	assert.Len(out.Routes, 4)
+
+	// The mirror policy for the path of a route only applies to that route, whose match is preserved
+	assert.Equal(testHTTPRouteMatch, out.Routes[0].HTTPRouteMatch)
+	assert.True(out.Routes[0].PreserveRouteMatch)
+	assert.Equal(helloMirror, out.Routes[0].Mirror)
+
+	// The wildcard mirror policy applies to the routes without a mirror policy of their own
+	assert.Equal(testHTTPRouteMatch2, out.Routes[1].HTTPRouteMatch)
+	assert.False(out.Routes[1].PreserveRouteMatch)
+	assert.Equal(allMirror, out.Routes[1].Mirror)
+
+	// A route matching the path of the mirror policy is placed right ahead of the wildcard route
+	assert.Equal("/new", out.Routes[2].HTTPRouteMatch.Path)
+	assert.True(out.Routes[2].PreserveRouteMatch)
+	assert.Equal(newMirror, out.Routes[2].Mirror)
+	assert.True(out.Routes[2].WeightedClusters.Equal(mapset.NewSet(testWeightedCluster2)))
+
+	assert.Equal(WildCardRouteMatch, out.Routes[3].HTTPRouteMatch)
+	assert.False(out.Routes[3].PreserveRouteMatch)
+	assert.Equal(allMirror, out.Routes[3].Mirror)
+
+	// A mirror policy for a path without a route is ignored if there is no wildcard route
+	out = &OutboundTrafficPolicy{
+		Routes: []*RouteWeightedClusters{
+			{HTTPRouteMatch: testHTTPRouteMatch, WeightedClusters: mapset.NewSet(testWeightedCluster)},
+		},
+	}
+	out.ApplyRouteMirrors(map[string]*MirrorPolicy{"/new": newMirror})
+	assert.Len(out.Routes, 1)
+	assert.Nil(out.Routes[0].Mirror)
+}
+
+func TestApplyLoadBalancerHashKeys(t *testing.T) {
+	assert := tassert.New(t)
+
+	hashKeys := []policyv1alpha1.LoadBalancerHashKeySpec{
+		{Header: "x-user-id"},
+		{SourceIP: true},
+	}
+
+	out := &OutboundTrafficPolicy{
+		Routes: []*RouteWeightedClusters{
+			{HTTPRouteMatch: testHTTPRouteMatch, WeightedClusters: mapset.NewSet(testWeightedCluster)},
+			{HTTPRouteMatch: testHTTPRouteMatch2, WeightedClusters: mapset.NewSet(testWeightedCluster)},
+		},
+	}
+
+	out.ApplyLoadBalancerHashKeys(nil)
+	assert.Nil(out.Routes[0].HashKeys)
+	assert.Nil(out.Routes[1].HashKeys)
+
+	out.ApplyLoadBalancerHashKeys(&policyv1alpha1.UpstreamTrafficSetting{})
+	assert.Nil(out.Routes[0].HashKeys)
+	assert.Nil(out.Routes[1].HashKeys)
+
+	out.ApplyLoadBalancerHashKeys(&policyv1alpha1.UpstreamTrafficSetting{
+		Spec: policyv1alpha1.UpstreamTrafficSettingSpec{
+			LoadBalancer: &policyv1alpha1.LoadBalancerSpec{
+				Type:     policyv1alpha1.LoadBalancerRingHash,
+				HashKeys: hashKeys,
+			},
+		},
+	})
+	assert.Equal(hashKeys, out.Routes[0].HashKeys)
+	assert.Equal(hashKeys, out.Routes[1].HashKeys)
+}
+
+func TestApplyRateLimitDescriptors(t *testing.T) {
+	assert := tassert.New(t)
+
+	descriptors := []RateLimitDescriptor{
+		{
+			{Key: "destination", Value: "bookstore.bookstore.svc.cluster.local"},
+			{Key: "path", Header: ":path"},
+		},
+	}
+
+	out := &OutboundTrafficPolicy{
+		Routes: []*RouteWeightedClusters{
+			{HTTPRouteMatch: testHTTPRouteMatch, WeightedClusters: mapset.NewSet(testWeightedCluster)},
+			{HTTPRouteMatch: testHTTPRouteMatch2, WeightedClusters: mapset.NewSet(testWeightedCluster)},
+		},
+	}
+
+	out.ApplyRateLimitDescriptors(nil)
+	assert.Nil(out.Routes[0].RateLimitDescriptors)
+	assert.Nil(out.Routes[1].RateLimitDescriptors)
+
+	out.ApplyRateLimitDescriptors(descriptors)
+	assert.Equal(descriptors, out.Routes[0].RateLimitDescriptors)
+	assert.Equal(descriptors, out.Routes[1].RateLimitDescriptors)
+}
+
+func TestApplyFaultInjection(t *testing.T) {
+	assert := tassert.New(t)
+
+	wildcardRouteMatch := HTTPRouteMatch{
+		Path:          constants.RegexMatchAll,
+		PathMatchType: PathMatchRegex,
+		Methods:       []string{constants.WildcardHTTPMethod},
+	}
+	allFault := policyv1alpha1.HTTPFaultInjectionRouteSpec{
+		Abort: &policyv1alpha1.HTTPFaultAbortSpec{Percentage: 10, HTTPStatus: 503},
+	}
+	helloFault := policyv1alpha1.HTTPFaultInjectionRouteSpec{
+		Path:  testHTTPRouteMatch.Path,
+		Delay: &policyv1alpha1.HTTPFaultDelaySpec{Percentage: 50, FixedDelay: metav1.Duration{Duration: time.Second}},
+	}
+	newFault := policyv1alpha1.HTTPFaultInjectionRouteSpec{
+		Path:  "/new",
+		Abort: &policyv1alpha1.HTTPFaultAbortSpec{Percentage: 100, HTTPStatus: 500},
+	}
+
+	out := &OutboundTrafficPolicy{
+		Routes: []*RouteWeightedClusters{
+			{HTTPRouteMatch: testHTTPRouteMatch, WeightedClusters: mapset.NewSet(testWeightedCluster)},
+			{HTTPRouteMatch: wildcardRouteMatch, WeightedClusters: mapset.NewSet(testWeightedCluster)},
+		},
+	}
+
+	out.ApplyFaultInjection(nil)
+	assert.Len(out.Routes, 2)
+	assert.Nil(out.Routes[0].FaultInjection)
+	assert.Nil(out.Routes[1].FaultInjection)
+
+	out.ApplyFaultInjection([]policyv1alpha1.HTTPFaultInjectionRouteSpec{helloFault, newFault, allFault})
+	assert.Len(out.Routes, 3)
+
+	// The fault for an existing path is applied to that route, whose match is preserved, and not
+	// overridden by the fault for all paths
+	assert.Equal(testHTTPRouteMatch, out.Routes[0].HTTPRouteMatch)
+	assert.True(out.Routes[0].PreserveRouteMatch)
+	assert.Equal(helloFault.Delay, out.Routes[0].FaultInjection.Delay)
+	assert.Nil(out.Routes[0].FaultInjection.Abort)
+
+	// A new route matching the path of the fault is placed right ahead of the wildcard route
+	assert.Equal(HTTPRouteMatch{
+		Path:          newFault.Path,
+		PathMatchType: PathMatchRegex,
+		Methods:       []string{constants.WildcardHTTPMethod},
+	}, out.Routes[1].HTTPRouteMatch)
+	assert.True(out.Routes[1].PreserveRouteMatch)
+	assert.Equal(&newFault, out.Routes[1].FaultInjection)
+	assert.True(out.Routes[1].WeightedClusters.Equal(mapset.NewSet(testWeightedCluster)))
+
+	assert.Equal(wildcardRouteMatch, out.Routes[2].HTTPRouteMatch)
+	assert.False(out.Routes[2].PreserveRouteMatch)
+	assert.Equal(allFault.Abort, out.Routes[2].FaultInjection.Abort)
+}
+
 func TestNewOutboundPolicy(t *testing.T) {
 	assert := tassert.New(t)
 
@@ -44,6 +44,11 @@ type HTTPRouteMatchWithWeightedClusters struct {
 	UpstreamClusters []service.WeightedCluster
 	RouteMatches     []HTTPRouteMatch
 	HasSplitMatches  bool
+
+	// PreserveRouteMatches indicates the RouteMatches must be programmed as is on the
+	// outbound routes instead of being widened to a wildcard match, as is the case
+	// for matches derived from Gateway API routes
+	PreserveRouteMatches bool
 }
 
 // TCPRouteMatch is a struct to represent a TCP route matching based on ports
@@ -61,6 +66,54 @@ type RouteWeightedClusters struct {
 	// for the given HTTPRouteMatch
 	// +optional
 	RateLimit *policyv1alpha1.HTTPPerRouteRateLimitSpec `json:"rate_limit:omitempty"`
+
+	// Timeout defines the timeout settings applied at the route level
+	// for the given HTTPRouteMatch
+	// +optional
+	Timeout *policyv1alpha1.HTTPRouteTimeoutSpec `json:"timeout:omitempty"`
+
+	// FaultInjection defines the faults injected at the route level
+	// for the given HTTPRouteMatch
+	// +optional
+	FaultInjection *policyv1alpha1.HTTPFaultInjectionRouteSpec `json:"fault_injection:omitempty"`
+
+	// Mirror defines the upstream cluster requests matching the given
+	// HTTPRouteMatch are mirrored to
+	// +optional
+	Mirror *MirrorPolicy `json:"mirror:omitempty"`
+
+	// HashKeys defines the keys hashed to select an upstream endpoint for
+	// requests matching the given HTTPRouteMatch when a consistent hashing
+	// load balancer is used
+	// +optional
+	HashKeys []policyv1alpha1.LoadBalancerHashKeySpec `json:"hash_keys:omitempty"`
+
+	// RateLimitDescriptors defines the descriptors sent to the global rate
+	// limit service for requests matching the given HTTPRouteMatch
+	// +optional
+	RateLimitDescriptors []RateLimitDescriptor `json:"rate_limit_descriptors:omitempty"`
+
+	// PreserveRouteMatch indicates the HTTPRouteMatch must be programmed as is
+	// on outbound routes instead of being widened to a wildcard match
+	// +optional
+	PreserveRouteMatch bool `json:"preserve_route_match:omitempty"`
+}
+
+// RateLimitDescriptor is a type to represent a descriptor sent to the global rate limit service
+type RateLimitDescriptor []RateLimitDescriptorEntry
+
+// RateLimitDescriptorEntry is a struct to represent an entry of a rate limit descriptor, whose value is
+// either the static Value or the value of the request Header
+type RateLimitDescriptorEntry struct {
+	Key    string `json:"key"`
+	Value  string `json:"value,omitempty"`
+	Header string `json:"header,omitempty"`
+}
+
+// MirrorPolicy is a struct to represent the upstream cluster a fraction of the requests on a route are mirrored to
+type MirrorPolicy struct {
+	ClusterName service.ClusterName `json:"cluster_name:omitempty"`
+	Percentage  uint32              `json:"percentage:omitempty"`
 }
 
 // InboundTrafficPolicy is a struct that associates incoming traffic on a set of Hostnames with a list of Rules
@@ -73,6 +126,31 @@ type InboundTrafficPolicy struct {
 	// for the given set of hostnames (domains) corresponding to the virtual_host
 	// +optional
 	RateLimit *policyv1alpha1.RateLimitSpec `json:"rate_limit:omitempty"`
+
+	// RequestAuthentication defines the JWT authentication applied to requests
+	// for the given set of hostnames (domains) corresponding to the virtual_host
+	// +optional
+	RequestAuthentication *RequestAuthentication `json:"request_authentication:omitempty"`
+}
+
+// RequestAuthentication is a struct to represent the JWT authentication applied to inbound HTTP traffic
+type RequestAuthentication struct {
+	// Providers defines the JWT providers trusted for the traffic, one per JWT rule of the policy
+	Providers []JWTProvider `json:"providers"`
+
+	// Rules defines the authorization rules matched against the claims of a valid JWT
+	// +optional
+	Rules []policyv1alpha1.JWTAuthorizationRuleSpec `json:"rules:omitempty"`
+}
+
+// JWTProvider is a struct to represent a trusted JWT issuer and the JSON Web Key Set its tokens are verified with
+type JWTProvider struct {
+	Name                 string                             `json:"name"`
+	Issuer               string                             `json:"issuer"`
+	Audiences            []string                           `json:"audiences:omitempty"`
+	JWKS                 string                             `json:"jwks"`
+	ForwardOriginalToken bool                               `json:"forward_original_token:omitempty"`
+	ClaimToHeaders       []policyv1alpha1.ClaimToHeaderSpec `json:"claim_to_headers:omitempty"`
 }
 
 // Rule is a struct that represents which authenticated principals can access a Route.
@@ -205,5 +283,9 @@ type TrafficMatch struct {
 	// +optional
 	RateLimit *policyv1alpha1.RateLimitSpec
 
+	// RequestAuthentication defines the JWT authentication applied for this TrafficMatch
+	// +optional
+	RequestAuthentication *RequestAuthentication
+
 	EgressGateWay *string
 }
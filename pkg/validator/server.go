@@ -32,6 +32,9 @@ var (
 type validatingWebhookServer struct {
 	// Map of Resource (GroupVersionKind), to validator
 	validators map[string]validateFunc
+
+	// Map of Resource (GroupVersionKind), to the func returning the warnings about admitted changes
+	warners map[string]warnFunc
 }
 
 // NewValidatingWebhook returns a validatingWebhookServer with the defaultValidators that were previously registered.
@@ -50,11 +53,20 @@ func NewValidatingWebhook(ctx context.Context, cfg *configurator.Client, webhook
 			policyv1alpha1.SchemeGroupVersion.WithKind("Egress").String():                 egressValidator,
 			policyv1alpha1.SchemeGroupVersion.WithKind("EgressGateway").String():          kv.egressGatewayValidator,
 			policyv1alpha1.SchemeGroupVersion.WithKind("UpstreamTrafficSetting").String(): kv.upstreamTrafficSettingValidator,
+			policyv1alpha1.SchemeGroupVersion.WithKind("RequestAuthentication").String():  kv.requestAuthenticationValidator,
+			policyv1alpha1.SchemeGroupVersion.WithKind("Retry").String():                  kv.retryValidator,
 			smiAccess.SchemeGroupVersion.WithKind("TrafficTarget").String():               trafficTargetValidator,
 			pluginv1alpha1.SchemeGroupVersion.WithKind("Plugin").String():                 kv.pluginValidator,
 			pluginv1alpha1.SchemeGroupVersion.WithKind("PluginConfig").String():           kv.pluginConfigValidator,
 			pluginv1alpha1.SchemeGroupVersion.WithKind("PluginChain").String():            kv.pluginChainValidator,
 		},
+		warners: map[string]warnFunc{
+			policyv1alpha1.SchemeGroupVersion.WithKind("AccessControl").String():          kv.accessControlWarnings,
+			policyv1alpha1.SchemeGroupVersion.WithKind("IngressBackend").String():         kv.ingressBackendWarnings,
+			policyv1alpha1.SchemeGroupVersion.WithKind("UpstreamTrafficSetting").String(): kv.upstreamTrafficSettingWarnings,
+			policyv1alpha1.SchemeGroupVersion.WithKind("RequestAuthentication").String():  kv.requestAuthenticationWarnings,
+			policyv1alpha1.SchemeGroupVersion.WithKind("Retry").String():                  kv.retryWarnings,
+		},
 	}
 
 	srv, err := webhook.NewServer(ValidatorWebhookSvc, osmNamespace, constants.ValidatorWebhookPort, certManager, map[string]http.HandlerFunc{
@@ -146,11 +158,33 @@ func (s *validatingWebhookServer) handleValidation(req *admissionv1.AdmissionReq
 		if err != nil {
 			log.Warn().Msgf("Warning! validator for gvk: %s returned both an AdmissionResponse *and* an error. Please return one or the other", gvk)
 		}
+		if resp.Allowed {
+			resp.Warnings = append(resp.Warnings, s.getWarnings(gvk, req)...)
+		}
 		return
 	}
 	// No response, but got an error.
 	if err != nil {
 		resp = webhook.AdmissionError(err)
+		return
+	}
+	if warnings := s.getWarnings(gvk, req); len(warnings) > 0 {
+		resp = &admissionv1.AdmissionResponse{Allowed: true, Warnings: warnings}
 	}
 	return
 }
+
+// getWarnings returns the warnings about the admitted change of the given request
+func (s *validatingWebhookServer) getWarnings(gvk string, req *admissionv1.AdmissionRequest) []string {
+	w, ok := s.warners[gvk]
+	if !ok {
+		return nil
+	}
+	warnings, err := w(req)
+	if err != nil {
+		// Warnings are best effort, a change admitted by the validator is never denied because of them
+		log.Error().Err(err).Msgf("Error computing the admission warnings for gvk: %s", gvk)
+		return nil
+	}
+	return warnings
+}
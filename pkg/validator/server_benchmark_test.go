@@ -53,7 +53,7 @@ func BenchmarkDoValidation(b *testing.B) {
 	if err != nil {
 		b.Fatalf("Failed to create informer collection: %s", err)
 	}
-	k8sClient := k8s.NewKubernetesController(informerCollection, policyClient, pluginClient, msgBroker)
+	k8sClient := k8s.NewKubernetesController(informerCollection, policyClient, pluginClient, nil, msgBroker)
 	policyController := policy.NewPolicyController(informerCollection, kubeClient, k8sClient, msgBroker)
 	kv := &policyValidator{
 		policyClient: policyController,
@@ -30,6 +30,7 @@ type fakeObj struct {
 	Allow        bool
 	Error        bool
 	ExplicitResp bool
+	Warn         bool
 }
 
 func TestHandleValidation(t *testing.T) {
@@ -57,6 +58,18 @@ func TestHandleValidation(t *testing.T) {
 				return nil, nil
 			},
 		},
+		warners: map[string]warnFunc{
+			gvk.String(): func(req *admissionv1.AdmissionRequest) ([]string, error) {
+				f := fakeObj{}
+				if err := json.Unmarshal(req.Object.Raw, &f); err != nil {
+					return nil, err
+				}
+				if f.Warn {
+					return []string{"explicit warning"}, nil
+				}
+				return nil, nil
+			},
+		},
 	}
 	badGvk := metav1.GroupVersionKind{
 		Kind:    "Fake",
@@ -125,6 +138,35 @@ func TestHandleValidation(t *testing.T) {
 				Allowed: true,
 			},
 		},
+		{
+			testName: "valid obj implicit response obj with warnings",
+			req: &admissionv1.AdmissionRequest{
+				UID:  "5",
+				Kind: gvk,
+				Object: runtime.RawExtension{
+					Raw: []byte(`{"Warn": true}`),
+				},
+			},
+			expResp: &admissionv1.AdmissionResponse{
+				UID:      "5",
+				Allowed:  true,
+				Warnings: []string{"explicit warning"},
+			},
+		},
+		{
+			testName: "denied obj explicit response obj without warnings",
+			req: &admissionv1.AdmissionRequest{
+				UID:  "6",
+				Kind: gvk,
+				Object: runtime.RawExtension{
+					Raw: []byte(`{"ExplicitResp": true, "Warn": true}`),
+				},
+			},
+			expResp: &admissionv1.AdmissionResponse{
+				UID:    "6",
+				Result: &metav1.Status{Message: "explicit response"},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -15,11 +15,13 @@ import (
 
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
+	configv1alpha2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
 	pluginv1alpha1 "github.com/openservicemesh/osm/pkg/apis/plugin/v1alpha1"
 	policyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
 
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/identity"
 	"github.com/openservicemesh/osm/pkg/k8s"
 	"github.com/openservicemesh/osm/pkg/policy"
 	"github.com/openservicemesh/osm/pkg/service"
@@ -75,6 +77,20 @@ type policyValidator struct {
 	cfg            *configurator.Client
 }
 
+// warnOnConflicts returns whether policies conflicting with existing policies are admitted with warnings instead of
+// being rejected, which is the case when the mesh is configured with the Warn policy conflict mode
+func (kc *policyValidator) warnOnConflicts() bool {
+	return kc.cfg != nil && kc.cfg.GetPolicyConflictMode() == configv1alpha2.PolicyConflictModeWarn
+}
+
+// conflictWarningsResponse returns a response admitting a request with the given conflict warnings, or nil if there are none
+func conflictWarningsResponse(warnings []string) *admissionv1.AdmissionResponse {
+	if len(warnings) == 0 {
+		return nil
+	}
+	return &admissionv1.AdmissionResponse{Allowed: true, Warnings: warnings}
+}
+
 func trafficTargetValidator(req *admissionv1.AdmissionRequest) (*admissionv1.AdmissionResponse, error) {
 	trafficTarget := &smiAccess.TrafficTarget{}
 	if err := json.NewDecoder(bytes.NewBuffer(req.Object.Raw)).Decode(trafficTarget); err != nil {
@@ -116,6 +132,7 @@ func (kc *policyValidator) accessControlValidator(req *admissionv1.AdmissionRequ
 
 	backends := mapset.NewSet()
 	var conflictString strings.Builder
+	var warnings []string
 	conflictingAcls := mapset.NewSet()
 	for _, backend := range acl.Spec.Backends {
 		if unique := backends.Add(setEntry{backend.Name, backend.Port.Number}); !unique {
@@ -138,6 +155,7 @@ func (kc *policyValidator) accessControlValidator(req *admissionv1.AdmissionRequ
 			fmt.Fprintf(&conflictString, "[+] AccessControlBackend %s/%s conflicts with %s/%s:\n", ns, acl.ObjectMeta.GetName(), ns, matchingPolicy.ObjectMeta.GetName())
 			for _, err := range conflicts {
 				fmt.Fprintf(&conflictString, "%s\n", err)
+				warnings = append(warnings, fmt.Sprintf("AccessControl %s/%s conflicts with %s/%s: %s", ns, acl.ObjectMeta.GetName(), ns, matchingPolicy.ObjectMeta.GetName(), err))
 			}
 			fmt.Fprintf(&conflictString, "\n")
 		}
@@ -158,7 +176,7 @@ func (kc *policyValidator) accessControlValidator(req *admissionv1.AdmissionRequ
 		}
 	}
 
-	if conflictString.Len() != 0 {
+	if conflictString.Len() != 0 && !kc.warnOnConflicts() {
 		return nil, fmt.Errorf("duplicate backends detected\n%s", conflictString.String())
 	}
 
@@ -190,7 +208,7 @@ func (kc *policyValidator) accessControlValidator(req *admissionv1.AdmissionRequ
 		}
 	}
 
-	return nil, nil
+	return conflictWarningsResponse(warnings), nil
 }
 
 // ingressBackendValidator validates the IngressBackend custom resource
@@ -208,6 +226,7 @@ func (kc *policyValidator) ingressBackendValidator(req *admissionv1.AdmissionReq
 
 	backends := mapset.NewSet()
 	var conflictString strings.Builder
+	var warnings []string
 	conflictingIngressBackends := mapset.NewSet()
 	for _, backend := range ingressBackend.Spec.Backends {
 		if unique := backends.Add(setEntry{backend.Name, backend.Port.Number}); !unique {
@@ -230,6 +249,7 @@ func (kc *policyValidator) ingressBackendValidator(req *admissionv1.AdmissionReq
 			fmt.Fprintf(&conflictString, "[+] IngressBackend %s/%s conflicts with %s/%s:\n", ns, ingressBackend.ObjectMeta.GetName(), ns, matchingPolicy.ObjectMeta.GetName())
 			for _, err := range conflicts {
 				fmt.Fprintf(&conflictString, "%s\n", err)
+				warnings = append(warnings, fmt.Sprintf("IngressBackend %s/%s conflicts with %s/%s: %s", ns, ingressBackend.ObjectMeta.GetName(), ns, matchingPolicy.ObjectMeta.GetName(), err))
 			}
 			fmt.Fprintf(&conflictString, "\n")
 		}
@@ -259,7 +279,7 @@ func (kc *policyValidator) ingressBackendValidator(req *admissionv1.AdmissionReq
 		}
 	}
 
-	if conflictString.Len() != 0 {
+	if conflictString.Len() != 0 && !kc.warnOnConflicts() {
 		return nil, fmt.Errorf("duplicate backends detected\n%s", conflictString.String())
 	}
 
@@ -291,7 +311,102 @@ func (kc *policyValidator) ingressBackendValidator(req *admissionv1.AdmissionReq
 		}
 	}
 
-	return nil, nil
+	return conflictWarningsResponse(warnings), nil
+}
+
+// requestAuthenticationValidator validates the RequestAuthentication custom resource
+func (kc *policyValidator) requestAuthenticationValidator(req *admissionv1.AdmissionRequest) (*admissionv1.AdmissionResponse, error) {
+	requestAuthn := &policyv1alpha1.RequestAuthentication{}
+	if err := json.NewDecoder(bytes.NewBuffer(req.Object.Raw)).Decode(requestAuthn); err != nil {
+		return nil, err
+	}
+	ns := requestAuthn.Namespace
+
+	type setEntry struct {
+		name string
+		port int
+	}
+
+	backends := mapset.NewSet()
+	var warnings []string
+	for _, backend := range requestAuthn.Spec.Backends {
+		if unique := backends.Add(setEntry{backend.Name, backend.Port.Number}); !unique {
+			return nil, fmt.Errorf("Duplicate backends detected with service name: %s and port: %d", backend.Name, backend.Port.Number)
+		}
+
+		if strings.ToLower(backend.Port.Protocol) != constants.ProtocolHTTP {
+			return nil, fmt.Errorf("Expected 'port.protocol' to be 'http', got: %s", backend.Port.Protocol)
+		}
+
+		fakeMeshSvc := service.MeshService{
+			Name:       backend.Name,
+			Namespace:  ns,
+			TargetPort: uint16(backend.Port.Number),
+			Protocol:   backend.Port.Protocol,
+		}
+
+		if matchingPolicy := kc.policyClient.GetRequestAuthenticationPolicy(fakeMeshSvc); matchingPolicy != nil && matchingPolicy.Name != requestAuthn.Name {
+			conflict := fmt.Errorf("RequestAuthentication %s/%s conflicts with %s/%s on backend %s and port %d",
+				ns, requestAuthn.Name, ns, matchingPolicy.Name, backend.Name, backend.Port.Number)
+			if !kc.warnOnConflicts() {
+				return nil, conflict
+			}
+			warnings = append(warnings, conflict.Error())
+		}
+	}
+
+	if len(requestAuthn.Spec.JWTRules) == 0 {
+		return nil, fmt.Errorf("'jwtRules' must specify at least one JWT issuer")
+	}
+
+	for _, jwtRule := range requestAuthn.Spec.JWTRules {
+		if jwtRule.Issuer == "" {
+			return nil, fmt.Errorf("'jwtRules.issuer' not specified")
+		}
+
+		switch {
+		case jwtRule.JWKS != "" && jwtRule.JWKSSecretRef != nil:
+			return nil, fmt.Errorf("Only one of 'jwks' or 'jwksSecretRef' can be specified for issuer %s", jwtRule.Issuer)
+
+		case jwtRule.JWKS != "":
+			jwks := struct {
+				Keys []json.RawMessage `json:"keys"`
+			}{}
+			if err := json.Unmarshal([]byte(jwtRule.JWKS), &jwks); err != nil || len(jwks.Keys) == 0 {
+				return nil, fmt.Errorf("Invalid 'jwks' specified for issuer %s, expected a JSON Web Key Set with at least one key", jwtRule.Issuer)
+			}
+
+		case jwtRule.JWKSSecretRef != nil:
+			if jwtRule.JWKSSecretRef.Name == "" {
+				return nil, fmt.Errorf("'jwksSecretRef.name' not specified for issuer %s", jwtRule.Issuer)
+			}
+
+		default:
+			return nil, fmt.Errorf("One of 'jwks' or 'jwksSecretRef' must be specified for issuer %s", jwtRule.Issuer)
+		}
+
+		for _, claimToHeader := range jwtRule.ClaimToHeaders {
+			if claimToHeader.Claim == "" || claimToHeader.Header == "" {
+				return nil, fmt.Errorf("'claimToHeaders' must specify both 'claim' and 'header' for issuer %s", jwtRule.Issuer)
+			}
+		}
+	}
+
+	for _, rule := range requestAuthn.Spec.Rules {
+		if len(rule.Claims) == 0 {
+			return nil, fmt.Errorf("'rules.claims' must specify at least one claim")
+		}
+		for _, claim := range rule.Claims {
+			if claim.Name == "" {
+				return nil, fmt.Errorf("'rules.claims.name' not specified")
+			}
+			if len(claim.Values) == 0 {
+				return nil, fmt.Errorf("'rules.claims.values' must specify at least one value for claim %s", claim.Name)
+			}
+		}
+	}
+
+	return conflictWarningsResponse(warnings), nil
 }
 
 // egressValidator validates the Egress custom resource
@@ -350,10 +465,16 @@ func (kc *policyValidator) upstreamTrafficSettingValidator(req *admissionv1.Admi
 		return nil, field.Invalid(field.NewPath("spec").Child("host"), upstreamTrafficSetting.Spec.Host, "invalid FQDN specified as host")
 	}
 
+	var warnings []string
 	opt := policy.UpstreamTrafficSettingGetOpt{Host: upstreamTrafficSetting.Spec.Host}
-	if matchingUpstreamTrafficSetting := kc.policyClient.GetUpstreamTrafficSetting(opt); matchingUpstreamTrafficSetting != nil && matchingUpstreamTrafficSetting.Name != upstreamTrafficSetting.Name {
+	if matchingUpstreamTrafficSetting := kc.policyClient.GetUpstreamTrafficSetting(opt); matchingUpstreamTrafficSetting != nil && matchingUpstreamTrafficSetting.Name != upstreamTrafficSetting.Name &&
+		len(policy.DetectUpstreamTrafficSettingConflicts(*upstreamTrafficSetting, *matchingUpstreamTrafficSetting)) > 0 {
 		// duplicate detected
-		return nil, fmt.Errorf("UpstreamTrafficSetting %s/%s conflicts with %s/%s since they have the same host %s", ns, upstreamTrafficSetting.ObjectMeta.GetName(), ns, matchingUpstreamTrafficSetting.ObjectMeta.GetName(), matchingUpstreamTrafficSetting.Spec.Host)
+		conflict := fmt.Errorf("UpstreamTrafficSetting %s/%s conflicts with %s/%s since they have the same host %s", ns, upstreamTrafficSetting.ObjectMeta.GetName(), ns, matchingUpstreamTrafficSetting.ObjectMeta.GetName(), matchingUpstreamTrafficSetting.Spec.Host)
+		if !kc.warnOnConflicts() {
+			return nil, conflict
+		}
+		warnings = append(warnings, conflict.Error())
 	}
 
 	// Validate rate limiting config
@@ -364,6 +485,65 @@ func (kc *policyValidator) upstreamTrafficSettingValidator(req *admissionv1.Admi
 				rl.Local.HTTP.ResponseStatusCode)
 		}
 	}
+	if rl != nil && rl.Global != nil {
+		for i, descriptor := range rl.Global.Descriptors {
+			if len(descriptor.Entries) == 0 {
+				return nil, fmt.Errorf("Invalid global rate limit descriptor at index %d, at least one entry must be specified", i)
+			}
+			for j, entry := range descriptor.Entries {
+				sources := 0
+				if entry.Header != "" {
+					sources++
+				}
+				if entry.SourceIdentity {
+					sources++
+				}
+				if entry.Path {
+					sources++
+				}
+				if sources != 1 {
+					return nil, fmt.Errorf("Invalid entry at index %d of global rate limit descriptor at index %d, exactly one of header, sourceIdentity or path must be specified", j, i)
+				}
+			}
+		}
+	}
+
+	// Validate outlier detection config
+	if od := upstreamTrafficSetting.Spec.OutlierDetection; od != nil {
+		if od.Interval != nil && od.Interval.Duration <= 0 {
+			return nil, fmt.Errorf("Invalid outlier detection interval %s, must be greater than 0", od.Interval.Duration)
+		}
+		if od.BaseEjectionTime != nil && od.BaseEjectionTime.Duration <= 0 {
+			return nil, fmt.Errorf("Invalid outlier detection base ejection time %s, must be greater than 0", od.BaseEjectionTime.Duration)
+		}
+		if od.MaxEjectionPercent != nil && *od.MaxEjectionPercent > 100 {
+			return nil, fmt.Errorf("Invalid outlier detection max ejection percent %d, must be between 0 and 100", *od.MaxEjectionPercent)
+		}
+	}
+
+	// Validate load balancer config
+	if lb := upstreamTrafficSetting.Spec.LoadBalancer; lb != nil && len(lb.HashKeys) > 0 {
+		if lb.Type != policyv1alpha1.LoadBalancerRingHash && lb.Type != policyv1alpha1.LoadBalancerMaglev {
+			return nil, fmt.Errorf("Invalid load balancer type %q, hash keys require the %s or %s load balancer type",
+				lb.Type, policyv1alpha1.LoadBalancerRingHash, policyv1alpha1.LoadBalancerMaglev)
+		}
+		for i, key := range lb.HashKeys {
+			sources := 0
+			if key.Header != "" {
+				sources++
+			}
+			if key.Cookie != nil {
+				sources++
+			}
+			if key.SourceIP {
+				sources++
+			}
+			if sources != 1 {
+				return nil, fmt.Errorf("Invalid load balancer hash key at index %d, exactly one of header, cookie or sourceIP must be specified", i)
+			}
+		}
+	}
+
 	for _, route := range upstreamTrafficSetting.Spec.HTTPRoutes {
 		if route.RateLimit != nil && route.RateLimit.Local != nil {
 			if _, ok := xds_type.StatusCode_name[int32(route.RateLimit.Local.ResponseStatusCode)]; !ok {
@@ -371,9 +551,28 @@ func (kc *policyValidator) upstreamTrafficSettingValidator(req *admissionv1.Admi
 					route.RateLimit.Local.ResponseStatusCode)
 			}
 		}
+		if route.Timeout != nil {
+			if route.Timeout.Request != nil && route.Timeout.Request.Duration < 0 {
+				return nil, fmt.Errorf("Invalid request timeout %s for HTTP route %s, must not be negative", route.Timeout.Request.Duration, route.Path)
+			}
+			if route.Timeout.Idle != nil && route.Timeout.Idle.Duration < 0 {
+				return nil, fmt.Errorf("Invalid idle timeout %s for HTTP route %s, must not be negative", route.Timeout.Idle.Duration, route.Path)
+			}
+		}
+		if route.Mirror != nil {
+			if route.Mirror.Service == "" {
+				return nil, fmt.Errorf("Mirror service for HTTP route %s must be specified", route.Path)
+			}
+			if route.Mirror.Service == hostComponents[0] {
+				return nil, fmt.Errorf("Invalid mirror service %s for HTTP route %s, cannot mirror requests to the upstream host", route.Mirror.Service, route.Path)
+			}
+			if route.Mirror.Percentage != nil && *route.Mirror.Percentage > 100 {
+				return nil, fmt.Errorf("Invalid mirror percentage %d for HTTP route %s, must be in the range 0-100", *route.Mirror.Percentage, route.Path)
+			}
+		}
 	}
 
-	return nil, nil
+	return conflictWarningsResponse(warnings), nil
 }
 
 // egressGatewayValidator validates the EgressGateway custom resource
@@ -383,19 +582,57 @@ func (kc *policyValidator) egressGatewayValidator(req *admissionv1.AdmissionRequ
 		return nil, err
 	}
 
+	var warnings []string
 	if len(egressGateway.Spec.GlobalEgressGateways) > 0 {
 		existEgressGateways := kc.policyClient.ListEgressGateways()
 		for _, p := range existEgressGateways {
 			if strings.EqualFold(egressGateway.Name, p.Name) && strings.EqualFold(egressGateway.Namespace, p.Namespace) {
 				continue
 			}
-			if len(p.Spec.GlobalEgressGateways) > 0 {
-				return nil, fmt.Errorf("Redefinition of global egress gateway policy and conflict with %s.%s", p.Namespace, p.Name)
+			if len(policy.DetectEgressGatewayConflicts(*egressGateway, *p)) > 0 {
+				conflict := fmt.Errorf("Redefinition of global egress gateway policy and conflict with %s.%s", p.Namespace, p.Name)
+				if !kc.warnOnConflicts() {
+					return nil, conflict
+				}
+				warnings = append(warnings, conflict.Error())
 			}
 		}
 	}
 
-	return nil, nil
+	return conflictWarningsResponse(warnings), nil
+}
+
+// retryValidator validates the Retry custom resource
+func (kc *policyValidator) retryValidator(req *admissionv1.AdmissionRequest) (*admissionv1.AdmissionResponse, error) {
+	retry := &policyv1alpha1.Retry{}
+	if err := json.NewDecoder(bytes.NewBuffer(req.Object.Raw)).Decode(retry); err != nil {
+		return nil, err
+	}
+
+	source := identity.K8sServiceAccount{Name: retry.Spec.Source.Name, Namespace: retry.Spec.Source.Namespace}
+	var conflictString strings.Builder
+	var warnings []string
+	for _, matchingPolicy := range kc.policyClient.ListRetryPolicies(source) {
+		if matchingPolicy.Name == retry.Name && matchingPolicy.Namespace == retry.Namespace {
+			continue
+		}
+		conflicts := policy.DetectRetryConflicts(*retry, *matchingPolicy)
+		if len(conflicts) == 0 {
+			continue
+		}
+		fmt.Fprintf(&conflictString, "[+] Retry %s/%s conflicts with %s/%s:\n", retry.Namespace, retry.Name, matchingPolicy.Namespace, matchingPolicy.Name)
+		for _, err := range conflicts {
+			fmt.Fprintf(&conflictString, "%s\n", err)
+			warnings = append(warnings, fmt.Sprintf("Retry %s/%s conflicts with %s/%s: %s", retry.Namespace, retry.Name, matchingPolicy.Namespace, matchingPolicy.Name, err))
+		}
+		fmt.Fprintf(&conflictString, "\n")
+	}
+
+	if conflictString.Len() != 0 && !kc.warnOnConflicts() {
+		return nil, fmt.Errorf("conflicting retry policies detected\n%s", conflictString.String())
+	}
+
+	return conflictWarningsResponse(warnings), nil
 }
 
 // pluginValidator validates the plugin custom resource
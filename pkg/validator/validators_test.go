@@ -10,7 +10,10 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 
 	"github.com/openservicemesh/osm/pkg/announcements"
+	configv1alpha2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
 	policyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	fakeConfigClientset "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned/fake"
 	fakePolicyClientset "github.com/openservicemesh/osm/pkg/gen/client/policy/clientset/versioned/fake"
 	"github.com/openservicemesh/osm/pkg/k8s"
 	"github.com/openservicemesh/osm/pkg/k8s/informers"
@@ -2050,6 +2053,589 @@ func TestUpstreamTrafficSettingValidator(t *testing.T) {
 			expResp:   nil,
 			expErrStr: "Invalid responseStatusCode 1. See https://www.envoyproxy.io/docs/envoy/latest/api-v3/type/v3/http_status.proto#enum-type-v3-statuscode for allowed values",
 		},
+		{
+			name: "UpstreamTrafficSetting with valid HTTP route timeouts",
+			input: &admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{
+					Group:   "v1alpha1",
+					Version: "policy.openservicemesh.io",
+					Kind:    "UpstreamTrafficSetting",
+				},
+				Object: runtime.RawExtension{
+					Raw: []byte(`
+					{
+						"apiVersion": "policy.openservicemesh.io/v1alpha1",
+						"kind": "UpstreamTrafficSetting",
+						"metadata": {
+							"name": "httpbin",
+							"namespace": "test"
+						},
+						"spec": {
+							"host": "httpbin.test.svc.cluster.local",
+							"httpRoutes": [
+								{
+								"path": "/slow",
+								"timeout": {
+									"request": "60s",
+									"idle": "10s"
+								}
+								}
+							]
+						}
+					}
+					`),
+				},
+			},
+			expResp:   nil,
+			expErrStr: "",
+		},
+		{
+			name: "UpstreamTrafficSetting with invalid HTTP route request timeout",
+			input: &admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{
+					Group:   "v1alpha1",
+					Version: "policy.openservicemesh.io",
+					Kind:    "UpstreamTrafficSetting",
+				},
+				Object: runtime.RawExtension{
+					Raw: []byte(`
+					{
+						"apiVersion": "policy.openservicemesh.io/v1alpha1",
+						"kind": "UpstreamTrafficSetting",
+						"metadata": {
+							"name": "httpbin",
+							"namespace": "test"
+						},
+						"spec": {
+							"host": "httpbin.test.svc.cluster.local",
+							"httpRoutes": [
+								{
+								"path": "/slow",
+								"timeout": {
+									"request": "-1s",
+									"idle": "10s"
+								}
+								}
+							]
+						}
+					}
+					`),
+				},
+			},
+			expResp:   nil,
+			expErrStr: "Invalid request timeout -1s for HTTP route /slow, must not be negative",
+		},
+		{
+			name: "UpstreamTrafficSetting with invalid HTTP route idle timeout",
+			input: &admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{
+					Group:   "v1alpha1",
+					Version: "policy.openservicemesh.io",
+					Kind:    "UpstreamTrafficSetting",
+				},
+				Object: runtime.RawExtension{
+					Raw: []byte(`
+					{
+						"apiVersion": "policy.openservicemesh.io/v1alpha1",
+						"kind": "UpstreamTrafficSetting",
+						"metadata": {
+							"name": "httpbin",
+							"namespace": "test"
+						},
+						"spec": {
+							"host": "httpbin.test.svc.cluster.local",
+							"httpRoutes": [
+								{
+								"path": "/slow",
+								"timeout": {
+									"request": "60s",
+									"idle": "-10s"
+								}
+								}
+							]
+						}
+					}
+					`),
+				},
+			},
+			expResp:   nil,
+			expErrStr: "Invalid idle timeout -10s for HTTP route /slow, must not be negative",
+		},
+		{
+			name: "UpstreamTrafficSetting with valid HTTP route mirror",
+			input: &admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{
+					Group:   "v1alpha1",
+					Version: "policy.openservicemesh.io",
+					Kind:    "UpstreamTrafficSetting",
+				},
+				Object: runtime.RawExtension{
+					Raw: []byte(`
+					{
+						"apiVersion": "policy.openservicemesh.io/v1alpha1",
+						"kind": "UpstreamTrafficSetting",
+						"metadata": {
+							"name": "httpbin",
+							"namespace": "test"
+						},
+						"spec": {
+							"host": "httpbin.test.svc.cluster.local",
+							"httpRoutes": [
+								{
+								"path": "/api",
+								"mirror": {
+									"service": "httpbin-v2",
+									"percentage": 10
+								}
+								}
+							]
+						}
+					}
+					`),
+				},
+			},
+			expResp:   nil,
+			expErrStr: "",
+		},
+		{
+			name: "UpstreamTrafficSetting with HTTP route mirror to the upstream host",
+			input: &admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{
+					Group:   "v1alpha1",
+					Version: "policy.openservicemesh.io",
+					Kind:    "UpstreamTrafficSetting",
+				},
+				Object: runtime.RawExtension{
+					Raw: []byte(`
+					{
+						"apiVersion": "policy.openservicemesh.io/v1alpha1",
+						"kind": "UpstreamTrafficSetting",
+						"metadata": {
+							"name": "httpbin",
+							"namespace": "test"
+						},
+						"spec": {
+							"host": "httpbin.test.svc.cluster.local",
+							"httpRoutes": [
+								{
+								"path": "/api",
+								"mirror": {
+									"service": "httpbin"
+								}
+								}
+							]
+						}
+					}
+					`),
+				},
+			},
+			expResp:   nil,
+			expErrStr: "Invalid mirror service httpbin for HTTP route /api, cannot mirror requests to the upstream host",
+		},
+		{
+			name: "UpstreamTrafficSetting with invalid HTTP route mirror percentage",
+			input: &admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{
+					Group:   "v1alpha1",
+					Version: "policy.openservicemesh.io",
+					Kind:    "UpstreamTrafficSetting",
+				},
+				Object: runtime.RawExtension{
+					Raw: []byte(`
+					{
+						"apiVersion": "policy.openservicemesh.io/v1alpha1",
+						"kind": "UpstreamTrafficSetting",
+						"metadata": {
+							"name": "httpbin",
+							"namespace": "test"
+						},
+						"spec": {
+							"host": "httpbin.test.svc.cluster.local",
+							"httpRoutes": [
+								{
+								"path": "/api",
+								"mirror": {
+									"service": "httpbin-v2",
+									"percentage": 101
+								}
+								}
+							]
+						}
+					}
+					`),
+				},
+			},
+			expResp:   nil,
+			expErrStr: "Invalid mirror percentage 101 for HTTP route /api, must be in the range 0-100",
+		},
+		{
+			name: "UpstreamTrafficSetting with valid outlier detection",
+			input: &admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{
+					Group:   "v1alpha1",
+					Version: "policy.openservicemesh.io",
+					Kind:    "UpstreamTrafficSetting",
+				},
+				Object: runtime.RawExtension{
+					Raw: []byte(`
+					{
+						"apiVersion": "policy.openservicemesh.io/v1alpha1",
+						"kind": "UpstreamTrafficSetting",
+						"metadata": {
+							"name": "httpbin",
+							"namespace": "test"
+						},
+						"spec": {
+							"host": "httpbin.test.svc.cluster.local",
+							"outlierDetection": {
+								"consecutiveErrors": 3,
+								"interval": "5s",
+								"baseEjectionTime": "30s",
+								"maxEjectionPercent": 50
+							}
+						}
+					}
+					`),
+				},
+			},
+			expResp:   nil,
+			expErrStr: "",
+		},
+		{
+			name: "UpstreamTrafficSetting with invalid outlier detection interval",
+			input: &admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{
+					Group:   "v1alpha1",
+					Version: "policy.openservicemesh.io",
+					Kind:    "UpstreamTrafficSetting",
+				},
+				Object: runtime.RawExtension{
+					Raw: []byte(`
+					{
+						"apiVersion": "policy.openservicemesh.io/v1alpha1",
+						"kind": "UpstreamTrafficSetting",
+						"metadata": {
+							"name": "httpbin",
+							"namespace": "test"
+						},
+						"spec": {
+							"host": "httpbin.test.svc.cluster.local",
+							"outlierDetection": {
+								"interval": "0s"
+							}
+						}
+					}
+					`),
+				},
+			},
+			expResp:   nil,
+			expErrStr: "Invalid outlier detection interval 0s, must be greater than 0",
+		},
+		{
+			name: "UpstreamTrafficSetting with invalid outlier detection base ejection time",
+			input: &admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{
+					Group:   "v1alpha1",
+					Version: "policy.openservicemesh.io",
+					Kind:    "UpstreamTrafficSetting",
+				},
+				Object: runtime.RawExtension{
+					Raw: []byte(`
+					{
+						"apiVersion": "policy.openservicemesh.io/v1alpha1",
+						"kind": "UpstreamTrafficSetting",
+						"metadata": {
+							"name": "httpbin",
+							"namespace": "test"
+						},
+						"spec": {
+							"host": "httpbin.test.svc.cluster.local",
+							"outlierDetection": {
+								"baseEjectionTime": "-1s"
+							}
+						}
+					}
+					`),
+				},
+			},
+			expResp:   nil,
+			expErrStr: "Invalid outlier detection base ejection time -1s, must be greater than 0",
+		},
+		{
+			name: "UpstreamTrafficSetting with invalid outlier detection max ejection percent",
+			input: &admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{
+					Group:   "v1alpha1",
+					Version: "policy.openservicemesh.io",
+					Kind:    "UpstreamTrafficSetting",
+				},
+				Object: runtime.RawExtension{
+					Raw: []byte(`
+					{
+						"apiVersion": "policy.openservicemesh.io/v1alpha1",
+						"kind": "UpstreamTrafficSetting",
+						"metadata": {
+							"name": "httpbin",
+							"namespace": "test"
+						},
+						"spec": {
+							"host": "httpbin.test.svc.cluster.local",
+							"outlierDetection": {
+								"maxEjectionPercent": 150
+							}
+						}
+					}
+					`),
+				},
+			},
+			expResp:   nil,
+			expErrStr: "Invalid outlier detection max ejection percent 150, must be between 0 and 100",
+		},
+		{
+			name: "UpstreamTrafficSetting with valid ring hash load balancer",
+			input: &admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{
+					Group:   "v1alpha1",
+					Version: "policy.openservicemesh.io",
+					Kind:    "UpstreamTrafficSetting",
+				},
+				Object: runtime.RawExtension{
+					Raw: []byte(`
+					{
+						"apiVersion": "policy.openservicemesh.io/v1alpha1",
+						"kind": "UpstreamTrafficSetting",
+						"metadata": {
+							"name": "httpbin",
+							"namespace": "test"
+						},
+						"spec": {
+							"host": "httpbin.test.svc.cluster.local",
+							"loadBalancer": {
+								"type": "RingHash",
+								"hashKeys": [
+									{
+										"header": "x-user-id"
+									},
+									{
+										"cookie": {
+											"name": "session",
+											"ttl": "1h"
+										}
+									},
+									{
+										"sourceIP": true
+									}
+								]
+							}
+						}
+					}
+					`),
+				},
+			},
+			expResp:   nil,
+			expErrStr: "",
+		},
+		{
+			name: "UpstreamTrafficSetting with hash keys for a non-hashing load balancer",
+			input: &admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{
+					Group:   "v1alpha1",
+					Version: "policy.openservicemesh.io",
+					Kind:    "UpstreamTrafficSetting",
+				},
+				Object: runtime.RawExtension{
+					Raw: []byte(`
+					{
+						"apiVersion": "policy.openservicemesh.io/v1alpha1",
+						"kind": "UpstreamTrafficSetting",
+						"metadata": {
+							"name": "httpbin",
+							"namespace": "test"
+						},
+						"spec": {
+							"host": "httpbin.test.svc.cluster.local",
+							"loadBalancer": {
+								"type": "LeastRequest",
+								"hashKeys": [
+									{
+										"header": "x-user-id"
+									}
+								]
+							}
+						}
+					}
+					`),
+				},
+			},
+			expResp:   nil,
+			expErrStr: "Invalid load balancer type \"LeastRequest\", hash keys require the RingHash or Maglev load balancer type",
+		},
+		{
+			name: "UpstreamTrafficSetting with load balancer hash key specifying multiple sources",
+			input: &admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{
+					Group:   "v1alpha1",
+					Version: "policy.openservicemesh.io",
+					Kind:    "UpstreamTrafficSetting",
+				},
+				Object: runtime.RawExtension{
+					Raw: []byte(`
+					{
+						"apiVersion": "policy.openservicemesh.io/v1alpha1",
+						"kind": "UpstreamTrafficSetting",
+						"metadata": {
+							"name": "httpbin",
+							"namespace": "test"
+						},
+						"spec": {
+							"host": "httpbin.test.svc.cluster.local",
+							"loadBalancer": {
+								"type": "Maglev",
+								"hashKeys": [
+									{
+										"header": "x-user-id",
+										"sourceIP": true
+									}
+								]
+							}
+						}
+					}
+					`),
+				},
+			},
+			expResp:   nil,
+			expErrStr: "Invalid load balancer hash key at index 0, exactly one of header, cookie or sourceIP must be specified",
+		},
+		{
+			name: "UpstreamTrafficSetting with valid global rate limit",
+			input: &admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{
+					Group:   "v1alpha1",
+					Version: "policy.openservicemesh.io",
+					Kind:    "UpstreamTrafficSetting",
+				},
+				Object: runtime.RawExtension{
+					Raw: []byte(`
+					{
+						"apiVersion": "policy.openservicemesh.io/v1alpha1",
+						"kind": "UpstreamTrafficSetting",
+						"metadata": {
+							"name": "httpbin",
+							"namespace": "test"
+						},
+						"spec": {
+							"host": "httpbin.test.svc.cluster.local",
+							"rateLimit": {
+								"global": {
+									"descriptors": [
+										{
+											"entries": [
+												{
+													"sourceIdentity": true
+												},
+												{
+													"key": "user",
+													"header": "x-user-id"
+												}
+											]
+										},
+										{
+											"entries": [
+												{
+													"path": true
+												}
+											]
+										}
+									]
+								}
+							}
+						}
+					}
+					`),
+				},
+			},
+			expResp:   nil,
+			expErrStr: "",
+		},
+		{
+			name: "UpstreamTrafficSetting with global rate limit descriptor without entries",
+			input: &admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{
+					Group:   "v1alpha1",
+					Version: "policy.openservicemesh.io",
+					Kind:    "UpstreamTrafficSetting",
+				},
+				Object: runtime.RawExtension{
+					Raw: []byte(`
+					{
+						"apiVersion": "policy.openservicemesh.io/v1alpha1",
+						"kind": "UpstreamTrafficSetting",
+						"metadata": {
+							"name": "httpbin",
+							"namespace": "test"
+						},
+						"spec": {
+							"host": "httpbin.test.svc.cluster.local",
+							"rateLimit": {
+								"global": {
+									"descriptors": [
+										{
+											"entries": []
+										}
+									]
+								}
+							}
+						}
+					}
+					`),
+				},
+			},
+			expResp:   nil,
+			expErrStr: "Invalid global rate limit descriptor at index 0, at least one entry must be specified",
+		},
+		{
+			name: "UpstreamTrafficSetting with global rate limit descriptor entry without a source",
+			input: &admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{
+					Group:   "v1alpha1",
+					Version: "policy.openservicemesh.io",
+					Kind:    "UpstreamTrafficSetting",
+				},
+				Object: runtime.RawExtension{
+					Raw: []byte(`
+					{
+						"apiVersion": "policy.openservicemesh.io/v1alpha1",
+						"kind": "UpstreamTrafficSetting",
+						"metadata": {
+							"name": "httpbin",
+							"namespace": "test"
+						},
+						"spec": {
+							"host": "httpbin.test.svc.cluster.local",
+							"rateLimit": {
+								"global": {
+									"descriptors": [
+										{
+											"entries": [
+												{
+													"path": true
+												},
+												{
+													"key": "user"
+												}
+											]
+										}
+									]
+								}
+							}
+						}
+					}
+					`),
+				},
+			},
+			expResp:   nil,
+			expErrStr: "Invalid entry at index 1 of global rate limit descriptor at index 0, exactly one of header, sourceIdentity or path must be specified",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -2103,3 +2689,354 @@ func TestUpstreamTrafficSettingValidator(t *testing.T) {
 		})
 	}
 }
+
+func TestRequestAuthenticationValidator(t *testing.T) {
+	requestAuthnReq := func(spec string) *admissionv1.AdmissionRequest {
+		return &admissionv1.AdmissionRequest{
+			Kind: metav1.GroupVersionKind{
+				Group:   "v1alpha1",
+				Version: "policy.openservicemesh.io",
+				Kind:    "RequestAuthentication",
+			},
+			Object: runtime.RawExtension{
+				Raw: []byte(`
+				{
+					"apiVersion": "v1alpha1",
+					"kind": "RequestAuthentication",
+					"metadata": {
+						"name": "authn",
+						"namespace": "test-namespace"
+					},
+					"spec": ` + spec + `
+				}
+				`),
+			},
+		}
+	}
+
+	testCases := []struct {
+		name                           string
+		input                          *admissionv1.AdmissionRequest
+		expResp                        *admissionv1.AdmissionResponse
+		expErrStr                      string
+		existingRequestAuthentications []*policyv1alpha1.RequestAuthentication
+	}{
+		{
+			name: "RequestAuthentication with inline JWKS and claim rules succeeds",
+			input: requestAuthnReq(`{
+				"backends": [{"name": "bookstore", "port": {"number": 80, "protocol": "http"}}],
+				"jwtRules": [
+					{
+						"issuer": "https://issuer.example.com",
+						"audiences": ["bookstore"],
+						"jwks": "{\"keys\":[{\"kty\":\"oct\",\"k\":\"c2VjcmV0\"}]}",
+						"claimToHeaders": [{"claim": "sub", "header": "x-user"}]
+					}
+				],
+				"rules": [{"claims": [{"name": "groups", "values": ["admin"]}]}]
+			}`),
+			expResp:   nil,
+			expErrStr: "",
+		},
+		{
+			name: "RequestAuthentication with JWKS secret reference succeeds",
+			input: requestAuthnReq(`{
+				"backends": [{"name": "bookstore", "port": {"number": 80, "protocol": "http"}}],
+				"jwtRules": [{"issuer": "https://issuer.example.com", "jwksSecretRef": {"name": "jwks"}}]
+			}`),
+			expResp:   nil,
+			expErrStr: "",
+		},
+		{
+			name: "RequestAuthentication with duplicate backends errors",
+			input: requestAuthnReq(`{
+				"backends": [
+					{"name": "bookstore", "port": {"number": 80, "protocol": "http"}},
+					{"name": "bookstore", "port": {"number": 80, "protocol": "http"}}
+				],
+				"jwtRules": [{"issuer": "https://issuer.example.com", "jwksSecretRef": {"name": "jwks"}}]
+			}`),
+			expResp:   nil,
+			expErrStr: "Duplicate backends detected with service name: bookstore and port: 80",
+		},
+		{
+			name: "RequestAuthentication with non HTTP backend errors",
+			input: requestAuthnReq(`{
+				"backends": [{"name": "bookstore", "port": {"number": 80, "protocol": "tcp"}}],
+				"jwtRules": [{"issuer": "https://issuer.example.com", "jwksSecretRef": {"name": "jwks"}}]
+			}`),
+			expResp:   nil,
+			expErrStr: "Expected 'port.protocol' to be 'http', got: tcp",
+		},
+		{
+			name: "RequestAuthentication conflicting with an existing policy errors",
+			input: requestAuthnReq(`{
+				"backends": [{"name": "bookstore", "port": {"number": 80, "protocol": "http"}}],
+				"jwtRules": [{"issuer": "https://issuer.example.com", "jwksSecretRef": {"name": "jwks"}}]
+			}`),
+			existingRequestAuthentications: []*policyv1alpha1.RequestAuthentication{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "existing",
+						Namespace: "test-namespace",
+					},
+					Spec: policyv1alpha1.RequestAuthenticationSpec{
+						Backends: []policyv1alpha1.RequestAuthenticationBackendSpec{
+							{Name: "bookstore", Port: policyv1alpha1.PortSpec{Number: 80, Protocol: "http"}},
+						},
+						JWTRules: []policyv1alpha1.JWTRuleSpec{
+							{Issuer: "https://issuer.example.com", JWKSSecretRef: &policyv1alpha1.JWKSSecretReferenceSpec{Name: "jwks"}},
+						},
+					},
+				},
+			},
+			expResp:   nil,
+			expErrStr: "RequestAuthentication test-namespace/authn conflicts with test-namespace/existing on backend bookstore and port 80",
+		},
+		{
+			name: "RequestAuthentication without JWT rules errors",
+			input: requestAuthnReq(`{
+				"backends": [{"name": "bookstore", "port": {"number": 80, "protocol": "http"}}],
+				"jwtRules": []
+			}`),
+			expResp:   nil,
+			expErrStr: "'jwtRules' must specify at least one JWT issuer",
+		},
+		{
+			name: "RequestAuthentication with both JWKS and JWKS secret reference errors",
+			input: requestAuthnReq(`{
+				"backends": [{"name": "bookstore", "port": {"number": 80, "protocol": "http"}}],
+				"jwtRules": [
+					{
+						"issuer": "https://issuer.example.com",
+						"jwks": "{\"keys\":[{\"kty\":\"oct\",\"k\":\"c2VjcmV0\"}]}",
+						"jwksSecretRef": {"name": "jwks"}
+					}
+				]
+			}`),
+			expResp:   nil,
+			expErrStr: "Only one of 'jwks' or 'jwksSecretRef' can be specified for issuer https://issuer.example.com",
+		},
+		{
+			name: "RequestAuthentication without JWKS errors",
+			input: requestAuthnReq(`{
+				"backends": [{"name": "bookstore", "port": {"number": 80, "protocol": "http"}}],
+				"jwtRules": [{"issuer": "https://issuer.example.com"}]
+			}`),
+			expResp:   nil,
+			expErrStr: "One of 'jwks' or 'jwksSecretRef' must be specified for issuer https://issuer.example.com",
+		},
+		{
+			name: "RequestAuthentication with invalid inline JWKS errors",
+			input: requestAuthnReq(`{
+				"backends": [{"name": "bookstore", "port": {"number": 80, "protocol": "http"}}],
+				"jwtRules": [{"issuer": "https://issuer.example.com", "jwks": "not-a-jwks"}]
+			}`),
+			expResp:   nil,
+			expErrStr: "Invalid 'jwks' specified for issuer https://issuer.example.com, expected a JSON Web Key Set with at least one key",
+		},
+		{
+			name: "RequestAuthentication with claim rule without values errors",
+			input: requestAuthnReq(`{
+				"backends": [{"name": "bookstore", "port": {"number": 80, "protocol": "http"}}],
+				"jwtRules": [{"issuer": "https://issuer.example.com", "jwksSecretRef": {"name": "jwks"}}],
+				"rules": [{"claims": [{"name": "groups", "values": []}]}]
+			}`),
+			expResp:   nil,
+			expErrStr: "'rules.claims.values' must specify at least one value for claim groups",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			stop := make(chan struct{})
+			defer close(stop)
+			broker := messaging.NewBroker(stop)
+
+			objects := make([]runtime.Object, len(tc.existingRequestAuthentications))
+			for i := range tc.existingRequestAuthentications {
+				objects[i] = tc.existingRequestAuthentications[i]
+			}
+
+			k8sController := k8s.NewMockController(mockCtrl)
+			if len(objects) > 0 {
+				k8sController.EXPECT().IsMonitoredNamespace(gomock.Any()).Return(true)
+			}
+
+			fakeClient := fakePolicyClientset.NewSimpleClientset(objects...)
+			informerCollection, err := informers.NewInformerCollection("osm", stop, informers.WithPolicyClient(fakeClient))
+			assert.NoError(err)
+
+			policyClient := policy.NewPolicyController(informerCollection, nil, k8sController, broker)
+			pv := &policyValidator{
+				policyClient: policyClient,
+			}
+
+			// Block until the existing policies are observed to avoid racing the informer's event handler
+			if len(objects) > 0 {
+				events := broker.GetKubeEventPubSub().Sub(announcements.RequestAuthenticationAdded.String())
+				<-events
+			}
+
+			resp, err := pv.requestAuthenticationValidator(tc.input)
+			assert.Equal(tc.expResp, resp)
+			if tc.expErrStr == "" {
+				assert.Nil(err)
+			}
+			if err != nil {
+				assert.Equal(tc.expErrStr, err.Error())
+			}
+		})
+	}
+}
+
+func TestRetryValidator(t *testing.T) {
+	retryRequest := &admissionv1.AdmissionRequest{
+		Kind: metav1.GroupVersionKind{
+			Group:   "v1alpha1",
+			Version: "policy.openservicemesh.io",
+			Kind:    "Retry",
+		},
+		Object: runtime.RawExtension{
+			Raw: []byte(`
+			{
+				"apiVersion": "policy.openservicemesh.io/v1alpha1",
+				"kind": "Retry",
+				"metadata": {
+					"name": "retry",
+					"namespace": "test"
+				},
+				"spec": {
+					"source": {
+						"kind": "ServiceAccount",
+						"name": "client",
+						"namespace": "test"
+					},
+					"destinations": [
+						{
+							"kind": "Service",
+							"name": "server",
+							"namespace": "test"
+						}
+					],
+					"retryPolicy": {
+						"retryOn": "5xx"
+					}
+				}
+			}
+			`),
+		},
+	}
+	existingRetry := func(destination string) *policyv1alpha1.Retry {
+		return &policyv1alpha1.Retry{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "existing",
+				Namespace: "test",
+			},
+			Spec: policyv1alpha1.RetrySpec{
+				Source: policyv1alpha1.RetrySrcDstSpec{Kind: "ServiceAccount", Name: "client", Namespace: "test"},
+				Destinations: []policyv1alpha1.RetrySrcDstSpec{
+					{Kind: "Service", Name: destination, Namespace: "test"},
+				},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name               string
+		existingRetry      *policyv1alpha1.Retry
+		policyConflictMode configv1alpha2.PolicyConflictMode
+		expResp            *admissionv1.AdmissionResponse
+		expErrStr          string
+	}{
+		{
+			name:      "Retry without existing Retry policies",
+			expResp:   nil,
+			expErrStr: "",
+		},
+		{
+			name:          "Retry for a different destination than an existing Retry policy",
+			existingRetry: existingRetry("other"),
+			expResp:       nil,
+			expErrStr:     "",
+		},
+		{
+			name:          "Retry conflicting with an existing Retry policy is rejected",
+			existingRetry: existingRetry("server"),
+			expResp:       nil,
+			expErrStr:     "conflicting retry policies detected\n[+] Retry test/retry conflicts with test/existing:\nDestination Service test/server for source ServiceAccount test/client specified in retry and existing conflicts\n\n",
+		},
+		{
+			name:               "Retry conflicting with an existing Retry policy is admitted with a warning",
+			existingRetry:      existingRetry("server"),
+			policyConflictMode: configv1alpha2.PolicyConflictModeWarn,
+			expResp: &admissionv1.AdmissionResponse{
+				Allowed:  true,
+				Warnings: []string{"Retry test/retry conflicts with test/existing: Destination Service test/server for source ServiceAccount test/client specified in retry and existing conflicts"},
+			},
+			expErrStr: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			stop := make(chan struct{})
+			defer close(stop)
+			broker := messaging.NewBroker(stop)
+
+			var objects []runtime.Object
+			if tc.existingRetry != nil {
+				objects = append(objects, tc.existingRetry)
+			}
+
+			k8sController := k8s.NewMockController(mockCtrl)
+			if len(objects) > 0 {
+				k8sController.EXPECT().IsMonitoredNamespace(gomock.Any()).Return(true)
+			}
+
+			fakeClient := fakePolicyClientset.NewSimpleClientset(objects...)
+			fakeConfigClient := fakeConfigClientset.NewSimpleClientset(&configv1alpha2.MeshConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "osm-mesh-config",
+					Namespace: "osm-system",
+				},
+				Spec: configv1alpha2.MeshConfigSpec{
+					Traffic: configv1alpha2.TrafficSpec{
+						PolicyConflictMode: tc.policyConflictMode,
+					},
+				},
+			})
+			informerCollection, err := informers.NewInformerCollection("osm", stop, informers.WithPolicyClient(fakeClient),
+				informers.WithConfigClient(fakeConfigClient, "osm-mesh-config", "osm-system"))
+			assert.NoError(err)
+
+			policyClient := policy.NewPolicyController(informerCollection, nil, k8sController, broker)
+			pv := &policyValidator{
+				policyClient: policyClient,
+				cfg:          configurator.NewConfigurator(informerCollection, "osm-system", "osm-mesh-config", broker),
+			}
+
+			// Block until the existing policies are observed to avoid racing the informer's event handler
+			if len(objects) > 0 {
+				events := broker.GetKubeEventPubSub().Sub(announcements.RetryPolicyAdded.String())
+				<-events
+			}
+
+			resp, err := pv.retryValidator(retryRequest)
+			assert.Equal(tc.expResp, resp)
+			if tc.expErrStr == "" {
+				assert.Nil(err)
+			}
+			if err != nil {
+				assert.Equal(tc.expErrStr, err.Error())
+			}
+		})
+	}
+}
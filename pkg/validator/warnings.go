@@ -0,0 +1,151 @@
+package validator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	mapset "github.com/deckarep/golang-set"
+	admissionv1 "k8s.io/api/admission/v1"
+
+	policyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+// warnFunc returns the warnings about a change admitted by the webhook which is legal but risky, such as a policy
+// selecting no service. The warnings are returned to the client along with the admission response.
+type warnFunc func(req *admissionv1.AdmissionRequest) ([]string, error)
+
+// kindService is the kind of the Service destinations of Retry policies
+const kindService = "Service"
+
+// accessControlWarnings warns about AccessControl backends matching no service, and about AccessControl backends
+// also selected by an IngressBackend, whose ingress routes the access control routes override
+func (kc *policyValidator) accessControlWarnings(req *admissionv1.AdmissionRequest) ([]string, error) {
+	acl := &policyv1alpha1.AccessControl{}
+	if err := json.NewDecoder(bytes.NewBuffer(req.Object.Raw)).Decode(acl); err != nil {
+		return nil, err
+	}
+
+	var warnings []string
+	var backends []string
+	for _, backend := range acl.Spec.Backends {
+		backends = append(backends, backend.Name)
+
+		svc := service.MeshService{Name: backend.Name, Namespace: acl.Namespace, TargetPort: uint16(backend.Port.Number)}
+		if ingressBackend := kc.policyClient.GetIngressBackendPolicy(svc); ingressBackend != nil {
+			warnings = append(warnings, fmt.Sprintf("AccessControl %s/%s shadows IngressBackend %s/%s on backend %s and port %d",
+				acl.Namespace, acl.Name, ingressBackend.Namespace, ingressBackend.Name, backend.Name, backend.Port.Number))
+		}
+	}
+
+	return append(kc.missingServiceWarnings("AccessControl", acl.Namespace, acl.Name, backends), warnings...), nil
+}
+
+// ingressBackendWarnings warns about IngressBackend backends matching no service, and about IngressBackend backends
+// also selected by an AccessControl, whose access control routes override the ingress routes
+func (kc *policyValidator) ingressBackendWarnings(req *admissionv1.AdmissionRequest) ([]string, error) {
+	ingressBackend := &policyv1alpha1.IngressBackend{}
+	if err := json.NewDecoder(bytes.NewBuffer(req.Object.Raw)).Decode(ingressBackend); err != nil {
+		return nil, err
+	}
+
+	var warnings []string
+	var backends []string
+	for _, backend := range ingressBackend.Spec.Backends {
+		backends = append(backends, backend.Name)
+
+		svc := service.MeshService{Name: backend.Name, Namespace: ingressBackend.Namespace, TargetPort: uint16(backend.Port.Number)}
+		if acl := kc.policyClient.GetAccessControlPolicy(svc); acl != nil {
+			warnings = append(warnings, fmt.Sprintf("IngressBackend %s/%s is shadowed by AccessControl %s/%s on backend %s and port %d",
+				ingressBackend.Namespace, ingressBackend.Name, acl.Namespace, acl.Name, backend.Name, backend.Port.Number))
+		}
+	}
+
+	return append(kc.missingServiceWarnings("IngressBackend", ingressBackend.Namespace, ingressBackend.Name, backends), warnings...), nil
+}
+
+// requestAuthenticationWarnings warns about RequestAuthentication backends matching no service
+func (kc *policyValidator) requestAuthenticationWarnings(req *admissionv1.AdmissionRequest) ([]string, error) {
+	requestAuthn := &policyv1alpha1.RequestAuthentication{}
+	if err := json.NewDecoder(bytes.NewBuffer(req.Object.Raw)).Decode(requestAuthn); err != nil {
+		return nil, err
+	}
+
+	var backends []string
+	for _, backend := range requestAuthn.Spec.Backends {
+		backends = append(backends, backend.Name)
+	}
+
+	return kc.missingServiceWarnings("RequestAuthentication", requestAuthn.Namespace, requestAuthn.Name, backends), nil
+}
+
+// retryWarnings warns about Retry service destinations matching no service
+func (kc *policyValidator) retryWarnings(req *admissionv1.AdmissionRequest) ([]string, error) {
+	retry := &policyv1alpha1.Retry{}
+	if err := json.NewDecoder(bytes.NewBuffer(req.Object.Raw)).Decode(retry); err != nil {
+		return nil, err
+	}
+
+	var warnings []string
+	selected := 0
+	for _, destination := range retry.Spec.Destinations {
+		if destination.Kind != kindService {
+			selected++
+			continue
+		}
+		if kc.kubeController.GetService(service.MeshService{Name: destination.Name, Namespace: destination.Namespace}) == nil {
+			warnings = append(warnings, fmt.Sprintf("Retry %s/%s destination service %s/%s does not exist", retry.Namespace, retry.Name, destination.Namespace, destination.Name))
+			continue
+		}
+		selected++
+	}
+	if len(retry.Spec.Destinations) > 0 && selected == 0 {
+		warnings = append([]string{fmt.Sprintf("Retry %s/%s selects no service", retry.Namespace, retry.Name)}, warnings...)
+	}
+
+	return warnings, nil
+}
+
+// upstreamTrafficSettingWarnings warns about an UpstreamTrafficSetting whose host matches no service
+func (kc *policyValidator) upstreamTrafficSettingWarnings(req *admissionv1.AdmissionRequest) ([]string, error) {
+	upstreamTrafficSetting := &policyv1alpha1.UpstreamTrafficSetting{}
+	if err := json.NewDecoder(bytes.NewBuffer(req.Object.Raw)).Decode(upstreamTrafficSetting); err != nil {
+		return nil, err
+	}
+
+	// The host is the FQDN of a service, of the form <name>.<namespace>.svc.<trust domain>
+	labels := strings.Split(upstreamTrafficSetting.Spec.Host, ".")
+	if len(labels) < 2 {
+		// Invalid hosts are rejected by the validator
+		return nil, nil
+	}
+	if kc.kubeController.GetService(service.MeshService{Name: labels[0], Namespace: labels[1]}) == nil {
+		return []string{fmt.Sprintf("UpstreamTrafficSetting %s/%s selects no service, host %s does not match any service",
+			upstreamTrafficSetting.Namespace, upstreamTrafficSetting.Name, upstreamTrafficSetting.Spec.Host)}, nil
+	}
+
+	return nil, nil
+}
+
+// missingServiceWarnings returns warnings about the given backend services of a policy which do not exist in the
+// namespace of the policy, led by a warning that the policy selects no service when none of them exist
+func (kc *policyValidator) missingServiceWarnings(kind, namespace, name string, backends []string) []string {
+	var warnings []string
+	checked := mapset.NewSet()
+	for _, backend := range backends {
+		// Backends of a policy may select several ports of the same service
+		if unique := checked.Add(backend); !unique {
+			continue
+		}
+		if kc.kubeController.GetService(service.MeshService{Name: backend, Namespace: namespace}) == nil {
+			warnings = append(warnings, fmt.Sprintf("%s %s/%s backend service %s/%s does not exist", kind, namespace, name, namespace, backend))
+		}
+	}
+	if checked.Cardinality() > 0 && len(warnings) == checked.Cardinality() {
+		warnings = append([]string{fmt.Sprintf("%s %s/%s selects no service", kind, namespace, name)}, warnings...)
+	}
+	return warnings
+}
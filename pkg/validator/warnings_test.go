@@ -0,0 +1,132 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	policyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+
+	"github.com/openservicemesh/osm/pkg/k8s"
+	"github.com/openservicemesh/osm/pkg/policy"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+func TestPolicyWarnings(t *testing.T) {
+	bookstore := service.MeshService{Name: "bookstore", Namespace: "test"}
+	bookstoreSvc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "bookstore", Namespace: "test"}}
+
+	testCases := []struct {
+		name             string
+		object           string
+		warn             func(*policyValidator) warnFunc
+		services         map[service.MeshService]*corev1.Service
+		ingressBackend   *policyv1alpha1.IngressBackend
+		accessControl    *policyv1alpha1.AccessControl
+		expectedWarnings []string
+		expectedErr      bool
+	}{
+		{
+			name:     "AccessControl selecting existing services",
+			object:   `{"metadata":{"name":"acl","namespace":"test"},"spec":{"backends":[{"name":"bookstore","port":{"number":80}},{"name":"bookstore","port":{"number":81}}]}}`,
+			warn:     func(kc *policyValidator) warnFunc { return kc.accessControlWarnings },
+			services: map[service.MeshService]*corev1.Service{bookstore: bookstoreSvc},
+		},
+		{
+			name:   "AccessControl selecting no service",
+			object: `{"metadata":{"name":"acl","namespace":"test"},"spec":{"backends":[{"name":"bookstore-v2","port":{"number":80}},{"name":"bookstore-v2","port":{"number":81}}]}}`,
+			warn:   func(kc *policyValidator) warnFunc { return kc.accessControlWarnings },
+			expectedWarnings: []string{
+				"AccessControl test/acl selects no service",
+				"AccessControl test/acl backend service test/bookstore-v2 does not exist",
+			},
+		},
+		{
+			name:           "AccessControl shadowing an IngressBackend",
+			object:         `{"metadata":{"name":"acl","namespace":"test"},"spec":{"backends":[{"name":"bookstore","port":{"number":80}}]}}`,
+			warn:           func(kc *policyValidator) warnFunc { return kc.accessControlWarnings },
+			services:       map[service.MeshService]*corev1.Service{bookstore: bookstoreSvc},
+			ingressBackend: &policyv1alpha1.IngressBackend{ObjectMeta: metav1.ObjectMeta{Name: "ingress", Namespace: "test"}},
+			expectedWarnings: []string{
+				"AccessControl test/acl shadows IngressBackend test/ingress on backend bookstore and port 80",
+			},
+		},
+		{
+			name:          "IngressBackend shadowed by an AccessControl",
+			object:        `{"metadata":{"name":"ingress","namespace":"test"},"spec":{"backends":[{"name":"bookstore","port":{"number":80}}]}}`,
+			warn:          func(kc *policyValidator) warnFunc { return kc.ingressBackendWarnings },
+			services:      map[service.MeshService]*corev1.Service{bookstore: bookstoreSvc},
+			accessControl: &policyv1alpha1.AccessControl{ObjectMeta: metav1.ObjectMeta{Name: "acl", Namespace: "test"}},
+			expectedWarnings: []string{
+				"IngressBackend test/ingress is shadowed by AccessControl test/acl on backend bookstore and port 80",
+			},
+		},
+		{
+			name:     "RequestAuthentication with a missing backend",
+			object:   `{"metadata":{"name":"authn","namespace":"test"},"spec":{"backends":[{"name":"bookstore"},{"name":"bookstore-v2"}]}}`,
+			warn:     func(kc *policyValidator) warnFunc { return kc.requestAuthenticationWarnings },
+			services: map[service.MeshService]*corev1.Service{bookstore: bookstoreSvc},
+			expectedWarnings: []string{
+				"RequestAuthentication test/authn backend service test/bookstore-v2 does not exist",
+			},
+		},
+		{
+			name:   "Retry selecting no service",
+			object: `{"metadata":{"name":"retry","namespace":"test"},"spec":{"destinations":[{"kind":"Service","name":"bookstore-v2","namespace":"test"}]}}`,
+			warn:   func(kc *policyValidator) warnFunc { return kc.retryWarnings },
+			expectedWarnings: []string{
+				"Retry test/retry selects no service",
+				"Retry test/retry destination service test/bookstore-v2 does not exist",
+			},
+		},
+		{
+			name:     "Retry selecting an existing service",
+			object:   `{"metadata":{"name":"retry","namespace":"test"},"spec":{"destinations":[{"kind":"Service","name":"bookstore","namespace":"test"}]}}`,
+			warn:     func(kc *policyValidator) warnFunc { return kc.retryWarnings },
+			services: map[service.MeshService]*corev1.Service{bookstore: bookstoreSvc},
+		},
+		{
+			name:   "UpstreamTrafficSetting selecting no service",
+			object: `{"metadata":{"name":"setting","namespace":"test"},"spec":{"host":"bookstore-v2.test.svc.cluster.local"}}`,
+			warn:   func(kc *policyValidator) warnFunc { return kc.upstreamTrafficSettingWarnings },
+			expectedWarnings: []string{
+				"UpstreamTrafficSetting test/setting selects no service, host bookstore-v2.test.svc.cluster.local does not match any service",
+			},
+		},
+		{
+			name:        "invalid object",
+			object:      `{"metadata":`,
+			warn:        func(kc *policyValidator) warnFunc { return kc.upstreamTrafficSettingWarnings },
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			mockCtrl := gomock.NewController(t)
+			mockPolicyController := policy.NewMockController(mockCtrl)
+			mockKubeController := k8s.NewMockController(mockCtrl)
+
+			mockPolicyController.EXPECT().GetIngressBackendPolicy(gomock.Any()).Return(tc.ingressBackend).AnyTimes()
+			mockPolicyController.EXPECT().GetAccessControlPolicy(gomock.Any()).Return(tc.accessControl).AnyTimes()
+			mockKubeController.EXPECT().GetService(gomock.Any()).DoAndReturn(func(svc service.MeshService) *corev1.Service {
+				return tc.services[svc]
+			}).AnyTimes()
+
+			kc := &policyValidator{
+				policyClient:   mockPolicyController,
+				kubeController: mockKubeController,
+			}
+
+			warnings, err := tc.warn(kc)(&admissionv1.AdmissionRequest{Object: runtime.RawExtension{Raw: []byte(tc.object)}})
+			assert.Equal(tc.expectedErr, err != nil)
+			assert.Equal(tc.expectedWarnings, warnings)
+		})
+	}
+}